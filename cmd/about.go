@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/buildinfo"
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+
+	"github.com/spf13/cobra"
+)
+
+// aboutReport is the output of the about command
+type aboutReport struct {
+	buildinfo.Info
+	Plugins []string `json:"plugins"`
+	Formats []string `json:"formats"`
+	VCS     []string `json:"vcs"`
+}
+
+var aboutCmd = &cobra.Command{
+	Use:   "about",
+	Short: "Show versionator's own version and build information",
+	Long: `Show the version, commit, and build date of this versionator binary,
+along with the enabled plugins, supported emit formats, and VCS backends.
+
+Include the output in bug reports. In CI, the --json output makes a cheap
+cache key that changes whenever the versionator binary does.
+
+Examples:
+  versionator about
+  versionator about --json`,
+	Args: cobra.NoArgs,
+	RunE: runAbout,
+}
+
+func init() {
+	rootCmd.AddCommand(aboutCmd)
+
+	aboutCmd.Flags().Bool("json", false, "Output as JSON")
+}
+
+func runAbout(cmd *cobra.Command, args []string) error {
+	report := buildAboutReport()
+
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding build information: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+		return nil
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "versionator %s\n", report.Version)
+	fmt.Fprintf(out, "  Commit:     %s\n", valueOrUnknown(report.Commit))
+	fmt.Fprintf(out, "  Built:      %s\n", valueOrUnknown(report.BuildDate))
+	if report.Modified {
+		fmt.Fprintf(out, "  Modified:   true\n")
+	}
+	fmt.Fprintf(out, "  Go:         %s\n", report.GoVersion)
+	fmt.Fprintf(out, "  Platform:   %s\n", report.Platform)
+	fmt.Fprintf(out, "  Plugins:    %s\n", valueOrUnknown(strings.Join(report.Plugins, ", ")))
+	fmt.Fprintf(out, "  Formats:    %s\n", strings.Join(report.Formats, ", "))
+	fmt.Fprintf(out, "  VCS:        %s\n", strings.Join(report.VCS, ", "))
+	return nil
+}
+
+func buildAboutReport() aboutReport {
+	var plugins []string
+	for _, p := range plugin.GetPlugins() {
+		plugins = append(plugins, p.Name())
+	}
+	slices.Sort(plugins)
+
+	return aboutReport{
+		Info:    buildinfo.Get(),
+		Plugins: plugins,
+		Formats: emit.SupportedFormats(),
+		VCS:     vcs.ListVCS(),
+	}
+}
+
+func valueOrUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
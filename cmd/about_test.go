@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/buildinfo"
+)
+
+// TestAbout_JSON_ReportsBuildAndCapabilities validates the about command.
+//
+// Why: Bug reports and CI cache keys need versionator's own version and
+// capabilities in a stable, machine-readable form, from any directory.
+//
+// What: "about --json" outside a repository emits valid JSON carrying the
+// build version and the supported emit formats.
+func TestAbout_JSON_ReportsBuildAndCapabilities(t *testing.T) {
+	// Precondition: Directory without VERSION or repository
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	_ = os.Chdir(t.TempDir())
+	defer func() {
+		_ = aboutCmd.Flags().Set("json", "false")
+		aboutCmd.Flags().Lookup("json").Changed = false
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+	}()
+
+	// Action: Run about --json
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"about", "--json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("about failed: %v", err)
+	}
+
+	// Expected: JSON with version and formats
+	var report aboutReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out.String(), err)
+	}
+	if report.Version != buildinfo.Version {
+		t.Errorf("version = %q, want %q", report.Version, buildinfo.Version)
+	}
+	if !slices.Contains(report.Formats, "go") || report.GoVersion == "" {
+		t.Errorf("incomplete report: %+v", report)
+	}
+}
+
+// TestAbout_Text_ShowsLabelledReport validates the human output.
+//
+// Why: Test binaries and plain `go run` builds carry no revision; the output
+// must still be readable rather than showing blank fields.
+//
+// What: Without --json the report is labelled lines starting with the
+// versionator version.
+func TestAbout_Text_ShowsLabelledReport(t *testing.T) {
+	// Precondition: Default flags
+	defer func() {
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+	}()
+
+	// Action: Run about
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"about"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("about failed: %v", err)
+	}
+
+	// Expected: Labelled text output
+	if !strings.HasPrefix(out.String(), "versionator "+buildinfo.Version) ||
+		!strings.Contains(out.String(), "Commit:") || !strings.Contains(out.String(), "Formats:") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
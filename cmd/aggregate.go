@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/aggregate"
+	"github.com/benjaminabbitt/versionator/internal/clierr"
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+var aggregateCmd = &cobra.Command{
+	Use:   "aggregate",
+	Short: "Combine component versions into one manifest",
+	Long: `Collect the versions of the components of an umbrella release and write
+them, with the version of this repository, as one manifest.
+
+Components are submodules or subdirectories listed under
+'aggregate.components' in .versionator.yaml. Each is read from the VERSION
+file in its path, or with tagFormat from the highest tag of this repository
+following it:
+
+  aggregate:
+    components:
+      api:
+        path: services/api
+      web:
+        tagFormat: "web/v{{Version}}"
+
+Templates read the same versions as {{Components.api.Version}} (also Major,
+Minor, Patch, PreRelease, Metadata, Path and Source).
+
+Examples:
+  versionator aggregate                          # JSON manifest on stdout
+  versionator aggregate --format=yaml -o components.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runAggregate,
+}
+
+func init() {
+	rootCmd.AddCommand(aggregateCmd)
+
+	aggregateCmd.Flags().StringP("format", "f", string(aggregate.FormatJSON),
+		"Output format ("+strings.Join(aggregate.AvailableFormats(), ", ")+")")
+	aggregateCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
+}
+
+func runAggregate(cmd *cobra.Command, args []string) error {
+	formatFlag, _ := cmd.Flags().GetString("format")
+	format := aggregate.Format(strings.ToLower(formatFlag))
+	if !slices.Contains(aggregate.AvailableFormats(), string(format)) {
+		return clierr.UnknownChoice("invalid format", formatFlag, aggregate.AvailableFormats())
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("error reading config: %w", err)
+	}
+	sources := aggregate.Sources(cfg.Aggregate)
+	if len(sources) == 0 {
+		return fmt.Errorf("%s", aggregate.ErrNoComponents)
+	}
+	components, err := aggregate.CollectActive(sources)
+	if err != nil {
+		return err
+	}
+	v, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("error loading version: %w", err)
+	}
+
+	writer := cmd.OutOrStdout()
+	outputFile, _ := cmd.Flags().GetString("output")
+	if outputFile != "" {
+		file, err := fileperm.Create(outputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	return aggregate.NewManifest(v.FullString(), components).Write(writer, format)
+}
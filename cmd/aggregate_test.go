@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/aggregate"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// AggregateTestSuite defines the test suite for the aggregate command.
+type AggregateTestSuite struct {
+	suite.Suite
+	out bytes.Buffer
+}
+
+// SetupTest runs before each test
+func (suite *AggregateTestSuite) SetupTest() {
+	suite.T().Chdir(suite.T().TempDir())
+	suite.out.Reset()
+	rootCmd.SetOut(&suite.out)
+	rootCmd.SetErr(&suite.out)
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("3.0.0\n"), 0644))
+}
+
+// TearDownTest runs after each test
+func (suite *AggregateTestSuite) TearDownTest() {
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	_ = aggregateCmd.Flags().Set("format", string(aggregate.FormatJSON))
+	_ = aggregateCmd.Flags().Set("output", "")
+}
+
+// TestAggregate_WritesManifest validates the combined manifest.
+//
+// Why: Umbrella release builds record which component versions they ship.
+//
+// What: The YAML manifest written to --output holds the umbrella version and
+// each configured component read from its VERSION file.
+func (suite *AggregateTestSuite) TestAggregate_WritesManifest() {
+	// Precondition
+	suite.Require().NoError(os.MkdirAll("api", 0755))
+	suite.Require().NoError(os.WriteFile("api/VERSION", []byte("1.4.2\n"), 0644))
+	suite.Require().NoError(os.WriteFile(".versionator.yaml", []byte(
+		"aggregate:\n  components:\n    api:\n      path: api\n"), 0644))
+
+	// Action
+	rootCmd.SetArgs([]string{"aggregate", "--format", "yaml", "-o", "components.yaml"})
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Require().NoError(err)
+	manifest, err := os.ReadFile("components.yaml")
+	suite.Require().NoError(err)
+	suite.Equal("version: 3.0.0\ncomponents:\n  api:\n    version: 1.4.2\n    path: api\n    source: api/VERSION\n", string(manifest))
+}
+
+// TestAggregate_NoComponents_Fails validates the unconfigured case.
+//
+// Why: An empty manifest would hide a missing configuration.
+//
+// What: Without aggregate.components the command fails with a hint.
+func (suite *AggregateTestSuite) TestAggregate_NoComponents_Fails() {
+	// Action
+	rootCmd.SetArgs([]string{"aggregate"})
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), aggregate.ErrNoComponents)
+}
+
+func TestAggregateTestSuite(t *testing.T) {
+	suite.Run(t, new(AggregateTestSuite))
+}
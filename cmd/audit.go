@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/benjaminabbitt/versionator/internal/audit"
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/history"
+	"github.com/benjaminabbitt/versionator/internal/update"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var auditFix bool
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Report manifests whose version disagrees with VERSION",
+	Long: `Compare every place the repository records its version against VERSION
+and report any that disagree.
+
+Sources checked:
+  - every entry under 'updates' in .versionator.yaml, against its template
+  - package.json, Cargo.toml, pyproject.toml and pom.xml, when present and
+    not already covered by an update
+  - the highest version tag, which is drift only when it is newer than VERSION
+
+Exits non-zero when drift is found. With --fix, drifted sources are patched
+through the same update mechanism 'release' uses. pom.xml and tags are
+reported but never rewritten.
+
+Examples:
+  versionator audit         # Report drift
+  versionator audit --fix   # Patch drifted manifests to match VERSION`,
+	Args: cobra.NoArgs,
+	RunE: runAudit,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.Flags().BoolVar(&auditFix, "fix", false, "Patch drifted manifests to match VERSION")
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	v, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+
+	data := emit.BuildCompleteTemplateData(v, "", "")
+	auditor := audit.NewAuditorDefault(nil)
+	if cfg, err := config.ReadConfig(); err == nil && cfg != nil {
+		data = emit.BuildCompleteTemplateData(v, cfg.PreRelease.Template, cfg.Metadata.Template)
+		auditor = audit.NewAuditor(cfg.Updates, update.NewDaselFileParser(), newConfiguredUpdater(cfg, data, false, zap.NewNop()))
+	}
+
+	findings := auditor.Audit(v, data)
+	if f, ok := audit.AuditTag(v, latestVersionTag()); ok {
+		findings = append(findings, f)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "VERSION: %s\n", v.FullString())
+	if len(findings) == 0 {
+		fmt.Fprintln(out, "No other version sources found")
+		return nil
+	}
+
+	if auditFix {
+		fixed, err := auditor.Fix(findings)
+		for _, f := range fixed {
+			fmt.Fprintf(out, "Fixed %s: %s -> %s\n", describeSource(f), f.Value, f.Expected)
+		}
+		if err != nil {
+			return err
+		}
+		if len(fixed) > 0 {
+			findings = auditor.Audit(v, data)
+			if f, ok := audit.AuditTag(v, latestVersionTag()); ok {
+				findings = append(findings, f)
+			}
+		}
+	}
+
+	drift := 0
+	for _, f := range findings {
+		switch f.Status {
+		case audit.StatusOK:
+			fmt.Fprintf(out, "  ok     %s: %s\n", describeSource(f), f.Value)
+		case audit.StatusDrift:
+			drift++
+			note := ""
+			if auditFix && f.Fix == nil {
+				note = " (" + audit.ErrNotFixable + ")"
+			}
+			fmt.Fprintf(out, "  drift  %s: %s (expected %s)%s\n", describeSource(f), f.Value, f.Expected, note)
+		case audit.StatusError:
+			drift++
+			fmt.Fprintf(out, "  error  %s: %v\n", describeSource(f), f.Err)
+		}
+	}
+
+	if drift > 0 {
+		return fmt.Errorf("%s: %d source(s) disagree with VERSION", audit.ErrDrift, drift)
+	}
+	return nil
+}
+
+// describeSource formats a finding's source as "file (path)" or "file"
+func describeSource(f audit.Finding) string {
+	if f.Path == "" {
+		return f.Source
+	}
+	return fmt.Sprintf("%s (%s)", f.Source, f.Path)
+}
+
+// latestVersionTag returns the highest version tag in the active VCS, or nil
+// when there is no VCS, it cannot list tags, or no tag parses as a version
+func latestVersionTag() *history.Entry {
+	activeVCS := vcs.GetActiveVCS()
+	if activeVCS == nil {
+		return nil
+	}
+	lister, ok := activeVCS.(vcs.TagLister)
+	if !ok {
+		return nil
+	}
+	tags, err := lister.ListTags()
+	if err != nil {
+		return nil
+	}
+	return history.Highest(history.Build(tags))
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/audit"
+	"github.com/stretchr/testify/suite"
+)
+
+// AuditTestSuite defines the test suite for the audit command.
+type AuditTestSuite struct {
+	suite.Suite
+	out bytes.Buffer
+}
+
+// SetupTest runs before each test
+func (suite *AuditTestSuite) SetupTest() {
+	suite.T().Chdir(suite.T().TempDir())
+	suite.out.Reset()
+	rootCmd.SetOut(&suite.out)
+	rootCmd.SetErr(&suite.out)
+}
+
+// TearDownTest runs after each test
+func (suite *AuditTestSuite) TearDownTest() {
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	auditFix = false
+	_ = auditCmd.Flags().Set("fix", "false")
+}
+
+// TestAudit_Drift_ReturnsError validates that drift fails the command.
+//
+// Why: audit is meant to gate CI; drift must produce a non-zero exit.
+//
+// What: A stale package.json is reported and the command errors.
+func (suite *AuditTestSuite) TestAudit_Drift_ReturnsError() {
+	// Precondition
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+	suite.Require().NoError(os.WriteFile("package.json", []byte(`{"version": "1.2.0"}`), 0644))
+
+	// Action
+	rootCmd.SetArgs([]string{"audit"})
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), audit.ErrDrift)
+	suite.Contains(suite.out.String(), "drift  package.json (version): 1.2.0 (expected 1.2.3)")
+}
+
+// TestAudit_Fix_PatchesAndSucceeds validates --fix.
+//
+// Why: Once drift is patched, the command should report success.
+//
+// What: package.json is rewritten to VERSION and the command succeeds.
+func (suite *AuditTestSuite) TestAudit_Fix_PatchesAndSucceeds() {
+	// Precondition
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+	suite.Require().NoError(os.WriteFile("package.json", []byte(`{"version": "1.2.0"}`), 0644))
+
+	// Action
+	rootCmd.SetArgs([]string{"audit", "--fix"})
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Require().NoError(err)
+	suite.Contains(suite.out.String(), "Fixed package.json (version): 1.2.0 -> 1.2.3")
+	suite.Contains(suite.out.String(), "ok     package.json (version): 1.2.3")
+}
+
+func TestAuditTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditTestSuite))
+}
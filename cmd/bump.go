@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/benjaminabbitt/versionator/internal/clierr"
 	"github.com/benjaminabbitt/versionator/internal/commitparser"
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/plan"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
 	"github.com/benjaminabbitt/versionator/internal/vcs"
 	"github.com/benjaminabbitt/versionator/internal/version"
 
@@ -15,6 +19,7 @@ var bumpCmd = &cobra.Command{
 	Use:   "bump",
 	Short: "Auto-bump version based on commit messages",
 	Long: `Analyze commits since the last tag and bump the version accordingly.
+This is the default without a level subcommand; --auto states it explicitly.
 
 Supported commit message formats:
 
@@ -34,18 +39,52 @@ Conflict resolution:
   - Highest bump level wins (major > minor > patch)
   - +semver:skip takes precedence and prevents any bump
 
+Configuration (.versionator.yaml):
+  bump:
+    mode: conventional   # default for --mode
+    types:               # Conventional Commit type -> major, minor, patch, or none
+      perf: patch
+
 Examples:
   versionator bump                   # Auto-bump and amend last commit
+  versionator bump --auto            # Same, explicitly
   versionator bump --dry-run         # Show what would happen
+  versionator bump --plan json > plan.json   # Record intended changes for review
+  versionator bump --apply-plan plan.json    # Apply a reviewed plan
   versionator bump --no-amend        # Bump without amending the commit
   versionator bump --mode=semver     # Only use +semver: markers
   versionator bump --mode=conventional  # Only use conventional commits`,
 	RunE: runBump,
 }
 
-// runLevelIncrement handles incrementing a version level
+// runLevelIncrement handles incrementing a version level (by --by steps)
 func runLevelIncrement(cmd *cobra.Command, level version.VersionLevel, titleName string) error {
-	if err := version.Increment(level); err != nil {
+	if err := version.CheckLevel(level); err != nil {
+		return err
+	}
+	if handled, err := applyPlanFlag(cmd); handled {
+		return err
+	}
+	by, _ := cmd.Flags().GetInt("by")
+	if handled, err := writePlanFlag(cmd, func() (*plan.Plan, error) {
+		return planLevelChange(cmd, level, true, by)
+	}); handled {
+		return err
+	}
+
+	current, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+	next := *current
+	if err := next.IncrementLevelBy(level, by); err != nil {
+		return err
+	}
+	if err := checkMonotonic(cmd, &next); err != nil {
+		return err
+	}
+
+	if err := version.IncrementBy(level, by); err != nil {
 		return err
 	}
 	ver, err := version.GetCurrentVersion()
@@ -53,11 +92,39 @@ func runLevelIncrement(cmd *cobra.Command, level version.VersionLevel, titleName
 		return fmt.Errorf("error reading updated version: %w", err)
 	}
 	fmt.Fprintf(cmd.OutOrStdout(), "%s version incremented to: %s\n", titleName, ver)
-	return runConfiguredUpdates(cmd)
+	if err := runConfiguredUpdates(cmd); err != nil {
+		return err
+	}
+	runLifecycleHooks(cmd, plugin.EventBump)
+	return nil
 }
 
 // runLevelDecrement handles decrementing a version level
 func runLevelDecrement(cmd *cobra.Command, level version.VersionLevel, titleName string) error {
+	if err := version.CheckLevel(level); err != nil {
+		return err
+	}
+	if handled, err := applyPlanFlag(cmd); handled {
+		return err
+	}
+	if handled, err := writePlanFlag(cmd, func() (*plan.Plan, error) {
+		return planLevelChange(cmd, level, false, 1)
+	}); handled {
+		return err
+	}
+
+	current, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+	next := *current
+	if err := next.DecrementLevel(level); err != nil {
+		return err
+	}
+	if err := checkMonotonic(cmd, &next); err != nil {
+		return err
+	}
+
 	if err := version.Decrement(level); err != nil {
 		return err
 	}
@@ -76,13 +143,14 @@ func makeLevelCmd(level version.VersionLevel, name string) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   name,
 		Short: fmt.Sprintf("Increment %s version (default), or use subcommands", name),
-		Long:  fmt.Sprintf("Increment the %s version. Use 'decrement' subcommand to decrement instead.", name),
+		Long:  fmt.Sprintf("Increment the %s version. Use 'decrement' subcommand to decrement instead.\nUse --by to increment by more than one (e.g. 'bump %s --by 3').", name, name),
 		RunE: func(c *cobra.Command, args []string) error {
 			return runLevelIncrement(c, level, titleName)
 		},
 	}
+	cmd.Flags().Int("by", 1, "Increment by this many steps")
 
-	cmd.AddCommand(&cobra.Command{
+	incrementCmd := &cobra.Command{
 		Use:     "increment",
 		Aliases: []string{"inc", "+", "up"},
 		Short:   fmt.Sprintf("Increment %s version", name),
@@ -90,7 +158,9 @@ func makeLevelCmd(level version.VersionLevel, name string) *cobra.Command {
 		RunE: func(c *cobra.Command, args []string) error {
 			return runLevelIncrement(c, level, titleName)
 		},
-	})
+	}
+	incrementCmd.Flags().Int("by", 1, "Increment by this many steps")
+	cmd.AddCommand(incrementCmd)
 
 	cmd.AddCommand(&cobra.Command{
 		Use:     "decrement",
@@ -110,19 +180,38 @@ func init() {
 
 	bumpCmd.Flags().Bool("dry-run", false, "Show what would happen without making changes")
 	bumpCmd.Flags().Bool("no-amend", false, "Update VERSION file but do not amend the last commit")
-	bumpCmd.Flags().String("mode", "all", "Parse mode: semver, conventional, or all")
+	bumpCmd.Flags().Bool("auto", false, "Choose the level from commit messages (the default without a level subcommand)")
+	bumpCmd.Flags().String("mode", "all", "Parse mode: semver, conventional, or all (default: bump.mode)")
+	bumpCmd.PersistentFlags().Bool("allow-downgrade", false, allowDowngradeUsage)
+	addPlanFlags(bumpCmd.PersistentFlags())
 
 	// Add level commands to bump
 	bumpCmd.AddCommand(makeLevelCmd(version.MajorLevel, "major"))
 	bumpCmd.AddCommand(makeLevelCmd(version.MinorLevel, "minor"))
 	bumpCmd.AddCommand(makeLevelCmd(version.PatchLevel, "patch"))
+
+	// Revision is a level only with a four-part scheme
+	revisionCmd := makeLevelCmd(version.RevisionLevel, "revision")
+	revisionCmd.Aliases = []string{"build"}
+	revisionCmd.Short += " (four-part scheme)"
+	bumpCmd.AddCommand(revisionCmd)
 }
 
 func runBump(cmd *cobra.Command, args []string) error {
+	if handled, err := applyPlanFlag(cmd); handled {
+		return err
+	}
+
 	// Get active VCS
 	activeVCS := vcs.GetActiveVCS()
 	if activeVCS == nil {
-		return fmt.Errorf(commitparser.ErrNoVCSDetected)
+		return clierr.NoRepository(commitparser.ErrNoVCSDetected)
+	}
+
+	if noAmend, _ := cmd.Flags().GetBool("no-amend"); !noAmend {
+		if err := vcs.RequireCapability(activeVCS, vcs.CapabilityAmend); err != nil {
+			return fmt.Errorf("%w (use --no-amend)", err)
+		}
 	}
 
 	// Get commits since last tag
@@ -136,12 +225,11 @@ func runBump(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Determine parse mode
-	modeFlag, _ := cmd.Flags().GetString("mode")
-	mode := getParseMode(modeFlag)
-
 	// Parse and analyze commits
-	parser := commitparser.NewParser(mode)
+	parser, err := bumpCommitParser(cmd)
+	if err != nil {
+		return err
+	}
 	analysis := parser.AnalyzeCommits(messages)
 
 	// Handle skip
@@ -168,6 +256,30 @@ func runBump(cmd *cobra.Command, args []string) error {
 	// Calculate new version
 	newVersion := calculateNewVersion(v, analysis.BumpLevel)
 
+	// --plan: describe the bump (and amend) for review instead of performing it
+	noAmend, _ := cmd.Flags().GetBool("no-amend")
+	if handled, err := writePlanFlag(cmd, func() (*plan.Plan, error) {
+		next := *v
+		if err := next.IncrementLevel(analysis.BumpLevel.ToVersionLevel()); err != nil {
+			return nil, err
+		}
+		var commit *plan.CommitAction
+		if !noAmend && version.UsesFile() {
+			commit = &plan.CommitAction{Amend: true, Files: []string{"VERSION"}}
+		}
+		return buildVersionPlan(cmd, v, &next, commit, false, plugin.EventBump)
+	}); handled {
+		return err
+	}
+
+	next := *v
+	if err := next.IncrementLevel(analysis.BumpLevel.ToVersionLevel()); err != nil {
+		return fmt.Errorf("failed to bump version: %w", err)
+	}
+	if err := checkMonotonic(cmd, &next); err != nil {
+		return err
+	}
+
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 	if dryRun {
@@ -196,18 +308,41 @@ func runBump(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Amend the last commit by default (unless --no-amend is specified)
-	noAmend, _ := cmd.Flags().GetBool("no-amend")
-	if !noAmend {
+	// Amend the last commit by default (unless --no-amend is specified);
+	// there is nothing to amend when the version is not kept in VERSION
+	if !noAmend && version.UsesFile() {
 		if err := activeVCS.AmendCommit([]string{"VERSION"}); err != nil {
 			return fmt.Errorf("failed to amend commit: %w", err)
 		}
 		cmd.Println("Amended last commit to include VERSION change")
 	}
 
+	runLifecycleHooks(cmd, plugin.EventBump)
 	return nil
 }
 
+// bumpCommitParser returns the commit parser of `bump`: the --mode flag, else
+// bump.mode, with the bump.types mapping of Conventional Commit types
+func bumpCommitParser(cmd *cobra.Command) (*commitparser.Parser, error) {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error reading config: %w", err)
+	}
+	mode, _ := cmd.Flags().GetString("mode")
+	if !cmd.Flags().Changed("mode") && cfg.Bump.Mode != "" {
+		mode = cfg.Bump.Mode
+	}
+	types := make(map[string]commitparser.BumpLevel)
+	for commitType, name := range cfg.Bump.Types {
+		level, err := commitparser.ParseBumpLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("bump.types.%s: %w", commitType, err)
+		}
+		types[commitType] = level
+	}
+	return commitparser.NewParser(getParseMode(mode)).WithTypes(types), nil
+}
+
 func getParseMode(mode string) commitparser.ParseMode {
 	switch strings.ToLower(mode) {
 	case "semver":
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/benjaminabbitt/versionator/internal/commitparser"
+	"github.com/benjaminabbitt/versionator/internal/plan"
 	"github.com/benjaminabbitt/versionator/internal/vcs"
 	gitVCS "github.com/benjaminabbitt/versionator/internal/vcs/git"
 	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
@@ -67,7 +70,17 @@ func (suite *BumpTestSuite) resetBumpCommand() {
 	rootCmd.SetArgs(nil)
 	_ = bumpCmd.Flags().Set("dry-run", "false")
 	_ = bumpCmd.Flags().Set("no-amend", "false")
+	_ = bumpCmd.Flags().Set("auto", "false")
 	_ = bumpCmd.Flags().Set("mode", "all")
+	bumpCmd.Flags().Lookup("mode").Changed = false
+	_ = bumpCmd.PersistentFlags().Set("plan", "")
+	_ = bumpCmd.PersistentFlags().Set("apply-plan", "")
+	for _, levelCmd := range bumpCmd.Commands() {
+		_ = levelCmd.Flags().Set("by", "1")
+		for _, sub := range levelCmd.Commands() {
+			_ = sub.Flags().Set("by", "1")
+		}
+	}
 }
 
 func (suite *BumpTestSuite) createVersionFile(ver string) {
@@ -374,6 +387,66 @@ func (suite *BumpTestSuite) TestRunBump_SemverMode() {
 	suite.Contains(buf.String(), "No version bump detected")
 }
 
+// TestRunBump_Auto_ConfiguredTypes validates the bump.types mapping.
+//
+// Why: Teams decide which Conventional Commit types release; perf changes
+// may warrant a patch release the defaults would not cut.
+//
+// What: With bump.mode conventional and perf: patch, 'bump --auto' detects a
+// patch bump from a perf commit and ignores a +semver:major marker.
+func (suite *BumpTestSuite) TestRunBump_Auto_ConfiguredTypes() {
+	// Precondition: Config mapping perf to patch, conventional mode only
+	suite.createVersionFile("1.0.0")
+	suite.Require().NoError(os.WriteFile(".versionator.yaml", []byte("bump:\n  mode: conventional\n  types:\n    perf: patch\n"), 0644))
+	mockVCS := suite.setupMockVCS()
+	mockVCS.EXPECT().GetCommitMessagesSinceTag().Return([]string{
+		"perf: cache parsed templates",
+		"docs: mention it +semver:major",
+	}, nil)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"bump", "--auto", "--dry-run"})
+
+	// Action: Execute bump with --auto
+	err := rootCmd.Execute()
+
+	// Expected: Patch from the perf commit
+	suite.NoError(err)
+	suite.Contains(buf.String(), "Detected bump level: patch")
+	suite.Contains(buf.String(), "Would bump from 1.0.0 to 1.0.1")
+}
+
+// TestBumpCommitParser_ConfigErrors validates reading bump settings.
+//
+// Why: A config that fails to parse must not silently fall back to the
+// default mode and types, bumping by rules the team did not choose.
+//
+// What: A misspelled key fails with the parse error; levels in any case,
+// such as feat: Minor, are accepted as bump itself reads them.
+func (suite *BumpTestSuite) TestBumpCommitParser_ConfigErrors() {
+	// Precondition: A misspelled bump key
+	suite.Require().NoError(os.WriteFile(".versionator.yaml", []byte("bump:\n  mdoe: conventional\n"), 0644))
+
+	// Action
+	_, err := bumpCommitParser(bumpCmd)
+
+	// Expected
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), `"mdoe"`)
+
+	// Precondition: A level in title case
+	suite.Require().NoError(os.WriteFile(".versionator.yaml", []byte("bump:\n  types:\n    feat: Minor\n"), 0644))
+
+	// Action
+	parser, err := bumpCommitParser(bumpCmd)
+
+	// Expected
+	suite.Require().NoError(err)
+	suite.Equal(commitparser.BumpMinor, parser.AnalyzeCommits([]string{"feat: add"}).BumpLevel)
+}
+
 // TestMakeLevelCmd_MajorDecrement validates that 'bump major decrement'
 // correctly decrements the major version.
 //
@@ -435,6 +508,43 @@ func (suite *BumpTestSuite) TestMakeLevelCmd_IncrementAlias() {
 	suite.Equal("1.2.4", suite.readVersionFile())
 }
 
+// TestMakeLevelCmd_IncrementBy_StepsSeveralVersions validates --by.
+//
+// Why: Teams aligning with external numbering (sprint numbers, marketing
+// versions) need to skip several versions in one step.
+//
+// What: Given VERSION 1.2.3, 'bump minor --by 3' gives 1.5.0 and
+// 'bump patch increment --by 2' then gives 1.5.2; '--by 0' is rejected.
+func (suite *BumpTestSuite) TestMakeLevelCmd_IncrementBy_StepsSeveralVersions() {
+	// Precondition: VERSION file exists
+	suite.createVersionFile("1.2.3")
+	rootCmd.SetOut(new(bytes.Buffer))
+
+	// Action: Increment minor by 3
+	rootCmd.SetArgs([]string{"bump", "minor", "--by", "3"})
+	err := rootCmd.Execute()
+
+	// Expected: Minor stepped by 3, patch reset
+	suite.Require().NoError(err)
+	suite.Equal("1.5.0", suite.readVersionFile())
+
+	// Action: Increment patch by 2 via the increment subcommand
+	rootCmd.SetArgs([]string{"bump", "patch", "increment", "--by", "2"})
+	err = rootCmd.Execute()
+
+	// Expected: Patch stepped by 2
+	suite.Require().NoError(err)
+	suite.Equal("1.5.2", suite.readVersionFile())
+
+	// Action: Increment by 0
+	rootCmd.SetArgs([]string{"bump", "major", "--by", "0"})
+	err = rootCmd.Execute()
+
+	// Expected: Rejected, VERSION unchanged
+	suite.Require().Error(err)
+	suite.Equal("1.5.2", suite.readVersionFile())
+}
+
 // TestMakeLevelCmd_DecrementAlias validates that 'dec' is accepted as an
 // alias for 'decrement'.
 //
@@ -630,6 +740,167 @@ func (suite *BumpTestSuite) TestRunBump_SkipDetected() {
 	suite.Contains(buf.String(), "Version bump skipped")
 }
 
+// =============================================================================
+// PLAN / APPLY-PLAN
+// =============================================================================
+
+// TestRunBump_Plan_DescribesChangesWithoutApplying validates --plan json.
+//
+// Why: Review/approval workflows need to see exactly what a bump would change
+// before anything is written or committed.
+//
+// What: Given VERSION 1.0.0 and a feat commit, 'bump --plan json' prints a
+// plan with the VERSION change, the amend, HEAD, and the bump event, and
+// leaves VERSION untouched.
+func (suite *BumpTestSuite) TestRunBump_Plan_DescribesChangesWithoutApplying() {
+	// Precondition: VERSION file and a feat commit; no amend expected
+	suite.createVersionFile("1.0.0")
+	mockVCS := suite.setupMockVCS()
+	mockVCS.EXPECT().GetCommitMessagesSinceTag().Return([]string{"feat: new feature"}, nil)
+	mockVCS.EXPECT().GetVCSIdentifier(40).Return("abc123", nil)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"bump", "--plan", "json"})
+
+	// Action: Execute bump in plan mode
+	err := rootCmd.Execute()
+
+	// Expected: Plan describes 1.0.0 -> 1.1.0 with an amend; nothing written
+	suite.Require().NoError(err)
+	var p plan.Plan
+	suite.Require().NoError(json.Unmarshal(buf.Bytes(), &p), "output should be a JSON plan: %s", buf.String())
+	suite.Equal("bump", p.Command)
+	suite.Equal("abc123", p.Head)
+	suite.Equal(plan.VersionChange{File: "VERSION", Old: "1.0.0", New: "1.1.0"}, p.Version)
+	suite.Equal(&plan.CommitAction{Amend: true, Files: []string{"VERSION"}}, p.Commit)
+	suite.Equal([]string{"bump"}, p.Events)
+	suite.Equal("1.0.0", suite.readVersionFile())
+}
+
+// TestRunBump_ApplyPlan_AppliesReviewedChanges validates --apply-plan.
+//
+// Why: The approved plan, not a fresh analysis, must be what reaches the repo.
+//
+// What: Given a plan for 1.0.0 -> 1.1.0 with an amend at HEAD abc123,
+// 'bump --apply-plan' writes VERSION and amends without reading commits.
+func (suite *BumpTestSuite) TestRunBump_ApplyPlan_AppliesReviewedChanges() {
+	// Precondition: VERSION 1.0.0 and a matching plan file
+	suite.createVersionFile("1.0.0")
+	suite.writePlan(`{"schemaVersion": 1, "command": "bump", "head": "abc123",
+		"version": {"file": "VERSION", "old": "1.0.0", "new": "1.1.0"},
+		"commit": {"amend": true, "files": ["VERSION"]}}`)
+	mockVCS := suite.setupMockVCS()
+	mockVCS.EXPECT().GetVCSIdentifier(40).Return("abc123", nil)
+	mockVCS.EXPECT().AmendCommit([]string{"VERSION"}).Return(nil)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"bump", "--apply-plan", "plan.json"})
+
+	// Action: Apply the plan
+	err := rootCmd.Execute()
+
+	// Expected: VERSION written and commit amended
+	suite.Require().NoError(err)
+	suite.Equal("1.1.0", suite.readVersionFile())
+	suite.Contains(buf.String(), "Applied plan 'bump': 1.0.0 -> 1.1.0")
+}
+
+// TestRunBump_ApplyPlan_StaleVersion_Refuses validates stale plan detection.
+//
+// Why: Applying a plan after VERSION moved on would silently overwrite
+// someone else's bump.
+//
+// What: Given VERSION 1.0.5 and a plan from 1.0.0, apply fails with
+// ErrStaleVersion and neither writes VERSION nor amends.
+func (suite *BumpTestSuite) TestRunBump_ApplyPlan_StaleVersion_Refuses() {
+	// Precondition: VERSION has changed since the plan was made
+	suite.createVersionFile("1.0.5")
+	suite.writePlan(`{"schemaVersion": 1, "command": "bump", "head": "abc123",
+		"version": {"file": "VERSION", "old": "1.0.0", "new": "1.1.0"},
+		"commit": {"amend": true, "files": ["VERSION"]}}`)
+	suite.setupMockVCS()
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"bump", "--apply-plan", "plan.json"})
+
+	// Action: Apply the stale plan
+	err := rootCmd.Execute()
+
+	// Expected: Refused, VERSION unchanged
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), plan.ErrStaleVersion)
+	suite.Equal("1.0.5", suite.readVersionFile())
+}
+
+// TestMakeLevelCmd_PlanAndApply_IncludesFileUpdates validates level commands.
+//
+// Why: Configured updates are part of what a bump changes and must be
+// reviewable and applied from the plan.
+//
+// What: 'bump patch --plan json' lists the package.json change without writing
+// it; applying that plan writes both VERSION and package.json.
+func (suite *BumpTestSuite) TestMakeLevelCmd_PlanAndApply_IncludesFileUpdates() {
+	// Precondition: VERSION, package.json, and an updates config
+	suite.createVersionFile("1.2.3")
+	suite.Require().NoError(os.WriteFile("package.json", []byte(`{"version": "1.2.3"}`), 0644))
+	suite.Require().NoError(os.WriteFile(".versionator.yaml", []byte(`updates:
+  - file: package.json
+    path: version
+    template: "{{MajorMinorPatch}}"
+`), 0644))
+	mockVCS := suite.setupMockVCS()
+	mockVCS.EXPECT().GetVCSIdentifier(gomock.Any()).Return("abc1234", nil).AnyTimes()
+	mockVCS.EXPECT().GetBranchName().Return("main", nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitDate().Return(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitsSinceTag().Return(0, nil).AnyTimes()
+	mockVCS.EXPECT().GetLastTagCommit().Return("abc1234", nil).AnyTimes()
+	mockVCS.EXPECT().GetUncommittedChanges().Return(0, nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitAuthor().Return("Test Author", nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitAuthorEmail().Return("test@example.com", nil).AnyTimes()
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"bump", "patch", "--plan", "json"})
+
+	// Action: Plan a patch bump
+	err := rootCmd.Execute()
+
+	// Expected: Plan includes the package.json change; nothing written
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.WriteFile("plan.json", buf.Bytes(), 0644))
+	var p plan.Plan
+	suite.Require().NoError(json.Unmarshal(buf.Bytes(), &p))
+	suite.Equal("bump patch", p.Command)
+	suite.Nil(p.Commit)
+	suite.Equal([]plan.FileChange{{File: "package.json", Path: "version", Old: "1.2.3", New: "1.2.4"}}, p.Files)
+	suite.Equal("1.2.3", suite.readVersionFile())
+
+	// Action: Apply the plan
+	suite.resetBumpCommand()
+	buf.Reset()
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"bump", "patch", "--apply-plan", "plan.json"})
+	err = rootCmd.Execute()
+
+	// Expected: VERSION and package.json updated
+	suite.Require().NoError(err)
+	suite.Equal("1.2.4", suite.readVersionFile())
+	pkg, _ := os.ReadFile("package.json")
+	suite.Contains(string(pkg), `"1.2.4"`)
+}
+
+func (suite *BumpTestSuite) writePlan(content string) {
+	suite.Require().NoError(os.WriteFile("plan.json", []byte(content), 0644))
+}
+
 // =============================================================================
 // HELPER FUNCTION TESTS
 // =============================================================================
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/manifest"
+	"github.com/benjaminabbitt/versionator/internal/publish"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultCacheKeyTemplate keys on the version and commit, marking builds
+// from a modified tree
+const defaultCacheKeyTemplate = "{{MajorMinorPatch}}-{{ShortHash}}{{#Dirty}}-dirty{{/Dirty}}"
+
+// filesHashShortLength is the length of {{FilesShortHash}}, as MediumHash
+const filesHashShortLength = 12
+
+var cacheKeyCmd = &cobra.Command{
+	Use:   "cachekey",
+	Short: "Print a CI cache key tied to the version and input files",
+	Long: `Render a cache key from the version state and, optionally, the contents of
+input files such as lock files.
+
+The key is a Mustache template with every template variable (see 'vars').
+--hash-file adds files (or glob patterns) whose combined SHA-256 is available
+as {{FilesHash}} (64 hex digits) and {{FilesShortHash}} (12). When the
+template uses neither, -{{FilesShortHash}} is appended, so hashed files
+always affect the key. The hash depends on file paths and contents only, not
+on the order flags are given in; a pattern that matches no file is an error.
+
+The default template is {{MajorMinorPatch}}-{{ShortHash}}, with -dirty for a
+modified working tree.
+
+Examples:
+  versionator cachekey --template "{{MajorMinor}}-{{ShortHash}}-{{Dirty}}"
+  versionator cachekey --template "go-{{MajorMinor}}-{{FilesHash}}" --hash-file go.sum
+  versionator cachekey --hash-file go.sum --hash-file 'tools/*.mod'`,
+	Args: cobra.NoArgs,
+	RunE: runCacheKey,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheKeyCmd)
+
+	cacheKeyCmd.Flags().String("template", defaultCacheKeyTemplate, "Mustache template for the key")
+	cacheKeyCmd.Flags().StringArray("hash-file", nil, "File or glob pattern whose contents key the cache, can be repeated")
+}
+
+func runCacheKey(cmd *cobra.Command, args []string) error {
+	template, _ := cmd.Flags().GetString("template")
+	hashFiles, _ := cmd.Flags().GetStringArray("hash-file")
+
+	data, err := currentTemplateData()
+	if err != nil {
+		return err
+	}
+
+	if len(hashFiles) > 0 {
+		files, err := publish.ExpandAssets(hashFiles)
+		if err != nil {
+			return err
+		}
+		entries, err := manifest.Build(files)
+		if err != nil {
+			return err
+		}
+		digest := manifest.Digest(entries)
+		emit.MergeCustomVars(&data, map[string]string{
+			"FilesHash":      digest,
+			"FilesShortHash": digest[:filesHashShortLength],
+		})
+		if !strings.Contains(template, "FilesHash") && !strings.Contains(template, "FilesShortHash") {
+			template += "-{{FilesShortHash}}"
+		}
+	}
+
+	key, err := emit.NewRenderer(data).Render(template)
+	if err != nil {
+		return fmt.Errorf("failed to render cache key: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), strings.TrimSpace(key))
+	return nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/suite"
+)
+
+// CacheKeyTestSuite defines the test suite for the cachekey command.
+type CacheKeyTestSuite struct {
+	suite.Suite
+	origDir string
+}
+
+// SetupTest runs before each test
+func (suite *CacheKeyTestSuite) SetupTest() {
+	var err error
+	suite.origDir, err = os.Getwd()
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.Chdir(suite.T().TempDir()))
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.4.0\n"), 0644))
+	suite.Require().NoError(os.WriteFile("go.sum", []byte("module sums\n"), 0644))
+	suite.Require().NoError(os.Mkdir("tools", 0755))
+	suite.Require().NoError(os.WriteFile(filepath.Join("tools", "go.mod"), []byte("module tools\n"), 0644))
+}
+
+// TearDownTest runs after each test
+func (suite *CacheKeyTestSuite) TearDownTest() {
+	if suite.origDir != "" {
+		_ = os.Chdir(suite.origDir)
+	}
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	resetCacheKeyFlags()
+}
+
+// resetCacheKeyFlags restores the flag defaults between runs
+func resetCacheKeyFlags() {
+	_ = cacheKeyCmd.Flags().Set("template", defaultCacheKeyTemplate)
+	_ = cacheKeyCmd.Flags().Lookup("hash-file").Value.(pflag.SliceValue).Replace(nil)
+}
+
+// run runs cachekey with args and returns the key
+func (suite *CacheKeyTestSuite) run(args ...string) (string, error) {
+	defer resetCacheKeyFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs(append([]string{"cachekey"}, args...))
+	err := rootCmd.Execute()
+	return strings.TrimSpace(stdout.String()), err
+}
+
+// TestCacheKey_FilesHash_StableAcrossOrder validates the file hash.
+//
+// Why: CI restores a cache when the key matches; the key must change with
+// the hashed files and not with how the command line lists them.
+//
+// What: {{FilesHash}} is the same whichever order patterns are given in,
+// and changes when a hashed file does.
+func (suite *CacheKeyTestSuite) TestCacheKey_FilesHash_StableAcrossOrder() {
+	// Action
+	first, err := suite.run("--template", "{{MajorMinor}}-{{FilesHash}}", "--hash-file", "go.sum", "--hash-file", "tools/*.mod")
+	suite.Require().NoError(err)
+	second, err := suite.run("--template", "{{MajorMinor}}-{{FilesHash}}", "--hash-file", "tools/*.mod", "--hash-file", "go.sum")
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.WriteFile("go.sum", []byte("module sums v2\n"), 0644))
+	changed, err := suite.run("--template", "{{MajorMinor}}-{{FilesHash}}", "--hash-file", "go.sum", "--hash-file", "tools/*.mod")
+	suite.Require().NoError(err)
+
+	// Expected
+	suite.Regexp(`^1\.4-[0-9a-f]{64}$`, first)
+	suite.Equal(first, second)
+	suite.NotEqual(first, changed)
+}
+
+// TestCacheKey_TemplateWithoutFilesHash_AppendsShortHash validates that
+// hashed files always reach the key.
+//
+// Why: Passing --hash-file with a template that does not use the hash
+// would silently reuse a stale cache.
+//
+// What: -{{FilesShortHash}} (12 hex digits) is appended to such a template.
+func (suite *CacheKeyTestSuite) TestCacheKey_TemplateWithoutFilesHash_AppendsShortHash() {
+	// Action
+	key, err := suite.run("--template", "v{{MajorMinorPatch}}", "--hash-file", "go.sum")
+
+	// Expected
+	suite.Require().NoError(err)
+	suite.Regexp(`^v1\.4\.0-[0-9a-f]{12}$`, key)
+}
+
+// TestCacheKey_NoMatchingFile_Fails validates missing inputs.
+//
+// Why: A key computed without the lock file it was meant to cover would
+// match caches built from different dependencies.
+//
+// What: A --hash-file pattern matching nothing is an error naming it.
+func (suite *CacheKeyTestSuite) TestCacheKey_NoMatchingFile_Fails() {
+	// Action
+	_, err := suite.run("--hash-file", "package-lock.json")
+
+	// Expected
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "package-lock.json")
+}
+
+// TestCacheKey_WritesKeyToStdout validates the output stream.
+//
+// Why: CI captures the key with KEY=$(versionator cachekey); a key written
+// to stderr leaves the variable empty.
+//
+// What: The key is written to stdout and nothing to stderr.
+func (suite *CacheKeyTestSuite) TestCacheKey_WritesKeyToStdout() {
+	// Precondition: Process stdout, stderr kept apart
+	var stderr bytes.Buffer
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"cachekey", "--template", "v{{MajorMinorPatch}}"})
+
+	// Action
+	var err error
+	stdout := captureStdout(func() { err = rootCmd.Execute() })
+
+	// Expected
+	suite.Require().NoError(err)
+	suite.Equal("v1.4.0\n", stdout)
+	suite.Empty(stderr.String())
+}
+
+// TestCacheKeyTestSuite runs the cachekey test suite
+func TestCacheKeyTestSuite(t *testing.T) {
+	suite.Run(t, new(CacheKeyTestSuite))
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/manifest"
+	"github.com/benjaminabbitt/versionator/internal/publish"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultChecksumsTemplate renders a `sha256sum -c` compatible manifest,
+// the version and commit on a comment line
+const defaultChecksumsTemplate = `# {{Prefix}}{{MajorMinorPatch}}{{PreReleaseWithDash}}{{MetadataWithPlus}}{{#Hash}} {{Hash}}{{/Hash}}
+{{#Checksums}}
+{{SHA256}}  {{Path}}
+{{/Checksums}}
+`
+
+var checksumsCmd = &cobra.Command{
+	Use:   "checksums <glob>...",
+	Short: "Write a SHA-256 manifest of release artifacts",
+	Long: `Compute the SHA-256 checksum of every file matching the globs and render a
+manifest with the version and commit.
+
+The default manifest is in sha256sum format, so it can be verified with
+'sha256sum -c checksums.txt'; its first line is a comment naming the version
+and commit. A pattern that matches no file is an error, so a broken build does
+not publish an incomplete manifest. The output file is never listed.
+
+--template renders a Mustache template instead, with every template variable
+(see 'vars') and a Checksums list whose items have Path, Name (the file name)
+and SHA256:
+
+  {{#Checksums}}{{Name}} {{SHA256}}
+  {{/Checksums}}
+
+Examples:
+  versionator checksums 'dist/*' --output dist/checksums.txt
+  versionator checksums 'dist/*.tar.gz' 'dist/*.zip' --template checksums.md.tmpl`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runChecksums,
+}
+
+func init() {
+	rootCmd.AddCommand(checksumsCmd)
+
+	checksumsCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
+	checksumsCmd.Flags().String("template", "", "Mustache template file for the manifest (default: sha256sum format)")
+}
+
+func runChecksums(cmd *cobra.Command, args []string) error {
+	outputFile, _ := cmd.Flags().GetString("output")
+	template := defaultChecksumsTemplate
+	if templateFile, _ := cmd.Flags().GetString("template"); templateFile != "" {
+		content, err := os.ReadFile(templateFile)
+		if err != nil {
+			return fmt.Errorf("failed to read template: %w", err)
+		}
+		template = string(content)
+	}
+
+	files, err := publish.ExpandAssets(args)
+	if err != nil {
+		return err
+	}
+	artifacts := files[:0]
+	for _, f := range files {
+		if outputFile == "" || filepath.Clean(f) != filepath.Clean(outputFile) {
+			artifacts = append(artifacts, f)
+		}
+	}
+	entries, err := manifest.Build(artifacts)
+	if err != nil {
+		return err
+	}
+
+	data, err := currentTemplateData()
+	if err != nil {
+		return err
+	}
+	items := make([]map[string]string, len(entries))
+	for i, e := range entries {
+		items[i] = map[string]string{"Path": e.Path, "Name": filepath.Base(e.Path), "SHA256": e.SHA256}
+	}
+	rendered, err := emit.NewRenderer(data).WithSections(map[string][]map[string]string{"Checksums": items}).Render(template)
+	if err != nil {
+		return err
+	}
+
+	if outputFile == "" {
+		fmt.Fprint(cmd.OutOrStdout(), rendered)
+		return nil
+	}
+	if err := emit.WriteToFile(rendered, outputFile); err != nil {
+		return err
+	}
+	cmd.PrintErrf("Checksums of %d file(s) written to %s\n", len(entries), outputFile)
+	return nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// ChecksumsTestSuite defines the test suite for the checksums command.
+type ChecksumsTestSuite struct {
+	suite.Suite
+	origDir string
+}
+
+// SetupTest runs before each test
+func (suite *ChecksumsTestSuite) SetupTest() {
+	var err error
+	suite.origDir, err = os.Getwd()
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.Chdir(suite.T().TempDir()))
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.4.0\n"), 0644))
+	suite.Require().NoError(os.Mkdir("dist", 0755))
+	suite.Require().NoError(os.WriteFile(filepath.Join("dist", "app.tar.gz"), []byte("tarball"), 0644))
+	suite.Require().NoError(os.WriteFile(filepath.Join("dist", "app.zip"), []byte("zipfile"), 0644))
+}
+
+// TearDownTest runs after each test
+func (suite *ChecksumsTestSuite) TearDownTest() {
+	if suite.origDir != "" {
+		_ = os.Chdir(suite.origDir)
+	}
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	for _, name := range []string{"output", "template"} {
+		_ = checksumsCmd.Flags().Set(name, "")
+	}
+}
+
+// TestChecksums_DefaultManifest_SHA256SumFormat validates the default output.
+//
+// Why: Release pipelines publish a manifest that `sha256sum -c` verifies;
+// the version line tells which release the files belong to.
+//
+// What: A comment line with the version, then one "<sha256>  <path>" line
+// per matched file, sorted; the output file itself is not listed.
+func (suite *ChecksumsTestSuite) TestChecksums_DefaultManifest_SHA256SumFormat() {
+	// Precondition: A previous manifest inside the globbed directory
+	suite.Require().NoError(os.WriteFile(filepath.Join("dist", "checksums.txt"), []byte("old"), 0644))
+
+	// Action
+	var stderr bytes.Buffer
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"checksums", "dist/*", "--output", "dist/checksums.txt"})
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Require().NoError(err)
+	content, err := os.ReadFile(filepath.Join("dist", "checksums.txt"))
+	suite.Require().NoError(err)
+	lines := bytes.Split(bytes.TrimRight(content, "\n"), []byte("\n"))
+	suite.Require().Len(lines, 3, string(content))
+	suite.Contains(string(lines[0]), "# 1.4.0")
+	suite.Equal("db4b4d0d1cb480bf9aeea253771c00febe627f236765fa37d6a5614f079a3aa0  dist/app.tar.gz", string(lines[1]))
+	suite.Contains(string(lines[2]), "  dist/app.zip")
+	suite.Contains(stderr.String(), "2 file(s)")
+}
+
+// TestChecksums_Template_RendersItems validates custom manifests.
+//
+// Why: Some pipelines publish checksums in release notes or JSON rather
+// than in sha256sum format.
+//
+// What: The template sees the version variables and the Checksums list
+// with Name, Path and SHA256.
+func (suite *ChecksumsTestSuite) TestChecksums_Template_RendersItems() {
+	// Precondition: A Markdown template
+	suite.Require().NoError(os.WriteFile("t.tmpl", []byte("## {{MajorMinorPatch}}\n{{#Checksums}}- {{Name}} ({{Path}})\n{{/Checksums}}"), 0644))
+
+	// Action: Without --output, on process stdout with stderr kept apart
+	var stderr bytes.Buffer
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"checksums", "dist/*.zip", "dist/*.tar.gz", "--template", "t.tmpl"})
+	var err error
+	out := captureStdout(func() { err = rootCmd.Execute() })
+
+	// Expected: The manifest on stdout, so it can be redirected
+	suite.Require().NoError(err)
+	suite.Equal("## 1.4.0\n- app.tar.gz (dist/app.tar.gz)\n- app.zip (dist/app.zip)\n", out)
+	suite.Empty(stderr.String())
+}
+
+// TestChecksums_NoMatch_Fails validates that a missing artifact fails.
+//
+// Why: A manifest silently missing an artifact would be published as
+// complete.
+//
+// What: A pattern matching nothing is an error.
+func (suite *ChecksumsTestSuite) TestChecksums_NoMatch_Fails() {
+	// Action
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"checksums", "dist/*.deb"})
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Error(err)
+}
+
+// TestChecksumsTestSuite runs the checksums test suite
+func TestChecksumsTestSuite(t *testing.T) {
+	suite.Run(t, new(ChecksumsTestSuite))
+}
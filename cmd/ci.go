@@ -2,13 +2,14 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
 	"github.com/benjaminabbitt/versionator/internal/ci"
+	"github.com/benjaminabbitt/versionator/internal/clierr"
 	"github.com/benjaminabbitt/versionator/internal/config"
 	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
 	"github.com/benjaminabbitt/versionator/internal/vcs"
 	"github.com/benjaminabbitt/versionator/internal/version"
 
@@ -75,8 +76,7 @@ func runCI(cmd *cobra.Command, args []string) error {
 		var err error
 		formatter, err = ci.GetFormatterByName(formatFlag)
 		if err != nil {
-			return fmt.Errorf("invalid format: %s (available: %s)",
-				formatFlag, strings.Join(ci.AvailableFormatters(), ", "))
+			return clierr.UnknownChoice("invalid format", formatFlag, ci.AvailableFormatters())
 		}
 	} else {
 		// Auto-detect CI environment
@@ -95,7 +95,7 @@ func runCI(cmd *cobra.Command, args []string) error {
 	var writer = cmd.OutOrStdout()
 
 	if outputFile != "" {
-		file, err := os.Create(outputFile)
+		file, err := fileperm.Create(outputFile)
 		if err != nil {
 			return fmt.Errorf("failed to create output file: %w", err)
 		}
@@ -160,7 +160,7 @@ func buildCIVariables(v *version.Version) *ci.Variables {
 	// Get VCS information
 	activeVCS := vcs.GetActiveVCS()
 	if activeVCS != nil {
-		if sha, err := activeVCS.GetVCSIdentifier(40); err == nil {
+		if sha, err := activeVCS.GetVCSIdentifier(vcs.MaxIdentifierLength(activeVCS)); err == nil {
 			vars.GitSHA = sha
 		}
 		if shortSHA, err := activeVCS.GetVCSIdentifier(7); err == nil {
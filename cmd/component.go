@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+// versionComponent is one optional part of the version string managed by
+// the component commands. All components follow one state model:
+//
+//   - The current value lives in the VERSION file when the component is
+//     stored: always for the prefix, and for pre-release and metadata when
+//     their stable setting is true.
+//   - The default lives in .versionator.yaml (prefix, prerelease.template,
+//     metadata.template). 'enable' writes it to VERSION; a dynamic component
+//     (stable: false) renders it at output time instead.
+type versionComponent struct {
+	name      string // command name, e.g. "prerelease"
+	label     string // e.g. "Pre-release"
+	configKey string // key holding the default, e.g. "prerelease.template"
+	stored    func(*config.Config) bool
+	getValue  func(*version.Version) string
+	setValue  func(string) error
+	// getDefault and setDefault access the configured default
+	getDefault func(*config.Config) string
+	setDefault func(*config.Config, string)
+	// fallback is the value enable writes when no default is configured
+	fallback func(*config.Config) string
+	// check rejects a value before it is written; "" is the disabled value
+	check func(cmd *cobra.Command, cfg *config.Config, value string) error
+}
+
+var prefixComponent = versionComponent{
+	name:       "prefix",
+	label:      "Prefix",
+	configKey:  "prefix",
+	stored:     func(*config.Config) bool { return true },
+	getValue:   func(v *version.Version) string { return v.Prefix },
+	setValue:   version.SetPrefix,
+	getDefault: func(c *config.Config) string { return c.Prefix },
+	setDefault: func(c *config.Config, p string) { c.Prefix = p },
+	fallback:   func(*config.Config) string { return "v" },
+	check: func(cmd *cobra.Command, cfg *config.Config, value string) error {
+		if !validPrefix(value) {
+			return fmt.Errorf("invalid prefix %q: only 'v' or 'V' allowed per SemVer convention", value)
+		}
+		return checkPrefixAllowed(cmd, cfg, value)
+	},
+}
+
+var prereleaseComponent = versionComponent{
+	name:       "prerelease",
+	label:      "Pre-release",
+	configKey:  "prerelease.template",
+	stored:     prereleaseAccessor.getStable,
+	getValue:   func(v *version.Version) string { return v.PreRelease },
+	setValue:   version.SetPreRelease,
+	getDefault: prereleaseAccessor.getTemplate,
+	setDefault: prereleaseAccessor.setTemplate,
+	fallback:   func(*config.Config) string { return "alpha" },
+	check: func(cmd *cobra.Command, _ *config.Config, value string) error {
+		warnZeroPaddedPreRelease(cmd, value)
+		return nil
+	},
+}
+
+var metadataComponent = versionComponent{
+	name:       "metadata",
+	label:      "Metadata",
+	configKey:  "metadata.template",
+	stored:     metadataAccessor.getStable,
+	getValue:   func(v *version.Version) string { return v.BuildMetadata },
+	setValue:   version.SetMetadata,
+	getDefault: metadataAccessor.getTemplate,
+	setDefault: metadataAccessor.setTemplate,
+	fallback:   defaultMetadataValue,
+	check:      func(*cobra.Command, *config.Config, string) error { return nil },
+}
+
+var componentCmd = &cobra.Command{
+	Use:   "component",
+	Short: "Enable, disable, set, or show the prefix, pre-release, or metadata",
+	Long: `Manage the optional parts of the version string with one set of commands:
+
+  versionator component <prefix|prerelease|metadata> <enable|disable|set|status>
+
+Every component follows the same model:
+  - The current value lives in the VERSION file when the component is stored.
+    The prefix is always stored; pre-release and metadata are stored when
+    their 'stable' setting is true.
+  - The default lives in .versionator.yaml (prefix, prerelease.template,
+    metadata.template). 'enable' writes it to VERSION; a dynamic component
+    (stable: false) is rendered from it at output time instead.
+
+  enable   write the default to VERSION (stored components only)
+  disable  remove the value from VERSION (stored components only)
+  set      make a value the default, and write it to VERSION when stored
+  status   show the mode, value, and default
+
+Use 'config prerelease|metadata stable' to choose the mode, and
+'config migrate' to rewrite configuration keys from earlier releases.`,
+}
+
+// newComponentCmd returns the enable/disable/set/status command group for c
+func newComponentCmd(c versionComponent) *cobra.Command {
+	group := &cobra.Command{
+		Use:   c.name,
+		Short: fmt.Sprintf("Manage the %s (default: %s)", c.name, c.configKey),
+	}
+	group.AddCommand(
+		&cobra.Command{
+			Use:   "enable",
+			Short: fmt.Sprintf("Write the configured %s to VERSION", c.name),
+			Args:  cobra.NoArgs,
+			RunE:  func(cmd *cobra.Command, args []string) error { return runComponentEnable(cmd, c) },
+		},
+		&cobra.Command{
+			Use:   "disable",
+			Short: fmt.Sprintf("Remove the %s from VERSION", c.name),
+			Args:  cobra.NoArgs,
+			RunE:  func(cmd *cobra.Command, args []string) error { return runComponentDisable(cmd, c) },
+		},
+		&cobra.Command{
+			Use:   "set <value>",
+			Short: fmt.Sprintf("Set the %s default, and VERSION when stored", c.name),
+			Args:  cobra.ExactArgs(1),
+			RunE:  func(cmd *cobra.Command, args []string) error { return runComponentSet(cmd, c, args[0]) },
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: fmt.Sprintf("Show the %s mode, value, and default", c.name),
+			Args:  cobra.NoArgs,
+			RunE:  func(cmd *cobra.Command, args []string) error { return runComponentStatus(cmd, c) },
+		},
+	)
+	return group
+}
+
+// readComponentConfig reads the config for a component command
+func readComponentConfig() (*config.Config, error) {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error reading config: %w", err)
+	}
+	return cfg, nil
+}
+
+// errComponentDynamic explains why a dynamic component has no VERSION value
+func errComponentDynamic(c versionComponent) error {
+	return fmt.Errorf("%s: the %s is rendered from %s at output time\n"+
+		"Change it with: versionator component %s set <template>\n"+
+		"Store it in VERSION with: versionator config %s stable true",
+		ErrComponentDynamic, c.name, c.configKey, c.name, c.name)
+}
+
+// renderComponentDefault renders the configured default, or returns the
+// fallback when none is configured or it renders empty
+func renderComponentDefault(c versionComponent, cfg *config.Config, vd *version.Version) string {
+	if def := c.getDefault(cfg); def != "" {
+		rendered, err := emit.RenderTemplateWithData(def, emit.BuildTemplateDataFromVersion(vd))
+		if err == nil && rendered != "" {
+			return rendered
+		}
+	}
+	return c.fallback(cfg)
+}
+
+// writeComponentValue stores value in VERSION and reports the new version
+func writeComponentValue(cmd *cobra.Command, c versionComponent, value, done string) error {
+	if err := c.setValue(value); err != nil {
+		return fmt.Errorf("error setting %s: %w", c.name, err)
+	}
+	out := newConsole(cmd)
+	out.Successf("%s", done)
+
+	vd, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("error getting version: %w", err)
+	}
+	out.Infof("Current version: %s", vd.FullString())
+	return nil
+}
+
+func runComponentEnable(cmd *cobra.Command, c versionComponent) error {
+	cfg, err := readComponentConfig()
+	if err != nil {
+		return err
+	}
+	if !c.stored(cfg) {
+		return errComponentDynamic(c)
+	}
+
+	vd, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("error getting version: %w", err)
+	}
+	value := renderComponentDefault(c, cfg, vd)
+	if err := c.check(cmd, cfg, value); err != nil {
+		return err
+	}
+	return writeComponentValue(cmd, c, value, fmt.Sprintf("%s enabled with value '%s'", c.label, value))
+}
+
+func runComponentDisable(cmd *cobra.Command, c versionComponent) error {
+	cfg, err := readComponentConfig()
+	if err != nil {
+		return err
+	}
+	if !c.stored(cfg) {
+		return errComponentDynamic(c)
+	}
+	if err := c.check(cmd, cfg, ""); err != nil {
+		return err
+	}
+	return writeComponentValue(cmd, c, "", fmt.Sprintf("%s disabled", c.label))
+}
+
+func runComponentSet(cmd *cobra.Command, c versionComponent, value string) error {
+	cfg, err := readComponentConfig()
+	if err != nil {
+		return err
+	}
+	if err := c.check(cmd, cfg, value); err != nil {
+		return err
+	}
+
+	c.setDefault(cfg, value)
+	if err := config.WriteConfig(cfg); err != nil {
+		return fmt.Errorf("error writing config: %w", err)
+	}
+
+	if !c.stored(cfg) {
+		out := newConsole(cmd)
+		out.Successf("%s template set to: %s", c.label, value)
+		out.Infof("(Rendered at output time, not stored in VERSION file)")
+		return nil
+	}
+	return writeComponentValue(cmd, c, value, fmt.Sprintf("%s set to: %s", c.label, value))
+}
+
+func runComponentStatus(cmd *cobra.Command, c versionComponent) error {
+	cfg, err := readComponentConfig()
+	if err != nil {
+		return err
+	}
+	vd, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("error reading version: %w", err)
+	}
+
+	mode, value := "stored (VERSION file)", c.getValue(vd)
+	if !c.stored(cfg) {
+		mode = "dynamic (rendered at output time)"
+		value = ""
+		if def := c.getDefault(cfg); def != "" {
+			value, _ = emit.RenderTemplateWithData(def, emit.BuildTemplateDataFromVersion(vd))
+		}
+	}
+	if value == "" {
+		value = "(none)"
+	}
+	def := c.getDefault(cfg)
+	if def == "" {
+		def = "(none)"
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintf(w, "Component: %s\n", c.name)
+	fmt.Fprintf(w, "Mode: %s\n", mode)
+	fmt.Fprintf(w, "Value: %s\n", value)
+	fmt.Fprintf(w, "Default (%s): %s\n", c.configKey, def)
+	fmt.Fprintf(w, "Current version: %s\n", vd.FullString())
+	return nil
+}
+
+var componentMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Rewrite legacy component keys in .versionator.yaml",
+	Long: `Rewrite configuration keys from earlier releases as their current
+equivalents and remove them from .versionator.yaml. Same as
+'config migrate --write'.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return migrateConfig(cmd, true)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(componentCmd)
+	componentCmd.AddCommand(componentMigrateCmd)
+	markDeprecated(componentMigrateCmd, "config migrate --write")
+
+	prefixGroup := newComponentCmd(prefixComponent)
+	prefixGroup.PersistentFlags().Bool("force", false, "Allow a prefix outside the configured allowedPrefixes")
+	componentCmd.AddCommand(prefixGroup, newComponentCmd(prereleaseComponent), newComponentCmd(metadataComponent))
+}
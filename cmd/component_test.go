@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runComponent executes the CLI in dir and returns stdout and stderr
+func runComponent(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&errOut)
+	rootCmd.SetArgs(args)
+	defer func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		rootCmd.SetArgs(nil)
+	}()
+	err := rootCmd.Execute()
+	return out.String(), errOut.String(), err
+}
+
+// readVersionFile returns the trimmed VERSION content
+func readVersionFile(t *testing.T) string {
+	t.Helper()
+	content, err := os.ReadFile("VERSION")
+	require.NoError(t, err)
+	return strings.TrimSpace(string(content))
+}
+
+// TestComponent_Stored_EnableSetDisable validates the shared lifecycle.
+//
+// Why: prefix, pre-release, and metadata used to behave differently for the
+// same verb; with one model, enable/set/disable must do the same thing for
+// every stored component.
+//
+// What: For each component, enable writes the configured default to VERSION,
+// set writes VERSION and records the default in config, and disable clears
+// VERSION but keeps the default.
+func TestComponent_Stored_EnableSetDisable(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		enabled string
+		set     string
+		setTo   string
+		key     string
+	}{
+		{name: "prefix", config: "prefix: V\n", enabled: "V1.2.3", set: "v", setTo: "v1.2.3", key: "prefix: v"},
+		{name: "prerelease", config: "prefix: \"\"\nprerelease:\n  stable: true\n  template: beta\n", enabled: "1.2.3-beta", set: "rc-1", setTo: "1.2.3-rc-1", key: "template: rc-1"},
+		{name: "metadata", config: "prefix: \"\"\nmetadata:\n  stable: true\n  template: build-7\n", enabled: "1.2.3+build-7", set: "ci.9", setTo: "1.2.3+ci.9", key: "template: ci.9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition: Stored component with a configured default
+			t.Chdir(t.TempDir())
+			require.NoError(t, os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+			require.NoError(t, os.WriteFile(".versionator.yaml", []byte(tt.config), 0644))
+
+			// Action and Expected: enable writes the default
+			out, _, err := runComponent(t, "component", tt.name, "enable")
+			require.NoError(t, err)
+			assert.Contains(t, out, "enabled with value")
+			assert.Equal(t, tt.enabled, readVersionFile(t))
+
+			// set writes VERSION and the config default
+			_, _, err = runComponent(t, "component", tt.name, "set", tt.set)
+			require.NoError(t, err)
+			assert.Equal(t, tt.setTo, readVersionFile(t))
+			cfgData, _ := os.ReadFile(".versionator.yaml")
+			assert.Contains(t, string(cfgData), tt.key)
+
+			// disable clears VERSION only
+			_, _, err = runComponent(t, "component", tt.name, "disable")
+			require.NoError(t, err)
+			assert.Equal(t, "1.2.3", readVersionFile(t))
+			cfgData, _ = os.ReadFile(".versionator.yaml")
+			assert.Contains(t, string(cfgData), tt.key)
+		})
+	}
+}
+
+// TestComponent_Dynamic_SetsTemplateOnly validates dynamic components.
+//
+// Why: A dynamic pre-release has no VERSION value; writing one would make
+// the VERSION file disagree with every rendered output.
+//
+// What: enable and disable fail with ErrComponentDynamic; set stores the
+// template in config and leaves VERSION untouched; status reports the
+// rendered value.
+func TestComponent_Dynamic_SetsTemplateOnly(t *testing.T) {
+	// Precondition: Default config (stable: false)
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+
+	// Action
+	_, _, enableErr := runComponent(t, "component", "prerelease", "enable")
+	_, _, setErr := runComponent(t, "component", "prerelease", "set", "alpha")
+	status, _, statusErr := runComponent(t, "component", "prerelease", "status")
+
+	// Expected
+	require.Error(t, enableErr)
+	assert.Contains(t, enableErr.Error(), ErrComponentDynamic)
+	require.NoError(t, setErr)
+	require.NoError(t, statusErr)
+	assert.Equal(t, "1.2.3", readVersionFile(t))
+	assert.Contains(t, status, "Mode: dynamic")
+	assert.Contains(t, status, "Value: alpha")
+	assert.Contains(t, status, "Default (prerelease.template): alpha")
+}
+
+// TestComponent_Prefix_RejectsInvalidValues validates prefix checks.
+//
+// Why: The unified set must keep the SemVer 'v'/'V' rule and the
+// allowedPrefixes policy of 'config prefix set'.
+//
+// What: An arbitrary prefix is rejected; a prefix outside allowedPrefixes is
+// rejected without --force.
+func TestComponent_Prefix_RejectsInvalidValues(t *testing.T) {
+	// Precondition: Prefixes restricted to "v"
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("VERSION", []byte("v1.2.3\n"), 0644))
+	require.NoError(t, os.WriteFile(".versionator.yaml", []byte("prefix: v\nallowedPrefixes: [v]\n"), 0644))
+
+	// Action
+	_, _, invalidErr := runComponent(t, "component", "prefix", "set", "release-")
+	_, _, disallowedErr := runComponent(t, "component", "prefix", "disable")
+
+	// Expected
+	require.Error(t, invalidErr)
+	assert.Contains(t, invalidErr.Error(), "only 'v' or 'V' allowed")
+	require.Error(t, disallowedErr)
+	assert.Contains(t, disallowedErr.Error(), ErrPrefixNotAllowed)
+	assert.Equal(t, "v1.2.3", readVersionFile(t))
+}
+
+// TestComponentMigrate_RewritesLegacySuffix validates migration.
+//
+// Why: Configs from earlier releases describe metadata under 'suffix';
+// migrate must move them to the documented keys once and for all.
+//
+// What: A legacy suffix block becomes metadata.template and hash length, the
+// suffix key is removed, status warns before migration, and a second run
+// has nothing to do.
+func TestComponentMigrate_RewritesLegacySuffix(t *testing.T) {
+	// Precondition: Legacy config
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+	legacy := "prefix: v\nsuffix:\n  enabled: true\n  type: git\n  git:\n    hashLength: 8\n"
+	require.NoError(t, os.WriteFile(".versionator.yaml", []byte(legacy), 0644))
+
+	// Action
+	_, warning, err := runComponent(t, "component", "metadata", "status")
+	require.NoError(t, err)
+	out, _, err := runComponent(t, "component", "migrate")
+	require.NoError(t, err)
+	again, _, againErr := runComponent(t, "component", "migrate")
+
+	// Expected
+	assert.Contains(t, warning, "config migrate --write")
+	assert.Contains(t, out, "Migrated: suffix.enabled -> metadata.template")
+	cfgData, _ := os.ReadFile(".versionator.yaml")
+	assert.NotContains(t, string(cfgData), "suffix")
+	assert.Contains(t, string(cfgData), "template: '{{ShortHash}}'")
+	assert.Contains(t, string(cfgData), "hashLength: 8")
+	require.NoError(t, againErr)
+	assert.Contains(t, again, "No legacy keys")
+}
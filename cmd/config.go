@@ -15,7 +15,12 @@ Use subcommands to configure specific aspects:
   config prerelease  - Manage pre-release identifiers (includes stability setting)
   config metadata    - Manage build metadata (includes stability setting)
   config custom      - Manage custom key-value pairs
-  config vars        - Show all available template variables`,
+  config vars        - Show all available template variables
+  config schema      - Export a JSON Schema for .versionator.yaml
+  config migrate     - Upgrade legacy keys in .versionator.yaml
+
+Enable, disable, or set the prefix, pre-release, and metadata values with
+'versionator component'.`,
 }
 
 func init() {
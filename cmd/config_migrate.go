@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configMigrateWrite bool
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade legacy keys in .versionator.yaml",
+	Long: `Show how keys from earlier releases in .versionator.yaml translate to
+their current equivalents, and with --write rewrite the file without them.
+
+Legacy keys are already honored when read; migrating makes the file match
+the documented model. Keys already set in the current form are not
+overwritten. Currently migrated:
+  suffix.enabled        -> metadata.template ({{ShortHash}})
+  suffix.git.hashLength -> metadata.git.hashLength
+  metadata.enabled      -> metadata.template ({{ShortHash}})
+  metadata.type         -> metadata.template
+
+Examples:
+  versionator config migrate           # list the transformations
+  versionator config migrate --write   # rewrite .versionator.yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return migrateConfig(cmd, configMigrateWrite)
+	},
+}
+
+// migrateConfig reports each legacy key translation in .versionator.yaml,
+// rewriting the file without the legacy keys when write is set
+func migrateConfig(cmd *cobra.Command, write bool) error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("error reading config: %w", err)
+	}
+	out := newConsole(cmd)
+	if len(cfg.Migrations) == 0 {
+		out.Infof("No legacy keys in .versionator.yaml")
+		return nil
+	}
+
+	if !write {
+		for _, m := range cfg.Migrations {
+			out.Infof("Would migrate: %s -> %s", m.From, m.To)
+		}
+		out.Infof("Run with --write to rewrite .versionator.yaml")
+		return nil
+	}
+
+	if err := config.WriteConfig(cfg); err != nil {
+		return fmt.Errorf("error writing config: %w", err)
+	}
+	for _, m := range cfg.Migrations {
+		out.Successf("Migrated: %s -> %s", m.From, m.To)
+	}
+	return nil
+}
+
+func init() {
+	configMigrateCmd.Flags().BoolVar(&configMigrateWrite, "write", false, "Rewrite .versionator.yaml with the current keys")
+	configCmd.AddCommand(configMigrateCmd)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConfigMigrate_DryRunThenWrite validates the migrate command.
+//
+// Why: Rewriting the config drops comments and formatting, so users must be
+// able to see every transformation before committing to it.
+//
+// What: Without --write each legacy key is listed and the file is
+// unchanged; with --write the file holds only current keys.
+func TestConfigMigrate_DryRunThenWrite(t *testing.T) {
+	// Precondition: Config in the metadata.enabled layout
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+	legacy := "prefix: v\nmetadata:\n  type: git\n  enabled: true\n"
+	require.NoError(t, os.WriteFile(".versionator.yaml", []byte(legacy), 0644))
+	defer func() { configMigrateWrite = false }()
+
+	// Action: Dry run
+	out, _, err := runComponent(t, "config", "migrate")
+
+	// Expected
+	require.NoError(t, err)
+	assert.Contains(t, out, "Would migrate: metadata.enabled -> metadata.template ({{ShortHash}})")
+	assert.Contains(t, out, "Would migrate: metadata.type -> metadata.template")
+	unchanged, _ := os.ReadFile(".versionator.yaml")
+	assert.Equal(t, legacy, string(unchanged))
+
+	// Action: Write
+	out, _, err = runComponent(t, "config", "migrate", "--write")
+
+	// Expected
+	require.NoError(t, err)
+	assert.Contains(t, out, "Migrated: metadata.enabled -> metadata.template ({{ShortHash}})")
+	cfgData, _ := os.ReadFile(".versionator.yaml")
+	assert.NotContains(t, string(cfgData), "enabled: true")
+	assert.NotContains(t, string(cfgData), "type: git")
+	assert.Contains(t, string(cfgData), "template: '{{ShortHash}}'")
+}
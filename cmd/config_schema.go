@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+	"github.com/spf13/cobra"
+)
+
+var configSchemaOutput string
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Export a JSON Schema for .versionator.yaml",
+	Long: `Export a JSON Schema describing .versionator.yaml, for editor
+validation and autocompletion.
+
+The schema allows exactly the keys versionator accepts: unknown keys are
+rejected when the config is read, with the closest known key suggested.
+
+Examples:
+  versionator config schema -o versionator.schema.json
+
+  # VS Code (YAML extension), in .vscode/settings.json:
+  #   "yaml.schemas": {"./versionator.schema.json": ".versionator.yaml"}
+
+  # Or as the first line of .versionator.yaml:
+  #   # yaml-language-server: $schema=./versionator.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, err := json.MarshalIndent(config.JSONSchema(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("error generating config schema: %w", err)
+		}
+
+		if configSchemaOutput != "" {
+			if err := fileperm.WriteFile(configSchemaOutput, append(output, '\n')); err != nil {
+				return fmt.Errorf("error writing config schema to %s: %w", configSchemaOutput, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Config schema written to %s\n", configSchemaOutput)
+			return nil
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), string(output))
+		return nil
+	},
+}
+
+func init() {
+	configSchemaCmd.Flags().StringVarP(&configSchemaOutput, "output", "o", "", "Output file path (default: stdout)")
+	configCmd.AddCommand(configSchemaCmd)
+}
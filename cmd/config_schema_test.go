@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConfigSchema_PrintsJSONSchema validates the command output.
+//
+// Why: Editors load the schema from a file or URL; it must be valid JSON
+// on stdout with nothing else mixed in.
+//
+// What: 'config schema' prints a JSON Schema whose properties include the
+// top-level config keys.
+func TestConfigSchema_PrintsJSONSchema(t *testing.T) {
+	// Precondition
+	t.Chdir(t.TempDir())
+
+	// Action
+	out, _, err := runComponent(t, "config", "schema")
+
+	// Expected
+	require.NoError(t, err)
+	var schema struct {
+		Schema     string                     `json:"$schema"`
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &schema))
+	assert.Contains(t, schema.Schema, "json-schema.org")
+	assert.Contains(t, schema.Properties, "prerelease")
+	assert.Contains(t, schema.Properties, "updates")
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var noColorFlag bool
+var quietFlag bool
+
+// ANSI styles for status messages
+const (
+	ansiReset  = "\x1b[0m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+// console writes human-facing status messages ("Version set to: ...",
+// "Committed: ..."). Results a script may parse (versions, emitted content,
+// summaries) are printed directly and are never colored or suppressed.
+type console struct {
+	out   io.Writer
+	err   io.Writer
+	color bool
+	quiet bool
+}
+
+// newConsole returns the console for cmd: messages are colored only on a
+// terminal, unless --no-color, NO_COLOR, or TERM=dumb disables it; --quiet
+// drops everything but warnings
+func newConsole(cmd *cobra.Command) *console {
+	return &console{
+		out:   cmd.OutOrStdout(),
+		err:   cmd.ErrOrStderr(),
+		color: colorAllowed(),
+		quiet: quietFlag,
+	}
+}
+
+// colorAllowed reports whether color is permitted by flags and environment
+func colorAllowed() bool {
+	return !noColorFlag && os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+}
+
+// isTerminal reports whether w is a character device (an interactive
+// terminal rather than a pipe, file, or CI log)
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Successf reports a completed change, in green on a terminal
+func (c *console) Successf(format string, args ...any) {
+	if c.quiet {
+		return
+	}
+	c.write(c.out, ansiGreen, fmt.Sprintf(format, args...))
+}
+
+// Infof reports progress or context
+func (c *console) Infof(format string, args ...any) {
+	if c.quiet {
+		return
+	}
+	c.write(c.out, "", fmt.Sprintf(format, args...))
+}
+
+// Warnf reports a problem that did not stop the command on stderr, in
+// yellow on a terminal; --quiet does not suppress warnings
+func (c *console) Warnf(format string, args ...any) {
+	c.write(c.err, ansiYellow, "Warning: "+fmt.Sprintf(format, args...))
+}
+
+// write prints msg on its own line, styled when color is enabled
+func (c *console) write(w io.Writer, style, msg string) {
+	if style != "" && c.color && isTerminal(w) {
+		msg = style + msg + ansiReset
+	}
+	fmt.Fprintln(w, msg)
+}
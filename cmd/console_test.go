@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConsole_Quiet_KeepsWarningsOnly validates --quiet.
+//
+// Why: CI logs should show only what matters; a warning must never be
+// swallowed with the chatter.
+//
+// What: With quiet set, Successf and Infof print nothing and Warnf still
+// writes to stderr; without it, all three print plain text to a buffer.
+func TestConsole_Quiet_KeepsWarningsOnly(t *testing.T) {
+	for _, quiet := range []bool{true, false} {
+		// Precondition: Console over buffers
+		var out, errOut bytes.Buffer
+		c := &console{out: &out, err: &errOut, color: true, quiet: quiet}
+
+		// Action
+		c.Successf("Version set to: %s", "1.2.3")
+		c.Infof("Current version: %s", "v1.2.3")
+		c.Warnf("branch %s exists", "release/v1.2.3")
+
+		// Expected
+		if quiet {
+			assert.Empty(t, out.String())
+		} else {
+			assert.Equal(t, "Version set to: 1.2.3\nCurrent version: v1.2.3\n", out.String())
+		}
+		assert.Equal(t, "Warning: branch release/v1.2.3 exists\n", errOut.String())
+	}
+}
+
+// TestColorAllowed_RespectsFlagAndEnvironment validates color selection.
+//
+// Why: Escape codes corrupt CI logs and files; the NO_COLOR convention and
+// TERM=dumb must switch them off as well as --no-color.
+//
+// What: Color is allowed by default and disabled by each of the flag,
+// NO_COLOR, and TERM=dumb; a non-file writer is never a terminal.
+func TestColorAllowed_RespectsFlagAndEnvironment(t *testing.T) {
+	// Precondition: Clean environment
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm-256color")
+	defer func() { noColorFlag = false }()
+
+	// Action and Expected
+	assert.True(t, colorAllowed())
+
+	noColorFlag = true
+	assert.False(t, colorAllowed(), "--no-color")
+	noColorFlag = false
+
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, colorAllowed(), "NO_COLOR")
+	t.Setenv("NO_COLOR", "")
+
+	t.Setenv("TERM", "dumb")
+	assert.False(t, colorAllowed(), "TERM=dumb")
+
+	assert.False(t, isTerminal(&bytes.Buffer{}))
+}
+
+// TestPrefixEnable_Quiet_PrintsNothing validates --quiet on a command.
+//
+// Why: Scripts that change the prefix only care about the exit status.
+//
+// What: component prefix enable --quiet updates VERSION and prints no status.
+func TestPrefixEnable_Quiet_PrintsNothing(t *testing.T) {
+	// Precondition: VERSION without prefix
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+	defer func() {
+		quietFlag = false
+		rootCmd.PersistentFlags().Lookup("quiet").Changed = false
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+	}()
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"component", "prefix", "enable", "--quiet"})
+
+	// Action
+	err := rootCmd.Execute()
+
+	// Expected: Prefix applied silently
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+	content, _ := os.ReadFile("VERSION")
+	assert.Equal(t, "v1.2.3", string(bytes.TrimSpace(content)))
+}
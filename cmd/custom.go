@@ -24,7 +24,7 @@ Examples:
   versionator custom delete AppName
 
 Then use in templates:
-  versionator version -t "{{AppName}} v{{MajorMinorPatch}}"`,
+  versionator output version -t "{{AppName}} v{{MajorMinorPatch}}"`,
 }
 
 var customSetCmd = &cobra.Command{
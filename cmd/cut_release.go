@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/benjaminabbitt/versionator/internal/clierr"
+	"github.com/benjaminabbitt/versionator/internal/commitparser"
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/offline"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+// cutReleaseKinds are the release kinds a branch can be cut for
+var cutReleaseKinds = []string{"minor", "major"}
+
+var cutReleaseCmd = &cobra.Command{
+	Use:   "cut-release <minor|major>",
+	Short: "Cut a release branch from trunk",
+	Long: `Cut a release branch for the next minor or major release, as in
+trunk-based development: the release stabilises on its own branch while
+trunk moves on to the next version.
+
+This command will:
+1. Check that a branch is checked out and the working directory is clean
+2. Work out the release version from VERSION:
+   - a pre-release already naming the release (1.3.0-dev for minor,
+     2.0.0-dev for major) releases its core version
+   - otherwise VERSION is bumped (1.2.4 -> 1.3.0 for minor, 2.0.0 for major)
+3. Create <release.branchPrefix>X.Y (release/1.3) at HEAD and commit
+   X.Y.0-rc.1 to VERSION on it
+4. Commit the next development version (1.4.0-dev) to VERSION on trunk
+5. With --push, push both branches
+
+Trunk stays checked out. Tag the candidate from the release branch with
+'versionator release', then 'versionator rc graduate' when it is ready.
+
+Examples:
+  versionator cut-release minor            # release/1.3 at 1.3.0-rc.1, trunk at 1.4.0-dev
+  versionator cut-release major --push     # release/2.0 at 2.0.0-rc.1, trunk at 2.1.0-dev
+  versionator cut-release minor --dev-label=""   # trunk at 1.4.0`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: cutReleaseKinds,
+	RunE:      runCutRelease,
+}
+
+func init() {
+	rootCmd.AddCommand(cutReleaseCmd)
+
+	cutReleaseCmd.Flags().Bool("push", false, "Push the release branch and trunk")
+	cutReleaseCmd.Flags().String("dev-label", "dev", "Pre-release label of the next development version on trunk (empty for none)")
+}
+
+// cutReleaseVersions returns the version a release branch is cut for and
+// the development version trunk moves on to
+func cutReleaseVersions(current *version.Version, level version.VersionLevel, devLabel string) (release, next version.Version) {
+	release = *current
+	release.BuildMetadata = ""
+	named := release.Patch == 0 && (level == version.MinorLevel || release.Minor == 0)
+	if release.IsPreRelease() && named {
+		release.PreRelease = ""
+	} else if level == version.MajorLevel {
+		release.IncrementMajor()
+	} else {
+		release.IncrementMinor()
+	}
+	if release.Revision != nil {
+		zero := 0
+		release.Revision = &zero
+	}
+
+	next = release
+	next.IncrementMinor()
+	next.PreRelease = devLabel
+	release.PreRelease = rcLabel + ".1"
+	return release, next
+}
+
+func runCutRelease(cmd *cobra.Command, args []string) error {
+	level := version.MinorLevel
+	switch args[0] {
+	case "minor":
+	case "major":
+		level = version.MajorLevel
+	default:
+		return clierr.UnknownChoice("unsupported release kind", args[0], cutReleaseKinds)
+	}
+
+	activeVCS := vcs.GetActiveVCS()
+	if activeVCS == nil {
+		return clierr.NoRepository(commitparser.ErrNoVCSDetected)
+	}
+	if err := vcs.RequireCapability(activeVCS, vcs.CapabilityBranches); err != nil {
+		return err
+	}
+	switcher, ok := activeVCS.(vcs.BranchSwitcher)
+	if !ok {
+		return fmt.Errorf("%s does not support checking out branches", activeVCS.Name())
+	}
+	push, _ := cmd.Flags().GetBool("push")
+	if push {
+		if err := offline.Require("cut-release --push"); err != nil {
+			return err
+		}
+		if err := vcs.RequireCapability(activeVCS, vcs.CapabilityPush); err != nil {
+			return err
+		}
+	}
+
+	trunk, err := activeVCS.GetBranchName()
+	if err != nil {
+		return fmt.Errorf("error reading the current branch: %w", err)
+	}
+	if trunk == "" {
+		return fmt.Errorf("%s: check out the trunk branch to cut a release from", ErrDetachedHead)
+	}
+	clean, err := activeVCS.IsWorkingDirectoryClean()
+	if err != nil {
+		return fmt.Errorf("error checking %s status: %w", activeVCS.Name(), err)
+	}
+	if !clean {
+		return fmt.Errorf("working directory is not clean. Please commit or stash your changes first")
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("error reading config: %w", err)
+	}
+	current, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+	devLabel, _ := cmd.Flags().GetString("dev-label")
+	release, next := cutReleaseVersions(current, level, devLabel)
+
+	branchName := cfg.Release.BranchPrefix + release.MajorMinor()
+	exists, err := activeVCS.BranchExists(branchName)
+	if err != nil {
+		return fmt.Errorf("error checking if branch exists: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%s: '%s'", ErrReleaseBranchExists, branchName)
+	}
+
+	out := newConsole(cmd)
+	if err := activeVCS.CreateBranch(branchName); err != nil {
+		return fmt.Errorf("error creating release branch: %w", err)
+	}
+	if err := switcher.Checkout(branchName); err != nil {
+		return fmt.Errorf("error checking out '%s': %w", branchName, err)
+	}
+	if err := commitVersion(activeVCS, current, &release, fmt.Sprintf("Cut %s at %s", branchName, release.String())); err != nil {
+		// Leave the user where they started; the branch stays for inspection
+		_ = switcher.Checkout(trunk)
+		return err
+	}
+	out.Successf("Created branch '%s' at %s", branchName, release.FullString())
+
+	if err := switcher.Checkout(trunk); err != nil {
+		return fmt.Errorf("error checking out '%s': %w", trunk, err)
+	}
+	if err := commitVersion(activeVCS, current, &next, fmt.Sprintf("Begin %s after cutting %s", next.String(), branchName)); err != nil {
+		return err
+	}
+	out.Successf("Moved '%s' to %s", trunk, next.FullString())
+
+	if !push {
+		return nil
+	}
+	for _, branch := range []string{branchName, trunk} {
+		if err := activeVCS.PushBranch(branch); err != nil {
+			return fmt.Errorf("error pushing branch '%s': %w", branch, err)
+		}
+		out.Successf("Pushed branch '%s'", branch)
+	}
+	return nil
+}
+
+// commitVersion replaces current with v in VERSION and commits it on the
+// checked out branch; with another version store, v is only saved. The
+// write holds the VERSION lock and fails if current is no longer the version.
+func commitVersion(activeVCS vcs.VersionControlSystem, current, v *version.Version, message string) error {
+	if err := version.Replace(current, v); err != nil {
+		return fmt.Errorf("error writing VERSION: %w", err)
+	}
+	if !version.UsesFile() {
+		return nil
+	}
+	if err := activeVCS.CommitFiles([]string{"VERSION"}, message); err != nil {
+		return fmt.Errorf("error committing VERSION: %w", err)
+	}
+	return nil
+}
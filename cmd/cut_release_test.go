@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	gitVCS "github.com/benjaminabbitt/versionator/internal/vcs/git"
+	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
+	"github.com/benjaminabbitt/versionator/internal/version"
+	"github.com/golang/mock/gomock"
+)
+
+// switchingVCS is a mock repository that records branch checkouts and, like
+// a checkout, restores the VERSION committed on the branch checked out
+type switchingVCS struct {
+	*mock.MockVersionControlSystem
+	branch   string
+	versions map[string]string
+}
+
+func (s *switchingVCS) Checkout(branchName string) error {
+	s.branch = branchName
+	if content, ok := s.versions[branchName]; ok {
+		return os.WriteFile("VERSION", []byte(content), 0644)
+	}
+	return nil
+}
+
+// TestCutReleaseVersions validates the release and trunk versions.
+//
+// Why: Trunk either still holds the last release or already names the
+// release in development; cutting must not skip a version in either case.
+//
+// What: A released VERSION is bumped, a dev pre-release naming the release
+// is kept, and trunk moves to the next minor with the dev label.
+func TestCutReleaseVersions(t *testing.T) {
+	tests := []struct {
+		current string
+		level   version.VersionLevel
+		label   string
+		release string
+		next    string
+	}{
+		{current: "1.2.4", level: version.MinorLevel, label: "dev", release: "1.3.0-rc.1", next: "1.4.0-dev"},
+		{current: "1.3.0-dev", level: version.MinorLevel, label: "dev", release: "1.3.0-rc.1", next: "1.4.0-dev"},
+		{current: "1.3.0-dev", level: version.MajorLevel, label: "dev", release: "2.0.0-rc.1", next: "2.1.0-dev"},
+		{current: "2.0.0-alpha.3", level: version.MajorLevel, label: "", release: "2.0.0-rc.1", next: "2.1.0"},
+		{current: "1.2.4+build.7", level: version.MajorLevel, label: "dev", release: "2.0.0-rc.1", next: "2.1.0-dev"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.current, func(t *testing.T) {
+			// Precondition
+			current, err := version.ParseStrict(tt.current)
+			if err != nil {
+				t.Fatalf("ParseStrict: %v", err)
+			}
+
+			// Action
+			release, next := cutReleaseVersions(current, tt.level, tt.label)
+
+			// Expected
+			if release.String() != tt.release || next.String() != tt.next {
+				t.Errorf("got release %s, next %s; want %s, %s", release.String(), next.String(), tt.release, tt.next)
+			}
+		})
+	}
+}
+
+// TestCutRelease_CommitsOnBothBranches validates the branch cut.
+//
+// Why: Cutting by hand takes a branch, two checkouts, and two VERSION
+// edits, and getting one wrong publishes the wrong version.
+//
+// What: release/1.3 is created and gets 1.3.0-rc.1, trunk gets 1.4.0-dev,
+// both are pushed, and trunk is checked out afterwards.
+func TestCutRelease_CommitsOnBothBranches(t *testing.T) {
+	// Precondition: Clean trunk at 1.2.4
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("VERSION", []byte("v1.2.4\n"), 0644)
+	ctrl := gomock.NewController(t)
+	repo := &switchingVCS{MockVersionControlSystem: mock.NewMockVersionControlSystem(ctrl), branch: "main",
+		versions: map[string]string{"main": "v1.2.4\n"}}
+	repo.EXPECT().Name().Return("git").AnyTimes()
+	repo.EXPECT().IsRepository().Return(true).AnyTimes()
+	repo.EXPECT().GetRepositoryRoot().Return(".", nil).AnyTimes()
+	repo.EXPECT().GetBranchName().DoAndReturn(func() (string, error) { return repo.branch, nil }).AnyTimes()
+	repo.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	repo.EXPECT().BranchExists("release/1.3").Return(false, nil)
+	repo.EXPECT().CreateBranch("release/1.3").Return(nil)
+	commits := map[string]string{}
+	repo.EXPECT().CommitFiles([]string{"VERSION"}, gomock.Any()).DoAndReturn(func(files []string, message string) error {
+		data, _ := os.ReadFile("VERSION")
+		commits[repo.branch] = strings.TrimSpace(string(data))
+		repo.versions[repo.branch] = string(data)
+		return nil
+	}).Times(2)
+	repo.EXPECT().PushBranch("release/1.3").Return(nil)
+	repo.EXPECT().PushBranch("main").Return(nil)
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(repo)
+	t.Cleanup(func() {
+		_ = cutReleaseCmd.Flags().Set("push", "false")
+		cutReleaseCmd.Flags().Lookup("push").Changed = false
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		vcs.UnregisterVCS("git")
+		vcs.RegisterVCS(gitVCS.NewGitVCSDefault())
+	})
+
+	// Action
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"cut-release", "minor", "--push"})
+	err := rootCmd.Execute()
+
+	// Expected
+	if err != nil {
+		t.Fatalf("cut-release failed: %v", err)
+	}
+	if commits["release/1.3"] != "v1.3.0-rc.1" || commits["main"] != "v1.4.0-dev" {
+		t.Errorf("commits = %v", commits)
+	}
+	if repo.branch != "main" {
+		t.Errorf("checked out %q after the cut, want main", repo.branch)
+	}
+}
+
+// TestCutRelease_ExistingBranch_Fails validates the existing-branch guard.
+//
+// Why: Cutting the same release twice would move trunk a second version
+// forward and leave the first release branch behind.
+//
+// What: When release/1.3 exists, nothing is created or committed.
+func TestCutRelease_ExistingBranch_Fails(t *testing.T) {
+	// Precondition: release/1.3 already exists
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("VERSION", []byte("1.3.0-dev\n"), 0644)
+	ctrl := gomock.NewController(t)
+	repo := &switchingVCS{MockVersionControlSystem: mock.NewMockVersionControlSystem(ctrl), branch: "main"}
+	repo.EXPECT().Name().Return("git").AnyTimes()
+	repo.EXPECT().IsRepository().Return(true).AnyTimes()
+	repo.EXPECT().GetRepositoryRoot().Return(".", nil).AnyTimes()
+	repo.EXPECT().GetBranchName().Return("main", nil).AnyTimes()
+	repo.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	repo.EXPECT().BranchExists("release/1.3").Return(true, nil)
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(repo)
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		vcs.UnregisterVCS("git")
+		vcs.RegisterVCS(gitVCS.NewGitVCSDefault())
+	})
+
+	// Action
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"cut-release", "minor"})
+	err := rootCmd.Execute()
+
+	// Expected
+	if err == nil || !strings.Contains(err.Error(), ErrReleaseBranchExists) {
+		t.Errorf("expected %q, got %v", ErrReleaseBranchExists, err)
+	}
+	data, _ := os.ReadFile("VERSION")
+	if strings.TrimSpace(string(data)) != "1.3.0-dev" {
+		t.Errorf("VERSION changed to %q", data)
+	}
+}
+
+// TestCutRelease_ConcurrentChange_Refused validates the VERSION lock.
+//
+// Why: Both commits are computed from VERSION as loaded before the branch is
+// created; writing them blindly would undo a 'bump' that ran meanwhile.
+//
+// What: When VERSION changes after it was loaded, cut-release fails with
+// version.ErrVersionChanged before committing, keeping the new VERSION.
+func TestCutRelease_ConcurrentChange_Refused(t *testing.T) {
+	// Precondition: Clean trunk at 1.2.4, bumped to 1.2.5 while the branch is created
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("VERSION", []byte("v1.2.4\n"), 0644)
+	ctrl := gomock.NewController(t)
+	repo := &switchingVCS{MockVersionControlSystem: mock.NewMockVersionControlSystem(ctrl), branch: "main"}
+	repo.EXPECT().Name().Return("git").AnyTimes()
+	repo.EXPECT().IsRepository().Return(true).AnyTimes()
+	repo.EXPECT().GetRepositoryRoot().Return(".", nil).AnyTimes()
+	repo.EXPECT().GetBranchName().DoAndReturn(func() (string, error) { return repo.branch, nil }).AnyTimes()
+	repo.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	repo.EXPECT().BranchExists("release/1.3").Return(false, nil)
+	repo.EXPECT().CreateBranch("release/1.3").DoAndReturn(func(string) error {
+		return os.WriteFile("VERSION", []byte("v1.2.5\n"), 0644)
+	})
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(repo)
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		vcs.UnregisterVCS("git")
+		vcs.RegisterVCS(gitVCS.NewGitVCSDefault())
+	})
+
+	// Action
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"cut-release", "minor"})
+	err := rootCmd.Execute()
+
+	// Expected
+	if err == nil || !strings.Contains(err.Error(), version.ErrVersionChanged) {
+		t.Errorf("expected %q, got %v", version.ErrVersionChanged, err)
+	}
+	data, _ := os.ReadFile("VERSION")
+	if strings.TrimSpace(string(data)) != "v1.2.5" {
+		t.Errorf("VERSION changed to %q", data)
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/deprecation"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// deprecatedAnnotation holds the replacement for a deprecated command or
+// flag. Cobra's and pflag's own Deprecated fields print free text, so
+// deprecated usage is marked here and reported on the deprecation channel.
+const deprecatedAnnotation = "versionator_deprecated"
+
+// markDeprecated hides cmd from help and reports its use, naming the
+// replacement command (e.g. "component prefix enable")
+func markDeprecated(cmd *cobra.Command, replacement string) {
+	cmd.Hidden = true
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[deprecatedAnnotation] = replacement
+	cmd.Short += " (deprecated: use '" + replacement + "')"
+}
+
+// markFlagDeprecated hides the flag from help and reports its use, naming
+// the replacement (e.g. "--log-format")
+func markFlagDeprecated(flags *pflag.FlagSet, name, replacement string) {
+	f := flags.Lookup(name)
+	if f == nil {
+		return
+	}
+	f.Hidden = true
+	_ = flags.SetAnnotation(name, deprecatedAnnotation, []string{replacement})
+}
+
+// reportDeprecatedUsage reports the deprecated command, flags, and config
+// keys used by this invocation
+func reportDeprecatedUsage(cmd *cobra.Command) {
+	if replacement, ok := cmd.Annotations[deprecatedAnnotation]; ok {
+		deprecation.Report(deprecation.Notice{
+			Kind:        deprecation.KindCommand,
+			Name:        commandName(cmd),
+			Replacement: replacement,
+		})
+	}
+
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if replacement, ok := f.Annotations[deprecatedAnnotation]; ok && len(replacement) > 0 {
+			deprecation.Report(deprecation.Notice{
+				Kind:        deprecation.KindFlag,
+				Name:        "--" + f.Name,
+				Replacement: replacement[0],
+			})
+		}
+	})
+
+	if cfg, err := config.ReadConfig(); err == nil {
+		for _, m := range cfg.Migrations {
+			deprecation.Report(deprecation.Notice{
+				Kind:        deprecation.KindConfigKey,
+				Name:        m.From,
+				Replacement: m.To,
+				Hint:        "run 'versionator config migrate --write'",
+			})
+		}
+	}
+}
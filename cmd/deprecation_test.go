@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/deprecation"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeprecatedCommand_JSONLogFormat_WritesJSONLine validates the channel
+// end to end.
+//
+// Why: Organizations scan CI logs for deprecated usage; with the json log
+// format every notice must be a parseable line on stderr, apart from the
+// command's own output.
+//
+// What: A deprecated alias still works, stdout carries only its output, and
+// stderr carries a JSON notice naming the command and its replacement.
+func TestDeprecatedCommand_JSONLogFormat_WritesJSONLine(t *testing.T) {
+	// Precondition: VERSION without prefix
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+	defer func() {
+		logOutput = "quiet"
+		rootCmd.PersistentFlags().Lookup("log-format").Changed = false
+		deprecation.Configure(nil, false)
+	}()
+
+	// Action
+	out, errOut, err := runComponent(t, "config", "prefix", "enable", "--log-format=json")
+
+	// Expected
+	require.NoError(t, err)
+	assert.Contains(t, out, "Version prefix enabled")
+	assert.NotContains(t, out, "deprecated")
+	var notice map[string]string
+	for _, line := range strings.Split(errOut, "\n") {
+		if strings.Contains(line, `"msg":"deprecated"`) {
+			require.NoError(t, json.Unmarshal([]byte(line), &notice))
+		}
+	}
+	assert.Equal(t, "command", notice["kind"])
+	assert.Equal(t, "config prefix enable", notice["name"])
+	assert.Equal(t, "component prefix enable", notice["replacement"])
+}
+
+// TestReportDeprecatedUsage_Flag validates deprecated flags.
+//
+// Why: Flags are renamed as often as commands; a renamed flag must be
+// reported on the same channel, but only when it is used.
+//
+// What: A flag marked deprecated is hidden and reported when set; an unset
+// one is not reported.
+func TestReportDeprecatedUsage_Flag(t *testing.T) {
+	// Precondition: Command with a deprecated flag
+	t.Chdir(t.TempDir())
+	c := &cobra.Command{Use: "demo"}
+	c.Flags().Bool("old", false, "")
+	c.Flags().Bool("unused-old", false, "")
+	markFlagDeprecated(c.Flags(), "old", "--new")
+	markFlagDeprecated(c.Flags(), "unused-old", "--other")
+	require.NoError(t, c.Flags().Parse([]string{"--old"}))
+	var warnings bytes.Buffer
+	deprecation.Configure(&warnings, false)
+	defer deprecation.Configure(nil, false)
+
+	// Action
+	reportDeprecatedUsage(c)
+
+	// Expected
+	assert.True(t, c.Flags().Lookup("old").Hidden)
+	assert.Equal(t, "warning: deprecated flag: --old (use --new)\n", warnings.String())
+}
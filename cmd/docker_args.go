@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/ci"
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+var dockerArgsCmd = &cobra.Command{
+	Use:   "docker-args",
+	Short: "Print --build-arg and --label flags for docker/podman builds",
+	Long: `Print version build args and OCI image labels as docker/podman build flags:
+
+  --build-arg VERSION=v1.2.3 --build-arg VERSION_SEMVER=1.2.3 --build-arg GIT_SHA=<sha>
+  --label org.opencontainers.image.version=1.2.3
+  --label org.opencontainers.image.revision=<sha>
+  --label org.opencontainers.image.created=<build time>
+
+Use --vars to choose the build args from the variables of 'output ci'
+(VERSION, VERSION_SEMVER, VERSION_CORE, VERSION_MAJOR, GIT_SHA_SHORT,
+GIT_BRANCH, BUILD_NUMBER, ...). With --env-file, the build args are written as
+NAME=value lines instead (podman build --build-arg-file).
+
+Examples:
+  docker build $(versionator docker-args) -t app .
+  versionator docker-args --vars VERSION,GIT_SHA_SHORT,BUILD_NUMBER --no-labels
+  versionator docker-args --env-file build.args && podman build --build-arg-file build.args .`,
+	Args: cobra.NoArgs,
+	RunE: runDockerArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(dockerArgsCmd)
+
+	dockerArgsCmd.Flags().StringSlice("vars", ci.DefaultDockerVars, "Variables to pass as build args")
+	dockerArgsCmd.Flags().Bool("no-labels", false, "Omit the OCI image labels")
+	dockerArgsCmd.Flags().String("env-file", "", "Write build args as NAME=value lines to this file instead")
+}
+
+func runDockerArgs(cmd *cobra.Command, args []string) error {
+	v, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+	vars := buildCIVariables(v)
+
+	names, _ := cmd.Flags().GetStringSlice("vars")
+	buildArgs, err := ci.SelectVariables(vars.ToMap(""), names)
+	if err != nil {
+		return err
+	}
+
+	if envFile, _ := cmd.Flags().GetString("env-file"); envFile != "" {
+		file, err := fileperm.Create(envFile)
+		if err != nil {
+			return fmt.Errorf("failed to create env file: %w", err)
+		}
+		defer file.Close()
+		if err := ci.WriteEnvFile(file, buildArgs); err != nil {
+			return fmt.Errorf("failed to write env file: %w", err)
+		}
+		cmd.PrintErrf("Wrote %d build arg(s) to %s\n", len(buildArgs), envFile)
+		return nil
+	}
+
+	var labels map[string]string
+	if noLabels, _ := cmd.Flags().GetBool("no-labels"); !noLabels {
+		labels = map[string]string{
+			ci.LabelVersion:  vars.VersionSemver,
+			ci.LabelRevision: vars.GitSHA,
+			ci.LabelCreated:  time.Now().UTC().Format(time.RFC3339),
+		}
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), ci.DockerArgs(buildArgs, labels))
+	return nil
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/ci"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/suite"
+)
+
+// DockerArgsTestSuite defines the test suite for the docker-args command.
+type DockerArgsTestSuite struct {
+	suite.Suite
+	origDir string
+}
+
+// SetupTest runs before each test
+func (suite *DockerArgsTestSuite) SetupTest() {
+	var err error
+	suite.origDir, err = os.Getwd()
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.Chdir(suite.T().TempDir()))
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("v1.4.0-rc.2\n"), 0644))
+}
+
+// TearDownTest runs after each test
+func (suite *DockerArgsTestSuite) TearDownTest() {
+	if suite.origDir != "" {
+		_ = os.Chdir(suite.origDir)
+	}
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	// Set would append to a slice flag that was already changed
+	vars := dockerArgsCmd.Flags().Lookup("vars")
+	_ = vars.Value.(pflag.SliceValue).Replace(ci.DefaultDockerVars)
+	vars.Changed = false
+	_ = dockerArgsCmd.Flags().Set("no-labels", "false")
+	_ = dockerArgsCmd.Flags().Set("env-file", "")
+}
+
+// TestDockerArgs_Defaults_PrintsBuildArgsAndLabels validates the default
+// variable set and the OCI labels.
+func (suite *DockerArgsTestSuite) TestDockerArgs_Defaults_PrintsBuildArgsAndLabels() {
+	// Precondition: VERSION v1.4.0-rc.2 (see SetupTest)
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"docker-args"})
+
+	// Action: Run docker-args
+	err := rootCmd.Execute()
+
+	// Expected: Default build args, then labels
+	suite.Require().NoError(err)
+	suite.True(strings.HasPrefix(out.String(),
+		"--build-arg VERSION=v1.4.0-rc.2 --build-arg VERSION_SEMVER=1.4.0-rc.2 --build-arg GIT_SHA= "), out.String())
+	suite.Contains(out.String(), "--label "+ci.LabelVersion+"=1.4.0-rc.2")
+	suite.Contains(out.String(), "--label "+ci.LabelCreated+"=")
+}
+
+// TestDockerArgs_EnvFile_WritesSelectedVars validates --vars with --env-file.
+func (suite *DockerArgsTestSuite) TestDockerArgs_EnvFile_WritesSelectedVars() {
+	// Precondition: Selection of two variables
+	rootCmd.SetArgs([]string{"docker-args", "--vars", "VERSION_CORE,VERSION_PRERELEASE", "--env-file", "build.args"})
+
+	// Action: Run docker-args
+	err := rootCmd.Execute()
+
+	// Expected: Only the selected variables, as NAME=value lines
+	suite.Require().NoError(err)
+	content, err := os.ReadFile("build.args")
+	suite.Require().NoError(err)
+	suite.Equal("VERSION_CORE=1.4.0\nVERSION_PRERELEASE=rc.2\n", string(content))
+}
+
+func TestDockerArgsTestSuite(t *testing.T) {
+	suite.Run(t, new(DockerArgsTestSuite))
+}
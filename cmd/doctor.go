@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/benjaminabbitt/versionator/internal/doctor"
+
+	"github.com/spf13/cobra"
+)
+
+// doctorSetupErr is the error the root setup returned for this invocation;
+// doctor reports it instead of failing before any check runs
+var doctorSetupErr error
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the workspace versionator runs in",
+	Long: `Check the environment versionator depends on and print a report with a
+hint for each problem:
+
+  git binary    git on PATH (release, commit, and push steps run it)
+  repository    the repository detected for the current directory
+  HEAD          a branch is checked out (not a detached HEAD)
+  history       the clone is not shallow (tags and commit counts need it)
+  config        .versionator.yaml parses and is valid
+  VERSION       the VERSION file exists and parses
+  write access  VERSION and its directory are writable
+  plugins       the registered plugins, and the one scheme needs
+
+Nothing is modified. Exits non-zero when a check fails; warnings do not.
+Include the output, with 'versionator about', in bug reports.
+
+Examples:
+  versionator doctor
+  versionator doctor --json`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		doctorSetupErr = runRootPersistentPreRun(cmd, args)
+		return nil
+	},
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().Bool("json", false, "Output as JSON")
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	report := doctor.Run()
+	if doctorSetupErr != nil {
+		setup := doctor.Check{Name: "setup", Status: doctor.StatusFail, Detail: doctorSetupErr.Error(),
+			Hint: "fix the flag or configuration named above; other commands stop here"}
+		report.Checks = append([]doctor.Check{setup}, report.Checks...)
+	}
+
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding report: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+	} else if err := report.Write(cmd.OutOrStdout()); err != nil {
+		return err
+	}
+
+	if report.Failed() {
+		return fmt.Errorf("%s", ErrDoctorChecksFailed)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/doctor"
+)
+
+// TestDoctor_ReportsSetupAndConfigFailures validates the doctor command.
+//
+// Why: A broken configuration stops every other command in its setup, so
+// doctor must still run and say what is wrong.
+//
+// What: With an unknown scheme, the report leads with the setup failure,
+// still runs the other checks, and the command fails; with valid files the
+// JSON report has no failure.
+func TestDoctor_ReportsSetupAndConfigFailures(t *testing.T) {
+	// Precondition: Workspace with an unknown scheme
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("VERSION", []byte("1.2.3\n"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte("scheme: bogus\n"), 0644)
+	t.Cleanup(func() {
+		_ = doctorCmd.Flags().Set("json", "false")
+		doctorCmd.Flags().Lookup("json").Changed = false
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+	})
+
+	// Action
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"doctor"})
+	err := rootCmd.Execute()
+
+	// Expected
+	if err == nil || !strings.Contains(err.Error(), ErrDoctorChecksFailed) {
+		t.Errorf("expected %q, got %v", ErrDoctorChecksFailed, err)
+	}
+	lines := strings.Split(out.String(), "\n")
+	if !strings.HasPrefix(lines[0], "[fail]") || !strings.Contains(lines[0], "setup") {
+		t.Errorf("report should lead with the setup failure:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "1.2.3") {
+		t.Errorf("VERSION check missing:\n%s", out.String())
+	}
+
+	// Precondition: Valid configuration
+	_ = os.WriteFile(".versionator.yaml", []byte("prefix: v\n"), 0644)
+	out.Reset()
+
+	// Action
+	rootCmd.SetArgs([]string{"doctor", "--json"})
+	err = rootCmd.Execute()
+
+	// Expected
+	if err != nil {
+		t.Fatalf("doctor failed: %v\n%s", err, out.String())
+	}
+	var report doctor.Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out.String(), err)
+	}
+	if report.Failed() || len(report.Checks) == 0 {
+		t.Errorf("report = %+v", report)
+	}
+}
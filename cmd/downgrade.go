@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/history"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+// allowDowngradeUsage is the help text of the --allow-downgrade flag
+const allowDowngradeUsage = "Allow a version not greater than the highest tag (overrides release.monotonic)"
+
+// checkMonotonic refuses next when release.monotonic is enabled and next does
+// not have higher SemVer precedence than the highest version tag. It is a
+// no-op with --allow-downgrade, without a VCS that can list tags, or when the
+// repository has no version tags.
+func checkMonotonic(cmd *cobra.Command, next *version.Version) error {
+	if allow, _ := cmd.Flags().GetBool("allow-downgrade"); allow {
+		return nil
+	}
+	cfg, err := config.ReadConfig()
+	if err != nil || !cfg.Release.Monotonic {
+		return nil
+	}
+
+	lister, ok := vcs.GetActiveVCS().(vcs.TagLister)
+	if !ok {
+		return nil
+	}
+	tags, err := lister.ListTags()
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	highest := history.Highest(history.Build(tags))
+	if highest == nil {
+		return nil
+	}
+	tagged := version.Parse(highest.Version)
+	if next.Compare(&tagged) > 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: %s <= %s (use --allow-downgrade to override)",
+		ErrNotMonotonic, next.FullString(), highest.Tag)
+}
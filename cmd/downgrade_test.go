@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	gitVCS "github.com/benjaminabbitt/versionator/internal/vcs/git"
+	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+// DowngradeTestSuite defines the test suite for the release.monotonic guard.
+// With the guard enabled, bump and set refuse versions that do not exceed
+// the highest version tag.
+type DowngradeTestSuite struct {
+	suite.Suite
+	ctrl    *gomock.Controller
+	origDir string
+}
+
+// SetupTest runs before each test
+func (suite *DowngradeTestSuite) SetupTest() {
+	var err error
+	suite.origDir, err = os.Getwd()
+	suite.Require().NoError(err)
+	tempDir := suite.T().TempDir()
+	suite.Require().NoError(os.Chdir(tempDir))
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.2.0\n"), 0644))
+	suite.Require().NoError(os.WriteFile(".versionator.yaml", []byte("release:\n  monotonic: true\n"), 0644))
+
+	suite.ctrl = gomock.NewController(suite.T())
+
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(tempDir, nil).AnyTimes()
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(&tagListingVCS{
+		MockVersionControlSystem: mockVCS,
+		tags: []vcs.TagRef{
+			{Name: "v1.2.0", Branches: []string{"main"}},
+			{Name: "v1.3.0-rc.1", Branches: []string{"main"}},
+			{Name: "deploy-prod", Branches: []string{"main"}},
+		},
+	})
+}
+
+// TearDownTest runs after each test
+func (suite *DowngradeTestSuite) TearDownTest() {
+	if suite.origDir != "" {
+		_ = os.Chdir(suite.origDir)
+	}
+	if suite.ctrl != nil {
+		suite.ctrl.Finish()
+	}
+
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	_ = setCmd.Flags().Set("allow-downgrade", "false")
+	_ = bumpCmd.PersistentFlags().Set("allow-downgrade", "false")
+
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(gitVCS.NewGitVCSDefault())
+}
+
+func (suite *DowngradeTestSuite) readVersion() string {
+	content, err := os.ReadFile("VERSION")
+	suite.Require().NoError(err)
+	return strings.TrimSpace(string(content))
+}
+
+// TestSetCommand_BelowHighestTag_Refuses validates that set rejects an older
+// number when the guard is enabled.
+func (suite *DowngradeTestSuite) TestSetCommand_BelowHighestTag_Refuses() {
+	// Precondition: Highest tag is v1.3.0-rc.1 (see SetupTest)
+	rootCmd.SetArgs([]string{"set", "1.1.0"})
+
+	// Action: Set an older version
+	err := rootCmd.Execute()
+
+	// Expected: Refused, VERSION untouched
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), ErrNotMonotonic)
+	suite.Contains(err.Error(), "v1.3.0-rc.1")
+	suite.Equal("1.2.0", suite.readVersion())
+}
+
+// TestSetCommand_AllowDowngrade_WritesVersion validates the override flag.
+func (suite *DowngradeTestSuite) TestSetCommand_AllowDowngrade_WritesVersion() {
+	// Precondition: Guard enabled (see SetupTest)
+	rootCmd.SetArgs([]string{"set", "1.1.0", "--allow-downgrade"})
+
+	// Action: Set an older version with the override
+	err := rootCmd.Execute()
+
+	// Expected: VERSION rewritten
+	suite.Require().NoError(err)
+	suite.Equal("1.1.0", suite.readVersion())
+}
+
+// TestBumpLevel_PrecedenceAgainstPreReleaseTag_GuardsByPrecedence validates
+// that bumps are compared with pre-release tags by SemVer precedence.
+func (suite *DowngradeTestSuite) TestBumpLevel_PrecedenceAgainstPreReleaseTag_GuardsByPrecedence() {
+	// Precondition: VERSION 1.2.0, highest tag v1.3.0-rc.1
+	rootCmd.SetArgs([]string{"bump", "patch"})
+
+	// Action: Bump patch (1.2.1 < 1.3.0-rc.1), then minor (1.3.0 > 1.3.0-rc.1)
+	patchErr := rootCmd.Execute()
+	rootCmd.SetArgs([]string{"bump", "minor"})
+	minorErr := rootCmd.Execute()
+
+	// Expected: Patch refused, minor accepted
+	suite.Require().Error(patchErr)
+	suite.Contains(patchErr.Error(), ErrNotMonotonic)
+	suite.Require().NoError(minorErr)
+	suite.Equal("1.3.0", suite.readVersion())
+}
+
+// TestSetCommand_GuardDisabled_AllowsOlderVersion validates that the guard is
+// opt-in.
+func (suite *DowngradeTestSuite) TestSetCommand_GuardDisabled_AllowsOlderVersion() {
+	// Precondition: No release.monotonic in the config
+	suite.Require().NoError(os.Remove(".versionator.yaml"))
+	rootCmd.SetArgs([]string{"set", "1.0.0"})
+
+	// Action: Set an older version
+	err := rootCmd.Execute()
+
+	// Expected: VERSION rewritten
+	suite.Require().NoError(err)
+	suite.Equal("1.0.0", suite.readVersion())
+}
+
+func TestDowngradeTestSuite(t *testing.T) {
+	suite.Run(t, new(DowngradeTestSuite))
+}
@@ -3,9 +3,13 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/benjaminabbitt/versionator/internal/clierr"
 	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/detect"
 	"github.com/benjaminabbitt/versionator/internal/emit"
 	"github.com/benjaminabbitt/versionator/internal/version"
 	"github.com/benjaminabbitt/versionator/internal/versionator"
@@ -20,6 +24,15 @@ var (
 	emitPrereleaseTemplate string
 	emitMetadataTemplate   string
 	emitPrefixOverride     string
+	emitObfuscate          bool
+	dumpObfuscate          bool
+	emitAll                bool
+	emitAuto               bool
+	emitSummary            string
+	emitStreamFormat       string
+	emitLineEndings        string
+	emitFinalNewline       bool
+	emitGitignore          string
 )
 
 var emitCmd = &cobra.Command{
@@ -28,6 +41,10 @@ var emitCmd = &cobra.Command{
 	Long: `Emit the current version in various programming language formats.
 
 Supported formats: ` + strings.Join(emit.SupportedFormats(), ", ") + `
+Aliases: ` + strings.Join(emit.FormatAliases(), ", ") + `
+
+Without a format or template, the format is inferred from the extension of
+--output (version.py -> python, version.go -> go).
 
 FLAGS WITH OPTIONAL VALUES (use = syntax for values, e.g., --prefix=value):
   --prefix, -p            Enable prefix (default "v" if no value given)
@@ -56,13 +73,17 @@ TEMPLATE VARIABLES (Mustache syntax):
   Pre-release (rendered from --prerelease template):
     {{PreRelease}}           - Rendered pre-release (e.g., "alpha-5")
     {{PreReleaseWithDash}}   - With dash prefix (e.g., "-alpha-5") or empty
+    {{#PreReleaseParts}}{{.}}{{/PreReleaseParts}}
+                             - Each identifier (split on dots and dashes)
 
   Metadata (rendered from --metadata template):
     {{Metadata}}             - Rendered metadata (e.g., "20241211.abc1234")
     {{MetadataWithPlus}}     - With plus prefix (e.g., "+20241211.abc1234")
+    {{#MetadataParts}}{{.}}{{/MetadataParts}}
+                             - Each identifier (split on dots)
 
   VCS/Git Information:
-    {{Hash}}                 - Full commit hash (40 chars for git)
+    {{Hash}}                 - Full commit hash (40 chars for git, 64 for SHA-256 repos)
     {{ShortHash}}            - Short commit hash (7 chars)
     {{MediumHash}}           - Medium commit hash (12 chars)
     {{BranchName}}           - Current branch (e.g., "feature/foo")
@@ -70,13 +91,24 @@ TEMPLATE VARIABLES (Mustache syntax):
     {{CommitsSinceTag}}      - Commits since last tag (e.g., "42")
     {{BuildNumber}}          - Alias for CommitsSinceTag (GitVersion compatibility)
     {{BuildNumberPadded}}    - Padded to 4 digits (e.g., "0042")
+    {{CommitsSinceTagPadded(N)}} - Padded to N digits (e.g., "000042" for 6)
     {{UncommittedChanges}}   - Count of dirty files (e.g., "3")
     {{Dirty}}                - "dirty" if uncommitted changes > 0, empty otherwise
+    {{IsReleaseBuild}}       - "true" if HEAD is at a version tag and clean, empty otherwise
     {{VersionSourceHash}}    - Hash of commit the last tag points to
+    {{HashAlgorithm}}        - Commit hash algorithm (e.g., "sha1", "sha256")
+    {{PreviousVersion}}      - Highest tagged version below this one (e.g., "1.2.2")
+    {{PreviousTag}}          - Tag of PreviousVersion (e.g., "v1.2.2")
+    {{CommitsAheadOfDefault}} - Commits on HEAD not on the default branch (e.g., "7")
+    {{CommitsBehindDefault}}  - Commits on the default branch not on HEAD (e.g., "3")
+    {{InferredBranchName}}   - Branch, inferred when HEAD is detached (e.g., "main")
+    {{InferredBranchConfidence}} - exact, high (CI), medium, low, or none
 
   Commit Author:
     {{CommitAuthor}}         - Name of the commit author
     {{CommitAuthorEmail}}    - Email of the commit author
+    {{VersionFileLastChangedBy}}   - Author of the last commit that modified VERSION
+    {{VersionFileLastChangedDate}} - Date of that commit, ISO 8601
 
   Commit Timestamp (UTC):
     {{CommitDate}}           - ISO 8601: 2024-01-15T10:30:00Z
@@ -92,6 +124,9 @@ TEMPLATE VARIABLES (Mustache syntax):
     {{BuildDay}}             - Day: 15 (zero-padded)
     {{DateTimeDirty}}        - ".{BuildDateTimeCompact}" if uncommitted, empty otherwise
 
+  Build Host (with env.host: true in .versionator.yaml):
+    {{GoVersion}} {{OS}} {{Arch}} {{Hostname}} {{NumCPU}}
+
 Use 'versionator vars' to see all template variables and their current values.
 
 EXAMPLES:
@@ -111,6 +146,23 @@ EXAMPLES:
   # Write to file
   versionator emit python --output mypackage/_version.py
 
+  # Same, with the format inferred from the file extension
+  versionator emit --output mypackage/_version.py
+
+  # Keep the version string out of the compiled binary's plain strings
+  versionator emit go --obfuscate --output version/version.go
+
+  # Emit every target in emit.targets, with a JSON report for CI logs
+  versionator emit --all --summary json
+
+  # Stream every target to stdout as YAML documents ({target, path, content})
+  # instead of writing files, for Bazel rules or Nix builders
+  versionator emit --all --output -
+
+  # Emit a version file for each language detected in the repository
+  # (go.mod -> version/version.go, Cargo.toml -> src/version.rs, ...)
+  versionator emit --auto
+
   # Use template file
   versionator emit --template-file _version.tmpl.py --output _version.py
 
@@ -127,53 +179,146 @@ func runEmit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error getting version: %w", err)
 	}
 
+	// Read config for stability settings
+	cfg, _ := config.ReadConfig()
+
+	templateData, renderer, err := emitVersionData(cmd, vd, cfg, emitPrefixOverride, emitPrereleaseTemplate, emitMetadataTemplate)
+	if err != nil {
+		return err
+	}
+
+	if emitObfuscate {
+		emit.Obfuscate(&templateData)
+	}
+
+	if emitAll && emitAuto {
+		return fmt.Errorf("--all and --auto cannot be combined")
+	}
+	if emitAll || emitAuto {
+		if len(args) > 0 || (emitOutput != "" && emitOutput != emit.StdoutPath) || emitTemplate != "" || emitTemplateFile != "" {
+			return fmt.Errorf("--all and --auto emit several targets; they cannot be combined with a format, --output (other than '-'), or templates")
+		}
+		if emitOutput == emit.StdoutPath && emitSummary != "" {
+			return fmt.Errorf("--summary cannot be combined with --output -; the stream is the report")
+		}
+		if emitAuto {
+			return runEmitAuto(cmd, cfg, templateData)
+		}
+		return runEmitAll(cmd, cfg, templateData)
+	}
+
+	var templateStr string
+
+	// Check if using template file
+	if emitTemplateFile != "" {
+		data, err := os.ReadFile(emitTemplateFile)
+		if err != nil {
+			return fmt.Errorf("error reading template file: %w", err)
+		}
+		templateStr = string(data)
+	} else if emitTemplate != "" {
+		templateStr = emitTemplate
+	}
+
+	format := ""
+	if len(args) > 0 {
+		format = args[0]
+	} else if templateStr == "" && emitOutput != "" && emitOutput != emit.StdoutPath {
+		// No format given: infer it from the output file (version.py -> python)
+		if f, ok := emit.FormatForFile(emitOutput); ok {
+			format = string(f)
+		}
+	}
+	content, err := renderEmit(format, templateStr, renderer.With(templateData), cfg)
+	if err != nil {
+		return err
+	}
+	textFormat, err := emitTextFormat(cmd, cfg, config.EmitTarget{})
+	if err != nil {
+		return err
+	}
+	content = textFormat.Apply(content)
+
+	// Output to file or stdout
+	if emitOutput != "" && emitOutput != emit.StdoutPath {
+		if emitSummary != "" {
+			return writeEmitTargets(cmd, []emitTarget{{name: emitOutput, format: emitFormatLabel(format, templateStr), path: emitOutput, content: content}})
+		}
+		gitignoreMode, err := emitGitignoreMode()
+		if err != nil {
+			return err
+		}
+		if err := emit.WriteToFile(content, emitOutput); err != nil {
+			return fmt.Errorf("error writing to file: %w", err)
+		}
+		newConsole(cmd).Successf("Version %s written to %s", vd.CoreVersion(), emitOutput)
+		return guardEmitOutputs(cmd, gitignoreMode, []string{emitOutput})
+	} else {
+		if emitSummary != "" {
+			return fmt.Errorf("--summary requires --output, --all, or --auto")
+		}
+		fmt.Print(content)
+	}
+	return nil
+}
+
+// emitVersionData builds the template data of the version being emitted:
+// the prefix, pre-release, and metadata flags of cmd (given as flagged
+// values) override the stored values, and dynamic (stable: false)
+// templates are rendered. The returned renderer holds the base data.
+func emitVersionData(cmd *cobra.Command, vd *version.Version, cfg *config.Config, prefixFlag, prereleaseFlag, metadataFlag string) (emit.TemplateData, *emit.Renderer, error) {
+	var err error
+
 	// Handle prefix override
 	var prefix string
 	if cmd.Flags().Changed("prefix") {
-		if emitPrefixOverride == useDefaultMarker {
+		if prefixFlag == useDefaultMarker {
 			// Flag provided without value - use default "v"
 			prefix = "v"
 		} else {
-			prefix = emitPrefixOverride
+			prefix = prefixFlag
 		}
 	} else {
 		// Use prefix from VERSION file
 		prefix = vd.Prefix
 	}
 
-	// Read config for stability settings
-	cfg, _ := config.ReadConfig()
+	// One renderer serves the pre-release and metadata templates, so the
+	// VCS data, variable map, and plugin values are built once
+	baseData := emit.BuildTemplateDataFromVersion(vd)
+	renderer := emit.NewRenderer(baseData)
 
 	// Handle prerelease
 	// Priority: 1) --prerelease flag, 2) non-stable template, 3) VERSION file value
 	var prereleaseResult string
 	if cmd.Flags().Changed("prerelease") {
 		// Flag explicitly provided - use it
-		baseData := emit.BuildTemplateDataFromVersion(vd)
-		if emitPrereleaseTemplate == useDefaultMarker {
+		if prereleaseFlag == useDefaultMarker {
 			// Flag provided without value - use defaults from config
 			template, _ := versionator.GetPreReleaseTemplate()
 			if template != "" {
-				prereleaseResult, err = emit.RenderTemplateWithData(template, baseData)
+				warnZeroPaddedPreRelease(cmd, template)
+				prereleaseResult, err = renderer.Render(template)
 				if err != nil {
-					return fmt.Errorf("error rendering prerelease template: %w", err)
+					return emit.TemplateData{}, nil, fmt.Errorf("error rendering prerelease template: %w", err)
 				}
 				prereleaseResult = strings.TrimSpace(prereleaseResult)
 			}
 		} else {
 			// Render the provided template
-			prereleaseResult, err = emit.RenderTemplateWithData(emitPrereleaseTemplate, baseData)
+			warnZeroPaddedPreRelease(cmd, prereleaseFlag)
+			prereleaseResult, err = renderer.Render(prereleaseFlag)
 			if err != nil {
-				return fmt.Errorf("error rendering prerelease template: %w", err)
+				return emit.TemplateData{}, nil, fmt.Errorf("error rendering prerelease template: %w", err)
 			}
 			prereleaseResult = strings.TrimSpace(prereleaseResult)
 		}
 	} else if cfg != nil && !cfg.PreRelease.Stable && cfg.PreRelease.Template != "" {
 		// Non-stable: automatically render template
-		baseData := emit.BuildTemplateDataFromVersion(vd)
-		prereleaseResult, err = emit.RenderTemplateWithData(cfg.PreRelease.Template, baseData)
+		warnZeroPaddedPreRelease(cmd, cfg.PreRelease.Template)
+		prereleaseResult, err = renderer.Render(cfg.PreRelease.Template)
 		if err != nil {
-			return fmt.Errorf("error rendering prerelease template: %w", err)
+			return emit.TemplateData{}, nil, fmt.Errorf("error rendering prerelease template: %w", err)
 		}
 		prereleaseResult = strings.TrimSpace(prereleaseResult)
 	} else {
@@ -186,31 +331,29 @@ func runEmit(cmd *cobra.Command, args []string) error {
 	var metadataResult string
 	if cmd.Flags().Changed("metadata") {
 		// Flag explicitly provided - use it
-		baseData := emit.BuildTemplateDataFromVersion(vd)
-		if emitMetadataTemplate == useDefaultMarker {
+		if metadataFlag == useDefaultMarker {
 			// Flag provided without value - use defaults from config
 			template, _ := versionator.GetMetadataTemplate()
 			if template != "" {
-				metadataResult, err = emit.RenderTemplateWithData(template, baseData)
+				metadataResult, err = renderer.Render(template)
 				if err != nil {
-					return fmt.Errorf("error rendering metadata template: %w", err)
+					return emit.TemplateData{}, nil, fmt.Errorf("error rendering metadata template: %w", err)
 				}
 				metadataResult = strings.TrimSpace(metadataResult)
 			}
 		} else {
 			// Render the provided template
-			metadataResult, err = emit.RenderTemplateWithData(emitMetadataTemplate, baseData)
+			metadataResult, err = renderer.Render(metadataFlag)
 			if err != nil {
-				return fmt.Errorf("error rendering metadata template: %w", err)
+				return emit.TemplateData{}, nil, fmt.Errorf("error rendering metadata template: %w", err)
 			}
 			metadataResult = strings.TrimSpace(metadataResult)
 		}
 	} else if cfg != nil && !cfg.Metadata.Stable && cfg.Metadata.Template != "" {
 		// Non-stable: automatically render template
-		baseData := emit.BuildTemplateDataFromVersion(vd)
-		metadataResult, err = emit.RenderTemplateWithData(cfg.Metadata.Template, baseData)
+		metadataResult, err = renderer.Render(cfg.Metadata.Template)
 		if err != nil {
-			return fmt.Errorf("error rendering metadata template: %w", err)
+			return emit.TemplateData{}, nil, fmt.Errorf("error rendering metadata template: %w", err)
 		}
 		metadataResult = strings.TrimSpace(metadataResult)
 	} else {
@@ -219,7 +362,7 @@ func runEmit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Build template data with rendered prerelease and metadata
-	templateData := emit.BuildTemplateDataFromVersion(vd)
+	templateData := baseData
 	templateData.Prefix = prefix
 	templateData.PreRelease = prereleaseResult
 	if prereleaseResult != "" {
@@ -229,57 +372,279 @@ func runEmit(cmd *cobra.Command, args []string) error {
 	if metadataResult != "" {
 		templateData.MetadataWithPlus = "+" + metadataResult
 	}
+	return templateData, renderer, nil
+}
 
-	var content string
-	var templateStr string
-
-	// Check if using template file
-	if emitTemplateFile != "" {
-		data, err := os.ReadFile(emitTemplateFile)
+// renderEmit renders templateStr, or the built-in format when templateStr is
+// empty, with r's data
+func renderEmit(format, templateStr string, r *emit.Renderer, cfg *config.Config) (string, error) {
+	if templateStr != "" {
+		content, err := r.Render(templateStr)
 		if err != nil {
-			return fmt.Errorf("error reading template file: %w", err)
+			return "", fmt.Errorf("error rendering template: %w", err)
 		}
-		templateStr = string(data)
-	} else if emitTemplate != "" {
-		templateStr = emitTemplate
+		return content, nil
+	}
+
+	// Require format argument if no template
+	if format == "" {
+		return "", clierr.New("format argument required (or use --template/--template-file)",
+			"available: "+strings.Join(emit.SupportedFormats(), ", "),
+			"with --output, a known extension (e.g. version.py) selects the format")
+	}
+	resolved, ok := emit.ResolveFormat(format)
+	if !ok {
+		return "", clierr.UnknownChoice("unsupported format", format, emit.SupportedFormats())
+	}
+	format = string(resolved)
+
+	// Maven/Gradle outputs mark development builds as SNAPSHOT
+	if data := r.Data(); cfg != nil && emit.IsSnapshotFormat(emit.Format(format)) && cfg.Java.UseSnapshot(emit.IsTaggedBuild(data)) {
+		emit.AppendSnapshot(&data)
+		r = r.With(data)
+	}
+
+	// Built-in formats render like any other template
+	var tmplStr string
+	var err error
+	if emitObfuscate {
+		tmplStr, err = emit.GetObfuscatedTemplate(emit.Format(format))
+	} else {
+		tmplStr, err = emit.GetEmbeddedTemplate(emit.Format(format))
+	}
+	if err != nil {
+		return "", fmt.Errorf("error getting template: %w", err)
+	}
+	content, err := r.Render(tmplStr)
+	if err != nil {
+		return "", fmt.Errorf("error rendering format: %w", err)
+	}
+	return content, nil
+}
+
+// emitTextFormat returns the line endings and final newline for target:
+// emit.lineEndings and emit.finalNewline, replaced by the target's own
+// settings, replaced in turn by --line-endings and --final-newline
+func emitTextFormat(cmd *cobra.Command, cfg *config.Config, target config.EmitTarget) (emit.TextFormat, error) {
+	var f emit.TextFormat
+	if cfg != nil {
+		f = emit.TextFormat{LineEndings: cfg.Emit.LineEndings, FinalNewline: cfg.Emit.FinalNewline}
+	}
+	f = f.Override(emit.TextFormat{LineEndings: target.LineEndings, FinalNewline: target.FinalNewline})
+
+	flags := emit.TextFormat{LineEndings: emitLineEndings}
+	if flags.LineEndings != "" && !slices.Contains(emit.LineEndingStyles(), flags.LineEndings) {
+		return emit.TextFormat{}, clierr.UnknownChoice("invalid --line-endings", flags.LineEndings, emit.LineEndingStyles())
+	}
+	if cmd.Flags().Changed("final-newline") {
+		finalNewline := emitFinalNewline
+		flags.FinalNewline = &finalNewline
 	}
+	return f.Override(flags), nil
+}
 
-	// Render content
+// emitTarget is rendered content bound for a file
+type emitTarget struct {
+	name    string
+	format  string
+	path    string
+	content string
+}
+
+// emitFormatLabel names what produced a target's content in the summary
+func emitFormatLabel(format, templateStr string) string {
 	if templateStr != "" {
-		content, err = emit.RenderTemplateWithData(templateStr, templateData)
-		if err != nil {
-			return fmt.Errorf("error rendering template: %w", err)
+		return "template"
+	}
+	return format
+}
+
+// runEmitAll renders every target in emit.targets, then writes them all, so
+// a template error leaves no target half-updated
+func runEmitAll(cmd *cobra.Command, cfg *config.Config, data emit.TemplateData) error {
+	if cfg == nil || len(cfg.Emit.Targets) == 0 {
+		return fmt.Errorf("no emit targets configured (add emit.targets to .versionator.yaml)")
+	}
+
+	targets, err := renderEmitTargets(cmd, cfg.Emit.Targets, data, cfg)
+	if err != nil {
+		return err
+	}
+	return writeEmitTargets(cmd, targets)
+}
+
+// runEmitAuto renders the default target of each detected language (or of
+// each language listed in languages), creating output directories as needed
+func runEmitAuto(cmd *cobra.Command, cfg *config.Config, data emit.TemplateData) error {
+	langs, err := detect.ResolveConfig(".", cfg)
+	if err != nil {
+		return err
+	}
+	if len(langs) == 0 {
+		return fmt.Errorf("%s", detect.ErrNoLanguages)
+	}
+
+	targets, err := renderEmitTargets(cmd, detect.EmitTargets(langs), data, cfg)
+	if err != nil {
+		return err
+	}
+	for _, t := range targets {
+		if emitOutput == emit.StdoutPath {
+			break
 		}
-	} else {
-		// Require format argument if no template
-		if len(args) == 0 {
-			return fmt.Errorf("format argument required (or use --template/--template-file)\nSupported formats: %s", strings.Join(emit.SupportedFormats(), ", "))
+		if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+			return fmt.Errorf("error creating directory for %s: %w", t.path, err)
 		}
+	}
+	return writeEmitTargets(cmd, targets)
+}
 
-		format := emit.Format(args[0])
-		if !emit.IsValidFormat(string(format)) {
-			return fmt.Errorf("unsupported format '%s'\nSupported formats: %s", format, strings.Join(emit.SupportedFormats(), ", "))
-		}
+// runVersionWrite writes the default emit target (emit.default) with the
+// version data of `output version`, for `output version --write`
+func runVersionWrite(cmd *cobra.Command, vd *version.Version) error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("error reading config: %w", err)
+	}
+	target, ok := cfg.Emit.DefaultTarget()
+	if !ok {
+		return fmt.Errorf("no default emit target configured (set emit.default, or configure a single target in emit.targets)")
+	}
+
+	data, _, err := emitVersionData(cmd, vd, cfg, prefixOverride, prereleaseTemplate, metadataTemplate)
+	if err != nil {
+		return err
+	}
+	custom, err := config.GetAllCustom()
+	if err != nil {
+		return fmt.Errorf("error loading custom variables: %w", err)
+	}
+	emit.MergeCustomVars(&data, custom)
+	extraVars, err := customFlagVars(setFileVars, setVars)
+	if err != nil {
+		return err
+	}
+	emit.MergeCustomVars(&data, extraVars)
+
+	targets, err := renderEmitTargets(cmd, []config.EmitTarget{target}, data, cfg)
+	if err != nil {
+		return err
+	}
+	_, changed, err := emit.WriteIfChanged(targets[0].content, target.Output)
+	if err != nil {
+		return fmt.Errorf("error writing to file: %w", err)
+	}
+	if changed {
+		newConsole(cmd).Successf("Version %s written to %s", vd.CoreVersion(), target.Output)
+	} else {
+		newConsole(cmd).Infof("%s is up to date", target.Output)
+	}
+	return guardEmitOutputs(cmd, cfg.Emit.Gitignore, []string{target.Output})
+}
 
-		// For built-in formats, use RenderTemplateWithData for consistency
-		tmplStr, err := emit.GetEmbeddedTemplate(format)
+// renderEmitTargets renders every target before any is written. Each target
+// starts from the same data, with its own version overrides applied.
+func renderEmitTargets(cmd *cobra.Command, configured []config.EmitTarget, data emit.TemplateData, cfg *config.Config) ([]emitTarget, error) {
+	targets := make([]emitTarget, 0, len(configured))
+	renderer := emit.NewRenderer(data)
+	for i, t := range configured {
+		targetData, err := emit.ApplyOverrides(data, emit.Overrides{Prefix: t.Prefix, PreRelease: t.PreRelease, Metadata: t.Metadata, Style: t.Style})
 		if err != nil {
-			return fmt.Errorf("error getting template: %w", err)
+			return nil, fmt.Errorf("emit.targets[%d]: %w", i, err)
 		}
-		content, err = emit.RenderTemplateWithData(tmplStr, templateData)
+		templateStr := ""
+		if t.TemplateFile != "" {
+			tmpl, err := os.ReadFile(t.TemplateFile)
+			if err != nil {
+				return nil, fmt.Errorf("emit.targets[%d]: error reading template file: %w", i, err)
+			}
+			templateStr = string(tmpl)
+		}
+		content, err := renderEmit(t.Format, templateStr, renderer.With(targetData).WithEscape(t.Escape), cfg)
 		if err != nil {
-			return fmt.Errorf("error rendering format: %w", err)
+			return nil, fmt.Errorf("emit.targets[%d]: %w", i, err)
+		}
+		textFormat, err := emitTextFormat(cmd, cfg, t)
+		if err != nil {
+			return nil, err
+		}
+		content = textFormat.Apply(content)
+		name := t.Name
+		if name == "" {
+			name = t.Output
 		}
+		targets = append(targets, emitTarget{name: name, format: emitFormatLabel(t.Format, templateStr), path: t.Output, content: content})
 	}
+	return targets, nil
+}
 
-	// Output to file or stdout
-	if emitOutput != "" {
-		if err := emit.WriteToFile(content, emitOutput); err != nil {
+// writeEmitTargets writes targets, skipping files whose content is
+// unchanged, and prints the --summary report. With --output - the targets
+// are streamed to stdout instead and no file is touched.
+func writeEmitTargets(cmd *cobra.Command, targets []emitTarget) error {
+	if emitOutput == emit.StdoutPath {
+		docs := make([]emit.Document, 0, len(targets))
+		for _, t := range targets {
+			docs = append(docs, emit.Document{Target: t.name, Path: t.path, Content: t.content})
+		}
+		return emit.WriteStream(cmd.OutOrStdout(), emitStreamFormat, docs)
+	}
+
+	gitignoreMode, err := emitGitignoreMode()
+	if err != nil {
+		return err
+	}
+	written := make([]emit.Written, 0, len(targets))
+	paths := make([]string, 0, len(targets))
+	for _, t := range targets {
+		n, changed, err := emit.WriteIfChanged(t.content, t.path)
+		if err != nil {
 			return fmt.Errorf("error writing to file: %w", err)
 		}
-		fmt.Printf("Version %s written to %s\n", vd.CoreVersion(), emitOutput)
-	} else {
-		fmt.Print(content)
+		written = append(written, emit.Written{Target: t.name, Format: t.format, Path: t.path, Bytes: n, Changed: changed})
+		paths = append(paths, t.path)
+	}
+	if err := emit.WriteSummary(cmd.OutOrStdout(), emitSummary, written); err != nil {
+		return err
+	}
+	return guardEmitOutputs(cmd, gitignoreMode, paths)
+}
+
+// emitGitignoreMode returns the .gitignore guard mode: --gitignore, else
+// emit.gitignore
+func emitGitignoreMode() (string, error) {
+	if emitGitignore != "" {
+		if !slices.Contains(emit.GitignoreModes(), emitGitignore) {
+			return "", clierr.UnknownChoice("invalid --gitignore", emitGitignore, emit.GitignoreModes())
+		}
+		return emitGitignore, nil
+	}
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return "", nil
+	}
+	return cfg.Emit.Gitignore, nil
+}
+
+// guardEmitOutputs keeps written files out of commits per mode, warning
+// about generated files that are tracked or (verify) not ignored. Status
+// goes to stderr so summaries on stdout stay parseable.
+func guardEmitOutputs(cmd *cobra.Command, mode string, paths []string) error {
+	report, err := emit.GuardIgnored(mode, paths)
+	if err != nil {
+		return fmt.Errorf("error checking .gitignore: %w", err)
+	}
+	out := newConsole(cmd)
+	for _, path := range report.Added {
+		if !quietFlag {
+			cmd.PrintErrf("Added %s to .gitignore\n", path)
+		}
+	}
+	for _, path := range report.Unignored {
+		out.Warnf("%s is generated but not ignored; add it to .gitignore so it is not committed", path)
+	}
+	for _, path := range report.Tracked {
+		out.Warnf("%s is generated but tracked, so every build changes it; untrack it with 'git rm --cached %s'", path, path)
 	}
 	return nil
 }
@@ -292,6 +657,7 @@ var emitDumpCmd = &cobra.Command{
 This allows you to customize the template and use it with --template-file.
 
 Supported formats: ` + strings.Join(emit.SupportedFormats(), ", ") + `
+Aliases: ` + strings.Join(emit.FormatAliases(), ", ") + `
 
 See 'versionator emit --help' for the full list of template variables.
 
@@ -309,12 +675,18 @@ Examples:
 }
 
 func runEmitDump(cmd *cobra.Command, args []string) error {
-	format := emit.Format(args[0])
-	if !emit.IsValidFormat(string(format)) {
-		return fmt.Errorf("unsupported format '%s'\nSupported formats: %s", format, strings.Join(emit.SupportedFormats(), ", "))
+	format, ok := emit.ResolveFormat(args[0])
+	if !ok {
+		return clierr.UnknownChoice("unsupported format", args[0], emit.SupportedFormats())
 	}
 
-	template, err := emit.GetEmbeddedTemplate(format)
+	var template string
+	var err error
+	if dumpObfuscate {
+		template, err = emit.GetObfuscatedTemplate(format)
+	} else {
+		template, err = emit.GetEmbeddedTemplate(format)
+	}
 	if err != nil {
 		return fmt.Errorf("error getting template: %w", err)
 	}
@@ -324,7 +696,7 @@ func runEmitDump(cmd *cobra.Command, args []string) error {
 		if err := emit.WriteToFile(template, dumpOutput); err != nil {
 			return fmt.Errorf("error writing to file: %w", err)
 		}
-		fmt.Printf("Template for '%s' written to %s\n", format, dumpOutput)
+		newConsole(cmd).Successf("Template for '%s' written to %s", format, dumpOutput)
 	} else {
 		fmt.Print(template)
 	}
@@ -335,7 +707,7 @@ func init() {
 	outputCmd.AddCommand(emitCmd)
 	emitCmd.AddCommand(emitDumpCmd)
 
-	emitCmd.Flags().StringVarP(&emitOutput, "output", "o", "", "Output file path (default: stdout)")
+	emitCmd.Flags().StringVarP(&emitOutput, "output", "o", "", "Output file path, or - for stdout (default: stdout)")
 	emitCmd.Flags().StringVarP(&emitTemplate, "template", "t", "", "Custom Mustache template string")
 	emitCmd.Flags().StringVarP(&emitTemplateFile, "template-file", "f", "", "Path to template file")
 
@@ -351,5 +723,18 @@ func init() {
 	emitCmd.Flags().StringVar(&emitMetadataTemplate, "metadata", "", "Metadata template (uses config default if flag provided without value)")
 	emitCmd.Flag("metadata").NoOptDefVal = useDefaultMarker
 
+	// Obfuscated constants: the version is XOR-encoded behind an accessor
+	emitCmd.Flags().BoolVar(&emitObfuscate, "obfuscate", false, "Emit the version obfuscated behind an accessor function ("+strings.Join(emit.ObfuscatedFormats(), ", ")+")")
+
+	// Configured targets and the report of what was written
+	emitCmd.Flags().BoolVar(&emitAll, "all", false, "Emit every target in emit.targets and print a summary")
+	emitCmd.Flags().BoolVar(&emitAuto, "auto", false, "Emit the default file for each detected language and print a summary")
+	emitCmd.Flags().StringVar(&emitSummary, "summary", "", "Summary of written files: table (default with --all/--auto) or json")
+	emitCmd.Flags().StringVar(&emitLineEndings, "line-endings", "", "Line endings of the output: "+strings.Join(emit.LineEndingStyles(), ", ")+" (default: as rendered, or emit.lineEndings)")
+	emitCmd.Flags().BoolVar(&emitFinalNewline, "final-newline", false, "End the output with exactly one line ending (--final-newline=false: none); default as rendered, or emit.finalNewline")
+	emitCmd.Flags().StringVar(&emitGitignore, "gitignore", "", "Keep written files out of commits: add (add them to .gitignore) or verify (warn when not ignored); default emit.gitignore")
+	emitCmd.Flags().StringVar(&emitStreamFormat, "stream-format", emit.StreamYAML, "Format of the targets streamed by --all/--auto with --output -: yaml (one document per target) or json (array)")
+
 	emitDumpCmd.Flags().StringVarP(&dumpOutput, "output", "o", "", "Output file path (default: stdout)")
+	emitDumpCmd.Flags().BoolVar(&dumpObfuscate, "obfuscate", false, "Dump the obfuscated template variant")
 }
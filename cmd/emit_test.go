@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"testing"
 
@@ -643,6 +644,76 @@ func TestEmit_WithOutputFile_WritesToFile(t *testing.T) {
 	emitOutput = ""
 }
 
+// TestEmit_OutputExtension_InfersFormat verifies format inference and aliases.
+//
+// Why: The output file name already says which language it holds; naming
+// the format as well is redundant, and users type py or yml out of habit.
+//
+// What: "emit --output version.py" writes the python format, and the alias
+// "yml" renders the yaml format.
+func TestEmit_OutputExtension_InfersFormat(t *testing.T) {
+	// Precondition
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("VERSION", []byte("1.2.3\n"), 0644)
+	emitOutput, emitTemplate, emitTemplateFile = "", "", ""
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		emitOutput = ""
+	})
+
+	// Action: No format, Python output file
+	captureStdout(func() {
+		rootCmd.SetArgs([]string{"output", "emit", "--output", "version.py"})
+		_ = rootCmd.Execute()
+	})
+
+	// Expected: The python template was rendered
+	content, err := os.ReadFile("version.py")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "__version__")
+
+	// Action: The yml alias
+	emitOutput = ""
+	output := captureStdout(func() {
+		rootCmd.SetArgs([]string{"output", "emit", "yml"})
+		_ = rootCmd.Execute()
+	})
+
+	// Expected: The yaml template was rendered
+	assert.Contains(t, output, `version: "1.2.3"`)
+}
+
+// TestEmit_LineEndings_ConfigAndFlags verifies line ending control.
+//
+// Why: Windows resource and batch files need CRLF, whatever the templates
+// use, and the flags must win over .versionator.yaml.
+//
+// What: emit.lineEndings converts the output; --final-newline=false strips
+// the final line ending.
+func TestEmit_LineEndings_ConfigAndFlags(t *testing.T) {
+	// Precondition: CRLF configured
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("VERSION", []byte("1.2.3\n"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte("emit:\n  lineEndings: crlf\n"), 0644)
+	emitOutput, emitTemplate, emitTemplateFile, emitLineEndings = "", "", "", ""
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		emitOutput, emitFinalNewline = "", false
+		emitCmd.Flags().Lookup("final-newline").Changed = false
+	})
+
+	// Action
+	captureStdout(func() {
+		rootCmd.SetArgs([]string{"output", "emit", "--template", "a\nb\n", "--output", "out.txt", "--final-newline=false"})
+		_ = rootCmd.Execute()
+	})
+
+	// Expected
+	content, err := os.ReadFile("out.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "a\r\nb", string(content))
+}
+
 // TestEmit_InvalidFormat_ReturnsError verifies that an unknown format returns an error.
 func TestEmit_InvalidFormat_ReturnsError(t *testing.T) {
 	tempDir := t.TempDir()
@@ -731,3 +802,347 @@ func TestEmit_WithValidFormat_OutputsContent(t *testing.T) {
 
 	rootCmd.SetArgs(nil)
 }
+
+// TestEmit_JavaSnapshotAuto_AppendsSnapshotToMavenFormats verifies the Maven
+// SNAPSHOT workflow for emitted Java/Kotlin sources.
+//
+// Why: JVM builds mark development versions with -SNAPSHOT so repositories
+// treat them as mutable; only Maven/Gradle outputs should carry it.
+//
+// What: With java.snapshot auto outside a tagged commit, java and kotlin
+// output contain "3.2.1-SNAPSHOT" while json does not; with never, java
+// output is the plain version.
+func TestEmit_JavaSnapshotAuto_AppendsSnapshotToMavenFormats(t *testing.T) {
+	// Precondition: VERSION and java.snapshot auto, no release tag
+	t.Chdir(t.TempDir())
+	emitOutput, emitTemplate, emitTemplateFile = "", "", ""
+	_ = os.WriteFile("VERSION", []byte("3.2.1\n"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte("prefix: \"\"\njava:\n  snapshot: auto\n"), 0644)
+	defer rootCmd.SetArgs(nil)
+
+	// Action: Emit each format
+	emitFormat := func(format string) string {
+		return captureStdout(func() {
+			rootCmd.SetArgs([]string{"output", "emit", format})
+			_ = rootCmd.Execute()
+		})
+	}
+
+	// Expected: SNAPSHOT only in Maven/Gradle outputs
+	assert.Contains(t, emitFormat("java"), `VERSION = "3.2.1-SNAPSHOT"`)
+	assert.Contains(t, emitFormat("kotlin"), `VERSION = "3.2.1-SNAPSHOT"`)
+	assert.NotContains(t, emitFormat("json"), "SNAPSHOT")
+
+	// Action: Disable the snapshot mode
+	_ = os.WriteFile(".versionator.yaml", []byte("prefix: \"\"\njava:\n  snapshot: never\n"), 0644)
+
+	// Expected: Plain version
+	assert.Contains(t, emitFormat("java"), `VERSION = "3.2.1"`)
+}
+
+// TestEmit_Obfuscate_HidesVersionBehindAccessor verifies --obfuscate output.
+//
+// Why: Some distributors do not want the version string greppable in shipped
+// binaries; the emitted source must only contain the encoded bytes.
+//
+// What: "output emit go --obfuscate" produces a Version() accessor and never
+// the plain version; unsupported formats fail with an error.
+func TestEmit_Obfuscate_HidesVersionBehindAccessor(t *testing.T) {
+	// Precondition: Repository-less directory with a VERSION file
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	_ = os.Chdir(tempDir)
+	defer func() {
+		emitObfuscate = false
+		emitCmd.Flags().Lookup("obfuscate").Changed = false
+		rootCmd.SetArgs(nil)
+	}()
+	emitOutput = ""
+	emitTemplate = ""
+	emitTemplateFile = ""
+	emitPrereleaseTemplate = ""
+	emitMetadataTemplate = ""
+	emitPrefixOverride = ""
+	_ = os.WriteFile("VERSION", []byte("3.2.1\n"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte("prefix: \"\"\n"), 0644)
+
+	// Action: Emit obfuscated Go source
+	output := captureStdout(func() {
+		rootCmd.SetArgs([]string{"output", "emit", "go", "--obfuscate"})
+		_ = rootCmd.Execute()
+	})
+
+	// Expected: Accessor present, plain version absent
+	assert.Contains(t, output, "func Version() string")
+	assert.NotContains(t, output, "3.2.1")
+
+	// Action: Obfuscate a format without an accessor
+	rootCmd.SetArgs([]string{"output", "emit", "json", "--obfuscate"})
+	err := rootCmd.Execute()
+
+	// Expected: Unsupported format error
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support obfuscation")
+}
+
+// TestEmit_All_WritesTargetsAndSummary verifies `output emit --all`.
+//
+// Why: CI generates several version files in one step and its logs must
+// show what was written and whether anything changed.
+//
+// What: Every emit.targets entry is written; the JSON summary lists each
+// with its bytes and a changed status that turns false on a rerun.
+func TestEmit_All_WritesTargetsAndSummary(t *testing.T) {
+	// Precondition: Two targets, one built-in format and one template file
+	t.Chdir(t.TempDir())
+	emitOutput, emitTemplate, emitTemplateFile = "", "", ""
+	defer func() {
+		emitAll, emitSummary = false, ""
+		emitCmd.Flags().Lookup("all").Changed = false
+		emitCmd.Flags().Lookup("summary").Changed = false
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+	}()
+	_ = os.Mkdir("build", 0755)
+	_ = os.WriteFile("VERSION", []byte("1.4.0\n"), 0644)
+	_ = os.WriteFile("notes.tmpl", []byte("Release {{MajorMinorPatch}}\n"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte(`prefix: ""
+emit:
+  targets:
+    - name: json
+      format: json
+      output: build/version.json
+    - name: notes
+      templateFile: notes.tmpl
+      output: NOTES.txt
+`), 0644)
+
+	runAll := func() []emit.Written {
+		var out bytes.Buffer
+		rootCmd.SetOut(&out)
+		rootCmd.SetArgs([]string{"output", "emit", "--all", "--summary", "json"})
+		require.NoError(t, rootCmd.Execute())
+		var written []emit.Written
+		require.NoError(t, json.Unmarshal(out.Bytes(), &written), out.String())
+		return written
+	}
+
+	// Action: Emit all targets
+	written := runAll()
+
+	// Expected: Both files written and reported as changed
+	require.Len(t, written, 2)
+	assert.Equal(t, emit.Written{Target: "notes", Format: "template", Path: "NOTES.txt", Bytes: len("Release 1.4.0\n"), Changed: true}, written[1])
+	assert.Equal(t, "json", written[0].Format)
+	assert.True(t, written[0].Changed)
+	content, err := os.ReadFile("build/version.json")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "1.4.0")
+
+	// Action: Emit again with nothing changed
+	written = runAll()
+
+	// Expected: Both reported unchanged
+	require.Len(t, written, 2)
+	assert.False(t, written[0].Changed)
+	assert.False(t, written[1].Changed)
+}
+
+// TestEmit_All_OutputDash_StreamsDocuments verifies `output emit --all
+// --output -`.
+//
+// Why: Bazel rules and Nix builders place generated files themselves and
+// must not have emit write into the source tree.
+//
+// What: Every target is printed as {target, path, content} and no file is
+// written; --summary is rejected with the stream.
+func TestEmit_All_OutputDash_StreamsDocuments(t *testing.T) {
+	// Precondition: Two targets
+	t.Chdir(t.TempDir())
+	emitTemplate, emitTemplateFile = "", ""
+	defer func() {
+		emitAll, emitOutput, emitSummary, emitStreamFormat = false, "", "", emit.StreamYAML
+		for _, name := range []string{"all", "output", "summary", "stream-format"} {
+			emitCmd.Flags().Lookup(name).Changed = false
+		}
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+	}()
+	_ = os.WriteFile("VERSION", []byte("1.4.0\n"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte(`prefix: ""
+emit:
+  targets:
+    - name: json
+      format: json
+      output: build/version.json
+    - name: notes
+      templateFile: notes.tmpl
+      output: NOTES.txt
+`), 0644)
+	_ = os.WriteFile("notes.tmpl", []byte("Release {{MajorMinorPatch}}\n"), 0644)
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"output", "emit", "--all", "--output", "-", "--stream-format", "json"})
+
+	// Action
+	err := rootCmd.Execute()
+
+	// Expected: Documents on stdout, nothing on disk
+	require.NoError(t, err)
+	var docs []emit.Document
+	require.NoError(t, json.Unmarshal(out.Bytes(), &docs), out.String())
+	require.Len(t, docs, 2)
+	assert.Equal(t, emit.Document{Target: "notes", Path: "NOTES.txt", Content: "Release 1.4.0\n"}, docs[1])
+	assert.Equal(t, "build/version.json", docs[0].Path)
+	assert.NoFileExists(t, "NOTES.txt")
+
+	// Action: With --summary
+	rootCmd.SetArgs([]string{"output", "emit", "--all", "--output", "-", "--summary", "json"})
+	err = rootCmd.Execute()
+
+	// Expected
+	assert.ErrorContains(t, err, "--summary cannot be combined")
+}
+
+// TestEmit_All_AppliesTargetOverrides verifies per-target version overrides.
+//
+// Why: A Docker tag cannot carry build metadata and a Python package needs
+// PEP 440, yet both come from the same version in one CI step.
+//
+// What: From 1.4.0-rc.2+abc, the tag target drops the prefix and metadata
+// and the Python target renders 1.4.0rc2+abc.
+func TestEmit_All_AppliesTargetOverrides(t *testing.T) {
+	// Precondition: VERSION with pre-release and metadata; two targets
+	t.Chdir(t.TempDir())
+	emitOutput, emitTemplate, emitTemplateFile = "", "", ""
+	defer func() {
+		emitAll = false
+		emitCmd.Flags().Lookup("all").Changed = false
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+	}()
+	emitCmd.Flags().Lookup("prerelease").Changed = false
+	emitCmd.Flags().Lookup("metadata").Changed = false
+	_ = os.WriteFile("VERSION", []byte("v1.4.0-rc.2+abc\n"), 0644)
+	_ = os.WriteFile("tag.tmpl", []byte("{{Prefix}}{{MajorMinorPatch}}{{PreReleaseWithDash}}{{MetadataWithPlus}}"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte(`prefix: v
+emit:
+  targets:
+    - name: tag
+      templateFile: tag.tmpl
+      output: TAG
+      prefix: ""
+      metadata: ""
+    - format: python
+      output: _version.py
+      style: pep440
+`), 0644)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"output", "emit", "--all"})
+
+	// Action: Emit all targets
+	err := rootCmd.Execute()
+
+	// Expected: Each target rendered with its overrides
+	require.NoError(t, err)
+	tag, err := os.ReadFile("TAG")
+	require.NoError(t, err)
+	assert.Equal(t, "1.4.0-rc.2", string(tag))
+	python, err := os.ReadFile("_version.py")
+	require.NoError(t, err)
+	assert.Contains(t, string(python), `"1.4.0rc2+abc"`)
+}
+
+// TestEmit_All_EscapesPerTarget verifies the per-target escape mode.
+//
+// Why: Mustache HTML-escapes values by default, which turns R&D into
+// R&amp;D in generated code, yet an XML output needs exactly that.
+//
+// What: An environment value with & and < is written as-is to a code
+// target and escaped in the target with escape: xml, where {{{ }}} stays raw.
+func TestEmit_All_EscapesPerTarget(t *testing.T) {
+	// Precondition: A value with markup characters and two targets
+	t.Chdir(t.TempDir())
+	emitOutput, emitTemplate, emitTemplateFile = "", "", ""
+	defer func() {
+		emitAll = false
+		emitCmd.Flags().Lookup("all").Changed = false
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+	}()
+	_ = os.WriteFile("VERSION", []byte("1.4.0\n"), 0644)
+	t.Setenv("TEAM_OWNER", "R&D <eng>")
+	_ = os.WriteFile("owner.tmpl", []byte("{{Env.TEAM_OWNER}}|{{{Env.TEAM_OWNER}}}"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte(`env:
+  allow: [TEAM_OWNER]
+emit:
+  targets:
+    - templateFile: owner.tmpl
+      output: owner.txt
+    - templateFile: owner.tmpl
+      output: owner.xml
+      escape: xml
+`), 0644)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"output", "emit", "--all"})
+
+	// Action: Emit all targets
+	err := rootCmd.Execute()
+
+	// Expected: Raw by default, escaped where configured
+	require.NoError(t, err)
+	raw, err := os.ReadFile("owner.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "R&D <eng>|R&D <eng>", string(raw))
+	escaped, err := os.ReadFile("owner.xml")
+	require.NoError(t, err)
+	assert.Equal(t, "R&amp;D &lt;eng&gt;|R&D <eng>", string(escaped))
+}
+
+// TestEmit_Auto_WritesDetectedLanguageFiles validates emit --auto.
+//
+// Why: Polyglot repositories want a version file per language without
+// listing targets by hand.
+//
+// What: With go.mod and package.json present, version/version.go and
+// src/version.js are written (directories created); a languages override
+// limits output to the listed language.
+func TestEmit_Auto_WritesDetectedLanguageFiles(t *testing.T) {
+	// Precondition: Go and JavaScript markers
+	t.Chdir(t.TempDir())
+	emitOutput, emitTemplate, emitTemplateFile = "", "", ""
+	defer func() {
+		emitAuto, emitSummary = false, ""
+		emitCmd.Flags().Lookup("auto").Changed = false
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+	}()
+	_ = os.WriteFile("VERSION", []byte("2.1.0\n"), 0644)
+	_ = os.WriteFile("go.mod", []byte("module example.com/app\n"), 0644)
+	_ = os.WriteFile("package.json", []byte(`{"name": "app"}`), 0644)
+
+	// Action: Emit for detected languages
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"output", "emit", "--auto"})
+	require.NoError(t, rootCmd.Execute())
+
+	// Expected: A file per language
+	goFile, err := os.ReadFile("version/version.go")
+	require.NoError(t, err)
+	assert.Contains(t, string(goFile), "2.1.0")
+	_, err = os.ReadFile("src/version.js")
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "version/version.go")
+
+	// Action: Override detection
+	require.NoError(t, os.RemoveAll("version"))
+	_ = os.WriteFile(".versionator.yaml", []byte("languages: [js]\n"), 0644)
+	rootCmd.SetArgs([]string{"output", "emit", "--auto"})
+	require.NoError(t, rootCmd.Execute())
+
+	// Expected: Only the listed language
+	_, err = os.Stat("version/version.go")
+	assert.True(t, os.IsNotExist(err))
+}
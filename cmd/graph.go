@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/clierr"
+	"github.com/benjaminabbitt/versionator/internal/commitparser"
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+	"github.com/benjaminabbitt/versionator/internal/history"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+
+	"github.com/spf13/cobra"
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Show version tag history per branch",
+	Long: `Render the history of version tags over time, grouped by branch.
+
+Each version tag is resolved to its commit and commit date, then grouped
+under every local branch whose history contains it. Tags that do not
+parse as versions are ignored.
+
+Formats:
+  ascii - Timeline per branch (default)
+  dot   - Graphviz digraph (pipe to 'dot -Tsvg')
+  json  - Tag list and branch mapping for dashboards
+
+Examples:
+  versionator graph                          # ASCII timeline
+  versionator graph --format=dot | dot -Tsvg -o versions.svg
+  versionator graph --format=json --output=history.json`,
+	Args: cobra.NoArgs,
+	RunE: runGraph,
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+
+	graphCmd.Flags().StringP("format", "f", string(history.FormatASCII),
+		"Output format ("+strings.Join(history.AvailableFormats(), ", ")+")")
+	graphCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	formatFlag, _ := cmd.Flags().GetString("format")
+	format := history.Format(strings.ToLower(formatFlag))
+	if !slices.Contains(history.AvailableFormats(), string(format)) {
+		return clierr.UnknownChoice("invalid format", formatFlag, history.AvailableFormats())
+	}
+
+	activeVCS := vcs.GetActiveVCS()
+	if activeVCS == nil {
+		return clierr.NoRepository(commitparser.ErrNoVCSDetected)
+	}
+
+	lister, ok := activeVCS.(vcs.TagLister)
+	if !ok {
+		return fmt.Errorf("%s does not support listing tags", activeVCS.Name())
+	}
+
+	tags, err := lister.ListTags()
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	writer := cmd.OutOrStdout()
+	outputFile, _ := cmd.Flags().GetString("output")
+	if outputFile != "" {
+		file, err := fileperm.Create(outputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	return history.Render(writer, history.Build(tags), format)
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	gitVCS "github.com/benjaminabbitt/versionator/internal/vcs/git"
+	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+// tagListingVCS adds the optional TagLister capability to the generated mock
+type tagListingVCS struct {
+	*mock.MockVersionControlSystem
+	tags []vcs.TagRef
+}
+
+func (t *tagListingVCS) ListTags() ([]vcs.TagRef, error) {
+	return t.tags, nil
+}
+
+// GraphTestSuite defines the test suite for the graph command.
+// The graph command renders version tag history grouped by branch.
+type GraphTestSuite struct {
+	suite.Suite
+	ctrl    *gomock.Controller
+	tempDir string
+	origDir string
+}
+
+// SetupTest runs before each test
+func (suite *GraphTestSuite) SetupTest() {
+	suite.tempDir = suite.T().TempDir()
+	var err error
+	suite.origDir, err = os.Getwd()
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.Chdir(suite.tempDir))
+
+	suite.ctrl = gomock.NewController(suite.T())
+
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	_ = graphCmd.Flags().Set("format", "ascii")
+	_ = graphCmd.Flags().Set("output", "")
+}
+
+// TearDownTest runs after each test
+func (suite *GraphTestSuite) TearDownTest() {
+	if suite.origDir != "" {
+		_ = os.Chdir(suite.origDir)
+	}
+	if suite.ctrl != nil {
+		suite.ctrl.Finish()
+	}
+
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(gitVCS.NewGitVCSDefault())
+}
+
+// registerTagListingVCS registers a mock VCS that reports the given tags
+func (suite *GraphTestSuite) registerTagListingVCS(tags []vcs.TagRef) {
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(&tagListingVCS{MockVersionControlSystem: mockVCS, tags: tags})
+}
+
+// TestGraphCommand_JSON_OutputsTagHistory validates that graph --format=json
+// emits the tag list and branch grouping from the active VCS.
+func (suite *GraphTestSuite) TestGraphCommand_JSON_OutputsTagHistory() {
+	// Precondition: VCS reports two version tags on main and one unrelated tag
+	suite.registerTagListingVCS([]vcs.TagRef{
+		{Name: "v1.0.0", Commit: "aaaaaaaa", Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Branches: []string{"main"}},
+		{Name: "deploy-prod", Commit: "bbbbbbbb", Date: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), Branches: []string{"main"}},
+		{Name: "v1.1.0", Commit: "cccccccc", Date: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC), Branches: []string{"main"}},
+	})
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"graph", "--format", "json"})
+
+	// Action: Render the graph as JSON
+	err := rootCmd.Execute()
+
+	// Expected: Only version tags, grouped under main
+	suite.Require().NoError(err)
+	var out struct {
+		Tags     []map[string]any    `json:"tags"`
+		Branches map[string][]string `json:"branches"`
+	}
+	suite.Require().NoError(json.Unmarshal(buf.Bytes(), &out), buf.String())
+	suite.Len(out.Tags, 2)
+	suite.Equal([]string{"v1.0.0", "v1.1.0"}, out.Branches["main"])
+}
+
+// TestGraphCommand_OutputFile_WritesFile validates that --output writes the
+// rendered graph to a file instead of stdout.
+func (suite *GraphTestSuite) TestGraphCommand_OutputFile_WritesFile() {
+	// Precondition: VCS reports a single version tag
+	suite.registerTagListingVCS([]vcs.TagRef{
+		{Name: "v1.0.0", Commit: "aaaaaaaa", Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Branches: []string{"main"}},
+	})
+
+	rootCmd.SetArgs([]string{"graph", "--format", "dot", "--output", "versions.dot"})
+
+	// Action: Render DOT output to a file
+	err := rootCmd.Execute()
+
+	// Expected: File contains a digraph
+	suite.Require().NoError(err)
+	content, err := os.ReadFile("versions.dot")
+	suite.Require().NoError(err)
+	suite.Contains(string(content), "digraph versions {")
+}
+
+// TestGraphCommand_InvalidFormat_ReturnsError validates that an unknown
+// format is rejected before any output is produced.
+func (suite *GraphTestSuite) TestGraphCommand_InvalidFormat_ReturnsError() {
+	// Precondition: An unsupported format
+	rootCmd.SetArgs([]string{"graph", "--format", "svg", "--output", "versions.svg"})
+
+	// Action: Run graph
+	err := rootCmd.Execute()
+
+	// Expected: Error lists available formats and no file is created
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "available: ascii, dot, json")
+	_, statErr := os.Stat("versions.svg")
+	suite.True(os.IsNotExist(statErr), "output file should not be created")
+}
+
+// TestGraphCommand_VCSWithoutTagListing_ReturnsError validates the error when
+// the active VCS cannot enumerate tags.
+func (suite *GraphTestSuite) TestGraphCommand_VCSWithoutTagListing_ReturnsError() {
+	// Precondition: Active VCS does not implement TagLister
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(mockVCS)
+
+	rootCmd.SetArgs([]string{"graph"})
+
+	// Action: Run graph
+	err := rootCmd.Execute()
+
+	// Expected: Error explains the missing capability
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "does not support listing tags")
+}
+
+func TestGraphTestSuite(t *testing.T) {
+	suite.Run(t, new(GraphTestSuite))
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+// runLifecycleHooks notifies registered hook plugins (e.g., webhooks) that an
+// event completed. The version operation has already succeeded, so hook
+// failures are reported as warnings rather than failing the command.
+func runLifecycleHooks(cmd *cobra.Command, event plugin.Event) {
+	if len(plugin.GetHooks()) == 0 {
+		return
+	}
+
+	v, err := version.Load()
+	if err != nil {
+		cmd.PrintErrf("Warning: skipping %s hooks: %v\n", event, err)
+		return
+	}
+
+	var preTemplate, metaTemplate string
+	if cfg, err := config.ReadConfig(); err == nil {
+		preTemplate, metaTemplate = cfg.PreRelease.Template, cfg.Metadata.Template
+	}
+	vars := emit.TemplateDataToStringMap(emit.BuildCompleteTemplateData(v, preTemplate, metaTemplate))
+
+	if err := plugin.RunHooks(event, vars); err != nil {
+		cmd.PrintErrf("Warning: %s hook failed: %v\n", event, err)
+	}
+}
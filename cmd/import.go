@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+	"github.com/benjaminabbitt/versionator/internal/importer"
+
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import configuration from another versioning tool",
+	Long: `Translate another versioning tool's configuration into .versionator.yaml.
+
+Settings are translated to their nearest equivalent. A report lists what
+was translated directly, what was approximated, and what has no equivalent
+and was ignored, so the remaining decisions can be made by hand.
+
+Use --dry-run to print the translated configuration instead of writing it.
+An existing .versionator.yaml is only replaced with --force.`,
+}
+
+var importGitVersionCmd = &cobra.Command{
+	Use:   "gitversion [GitVersion.yml]",
+	Short: "Import a GitVersion configuration",
+	Long: `Translate a GitVersion.yml (v5 or v6) into .versionator.yaml.
+
+Translated:
+  tag-prefix                   -> prefix ('v', 'V', or empty)
+  branches (main/release)      -> branchVersioning.mainBranches (regex -> glob)
+  branches (labelled)          -> branchVersioning.prereleaseTemplate
+  mode                         -> nearest versionator workflow
+  *-version-bump-message       -> +semver: markers read by 'versionator bump'
+
+Example:
+  versionator import gitversion                  # reads ./GitVersion.yml
+  versionator import gitversion ci/GitVersion.yml --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImport(cmd, args, importer.FromGitVersion, "GitVersion.yml", "GitVersion.yaml")
+	},
+}
+
+var importSemanticReleaseCmd = &cobra.Command{
+	Use:   "semantic-release [.releaserc]",
+	Short: "Import a semantic-release configuration",
+	Long: `Translate a semantic-release configuration into .versionator.yaml.
+
+Reads .releaserc (JSON or YAML), .releaserc.json, .releaserc.yml, or the
+"release" key of package.json.
+
+Translated:
+  tagFormat                    -> prefix ('v', 'V', or empty)
+  branches (release)           -> branchVersioning.mainBranches
+  branches (prerelease)        -> branchVersioning.prereleaseTemplate
+  branches (channel)           -> reported; versionator has no channels
+  @semantic-release/npm        -> updates (package.json version)
+  @semantic-release/github     -> release.publish (also gitlab, gitea)
+  repositoryUrl                -> release.publish.repository
+
+Example:
+  versionator import semantic-release            # reads ./.releaserc*
+  versionator import semantic-release package.json --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImport(cmd, args, importer.FromSemanticRelease,
+			".releaserc", ".releaserc.json", ".releaserc.yaml", ".releaserc.yml", "package.json")
+	},
+}
+
+var importBump2VersionCmd = &cobra.Command{
+	Use:     "bump2version [.bumpversion.cfg]",
+	Aliases: []string{"bumpversion"},
+	Short:   "Import a bump2version configuration",
+	Long: `Translate a .bumpversion.cfg (or the [bumpversion] sections of setup.cfg)
+into .versionator.yaml.
+
+Translated:
+  tag_name                     -> prefix ('v', 'V', or empty)
+  [bumpversion:file:...]       -> updates, for package.json, composer.json,
+                                  Cargo.toml, pyproject.toml, and Chart.yaml
+  current_version              -> reported; run 'versionator set'
+
+Files patched by text search and replace are reported; updates only edit
+JSON, YAML, and TOML fields.
+
+Example:
+  versionator import bump2version                # reads ./.bumpversion.cfg or setup.cfg
+  versionator import bump2version setup.cfg --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImport(cmd, args, importer.FromBump2Version, ".bumpversion.cfg", "setup.cfg")
+	},
+}
+
+// runImport reads the source file (the argument, or the first of
+// defaultPaths that exists), translates it, writes (or prints) the
+// configuration, and prints the migration report
+func runImport(cmd *cobra.Command, args []string, translate func([]byte) (*importer.Result, error), defaultPaths ...string) error {
+	path := defaultPaths[0]
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		for _, candidate := range defaultPaths {
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break
+			}
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	result, err := translate(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	content, err := config.Encode(result.Config)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun {
+		_, _ = out.Write(content)
+		printImportReport(cmd.ErrOrStderr(), path, result)
+		return nil
+	}
+
+	configPath := ".versionator.yaml"
+	force, _ := cmd.Flags().GetBool("force")
+	if _, err := os.Stat(configPath); err == nil && !force {
+		return fmt.Errorf("%s already exists; use --force to replace it or --dry-run to preview", configPath)
+	}
+	if err := fileperm.WriteFile(configPath, content); err != nil {
+		return fmt.Errorf("error writing %s: %w", configPath, err)
+	}
+	config.Invalidate()
+
+	fmt.Fprintf(out, "Wrote %s from %s\n", configPath, path)
+	printImportReport(out, path, result)
+	return nil
+}
+
+// printImportReport lists translated, approximated, and unsupported settings
+func printImportReport(w io.Writer, source string, result *importer.Result) {
+	sections := []struct {
+		title string
+		notes []importer.Note
+	}{
+		{"Translated", result.Translated},
+		{"Approximated (review these)", result.Approximated},
+		{"Unsupported (ignored)", result.Unsupported},
+	}
+	for _, section := range sections {
+		if len(section.notes) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s:\n", section.title)
+		for _, note := range section.notes {
+			fmt.Fprintf(w, "  %s: %s\n", note.Setting, note.Message)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importGitVersionCmd)
+	importCmd.AddCommand(importSemanticReleaseCmd)
+	importCmd.AddCommand(importBump2VersionCmd)
+
+	importCmd.PersistentFlags().Bool("dry-run", false, "Print the translated configuration instead of writing it")
+	importCmd.PersistentFlags().Bool("force", false, "Replace an existing .versionator.yaml")
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/stretchr/testify/suite"
+)
+
+// ImportTestSuite defines the test suite for import command tests.
+// The import command translates other versioning tools' configuration into
+// .versionator.yaml and reports what could not be carried over.
+type ImportTestSuite struct {
+	suite.Suite
+	origDir string
+}
+
+// SetupTest runs before each test
+func (suite *ImportTestSuite) SetupTest() {
+	var err error
+	suite.origDir, err = os.Getwd()
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.Chdir(suite.T().TempDir()))
+
+	gitVersion := "tag-prefix: v\nbranches:\n  main:\n    regex: ^main$\n    label: ''\n"
+	suite.Require().NoError(os.WriteFile("GitVersion.yml", []byte(gitVersion), 0644))
+}
+
+// TearDownTest runs after each test
+func (suite *ImportTestSuite) TearDownTest() {
+	if suite.origDir != "" {
+		_ = os.Chdir(suite.origDir)
+	}
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	_ = importCmd.PersistentFlags().Set("dry-run", "false")
+	_ = importCmd.PersistentFlags().Set("force", "false")
+}
+
+// TestImportGitVersion_DryRun_PrintsConfigWithoutWriting validates --dry-run.
+//
+// Why: Users should be able to review a translation before it replaces their
+// configuration.
+//
+// What: The translated YAML is printed to stdout, the report to stderr, and
+// no .versionator.yaml is written.
+func (suite *ImportTestSuite) TestImportGitVersion_DryRun_PrintsConfigWithoutWriting() {
+	// Precondition: GitVersion.yml (handled by SetupTest)
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	rootCmd.SetOut(stdout)
+	rootCmd.SetErr(stderr)
+
+	// Action: Import with --dry-run
+	rootCmd.SetArgs([]string{"import", "gitversion", "--dry-run"})
+	err := rootCmd.Execute()
+
+	// Expected: Config printed, report on stderr, nothing written
+	suite.Require().NoError(err)
+	suite.Contains(stdout.String(), "prefix: v")
+	suite.Contains(stdout.String(), "- main")
+	suite.Contains(stderr.String(), "Translated:")
+	suite.NoFileExists(".versionator.yaml")
+}
+
+// TestImportGitVersion_ExistingConfig_RequiresForce validates that import does
+// not overwrite an existing configuration by default.
+//
+// Why: .versionator.yaml may hold hand-written settings that the import would
+// lose.
+//
+// What: Import fails while .versionator.yaml exists; with --force it writes a
+// configuration that loads with the translated prefix.
+func (suite *ImportTestSuite) TestImportGitVersion_ExistingConfig_RequiresForce() {
+	// Precondition: An existing configuration
+	suite.Require().NoError(os.WriteFile(".versionator.yaml", []byte("prefix: V\n"), 0644))
+	rootCmd.SetOut(new(bytes.Buffer))
+
+	// Action: Import without --force
+	rootCmd.SetArgs([]string{"import", "gitversion"})
+	err := rootCmd.Execute()
+
+	// Expected: Refused
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "--force")
+
+	// Action: Import with --force
+	rootCmd.SetArgs([]string{"import", "gitversion", "--force"})
+	err = rootCmd.Execute()
+
+	// Expected: Configuration replaced
+	suite.Require().NoError(err)
+	cfg, err := config.ReadConfig()
+	suite.Require().NoError(err)
+	suite.Equal("v", cfg.Prefix)
+	suite.Equal([]string{"main"}, cfg.BranchVersioning.MainBranches)
+}
+
+// TestImportBump2Version_NoArgument_FallsBackToSetupCfg validates the
+// default source lookup.
+//
+// Why: bump2version configuration often lives in setup.cfg rather than a
+// dedicated .bumpversion.cfg; import should find it without an argument.
+//
+// What: With only setup.cfg present, import bump2version reads it and writes
+// the translated prefix.
+func (suite *ImportTestSuite) TestImportBump2Version_NoArgument_FallsBackToSetupCfg() {
+	// Precondition: setup.cfg with a [bumpversion] section, no .bumpversion.cfg
+	setupCfg := "[bumpversion]\ncurrent_version = 1.0.0\ntag_name = v{new_version}\n"
+	suite.Require().NoError(os.WriteFile("setup.cfg", []byte(setupCfg), 0644))
+	out := new(bytes.Buffer)
+	rootCmd.SetOut(out)
+
+	// Action: Import without a path
+	rootCmd.SetArgs([]string{"import", "bump2version"})
+	err := rootCmd.Execute()
+
+	// Expected: setup.cfg translated and written
+	suite.Require().NoError(err)
+	suite.Contains(out.String(), "from setup.cfg")
+	cfg, err := config.ReadConfig()
+	suite.Require().NoError(err)
+	suite.Equal("v", cfg.Prefix)
+}
+
+// TestImportTestSuite runs the import test suite
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
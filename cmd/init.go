@@ -4,8 +4,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/benjaminabbitt/versionator/internal/clierr"
 	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/detect"
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+	"github.com/benjaminabbitt/versionator/internal/preset"
 	"github.com/benjaminabbitt/versionator/internal/vcs"
 	"github.com/benjaminabbitt/versionator/internal/version"
 	"github.com/spf13/cobra"
@@ -16,6 +21,7 @@ var (
 	initPrefix      string
 	initWithConfig  bool
 	initForce       bool
+	initPreset      string
 	hookUninstall   bool
 )
 
@@ -38,7 +44,17 @@ var initCmd = &cobra.Command{
 	Long: `Initialize versionator by creating a VERSION file.
 
 Creates a VERSION file with the specified initial version and prefix.
-Optionally creates a .versionator.yaml configuration file.
+Optionally creates a .versionator.yaml configuration file, with an emit
+target for each language detected in the directory (go.mod, package.json,
+Cargo.toml, pom.xml, ...).
+
+--preset writes a configuration for a common stack instead, with the tag
+pattern, pre-release and metadata templates, emit targets and manifest
+updates its ecosystem expects:
+  go-service       Go service: v-prefixed module tags, dev builds marked with the commit
+  node-lib         npm library: package.json kept in sync, npm-style v tags
+  python-package   Python package: PEP 440 versions in pyproject.toml and _version.py
+  rust-crate       Rust crate: Cargo.toml kept in sync, cargo-release style v tags
 
 Only 'v' or 'V' prefixes are allowed per SemVer convention.
 
@@ -47,6 +63,7 @@ Examples:
   versionator init --version 1.0.0        # Create VERSION with 1.0.0
   versionator init --prefix v             # Create VERSION with v0.0.1
   versionator init --config               # Also create .versionator.yaml
+  versionator init --preset rust-crate    # Create both, configured for a crate
   versionator init --force                # Overwrite existing files`,
 	RunE: runInit,
 }
@@ -59,6 +76,19 @@ func runInit(cmd *cobra.Command, args []string) error {
 	if initPrefix != "" && initPrefix != "v" && initPrefix != "V" {
 		return fmt.Errorf("invalid prefix %q: only 'v' or 'V' allowed per SemVer convention", initPrefix)
 	}
+	if cfg, err := config.ReadConfig(); err == nil && initPrefix != "" && !cfg.PrefixAllowed(initPrefix) {
+		return fmt.Errorf("%s: %q (allowed: %q)", ErrPrefixNotAllowed, initPrefix, cfg.AllowedPrefixes)
+	}
+
+	// A preset implies --config
+	var stack preset.Preset
+	if initPreset != "" {
+		var ok bool
+		if stack, ok = preset.Lookup(initPreset); !ok {
+			return clierr.UnknownChoice(preset.ErrUnknownPreset, initPreset, preset.Names())
+		}
+	}
+	withConfig := initWithConfig || initPreset != ""
 
 	// Check if VERSION exists
 	if _, err := os.Stat(versionPath); err == nil && !initForce {
@@ -66,7 +96,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check if config exists when --config is specified
-	if initWithConfig {
+	if withConfig {
 		if _, err := os.Stat(configPath); err == nil && !initForce {
 			return fmt.Errorf(".versionator.yaml already exists (use --force to overwrite)")
 		}
@@ -76,6 +106,8 @@ func runInit(cmd *cobra.Command, args []string) error {
 	v := version.Parse(initVersion)
 	if initPrefix != "" {
 		v.Prefix = initPrefix
+	} else if initPreset != "" {
+		v.Prefix = stack.Prefix
 	}
 
 	// Validate version
@@ -83,20 +115,40 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid version: %w", err)
 	}
 
+	// Detect languages before writing anything
+	var langs []detect.Language
+	if withConfig && initPreset == "" {
+		cfg, _ := config.ReadConfig()
+		resolved, err := detect.ResolveConfig(".", cfg)
+		if err != nil {
+			return err
+		}
+		langs = resolved
+	}
+
 	// Write VERSION file
 	content := v.FullString() + "\n"
-	if err := os.WriteFile(versionPath, []byte(content), FilePermission); err != nil {
+	if err := fileperm.WriteFile(versionPath, []byte(content)); err != nil {
 		return fmt.Errorf("error writing VERSION file: %w", err)
 	}
 	fmt.Fprintf(cmd.OutOrStdout(), "Created VERSION: %s\n", v.FullString())
 
-	// Write config if requested
-	if initWithConfig {
-		defaultConfig := config.DefaultConfigYAML()
-		if err := os.WriteFile(configPath, []byte(defaultConfig), FilePermission); err != nil {
+	// Write config if requested: the preset, or the defaults with emit
+	// targets for detected languages
+	if withConfig {
+		defaultConfig := config.DefaultConfigYAML() + detect.ScaffoldYAML(langs)
+		if initPreset != "" {
+			defaultConfig = stack.Config
+		}
+		if err := fileperm.WriteFile(configPath, []byte(defaultConfig)); err != nil {
 			return fmt.Errorf("error writing .versionator.yaml: %w", err)
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "Created .versionator.yaml\n")
+		config.Invalidate()
+		if initPreset != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "Created .versionator.yaml (%s preset)\n", initPreset)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "Created .versionator.yaml\n")
+		}
 	}
 
 	return nil
@@ -125,7 +177,7 @@ func runInitHook(cmd *cobra.Command, args []string) error {
 	// Get active VCS
 	activeVCS := vcs.GetActiveVCS()
 	if activeVCS == nil {
-		return fmt.Errorf("not in a git repository")
+		return clierr.New("not in a git repository", clierr.HintNoRepository)
 	}
 
 	hooksPath, err := activeVCS.GetHooksPath()
@@ -185,6 +237,7 @@ func init() {
 	initCmd.Flags().StringVarP(&initPrefix, "prefix", "p", "", "Version prefix ('v' or 'V' only)")
 	initCmd.Flags().BoolVar(&initWithConfig, "config", false, "Also create .versionator.yaml")
 	initCmd.Flags().BoolVarP(&initForce, "force", "f", false, "Overwrite existing files")
+	initCmd.Flags().StringVar(&initPreset, "preset", "", "Create .versionator.yaml for a stack ("+strings.Join(preset.Names(), ", ")+")")
 
 	initHookCmd.Flags().BoolVar(&hookUninstall, "uninstall", false, "Remove the post-commit hook")
 	initHookCmd.Flags().BoolVarP(&initForce, "force", "f", false, "Overwrite existing hook")
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/benjaminabbitt/versionator/internal/config"
 	"github.com/benjaminabbitt/versionator/internal/vcs"
 	gitVCS "github.com/benjaminabbitt/versionator/internal/vcs/git"
 	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
@@ -50,6 +51,7 @@ func (suite *InitTestSuite) TearDownTest() {
 	initPrefix = ""
 	initWithConfig = false
 	initForce = false
+	initPreset = ""
 }
 
 // =============================================================================
@@ -109,6 +111,80 @@ func (suite *InitTestSuite) TestInitCommand_WithConfig_CreatesBothFiles() {
 	suite.Require().NoError(err, "Config file should exist with --config flag")
 }
 
+// TestInitCommand_WithConfig_ScaffoldsDetectedLanguages validates that
+// init --config adds emit targets for the languages it detects.
+//
+// Why: A new user in a Go or Rust repository should get a working
+// 'output emit --all' without looking up format names and paths.
+//
+// What: With go.mod and Cargo.toml present, the config declares go and rust
+// emit targets and still loads.
+func (suite *InitTestSuite) TestInitCommand_WithConfig_ScaffoldsDetectedLanguages() {
+	// Precondition: Go and Rust markers
+	suite.Require().NoError(os.WriteFile("go.mod", []byte("module example.com/app\n"), 0644))
+	suite.Require().NoError(os.WriteFile("Cargo.toml", []byte("[package]\nname = \"app\"\n"), 0644))
+
+	// Action
+	rootCmd.SetArgs([]string{"init", "--config"})
+	err := rootCmd.Execute()
+
+	// Expected: Targets for both languages in a loadable config
+	suite.Require().NoError(err)
+	cfg, err := config.ReadConfig()
+	suite.Require().NoError(err)
+	suite.Equal([]config.EmitTarget{
+		{Name: "go", Format: "go", Output: "version/version.go"},
+		{Name: "rust", Format: "rust", Output: "src/version.rs"},
+	}, cfg.Emit.Targets)
+}
+
+// TestInitCommand_Preset_WritesStackConfig validates init --preset.
+//
+// Why: A new Go service or npm library should get its ecosystem's tags,
+// templates, emit targets and manifest updates in one step.
+//
+// What: --preset go-service writes a v-prefixed VERSION and the preset's
+// config without --config; node-lib writes an unprefixed VERSION and a
+// package.json update; an unknown preset fails before writing anything.
+func (suite *InitTestSuite) TestInitCommand_Preset_WritesStackConfig() {
+	// Action
+	rootCmd.SetArgs([]string{"init", "--preset", "go-service"})
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Require().NoError(err)
+	content, _ := os.ReadFile("VERSION")
+	suite.Equal("v0.0.1", strings.TrimSpace(string(content)))
+	cfg, err := config.ReadConfig()
+	suite.Require().NoError(err)
+	suite.Equal("v{{Version}}", cfg.Release.TagFormat)
+	suite.Equal("internal/version/version.go", cfg.Emit.Targets[0].Output)
+
+	// Action: Another stack over the first
+	rootCmd.SetArgs([]string{"init", "--preset", "node-lib", "--force"})
+	err = rootCmd.Execute()
+
+	// Expected
+	suite.Require().NoError(err)
+	content, _ = os.ReadFile("VERSION")
+	suite.Equal("0.0.1", strings.TrimSpace(string(content)))
+	cfg, err = config.ReadConfig()
+	suite.Require().NoError(err)
+	suite.Require().Len(cfg.Updates, 1)
+	suite.Equal("package.json", cfg.Updates[0].File)
+
+	// Action: An unknown preset in a fresh directory
+	suite.Require().NoError(os.Chdir(suite.T().TempDir()))
+	rootCmd.SetArgs([]string{"init", "--preset", "go-servic"})
+	err = rootCmd.Execute()
+
+	// Expected
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "go-service")
+	_, statErr := os.Stat("VERSION")
+	suite.True(os.IsNotExist(statErr), "VERSION should not be written")
+}
+
 // =============================================================================
 // KEY VARIATIONS - Important alternate flows for init customization
 // =============================================================================
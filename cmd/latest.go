@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/benjaminabbitt/versionator/internal/clierr"
+	"github.com/benjaminabbitt/versionator/internal/commitparser"
+	"github.com/benjaminabbitt/versionator/internal/history"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+
+	"github.com/spf13/cobra"
+)
+
+var latestCmd = &cobra.Command{
+	Use:   "latest",
+	Short: "Show the highest released and pre-release versions from tags",
+	Long: `Scan every tag in the repository and report the highest released
+version and the highest pre-release version by SemVer precedence.
+
+Unlike the version derived from the nearest tag, this considers all tags,
+including those on other branches. Tags that do not parse as versions are
+ignored. Use --branch to only consider tags reachable from one branch.
+
+Examples:
+  versionator latest                     # Across all branches
+  versionator latest --branch=main       # Only tags on main
+  versionator latest --branch=release/1.x`,
+	Args: cobra.NoArgs,
+	RunE: runLatest,
+}
+
+func init() {
+	rootCmd.AddCommand(latestCmd)
+
+	latestCmd.Flags().StringP("branch", "b", "", "Only consider tags reachable from this branch")
+}
+
+func runLatest(cmd *cobra.Command, args []string) error {
+	activeVCS := vcs.GetActiveVCS()
+	if activeVCS == nil {
+		return clierr.NoRepository(commitparser.ErrNoVCSDetected)
+	}
+
+	lister, ok := activeVCS.(vcs.TagLister)
+	if !ok {
+		return fmt.Errorf("%s does not support listing tags", activeVCS.Name())
+	}
+
+	tags, err := lister.ListTags()
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	branchName, _ := cmd.Flags().GetString("branch")
+	release, prerelease := history.Latest(history.Build(tags), branchName)
+
+	cmd.Printf("Latest release:     %s\n", describeLatest(release))
+	cmd.Printf("Latest pre-release: %s\n", describeLatest(prerelease))
+	return nil
+}
+
+// describeLatest formats a latest entry as "tag (version)" or "none"
+func describeLatest(e *history.Entry) string {
+	if e == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%s (%s)", e.Tag, e.Version)
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	gitVCS "github.com/benjaminabbitt/versionator/internal/vcs/git"
+	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+// LatestTestSuite defines the test suite for the latest command.
+// The latest command reports the highest versions found across all tags.
+type LatestTestSuite struct {
+	suite.Suite
+	ctrl    *gomock.Controller
+	origDir string
+}
+
+// SetupTest runs before each test
+func (suite *LatestTestSuite) SetupTest() {
+	var err error
+	suite.origDir, err = os.Getwd()
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.Chdir(suite.T().TempDir()))
+
+	suite.ctrl = gomock.NewController(suite.T())
+
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	_ = latestCmd.Flags().Set("branch", "")
+
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(&tagListingVCS{
+		MockVersionControlSystem: mockVCS,
+		tags: []vcs.TagRef{
+			{Name: "v1.10.0", Branches: []string{"main"}},
+			{Name: "v2.0.0-rc.1", Branches: []string{"next"}},
+			{Name: "v1.9.1", Branches: []string{"release/1.9"}},
+		},
+	})
+}
+
+// TearDownTest runs after each test
+func (suite *LatestTestSuite) TearDownTest() {
+	if suite.origDir != "" {
+		_ = os.Chdir(suite.origDir)
+	}
+	if suite.ctrl != nil {
+		suite.ctrl.Finish()
+	}
+
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(gitVCS.NewGitVCSDefault())
+}
+
+// TestLatestCommand_AllBranches_PrintsHighestVersions validates that latest
+// reports the highest release and pre-release across every branch.
+func (suite *LatestTestSuite) TestLatestCommand_AllBranches_PrintsHighestVersions() {
+	// Precondition: Tags on main, next, and a maintenance branch (see SetupTest)
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"latest"})
+
+	// Action: Run latest
+	err := rootCmd.Execute()
+
+	// Expected: v1.10.0 is the latest release despite v1.9.1 being tagged later
+	suite.Require().NoError(err)
+	suite.Contains(buf.String(), "Latest release:     v1.10.0 (1.10.0)")
+	suite.Contains(buf.String(), "Latest pre-release: v2.0.0-rc.1 (2.0.0-rc.1)")
+}
+
+// TestLatestCommand_BranchScope_LimitsToBranch validates --branch scoping.
+func (suite *LatestTestSuite) TestLatestCommand_BranchScope_LimitsToBranch() {
+	// Precondition: Maintenance branch has only v1.9.1
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"latest", "--branch", "release/1.9"})
+
+	// Action: Run latest scoped to the maintenance branch
+	err := rootCmd.Execute()
+
+	// Expected: v1.9.1 and no pre-release
+	suite.Require().NoError(err)
+	suite.Contains(buf.String(), "Latest release:     v1.9.1 (1.9.1)")
+	suite.Contains(buf.String(), "Latest pre-release: none")
+}
+
+func TestLatestTestSuite(t *testing.T) {
+	suite.Run(t, new(LatestTestSuite))
+}
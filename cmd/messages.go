@@ -2,18 +2,26 @@
 // Exported so tests can compare against them
 package cmd
 
-import "os"
-
-// File permission constants
-const (
-	// FilePermission is the default permission for created files (owner rw, group/other r)
-	FilePermission os.FileMode = 0644
-)
-
 // Error messages
 const (
-	ErrLoadingVersion    = "error loading version"
-	ErrCustomKeyNotFound = "custom key not found"
+	ErrLoadingVersion        = "error loading version"
+	ErrCustomKeyNotFound     = "custom key not found"
+	ErrPrefixNotAllowed      = "prefix not in allowedPrefixes"
+	ErrNotMonotonic          = "version is not greater than the highest tag"
+	ErrTagConflictUnresolved = "no free tag name found"
+	ErrComponentDynamic      = "component is dynamic (stable: false)"
+	ErrNoAllowedSigners      = "no allowed signers configured"
+	ErrSignerNotAllowed      = "tag signer is not allowed"
+	ErrTagVersionMismatch    = "tag does not match VERSION at the tagged commit"
+	ErrNotReleaseCandidate   = "VERSION is not a release candidate"
+	ErrCandidateNotTagged    = "release candidate is not tagged"
+	ErrCommitsSinceCandidate = "commits were added since the release candidate was tagged"
+	ErrStampChecksFailed     = "binary failed release checks"
+	ErrDoctorChecksFailed    = "workspace checks failed"
+	ErrDetachedHead          = "HEAD is detached"
+	ErrReleaseBranchExists   = "release branch already exists"
+	ErrServeNoToken          = "no serve token set"
+	ErrServeDirty            = "working directory is not clean; refusing to bump"
 )
 
 // Log messages for structured logging
@@ -87,14 +87,14 @@ func runMetadataStable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error writing config: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Metadata stable set to: %t\n", cfg.Metadata.Stable)
+	newConsole(cmd).Successf("Metadata stable set to: %t", cfg.Metadata.Stable)
 
 	// If switching to stable=false, clear metadata from VERSION file
 	if !cfg.Metadata.Stable {
 		if err := version.SetMetadata(""); err != nil {
 			return fmt.Errorf("error clearing metadata from VERSION: %w", err)
 		}
-		fmt.Fprintln(cmd.OutOrStdout(), "Metadata cleared from VERSION file (will be generated at output time)")
+		newConsole(cmd).Successf("Metadata cleared from VERSION file (will be generated at output time)")
 	}
 
 	return nil
@@ -144,21 +144,7 @@ func runMetadataEnable(cmd *cobra.Command, args []string) error {
 
 	// If no template or render failed, use git hash as default
 	if metadata == "" {
-		gitVCS := vcs.GetVCS("git")
-		hashLength := 7
-		if cfg.Metadata.Git.HashLength > 0 {
-			hashLength = cfg.Metadata.Git.HashLength
-		}
-		if gitVCS != nil && gitVCS.IsRepository() {
-			if hash, err := gitVCS.GetVCSIdentifier(hashLength); err == nil {
-				metadata = hash
-			}
-		}
-	}
-
-	// If still no metadata, use a default
-	if metadata == "" {
-		metadata = "build"
+		metadata = defaultMetadataValue(cfg)
 	}
 
 	// Set metadata in VERSION file
@@ -166,7 +152,7 @@ func runMetadataEnable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error setting metadata: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Metadata enabled with value '%s'\n", metadata)
+	newConsole(cmd).Successf("Metadata enabled with value '%s'", metadata)
 
 	// Show current version
 	vd, err = version.Load()
@@ -174,10 +160,26 @@ func runMetadataEnable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error getting version: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Current version: %s\n", vd.FullString())
+	newConsole(cmd).Infof("Current version: %s", vd.FullString())
 	return nil
 }
 
+// defaultMetadataValue returns the metadata enable writes when no template
+// is configured: the git hash, or "build" outside a git repository
+func defaultMetadataValue(cfg *config.Config) string {
+	gitVCS := vcs.GetVCS("git")
+	hashLength := 7
+	if cfg.Metadata.Git.HashLength > 0 {
+		hashLength = cfg.Metadata.Git.HashLength
+	}
+	if gitVCS != nil && gitVCS.IsRepository() {
+		if hash, err := gitVCS.GetVCSIdentifier(hashLength); err == nil {
+			return hash
+		}
+	}
+	return "build"
+}
+
 var metadataDisableCmd = &cobra.Command{
 	Use:   "disable",
 	Short: "Disable build metadata (requires stable: true)",
@@ -206,7 +208,7 @@ func runMetadataDisable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error clearing metadata: %w", err)
 	}
 
-	fmt.Fprintln(cmd.OutOrStdout(), "Metadata disabled")
+	newConsole(cmd).Successf("Metadata disabled")
 
 	// Show current version without metadata
 	vd, err := version.Load()
@@ -214,7 +216,7 @@ func runMetadataDisable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error getting version: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Current version: %s\n", vd.FullString())
+	newConsole(cmd).Infof("Current version: %s", vd.FullString())
 	return nil
 }
 
@@ -335,18 +337,18 @@ func runMetadataSet(cmd *cobra.Command, args []string) error {
 		if err := version.SetMetadata(value); err != nil {
 			return fmt.Errorf("error setting metadata: %w", err)
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "Metadata set to: %s\n", value)
+		newConsole(cmd).Successf("Metadata set to: %s", value)
 
 		// Show current version with metadata
 		vd, err := version.Load()
 		if err != nil {
 			return fmt.Errorf("error getting version: %w", err)
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "Current version: %s\n", vd.FullString())
+		newConsole(cmd).Infof("Current version: %s", vd.FullString())
 	} else {
 		// --force was used: set template to literal, don't write to VERSION
-		fmt.Fprintf(cmd.OutOrStdout(), "Metadata template set to literal: %s\n", value)
-		fmt.Fprintln(cmd.OutOrStdout(), "(Value will be used at output time, not stored in VERSION file)")
+		newConsole(cmd).Successf("Metadata template set to literal: %s", value)
+		newConsole(cmd).Infof("(Value will be used at output time, not stored in VERSION file)")
 	}
 
 	return nil
@@ -379,7 +381,7 @@ func runMetadataClear(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error clearing metadata: %w", err)
 	}
 
-	fmt.Fprintln(cmd.OutOrStdout(), "Metadata cleared")
+	newConsole(cmd).Successf("Metadata cleared")
 
 	// Show current version without metadata
 	vd, err := version.Load()
@@ -387,7 +389,7 @@ func runMetadataClear(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error getting version: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Current version: %s\n", vd.FullString())
+	newConsole(cmd).Infof("Current version: %s", vd.FullString())
 	return nil
 }
 
@@ -407,7 +409,7 @@ The leading plus (+) is added automatically - do NOT include it in your template
 The template uses Mustache syntax. Available variables:
   {{ShortHash}}            - Short git commit hash, 7 chars (e.g., "abc1234")
   {{MediumHash}}           - Medium git commit hash, 12 chars (e.g., "abc1234def01")
-  {{Hash}}                 - Full git commit hash (40 chars)
+  {{Hash}}                 - Full git commit hash (40 chars, 64 for SHA-256 repos)
   {{BranchName}}           - Current branch name
   {{EscapedBranchName}}    - Branch name with / replaced by -
   {{CommitsSinceTag}}      - Commits since last tag
@@ -442,4 +444,11 @@ func init() {
 
 	// Add --force flag to set command
 	metadataSetCmd.Flags().BoolVarP(&metadataForceFlag, "force", "f", false, "Force set on dynamic mode (sets template to literal value)")
+
+	// Values are managed by 'component'; these remain as aliases
+	markDeprecated(metadataEnableCmd, "component metadata enable")
+	markDeprecated(metadataDisableCmd, "component metadata disable")
+	markDeprecated(metadataStatusCmd, "component metadata status")
+	markDeprecated(metadataSetCmd, "component metadata set")
+	markDeprecated(metadataClearCmd, "component metadata disable")
 }
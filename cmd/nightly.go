@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/offline"
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+// nightlyDateFormat is the date identifier in nightly pre-releases
+const nightlyDateFormat = "20060102"
+
+var nightlyCmd = &cobra.Command{
+	Use:   "nightly",
+	Short: "Print (and optionally tag) the nightly build version",
+	Long: `Print the nightly version for scheduled builds: the next minor version
+with a "nightly.<date>" pre-release and the short commit hash as metadata.
+
+  VERSION 1.2.3, built 2024-01-15 at abc1234 -> 1.3.0-nightly.20240115+abc1234
+
+The version depends only on VERSION, the UTC build date, and HEAD, so every
+run of the same build on the same day produces the same version. VERSION is
+not modified. With --tag, the commit is tagged "nightly/<version>" (without
+metadata); re-running on the same commit is a no-op.
+
+Examples:
+  versionator nightly                       # Print the version
+  versionator nightly --date=20240115       # Pin the build date
+  versionator nightly --tag --push          # Tag and push nightly/1.3.0-nightly.20240115`,
+	Args: cobra.NoArgs,
+	RunE: runNightly,
+}
+
+func init() {
+	rootCmd.AddCommand(nightlyCmd)
+
+	nightlyCmd.Flags().String("date", "", "Build date as YYYYMMDD (default: today, UTC)")
+	nightlyCmd.Flags().Bool("tag", false, "Tag HEAD with nightly/<version>")
+	nightlyCmd.Flags().Bool("push", false, "Push the nightly tag (implies --tag)")
+	nightlyCmd.Flags().String("tag-prefix", "nightly/", "Prefix of the nightly tag ref")
+}
+
+// nightlyVersion returns the nightly version derived from current: the next
+// minor with a nightly.<date> pre-release and hash as metadata
+func nightlyVersion(current *version.Version, date time.Time, hash string) *version.Version {
+	next := *current
+	next.IncrementMinor()
+	next.PreRelease = "nightly." + date.UTC().Format(nightlyDateFormat)
+	next.BuildMetadata = hash
+	return &next
+}
+
+func runNightly(cmd *cobra.Command, args []string) error {
+	current, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+
+	date := time.Now().UTC()
+	if value, _ := cmd.Flags().GetString("date"); value != "" {
+		if date, err = time.Parse(nightlyDateFormat, value); err != nil {
+			return fmt.Errorf("invalid --date %q: expected YYYYMMDD", value)
+		}
+	}
+
+	push, _ := cmd.Flags().GetBool("push")
+	tag, _ := cmd.Flags().GetBool("tag")
+	tag = tag || push
+
+	activeVCS := vcs.GetActiveVCS()
+	hash := ""
+	if activeVCS != nil {
+		if id, err := activeVCS.GetVCSIdentifier(7); err == nil {
+			hash = id
+		}
+	} else if tag {
+		return fmt.Errorf("not in a version control repository")
+	}
+
+	if push {
+		if err := offline.Require("nightly --push"); err != nil {
+			return err
+		}
+		if err := vcs.RequireCapability(activeVCS, vcs.CapabilityPush); err != nil {
+			return err
+		}
+	}
+	if tag {
+		if err := vcs.RequireCapability(activeVCS, vcs.CapabilityTags); err != nil {
+			return err
+		}
+	}
+
+	nightly := nightlyVersion(current, date, hash)
+	fmt.Fprintln(cmd.OutOrStdout(), nightly.String())
+	if !tag {
+		return nil
+	}
+
+	tagPrefix, _ := cmd.Flags().GetString("tag-prefix")
+	tagName := tagformat.Namespaced(tagPrefix + nightly.SemVer())
+	created, err := createNightlyTag(activeVCS, tagName)
+	if err != nil {
+		return err
+	}
+	if created {
+		cmd.PrintErrf("Created tag '%s'\n", tagName)
+	} else {
+		cmd.PrintErrf("Tag '%s' already at HEAD\n", tagName)
+	}
+
+	if push {
+		if err := activeVCS.PushTag(tagName); err != nil {
+			return fmt.Errorf("failed to push tag: %w", err)
+		}
+		cmd.PrintErrf("Pushed tag '%s'\n", tagName)
+	}
+	return nil
+}
+
+// createNightlyTag tags HEAD, reporting false when the tag already points
+// there; a tag on another commit is an error
+func createNightlyTag(activeVCS vcs.VersionControlSystem, tagName string) (bool, error) {
+	exists, err := activeVCS.TagExists(tagName)
+	if err != nil {
+		return false, fmt.Errorf("error checking if tag exists: %w", err)
+	}
+	if exists {
+		head, err := activeVCS.GetVCSIdentifier(vcs.MaxIdentifierLength(activeVCS))
+		if err != nil {
+			return false, fmt.Errorf("error reading HEAD: %w", err)
+		}
+		tagged, err := activeVCS.GetTagCommit(tagName)
+		if err != nil {
+			return false, fmt.Errorf("error resolving existing tag %q: %w", tagName, err)
+		}
+		if tagged != head {
+			return false, fmt.Errorf("tag '%s' already exists at %s (not HEAD %s). Use --date to build another nightly",
+				tagName, tagged[:min(7, len(tagged))], head[:min(7, len(head))])
+		}
+		return false, nil
+	}
+
+	if err := activeVCS.CreateTag(tagName, "Nightly "+tagName); err != nil {
+		return false, fmt.Errorf("error creating tag: %w", err)
+	}
+	return true, nil
+}
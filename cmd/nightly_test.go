@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/offline"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	gitVCS "github.com/benjaminabbitt/versionator/internal/vcs/git"
+	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+// NightlyTestSuite defines the test suite for the nightly command.
+// The nightly command derives a dated pre-release of the next minor version
+// for scheduled builds and optionally tags it.
+type NightlyTestSuite struct {
+	suite.Suite
+	ctrl    *gomock.Controller
+	mockVCS *mock.MockVersionControlSystem
+	origDir string
+}
+
+// SetupTest runs before each test
+func (suite *NightlyTestSuite) SetupTest() {
+	var err error
+	suite.origDir, err = os.Getwd()
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.Chdir(suite.T().TempDir()))
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.2.3-rc.1\n"), 0644))
+
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockVCS = mock.NewMockVersionControlSystem(suite.ctrl)
+	suite.mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	suite.mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	suite.mockVCS.EXPECT().GetRepositoryRoot().Return(".", nil).AnyTimes()
+	suite.mockVCS.EXPECT().GetVCSIdentifier(7).Return("abc1234", nil).AnyTimes()
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(suite.mockVCS)
+}
+
+// TearDownTest runs after each test
+func (suite *NightlyTestSuite) TearDownTest() {
+	if suite.origDir != "" {
+		_ = os.Chdir(suite.origDir)
+	}
+	if suite.ctrl != nil {
+		suite.ctrl.Finish()
+	}
+
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	_ = nightlyCmd.Flags().Set("date", "")
+	_ = nightlyCmd.Flags().Set("tag", "false")
+	_ = nightlyCmd.Flags().Set("push", "false")
+	_ = rootCmd.PersistentFlags().Set("offline", "false")
+	offline.Set(false)
+
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(gitVCS.NewGitVCSDefault())
+}
+
+// TestNightly_PinnedDate_PrintsNextMinorNightly validates the nightly version.
+//
+// Why: Scheduled pipelines need a version that sorts after the current
+// release line and is identical for every run of the same build on the same
+// day.
+//
+// What: VERSION 1.2.3-rc.1 on 2024-01-15 at abc1234 prints
+// 1.3.0-nightly.20240115+abc1234, and VERSION is left unchanged.
+func (suite *NightlyTestSuite) TestNightly_PinnedDate_PrintsNextMinorNightly() {
+	// Precondition: VERSION and HEAD (see SetupTest)
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+
+	// Action: Run nightly with a pinned date
+	rootCmd.SetArgs([]string{"nightly", "--date=20240115"})
+	err := rootCmd.Execute()
+
+	// Expected: Next minor nightly; VERSION untouched
+	suite.Require().NoError(err)
+	suite.Equal("1.3.0-nightly.20240115+abc1234\n", out.String())
+	data, err := os.ReadFile("VERSION")
+	suite.Require().NoError(err)
+	suite.Equal("1.2.3-rc.1\n", string(data))
+}
+
+// TestNightly_Tag_CreatesNightlyRefOnce validates --tag.
+//
+// Why: Re-running a scheduled job on the same commit must not fail, while a
+// clashing tag on another commit must not be silently reused.
+//
+// What: --tag creates nightly/1.3.0-nightly.20240115 without metadata; when
+// that tag already points at HEAD, no tag is created; when it points
+// elsewhere, the command fails.
+func (suite *NightlyTestSuite) TestNightly_Tag_CreatesNightlyRefOnce() {
+	// Precondition: No nightly tag yet
+	tagName := "nightly/1.3.0-nightly.20240115"
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	gomock.InOrder(
+		suite.mockVCS.EXPECT().TagExists(tagName).Return(false, nil),
+		suite.mockVCS.EXPECT().CreateTag(tagName, "Nightly "+tagName).Return(nil),
+	)
+
+	// Action: Tag
+	rootCmd.SetArgs([]string{"nightly", "--date=20240115", "--tag"})
+	err := rootCmd.Execute()
+
+	// Expected: Tag created
+	suite.Require().NoError(err)
+
+	// Precondition: Tag already at HEAD
+	suite.mockVCS.EXPECT().TagExists(tagName).Return(true, nil).Times(2)
+	suite.mockVCS.EXPECT().GetVCSIdentifier(vcs.DefaultIdentifierLength).Return("abc1234def", nil).Times(2)
+	gomock.InOrder(
+		suite.mockVCS.EXPECT().GetTagCommit(tagName).Return("abc1234def", nil),
+		suite.mockVCS.EXPECT().GetTagCommit(tagName).Return("fff0000aaa", nil),
+	)
+
+	// Action: Tag again
+	err = rootCmd.Execute()
+
+	// Expected: No-op
+	suite.Require().NoError(err)
+
+	// Action: Tag again after the tag moved elsewhere
+	err = rootCmd.Execute()
+
+	// Expected: Refused
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "already exists")
+}
+
+// noPushVCS wraps the generated mock with a CapabilityReporter for a backend
+// that can tag but not push
+type noPushVCS struct {
+	*mock.MockVersionControlSystem
+}
+
+func (v *noPushVCS) Capabilities() []vcs.Capability {
+	return []vcs.Capability{vcs.CapabilityTags, vcs.CapabilityBranches}
+}
+
+// TestNightly_PushUnsupported_FailsBeforeTagging validates that a missing
+// VCS capability is reported before any tag is created.
+//
+// Why: Failing deep inside the push would leave a local nightly tag behind
+// and an unhelpful backend error.
+//
+// What: With a backend that cannot push, --push fails naming the missing
+// feature, and no tag is created (the mock expects no CreateTag).
+func (suite *NightlyTestSuite) TestNightly_PushUnsupported_FailsBeforeTagging() {
+	// Precondition: Backend without push support
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(&noPushVCS{MockVersionControlSystem: suite.mockVCS})
+
+	// Action: Run nightly with --push
+	rootCmd.SetArgs([]string{"nightly", "--date=20240115", "--push"})
+	err := rootCmd.Execute()
+
+	// Expected: Capability error; no tag operations
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "git does not support pushing to a remote")
+}
+
+// TestNightly_Offline_PushFailsBeforeTagging validates --offline.
+//
+// Why: Hermetic builds must be sure nothing reaches the network, and must
+// learn so before a local tag is left behind.
+//
+// What: --offline with --push fails naming offline mode, and no tag is
+// created (the mock expects no TagExists or CreateTag).
+func (suite *NightlyTestSuite) TestNightly_Offline_PushFailsBeforeTagging() {
+	// Precondition: VERSION and HEAD (see SetupTest)
+
+	// Action: Run nightly with --push while offline
+	rootCmd.SetArgs([]string{"--offline", "nightly", "--date=20240115", "--push"})
+	err := rootCmd.Execute()
+
+	// Expected: Offline error; no tag operations
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), offline.ErrNetworkDisabled)
+}
+
+// TestNightlyTestSuite runs the nightly test suite
+func TestNightlyTestSuite(t *testing.T) {
+	suite.Run(t, new(NightlyTestSuite))
+}
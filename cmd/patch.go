@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/benjaminabbitt/versionator/internal/audit"
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/detect"
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/plan"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+var patchAuto bool
+
+var patchCmd = &cobra.Command{
+	Use:   "patch",
+	Short: "Write the current version into manifest files",
+	Long: `Apply the 'updates' in .versionator.yaml now, writing the current version
+into the files they name. bump, set and release do this automatically; patch
+is for files added or edited since.
+
+With --auto, the manifest of each language detected in the repository is
+patched too, unless an update already covers it:
+
+  js, ts   package.json (version)
+  rust     Cargo.toml (package.version)
+  python   pyproject.toml (project.version or tool.poetry.version)
+
+Set 'languages' in .versionator.yaml to replace detection.
+
+--plan json prints the values that would be written, with their current
+values, for review; --apply-plan writes them, refusing if any has changed.
+
+Examples:
+  versionator patch          # Apply configured updates
+  versionator patch --auto   # Also patch detected manifests
+  versionator patch --auto --plan json > plan.json
+  versionator patch --apply-plan plan.json`,
+	Args: cobra.NoArgs,
+	RunE: runPatch,
+}
+
+func init() {
+	rootCmd.AddCommand(patchCmd)
+
+	patchCmd.Flags().BoolVar(&patchAuto, "auto", false, "Also patch the manifests of detected languages")
+	addPlanFlags(patchCmd.Flags())
+}
+
+func runPatch(cmd *cobra.Command, args []string) error {
+	if handled, err := applyPlanFlag(cmd); handled {
+		return err
+	}
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("error reading config: %w", err)
+	}
+	if len(cfg.Updates) == 0 && !patchAuto {
+		return fmt.Errorf("no updates configured (add updates to .versionator.yaml, or use --auto)")
+	}
+	if handled, err := writePlanFlag(cmd, func() (*plan.Plan, error) {
+		return planPatch(cmd, cfg)
+	}); handled {
+		return err
+	}
+
+	if err := runConfiguredUpdates(cmd); err != nil {
+		return err
+	}
+	if !patchAuto {
+		return nil
+	}
+
+	v, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+	auditor := audit.NewAuditorDefault(cfg.Updates)
+	drifted, err := detectedDrift(cmd, cfg, auditor, v)
+	if err != nil {
+		return err
+	}
+
+	fixed, err := auditor.Fix(drifted)
+	for _, f := range fixed {
+		newConsole(cmd).Successf("Patched %s: %s -> %s", describeSource(f), f.Value, f.Expected)
+	}
+	return err
+}
+
+// planPatch describes the values patch would write: the configured updates
+// and, with --auto, the drifted manifests of detected languages. VERSION
+// stays as it is.
+func planPatch(cmd *cobra.Command, cfg *config.Config) (*plan.Plan, error) {
+	v, err := version.Load()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+	p, err := buildVersionPlan(cmd, v, v, nil, false)
+	if err != nil || !patchAuto {
+		return p, err
+	}
+
+	drifted, err := detectedDrift(cmd, cfg, audit.NewAuditorDefault(cfg.Updates), v)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range drifted {
+		p.Files = append(p.Files, plan.FileChange{
+			File: f.Fix.File, Path: f.Fix.Path, Format: f.Fix.Format,
+			Old: f.Value, New: f.Expected,
+		})
+	}
+	return p, nil
+}
+
+// detectedDrift returns the findings for manifests of detected languages
+// whose version differs from v and can be patched, warning about those that
+// cannot
+func detectedDrift(cmd *cobra.Command, cfg *config.Config, auditor *audit.Auditor, v *version.Version) ([]audit.Finding, error) {
+	langs, err := detect.ResolveConfig(".", cfg)
+	if err != nil {
+		return nil, err
+	}
+	manifests := detect.Manifests(langs)
+	data := emit.BuildCompleteTemplateData(v, cfg.PreRelease.Template, cfg.Metadata.Template)
+
+	var drifted []audit.Finding
+	for _, f := range auditor.Audit(v, data) {
+		if !slices.Contains(manifests, f.Source) {
+			continue
+		}
+		switch {
+		case f.Status == audit.StatusError:
+			return nil, fmt.Errorf("%s: %w", describeSource(f), f.Err)
+		case f.Status == audit.StatusDrift && f.Fix == nil:
+			newConsole(cmd).Warnf("%s: %s", describeSource(f), audit.ErrNotFixable)
+		case f.Status == audit.StatusDrift:
+			drifted = append(drifted, f)
+		}
+	}
+	return drifted, nil
+}
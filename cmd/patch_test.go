@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/plan"
+	"github.com/stretchr/testify/suite"
+)
+
+// PatchTestSuite defines the test suite for the patch command.
+type PatchTestSuite struct {
+	suite.Suite
+	out bytes.Buffer
+}
+
+// SetupTest runs before each test
+func (suite *PatchTestSuite) SetupTest() {
+	suite.T().Chdir(suite.T().TempDir())
+	suite.out.Reset()
+	rootCmd.SetOut(&suite.out)
+	rootCmd.SetErr(&suite.out)
+}
+
+// TearDownTest runs after each test
+func (suite *PatchTestSuite) TearDownTest() {
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	patchAuto = false
+	_ = patchCmd.Flags().Set("auto", "false")
+	_ = patchCmd.Flags().Set("plan", "")
+	_ = patchCmd.Flags().Set("apply-plan", "")
+}
+
+// TestPatch_NothingConfigured_ReturnsError validates plain patch without
+// updates.
+//
+// Why: Silently doing nothing would hide a missing updates section.
+//
+// What: With no updates and no --auto, the command errors.
+func (suite *PatchTestSuite) TestPatch_NothingConfigured_ReturnsError() {
+	// Precondition
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+
+	// Action
+	rootCmd.SetArgs([]string{"patch"})
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "no updates configured")
+}
+
+// TestPatch_Auto_PatchesDetectedManifest validates patch --auto.
+//
+// Why: A JavaScript project should get package.json in step with VERSION
+// without writing an updates entry.
+//
+// What: A stale package.json is rewritten to VERSION and reported.
+func (suite *PatchTestSuite) TestPatch_Auto_PatchesDetectedManifest() {
+	// Precondition
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+	suite.Require().NoError(os.WriteFile("package.json", []byte(`{"version": "1.2.0"}`), 0644))
+
+	// Action
+	rootCmd.SetArgs([]string{"patch", "--auto"})
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Require().NoError(err)
+	suite.Contains(suite.out.String(), "Patched package.json (version): 1.2.0 -> 1.2.3")
+	content, err := os.ReadFile("package.json")
+	suite.Require().NoError(err)
+	suite.Contains(string(content), `"1.2.3"`)
+}
+
+// TestPatch_JavaSnapshot_QualifiesMavenUpdates validates java.snapshot for
+// configured updates.
+//
+// Why: java.snapshot marks development builds for Maven and Gradle; a
+// pom.xml patched with the plain version would look like a release.
+//
+// What: With java.snapshot always, patch writes 1.2.3-SNAPSHOT to pom.xml
+// and gradle.properties, and 1.2.3 to app.json.
+func (suite *PatchTestSuite) TestPatch_JavaSnapshot_QualifiesMavenUpdates() {
+	// Precondition
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+	suite.Require().NoError(os.WriteFile("pom.xml", []byte("<project><version>1.2.0</version></project>\n"), 0644))
+	suite.Require().NoError(os.WriteFile("gradle.properties", []byte("version=1.2.0\n"), 0644))
+	suite.Require().NoError(os.WriteFile("app.json", []byte(`{"release": "1.2.0"}`), 0644))
+	suite.Require().NoError(os.WriteFile(".versionator.yaml", []byte(`java:
+  snapshot: always
+updates:
+  - file: pom.xml
+    path: project.version
+    template: "{{MajorMinorPatch}}{{PreReleaseWithDash}}"
+  - file: gradle.properties
+    path: version
+    template: "{{MajorMinorPatch}}{{PreReleaseWithDash}}"
+  - file: app.json
+    path: release
+    template: "{{MajorMinorPatch}}{{PreReleaseWithDash}}"
+`), 0644))
+
+	// Action
+	rootCmd.SetArgs([]string{"patch"})
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Require().NoError(err)
+	pom, _ := os.ReadFile("pom.xml")
+	suite.Equal("<project><version>1.2.3-SNAPSHOT</version></project>\n", string(pom))
+	properties, _ := os.ReadFile("gradle.properties")
+	suite.Equal("version=1.2.3-SNAPSHOT\n", string(properties))
+	app, _ := os.ReadFile("app.json")
+	suite.Contains(string(app), `"1.2.3"`)
+}
+
+// TestPatch_PlanAndApply_ListsFileValues validates patch --plan and
+// --apply-plan.
+//
+// Why: Reviewers approve the values patch writes; what is applied later must
+// be exactly those, with the values they replace.
+//
+// What: 'patch --auto --plan json' lists the configured update and the
+// detected package.json with their old and new values, writing nothing;
+// applying the plan writes both and leaves VERSION alone.
+func (suite *PatchTestSuite) TestPatch_PlanAndApply_ListsFileValues() {
+	// Precondition: A configured update and a detected manifest, both stale
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+	suite.Require().NoError(os.WriteFile("package.json", []byte(`{"version": "1.2.0"}`), 0644))
+	suite.Require().NoError(os.WriteFile("app.json", []byte(`{"release": "1.1.0"}`), 0644))
+	suite.Require().NoError(os.WriteFile(".versionator.yaml", []byte(`updates:
+  - file: app.json
+    path: release
+    template: "{{MajorMinorPatch}}"
+`), 0644))
+
+	// Action: Plan
+	rootCmd.SetArgs([]string{"patch", "--auto", "--plan", "json"})
+	err := rootCmd.Execute()
+
+	// Expected: Both values listed, nothing written
+	suite.Require().NoError(err)
+	var p plan.Plan
+	suite.Require().NoError(json.Unmarshal(suite.out.Bytes(), &p), "output should be a JSON plan: %s", suite.out.String())
+	suite.Equal("patch", p.Command)
+	suite.Equal(plan.VersionChange{File: "VERSION", Old: "1.2.3", New: "1.2.3"}, p.Version)
+	suite.Equal([]plan.FileChange{
+		{File: "app.json", Path: "release", Old: "1.1.0", New: "1.2.3"},
+		{File: "package.json", Path: "version", Format: "json", Old: "1.2.0", New: "1.2.3"},
+	}, p.Files)
+	suite.Nil(p.Commit)
+	content, _ := os.ReadFile("package.json")
+	suite.Contains(string(content), `"1.2.0"`)
+	suite.Require().NoError(os.WriteFile("plan.json", suite.out.Bytes(), 0644))
+
+	// Action: Apply
+	suite.TearDownTest()
+	suite.out.Reset()
+	rootCmd.SetOut(&suite.out)
+	rootCmd.SetErr(&suite.out)
+	rootCmd.SetArgs([]string{"patch", "--apply-plan", "plan.json"})
+	err = rootCmd.Execute()
+
+	// Expected: Both files written, VERSION unchanged
+	suite.Require().NoError(err)
+	content, _ = os.ReadFile("package.json")
+	suite.Contains(string(content), `"1.2.3"`)
+	content, _ = os.ReadFile("app.json")
+	suite.Contains(string(content), `"1.2.3"`)
+	content, _ = os.ReadFile("VERSION")
+	suite.Equal("1.2.3\n", string(content))
+}
+
+// TestPatch_ApplyPlan_StaleValue_Refuses validates stale patch plans.
+//
+// Why: Overwriting a value someone changed after the review would discard
+// their edit unseen.
+//
+// What: When package.json no longer holds the planned old value, applying
+// fails with ErrStaleFile and the file is left as it is.
+func (suite *PatchTestSuite) TestPatch_ApplyPlan_StaleValue_Refuses() {
+	// Precondition: The plan expects 1.2.0; package.json now holds 1.2.1
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+	suite.Require().NoError(os.WriteFile("package.json", []byte(`{"version": "1.2.1"}`), 0644))
+	suite.Require().NoError(os.WriteFile("plan.json", []byte(`{"schemaVersion": 1, "command": "patch",
+		"version": {"file": "VERSION", "old": "1.2.3", "new": "1.2.3"},
+		"files": [{"file": "package.json", "path": "version", "old": "1.2.0", "new": "1.2.3"}]}`), 0644))
+
+	// Action
+	rootCmd.SetArgs([]string{"patch", "--apply-plan", "plan.json"})
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), plan.ErrStaleFile)
+	content, _ := os.ReadFile("package.json")
+	suite.Contains(string(content), `"1.2.1"`)
+}
+
+func TestPatchTestSuite(t *testing.T) {
+	suite.Run(t, new(PatchTestSuite))
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/clierr"
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/plan"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+)
+
+// addPlanFlags registers --plan and --apply-plan in the flags of a mutating
+// command (its persistent flags to cover its subcommands too)
+func addPlanFlags(flags *pflag.FlagSet) {
+	flags.String("plan", "", "Print the intended changes as a plan (format: json) without modifying anything")
+	flags.String("apply-plan", "", "Apply a plan file previously produced with --plan")
+}
+
+// commandName returns the command path without the binary name (e.g. "bump patch")
+func commandName(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// applyPlanFlag applies the --apply-plan file if set; handled reports whether
+// the flag was present. The plan must come from the same top-level command.
+func applyPlanFlag(cmd *cobra.Command) (handled bool, err error) {
+	path, _ := cmd.Flags().GetString("apply-plan")
+	if path == "" {
+		return false, nil
+	}
+
+	p, err := plan.Read(path)
+	if err != nil {
+		return true, err
+	}
+	family, _, _ := strings.Cut(commandName(cmd), " ")
+	if p.Family() != family {
+		return true, fmt.Errorf("%s: plan is for %q, not %q", plan.ErrCommandMismatch, p.Command, family)
+	}
+
+	if err := plan.NewApplierDefault().Apply(p); err != nil {
+		return true, fmt.Errorf("failed to apply plan: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Applied plan '%s': %s -> %s\n", p.Command, p.Version.Old, p.Version.New)
+	for _, f := range p.Files {
+		fmt.Fprintf(cmd.OutOrStdout(), "  %s %s: %s -> %s\n", f.File, f.Path, f.Old, f.New)
+	}
+	if p.Commit != nil && p.Commit.Amend {
+		fmt.Fprintln(cmd.OutOrStdout(), "Amended last commit to include VERSION change")
+	}
+	if p.Tag != nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "Created tag '%s'\n", p.Tag.Name)
+	}
+	if p.Branch != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Created branch '%s'\n", p.Branch)
+	}
+	if p.Push != nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "Pushed tag '%s'\n", p.Push.Tag)
+		if p.Push.Branch != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "Pushed branch '%s'\n", p.Push.Branch)
+		}
+	}
+
+	for _, event := range p.Events {
+		runLifecycleHooks(cmd, plugin.Event(event))
+	}
+	return true, nil
+}
+
+// writePlanFlag prints the plan produced by build if --plan is set; handled
+// reports whether the flag was present
+func writePlanFlag(cmd *cobra.Command, build func() (*plan.Plan, error)) (handled bool, err error) {
+	format, _ := cmd.Flags().GetString("plan")
+	if format == "" {
+		return false, nil
+	}
+	if format != plan.FormatJSON {
+		return true, clierr.UnknownChoice(plan.ErrUnsupportedFormat, format, []string{plan.FormatJSON})
+	}
+
+	p, err := build()
+	if err != nil {
+		return true, err
+	}
+	return true, p.Encode(cmd.OutOrStdout(), format)
+}
+
+// buildVersionPlan describes changing VERSION from current to next, the
+// configured file updates that follow, and an optional commit. A release
+// writes its updates without the SNAPSHOT qualifier.
+func buildVersionPlan(cmd *cobra.Command, current, next *version.Version, commit *plan.CommitAction, release bool, events ...plugin.Event) (*plan.Plan, error) {
+	p := &plan.Plan{
+		SchemaVersion: plan.SchemaVersion,
+		Command:       commandName(cmd),
+		Version: plan.VersionChange{
+			File: "VERSION",
+			Old:  current.FullString(),
+			New:  next.FullString(),
+		},
+		Commit: commit,
+	}
+	for _, event := range events {
+		p.Events = append(p.Events, string(event))
+	}
+
+	if cfg, err := config.ReadConfig(); err == nil && cfg != nil && len(cfg.Updates) > 0 {
+		templateData := emit.BuildCompleteTemplateData(next, cfg.PreRelease.Template, cfg.Metadata.Template)
+		updater := newConfiguredUpdater(cfg, templateData, release, zap.NewNop())
+		changes, err := updater.PlanUpdates(templateData)
+		if err != nil {
+			return nil, fmt.Errorf("error planning file updates: %w", err)
+		}
+		for _, c := range changes {
+			p.Files = append(p.Files, plan.FileChange{
+				File: c.Config.File, Path: c.Config.Path, Format: c.Config.Format,
+				Old: c.Old, New: c.New,
+			})
+		}
+	}
+
+	if commit != nil {
+		head, err := planHead()
+		if err != nil {
+			return nil, err
+		}
+		p.Head = head
+	}
+	return p, nil
+}
+
+// planHead returns the HEAD a plan that commits or tags is computed against
+func planHead() (string, error) {
+	activeVCS := vcs.GetActiveVCS()
+	if activeVCS == nil {
+		return "", fmt.Errorf("%s", plan.ErrNoVCS)
+	}
+	head, err := activeVCS.GetVCSIdentifier(vcs.MaxIdentifierLength(activeVCS))
+	if err != nil {
+		return "", fmt.Errorf("error reading HEAD: %w", err)
+	}
+	return head, nil
+}
+
+// planLevelChange builds the plan for incrementing a level by n, or
+// decrementing it
+func planLevelChange(cmd *cobra.Command, level version.VersionLevel, increment bool, n int) (*plan.Plan, error) {
+	current, err := version.Load()
+	if err != nil {
+		return nil, err
+	}
+	next := *current
+	if increment {
+		err = next.IncrementLevelBy(level, n)
+	} else {
+		err = next.DecrementLevel(level)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if increment {
+		return buildVersionPlan(cmd, current, &next, nil, false, plugin.EventBump)
+	}
+	return buildVersionPlan(cmd, current, &next, nil, false)
+}
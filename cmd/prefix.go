@@ -14,12 +14,26 @@ func validPrefix(p string) bool {
 	return p == "" || p == "v" || p == "V"
 }
 
+// checkPrefixAllowed rejects a prefix outside the configured allowedPrefixes
+// unless --force is set
+func checkPrefixAllowed(cmd *cobra.Command, cfg *config.Config, prefix string) error {
+	if cfg.PrefixAllowed(prefix) {
+		return nil
+	}
+	if force, _ := cmd.Flags().GetBool("force"); force {
+		return nil
+	}
+	return fmt.Errorf("%s: %q (allowed: %q); use --force to override", ErrPrefixNotAllowed, prefix, cfg.AllowedPrefixes)
+}
+
 var prefixCmd = &cobra.Command{
 	Use:   "prefix",
 	Short: "Manage version prefix",
 	Long: `Commands to enable, disable, or set version prefix in VERSION file.
 
-Only 'v' or 'V' prefixes are allowed per SemVer convention.`,
+Only 'v' or 'V' prefixes are allowed per SemVer convention. When
+allowedPrefixes is set in .versionator.yaml, 'set' and 'disable' also
+reject prefixes outside that list unless --force is given.`,
 }
 
 var prefixEnableCmd = &cobra.Command{
@@ -32,18 +46,23 @@ var prefixEnableCmd = &cobra.Command{
 func runPrefixEnable(cmd *cobra.Command, args []string) error {
 	// Use config prefix if set and valid, otherwise default to "v"
 	prefix := "v"
-	if cfg, err := config.ReadConfig(); err == nil && cfg.Prefix != "" {
-		if !validPrefix(cfg.Prefix) {
-			return fmt.Errorf("invalid config prefix %q: only 'v' or 'V' allowed per SemVer convention", cfg.Prefix)
+	if cfg, err := config.ReadConfig(); err == nil {
+		if cfg.Prefix != "" {
+			if !validPrefix(cfg.Prefix) {
+				return fmt.Errorf("invalid config prefix %q: only 'v' or 'V' allowed per SemVer convention", cfg.Prefix)
+			}
+			prefix = cfg.Prefix
+		}
+		if err := checkPrefixAllowed(cmd, cfg, prefix); err != nil {
+			return err
 		}
-		prefix = cfg.Prefix
 	}
 
 	if err := version.SetPrefix(prefix); err != nil {
 		return fmt.Errorf("error setting prefix: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Version prefix enabled with value '%s'\n", prefix)
+	newConsole(cmd).Successf("Version prefix enabled with value '%s'", prefix)
 
 	// Show current version with prefix
 	vd, err := version.Load()
@@ -51,7 +70,7 @@ func runPrefixEnable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error getting version: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Current version: %s\n", vd.FullString())
+	newConsole(cmd).Infof("Current version: %s", vd.FullString())
 	return nil
 }
 
@@ -63,11 +82,17 @@ var prefixDisableCmd = &cobra.Command{
 }
 
 func runPrefixDisable(cmd *cobra.Command, args []string) error {
+	if cfg, err := config.ReadConfig(); err == nil {
+		if err := checkPrefixAllowed(cmd, cfg, ""); err != nil {
+			return err
+		}
+	}
+
 	if err := version.SetPrefix(""); err != nil {
 		return fmt.Errorf("error setting prefix: %w", err)
 	}
 
-	fmt.Fprintln(cmd.OutOrStdout(), "Version prefix disabled")
+	newConsole(cmd).Successf("Version prefix disabled")
 
 	// Show current version without prefix
 	v, err := version.GetCurrentVersion()
@@ -75,7 +100,7 @@ func runPrefixDisable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error getting version: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Current version: %s\n", v)
+	newConsole(cmd).Infof("Current version: %s", v)
 	return nil
 }
 
@@ -87,6 +112,9 @@ var prefixSetCmd = &cobra.Command{
 Only 'v' or 'V' prefixes are allowed per SemVer convention.
 Use 'prefix disable' to remove the prefix.
 
+If allowedPrefixes is configured, the prefix must be in that list;
+--force overrides the list (but not the 'v'/'V' rule).
+
 This updates:
 1. The config file (.versionator.yaml) - so 'prefix enable' can restore it
 2. The VERSION file - the source of truth for the current version`,
@@ -107,6 +135,9 @@ func runPrefixSet(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("error reading config: %w", err)
 	}
+	if err := checkPrefixAllowed(cmd, cfg, prefix); err != nil {
+		return err
+	}
 	cfg.Prefix = prefix
 	if err := config.WriteConfig(cfg); err != nil {
 		return fmt.Errorf("error writing config: %w", err)
@@ -118,9 +149,9 @@ func runPrefixSet(cmd *cobra.Command, args []string) error {
 	}
 
 	if prefix == "" {
-		fmt.Fprintln(cmd.OutOrStdout(), "Version prefix disabled (set to empty)")
+		newConsole(cmd).Successf("Version prefix disabled (set to empty)")
 	} else {
-		fmt.Fprintf(cmd.OutOrStdout(), "Version prefix set to: %s\n", prefix)
+		newConsole(cmd).Successf("Version prefix set to: %s", prefix)
 	}
 
 	// Show current version with new prefix
@@ -129,7 +160,7 @@ func runPrefixSet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error getting version: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Current version: %s\n", vd.FullString())
+	newConsole(cmd).Infof("Current version: %s", vd.FullString())
 	return nil
 }
 
@@ -173,4 +204,12 @@ func init() {
 	prefixCmd.AddCommand(prefixDisableCmd)
 	prefixCmd.AddCommand(prefixSetCmd)
 	prefixCmd.AddCommand(prefixStatusCmd)
+
+	prefixCmd.PersistentFlags().Bool("force", false, "Allow a prefix outside the configured allowedPrefixes")
+
+	// Values are managed by 'component'; these remain as aliases
+	markDeprecated(prefixEnableCmd, "component prefix enable")
+	markDeprecated(prefixDisableCmd, "component prefix disable")
+	markDeprecated(prefixSetCmd, "component prefix set")
+	markDeprecated(prefixStatusCmd, "component prefix status")
 }
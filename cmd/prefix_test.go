@@ -618,6 +618,56 @@ func TestPrefixSetCommand_InvalidPrefix_ReturnsError(t *testing.T) {
 	rootCmd.SetArgs(nil)
 }
 
+// TestPrefixSetCommand_OutsideAllowlist_RequiresForce validates the
+// allowedPrefixes config list.
+//
+// Why: Large organizations standardize on one prefix; a stray 'prefix set V'
+// or 'prefix disable' in one repository breaks tag conventions across teams.
+//
+// What: With allowedPrefixes: ["v"], setting "V" and disabling the prefix
+// fail with ErrPrefixNotAllowed and leave VERSION untouched; with --force,
+// "V" is accepted.
+func TestPrefixSetCommand_OutsideAllowlist_RequiresForce(t *testing.T) {
+	// Precondition: Config allowing only "v", VERSION with "v" prefix
+	t.Chdir(t.TempDir())
+	initialConfig := &config.Config{Prefix: "v", AllowedPrefixes: []string{"v"}}
+	configData, err := yaml.Marshal(initialConfig)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(".versionator.yaml", configData, 0644))
+	require.NoError(t, os.WriteFile("VERSION", []byte("v1.0.0\n"), 0644))
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+		_ = prefixCmd.PersistentFlags().Set("force", "false")
+	})
+	rootCmd.SetOut(new(bytes.Buffer))
+
+	// Action: Set and disable outside the allowlist
+	rootCmd.SetArgs([]string{"config", "prefix", "set", "V"})
+	setErr := rootCmd.Execute()
+	rootCmd.SetArgs([]string{"config", "prefix", "disable"})
+	disableErr := rootCmd.Execute()
+
+	// Expected: Both rejected, VERSION unchanged
+	require.Error(t, setErr)
+	assert.Contains(t, setErr.Error(), ErrPrefixNotAllowed)
+	require.Error(t, disableErr)
+	assert.Contains(t, disableErr.Error(), ErrPrefixNotAllowed)
+	vd, err := version.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "v", vd.Prefix)
+
+	// Action: Set with --force
+	rootCmd.SetArgs([]string{"config", "prefix", "set", "V", "--force"})
+	err = rootCmd.Execute()
+
+	// Expected: Override accepted
+	require.NoError(t, err)
+	vd, err = version.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "V", vd.Prefix)
+}
+
 // =============================================================================
 // EDGE CASES
 // =============================================================================
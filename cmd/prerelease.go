@@ -84,14 +84,14 @@ func runPrereleaseStable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error writing config: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Pre-release stable set to: %t\n", cfg.PreRelease.Stable)
+	newConsole(cmd).Successf("Pre-release stable set to: %t", cfg.PreRelease.Stable)
 
 	// If switching to stable=false, clear prerelease from VERSION file
 	if !cfg.PreRelease.Stable {
 		if err := version.SetPreRelease(""); err != nil {
 			return fmt.Errorf("error clearing pre-release from VERSION: %w", err)
 		}
-		fmt.Fprintln(cmd.OutOrStdout(), "Pre-release cleared from VERSION file (will be generated at output time)")
+		newConsole(cmd).Successf("Pre-release cleared from VERSION file (will be generated at output time)")
 	}
 
 	return nil
@@ -144,7 +144,7 @@ func runPrereleaseEnable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error setting pre-release: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Pre-release enabled with value '%s'\n", prerelease)
+	newConsole(cmd).Successf("Pre-release enabled with value '%s'", prerelease)
 
 	// Show current version
 	vd, err = version.Load()
@@ -152,7 +152,7 @@ func runPrereleaseEnable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error getting version: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Current version: %s\n", vd.FullString())
+	newConsole(cmd).Infof("Current version: %s", vd.FullString())
 	return nil
 }
 
@@ -184,7 +184,7 @@ func runPrereleaseDisable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error clearing pre-release: %w", err)
 	}
 
-	fmt.Fprintln(cmd.OutOrStdout(), "Pre-release disabled")
+	newConsole(cmd).Successf("Pre-release disabled")
 
 	// Show current version without pre-release
 	vd, err := version.Load()
@@ -192,7 +192,7 @@ func runPrereleaseDisable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error getting version: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Current version: %s\n", vd.FullString())
+	newConsole(cmd).Infof("Current version: %s", vd.FullString())
 	return nil
 }
 
@@ -282,6 +282,7 @@ func runPrereleaseSet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Update template in config
+	warnZeroPaddedPreRelease(cmd, value)
 	cfg.PreRelease.Template = value
 	if err := config.WriteConfig(cfg); err != nil {
 		return fmt.Errorf("error writing config: %w", err)
@@ -292,18 +293,18 @@ func runPrereleaseSet(cmd *cobra.Command, args []string) error {
 		if err := version.SetPreRelease(value); err != nil {
 			return fmt.Errorf("error setting pre-release: %w", err)
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "Pre-release set to: %s\n", value)
+		newConsole(cmd).Successf("Pre-release set to: %s", value)
 
 		// Show current version with pre-release
 		vd, err := version.Load()
 		if err != nil {
 			return fmt.Errorf("error getting version: %w", err)
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "Current version: %s\n", vd.FullString())
+		newConsole(cmd).Infof("Current version: %s", vd.FullString())
 	} else {
 		// --force was used: set template to literal, don't write to VERSION
-		fmt.Fprintf(cmd.OutOrStdout(), "Pre-release template set to literal: %s\n", value)
-		fmt.Fprintln(cmd.OutOrStdout(), "(Value will be used at output time, not stored in VERSION file)")
+		newConsole(cmd).Successf("Pre-release template set to literal: %s", value)
+		newConsole(cmd).Infof("(Value will be used at output time, not stored in VERSION file)")
 	}
 
 	return nil
@@ -336,7 +337,7 @@ func runPrereleaseClear(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error clearing pre-release: %w", err)
 	}
 
-	fmt.Fprintln(cmd.OutOrStdout(), "Pre-release cleared")
+	newConsole(cmd).Successf("Pre-release cleared")
 
 	// Show current version without pre-release
 	vd, err := version.Load()
@@ -344,7 +345,7 @@ func runPrereleaseClear(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error getting version: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Current version: %s\n", vd.FullString())
+	newConsole(cmd).Infof("Current version: %s", vd.FullString())
 	return nil
 }
 
@@ -363,7 +364,7 @@ The leading dash (-) is added automatically - do NOT include it in your template
 The template uses Mustache syntax. Available variables:
   {{ShortHash}}            - Short git commit hash, 7 chars (e.g., "abc1234")
   {{MediumHash}}           - Medium git commit hash, 12 chars (e.g., "abc1234def01")
-  {{Hash}}                 - Full git commit hash (40 chars)
+  {{Hash}}                 - Full git commit hash (40 chars, 64 for SHA-256 repos)
   {{BranchName}}           - Current branch name
   {{EscapedBranchName}}    - Branch name with / replaced by -
   {{CommitsSinceTag}}      - Commits since last tag
@@ -398,4 +399,11 @@ func init() {
 
 	// Add --force flag to set command
 	prereleaseSetCmd.Flags().BoolVarP(&prereleaseForceFlag, "force", "f", false, "Force set on dynamic mode (sets template to literal value)")
+
+	// Values are managed by 'component'; these remain as aliases
+	markDeprecated(prereleaseEnableCmd, "component prerelease enable")
+	markDeprecated(prereleaseDisableCmd, "component prerelease disable")
+	markDeprecated(prereleaseStatusCmd, "component prerelease status")
+	markDeprecated(prereleaseSetCmd, "component prerelease set")
+	markDeprecated(prereleaseClearCmd, "component prerelease disable")
 }
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/clierr"
+	"github.com/benjaminabbitt/versionator/internal/commitparser"
+	"github.com/benjaminabbitt/versionator/internal/offline"
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+// rcLabel is the pre-release label that marks a release candidate
+const rcLabel = "rc"
+
+var rcCmd = &cobra.Command{
+	Use:   "rc",
+	Short: "Promote release candidates",
+	Long: `Commands for the release candidate workflow: tag 1.3.0-rc.1, test it,
+then graduate the same commit to 1.3.0.`,
+}
+
+var rcGraduateCmd = &cobra.Command{
+	Use:   "graduate",
+	Short: "Promote the tagged release candidate to a release",
+	Long: `Promote the current release candidate to its release version.
+
+This command will:
+1. Check that VERSION has an rc pre-release (e.g. 1.3.0-rc.2)
+2. Check that the candidate's tag (e.g. v1.3.0-rc.2) points at HEAD, so
+   nothing was committed after the candidate was tested
+3. Check that the working directory is clean
+4. Strip the pre-release and write VERSION (1.3.0)
+
+With --tag, the release then proceeds as 'versionator release': VERSION is
+committed and tagged, and a release branch is created unless disabled.
+--push also pushes the tag and branch.
+
+Examples:
+  versionator rc graduate               # Write 1.3.0 to VERSION
+  versionator rc graduate --tag         # ...then commit and tag v1.3.0
+  versionator rc graduate --push        # ...and push the tag and branch`,
+	Args: cobra.NoArgs,
+	RunE: runRCGraduate,
+}
+
+func init() {
+	rootCmd.AddCommand(rcCmd)
+	rcCmd.AddCommand(rcGraduateCmd)
+
+	rcGraduateCmd.Flags().Bool("tag", false, "Commit VERSION and tag the release, as 'versionator release'")
+	rcGraduateCmd.Flags().Bool("push", false, "Push the release tag and branch (implies --tag)")
+	rcGraduateCmd.Flags().Bool("no-branch", false, "Skip creating release branch")
+}
+
+// isReleaseCandidate reports whether v's pre-release label is rc (rc.1,
+// rc1, RC.2)
+func isReleaseCandidate(v *version.Version) bool {
+	return strings.HasPrefix(strings.ToLower(v.PreReleaseLabel()), rcLabel)
+}
+
+func runRCGraduate(cmd *cobra.Command, args []string) error {
+	activeVCS := vcs.GetActiveVCS()
+	if activeVCS == nil {
+		return clierr.NoRepository(commitparser.ErrNoVCSDetected)
+	}
+	if err := vcs.RequireCapability(activeVCS, vcs.CapabilityTags); err != nil {
+		return err
+	}
+
+	push, _ := cmd.Flags().GetBool("push")
+	tag, _ := cmd.Flags().GetBool("tag")
+	tag = tag || push
+	if push {
+		if err := offline.Require("rc graduate --push"); err != nil {
+			return err
+		}
+		if err := vcs.RequireCapability(activeVCS, vcs.CapabilityPush); err != nil {
+			return err
+		}
+	}
+
+	candidate, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+	if !isReleaseCandidate(candidate) {
+		return fmt.Errorf("%s: %s (expected an rc pre-release, e.g. 1.3.0-rc.1)", ErrNotReleaseCandidate, candidate.FullString())
+	}
+
+	if err := checkCandidateAtHead(activeVCS, candidateTag(candidate)); err != nil {
+		return err
+	}
+	clean, err := activeVCS.IsWorkingDirectoryClean()
+	if err != nil {
+		return fmt.Errorf("error checking %s status: %w", activeVCS.Name(), err)
+	}
+	if !clean {
+		return fmt.Errorf("working directory is not clean. Please commit or stash your changes first")
+	}
+
+	graduated := *candidate
+	graduated.PreRelease = ""
+	if err := version.Replace(candidate, &graduated); err != nil {
+		return fmt.Errorf("error writing VERSION: %w", err)
+	}
+	newConsole(cmd).Successf("Graduated %s to %s", candidate.FullString(), graduated.FullString())
+
+	if !tag {
+		return nil
+	}
+	result, err := runRelease(cmd)
+	if err != nil {
+		return err
+	}
+	if push {
+		return pushRelease(cmd, result)
+	}
+	return nil
+}
+
+// candidateTag returns the tag 'versionator release' created for the
+// candidate: release.tagFormat, or VERSION's prefix (default "v") followed
+// by the version
+func candidateTag(candidate *version.Version) string {
+	prefix := candidate.Prefix
+	if prefix == "" {
+		prefix = "v"
+	}
+	return tagformat.Name(prefix, candidate.String())
+}
+
+// checkCandidateAtHead verifies that the candidate was tagged and that HEAD
+// is still the tagged commit
+func checkCandidateAtHead(activeVCS vcs.VersionControlSystem, tagName string) error {
+	exists, err := activeVCS.TagExists(tagName)
+	if err != nil {
+		return fmt.Errorf("error checking if tag exists: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("%s: tag '%s' not found (run 'versionator release' first)", ErrCandidateNotTagged, tagName)
+	}
+
+	head, err := activeVCS.GetVCSIdentifier(vcs.MaxIdentifierLength(activeVCS))
+	if err != nil {
+		return fmt.Errorf("error reading HEAD: %w", err)
+	}
+	tagged, err := activeVCS.GetTagCommit(tagName)
+	if err != nil {
+		return fmt.Errorf("error resolving tag %q: %w", tagName, err)
+	}
+	if tagged != head {
+		return fmt.Errorf("%s: '%s' is at %s, HEAD is %s", ErrCommitsSinceCandidate,
+			tagName, tagged[:min(7, len(tagged))], head[:min(7, len(head))])
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	gitVCS "github.com/benjaminabbitt/versionator/internal/vcs/git"
+	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
+	"github.com/benjaminabbitt/versionator/internal/version"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+// RCTestSuite defines the test suite for the rc command.
+// rc graduate promotes a tagged release candidate to its release version
+// once nothing has been committed since the candidate was tagged.
+type RCTestSuite struct {
+	suite.Suite
+	ctrl    *gomock.Controller
+	mockVCS *mock.MockVersionControlSystem
+	origDir string
+}
+
+// SetupTest runs before each test
+func (suite *RCTestSuite) SetupTest() {
+	var err error
+	suite.origDir, err = os.Getwd()
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.Chdir(suite.T().TempDir()))
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.3.0-rc.2\n"), 0644))
+
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockVCS = mock.NewMockVersionControlSystem(suite.ctrl)
+	suite.mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	suite.mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	suite.mockVCS.EXPECT().GetRepositoryRoot().Return(".", nil).AnyTimes()
+	suite.mockVCS.EXPECT().GetVCSIdentifier(vcs.DefaultIdentifierLength).Return("abc1234def", nil).AnyTimes()
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(suite.mockVCS)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+}
+
+// TearDownTest runs after each test
+func (suite *RCTestSuite) TearDownTest() {
+	if suite.origDir != "" {
+		_ = os.Chdir(suite.origDir)
+	}
+	if suite.ctrl != nil {
+		suite.ctrl.Finish()
+	}
+
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	_ = rcGraduateCmd.Flags().Set("tag", "false")
+	_ = rcGraduateCmd.Flags().Set("push", "false")
+	_ = rcGraduateCmd.Flags().Set("no-branch", "false")
+
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(gitVCS.NewGitVCSDefault())
+}
+
+// readVersionFile returns the VERSION file contents
+func (suite *RCTestSuite) readVersionFile() string {
+	data, err := os.ReadFile("VERSION")
+	suite.Require().NoError(err)
+	return string(data)
+}
+
+// TestGraduate_CandidateAtHead_WritesRelease validates the basic promotion.
+//
+// Why: Promoting rc to GA by hand means editing VERSION and trusting that
+// nothing changed since the candidate was tested.
+//
+// What: VERSION 1.3.0-rc.2 whose tag v1.3.0-rc.2 is at HEAD on a clean tree
+// becomes 1.3.0; nothing is committed or tagged without --tag.
+func (suite *RCTestSuite) TestGraduate_CandidateAtHead_WritesRelease() {
+	// Precondition: Candidate tag at HEAD, clean tree
+	suite.mockVCS.EXPECT().TagExists("v1.3.0-rc.2").Return(true, nil)
+	suite.mockVCS.EXPECT().GetTagCommit("v1.3.0-rc.2").Return("abc1234def", nil)
+	suite.mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+
+	// Action
+	rootCmd.SetArgs([]string{"rc", "graduate"})
+	err := rootCmd.Execute()
+
+	// Expected: VERSION stripped of the pre-release
+	suite.Require().NoError(err)
+	suite.Equal("1.3.0\n", suite.readVersionFile())
+}
+
+// TestGraduate_CommitsSinceCandidate_Refuses validates the safety check.
+//
+// Why: A release must be the exact commit that was tested as the candidate;
+// later commits were never validated.
+//
+// What: The candidate tag points at an older commit; the command fails and
+// VERSION is unchanged.
+func (suite *RCTestSuite) TestGraduate_CommitsSinceCandidate_Refuses() {
+	// Precondition: Candidate tag behind HEAD
+	suite.mockVCS.EXPECT().TagExists("v1.3.0-rc.2").Return(true, nil)
+	suite.mockVCS.EXPECT().GetTagCommit("v1.3.0-rc.2").Return("fff0000aaa", nil)
+
+	// Action
+	rootCmd.SetArgs([]string{"rc", "graduate"})
+	err := rootCmd.Execute()
+
+	// Expected: Refused, VERSION untouched
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), ErrCommitsSinceCandidate)
+	suite.Equal("1.3.0-rc.2\n", suite.readVersionFile())
+}
+
+// TestGraduate_ConcurrentChange_Refuses validates the VERSION lock.
+//
+// Why: The release version is computed from the candidate as loaded; writing
+// it blindly would undo a change another invocation made meanwhile.
+//
+// What: When VERSION becomes 1.3.0-rc.3 during the checks, the command fails
+// with version.ErrVersionChanged and keeps 1.3.0-rc.3.
+func (suite *RCTestSuite) TestGraduate_ConcurrentChange_Refuses() {
+	// Precondition: Candidate tag at HEAD; VERSION changes while the tree is checked
+	suite.mockVCS.EXPECT().TagExists("v1.3.0-rc.2").Return(true, nil)
+	suite.mockVCS.EXPECT().GetTagCommit("v1.3.0-rc.2").Return("abc1234def", nil)
+	suite.mockVCS.EXPECT().IsWorkingDirectoryClean().DoAndReturn(func() (bool, error) {
+		return true, os.WriteFile("VERSION", []byte("1.3.0-rc.3\n"), 0644)
+	})
+
+	// Action
+	rootCmd.SetArgs([]string{"rc", "graduate"})
+	err := rootCmd.Execute()
+
+	// Expected: Refused, the concurrent change kept
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), version.ErrVersionChanged)
+	suite.Equal("1.3.0-rc.3\n", suite.readVersionFile())
+}
+
+// TestGraduate_NotCandidate_Refuses validates the pre-release check.
+//
+// Why: Graduating a beta or a plain release would silently skip the
+// candidate stage the workflow exists to enforce.
+//
+// What: VERSION 1.3.0-beta.1 and the candidate's missing tag are both
+// reported; VERSION is unchanged.
+func (suite *RCTestSuite) TestGraduate_NotCandidate_Refuses() {
+	// Precondition: A beta pre-release
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.3.0-beta.1\n"), 0644))
+
+	// Action
+	rootCmd.SetArgs([]string{"rc", "graduate"})
+	err := rootCmd.Execute()
+
+	// Expected: Not a release candidate
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), ErrNotReleaseCandidate)
+
+	// Precondition: An untagged candidate
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.3.0-rc.2\n"), 0644))
+	suite.mockVCS.EXPECT().TagExists("v1.3.0-rc.2").Return(false, nil)
+
+	// Action
+	err = rootCmd.Execute()
+
+	// Expected: Candidate not tagged
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), ErrCandidateNotTagged)
+	suite.Equal("1.3.0-rc.2\n", suite.readVersionFile())
+}
+
+// TestGraduate_Tag_CommitsAndTagsRelease validates --tag.
+//
+// Why: Graduation is usually followed straight away by the release; doing
+// both in one command leaves no window for other commits.
+//
+// What: After writing 1.3.0, VERSION is committed and v1.3.0 is tagged as
+// 'versionator release' would; --no-branch skips the release branch.
+func (suite *RCTestSuite) TestGraduate_Tag_CommitsAndTagsRelease() {
+	// Precondition: Candidate tag at HEAD; VERSION is dirty after graduation
+	suite.mockVCS.EXPECT().TagExists("v1.3.0-rc.2").Return(true, nil)
+	suite.mockVCS.EXPECT().GetTagCommit("v1.3.0-rc.2").Return("abc1234def", nil)
+	gomock.InOrder(
+		suite.mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil),
+		suite.mockVCS.EXPECT().IsWorkingDirectoryClean().Return(false, nil),
+	)
+	suite.mockVCS.EXPECT().GetDirtyFiles().Return([]string{"VERSION"}, nil)
+	gomock.InOrder(
+		suite.mockVCS.EXPECT().CommitFiles([]string{"VERSION"}, "Release 1.3.0").Return(nil),
+		suite.mockVCS.EXPECT().TagExists("v1.3.0").Return(false, nil),
+		suite.mockVCS.EXPECT().CreateTag("v1.3.0", "Release 1.3.0").Return(nil),
+	)
+
+	// Action
+	rootCmd.SetArgs([]string{"rc", "graduate", "--tag", "--no-branch"})
+	err := rootCmd.Execute()
+
+	// Expected: Released
+	suite.Require().NoError(err)
+	suite.Equal("1.3.0\n", suite.readVersionFile())
+}
+
+// TestRCTestSuite runs the rc test suite
+func TestRCTestSuite(t *testing.T) {
+	suite.Run(t, new(RCTestSuite))
+}
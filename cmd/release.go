@@ -2,9 +2,21 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/benjaminabbitt/versionator/internal/changelog"
 	"github.com/benjaminabbitt/versionator/internal/config"
 	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/manifest"
+	"github.com/benjaminabbitt/versionator/internal/offline"
+	"github.com/benjaminabbitt/versionator/internal/plan"
+	"github.com/benjaminabbitt/versionator/internal/platform"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+	"github.com/benjaminabbitt/versionator/internal/publish"
+	"github.com/benjaminabbitt/versionator/internal/trailer"
 	"github.com/benjaminabbitt/versionator/internal/update"
 	"github.com/benjaminabbitt/versionator/internal/vcs"
 	"github.com/benjaminabbitt/versionator/internal/version"
@@ -18,6 +30,8 @@ type releaseResult struct {
 	tagName    string
 	branchName string
 	vcsImpl    vcs.VersionControlSystem
+	version    *version.Version
+	config     *config.Config
 }
 
 var releaseCmd = &cobra.Command{
@@ -44,12 +58,50 @@ Release branch creation is enabled by default. Configure in .versionator.yaml:
 
 Use --no-branch to skip branch creation for a single invocation.
 
+Tags other than <prefix><version> are configured with release.tagFormat,
+which replaces --prefix:
+  release:
+    tagFormat: "myapp-v{{Version}}"   # tags myapp-v1.2.3
+
+Use --manifest (or release.manifest.enabled) to append the SHA-256 checksums
+of VERSION, files patched by 'updates', the outputs of emit.targets that
+exist, and release.manifest.files to the tag annotation, in sha256sum
+format, so released artifacts can be audited later.
+
+Use --message-from-changelog (or release.changelog) to add the version's
+section of CHANGELOG.md, found by its heading ("## [1.2.3] - 2024-12-11"),
+to the tag annotation, truncated to release.changelog.maxLines/maxBytes and
+followed by a link to the full changelog.
+
+Use --trailer commit,tag (or release.trailer) to end the release commit
+message and/or tag annotation with a 'Versionator-Version: <version>' trailer,
+so tooling can find release commits by trailer search:
+  git log --format='%H %(trailers:key=Versionator-Version,valueonly)'
+
 The command will fail if there are uncommitted changes (other than VERSION)
-or if the tag already exists.`,
+or if the tag already exists on another commit. For unattended pipelines,
+--bump-on-conflict <level> bumps VERSION until the tag is free, and
+--suffix-on-conflict .1 tags v1.2.3.1, v1.2.3.2, ... instead (configurable
+as release.onConflict).
+
+Use --plan json to print the release commit, tag and branch (and for
+'release push', what is pushed) without changing anything, and --apply-plan
+to perform a reviewed plan, refusing if HEAD has moved or the tag or branch
+has been created since:
+  versionator release push --plan json > plan.json
+  versionator release push --apply-plan plan.json`,
 	RunE: runReleaseCmd,
 }
 
 func runReleaseCmd(cmd *cobra.Command, args []string) error {
+	if handled, err := applyPlanFlag(cmd); handled {
+		return err
+	}
+	if handled, err := writePlanFlag(cmd, func() (*plan.Plan, error) {
+		return planRelease(cmd)
+	}); handled {
+		return err
+	}
 	_, err := runRelease(cmd)
 	return err
 }
@@ -71,31 +123,56 @@ Example:
 }
 
 func runReleasePush(cmd *cobra.Command, args []string) error {
+	// Describing the release needs no network; applying it does
+	if handled, err := writePlanFlag(cmd, func() (*plan.Plan, error) {
+		return planRelease(cmd)
+	}); handled {
+		return err
+	}
+	if err := offline.Require("release push"); err != nil {
+		return err
+	}
+	if vcsImpl := vcs.GetActiveVCS(); vcsImpl != nil {
+		if err := vcs.RequireCapability(vcsImpl, vcs.CapabilityPush); err != nil {
+			return err
+		}
+	}
+	if handled, err := applyPlanFlag(cmd); handled {
+		return err
+	}
 	result, err := runRelease(cmd)
 	if err != nil {
 		return err
 	}
+	return pushRelease(cmd, result)
+}
+
+// pushRelease pushes the release tag and, if created, the release branch
+func pushRelease(cmd *cobra.Command, result *releaseResult) error {
+	out := newConsole(cmd)
 
 	// Push the tag
-	cmd.Printf("Pushing tag '%s' to remote...\n", result.tagName)
+	out.Infof("Pushing tag '%s' to remote...", result.tagName)
 	if err := result.vcsImpl.PushTag(result.tagName); err != nil {
 		return fmt.Errorf("failed to push tag: %w", err)
 	}
-	cmd.Printf("Successfully pushed tag '%s'\n", result.tagName)
+	out.Successf("Successfully pushed tag '%s'", result.tagName)
 
 	// Push the branch if it was created
 	if result.branchName != "" {
-		cmd.Printf("Pushing branch '%s' to remote...\n", result.branchName)
+		out.Infof("Pushing branch '%s' to remote...", result.branchName)
 		if err := result.vcsImpl.PushBranch(result.branchName); err != nil {
 			return fmt.Errorf("failed to push branch: %w", err)
 		}
-		cmd.Printf("Successfully pushed branch '%s'\n", result.branchName)
+		out.Successf("Successfully pushed branch '%s'", result.branchName)
 	}
 
 	return nil
 }
 
 func runRelease(cmd *cobra.Command) (*releaseResult, error) {
+	out := newConsole(cmd)
+
 	// Get active VCS
 	vcsImpl := vcs.GetActiveVCS()
 	if vcsImpl == nil {
@@ -108,54 +185,40 @@ func runRelease(cmd *cobra.Command) (*releaseResult, error) {
 		return nil, fmt.Errorf("error reading config: %w", err)
 	}
 
-	// Build set of allowed dirty files: VERSION + .versionator.yaml + files from updates config
-	allowedDirty := map[string]bool{"VERSION": true, ".versionator.yaml": true}
-	for _, u := range cfg.Updates {
-		allowedDirty[u.File] = true
-	}
+	// Check command-line flag for branch creation (overrides config)
+	noBranch, _ := cmd.Flags().GetBool("no-branch")
+	createBranch := cfg.Release.CreateBranch && !noBranch
 
-	// Check if working directory is clean
-	clean, err := vcsImpl.IsWorkingDirectoryClean()
+	trailerCommit, trailerTag, err := releaseTrailers(cmd, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("error checking %s status: %w", vcsImpl.Name(), err)
+		return nil, err
+	}
+
+	// Fail before committing anything if the backend lacks a needed feature
+	if err := requireReleaseCapabilities(vcsImpl, createBranch); err != nil {
+		return nil, err
 	}
 
-	var versionDirty bool
-	if !clean {
-		// Check what files are dirty
-		dirtyFiles, err := vcsImpl.GetDirtyFiles()
+	versionDirty, err := checkReleaseWorkingTree(vcsImpl, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if versionDirty && len(cfg.Updates) == 0 {
+		// Without updates, a modified VERSION is committed on its own first
+		vd, err := version.Load()
 		if err != nil {
-			return nil, fmt.Errorf("error getting dirty files: %w", err)
+			return nil, fmt.Errorf("error loading version: %w", err)
 		}
 
-		// If no updates configured, use original behavior: only allow VERSION dirty
-		if len(cfg.Updates) == 0 {
-			if len(dirtyFiles) == 1 && dirtyFiles[0] == "VERSION" {
-				// Load version to get the version string for commit message
-				vd, err := version.Load()
-				if err != nil {
-					return nil, fmt.Errorf("error loading version: %w", err)
-				}
-
-				commitMsg := fmt.Sprintf("Release %s", vd.String())
-				if err := vcsImpl.CommitFiles([]string{"VERSION"}, commitMsg); err != nil {
-					return nil, fmt.Errorf("error committing VERSION file: %w", err)
-				}
-				cmd.Printf("Committed VERSION file: %s\n", commitMsg)
-			} else {
-				return nil, fmt.Errorf("working directory is not clean. Please commit or stash your changes first (dirty files: %v)", dirtyFiles)
-			}
-		} else {
-			// With updates configured, allow VERSION + update target files to be dirty
-			for _, f := range dirtyFiles {
-				if !allowedDirty[f] {
-					return nil, fmt.Errorf("working directory is not clean. Please commit or stash your changes first (dirty files: %v)", dirtyFiles)
-				}
-				if f == "VERSION" {
-					versionDirty = true
-				}
-			}
+		commitMsg := fmt.Sprintf("Release %s", vd.String())
+		if trailerCommit {
+			commitMsg = trailer.Append(commitMsg, vd.String())
 		}
+		if err := vcsImpl.CommitFiles([]string{"VERSION"}, commitMsg); err != nil {
+			return nil, fmt.Errorf("error committing VERSION file: %w", err)
+		}
+		out.Successf("Committed VERSION file: %s", commitMsg)
+		versionDirty = false
 	}
 
 	// Get current version data (includes prefix)
@@ -164,53 +227,52 @@ func runRelease(cmd *cobra.Command) (*releaseResult, error) {
 		return nil, fmt.Errorf("error getting current version: %w", err)
 	}
 
-	// Use VERSION file prefix by default, or command-line override
-	prefix := vd.Prefix
-	if cmdPrefix, _ := cmd.Flags().GetString("prefix"); cmdPrefix != "" {
-		prefix = cmdPrefix
-	}
-	// If no prefix configured anywhere, default to "v"
-	if prefix == "" {
-		prefix = "v"
+	// Maven SNAPSHOT workflow: the release drops the SNAPSHOT qualifier
+	if cfg.Java.SnapshotEnabled() && emit.HasSnapshot(vd.PreRelease) {
+		vd, err = version.Update(func(v *version.Version) error {
+			v.PreRelease = emit.StripSnapshot(v.PreRelease)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error stripping SNAPSHOT from VERSION: %w", err)
+		}
+		versionDirty = true
+		out.Infof("Stripped SNAPSHOT: releasing %s", vd.String())
 	}
 
-	tagName := prefix + vd.String()
+	prefix := releasePrefix(cmd, vd)
 
 	// Tag idempotency: if the tag already exists AND points to the commit
 	// we'd be tagging anyway, skip creation and proceed (this is what makes
 	// `release` followed by `release push` work cleanly — the second
 	// invocation finds the tag already at HEAD and treats it as a no-op).
 	// If the tag exists but points elsewhere, that's a real conflict —
-	// require --force. The "what we'd tag" target is HEAD because
-	// CreateTag uses repo.Head() as the target commit.
-	exists, err := vcsImpl.TagExists(tagName)
+	// require --force or a conflict strategy. The "what we'd tag" target is
+	// HEAD because CreateTag uses repo.Head() as the target commit.
+	strategy, err := tagConflictStrategyFor(cmd, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("error checking if tag exists: %w", err)
+		return nil, err
 	}
-
-	tagAlreadyAtTarget := false
-	if exists {
-		force, _ := cmd.Flags().GetBool("force")
-		headCommit, err := vcsImpl.GetVCSIdentifier(40)
-		if err != nil {
-			return nil, fmt.Errorf("error reading HEAD: %w", err)
-		}
-		existingTagCommit, err := vcsImpl.GetTagCommit(tagName)
-		if err != nil {
-			return nil, fmt.Errorf("error resolving existing tag %q: %w", tagName, err)
-		}
-		if existingTagCommit == headCommit {
-			tagAlreadyAtTarget = true
-		} else if !force {
-			return nil, fmt.Errorf("tag '%s' already exists at %s (not HEAD %s). Use --force to overwrite",
-				tagName, existingTagCommit[:7], headCommit[:7])
+	loaded := *vd
+	tagName, tagAlreadyAtTarget, err := resolveReleaseTag(cmd, vcsImpl, vd, prefix, strategy)
+	if err != nil {
+		return nil, err
+	}
+	if vd.String() != loaded.String() {
+		// Bumped on conflict: the new version is committed with the release,
+		// unless another invocation changed VERSION meanwhile
+		if err := version.Replace(&loaded, vd); err != nil {
+			return nil, fmt.Errorf("error saving bumped version: %w", err)
 		}
+		versionDirty = true
+		out.Infof("Bumped VERSION to %s to avoid the existing tag", vd.String())
 	}
 
-	// Get custom message or use default
-	message, _ := cmd.Flags().GetString("message")
-	if message == "" {
-		message = fmt.Sprintf("Release %s", vd.String())
+	// Embed the changelog section before committing, so a missing section
+	// fails the release while nothing is changed
+	message, err := releaseMessage(cmd, cfg, vd, !tagAlreadyAtTarget)
+	if err != nil {
+		return nil, err
 	}
 
 	// Apply file updates if configured
@@ -226,44 +288,60 @@ func runRelease(cmd *cobra.Command) (*releaseResult, error) {
 			return nil, fmt.Errorf("error updating files: %w", err)
 		}
 		updatedFiles = updater.GetFilesToCommit()
-		cmd.Printf("Updated %d file(s)\n", len(updatedFiles))
+		out.Successf("Updated %d file(s)", len(updatedFiles))
 	}
 
 	// Commit VERSION + updated files if there are changes to commit
 	filesToCommit := make([]string, 0)
-	if versionDirty {
+	if versionDirty && version.UsesFile() {
 		filesToCommit = append(filesToCommit, "VERSION")
 	}
 	filesToCommit = append(filesToCommit, updatedFiles...)
 
 	if len(filesToCommit) > 0 {
 		commitMsg := fmt.Sprintf("Release %s", vd.String())
+		if trailerCommit {
+			commitMsg = trailer.Append(commitMsg, vd.String())
+		}
 		if err := vcsImpl.CommitFiles(filesToCommit, commitMsg); err != nil {
 			return nil, fmt.Errorf("error committing release files: %w", err)
 		}
-		cmd.Printf("Committed: %v\n", filesToCommit)
+		out.Successf("Committed: %v", filesToCommit)
+	}
+
+	// Append the artifact manifest (VERSION + patched + emitted + configured files)
+	withManifest, _ := cmd.Flags().GetBool("manifest")
+	if (withManifest || cfg.Release.Manifest.Enabled) && !tagAlreadyAtTarget {
+		message, err = appendReleaseManifest(message, vcsImpl, cfg, updatedFiles)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The trailer ends the annotation, after any manifest, as git expects
+	if trailerTag {
+		message = trailer.Append(message, vd.String())
 	}
 
 	// Create the tag (skip when it already points at HEAD — idempotent path
 	// for `release push` after `release`).
 	if tagAlreadyAtTarget {
-		cmd.Printf("Tag '%s' already at HEAD; skipping tag creation\n", tagName)
+		out.Infof("Tag '%s' already at HEAD; skipping tag creation", tagName)
 	} else {
 		if err := vcsImpl.CreateTag(tagName, message); err != nil {
 			return nil, fmt.Errorf("error creating tag: %w", err)
 		}
-		cmd.Printf("Successfully created tag '%s' for version %s using %s\n", tagName, vd.String(), vcsImpl.Name())
+		out.Successf("Successfully created tag '%s' for version %s using %s", tagName, vd.String(), vcsImpl.Name())
+		runLifecycleHooks(cmd, plugin.EventTag)
 	}
 
 	result := &releaseResult{
 		tagName: tagName,
 		vcsImpl: vcsImpl,
+		version: vd,
+		config:  cfg,
 	}
 
-	// Check command-line flag for branch creation (overrides config)
-	noBranch, _ := cmd.Flags().GetBool("no-branch")
-	createBranch := cfg.Release.CreateBranch && !noBranch
-
 	if createBranch {
 		branchName := cfg.Release.BranchPrefix + tagName
 
@@ -278,7 +356,7 @@ func runRelease(cmd *cobra.Command) (*releaseResult, error) {
 		}
 
 		if branchExists {
-			headCommit, err := vcsImpl.GetVCSIdentifier(40)
+			headCommit, err := vcsImpl.GetVCSIdentifier(vcs.MaxIdentifierLength(vcsImpl))
 			if err != nil {
 				return nil, fmt.Errorf("error reading HEAD: %w", err)
 			}
@@ -287,17 +365,17 @@ func runRelease(cmd *cobra.Command) (*releaseResult, error) {
 				return nil, fmt.Errorf("error resolving branch %q: %w", branchName, err)
 			}
 			if existingBranchCommit == headCommit {
-				cmd.Printf("Branch '%s' already at HEAD; skipping branch creation\n", branchName)
+				out.Infof("Branch '%s' already at HEAD; skipping branch creation", branchName)
 				result.branchName = branchName
 			} else {
-				cmd.Printf("Warning: branch '%s' exists at %s (not HEAD %s); skipping branch creation\n",
+				out.Warnf("branch '%s' exists at %s (not HEAD %s); skipping branch creation",
 					branchName, existingBranchCommit[:7], headCommit[:7])
 			}
 		} else {
 			if err := vcsImpl.CreateBranch(branchName); err != nil {
 				return nil, fmt.Errorf("error creating release branch: %w", err)
 			}
-			cmd.Printf("Successfully created branch '%s'\n", branchName)
+			out.Successf("Successfully created branch '%s'", branchName)
 			result.branchName = branchName
 		}
 	}
@@ -305,17 +383,359 @@ func runRelease(cmd *cobra.Command) (*releaseResult, error) {
 	// Show additional information if requested
 	verbose, _ := cmd.Flags().GetBool("verbose")
 	if verbose {
-		cmd.Printf("  Message: %s\n", message)
+		out.Infof("  Message: %s", message)
 
 		// Get current VCS identifier
 		if identifier, err := vcsImpl.GetVCSIdentifier(7); err == nil {
-			cmd.Printf("  %s ID: %s\n", vcsImpl.Name(), identifier)
+			out.Infof("  %s ID: %s", vcsImpl.Name(), identifier)
 		}
 	}
 
 	return result, nil
 }
 
+// planRelease describes the release runRelease would make: VERSION (with
+// SNAPSHOT stripped, or bumped past a taken tag), the file updates, the
+// release commit, the tag and branch, and for `release push` what is pushed
+func planRelease(cmd *cobra.Command) (*plan.Plan, error) {
+	vcsImpl := vcs.GetActiveVCS()
+	if vcsImpl == nil {
+		return nil, fmt.Errorf("%s", plan.ErrNoVCS)
+	}
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error reading config: %w", err)
+	}
+	noBranch, _ := cmd.Flags().GetBool("no-branch")
+	createBranch := cfg.Release.CreateBranch && !noBranch
+	trailerCommit, trailerTag, err := releaseTrailers(cmd, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireReleaseCapabilities(vcsImpl, createBranch); err != nil {
+		return nil, err
+	}
+	versionDirty, err := checkReleaseWorkingTree(vcsImpl, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := version.Load()
+	if err != nil {
+		return nil, fmt.Errorf("error getting current version: %w", err)
+	}
+	next := *current
+	if cfg.Java.SnapshotEnabled() && emit.HasSnapshot(next.PreRelease) {
+		next.PreRelease = emit.StripSnapshot(next.PreRelease)
+	}
+	strategy, err := tagConflictStrategyFor(cmd, cfg)
+	if err != nil {
+		return nil, err
+	}
+	tagName, tagAlreadyAtTarget, err := resolveReleaseTag(cmd, vcsImpl, &next, releasePrefix(cmd, &next), strategy)
+	if err != nil {
+		return nil, err
+	}
+	message, err := releaseMessage(cmd, cfg, &next, !tagAlreadyAtTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []plugin.Event
+	if !tagAlreadyAtTarget {
+		events = append(events, plugin.EventTag)
+	}
+	p, err := buildVersionPlan(cmd, current, &next, nil, true, events...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The release commits VERSION when it changes and every update target
+	var updatedFiles []string
+	for _, f := range p.Files {
+		if !slices.Contains(updatedFiles, f.File) {
+			updatedFiles = append(updatedFiles, f.File)
+		}
+	}
+	var commitFiles []string
+	if (versionDirty || next.FullString() != current.FullString()) && version.UsesFile() {
+		commitFiles = append(commitFiles, "VERSION")
+	}
+	commitFiles = append(commitFiles, updatedFiles...)
+	if len(commitFiles) > 0 {
+		commitMsg := fmt.Sprintf("Release %s", next.String())
+		if trailerCommit {
+			commitMsg = trailer.Append(commitMsg, next.String())
+		}
+		p.Commit = &plan.CommitAction{Files: commitFiles, Message: commitMsg}
+	}
+
+	if !tagAlreadyAtTarget {
+		force, _ := cmd.Flags().GetBool("force")
+		p.Tag = &plan.TagAction{Name: tagName, Message: message, Force: force}
+		withManifest, _ := cmd.Flags().GetBool("manifest")
+		if withManifest || cfg.Release.Manifest.Enabled {
+			if _, p.Tag.Manifest, err = releaseManifestFiles(vcsImpl, cfg, updatedFiles); err != nil {
+				return nil, err
+			}
+		}
+		if trailerTag {
+			p.Tag.Trailer = next.String()
+		}
+	}
+
+	// An existing branch is left alone, and pushed only when it is at HEAD
+	var pushBranch string
+	if createBranch {
+		branchName := cfg.Release.BranchPrefix + tagName
+		exists, err := vcsImpl.BranchExists(branchName)
+		if err != nil {
+			return nil, fmt.Errorf("error checking if branch exists: %w", err)
+		}
+		if !exists {
+			p.Branch = branchName
+			pushBranch = branchName
+		} else if atHead, err := branchAtHead(vcsImpl, branchName); err != nil {
+			return nil, err
+		} else if atHead {
+			pushBranch = branchName
+		}
+	}
+
+	if commandName(cmd) == "release push" {
+		p.Push = &plan.PushAction{Tag: tagName, Branch: pushBranch}
+	}
+
+	if p.Head, err = planHead(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// branchAtHead reports whether branchName points at HEAD
+func branchAtHead(vcsImpl vcs.VersionControlSystem, branchName string) (bool, error) {
+	headCommit, err := vcsImpl.GetVCSIdentifier(vcs.MaxIdentifierLength(vcsImpl))
+	if err != nil {
+		return false, fmt.Errorf("error reading HEAD: %w", err)
+	}
+	branchCommit, err := vcsImpl.GetBranchCommit(branchName)
+	if err != nil {
+		return false, fmt.Errorf("error resolving branch %q: %w", branchName, err)
+	}
+	return branchCommit == headCommit, nil
+}
+
+// releaseTrailers returns whether the Versionator-Version trailer goes in
+// the release commit and the tag annotation: --trailer when given, otherwise
+// release.trailer
+func releaseTrailers(cmd *cobra.Command, cfg *config.Config) (commit, tag bool, err error) {
+	if !cmd.Flags().Changed("trailer") {
+		return cfg.Release.Trailer.Commit, cfg.Release.Trailer.Tag, nil
+	}
+	places, _ := cmd.Flags().GetStringSlice("trailer")
+	for _, place := range places {
+		switch place {
+		case trailer.PlaceCommit:
+			commit = true
+		case trailer.PlaceTag:
+			tag = true
+		default:
+			return false, false, fmt.Errorf("invalid --trailer '%s' (must be '%s' or '%s')", place, trailer.PlaceCommit, trailer.PlaceTag)
+		}
+	}
+	return commit, tag, nil
+}
+
+// requireReleaseCapabilities fails when the backend cannot tag, or create
+// the release branch
+func requireReleaseCapabilities(vcsImpl vcs.VersionControlSystem, createBranch bool) error {
+	if err := vcs.RequireCapability(vcsImpl, vcs.CapabilityTags); err != nil {
+		return err
+	}
+	if createBranch {
+		if err := vcs.RequireCapability(vcsImpl, vcs.CapabilityBranches); err != nil {
+			return fmt.Errorf("%w (use --no-branch)", err)
+		}
+	}
+	return nil
+}
+
+// checkReleaseWorkingTree fails when the working tree has changes the release
+// would not commit, and reports whether VERSION is modified. Without updates
+// only VERSION may be modified; with them, also .versionator.yaml and the
+// update targets.
+func checkReleaseWorkingTree(vcsImpl vcs.VersionControlSystem, cfg *config.Config) (versionDirty bool, err error) {
+	clean, err := vcsImpl.IsWorkingDirectoryClean()
+	if err != nil {
+		return false, fmt.Errorf("error checking %s status: %w", vcsImpl.Name(), err)
+	}
+	if clean {
+		return false, nil
+	}
+	dirtyFiles, err := vcsImpl.GetDirtyFiles()
+	if err != nil {
+		return false, fmt.Errorf("error getting dirty files: %w", err)
+	}
+
+	// Keys are in VCS form (forward slashes), whatever separator config uses
+	allowedDirty := map[string]bool{"VERSION": true}
+	if len(cfg.Updates) > 0 {
+		allowedDirty[".versionator.yaml"] = true
+		for _, u := range cfg.Updates {
+			allowedDirty[platform.RepoPath(u.File)] = true
+		}
+	}
+	for _, f := range dirtyFiles {
+		if !allowedDirty[platform.RepoPath(f)] {
+			return false, fmt.Errorf("working directory is not clean. Please commit or stash your changes first (dirty files: %v)", dirtyFiles)
+		}
+		if f == "VERSION" {
+			versionDirty = true
+		}
+	}
+	return versionDirty, nil
+}
+
+// releasePrefix returns the tag prefix: --prefix, else the VERSION file
+// prefix, else "v"
+func releasePrefix(cmd *cobra.Command, vd *version.Version) string {
+	prefix := vd.Prefix
+	if cmdPrefix, _ := cmd.Flags().GetString("prefix"); cmdPrefix != "" {
+		prefix = cmdPrefix
+	}
+	if prefix == "" {
+		prefix = "v"
+	}
+	return prefix
+}
+
+// releaseMessage returns the tag message: --message or "Release <version>",
+// with the version's changelog section when enabled and withChangelog is set
+func releaseMessage(cmd *cobra.Command, cfg *config.Config, vd *version.Version, withChangelog bool) (string, error) {
+	message, _ := cmd.Flags().GetString("message")
+	if message == "" {
+		message = fmt.Sprintf("Release %s", vd.String())
+	}
+	fromChangelog, _ := cmd.Flags().GetBool("message-from-changelog")
+	if (fromChangelog || cfg.Release.Changelog.Enabled) && withChangelog {
+		return appendReleaseChangelog(message, vd, cfg)
+	}
+	return message, nil
+}
+
+// appendReleaseManifest adds checksums of VERSION, the patched files, the
+// emitted files, and the files matching release.manifest.files to the tag
+// message
+func appendReleaseManifest(message string, vcsImpl vcs.VersionControlSystem, cfg *config.Config, updatedFiles []string) (string, error) {
+	root, files, err := releaseManifestFiles(vcsImpl, cfg, updatedFiles)
+	if err != nil {
+		return "", err
+	}
+	entries, err := manifest.BuildAt(root, files)
+	if err != nil {
+		return "", fmt.Errorf("error building release manifest: %w", err)
+	}
+	return manifest.Append(message, entries), nil
+}
+
+// releaseManifestFiles returns the repository root and the files a release
+// manifest lists: VERSION, the patched files, the outputs of emit.targets,
+// and the files matching release.manifest.files. Outputs not generated yet
+// (e.g. emitted only at build time) are skipped. Files are relative to the
+// root, so the manifest checks out from any directory.
+func releaseManifestFiles(vcsImpl vcs.VersionControlSystem, cfg *config.Config, updatedFiles []string) (string, []string, error) {
+	var files []string
+	if version.UsesFile() {
+		path, err := version.Path()
+		if err != nil {
+			return "", nil, err
+		}
+		files = append(files, path)
+	}
+	files = append(files, updatedFiles...)
+	for _, target := range cfg.Emit.Targets {
+		if info, err := os.Stat(target.Output); err == nil && !info.IsDir() {
+			files = append(files, target.Output)
+		}
+	}
+	extra, err := publish.ExpandAssets(cfg.Release.Manifest.Files)
+	if err != nil {
+		return "", nil, fmt.Errorf("error resolving manifest files: %w", err)
+	}
+	files = append(files, extra...)
+
+	root, err := vcsImpl.GetRepositoryRoot()
+	if err != nil {
+		return "", nil, err
+	}
+	rels := make([]string, 0, len(files))
+	for _, file := range files {
+		rel, err := repoRelativePath(root, file)
+		if err != nil {
+			return "", nil, fmt.Errorf("error building release manifest: %w", err)
+		}
+		rels = append(rels, rel)
+	}
+	return root, rels, nil
+}
+
+// repoRelativePath returns path, absolute or relative to the working
+// directory, relative to the repository root, resolving symlinks so both agree
+func repoRelativePath(root, path string) (string, error) {
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+		abs = filepath.Join(dir, filepath.Base(abs))
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the repository", path)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// appendReleaseChangelog adds the released version's changelog section,
+// truncated to the configured limits, and a footer linking to the full
+// changelog to the tag message
+func appendReleaseChangelog(message string, vd *version.Version, cfg *config.Config) (string, error) {
+	settings := cfg.Release.Changelog
+	file := settings.File
+	if file == "" {
+		file = changelog.DefaultFile
+	}
+	maxLines, maxBytes := settings.MaxLines, settings.MaxBytes
+	if maxLines == 0 {
+		maxLines = changelog.DefaultMaxLines
+	}
+	if maxBytes == 0 {
+		maxBytes = changelog.DefaultMaxBytes
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("error reading changelog: %w", err)
+	}
+	section, ok := changelog.Section(string(content), vd.String())
+	if !ok {
+		return "", fmt.Errorf("%s %s in %s", changelog.ErrNoSection, vd.String(), file)
+	}
+	section, dropped := changelog.Truncate(section, maxLines, maxBytes)
+
+	link := file
+	if settings.Link != "" {
+		renderer := emit.BuildCompleteRenderer(vd, cfg.PreRelease.Template, cfg.Metadata.Template)
+		if link, err = renderer.Render(settings.Link); err != nil {
+			return "", fmt.Errorf("error rendering changelog link: %w", err)
+		}
+	}
+	return changelog.Append(message, section, dropped, strings.TrimSpace(link)), nil
+}
+
 func init() {
 	rootCmd.AddCommand(releaseCmd)
 
@@ -325,6 +745,12 @@ func init() {
 	releaseCmd.Flags().BoolP("force", "f", false, "Force creation even if tag exists")
 	releaseCmd.Flags().BoolP("verbose", "v", false, "Show additional information")
 	releaseCmd.Flags().Bool("no-branch", false, "Skip creating release branch")
+	releaseCmd.Flags().Bool("manifest", false, "Append checksums of released files to the tag annotation")
+	releaseCmd.Flags().Bool("message-from-changelog", false, "Embed the version's CHANGELOG.md section in the tag annotation")
+	releaseCmd.Flags().StringSlice("trailer", nil, "Add a Versionator-Version trailer to the release commit and/or tag annotation (commit, tag)")
+	releaseCmd.Flags().String("bump-on-conflict", "", "If the tag exists on another commit, bump this level (major, minor, patch) until free")
+	releaseCmd.Flags().String("suffix-on-conflict", "", "If the tag exists on another commit, append this suffix (e.g. .1), incrementing it until free")
+	addPlanFlags(releaseCmd.Flags())
 
 	// Add push subcommand
 	releaseCmd.AddCommand(releasePushCmd)
@@ -335,4 +761,10 @@ func init() {
 	releasePushCmd.Flags().BoolP("force", "f", false, "Force creation even if tag exists")
 	releasePushCmd.Flags().BoolP("verbose", "v", false, "Show additional information")
 	releasePushCmd.Flags().Bool("no-branch", false, "Skip creating release branch")
+	releasePushCmd.Flags().Bool("manifest", false, "Append checksums of released files to the tag annotation")
+	releasePushCmd.Flags().Bool("message-from-changelog", false, "Embed the version's CHANGELOG.md section in the tag annotation")
+	releasePushCmd.Flags().StringSlice("trailer", nil, "Add a Versionator-Version trailer to the release commit and/or tag annotation (commit, tag)")
+	releasePushCmd.Flags().String("bump-on-conflict", "", "If the tag exists on another commit, bump this level (major, minor, patch) until free")
+	releasePushCmd.Flags().String("suffix-on-conflict", "", "If the tag exists on another commit, append this suffix (e.g. .1), incrementing it until free")
+	addPlanFlags(releasePushCmd.Flags())
 }
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/offline"
+	"github.com/benjaminabbitt/versionator/internal/publish"
+
+	"github.com/spf13/cobra"
+)
+
+var releasePublishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Create release, push it, and publish it on GitHub/GitLab/Gitea",
+	Long: `Create a release (tag and branch), push both to the remote, then create
+a hosted release on GitHub, GitLab, or Gitea through its API.
+
+The provider is configured in .versionator.yaml:
+  release:
+    publish:
+      provider: github              # github, gitlab, or gitea
+      repository: owner/name
+      apiUrl: ""                    # set for self-hosted instances
+      tokenEnv: GITHUB_TOKEN        # default: GITHUB_TOKEN / GITLAB_TOKEN / GITEA_TOKEN
+      title: "{{Prefix}}{{MajorMinorPatch}}{{PreReleaseWithDash}}"
+      notes: "Built from {{ShortHash}}"
+      assets:
+        - "dist/*.tar.gz"
+
+Title and notes are Mustache templates with the same variables as other
+templates. The release is marked as a pre-release when the version has a
+pre-release component (GitHub and Gitea). Asset patterns are resolved
+before anything is pushed; a pattern matching no files is an error.
+
+Example:
+  versionator release publish
+  versionator release publish --no-branch`,
+	RunE: runReleasePublish,
+}
+
+func init() {
+	releaseCmd.AddCommand(releasePublishCmd)
+
+	releasePublishCmd.Flags().StringP("message", "m", "", "Tag message (default: 'Release <version>')")
+	releasePublishCmd.Flags().StringP("prefix", "p", "v", "Tag prefix (default: 'v')")
+	releasePublishCmd.Flags().BoolP("force", "f", false, "Force creation even if tag exists")
+	releasePublishCmd.Flags().BoolP("verbose", "v", false, "Show additional information")
+	releasePublishCmd.Flags().Bool("no-branch", false, "Skip creating release branch")
+	releasePublishCmd.Flags().String("bump-on-conflict", "", "If the tag exists on another commit, bump this level (major, minor, patch) until free")
+	releasePublishCmd.Flags().String("suffix-on-conflict", "", "If the tag exists on another commit, append this suffix (e.g. .1), incrementing it until free")
+}
+
+func runReleasePublish(cmd *cobra.Command, args []string) error {
+	// Validate publish settings before creating or pushing anything
+	if err := offline.Require("release publish"); err != nil {
+		return err
+	}
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("error reading config: %w", err)
+	}
+	pub := cfg.Release.Publish
+	if !slices.Contains(publish.AvailableProviders(), pub.Provider) {
+		return fmt.Errorf("release publish provider must be one of: %s (got %q in release.publish.provider)",
+			strings.Join(publish.AvailableProviders(), ", "), pub.Provider)
+	}
+
+	tokenEnv := pub.TokenEnv
+	if tokenEnv == "" {
+		tokenEnv = publish.DefaultTokenEnv(pub.Provider)
+	}
+	token := os.Getenv(tokenEnv)
+	if token == "" {
+		return fmt.Errorf("%s: $%s is empty", publish.ErrMissingToken, tokenEnv)
+	}
+
+	publisher, err := publish.NewPublisher(pub.Provider, publish.Options{
+		APIURL:     pub.APIURL,
+		Repository: pub.Repository,
+		Token:      token,
+	})
+	if err != nil {
+		return err
+	}
+
+	assets, err := publish.ExpandAssets(pub.Assets)
+	if err != nil {
+		return err
+	}
+
+	result, err := runRelease(cmd)
+	if err != nil {
+		return err
+	}
+	if err := pushRelease(cmd, result); err != nil {
+		return err
+	}
+
+	renderer := emit.BuildCompleteRenderer(result.version, cfg.PreRelease.Template, cfg.Metadata.Template)
+
+	title := result.tagName
+	if pub.Title != "" {
+		if title, err = renderer.Render(pub.Title); err != nil {
+			return fmt.Errorf("error rendering release title: %w", err)
+		}
+	}
+	notes, err := renderer.Render(pub.Notes)
+	if err != nil {
+		return fmt.Errorf("error rendering release notes: %w", err)
+	}
+
+	cmd.Printf("Publishing release '%s' to %s...\n", result.tagName, publisher.Name())
+	published, err := publisher.Publish(publish.Release{
+		TagName:    result.tagName,
+		Title:      strings.TrimSpace(title),
+		Notes:      notes,
+		PreRelease: result.version.IsPreRelease(),
+		Assets:     assets,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish release: %w", err)
+	}
+
+	for _, name := range published.Assets {
+		cmd.Printf("Uploaded asset '%s'\n", name)
+	}
+	if published.URL != "" {
+		cmd.Printf("Successfully published release: %s\n", published.URL)
+	} else {
+		cmd.Printf("Successfully published release '%s'\n", result.tagName)
+	}
+	return nil
+}
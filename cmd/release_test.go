@@ -2,14 +2,28 @@ package cmd
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/benjaminabbitt/versionator/internal/changelog"
+	"github.com/benjaminabbitt/versionator/internal/plan"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
 	"github.com/benjaminabbitt/versionator/internal/vcs"
 	gitVCS "github.com/benjaminabbitt/versionator/internal/vcs/git"
 	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
+	"github.com/benjaminabbitt/versionator/internal/version"
 	"github.com/golang/mock/gomock"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -84,6 +98,10 @@ func (suite *ReleaseTestSuite) resetReleaseCommand() {
 	_ = releaseCmd.Flags().Set("force", "false")
 	_ = releaseCmd.Flags().Set("verbose", "false")
 	_ = releaseCmd.Flags().Set("no-branch", "false")
+	_ = releaseCmd.Flags().Set("manifest", "false")
+	_ = releaseCmd.Flags().Set("message-from-changelog", "false")
+	_ = releaseCmd.Flags().Set("bump-on-conflict", "")
+	_ = releaseCmd.Flags().Set("suffix-on-conflict", "")
 
 	// Reset release push command flags
 	_ = releasePushCmd.Flags().Set("message", "")
@@ -91,6 +109,26 @@ func (suite *ReleaseTestSuite) resetReleaseCommand() {
 	_ = releasePushCmd.Flags().Set("force", "false")
 	_ = releasePushCmd.Flags().Set("verbose", "false")
 	_ = releasePushCmd.Flags().Set("no-branch", "false")
+	_ = releasePushCmd.Flags().Set("manifest", "false")
+	_ = releasePushCmd.Flags().Set("message-from-changelog", "false")
+	_ = releasePushCmd.Flags().Set("bump-on-conflict", "")
+	_ = releasePushCmd.Flags().Set("suffix-on-conflict", "")
+	for _, c := range []*cobra.Command{releaseCmd, releasePushCmd} {
+		_ = c.Flags().Set("plan", "")
+		_ = c.Flags().Set("apply-plan", "")
+		trailerFlag := c.Flags().Lookup("trailer")
+		_ = trailerFlag.Value.(pflag.SliceValue).Replace(nil)
+		trailerFlag.Changed = false
+	}
+
+	// Reset release publish command flags
+	_ = releasePublishCmd.Flags().Set("message", "")
+	_ = releasePublishCmd.Flags().Set("prefix", "v")
+	_ = releasePublishCmd.Flags().Set("force", "false")
+	_ = releasePublishCmd.Flags().Set("verbose", "false")
+	_ = releasePublishCmd.Flags().Set("no-branch", "false")
+	_ = releasePublishCmd.Flags().Set("bump-on-conflict", "")
+	_ = releasePublishCmd.Flags().Set("suffix-on-conflict", "")
 }
 
 // createTestFiles creates the standard test files needed for most tests
@@ -209,6 +247,43 @@ func (suite *ReleaseTestSuite) TestReleaseCommand_CustomPrefix() {
 	suite.Contains(output, "Successfully created tag 'release-2.0.0'", "Should contain success message with custom prefix")
 }
 
+// TestReleaseCommand_TagFormat validates release.tagFormat naming.
+//
+// Why: Monorepos tag each component separately (myapp-v1.0.0,
+// other-v2.0.0), so tags cannot be the bare version.
+//
+// What: Given tagFormat "myapp-v{{Version}}" and VERSION=2.0.0, release
+// creates tag "myapp-v2.0.0"; the --prefix default does not apply.
+func (suite *ReleaseTestSuite) TestReleaseCommand_TagFormat() {
+	// Precondition: VERSION 2.0.0, a component tag format, no branches
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("2.0.0"), 0644))
+	config := "release:\n  createBranch: false\n  tagFormat: \"myapp-v{{Version}}\"\n"
+	suite.Require().NoError(os.WriteFile(".versionator.yaml", []byte(config), 0644))
+	suite.T().Cleanup(func() { _ = tagformat.Set("") })
+
+	// Precondition: VCS is clean, no existing tag
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	mockVCS.EXPECT().TagExists("myapp-v2.0.0").Return(false, nil)
+	mockVCS.EXPECT().CreateTag("myapp-v2.0.0", "Release 2.0.0").Return(nil)
+
+	vcs.RegisterVCS(mockVCS)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"release"})
+
+	// Action: Execute release
+	err := rootCmd.Execute()
+
+	// Expected: Tag follows the format
+	suite.Require().NoError(err, "release command should succeed")
+	suite.Contains(buf.String(), "Successfully created tag 'myapp-v2.0.0'")
+}
+
 // TestReleaseCommand_CustomMessage validates that the --message flag overrides
 // the default "Release X.Y.Z" tag message.
 //
@@ -356,6 +431,45 @@ func (suite *ReleaseTestSuite) TestReleaseCommand_AutoCommitVersionFile() {
 	suite.Contains(output, "Successfully created tag 'v1.2.3'", "Should contain success message")
 }
 
+// TestReleaseCommand_JavaSnapshot_StripsSnapshotBeforeTagging validates the
+// Maven SNAPSHOT release step.
+//
+// Why: Maven projects keep 1.2.3-SNAPSHOT in VERSION during development; the
+// release must be the plain version, as with the Maven release plugin.
+// What: Given VERSION=1.2.3-SNAPSHOT and java.snapshot auto, when release
+// runs, then VERSION is rewritten to 1.2.3, committed, and tagged v1.2.3.
+func (suite *ReleaseTestSuite) TestReleaseCommand_JavaSnapshot_StripsSnapshotBeforeTagging() {
+	// Precondition: SNAPSHOT VERSION with java.snapshot auto
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.2.3-SNAPSHOT\n"), 0644))
+	suite.Require().NoError(os.WriteFile(".versionator.yaml", []byte("prefix: \"\"\nrelease:\n  createBranch: false\njava:\n  snapshot: auto\n"), 0644))
+
+	// Precondition: VCS reports clean working directory
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	mockVCS.EXPECT().TagExists("v1.2.3").Return(false, nil)
+	mockVCS.EXPECT().CommitFiles([]string{"VERSION"}, "Release 1.2.3").Return(nil)
+	mockVCS.EXPECT().CreateTag("v1.2.3", "Release 1.2.3").Return(nil)
+
+	vcs.RegisterVCS(mockVCS)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"release"})
+
+	// Action: Execute release
+	err := rootCmd.Execute()
+
+	// Expected: SNAPSHOT stripped from VERSION and the plain version tagged
+	suite.Require().NoError(err, "release command should succeed")
+	suite.Contains(buf.String(), "Stripped SNAPSHOT: releasing 1.2.3")
+	data, err := os.ReadFile("VERSION")
+	suite.Require().NoError(err)
+	suite.Equal("1.2.3\n", string(data))
+}
+
 // =============================================================================
 // ERROR HANDLING
 // Tests for expected failure modes that should produce clear error messages
@@ -528,6 +642,128 @@ func (suite *ReleaseTestSuite) TestReleaseCommand_TagExists_WithForce() {
 	suite.Contains(output, "Successfully created tag 'v1.0.0'", "Should contain success message")
 }
 
+// TestReleaseCommand_TagExists_BumpOnConflict validates the bump strategy.
+//
+// Why: Unattended pipelines must not fail when the computed tag was already
+// used by another commit; bumping gives the release the next free number.
+//
+// What: Given v1.0.0 and v1.0.1 exist elsewhere, --bump-on-conflict patch
+// bumps VERSION to 1.0.2, commits it, and tags v1.0.2.
+func (suite *ReleaseTestSuite) TestReleaseCommand_TagExists_BumpOnConflict() {
+	// Precondition: VERSION 1.0.0; v1.0.0 and v1.0.1 tag other commits
+	suite.createTestFilesWithRelease("1.0.0", false)
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	mockVCS.EXPECT().GetVCSIdentifier(40).Return("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", nil).AnyTimes()
+	for _, tag := range []string{"v1.0.0", "v1.0.1"} {
+		mockVCS.EXPECT().TagExists(tag).Return(true, nil)
+		mockVCS.EXPECT().GetTagCommit(tag).Return("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", nil)
+	}
+	mockVCS.EXPECT().TagExists("v1.0.2").Return(false, nil)
+	mockVCS.EXPECT().CommitFiles([]string{"VERSION"}, "Release 1.0.2").Return(nil)
+	mockVCS.EXPECT().CreateTag("v1.0.2", "Release 1.0.2").Return(nil)
+	vcs.RegisterVCS(mockVCS)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"release", "--bump-on-conflict", "patch"})
+
+	// Action: Release with the bump strategy
+	err := rootCmd.Execute()
+
+	// Expected: Next free patch tagged and VERSION bumped
+	suite.Require().NoError(err)
+	suite.Contains(buf.String(), "Successfully created tag 'v1.0.2'")
+	content, err := os.ReadFile("VERSION")
+	suite.Require().NoError(err)
+	suite.Equal("1.0.2", strings.TrimSpace(string(content)))
+}
+
+// TestReleaseCommand_BumpOnConflict_ConcurrentBump_Refused validates that
+// the bumped version does not overwrite a concurrent change.
+//
+// Why: The bump is computed from VERSION as loaded before the tag lookups;
+// saving it blindly would undo a 'bump' that ran meanwhile.
+//
+// What: When VERSION changes to 1.1.0 during the tag lookups, release fails
+// with version.ErrVersionChanged, keeps 1.1.0, and creates no tag.
+func (suite *ReleaseTestSuite) TestReleaseCommand_BumpOnConflict_ConcurrentBump_Refused() {
+	// Precondition: VERSION 1.0.0; v1.0.0 tags another commit; VERSION is
+	// bumped by another invocation while the tags are looked up
+	suite.createTestFilesWithRelease("1.0.0", false)
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	mockVCS.EXPECT().GetVCSIdentifier(40).Return("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", nil).AnyTimes()
+	mockVCS.EXPECT().TagExists("v1.0.0").Return(true, nil)
+	mockVCS.EXPECT().GetTagCommit("v1.0.0").DoAndReturn(func(string) (string, error) {
+		return "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", os.WriteFile("VERSION", []byte("1.1.0\n"), 0644)
+	})
+	mockVCS.EXPECT().TagExists("v1.0.1").Return(false, nil)
+	vcs.RegisterVCS(mockVCS)
+
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"release", "--bump-on-conflict", "patch"})
+
+	// Action
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), version.ErrVersionChanged)
+	content, err := os.ReadFile("VERSION")
+	suite.Require().NoError(err)
+	suite.Equal("1.1.0", strings.TrimSpace(string(content)))
+}
+
+// TestReleaseCommand_TagExists_SuffixOnConflict validates the suffix
+// strategy from config.
+//
+// Why: Some teams must not change VERSION after the fact; a suffixed tag
+// still gives the build a unique, traceable name.
+//
+// What: Given release.onConflict.suffix ".1" and v1.0.0 and v1.0.0.1 taken,
+// release tags v1.0.0.2 and leaves VERSION alone.
+func (suite *ReleaseTestSuite) TestReleaseCommand_TagExists_SuffixOnConflict() {
+	// Precondition: Suffix strategy configured; two tags taken
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.0.0"), 0644))
+	configContent := "release:\n  createBranch: false\n  onConflict:\n    suffix: \".1\"\n"
+	suite.Require().NoError(os.WriteFile(".versionator.yaml", []byte(configContent), 0644))
+
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	mockVCS.EXPECT().GetVCSIdentifier(40).Return("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", nil).AnyTimes()
+	for _, tag := range []string{"v1.0.0", "v1.0.0.1"} {
+		mockVCS.EXPECT().TagExists(tag).Return(true, nil)
+		mockVCS.EXPECT().GetTagCommit(tag).Return("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", nil)
+	}
+	mockVCS.EXPECT().TagExists("v1.0.0.2").Return(false, nil)
+	mockVCS.EXPECT().CreateTag("v1.0.0.2", "Release 1.0.0").Return(nil)
+	vcs.RegisterVCS(mockVCS)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"release"})
+
+	// Action: Release with the configured suffix strategy
+	err := rootCmd.Execute()
+
+	// Expected: First free suffix tagged; VERSION unchanged
+	suite.Require().NoError(err)
+	suite.Contains(buf.String(), "Successfully created tag 'v1.0.0.2'")
+	content, err := os.ReadFile("VERSION")
+	suite.Require().NoError(err)
+	suite.Equal("1.0.0", strings.TrimSpace(string(content)))
+}
+
 // TestReleaseCommand_NoVersionFile validates that the release command uses
 // a default version when no VERSION file exists.
 //
@@ -601,18 +837,18 @@ func (suite *ReleaseTestSuite) TestReleaseCommand_BranchAlreadyExists() {
 
 	vcs.RegisterVCS(mockVCS)
 
-	var buf bytes.Buffer
+	var buf, errBuf bytes.Buffer
 	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&errBuf)
 	rootCmd.SetArgs([]string{"release"})
 
 	// Action: Execute release when branch already exists at different commit
 	err := rootCmd.Execute()
 
-	// Expected: Tag created, warning about existing branch
+	// Expected: Tag created, warning about existing branch on stderr
 	suite.Require().NoError(err, "release command should succeed")
-	output := buf.String()
-	suite.Contains(output, "Successfully created tag 'v1.0.0'", "Should contain tag success message")
-	suite.Contains(output, "Warning: branch 'release/v1.0.0' exists at", "Should warn about existing branch at different commit")
+	suite.Contains(buf.String(), "Successfully created tag 'v1.0.0'", "Should contain tag success message")
+	suite.Contains(errBuf.String(), "Warning: branch 'release/v1.0.0' exists at", "Should warn about existing branch at different commit")
 }
 
 // TestReleaseCommand_BranchAlreadyAtHead validates the idempotent branch path:
@@ -1043,11 +1279,525 @@ func (suite *ReleaseTestSuite) TestReleasePushCommand_NoBranch() {
 	suite.NotContains(output, "branch")
 }
 
+// writePublishConfig writes a config enabling GitHub publishing against apiURL
+func (suite *ReleaseTestSuite) writePublishConfig(apiURL string) {
+	configContent := `prefix: ""
+release:
+  createBranch: false
+  publish:
+    provider: github
+    repository: acme/widget
+    apiUrl: %s
+    title: "Widget {{MajorMinorPatch}}"
+    notes: "Release notes for {{MajorMinorPatch}}"
+`
+	err := os.WriteFile(".versionator.yaml", []byte(fmt.Sprintf(configContent, apiURL)), 0644)
+	suite.Require().NoError(err, "Failed to create config file")
+}
+
+// TestReleasePublishCommand_Success validates that release publish tags,
+// pushes, and creates a hosted release with rendered title and notes.
+//
+// Why: Teams want a single command that produces a visible release on their
+// hosting provider, not just a tag.
+//
+// What: Run "release publish" against a fake GitHub API; verify the tag is
+// pushed and the API receives the rendered title and notes.
+func (suite *ReleaseTestSuite) TestReleasePublishCommand_Success() {
+	// Precondition: Fake GitHub API capturing the create-release payload
+	var payload map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("/repos/acme/widget/releases", r.URL.Path)
+		suite.Equal("Bearer test-token", r.Header.Get("Authorization"))
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"html_url":"https://github.com/acme/widget/releases/v1.0.0"}`))
+	}))
+	defer server.Close()
+
+	err := os.WriteFile("VERSION", []byte("1.0.0"), 0644)
+	suite.Require().NoError(err)
+	suite.writePublishConfig(server.URL)
+	suite.T().Setenv("GITHUB_TOKEN", "test-token")
+
+	// Precondition: VCS is clean and tag/push succeed
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	mockVCS.EXPECT().TagExists("v1.0.0").Return(false, nil)
+	mockVCS.EXPECT().CreateTag("v1.0.0", "Release 1.0.0").Return(nil)
+	mockVCS.EXPECT().PushTag("v1.0.0").Return(nil)
+	mockVCS.EXPECT().GetVCSIdentifier(gomock.Any()).Return("", fmt.Errorf("unavailable")).AnyTimes()
+	mockVCS.EXPECT().GetBranchName().Return("main", nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitDate().Return(time.Time{}, fmt.Errorf("unavailable")).AnyTimes()
+	mockVCS.EXPECT().GetCommitsSinceTag().Return(0, nil).AnyTimes()
+	mockVCS.EXPECT().GetLastTagCommit().Return("", nil).AnyTimes()
+	mockVCS.EXPECT().GetUncommittedChanges().Return(0, nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitAuthor().Return("", nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitAuthorEmail().Return("", nil).AnyTimes()
+
+	vcs.RegisterVCS(mockVCS)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"release", "publish"})
+
+	// Action: Execute release publish
+	err = rootCmd.Execute()
+
+	// Expected: Tag pushed and release created with rendered templates
+	suite.Require().NoError(err, "release publish should succeed")
+	suite.Contains(buf.String(), "Successfully pushed tag 'v1.0.0'")
+	suite.Contains(buf.String(), "Successfully published release: https://github.com/acme/widget/releases/v1.0.0")
+	suite.Equal("v1.0.0", payload["tag_name"])
+	suite.Equal("Widget 1.0.0", payload["name"])
+	suite.Equal("Release notes for 1.0.0", payload["body"])
+	suite.Equal(false, payload["prerelease"])
+}
+
+// TestReleasePublishCommand_MissingToken_FailsBeforeTagging validates that
+// publish settings are checked before any VCS changes are made.
+//
+// Why: A missing token discovered after pushing would leave a tag without a
+// hosted release.
+//
+// What: With no token in the environment, the command fails and no tag is
+// created (the mock has no tag expectations).
+func (suite *ReleaseTestSuite) TestReleasePublishCommand_MissingToken_FailsBeforeTagging() {
+	// Precondition: Publish configured, token unset
+	err := os.WriteFile("VERSION", []byte("1.0.0"), 0644)
+	suite.Require().NoError(err)
+	suite.writePublishConfig("http://127.0.0.1:0")
+	suite.T().Setenv("GITHUB_TOKEN", "")
+
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	vcs.RegisterVCS(mockVCS)
+
+	rootCmd.SetArgs([]string{"release", "publish"})
+
+	// Action: Execute release publish
+	err = rootCmd.Execute()
+
+	// Expected: Token error naming the environment variable
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "$GITHUB_TOKEN")
+}
+
 // =============================================================================
 // TEST SUITE RUNNER
 // =============================================================================
 
 // TestReleaseTestSuite runs the release test suite
+// recordingHook captures lifecycle events fired by commands
+type recordingHook struct {
+	events []plugin.Event
+	vars   map[string]string
+}
+
+func (h *recordingHook) Name() string                { return "test-recorder" }
+func (h *recordingHook) Types() plugin.PluginTypeSet { return plugin.NewPluginTypeSet(plugin.TypeHook) }
+func (h *recordingHook) OnEvent(event plugin.Event, vars map[string]string) error {
+	h.events = append(h.events, event)
+	h.vars = vars
+	return nil
+}
+
+// TestReleaseCommand_FiresTagHook validates that registered hooks are notified
+// after a tag is created.
+//
+// Why: Notification hooks (e.g., the built-in webhook) announce releases and
+// need the version variables of the tagged release.
+// What: Given a registered hook, when release creates tag v1.2.3, then the hook
+// receives a single "tag" event with MajorMinorPatch=1.2.3.
+func (suite *ReleaseTestSuite) TestReleaseCommand_FiresTagHook() {
+	// Precondition: Recording hook registered for this test only
+	hook := &recordingHook{}
+	plugin.Register(hook)
+	defer plugin.Unregister(hook.Name())
+
+	// Precondition: Clean repository, no existing tag, branch creation disabled
+	suite.createTestFilesWithRelease("1.2.3", false)
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	mockVCS.EXPECT().TagExists("v1.2.3").Return(false, nil)
+	mockVCS.EXPECT().CreateTag("v1.2.3", "Release 1.2.3").Return(nil)
+	mockVCS.EXPECT().GetVCSIdentifier(gomock.Any()).Return("abc1234", nil).AnyTimes()
+	mockVCS.EXPECT().GetBranchName().Return("master", nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitDate().Return(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitsSinceTag().Return(0, nil).AnyTimes()
+	mockVCS.EXPECT().GetLastTagCommit().Return("abc1234", nil).AnyTimes()
+	mockVCS.EXPECT().GetUncommittedChanges().Return(0, nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitAuthor().Return("Test Author", nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitAuthorEmail().Return("test@example.com", nil).AnyTimes()
+	vcs.RegisterVCS(mockVCS)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"release"})
+
+	// Action: Execute the release command
+	err := rootCmd.Execute()
+
+	// Expected: One tag event carrying the released version
+	suite.Require().NoError(err, "release command should succeed")
+	suite.Equal([]plugin.Event{plugin.EventTag}, hook.events)
+	suite.Equal("1.2.3", hook.vars["MajorMinorPatch"])
+	suite.NotContains(buf.String(), "Warning", "Hook should not report failures")
+}
+
+// TestReleaseCommand_ManifestFlag validates the artifact manifest in the tag
+// annotation.
+//
+// Why: Audits need to confirm which released files correspond to a tag.
+// What: Given VERSION=1.2.3, when release runs with --manifest, the tag message
+// is the default message followed by the VERSION checksum in sha256sum format.
+func (suite *ReleaseTestSuite) TestReleaseCommand_ManifestFlag() {
+	// Precondition: Clean repository, no existing tag, branch creation disabled
+	suite.createTestFilesWithRelease("1.2.3", false)
+	sum := sha256.Sum256([]byte("1.2.3"))
+	expectedMessage := "Release 1.2.3\n\nArtifacts (sha256):\n" + hex.EncodeToString(sum[:]) + "  VERSION"
+
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	mockVCS.EXPECT().TagExists("v1.2.3").Return(false, nil)
+	mockVCS.EXPECT().CreateTag("v1.2.3", expectedMessage).Return(nil)
+	vcs.RegisterVCS(mockVCS)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"release", "--manifest"})
+
+	// Action: Execute release with --manifest
+	err := rootCmd.Execute()
+
+	// Expected: Command succeeds (mock verified the manifest in the message)
+	suite.Require().NoError(err, "release command should succeed")
+}
+
+// TestReleaseCommand_ManifestFlag_IncludesEmitOutputs validates emitted
+// files in the manifest.
+//
+// Why: Generated version files (e.g. version.go) ship in the release; an
+// audit must be able to confirm which generated artifacts belonged to it
+// without each one being repeated in release.manifest.files.
+// What: Given emit targets whose output exists and one not generated yet,
+// the manifest lists VERSION and the existing output, skipping the missing
+// one.
+func (suite *ReleaseTestSuite) TestReleaseCommand_ManifestFlag_IncludesEmitOutputs() {
+	// Precondition: An emitted version file and a target not yet generated
+	suite.createTestFilesWithRelease("1.2.3", false)
+	cfg, err := os.OpenFile(".versionator.yaml", os.O_APPEND|os.O_WRONLY, 0)
+	suite.Require().NoError(err)
+	_, err = cfg.WriteString("emit:\n  targets:\n    - format: go\n      output: version.go\n    - format: python\n      output: _version.py\n")
+	suite.Require().NoError(err)
+	suite.Require().NoError(cfg.Close())
+	suite.Require().NoError(os.WriteFile("version.go", []byte("package main\n"), 0644))
+	versionSum := sha256.Sum256([]byte("1.2.3"))
+	emittedSum := sha256.Sum256([]byte("package main\n"))
+	expectedMessage := "Release 1.2.3\n\nArtifacts (sha256):\n" +
+		hex.EncodeToString(versionSum[:]) + "  VERSION\n" +
+		hex.EncodeToString(emittedSum[:]) + "  version.go"
+
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	mockVCS.EXPECT().TagExists("v1.2.3").Return(false, nil)
+	mockVCS.EXPECT().CreateTag("v1.2.3", expectedMessage).Return(nil)
+	vcs.RegisterVCS(mockVCS)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"release", "--manifest"})
+
+	// Action: Execute release with --manifest
+	err = rootCmd.Execute()
+
+	// Expected: Command succeeds (mock verified the manifest in the message)
+	suite.Require().NoError(err, "release command should succeed")
+}
+
+// TestReleaseCommand_ManifestFlag_FromSubdirectory validates manifest paths
+// outside the repository root.
+//
+// Why: VERSION is found by walking up from the working directory; a release
+// run from a subdirectory must still checksum it, and list it the way
+// `sha256sum -c` at the repository root finds it.
+// What: Given VERSION=1.2.3 at the root, when release --manifest runs in a
+// subdirectory, the manifest lists VERSION by its path from the root.
+func (suite *ReleaseTestSuite) TestReleaseCommand_ManifestFlag_FromSubdirectory() {
+	// Precondition: VERSION at the repository root, working directory below it
+	suite.createTestFilesWithRelease("1.2.3", false)
+	suite.Require().NoError(os.Mkdir("sub", 0755))
+	suite.Require().NoError(os.Chdir("sub"))
+	sum := sha256.Sum256([]byte("1.2.3"))
+	expectedMessage := "Release 1.2.3\n\nArtifacts (sha256):\n" + hex.EncodeToString(sum[:]) + "  VERSION"
+
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	mockVCS.EXPECT().TagExists("v1.2.3").Return(false, nil)
+	mockVCS.EXPECT().CreateTag("v1.2.3", expectedMessage).Return(nil)
+	vcs.RegisterVCS(mockVCS)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"release", "--manifest", "--no-branch"})
+
+	// Action: Execute release with --manifest from the subdirectory
+	err := rootCmd.Execute()
+
+	// Expected: Command succeeds (mock verified the manifest in the message)
+	suite.Require().NoError(err, "release command should succeed")
+}
+
+// TestReleasePush_Plan_DescribesTagBranchAndPush validates release --plan.
+//
+// Why: Reviewers approve the tag and branch a release creates, and what it
+// pushes, before anything reaches the repository or the remote.
+// What: Given VERSION=1.2.3 and branch creation enabled, 'release push
+// --plan json' prints the tag, branch, push and HEAD on stdout, and creates
+// nothing.
+func (suite *ReleaseTestSuite) TestReleasePush_Plan_DescribesTagBranchAndPush() {
+	// Precondition: Clean repository, no tag or branch yet
+	suite.createTestFilesWithRelease("1.2.3", true)
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	mockVCS.EXPECT().TagExists("v1.2.3").Return(false, nil)
+	mockVCS.EXPECT().BranchExists("release/v1.2.3").Return(false, nil)
+	mockVCS.EXPECT().GetVCSIdentifier(gomock.Any()).Return("abc123", nil).AnyTimes()
+	vcs.RegisterVCS(mockVCS)
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"release", "push", "--plan", "json"})
+
+	// Action: Plan the release
+	err := rootCmd.Execute()
+
+	// Expected: The tag, branch and push are described (the mock allows no
+	// tag or branch creation)
+	suite.Require().NoError(err, "release push --plan should succeed: %s", stderr.String())
+	var p plan.Plan
+	suite.Require().NoError(json.Unmarshal(stdout.Bytes(), &p), "output should be a JSON plan: %s", stdout.String())
+	suite.Equal("release push", p.Command)
+	suite.Equal("abc123", p.Head)
+	suite.Equal(plan.VersionChange{File: "VERSION", Old: "1.2.3", New: "1.2.3"}, p.Version)
+	suite.Nil(p.Commit)
+	suite.Equal(&plan.TagAction{Name: "v1.2.3", Message: "Release 1.2.3"}, p.Tag)
+	suite.Equal("release/v1.2.3", p.Branch)
+	suite.Equal(&plan.PushAction{Tag: "v1.2.3", Branch: "release/v1.2.3"}, p.Push)
+	suite.Equal([]string{"tag"}, p.Events)
+}
+
+// TestRelease_PlanAndApply_CreatesPlannedTag validates release --apply-plan.
+//
+// Why: The approved tag, not a fresh computation, must be what is created,
+// with the manifest checksumming the files as they are when it is applied.
+// What: Given VERSION=1.2.3, a plan from 'release --manifest --trailer tag'
+// creates v1.2.3 with the default message, the VERSION checksum and the
+// trailer when applied, and creates nothing while planning.
+func (suite *ReleaseTestSuite) TestRelease_PlanAndApply_CreatesPlannedTag() {
+	// Precondition: Clean repository, branch creation disabled
+	suite.createTestFilesWithRelease("1.2.3", false)
+	sum := sha256.Sum256([]byte("1.2.3"))
+	expectedMessage := "Release 1.2.3\n\nArtifacts (sha256):\n" + hex.EncodeToString(sum[:]) + "  VERSION\n\nVersionator-Version: 1.2.3"
+
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+	mockVCS.EXPECT().TagExists("v1.2.3").Return(false, nil).Times(2)
+	mockVCS.EXPECT().GetVCSIdentifier(gomock.Any()).Return("abc123", nil).AnyTimes()
+	mockVCS.EXPECT().CreateTag("v1.2.3", expectedMessage).Return(nil)
+	vcs.RegisterVCS(mockVCS)
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"release", "--manifest", "--trailer", "tag", "--plan", "json"})
+
+	// Action: Plan the release
+	err := rootCmd.Execute()
+
+	// Expected: The plan lists VERSION for the manifest
+	suite.Require().NoError(err)
+	var p plan.Plan
+	suite.Require().NoError(json.Unmarshal(stdout.Bytes(), &p))
+	suite.Require().NotNil(p.Tag)
+	suite.Equal([]string{"VERSION"}, p.Tag.Manifest)
+	suite.Require().NoError(os.WriteFile("plan.json", stdout.Bytes(), 0644))
+
+	// Action: Apply the plan
+	suite.resetReleaseCommand()
+	stdout.Reset()
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"release", "--apply-plan", "plan.json"})
+	err = rootCmd.Execute()
+
+	// Expected: Tag created (mock verified the message)
+	suite.Require().NoError(err)
+	suite.Contains(stdout.String(), "Created tag 'v1.2.3'")
+}
+
+// TestRelease_ApplyPlan_TagCreatedSince_Refuses validates stale release plans.
+//
+// Why: A tag created since the review may mark a different commit; applying
+// would commit a release for a version that is already taken.
+// What: When the planned tag exists by the time the plan is applied, apply
+// fails with ErrStaleTag and VERSION keeps its SNAPSHOT qualifier.
+func (suite *ReleaseTestSuite) TestRelease_ApplyPlan_TagCreatedSince_Refuses() {
+	// Precondition: A plan releasing 1.2.3-SNAPSHOT; v1.2.3 now exists
+	suite.createTestFilesWithRelease("1.2.3-SNAPSHOT", false)
+	suite.Require().NoError(os.WriteFile("plan.json", []byte(`{"schemaVersion": 1, "command": "release", "head": "abc123",
+		"version": {"file": "VERSION", "old": "1.2.3-SNAPSHOT", "new": "1.2.3"},
+		"commit": {"amend": false, "files": ["VERSION"], "message": "Release 1.2.3"},
+		"tag": {"name": "v1.2.3", "message": "Release 1.2.3"}}`), 0644))
+
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().GetVCSIdentifier(gomock.Any()).Return("abc123", nil).AnyTimes()
+	mockVCS.EXPECT().TagExists("v1.2.3").Return(true, nil)
+	vcs.RegisterVCS(mockVCS)
+
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"release", "--apply-plan", "plan.json"})
+
+	// Action: Apply the stale plan
+	err := rootCmd.Execute()
+
+	// Expected: Refused (the mock allows no commit or tag), VERSION unchanged
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), plan.ErrStaleTag)
+	content, _ := os.ReadFile("VERSION")
+	suite.Equal("1.2.3-SNAPSHOT", string(content))
+}
+
+// TestReleaseCommand_MessageFromChangelog validates the changelog section in
+// the tag annotation.
+//
+// Why: `git show v1.2.3` and hosting UIs should say what changed in the
+// release, without copying notes by hand.
+// What: Given VERSION=1.2.3 and a CHANGELOG.md section for it, when release
+// runs with --message-from-changelog, the tag message is the default message,
+// the section without its heading, and a link to the full file. Without a
+// section for the version, release fails before tagging.
+func (suite *ReleaseTestSuite) TestReleaseCommand_MessageFromChangelog() {
+	// Precondition: Clean repository, a changelog with 1.2.3 and 1.2.2
+	suite.createTestFilesWithRelease("1.2.3", false)
+	suite.Require().NoError(os.WriteFile("CHANGELOG.md",
+		[]byte("# Changelog\n\n## [1.2.3] - 2024-12-11\n\n- feature\n\n## [1.2.2]\n\n- fix\n"), 0644))
+	expectedMessage := "Release 1.2.3\n\n- feature\n\nFull changelog: CHANGELOG.md"
+
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(true, nil).Times(2)
+	mockVCS.EXPECT().TagExists("v1.2.3").Return(false, nil).Times(2)
+	mockVCS.EXPECT().CreateTag("v1.2.3", expectedMessage).Return(nil)
+	vcs.RegisterVCS(mockVCS)
+	rootCmd.SetOut(&bytes.Buffer{})
+
+	// Action: Release with the section present
+	rootCmd.SetArgs([]string{"release", "--message-from-changelog"})
+	err := rootCmd.Execute()
+
+	// Expected: Command succeeds (mock verified the message)
+	suite.Require().NoError(err, "release command should succeed")
+
+	// Precondition: The section is missing
+	suite.Require().NoError(os.WriteFile("CHANGELOG.md", []byte("## [1.2.2]\n\n- fix\n"), 0644))
+
+	// Action
+	rootCmd.SetArgs([]string{"release", "--message-from-changelog"})
+	err = rootCmd.Execute()
+
+	// Expected: No second tag is created
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), changelog.ErrNoSection)
+}
+
+// TestReleaseCommand_TrailerFlag validates the Versionator-Version trailer.
+//
+// Why: Tooling finds release commits by trailer search instead of guessing
+// from tag names.
+// What: Given a dirty VERSION=1.2.3, when release runs with --trailer
+// commit,tag, both the release commit and the tag annotation end with
+// "Versionator-Version: 1.2.3".
+func (suite *ReleaseTestSuite) TestReleaseCommand_TrailerFlag() {
+	// Precondition: Only VERSION dirty, branch creation disabled
+	suite.createTestFilesWithRelease("1.2.3", false)
+	expectedMessage := "Release 1.2.3\n\nVersionator-Version: 1.2.3"
+
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	mockVCS.EXPECT().IsWorkingDirectoryClean().Return(false, nil)
+	mockVCS.EXPECT().GetDirtyFiles().Return([]string{"VERSION"}, nil)
+	mockVCS.EXPECT().CommitFiles([]string{"VERSION"}, expectedMessage).Return(nil)
+	mockVCS.EXPECT().TagExists("v1.2.3").Return(false, nil)
+	mockVCS.EXPECT().CreateTag("v1.2.3", expectedMessage).Return(nil)
+	vcs.RegisterVCS(mockVCS)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"release", "--trailer", "commit,tag"})
+
+	// Action: Execute release with --trailer
+	err := rootCmd.Execute()
+
+	// Expected: Command succeeds (mock verified both messages)
+	suite.Require().NoError(err, "release command should succeed")
+}
+
+// TestReleaseCommand_TrailerFlag_Invalid validates --trailer values.
+//
+// Why: A typo such as "tags" must not silently release without a trailer.
+// What: --trailer tags fails before anything is committed or tagged.
+func (suite *ReleaseTestSuite) TestReleaseCommand_TrailerFlag_Invalid() {
+	// Precondition: Clean repository
+	suite.createTestFilesWithRelease("1.2.3", false)
+	mockVCS := mock.NewMockVersionControlSystem(suite.ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(suite.tempDir, nil).AnyTimes()
+	vcs.RegisterVCS(mockVCS)
+
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"release", "--trailer", "tags"})
+
+	// Action
+	err := rootCmd.Execute()
+
+	// Expected: Invalid value reported
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "invalid --trailer 'tags'")
+}
+
 func TestReleaseTestSuite(t *testing.T) {
 	suite.Run(t, new(ReleaseTestSuite))
 }
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/version"
+	"github.com/benjaminabbitt/versionator/internal/versionator"
+
+	"github.com/spf13/cobra"
+)
+
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render a Mustache template without changing anything",
+	Long: `Render a Mustache template file against supplied or current template data.
+
+Nothing is written besides --output: VERSION is not created or changed and
+the repository is only read. Use it to try templates locally, or in CI to
+check that templates render (a template error exits non-zero).
+
+--data takes a snapshot written by 'versionator snapshot', or a JSON object
+with the variables shown by 'vars' (custom variables under "Custom"). Without
+--data, the current version and repository state are used, with custom
+variables from the config. --set-file sets a variable to a file's contents;
+--set takes precedence over it.
+
+Examples:
+  versionator render --template version.go.tmpl
+  versionator render --data data.json --template version.go.tmpl
+  versionator render --template release-notes.tmpl --set Channel=beta --output notes.md`,
+	Args: cobra.NoArgs,
+	RunE: runRender,
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+
+	renderCmd.Flags().String("template", "", "Template file to render (required)")
+	renderCmd.Flags().String("data", "", "JSON template data or snapshot (default: current state)")
+	renderCmd.Flags().StringArray("set", nil, "Set custom variable (key=value), can be repeated")
+	renderCmd.Flags().StringArray("set-file", nil, "Set custom variable to a file's contents (key=path), can be repeated")
+	renderCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
+	_ = renderCmd.MarkFlagRequired("template")
+}
+
+func runRender(cmd *cobra.Command, args []string) error {
+	templateFile, _ := cmd.Flags().GetString("template")
+	content, err := os.ReadFile(templateFile)
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+
+	var data emit.TemplateData
+	if dataFile, _ := cmd.Flags().GetString("data"); dataFile != "" {
+		if data, err = emit.ReadTemplateData(dataFile); err != nil {
+			return err
+		}
+	} else if data, err = currentTemplateData(); err != nil {
+		return err
+	}
+	setFiles, _ := cmd.Flags().GetStringArray("set-file")
+	sets, _ := cmd.Flags().GetStringArray("set")
+	extraVars, err := customFlagVars(setFiles, sets)
+	if err != nil {
+		return err
+	}
+	emit.MergeCustomVars(&data, extraVars)
+
+	rendered, err := emit.NewRenderer(data).Render(string(content))
+	if err != nil {
+		return fmt.Errorf("%s: %w", templateFile, err)
+	}
+
+	outputFile, _ := cmd.Flags().GetString("output")
+	if outputFile == "" {
+		fmt.Fprint(cmd.OutOrStdout(), rendered)
+		return nil
+	}
+	return emit.WriteToFile(rendered, outputFile)
+}
+
+// currentTemplateData builds the template data of the current version, as
+// `vars` shows it, without creating a missing VERSION file
+func currentTemplateData() (emit.TemplateData, error) {
+	vd, err := readOnlyVersion()
+	if err != nil {
+		return emit.TemplateData{}, fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+	data := emit.BuildTemplateDataFromVersion(vd)
+	custom, err := config.GetAllCustom()
+	if err != nil {
+		return emit.TemplateData{}, fmt.Errorf("error loading custom variables: %w", err)
+	}
+	emit.MergeCustomVars(&data, custom)
+	// Configured templates render from this data, since RenderPreRelease and
+	// RenderMetadata would load (and create) VERSION
+	renderer := emit.NewRenderer(data)
+	if template, _ := versionator.GetPreReleaseTemplate(); template != "" {
+		if prerelease, err := renderer.Render(template); err == nil && prerelease != "" {
+			data.PreRelease = prerelease
+			data.PreReleaseWithDash = "-" + prerelease
+		}
+	}
+	if template, _ := versionator.GetMetadataTemplate(); template != "" {
+		if metadata, err := renderer.Render(template); err == nil && metadata != "" {
+			data.Metadata = metadata
+			data.MetadataWithPlus = "+" + metadata
+		}
+	}
+	return data, nil
+}
+
+// readOnlyVersion loads the version, returning the initial version instead
+// of creating VERSION when the file store has none
+func readOnlyVersion() (*version.Version, error) {
+	if version.UsesFile() {
+		path, err := version.Path()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return version.Initial(), nil
+		}
+	}
+	return version.Load()
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// RenderTestSuite defines the test suite for the render command.
+type RenderTestSuite struct {
+	suite.Suite
+	origDir string
+}
+
+// SetupTest runs before each test
+func (suite *RenderTestSuite) SetupTest() {
+	var err error
+	suite.origDir, err = os.Getwd()
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.Chdir(suite.T().TempDir()))
+}
+
+// TearDownTest runs after each test
+func (suite *RenderTestSuite) TearDownTest() {
+	if suite.origDir != "" {
+		_ = os.Chdir(suite.origDir)
+	}
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	for _, name := range []string{"template", "data", "output"} {
+		_ = renderCmd.Flags().Set(name, "")
+	}
+	_ = renderCmd.Flags().Set("set", "")
+	renderCmd.Flags().Lookup("set").Changed = false
+}
+
+// TestRender_SuppliedData_RendersTemplate validates rendering from a file.
+//
+// Why: Template authors test templates against fixed data, locally and in
+// CI, without a repository.
+//
+// What: The template renders with the --data fields and --set variables,
+// and no VERSION file is created.
+func (suite *RenderTestSuite) TestRender_SuppliedData_RendersTemplate() {
+	// Precondition: Template and data files
+	suite.Require().NoError(os.WriteFile("t.tmpl", []byte("{{Prefix}}{{MajorMinorPatch}} {{Channel}}"), 0644))
+	suite.Require().NoError(os.WriteFile("data.json", []byte(`{"Prefix": "v", "MajorMinorPatch": "3.1.4"}`), 0644))
+
+	// Action: Render
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"render", "--data", "data.json", "--template", "t.tmpl", "--set", "Channel=beta"})
+	err := rootCmd.Execute()
+
+	// Expected: Rendered from the supplied data; VERSION untouched
+	suite.Require().NoError(err)
+	suite.Equal("v3.1.4 beta", out.String())
+	suite.NoFileExists("VERSION")
+}
+
+// TestRender_CurrentData_DoesNotCreateVersion validates read-only rendering.
+//
+// Why: render must never change VERSION, even when there is none yet.
+//
+// What: Without VERSION the initial version renders and no file is created.
+func (suite *RenderTestSuite) TestRender_CurrentData_DoesNotCreateVersion() {
+	// Precondition: Template only
+	suite.Require().NoError(os.WriteFile("t.tmpl", []byte("{{MajorMinorPatch}}"), 0644))
+
+	// Action: Render against the current state, on process stdout with
+	// stderr kept apart
+	var stderr bytes.Buffer
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"render", "--template", "t.tmpl"})
+	var err error
+	out := captureStdout(func() { err = rootCmd.Execute() })
+
+	// Expected: Initial version on stdout, no VERSION created
+	suite.Require().NoError(err)
+	suite.Equal("0.0.1", out)
+	suite.Empty(stderr.String())
+	suite.NoFileExists(filepath.Join(".", "VERSION"))
+}
+
+// TestRender_InvalidTemplate_ReturnsError validates CI linting.
+func (suite *RenderTestSuite) TestRender_InvalidTemplate_ReturnsError() {
+	// Precondition: Unterminated section
+	suite.Require().NoError(os.WriteFile("bad.tmpl", []byte("{{#Dirty}}x"), 0644))
+
+	// Action: Render
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"render", "--template", "bad.tmpl"})
+	err := rootCmd.Execute()
+
+	// Expected: Error naming the template
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "bad.tmpl")
+}
+
+func TestRenderTestSuite(t *testing.T) {
+	suite.Run(t, new(RenderTestSuite))
+}
@@ -2,11 +2,22 @@ package cmd
 
 import (
 	"fmt"
+	"maps"
+	"os"
 	"strings"
 
+	"github.com/benjaminabbitt/versionator/internal/aggregate"
+	"github.com/benjaminabbitt/versionator/internal/clierr"
 	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/deprecation"
 	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+	"github.com/benjaminabbitt/versionator/internal/hosting"
 	"github.com/benjaminabbitt/versionator/internal/logging"
+	"github.com/benjaminabbitt/versionator/internal/offline"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
 	"github.com/benjaminabbitt/versionator/internal/version"
 	"github.com/benjaminabbitt/versionator/internal/versionator"
 
@@ -14,11 +25,18 @@ import (
 )
 
 var logOutput string
+var gitDirFlag string
+var strictFlag bool
+var offlineFlag bool
+var vcsFlag string
+var noDirtyCheckFlag bool
 var versionTemplate string
 var prereleaseTemplate string
 var metadataTemplate string
 var prefixOverride string
 var setVars []string
+var setFileVars []string
+var versionWriteFlag bool
 
 // Marker for "flag provided without value" - use defaults
 const useDefaultMarker = "\x00DEFAULT\x00"
@@ -32,18 +50,121 @@ stored in a VERSION file in the current directory.`,
 	PersistentPreRunE: runRootPersistentPreRun,
 }
 
+// applyScheme configures the version package for the versioning plugin
+// selected by scheme; empty restores Major.Minor.Patch
+func applyScheme(scheme string) error {
+	if scheme == "" {
+		version.SetSegments(3)
+		return nil
+	}
+	p := plugin.GetVersioningPlugin(scheme)
+	if p == nil {
+		return clierr.UnknownChoice("unknown scheme", scheme, plugin.ListSchemes())
+	}
+	version.SetSegments(p.Segments())
+	return nil
+}
+
 func runRootPersistentPreRun(cmd *cobra.Command, args []string) error {
-	// If log format wasn't explicitly set via flag, use config default
-	if !cmd.PersistentFlags().Changed("log-format") {
-		if cfg, err := config.ReadConfig(); err == nil {
-			logOutput = cfg.Logging.Output
+	// --git-dir is exported as GIT_DIR so repository detection and any git
+	// subprocesses agree on the repository (e.g. bare repos in server hooks)
+	if gitDirFlag != "" {
+		if err := os.Setenv("GIT_DIR", gitDirFlag); err != nil {
+			return fmt.Errorf("failed to set GIT_DIR: %w", err)
 		}
 	}
 
+	// --vcs forces a backend; otherwise vcs.priority orders detection and
+	// nested repositories of different systems must be disambiguated
+	if vcsFlag != "" && vcs.GetVCS(vcsFlag) == nil {
+		return clierr.UnknownChoice("unknown VCS", vcsFlag, vcs.ListVCS())
+	}
+	vcs.SetForced(vcsFlag)
+	vcs.SetDirtyCheck("")
+	vcs.SetCountIgnored(false)
+	vcs.SetDefaultBranch("")
+	vcs.SetTagDistance("")
+	_ = tagformat.Set("")
+	tagformat.SetNamespace("")
+	emit.SetComponents(nil)
+	hosting.Configure(hosting.Options{}, "")
+	emit.SetHostVariables(false)
+	offline.Set(offlineFlag)
+	version.SetStore(nil)
+
+	// A config that fails to parse (e.g. a misspelled key) is an error rather
+	// than silently ignored; a missing file reads as the defaults
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+	offline.Set(offlineFlag || cfg.Offline)
+	vcs.SetPriority(cfg.VCS.Priority)
+	vcs.SetDirtyCheck(cfg.VCS.DirtyCheck)
+	vcs.SetCountIgnored(cfg.VCS.CountIgnored)
+	vcs.SetDefaultBranch(cfg.VCS.DefaultBranch)
+	vcs.SetTagDistance(cfg.VCS.TagDistance)
+	hosted := cfg.VCS.Hosted
+	hosting.Configure(hosting.Options{Provider: hosted.Provider, Repository: hosted.Repository, APIURL: hosted.APIURL}, hosted.TokenEnv)
+	emit.SetEnvAllowlist(cfg.Env.Allow)
+	emit.SetHostVariables(cfg.Env.Host)
+	emit.SetComponents(aggregate.Sources(cfg.Aggregate))
+	loc, err := cfg.Dates.Location()
+	if err != nil {
+		return fmt.Errorf("dates timezone: %w", err)
+	}
+	emit.SetDateFormats(cfg.Dates.Build, cfg.Dates.Commit, loc)
+	plugin.SetMetadataTimeout(cfg.MetadataProviders.Timeout)
+	version.SetLooseMode(cfg.LooseVersions)
+	version.SetFileFormat(cfg.VersionFileFormat)
+	store, err := version.StoreFor(cfg.Store)
+	if err != nil {
+		return err
+	}
+	version.SetStore(store)
+	if err := tagformat.Set(cfg.Release.TagFormat); err != nil {
+		return fmt.Errorf("release tagFormat: %w", err)
+	}
+	tagformat.SetNamespace(cfg.Release.TagNamespace)
+	if err := applyScheme(cfg.Scheme); err != nil {
+		return err
+	}
+	if err := fileperm.Configure(cfg.FileMode); err != nil {
+		return err
+	}
+	if noDirtyCheckFlag {
+		vcs.SetDirtyCheck(vcs.DirtyCheckOff)
+	}
+	if _, err := vcs.DetectVCS(); err != nil {
+		return err
+	}
+
+	// --from-snapshot replays template data captured by `snapshot`
+	if err := applySnapshotFlag(fromSnapshotFlag); err != nil {
+		return err
+	}
+
+	// Strict mode reports deprecated template variables on stderr
+	if strictFlag {
+		emit.SetStrict(cmd.ErrOrStderr())
+	} else {
+		emit.SetStrict(nil)
+	}
+
+	// If log format wasn't explicitly set via flag, use config default
+	if !cmd.Flags().Changed("log-format") {
+		logOutput = cfg.Logging.Output
+	}
+
 	// Initialize logger with the specified output format
 	if err := logging.InitLogger(logOutput); err != nil {
 		return fmt.Errorf("failed to initialize logger: %w", err)
 	}
+
+	// Deprecated usage is reported on stderr, as JSON lines with the json
+	// log format so CI logs can be scanned for it
+	deprecation.Configure(cmd.ErrOrStderr(), logOutput == "json")
+	reportDeprecatedUsage(cmd)
 	return nil
 }
 
@@ -99,42 +220,55 @@ TEMPLATE VARIABLES:
     {{CommitsSinceTag}}  - Commits since last tag
     {{BuildNumber}}      - Alias for CommitsSinceTag
     {{BuildNumberPadded}} - Padded to 4 digits (e.g., "0042")
+    {{CommitsSinceTagPadded(N)}} - Padded to N digits (not for numeric
+                           pre-release identifiers: SemVer forbids leading zeros)
+    {{IsReleaseBuild}}   - "true" if HEAD is at a version tag and clean
+                           e.g. {{^IsReleaseBuild}}dev-{{CommitsSinceTag}}{{/IsReleaseBuild}}
 
   Commit Info:
     {{CommitDate}}       - Last commit datetime (ISO 8601)
     {{CommitDateCompact}} - Compact: 20241211103045
     {{CommitAuthor}}     - Commit author name
     {{CommitAuthorEmail}} - Commit author email
+    {{VersionFileLastChangedBy}}   - Who last changed VERSION
+    {{VersionFileLastChangedDate}} - When VERSION last changed (ISO 8601)
 
   Build Timestamps:
     {{BuildDateTimeCompact}} - Compact: 20241211103045
     {{BuildDateUTC}}         - Date only: 2024-12-11
     {{DateTimeDirty}}        - ".{BuildDateTimeCompact}" if uncommitted, empty otherwise
 
+  Build Host (with env.host: true in .versionator.yaml):
+    {{GoVersion}} {{OS}} {{Arch}} {{Hostname}} {{NumCPU}}
+
   Custom Variables:
     Use --set key=value to inject custom variables
-    Custom vars from .versionator.yaml config are also available
+    Use --set-file key=path to set one to a file's contents (--set wins)
+    Custom vars from .versionator.yaml config (custom, customFrom) are also available
 
 EXAMPLES:
   # Basic version (includes prerelease/metadata from VERSION file)
-  versionator version                              # Output: 1.2.3-alpha+build.1
+  versionator output version                       # Output: 1.2.3-alpha+build.1
 
   # With prefix
-  versionator version -t "{{Prefix}}{{MajorMinorPatch}}" --prefix
+  versionator output version -t "{{Prefix}}{{MajorMinorPatch}}" --prefix
                                                    # Output: v1.2.3
 
   # Full SemVer with prerelease and metadata
-  versionator version -t "{{MajorMinorPatch}}{{PreReleaseWithDash}}{{MetadataWithPlus}}" \
+  versionator output version -t "{{MajorMinorPatch}}{{PreReleaseWithDash}}{{MetadataWithPlus}}" \
     --prerelease "alpha-{{CommitsSinceTag}}" \
     --metadata "{{BuildDateTimeCompact}}.{{ShortSha}}"
                                                    # Output: 1.2.3-alpha-5+20241211103045.abc1234
 
   # Use config defaults for prerelease/metadata
-  versionator version -t "{{MajorMinorPatch}}{{PreReleaseWithDash}}{{MetadataWithPlus}}" \
+  versionator output version -t "{{MajorMinorPatch}}{{PreReleaseWithDash}}{{MetadataWithPlus}}" \
     --prerelease --metadata
 
   # With custom variables
-  versionator version -t "{{AppName}} v{{MajorMinorPatch}}" --set AppName="My App"`,
+  versionator output version -t "{{AppName}} v{{MajorMinorPatch}}" --set AppName="My App"
+
+  # Write the default emit target (emit.default, or the only emit target)
+  versionator output version --write`,
 	RunE: runVersion,
 }
 
@@ -144,8 +278,19 @@ func runVersion(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error reading version: %w", err)
 	}
 
-	// Parse --set flags into a map
-	extraVars := parseSetFlags(setVars)
+	// --write renders the default emit target instead of printing
+	if versionWriteFlag {
+		if versionTemplate != "" {
+			return fmt.Errorf("--write renders the default emit target; it cannot be combined with --template")
+		}
+		return runVersionWrite(cmd, vd)
+	}
+
+	// Parse --set-file and --set flags into a map, --set winning
+	extraVars, err := customFlagVars(setFileVars, setVars)
+	if err != nil {
+		return err
+	}
 
 	// If no template specified, output full SemVer (including prerelease and metadata from VERSION file)
 	if versionTemplate == "" {
@@ -175,6 +320,7 @@ func runVersion(cmd *cobra.Command, args []string) error {
 			// Flag provided without value - use defaults from config
 			template, _ := versionator.GetPreReleaseTemplate()
 			if template != "" {
+				warnZeroPaddedPreRelease(cmd, template)
 				prereleaseResult, err = emit.RenderTemplateWithData(template, templateData)
 				if err != nil {
 					return fmt.Errorf("error rendering prerelease template: %w", err)
@@ -183,6 +329,7 @@ func runVersion(cmd *cobra.Command, args []string) error {
 			}
 		} else {
 			// Render the provided template
+			warnZeroPaddedPreRelease(cmd, prereleaseTemplate)
 			prereleaseResult, err = emit.RenderTemplateWithData(prereleaseTemplate, templateData)
 			if err != nil {
 				return fmt.Errorf("error rendering prerelease template: %w", err)
@@ -227,11 +374,12 @@ func runVersion(cmd *cobra.Command, args []string) error {
 		templateData.MetadataWithPlus = "+" + metadataResult
 	}
 
-	// Load custom vars from config
+	// Load custom vars from config and its customFrom files
 	configCustomVars, err := config.GetAllCustom()
-	if err == nil && len(configCustomVars) > 0 {
-		emit.MergeCustomVars(&templateData, configCustomVars)
+	if err != nil {
+		return fmt.Errorf("error loading custom variables: %w", err)
 	}
+	emit.MergeCustomVars(&templateData, configCustomVars)
 
 	// Merge command-line custom vars (override config custom vars)
 	emit.MergeCustomVars(&templateData, extraVars)
@@ -247,9 +395,35 @@ func runVersion(cmd *cobra.Command, args []string) error {
 }
 
 func init() {
+	// Parse .versionator.yaml once per invocation
+	cobra.OnInitialize(config.BeginInvocation)
+	cobra.OnFinalize(config.EndInvocation)
+
 	// Add persistent flag for log output format (default: quiet for CLI usage)
 	rootCmd.PersistentFlags().StringVar(&logOutput, "log-format", "quiet", "Log output format (quiet, console, json, development)")
 
+	// Add persistent flag for an explicit git directory (bare repositories, server-side hooks)
+	rootCmd.PersistentFlags().StringVar(&gitDirFlag, "git-dir", "", "Path to the git directory, as with GIT_DIR (supports bare repositories)")
+
+	// Add persistent flag for forcing a VCS backend
+	rootCmd.PersistentFlags().StringVar(&vcsFlag, "vcs", "", "Version control system to use (e.g. git), overriding detection")
+
+	// Add persistent flag for skipping the working tree scan on large checkouts
+	rootCmd.PersistentFlags().BoolVar(&noDirtyCheckFlag, "no-dirty-check", false, "Skip checking for uncommitted changes when collecting template data ({{Dirty}} is empty)")
+
+	// Add persistent flag for replaying a template data snapshot
+	rootCmd.PersistentFlags().StringVar(&fromSnapshotFlag, "from-snapshot", "", "Render from template data captured by 'snapshot' instead of VERSION and the VCS")
+
+	// Add persistent flag for hermetic builds
+	rootCmd.PersistentFlags().BoolVar(&offlineFlag, "offline", false, "Make no network calls; fail features that need one (push, publish, webhooks, metadata providers)")
+
+	// Add persistent flags for status message output
+	rootCmd.PersistentFlags().BoolVar(&noColorFlag, "no-color", false, "Disable colored status messages (also: NO_COLOR, TERM=dumb)")
+	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress status messages; results and warnings are still printed")
+
+	// Add persistent flag for strict mode (deprecation warnings)
+	rootCmd.PersistentFlags().BoolVar(&strictFlag, "strict", false, "Warn when templates use deprecated variables")
+
 	// Add template flag to version command
 	versionCmd.Flags().StringVarP(&versionTemplate, "template", "t", "", "Template string for version output (Mustache syntax)")
 
@@ -267,11 +441,44 @@ func init() {
 
 	// Add --set flag for custom variables (can be used multiple times)
 	versionCmd.Flags().StringArrayVar(&setVars, "set", nil, "Set custom variable (key=value), can be repeated")
+	versionCmd.Flags().StringArrayVar(&setFileVars, "set-file", nil, "Set custom variable to a file's contents (key=path), can be repeated")
+
+	// Add --write flag for writing the default emit target
+	versionCmd.Flags().BoolVar(&versionWriteFlag, "write", false, "Write the default emit target (emit.default in .versionator.yaml) instead of printing")
 
 	// Add version command under output
 	outputCmd.AddCommand(versionCmd)
 }
 
+// parseSetFileFlags parses --set-file key=path flags into a map of each
+// file's contents, less one trailing newline
+func parseSetFileFlags(setFileFlags []string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, s := range setFileFlags {
+		key, path, ok := strings.Cut(s, "=")
+		if !ok || key == "" || path == "" {
+			return nil, fmt.Errorf("invalid --set-file %q: expected key=path", s)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("--set-file %s: %w", key, err)
+		}
+		value := strings.TrimSuffix(string(content), "\n")
+		result[key] = strings.TrimSuffix(value, "\r")
+	}
+	return result, nil
+}
+
+// customFlagVars merges --set-file and --set flags, --set taking precedence
+func customFlagVars(setFileFlags, setFlags []string) (map[string]string, error) {
+	result, err := parseSetFileFlags(setFileFlags)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(result, parseSetFlags(setFlags))
+	return result, nil
+}
+
 // parseSetFlags parses --set key=value flags into a map
 func parseSetFlags(setFlags []string) map[string]string {
 	result := make(map[string]string)
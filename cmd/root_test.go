@@ -3,7 +3,10 @@ package cmd
 import (
 	"bytes"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/version"
 )
 
 // =============================================================================
@@ -329,6 +332,64 @@ func TestVersionCommand_WithSetFlag_InjectsCustomVariable(t *testing.T) {
 	setVars = nil
 }
 
+// TestVersionCommand_WithSetFileFlag_InjectsFileContents validates that
+// --set-file sets a custom variable to a file's contents, under --set.
+func TestVersionCommand_WithSetFileFlag_InjectsFileContents(t *testing.T) {
+	// Precondition: A notes file, and a variable given by both flags
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("VERSION", []byte("1.0.0\n"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte("prefix: \"\"\n"), 0644)
+	_ = os.WriteFile("notes.txt", []byte("Fixed it\n"), 0644)
+	_ = os.WriteFile("name.txt", []byte("FromFile\n"), 0644)
+	t.Cleanup(func() {
+		setVars, setFileVars = nil, nil
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+	})
+
+	// Action
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"output", "version", "-t", "{{AppName}} {{MajorMinorPatch}}: {{Notes}}",
+		"--set-file", "Notes=notes.txt", "--set-file", "AppName=name.txt", "--set", "AppName=MyApp"})
+	err := rootCmd.Execute()
+
+	// Expected: File contents without the trailing newline; --set wins
+	if err != nil {
+		t.Fatalf("version command failed: %v", err)
+	}
+	if buf.String() != "MyApp 1.0.0: Fixed it\n" {
+		t.Errorf("Expected 'MyApp 1.0.0: Fixed it\\n', got %q", buf.String())
+	}
+}
+
+// TestVersionCommand_StructuredVersionFile_ExposesFieldsAsCustomVariables
+// validates that versionFileFormat: yaml makes VERSION fields template
+// variables, with config custom variables taking precedence.
+func TestVersionCommand_StructuredVersionFile_ExposesFieldsAsCustomVariables(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(func() { version.SetFileFormat("") })
+
+	_ = os.WriteFile("VERSION", []byte("version: 1.4.0\nchannel: beta\nbuild: 7\n"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte("prefix: \"\"\nversionFileFormat: yaml\ncustom:\n  build: \"8\"\n"), 0644)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"output", "version", "-t", "{{MajorMinorPatch}} {{channel}} {{build}}"})
+
+	err := rootCmd.Execute()
+
+	if err != nil {
+		t.Fatalf("version command failed: %v", err)
+	}
+	if buf.String() != "1.4.0 beta 8\n" {
+		t.Errorf("Expected '1.4.0 beta 8\\n', got %q", buf.String())
+	}
+
+	rootCmd.SetOut(nil)
+	rootCmd.SetArgs(nil)
+}
+
 // TestVersionCommand_WithPrereleaseFlag_RendersPrerelease validates that
 // --prerelease flag adds prerelease to version output.
 func TestVersionCommand_WithPrereleaseFlag_RendersPrerelease(t *testing.T) {
@@ -500,3 +561,159 @@ func TestParseSetFlags_VariousInputs_ParsesCorrectly(t *testing.T) {
 		})
 	}
 }
+
+// TestVCSFlag_UnknownVCS_ReturnsError validates --vcs validation.
+//
+// Why: A typo in --vcs would otherwise silently disable VCS detection and
+// produce versions without commit information.
+//
+// What: An unregistered --vcs name fails before the command runs, listing
+// the available systems.
+func TestVCSFlag_UnknownVCS_ReturnsError(t *testing.T) {
+	// Precondition: Temporary directory; only git is registered
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	_ = os.Chdir(tempDir)
+	defer func() {
+		vcsFlag = ""
+		rootCmd.SetArgs(nil)
+	}()
+
+	// Action: Run a command forcing an unknown VCS
+	rootCmd.SetArgs([]string{"output", "version", "--vcs", "fossil"})
+	err := rootCmd.Execute()
+
+	// Expected: Error naming the unknown VCS and the available ones
+	if err == nil {
+		t.Fatal("expected error for unknown VCS")
+	}
+	if !strings.Contains(err.Error(), `unknown VCS "fossil"`) || !strings.Contains(err.Error(), "git") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestConfig_MisspelledKey_FailsCommand validates strict config parsing.
+//
+// Why: A misspelled key used to make the whole config read as the defaults
+// without a message, silently disabling guards such as release.monotonic.
+//
+// What: A command run with an unknown key in .versionator.yaml fails naming
+// the key and the closest known one, and changes nothing.
+func TestConfig_MisspelledKey_FailsCommand(t *testing.T) {
+	// Precondition: A typo next to a valid section
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("VERSION", []byte("2.0.0\n"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte("release:\n  monotonic: true\nrelase:\n  monotonic: true\n"), 0644)
+	defer rootCmd.SetArgs(nil)
+
+	// Action
+	rootCmd.SetArgs([]string{"set", "1.0.0"})
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	defer rootCmd.SetOut(nil)
+	defer rootCmd.SetErr(nil)
+	err := rootCmd.Execute()
+
+	// Expected: Unknown key error with a suggestion; VERSION untouched
+	if err == nil {
+		t.Fatal("expected error for misspelled config key")
+	}
+	if !strings.Contains(err.Error(), `"relase"`) || !strings.Contains(err.Error(), `did you mean "release"?`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if content, _ := os.ReadFile("VERSION"); string(content) != "2.0.0\n" {
+		t.Errorf("VERSION changed to %q", content)
+	}
+}
+
+// TestVersionCommand_Examples_NameExistingCommands validates the examples in
+// the version help.
+//
+// Why: version is a subcommand of output; an example calling a top-level
+// `versionator version` fails with "unknown command" when copied.
+//
+// What: Every example invocation in the version help resolves to the
+// version command.
+func TestVersionCommand_Examples_NameExistingCommands(t *testing.T) {
+	for _, line := range strings.Split(versionCmd.Long, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "versionator" {
+			continue
+		}
+
+		// Action
+		found, _, err := rootCmd.Find(fields[1:])
+
+		// Expected
+		if err != nil || found != versionCmd {
+			t.Errorf("example %q does not run the version command", strings.TrimSpace(line))
+		}
+	}
+}
+
+// TestVersionCommand_Write_WritesDefaultEmitTarget validates that --write
+// renders the configured default emit target to its output.
+func TestVersionCommand_Write_WritesDefaultEmitTarget(t *testing.T) {
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("VERSION", []byte("1.2.3\n"), 0644)
+	_ = os.WriteFile("v.tmpl", []byte("{{AppName}} {{MajorMinorPatch}}{{PreReleaseWithDash}}\n"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte(`prefix: ""
+emit:
+  default: txt
+  targets:
+    - format: go
+      output: version.go
+    - name: txt
+      templateFile: v.tmpl
+      output: version.txt
+`), 0644)
+	versionTemplate, setVars = "", nil
+	t.Cleanup(func() {
+		versionWriteFlag, prereleaseTemplate, setVars = false, "", nil
+		versionCmd.Flags().Lookup("prerelease").Changed = false
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+	})
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"output", "version", "--write", "--prerelease=rc-1", "--set", "AppName=MyApp"})
+
+	err := rootCmd.Execute()
+
+	if err != nil {
+		t.Fatalf("version --write failed: %v", err)
+	}
+	content, err := os.ReadFile("version.txt")
+	if err != nil {
+		t.Fatalf("version.txt not written: %v", err)
+	}
+	if string(content) != "MyApp 1.2.3-rc-1\n" {
+		t.Errorf("version.txt = %q", content)
+	}
+	if _, err := os.Stat("version.go"); err == nil {
+		t.Error("only the default target should be written")
+	}
+}
+
+// TestVersionCommand_Write_RequiresDefaultTarget validates that --write
+// fails when no target is the default.
+func TestVersionCommand_Write_RequiresDefaultTarget(t *testing.T) {
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("VERSION", []byte("1.2.3\n"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte("prefix: \"\"\n"), 0644)
+	versionTemplate = ""
+	t.Cleanup(func() {
+		versionWriteFlag = false
+		rootCmd.SetArgs(nil)
+	})
+
+	rootCmd.SetArgs([]string{"output", "version", "--write"})
+
+	err := rootCmd.Execute()
+
+	if err == nil || !strings.Contains(err.Error(), "no default emit target") {
+		t.Errorf("expected a missing default target error, got %v", err)
+	}
+}
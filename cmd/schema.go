@@ -3,10 +3,10 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/benjaminabbitt/versionator/internal/buildinfo"
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -54,6 +54,7 @@ type TemplateVarsSchema struct {
 	VCS               []TemplateVarSchema `json:"vcs"`
 	CommitInfo        []TemplateVarSchema `json:"commitInfo"`
 	BuildTimestamps   []TemplateVarSchema `json:"buildTimestamps"`
+	BuildHost         []TemplateVarSchema `json:"buildHost"`
 }
 
 // TemplateVarSchema describes a template variable
@@ -89,7 +90,7 @@ func runSchema(cmd *cobra.Command, args []string) error {
 	}
 
 	if schemaOutput != "" {
-		if err := os.WriteFile(schemaOutput, output, FilePermission); err != nil {
+		if err := fileperm.WriteFile(schemaOutput, output); err != nil {
 			return fmt.Errorf("error writing schema to %s: %w", schemaOutput, err)
 		}
 		fmt.Fprintf(cmd.OutOrStdout(), "Schema written to %s\n", schemaOutput)
@@ -190,7 +191,7 @@ func buildTemplateVarsSchema() TemplateVarsSchema {
 			{Name: "MetadataWithPlus", Description: "Metadata with leading plus", Example: "+20241211.abc1234"},
 		},
 		VCS: []TemplateVarSchema{
-			{Name: "Hash", Description: "Full commit hash (40 chars)", Example: "abc1234def5678..."},
+			{Name: "Hash", Description: "Full commit hash (40 chars, 64 for SHA-256 repositories)", Example: "abc1234def5678..."},
 			{Name: "ShortHash", Description: "Short commit hash (7 chars)", Example: "abc1234"},
 			{Name: "MediumHash", Description: "Medium commit hash (12 chars)", Example: "abc1234def01"},
 			{Name: "BranchName", Description: "Current branch name", Example: "feature/foo"},
@@ -200,11 +201,21 @@ func buildTemplateVarsSchema() TemplateVarsSchema {
 			{Name: "BuildNumberPadded", Description: "Padded to 4 digits", Example: "0042"},
 			{Name: "UncommittedChanges", Description: "Count of uncommitted files", Example: "3"},
 			{Name: "Dirty", Description: "'dirty' if uncommitted changes exist", Example: "dirty"},
+			{Name: "IsReleaseBuild", Description: "'true' if HEAD is at a version tag and the tree is clean, empty otherwise", Example: "true"},
 			{Name: "VersionSourceHash", Description: "Hash of commit that last tag points to", Example: "def5678"},
+			{Name: "HashAlgorithm", Description: "Commit identifier algorithm (sha1, sha256, revision)", Example: "sha1"},
+			{Name: "PreviousVersion", Description: "Highest tagged version strictly lower than the current one", Example: "1.2.2"},
+			{Name: "PreviousTag", Description: "Tag of PreviousVersion", Example: "v1.2.2"},
+			{Name: "CommitsAheadOfDefault", Description: "Commits on HEAD not on the default branch (vcs.defaultBranch or origin's)", Example: "7"},
+			{Name: "CommitsBehindDefault", Description: "Commits on the default branch not on HEAD", Example: "3"},
+			{Name: "InferredBranchName", Description: "Branch, inferred from CI variables, refs, and the reflog when HEAD is detached", Example: "main"},
+			{Name: "InferredBranchConfidence", Description: "How InferredBranchName was found: exact, high, medium, low, or none", Example: "high"},
 		},
 		CommitInfo: []TemplateVarSchema{
 			{Name: "CommitAuthor", Description: "Commit author name", Example: "John Doe"},
 			{Name: "CommitAuthorEmail", Description: "Commit author email", Example: "john@example.com"},
+			{Name: "VersionFileLastChangedBy", Description: "Author of the last commit that modified VERSION", Example: "Jane Doe"},
+			{Name: "VersionFileLastChangedDate", Description: "ISO 8601 date of the last commit that modified VERSION", Example: "2024-01-10T09:15:00Z"},
 			{Name: "CommitDate", Description: "ISO 8601 commit date", Example: "2024-01-15T10:30:00Z"},
 			{Name: "CommitDateCompact", Description: "Compact commit date", Example: "20240115103045"},
 			{Name: "CommitDateShort", Description: "Date only", Example: "2024-01-15"},
@@ -221,5 +232,12 @@ func buildTemplateVarsSchema() TemplateVarsSchema {
 			{Name: "BuildMonth", Description: "Build month (zero-padded)", Example: "01"},
 			{Name: "BuildDay", Description: "Build day (zero-padded)", Example: "15"},
 		},
+		BuildHost: []TemplateVarSchema{
+			{Name: "GoVersion", Description: "Go toolchain version (env.host)", Example: "go1.22.1"},
+			{Name: "OS", Description: "Build operating system (env.host)", Example: "linux"},
+			{Name: "Arch", Description: "Build architecture (env.host)", Example: "amd64"},
+			{Name: "Hostname", Description: "Build machine name (env.host)", Example: "ci-runner-7"},
+			{Name: "NumCPU", Description: "Logical CPUs of the build machine (env.host)", Example: "8"},
+		},
 	}
 }
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/clierr"
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/offline"
+	"github.com/benjaminabbitt/versionator/internal/serve"
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+	"github.com/spf13/cobra"
+)
+
+// Serve defaults for unset configuration
+const (
+	defaultServeAddress  = "127.0.0.1:8080"
+	defaultServeTokenEnv = "VERSIONATOR_SERVE_TOKEN"
+)
+
+// serveExecutable returns the versionator binary each bump runs; replaced
+// in tests
+var serveExecutable = os.Executable
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a webhook endpoint that bumps and tags the version",
+	Long: `Serve an authenticated webhook endpoint for chat-ops release flows.
+
+A request bumps the version and tags it, exactly as these commands would:
+  versionator bump <level>
+  versionator release          # 'release push' with --push
+
+  POST /bump
+  Authorization: Bearer <token>
+  {"level": "minor", "actor": "alice", "reason": "weekly release"}
+
+  200 {"previous": "1.2.0", "version": "1.3.0", "tag": "v1.3.0"}
+
+The token is read from VERSIONATOR_SERVE_TOKEN (or the variable named by
+serve.tokenEnv); serve refuses to start without one. The actor and reason
+are recorded in the tag annotation as Requested-By and Reason.
+
+Requests are checked against the serve policy in .versionator.yaml before
+anything changes:
+  serve:
+    levels: [minor, patch]     # default: major, minor, patch
+    actors: [alice, bob]       # default: anyone with the token
+    branches: [main]           # default: any branch
+    requireReason: true
+
+Bumps run one at a time and only from a clean working tree. Failures are
+returned as {"error": "..."}: 401 without the token, 403 outside the policy,
+409 while another bump runs, and 422 when bump or release fails.
+
+Examples:
+  versionator serve                          # Listen on 127.0.0.1:8080
+  versionator serve --address :9000 --push   # Also push each release`,
+	RunE: runServe,
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	out := newConsole(cmd)
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("error reading config: %w", err)
+	}
+
+	tokenEnv := cfg.Serve.TokenEnv
+	if tokenEnv == "" {
+		tokenEnv = defaultServeTokenEnv
+	}
+	token := os.Getenv(tokenEnv)
+	if token == "" {
+		return clierr.New(fmt.Sprintf("%s (%s is empty)", ErrServeNoToken, tokenEnv),
+			fmt.Sprintf("export %s=<secret> and send it as 'Authorization: Bearer <secret>'", tokenEnv))
+	}
+
+	push := cfg.Serve.Push
+	if cmd.Flags().Changed("push") {
+		push, _ = cmd.Flags().GetBool("push")
+	}
+	if push {
+		if err := offline.Require("serve --push"); err != nil {
+			return err
+		}
+	}
+
+	activeVCS := vcs.GetActiveVCS()
+	if activeVCS == nil {
+		return clierr.NoRepository("serve needs a repository to tag")
+	}
+	if err := vcs.RequireCapability(activeVCS, vcs.CapabilityTags); err != nil {
+		return err
+	}
+	executable, err := serveExecutable()
+	if err != nil {
+		return fmt.Errorf("error locating versionator: %w", err)
+	}
+
+	address := cfg.Serve.Address
+	if cmd.Flags().Changed("address") || address == "" {
+		address, _ = cmd.Flags().GetString("address")
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", address, err)
+	}
+
+	handler := &serve.Handler{
+		Token: token,
+		Policy: serve.Policy{
+			Levels:        cfg.Serve.Levels,
+			Actors:        cfg.Serve.Actors,
+			Branches:      cfg.Serve.Branches,
+			RequireReason: cfg.Serve.RequireReason,
+		},
+		Branch: activeVCS.GetBranchName,
+		Bump: func(req serve.BumpRequest) (serve.BumpResult, error) {
+			return serveBump(activeVCS, executable, tokenEnv, push, req)
+		},
+	}
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdown)
+	}()
+
+	out.Infof("Serving bump requests on http://%s%s", listener.Addr(), serve.BumpPath)
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// serveBump runs 'bump <level>' and 'release' (or 'release push') as child
+// processes of this binary, so a request gets every check, update and hook
+// the CLI applies, with the actor and reason in the tag annotation. The
+// token is not passed to the children (and so to hooks).
+func serveBump(activeVCS vcs.VersionControlSystem, executable, tokenEnv string, push bool, req serve.BumpRequest) (serve.BumpResult, error) {
+	// A dirty tree would be committed with the release, and after a failed
+	// release it means the last bump was not tagged
+	clean, err := activeVCS.IsWorkingDirectoryClean()
+	if err != nil {
+		return serve.BumpResult{}, fmt.Errorf("error checking %s status: %w", activeVCS.Name(), err)
+	}
+	if !clean {
+		return serve.BumpResult{}, errors.New(ErrServeDirty)
+	}
+
+	previous, err := version.Load()
+	if err != nil {
+		return serve.BumpResult{}, fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+
+	env := make([]string, 0, len(os.Environ()))
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, tokenEnv+"=") {
+			env = append(env, kv)
+		}
+	}
+	run := func(args ...string) error {
+		child := exec.Command(executable, args...)
+		child.Env = env
+		output, err := child.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("versionator %s failed: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+
+	if err := run("bump", req.Level); err != nil {
+		return serve.BumpResult{}, err
+	}
+	bumped, err := version.Load()
+	if err != nil {
+		return serve.BumpResult{}, fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+
+	message := fmt.Sprintf("Release %s\n\nRequested-By: %s", bumped.String(), req.Actor)
+	if req.Reason != "" {
+		message += "\nReason: " + req.Reason
+	}
+	releaseArgs := []string{"release"}
+	if push {
+		releaseArgs = append(releaseArgs, "push")
+	}
+	if err := run(append(releaseArgs, "--message", message)...); err != nil {
+		return serve.BumpResult{}, fmt.Errorf("VERSION bumped to %s but not tagged: %w", bumped.String(), err)
+	}
+
+	// Re-read: release may bump again to avoid an existing tag
+	released, err := version.Load()
+	if err != nil {
+		return serve.BumpResult{}, fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+	return serve.BumpResult{
+		Previous: previous.String(),
+		Version:  released.String(),
+		// release tags with its --prefix default, "v", or release.tagFormat
+		Tag: tagformat.Name("v", released.String()),
+	}, nil
+}
+
+func init() {
+	serveCmd.Flags().String("address", defaultServeAddress, "Listen address (default: serve.address or 127.0.0.1:8080)")
+	serveCmd.Flags().Bool("push", false, "Push the tag and release branch after each bump (default: serve.push)")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/serve"
+	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
+	"github.com/golang/mock/gomock"
+)
+
+// fakeVersionator writes a stand-in versionator that logs its arguments and
+// the serve token it sees, and bumps VERSION to 1.3.0
+func fakeVersionator(t *testing.T) (executable, log string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stand-in binary is a shell script")
+	}
+	dir := t.TempDir()
+	log = filepath.Join(dir, "calls.log")
+	executable = filepath.Join(dir, "versionator")
+	script := "#!/bin/sh\n" +
+		"printf '%s|' \"$@\" >> " + log + "\n" +
+		"echo \"token=$VERSIONATOR_SERVE_TOKEN\" >> " + log + "\n" +
+		"if [ \"$1\" = bump ]; then echo 1.3.0 > VERSION; fi\n"
+	if err := os.WriteFile(executable, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return executable, log
+}
+
+// TestServeBump_RunsBumpAndRelease validates the bump a request performs.
+//
+// Why: A webhook bump must get the same checks, updates and hooks as the
+// CLI, and record who asked for it and why.
+//
+// What: bump <level> runs, then release (release push with push) with the
+// actor and reason in the message; the token is not passed on; the result
+// holds both versions and the tag.
+func TestServeBump_RunsBumpAndRelease(t *testing.T) {
+	// Precondition: Clean repository at 1.2.0
+	t.Chdir(t.TempDir())
+	t.Setenv(defaultServeTokenEnv, "s3cret")
+	_ = os.WriteFile("VERSION", []byte("1.2.0\n"), 0644)
+	executable, log := fakeVersionator(t)
+	repo := mock.NewMockVersionControlSystem(gomock.NewController(t))
+	repo.EXPECT().IsWorkingDirectoryClean().Return(true, nil)
+
+	// Action
+	result, err := serveBump(repo, executable, defaultServeTokenEnv, true,
+		serve.BumpRequest{Level: "minor", Actor: "alice", Reason: "weekly release"})
+
+	// Expected
+	if err != nil {
+		t.Fatalf("serveBump failed: %v", err)
+	}
+	if result != (serve.BumpResult{Previous: "1.2.0", Version: "1.3.0", Tag: "v1.3.0"}) {
+		t.Errorf("result = %+v", result)
+	}
+	calls, _ := os.ReadFile(log)
+	want := "bump|minor|token=\n" +
+		"release|push|--message|Release 1.3.0\n\nRequested-By: alice\nReason: weekly release|token=\n"
+	if string(calls) != want {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+}
+
+// TestServeBump_DirtyTree_Refuses validates the clean tree check.
+//
+// Why: Uncommitted changes would be committed with the release, and a
+// dirty VERSION after a failed release would be bumped twice.
+//
+// What: Nothing runs when the working tree is dirty.
+func TestServeBump_DirtyTree_Refuses(t *testing.T) {
+	// Precondition
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("VERSION", []byte("1.2.0\n"), 0644)
+	executable, log := fakeVersionator(t)
+	repo := mock.NewMockVersionControlSystem(gomock.NewController(t))
+	repo.EXPECT().IsWorkingDirectoryClean().Return(false, nil)
+
+	// Action
+	_, err := serveBump(repo, executable, defaultServeTokenEnv, false, serve.BumpRequest{Level: "patch", Actor: "alice"})
+
+	// Expected
+	if err == nil || !strings.Contains(err.Error(), ErrServeDirty) {
+		t.Errorf("err = %v", err)
+	}
+	if _, statErr := os.Stat(log); statErr == nil {
+		t.Error("versionator ran on a dirty tree")
+	}
+}
+
+// TestServe_WithoutToken_Fails validates the token requirement.
+//
+// Why: An endpoint that tags releases must never run unauthenticated.
+//
+// What: serve fails before listening when the token variable is empty.
+func TestServe_WithoutToken_Fails(t *testing.T) {
+	// Precondition
+	t.Chdir(t.TempDir())
+	t.Setenv(defaultServeTokenEnv, "")
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		rootCmd.SetArgs(nil)
+	})
+
+	// Action
+	rootCmd.SetArgs([]string{"serve"})
+	err := rootCmd.Execute()
+
+	// Expected
+	if err == nil || !strings.Contains(err.Error(), ErrServeNoToken) {
+		t.Errorf("err = %v", err)
+	}
+}
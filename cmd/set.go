@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/benjaminabbitt/versionator/internal/version"
 
@@ -16,6 +17,16 @@ var setCmd = &cobra.Command{
 Accepts any version string the parser grammar supports:
   [v|V]Major.Minor[.Patch[.Revision]][-PreRelease][+BuildMetadata]
 
+With release.monotonic enabled, versions not greater than the highest
+version tag are refused unless --allow-downgrade is given.
+
+With looseVersions: normalize, non-SemVer input such as 01.2.3 or 1.2 is
+rewritten as SemVer and each change is reported; with looseVersions: reject
+it is refused.
+
+With scheme: four-part, the version must have four segments; under
+looseVersions: normalize a missing revision is set to 0.
+
 Examples:
   versionator set 1.2.3
   versionator set v2.0.0-rc.1
@@ -26,7 +37,20 @@ Examples:
 }
 
 func runSet(cmd *cobra.Command, args []string) error {
-	if err := version.SetVersion(args[0]); err != nil {
+	next, changes, err := version.ParseInput(args[0])
+	if err != nil {
+		return err
+	}
+	if len(changes) > 0 {
+		cmd.PrintErrf("Normalized %s to %s:\n", args[0], next.FullString())
+		for _, change := range changes {
+			cmd.PrintErrf("  %s\n", change)
+		}
+	}
+	if err := checkMonotonic(cmd, next); err != nil {
+		return err
+	}
+	if err := version.SetVersion(next.FullString()); err != nil {
 		return err
 	}
 
@@ -44,6 +68,65 @@ func runSet(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var setComponentCmd = &cobra.Command{
+	Use:   "set-component <major|minor|patch|revision> <value>",
+	Short: "Set one version component to a value",
+	Long: `Set the major, minor, or patch component of VERSION to a value, for
+aligning versions with external numbering (marketing versions, sprint
+numbers). With scheme: four-part, the revision (alias build) can be set too.
+
+Changing a component resets the lower components and the pre-release, as
+incrementing does; setting the current value leaves VERSION unchanged. The
+value may be lower than the current one.
+
+Examples:
+  versionator set-component minor 7     # 1.2.3 -> 1.7.0
+  versionator set-component major 2024  # 1.7.0 -> 2024.0.0
+  versionator set-component revision 9  # 1.2.3.4 -> 1.2.3.9 (four-part)`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSetComponent,
+}
+
+func runSetComponent(cmd *cobra.Command, args []string) error {
+	level, err := version.ParseLevel(args[0])
+	if err != nil {
+		return err
+	}
+	value, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid %s value %q: must be a non-negative integer", args[0], args[1])
+	}
+
+	current, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+	next := *current
+	if err := next.SetLevel(level, value); err != nil {
+		return err
+	}
+	if err := checkMonotonic(cmd, &next); err != nil {
+		return err
+	}
+
+	if err := version.SetComponent(level, value); err != nil {
+		return err
+	}
+
+	v, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("version set but error reading back: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Version set to: %s\n", v.FullString())
+
+	return runConfiguredUpdates(cmd)
+}
+
 func init() {
 	rootCmd.AddCommand(setCmd)
+	rootCmd.AddCommand(setComponentCmd)
+
+	setCmd.Flags().Bool("allow-downgrade", false, allowDowngradeUsage)
+	setComponentCmd.Flags().Bool("allow-downgrade", false, allowDowngradeUsage)
 }
@@ -5,7 +5,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/benjaminabbitt/versionator/internal/version"
 	"github.com/stretchr/testify/suite"
+
+	// Register the four-part scheme as main does
+	_ "github.com/benjaminabbitt/versionator/internal/fourpart"
 )
 
 // SetTestSuite defines the test suite for set command tests.
@@ -135,3 +139,80 @@ func (s *SetTestSuite) TestSetCommand_NoArgs_ReturnsError() {
 
 	s.Error(err)
 }
+
+// TestSetComponentCommand_Minor_SetsValueAndResetsLower validates set-component.
+//
+// Why: Versions aligned with external numbering need one component set
+// directly rather than incremented step by step.
+//
+// What: Given v1.2.3-rc.1, 'set-component minor 7' writes v1.7.0; an unknown
+// component or a negative value is rejected without touching VERSION.
+func (s *SetTestSuite) TestSetComponentCommand_Minor_SetsValueAndResetsLower() {
+	// Precondition: Prefixed pre-release VERSION
+	s.Require().NoError(os.WriteFile("VERSION", []byte("v1.2.3-rc.1\n"), 0644))
+
+	// Action: Set minor
+	rootCmd.SetArgs([]string{"set-component", "minor", "7"})
+	err := rootCmd.Execute()
+
+	// Expected: Minor set, patch and pre-release reset, prefix kept
+	s.Require().NoError(err)
+	content, err := os.ReadFile("VERSION")
+	s.Require().NoError(err)
+	s.Equal("v1.7.0", strings.TrimSpace(string(content)))
+
+	// Action & Expected: Invalid component and value rejected
+	for _, args := range [][]string{{"set-component", "build", "1"}, {"set-component", "--", "patch", "-1"}} {
+		rootCmd.SetArgs(args)
+		s.Error(rootCmd.Execute(), "expected %v to fail", args)
+	}
+	content, err = os.ReadFile("VERSION")
+	s.Require().NoError(err)
+	s.Equal("v1.7.0", strings.TrimSpace(string(content)))
+}
+
+// TestFourPartScheme_BumpAndSetRevision_KeepsFourSegments validates the
+// four-part scheme end to end.
+//
+// Why: Projects versioning Major.Minor.Patch.Build must be able to drive the
+// fourth segment from the CLI, and must not end up with a three-part VERSION.
+//
+// What: With scheme: four-part, 'bump revision' and 'set-component build'
+// change the revision, 'bump patch' resets it, and 'set 2.0.0' is refused.
+func (s *SetTestSuite) TestFourPartScheme_BumpAndSetRevision_KeepsFourSegments() {
+	// Precondition: Four-part scheme and VERSION
+	s.T().Cleanup(func() { version.SetSegments(3) })
+	s.Require().NoError(os.WriteFile(".versionator.yaml", []byte("scheme: four-part\n"), 0644))
+	s.Require().NoError(os.WriteFile("VERSION", []byte("1.2.3.4\n"), 0644))
+
+	steps := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"bump", "revision"}, "1.2.3.5"},
+		{[]string{"set-component", "build", "9"}, "1.2.3.9"},
+		{[]string{"bump", "patch"}, "1.2.4.0"},
+	}
+	for _, step := range steps {
+		// Action: Run the command
+		rootCmd.SetArgs(step.args)
+		err := rootCmd.Execute()
+
+		// Expected: Four segments with the expected revision
+		s.Require().NoError(err, "%v", step.args)
+		content, err := os.ReadFile("VERSION")
+		s.Require().NoError(err)
+		s.Equal(step.want, strings.TrimSpace(string(content)), "%v", step.args)
+	}
+
+	// Action: Set a three-part version
+	rootCmd.SetArgs([]string{"set", "2.0.0"})
+	err := rootCmd.Execute()
+
+	// Expected: Refused, VERSION unchanged
+	s.Require().Error(err)
+	s.Contains(err.Error(), version.ErrMissingRevision)
+	content, err := os.ReadFile("VERSION")
+	s.Require().NoError(err)
+	s.Equal("1.2.4.0", strings.TrimSpace(string(content)))
+}
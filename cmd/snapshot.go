@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+var fromSnapshotFlag string
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Capture resolved template data for builds without repository access",
+	Long: `Capture the version and all resolved template data (commit hashes, branch,
+commits since tag, dirty state, commit and build dates) as JSON.
+
+Run it where the repository is available, then pass the file to later build
+stages with the global --from-snapshot flag. Every command then renders from
+the captured data instead of VERSION, the VCS, and the clock, so all stages
+produce identical versions, even without .git (e.g. inside a Docker build).
+
+Examples:
+  versionator snapshot --output snapshot.json
+  versionator --from-snapshot snapshot.json emit go --output version.go`,
+	Args: cobra.NoArgs,
+	RunE: runSnapshot,
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+
+	snapshotCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
+}
+
+func runSnapshot(cmd *cobra.Command, args []string) error {
+	v, err := version.Load()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+	}
+
+	snapshot := &emit.DataSnapshot{
+		Version: v.FullString(),
+		Data:    emit.BuildTemplateDataFromVersion(v),
+	}
+
+	outputFile, _ := cmd.Flags().GetString("output")
+	if outputFile == "" {
+		return emit.WriteDataSnapshot(cmd.OutOrStdout(), snapshot)
+	}
+
+	file, err := fileperm.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+	if err := emit.WriteDataSnapshot(file, snapshot); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	cmd.PrintErrf("Snapshot of %s written to %s\n", snapshot.Version, outputFile)
+	return nil
+}
+
+// applySnapshotFlag replays the snapshot at path for this invocation, or
+// restores live data when path is empty
+func applySnapshotFlag(path string) error {
+	if path == "" {
+		emit.UseDataSnapshot(nil)
+		version.SetFixed(nil)
+		return nil
+	}
+
+	snapshot, err := emit.ReadDataSnapshot(path)
+	if err != nil {
+		return err
+	}
+	v, err := version.ParseStrict(snapshot.Version)
+	if err != nil {
+		return fmt.Errorf("snapshot %s: %w", path, err)
+	}
+	emit.UseDataSnapshot(snapshot)
+	version.SetFixed(v)
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// SnapshotTestSuite defines the test suite for the snapshot command and the
+// global --from-snapshot flag.
+type SnapshotTestSuite struct {
+	suite.Suite
+	origDir string
+}
+
+// SetupTest runs before each test
+func (suite *SnapshotTestSuite) SetupTest() {
+	var err error
+	suite.origDir, err = os.Getwd()
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.Chdir(suite.T().TempDir()))
+}
+
+// TearDownTest runs after each test
+func (suite *SnapshotTestSuite) TearDownTest() {
+	if suite.origDir != "" {
+		_ = os.Chdir(suite.origDir)
+	}
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	_ = snapshotCmd.Flags().Set("output", "")
+	fromSnapshotFlag = ""
+	_ = applySnapshotFlag("")
+}
+
+// TestSnapshot_CaptureAndReplay_RendersWithoutVersionFile validates that a
+// captured snapshot renders the same version elsewhere.
+//
+// Why: Later build stages (e.g. Docker builds without .git or VERSION) must
+// produce the version computed on the build machine.
+//
+// What: snapshot --output writes the version; in an empty directory,
+// --from-snapshot renders it and no VERSION file is created.
+func (suite *SnapshotTestSuite) TestSnapshot_CaptureAndReplay_RendersWithoutVersionFile() {
+	// Precondition: VERSION on the "build machine"
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("v2.4.1-beta.3\n"), 0644))
+	path := filepath.Join(suite.T().TempDir(), "snapshot.json")
+
+	// Action: Capture, then replay from an empty directory
+	rootCmd.SetArgs([]string{"snapshot", "--output", path})
+	suite.Require().NoError(rootCmd.Execute())
+
+	content, err := os.ReadFile(path)
+	suite.Require().NoError(err)
+	var captured map[string]any
+	suite.Require().NoError(json.Unmarshal(content, &captured))
+
+	stage := suite.T().TempDir()
+	suite.Require().NoError(os.Chdir(stage))
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"--from-snapshot", path, "output", "version", "-t", "{{MajorMinorPatch}} {{PreReleaseLabel}}"})
+	err = rootCmd.Execute()
+
+	// Expected: Captured version rendered; no VERSION created in the stage
+	suite.Require().NoError(err)
+	suite.Equal("v2.4.1-beta.3", captured["version"])
+	suite.Equal("2.4.1 beta", strings.TrimSpace(out.String()))
+	suite.NoFileExists(filepath.Join(stage, "VERSION"))
+}
+
+// TestFromSnapshot_MissingFile_ReturnsError validates flag validation.
+func (suite *SnapshotTestSuite) TestFromSnapshot_MissingFile_ReturnsError() {
+	// Precondition: No snapshot file
+	rootCmd.SetArgs([]string{"--from-snapshot", "missing.json", "output", "version"})
+
+	// Action: Run a command replaying it
+	err := rootCmd.Execute()
+
+	// Expected: Read error
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "failed to read snapshot")
+}
+
+func TestSnapshotTestSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotTestSuite))
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/stamp"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+var stampCmd = &cobra.Command{
+	Use:   "stamp <binary>",
+	Short: "Check a Go binary's embedded version and commit",
+	Long: `Check the build information of a Go binary before it is released:
+
+1. Version: the binary carries the version in VERSION, as its module
+   version (go install, Go 1.24+), an -ldflags "-X ...Version=" value, or
+   a literal compiled in (e.g. from 'output emit go')
+2. Commit: the binary was built from HEAD (vcs.revision, or an
+   -ldflags "-X ...Commit=" value)
+3. Clean: the binary was not built from a tree with uncommitted changes
+
+The binary is only read, never modified. Exits non-zero when a check fails,
+so release pipelines can refuse the artifact.
+
+Examples:
+  versionator stamp ./bin/app
+  versionator stamp ./bin/app --version 1.2.3 --commit 4f2a9c1
+  versionator stamp ./bin/app --allow-dirty`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStamp,
+}
+
+func init() {
+	rootCmd.AddCommand(stampCmd)
+
+	stampCmd.Flags().String("version", "", "Expected version (default: VERSION)")
+	stampCmd.Flags().String("commit", "", "Expected commit (default: HEAD; skipped outside a repository)")
+	stampCmd.Flags().Bool("allow-dirty", false, "Accept binaries built from a tree with uncommitted changes")
+}
+
+func runStamp(cmd *cobra.Command, args []string) error {
+	binary := args[0]
+	info, err := stamp.Read(binary)
+	if err != nil {
+		return err
+	}
+	expect, err := stampExpectation(cmd)
+	if err != nil {
+		return err
+	}
+	contains, err := stamp.FileContains(binary)
+	if err != nil {
+		return err
+	}
+
+	out := newConsole(cmd)
+	out.Infof("Binary: %s (%s, %s)", binary, info.Module, info.GoVersion)
+	var failures []string
+	for _, r := range stamp.Verify(info, expect, contains) {
+		if r.OK {
+			out.Successf("%s: %s", r.Check, r.Detail)
+		} else {
+			failures = append(failures, fmt.Sprintf("%s: %s", r.Check, r.Detail))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s: %s\n  %s", ErrStampChecksFailed, binary, strings.Join(failures, "\n  "))
+	}
+	return nil
+}
+
+// stampExpectation returns the version and commit the binary must carry:
+// the flags, or VERSION and the HEAD of the active repository
+func stampExpectation(cmd *cobra.Command) (stamp.Expect, error) {
+	var expect stamp.Expect
+	expect.AllowDirty, _ = cmd.Flags().GetBool("allow-dirty")
+
+	expect.Version, _ = cmd.Flags().GetString("version")
+	if !cmd.Flags().Changed("version") {
+		v, err := version.Load()
+		if err != nil {
+			return expect, fmt.Errorf("%s: %w", ErrLoadingVersion, err)
+		}
+		expect.Version = v.String()
+	}
+
+	expect.Commit, _ = cmd.Flags().GetString("commit")
+	if !cmd.Flags().Changed("commit") {
+		if activeVCS := vcs.GetActiveVCS(); activeVCS != nil {
+			head, err := activeVCS.GetVCSIdentifier(vcs.MaxIdentifierLength(activeVCS))
+			if err != nil {
+				return expect, err
+			}
+			expect.Commit = head
+		}
+	}
+	return expect, nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/stamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStamp_FailingBinary verifies that stamp reports every failed check.
+//
+// Why: A release gate that stops at the first problem hides the others
+// until the next build.
+//
+// What: The test binary, which records no commit and no clean state, fails
+// with the commit and clean checks listed; --allow-dirty drops the latter.
+func TestStamp_FailingBinary(t *testing.T) {
+	// Precondition: A Go binary without VCS information
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		for _, name := range []string{"version", "commit", "allow-dirty"} {
+			f := stampCmd.Flags().Lookup(name)
+			_ = f.Value.Set(f.DefValue)
+			f.Changed = false
+		}
+	})
+
+	// Action
+	rootCmd.SetArgs([]string{"stamp", exe, "--commit", "4f2a9c1"})
+	err = rootCmd.Execute()
+
+	// Expected
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrStampChecksFailed)
+	assert.Contains(t, err.Error(), stamp.ErrNoRevision)
+	assert.Contains(t, err.Error(), stamp.ErrDirtyUnknown)
+
+	// Action: Dirty trees allowed
+	rootCmd.SetArgs([]string{"stamp", exe, "--commit", "4f2a9c1", "--allow-dirty"})
+	err = rootCmd.Execute()
+
+	// Expected
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), stamp.ErrDirtyUnknown)
+}
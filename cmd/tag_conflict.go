@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+// maxTagConflictAttempts bounds the search for a free tag name
+const maxTagConflictAttempts = 100
+
+// tagConflictStrategy picks another tag when the computed release tag
+// already exists on a different commit
+type tagConflictStrategy struct {
+	// bump is the level to increment until the tag is free
+	bump string
+	// suffix is appended to the tag, its trailing number incremented
+	suffix string
+}
+
+// tagConflictStrategyFor returns the strategy from --bump-on-conflict and
+// --suffix-on-conflict, falling back to release.onConflict
+func tagConflictStrategyFor(cmd *cobra.Command, cfg *config.Config) (tagConflictStrategy, error) {
+	conflict := cfg.Release.OnConflict
+	bump, _ := cmd.Flags().GetString("bump-on-conflict")
+	suffix, _ := cmd.Flags().GetString("suffix-on-conflict")
+	if bump != "" || suffix != "" {
+		conflict = config.TagConflictConfig{Bump: bump, Suffix: suffix}
+	}
+	if err := conflict.Validate(); err != nil {
+		return tagConflictStrategy{}, fmt.Errorf("invalid tag conflict strategy: %w", err)
+	}
+	return tagConflictStrategy{bump: conflict.Bump, suffix: conflict.Suffix}, nil
+}
+
+// resolveReleaseTag returns the tag to create for v and whether it already
+// points at HEAD (the idempotent path for `release push` after `release`).
+// A tag on another commit is reused with --force, resolved by the conflict
+// strategy, or reported. Bumping on conflict changes v.
+func resolveReleaseTag(cmd *cobra.Command, vcsImpl vcs.VersionControlSystem, v *version.Version, prefix string, strategy tagConflictStrategy) (string, bool, error) {
+	force, _ := cmd.Flags().GetBool("force")
+	base := tagformat.Name(prefix, v.String())
+	tagName := base
+
+	for attempt := 1; ; attempt++ {
+		exists, err := vcsImpl.TagExists(tagName)
+		if err != nil {
+			return "", false, fmt.Errorf("error checking if tag exists: %w", err)
+		}
+		if !exists {
+			return tagName, false, nil
+		}
+
+		headCommit, err := vcsImpl.GetVCSIdentifier(vcs.MaxIdentifierLength(vcsImpl))
+		if err != nil {
+			return "", false, fmt.Errorf("error reading HEAD: %w", err)
+		}
+		existingTagCommit, err := vcsImpl.GetTagCommit(tagName)
+		if err != nil {
+			return "", false, fmt.Errorf("error resolving existing tag %q: %w", tagName, err)
+		}
+		if existingTagCommit == headCommit {
+			return tagName, true, nil
+		}
+		if force {
+			return tagName, false, nil
+		}
+
+		var next string
+		switch {
+		case strategy.bump != "":
+			level, err := version.ParseLevel(strategy.bump)
+			if err != nil {
+				return "", false, err
+			}
+			if err := v.IncrementLevel(level); err != nil {
+				return "", false, err
+			}
+			next = tagformat.Name(prefix, v.String())
+		case strategy.suffix != "":
+			next = base + nthSuffix(strategy.suffix, attempt-1)
+		default:
+			return "", false, fmt.Errorf("tag '%s' already exists at %s (not HEAD %s). Use --force to overwrite, or --bump-on-conflict/--suffix-on-conflict",
+				tagName, shortCommit(existingTagCommit), shortCommit(headCommit))
+		}
+		if attempt > maxTagConflictAttempts {
+			return "", false, fmt.Errorf("%s after %d attempts (last tried '%s')", ErrTagConflictUnresolved, maxTagConflictAttempts, tagName)
+		}
+		cmd.Printf("Tag '%s' already exists at %s; trying '%s'\n", tagName, shortCommit(existingTagCommit), next)
+		tagName = next
+	}
+}
+
+// nthSuffix returns suffix with its trailing number increased by n
+// (".1", 2 -> ".3")
+func nthSuffix(suffix string, n int) string {
+	head := strings.TrimRight(suffix, "0123456789")
+	number, _ := strconv.Atoi(suffix[len(head):])
+	return head + strconv.Itoa(number+n)
+}
+
+// shortCommit abbreviates a commit identifier for messages
+func shortCommit(id string) string {
+	return id[:min(7, len(id))]
+}
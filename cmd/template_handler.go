@@ -52,6 +52,16 @@ var metadataAccessor = templateAccessor{
 	labelLower:  "metadata",
 }
 
+// warnZeroPaddedPreRelease warns about pre-release identifiers that render
+// as zero-padded numbers, which SemVer forbids
+func warnZeroPaddedPreRelease(cmd *cobra.Command, template string) {
+	for _, identifier := range emit.ZeroPaddedPreRelease(template) {
+		newConsole(cmd).Warnf("%s: %q (SemVer forbids leading zeros in numeric identifiers; "+
+			"prefix it with letters, e.g. build{{CommitsSinceTagPadded(4)}}, or move it to metadata)",
+			emit.ErrZeroPaddedPreRelease, identifier)
+	}
+}
+
 // runTemplateCommand handles the template subcommand for both prerelease and metadata
 func runTemplateCommand(cmd *cobra.Command, args []string, acc templateAccessor) error {
 	cfg, err := config.ReadConfig()
@@ -90,6 +100,9 @@ func showTemplate(cmd *cobra.Command, cfg *config.Config, acc templateAccessor)
 
 // setTemplate sets a new template value
 func setTemplate(cmd *cobra.Command, cfg *config.Config, template string, acc templateAccessor) error {
+	if acc.kind == templateKindPreRelease {
+		warnZeroPaddedPreRelease(cmd, template)
+	}
 	acc.setTemplate(cfg, template)
 	if err := config.WriteConfig(cfg); err != nil {
 		return fmt.Errorf("error writing config: %w", err)
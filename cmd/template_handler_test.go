@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/emit"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
@@ -314,3 +315,51 @@ func TestTemplateCommand_ShowsRenderedValue(t *testing.T) {
 	rootCmd.SetOut(nil)
 	rootCmd.SetArgs(nil)
 }
+
+// TestTemplateCommand_WarnsZeroPaddedPreRelease verifies that setting a
+// pre-release template that zero-pads a numeric identifier is reported.
+func TestTemplateCommand_WarnsZeroPaddedPreRelease(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantWarn bool
+	}{
+		{name: "padded numeric identifier", template: "rc.{{BuildNumberPadded}}", wantWarn: true},
+		{name: "padded alphanumeric identifier", template: "build{{CommitsSinceTagPadded(6)}}", wantWarn: false},
+		{name: "unpadded numeric identifier", template: "rc.{{CommitsSinceTag}}", wantWarn: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			originalDir, err := os.Getwd()
+			require.NoError(t, err)
+			defer func() { _ = os.Chdir(originalDir) }()
+			require.NoError(t, os.Chdir(tempDir))
+			require.NoError(t, os.WriteFile("VERSION", []byte("1.0.0\n"), 0644))
+			configData, err := yaml.Marshal(&config.Config{})
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(".versionator.yaml", configData, 0644))
+
+			var stdout, stderr bytes.Buffer
+			rootCmd.SetOut(&stdout)
+			rootCmd.SetErr(&stderr)
+			rootCmd.SetArgs([]string{"config", "prerelease", "template", tt.template})
+			defer func() {
+				rootCmd.SetOut(nil)
+				rootCmd.SetErr(nil)
+				rootCmd.SetArgs(nil)
+			}()
+
+			err = rootCmd.Execute()
+
+			require.NoError(t, err)
+			if tt.wantWarn {
+				assert.Contains(t, stderr.String(), emit.ErrZeroPaddedPreRelease)
+				assert.Contains(t, stderr.String(), `"{{BuildNumberPadded}}"`)
+			} else {
+				assert.NotContains(t, stderr.String(), emit.ErrZeroPaddedPreRelease)
+			}
+		})
+	}
+}
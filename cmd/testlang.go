@@ -0,0 +1,84 @@
+//go:build devtools
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/benjaminabbitt/versionator/internal/testlang"
+
+	"github.com/spf13/cobra"
+)
+
+var testlangCmd = &cobra.Command{
+	Use:   "testlang [language...]",
+	Short: "Run the example projects end to end in containers (developer tool)",
+	Long: `Build and run the example projects under examples/ in their containers,
+using versionator built from this checkout, and check each prints the
+version written to its VERSION file.
+
+For each language the example is copied to a temporary directory, VERSION is
+set (default ` + testlang.DefaultVersion + `), the image is built from its
+Containerfile, and "make run" runs with the local versionator mounted over
+the one in the image. The example passes when it prints "Version: <version>".
+
+With no arguments every example that has a Containerfile runs. Requires Go
+and podman or docker. Only available in builds with -tags devtools.
+
+Examples:
+  go run -tags devtools . testlang              # Every language
+  go run -tags devtools . testlang go python    # Selected languages
+  go run -tags devtools . testlang --engine docker rust`,
+	RunE: runTestlang,
+}
+
+func init() {
+	rootCmd.AddCommand(testlangCmd)
+
+	testlangCmd.Flags().String("examples", "examples", "Directory with one example project per language")
+	testlangCmd.Flags().String("engine", "", "Container CLI (default: podman, then docker)")
+	testlangCmd.Flags().String("version", testlang.DefaultVersion, "Version to inject and expect")
+}
+
+func runTestlang(cmd *cobra.Command, args []string) error {
+	examples, _ := cmd.Flags().GetString("examples")
+	engine, _ := cmd.Flags().GetString("engine")
+	version, _ := cmd.Flags().GetString("version")
+
+	harness := testlang.NewHarnessDefault(testlang.Options{
+		ExamplesDir: examples,
+		Engine:      engine,
+		Version:     version,
+		Log:         cmd.ErrOrStderr(),
+	})
+
+	languages := args
+	if len(languages) == 0 {
+		var err error
+		if languages, err = harness.Languages(); err != nil {
+			return err
+		}
+	}
+
+	results, err := harness.Run(cmd.Context(), languages)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Err == nil {
+			cmd.Printf("PASS  %s\n", r.Language)
+			continue
+		}
+		failed++
+		cmd.Printf("FAIL  %s: %v\n", r.Language, r.Err)
+		if r.Output != "" {
+			cmd.Printf("%s\n", r.Output)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d languages failed", failed, len(results))
+	}
+	return nil
+}
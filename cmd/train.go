@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/train"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+// trainNow is the clock the train commands read; tests replace it
+var trainNow = time.Now
+
+var trainCmd = &cobra.Command{
+	Use:   "train",
+	Short: "Show the release train schedule",
+	Long: `Show where the configured release train stands.
+
+A release train cuts one version level at a fixed interval from a start date.
+Configure it under 'train' in .versionator.yaml:
+
+  train:
+    level: minor
+    every: 6w
+    start: 2024-01-15
+    from: 1.0.0
+
+The same schedule is available to templates as TrainExpectedVersion,
+TrainLastCutDate, TrainNextVersion, TrainNextCutDate and TrainDaysToNextCut.`,
+}
+
+var trainStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the expected version and next cut",
+	Long: `Show the schedule, the version the train expects to be current, how
+VERSION compares to it, and when the next cut is due.`,
+	Args: cobra.NoArgs,
+	RunE: runTrainStatus,
+}
+
+var trainNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the next cut",
+	Long: `Print the version, date and days remaining for the next scheduled cut.
+
+Example:
+  versionator train next   # 1.5.0 on 2024-07-01 (in 12 days)`,
+	Args: cobra.NoArgs,
+	RunE: runTrainNext,
+}
+
+func init() {
+	rootCmd.AddCommand(trainCmd)
+	trainCmd.AddCommand(trainStatusCmd)
+	trainCmd.AddCommand(trainNextCmd)
+}
+
+// loadTrainStatus reads the train from .versionator.yaml and places it on
+// today's date
+func loadTrainStatus() (*config.TrainConfig, train.Status, error) {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return nil, train.Status{}, fmt.Errorf("error reading config: %w", err)
+	}
+	schedule, err := train.NewScheduleFromConfig(cfg)
+	if err != nil {
+		return nil, train.Status{}, err
+	}
+	status, err := schedule.At(trainNow())
+	if err != nil {
+		return nil, train.Status{}, err
+	}
+	return &cfg.Train, status, nil
+}
+
+func runTrainStatus(cmd *cobra.Command, args []string) error {
+	tc, status, err := loadTrainStatus()
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+
+	fmt.Fprintf(out, "Train: %s every %s from %s (starting %s)\n", tc.Level, tc.Every, tc.From, tc.Start)
+	if status.Started {
+		fmt.Fprintf(out, "Expected: %s (cut %s)\n", status.Current.FullString(), status.LastCut.Format(time.DateOnly))
+	} else {
+		fmt.Fprintln(out, "Expected: not started")
+	}
+
+	if v, err := version.Load(); err == nil {
+		fmt.Fprintf(out, "VERSION:  %s%s\n", v.FullString(), compareToTrain(v, status))
+	}
+
+	fmt.Fprintf(out, "Next:     %s on %s (in %s)\n", status.Next.FullString(), status.NextCut.Format(time.DateOnly), pluralDays(status.DaysToNextCut))
+	return nil
+}
+
+func runTrainNext(cmd *cobra.Command, args []string) error {
+	_, status, err := loadTrainStatus()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s on %s (in %s)\n", status.Next.FullString(), status.NextCut.Format(time.DateOnly), pluralDays(status.DaysToNextCut))
+	return nil
+}
+
+// compareToTrain describes VERSION relative to the expected version
+func compareToTrain(v *version.Version, status train.Status) string {
+	if !status.Started {
+		return ""
+	}
+	switch c := v.Compare(status.Current); {
+	case c < 0:
+		return " (behind the train)"
+	case c > 0:
+		return " (ahead of the train)"
+	default:
+		return " (on schedule)"
+	}
+}
+
+func pluralDays(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
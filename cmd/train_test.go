@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// TrainTestSuite defines the test suite for the train commands.
+type TrainTestSuite struct {
+	suite.Suite
+	out bytes.Buffer
+}
+
+// SetupTest runs before each test
+func (suite *TrainTestSuite) SetupTest() {
+	suite.T().Chdir(suite.T().TempDir())
+	suite.out.Reset()
+	rootCmd.SetOut(&suite.out)
+	rootCmd.SetErr(&suite.out)
+	trainNow = func() time.Time { return time.Date(2024, 6, 19, 9, 0, 0, 0, time.UTC) }
+	suite.Require().NoError(os.WriteFile(".versionator.yaml", []byte(
+		"train:\n  level: minor\n  every: 6w\n  start: 2024-01-15\n  from: 1.0.0\n"), 0644))
+}
+
+// TearDownTest runs after each test
+func (suite *TrainTestSuite) TearDownTest() {
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	rootCmd.SetArgs(nil)
+	trainNow = time.Now
+}
+
+// TestTrain_Status_ComparesVersionToSchedule validates train status.
+//
+// Why: Release managers check whether VERSION has kept up with the train
+// before a cut.
+//
+// What: On 2024-06-19 the train expects 1.3.0 (cut 2024-05-20); a VERSION
+// of 1.2.0 is reported behind, and the next cut is 1.4.0 in 12 days.
+func (suite *TrainTestSuite) TestTrain_Status_ComparesVersionToSchedule() {
+	// Precondition
+	suite.Require().NoError(os.WriteFile("VERSION", []byte("1.2.0\n"), 0644))
+
+	// Action
+	rootCmd.SetArgs([]string{"train", "status"})
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Require().NoError(err)
+	suite.Contains(suite.out.String(), "Expected: 1.3.0 (cut 2024-05-20)")
+	suite.Contains(suite.out.String(), "VERSION:  1.2.0 (behind the train)")
+	suite.Contains(suite.out.String(), "Next:     1.4.0 on 2024-07-01 (in 12 days)")
+}
+
+// TestTrain_Next_PrintsNextCut validates train next.
+//
+// Why: Scripts and chat bots print the upcoming cut as a single line.
+//
+// What: The next version, date, and days remaining are printed.
+func (suite *TrainTestSuite) TestTrain_Next_PrintsNextCut() {
+	// Action
+	rootCmd.SetArgs([]string{"train", "next"})
+	err := rootCmd.Execute()
+
+	// Expected
+	suite.Require().NoError(err)
+	suite.Equal("1.4.0 on 2024-07-01 (in 12 days)\n", suite.out.String())
+}
+
+func TestTrainTestSuite(t *testing.T) {
+	suite.Run(t, new(TrainTestSuite))
+}
@@ -26,9 +26,8 @@ func runConfiguredUpdates(cmd *cobra.Command) error {
 	}
 
 	logger, _ := zap.NewProduction()
-	updater := update.NewUpdater(cfg.Updates, update.NewDaselFileParser(), logger)
-
 	templateData := emit.BuildCompleteTemplateData(v, cfg.PreRelease.Template, cfg.Metadata.Template)
+	updater := newConfiguredUpdater(cfg, templateData, false, logger)
 
 	if err := updater.UpdateFiles(templateData); err != nil {
 		return fmt.Errorf("error updating files: %w", err)
@@ -40,3 +39,12 @@ func runConfiguredUpdates(cmd *cobra.Command) error {
 
 	return nil
 }
+
+// newConfiguredUpdater returns an Updater for the configured updates. Outside
+// a release, Maven/Gradle build files get the SNAPSHOT qualifier when
+// java.snapshot asks for it for the build data describes.
+func newConfiguredUpdater(cfg *config.Config, data emit.TemplateData, release bool, logger *zap.Logger) *update.Updater {
+	updater := update.NewUpdater(cfg.Updates, update.NewDaselFileParser(), logger)
+	updater.SetSnapshot(!release && cfg.Java.UseSnapshot(emit.IsTaggedBuild(data)))
+	return updater
+}
@@ -34,11 +34,12 @@ func runVars(cmd *cobra.Command, args []string) error {
 	// Build template data
 	templateData := emit.BuildTemplateDataFromVersion(vd)
 
-	// Load custom variables from config
-	customVars, _ := config.GetAllCustom()
-	if customVars != nil {
-		templateData.Custom = customVars
+	// Load custom variables from config, over any VERSION file fields
+	customVars, err := config.GetAllCustom()
+	if err != nil {
+		return fmt.Errorf("error loading custom variables: %w", err)
 	}
+	emit.MergeCustomVars(&templateData, customVars)
 
 	// Populate PreRelease and Metadata from config
 	prerelease, _ := versionator.RenderPreRelease()
@@ -65,6 +66,12 @@ func runVars(cmd *cobra.Command, args []string) error {
 		"Version Components": {
 			"Major", "Minor", "Patch", "Revision", "MajorMinorPatch", "MajorMinor", "Prefix",
 		},
+		"Version Arithmetic": {
+			"MajorPlusOne", "MinorPlusOne", "PatchPlusOne", "NextMajor", "NextMinor", "NextPatch",
+		},
+		"Numeric Encodings": {
+			"VersionInt", "VersionHex", "VersionBCD",
+		},
 		"Pre-release (template-based)": {
 			"PreRelease", "PreReleaseWithDash", "PreReleaseLabel", "PreReleaseNumber",
 		},
@@ -75,11 +82,15 @@ func runVars(cmd *cobra.Command, args []string) error {
 			"Hash", "ShortHash", "MediumHash",
 			"BranchName", "EscapedBranchName",
 			"CommitsSinceTag", "BuildNumber", "BuildNumberPadded",
-			"UncommittedChanges", "Dirty",
-			"VersionSourceHash",
+			"UncommittedChanges", "Dirty", "IsReleaseBuild",
+			"VersionSourceHash", "HashAlgorithm",
+			"PreviousVersion", "PreviousTag",
+			"CommitsAheadOfDefault", "CommitsBehindDefault",
+			"InferredBranchName", "InferredBranchConfidence",
 		},
 		"Commit Author": {
 			"CommitAuthor", "CommitAuthorEmail",
+			"VersionFileLastChangedBy", "VersionFileLastChangedDate",
 		},
 		"Commit Timestamps": {
 			"CommitDate", "CommitDateCompact", "CommitDateShort",
@@ -90,16 +101,22 @@ func runVars(cmd *cobra.Command, args []string) error {
 			"BuildYear", "BuildMonth", "BuildDay",
 			"DateTimeDirty",
 		},
+		"Build Host (env.host)": {
+			"GoVersion", "OS", "Arch", "Hostname", "NumCPU",
+		},
 	}
 
 	categoryOrder := []string{
 		"Version Components",
+		"Version Arithmetic",
+		"Numeric Encodings",
 		"Pre-release (template-based)",
 		"Metadata (template-based)",
 		"VCS/Git",
 		"Commit Author",
 		"Commit Timestamps",
 		"Build Timestamps",
+		"Build Host (env.host)",
 	}
 
 	for _, category := range categoryOrder {
@@ -141,18 +158,24 @@ func runVars(cmd *cobra.Command, args []string) error {
 
 	// Display custom variables from config file
 	if len(templateData.Custom) > 0 {
-		cmd.Printf("\nCustom Variables (from .versionator.yaml)\n")
-		cmd.Println(strings.Repeat("-", 36))
+		// Computed custom variables are shown as rendered
+		custom, err := emit.ResolveCustomVars(templateData)
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("\nCustom Variables (from .versionator.yaml and VERSION)\n")
+		cmd.Println(strings.Repeat("-", 48))
 
 		// Sort keys for consistent output
-		keys := make([]string, 0, len(templateData.Custom))
-		for k := range templateData.Custom {
+		keys := make([]string, 0, len(custom))
+		for k := range custom {
 			keys = append(keys, k)
 		}
 		sortStrings(keys)
 
 		for _, k := range keys {
-			valueStr := templateData.Custom[k]
+			valueStr := custom[k]
 			if len(valueStr) > 40 {
 				valueStr = valueStr[:37] + "..."
 			}
@@ -164,11 +187,46 @@ func runVars(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Display date variables defined under dates in the config
+	if len(templateData.Dates) > 0 {
+		cmd.Printf("\nDate Formats (from .versionator.yaml)\n")
+		cmd.Println(strings.Repeat("-", 37))
+
+		keys := make([]string, 0, len(templateData.Dates))
+		for k := range templateData.Dates {
+			keys = append(keys, k)
+		}
+		sortStrings(keys)
+
+		for _, k := range keys {
+			valueStr := templateData.Dates[k]
+			if valueStr == "" {
+				valueStr = "(empty)"
+			}
+			cmd.Printf("  {{%s}}%s = %s\n", k, strings.Repeat(" ", max(1, 30-len(k))), valueStr)
+		}
+	}
+
+	// Display aliases for renamed variables
+	cmd.Printf("\nAliases\n")
+	cmd.Println(strings.Repeat("-", 7))
+	for _, alias := range emit.Aliases() {
+		status := ""
+		if alias.Deprecated {
+			status = " (deprecated)"
+		}
+		padding := max(30-len(alias.Name), 1)
+		cmd.Printf("  {{%s}}%s = {{%s}}%s\n", alias.Name, strings.Repeat(" ", padding), alias.Target, status)
+	}
+
 	// Display plugin-provided variables
 	pluginVars := plugin.GetAllTemplateVariables(map[string]string{
-		"ShortHash":  templateData.ShortHash,
-		"MediumHash": templateData.MediumHash,
-		"Hash":       templateData.Hash,
+		"ShortHash":       templateData.ShortHash,
+		"MediumHash":      templateData.MediumHash,
+		"Hash":            templateData.Hash,
+		"MajorMinorPatch": templateData.MajorMinorPatch,
+		"PreRelease":      templateData.PreRelease,
+		"Metadata":        templateData.Metadata,
 	})
 	if len(pluginVars) > 0 {
 		cmd.Printf("\nPlugin Variables\n")
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/clierr"
+	"github.com/benjaminabbitt/versionator/internal/commitparser"
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+var verifyTagCmd = &cobra.Command{
+	Use:   "verify-tag <tag>",
+	Short: "Verify a release tag's signature and version",
+	Long: `Verify a release tag for release pipelines:
+
+1. The tag carries a valid GPG or SSH signature from an allowed signer
+2. The tag's version matches VERSION as committed at the tagged commit
+
+Allowed signers are configured in .versionator.yaml:
+
+  release:
+    verify:
+      allowedSignersFile: .github/allowed_signers  # SSH (git's format)
+      gpgKeys:                                     # GPG fingerprints
+        - 74C08BB82326AE3C2EEC642C6A32387C04BB2393
+
+SSH signatures are checked against the allowed signers file; GPG signatures
+must verify against the local keyring and come from a listed key (the
+signing key or its primary key), given by full fingerprint or 16-digit long
+key ID. The flags replace the configured signers.
+
+Exits non-zero when either check fails.
+
+Examples:
+  versionator verify-tag v1.2.3
+  versionator verify-tag v1.2.3 --allowed-signers ~/.ssh/allowed_signers
+  versionator verify-tag v1.2.3 --gpg-key 74C08BB82326AE3C2EEC642C6A32387C04BB2393`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVerifyTag,
+}
+
+func init() {
+	rootCmd.AddCommand(verifyTagCmd)
+
+	verifyTagCmd.Flags().String("allowed-signers", "", "SSH allowed signers file (replaces release.verify)")
+	verifyTagCmd.Flags().StringSlice("gpg-key", nil, "Trusted GPG key fingerprint or long key ID, repeatable (replaces release.verify)")
+}
+
+func runVerifyTag(cmd *cobra.Command, args []string) error {
+	tagName := args[0]
+	activeVCS := vcs.GetActiveVCS()
+	if activeVCS == nil {
+		return clierr.NoRepository(commitparser.ErrNoVCSDetected)
+	}
+	if err := vcs.RequireCapability(activeVCS, vcs.CapabilitySignedTags); err != nil {
+		return err
+	}
+	verifier, ok := activeVCS.(vcs.TagVerifier)
+	if !ok {
+		return fmt.Errorf("%s does not support signed tags", activeVCS.Name())
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("error reading config: %w", err)
+	}
+	signers, err := allowedSigners(cmd, cfg.Release.Verify, activeVCS)
+	if err != nil {
+		return err
+	}
+
+	commit, err := activeVCS.GetTagCommit(tagName)
+	if err != nil {
+		return err
+	}
+	sig, err := verifier.VerifyTag(tagName, signers.AllowedSignersFile)
+	if err != nil {
+		return err
+	}
+	if err := checkSigner(sig, signers); err != nil {
+		return err
+	}
+
+	tagged, fileVersion, err := taggedVersions(activeVCS, verifier, tagName, commit)
+	if err != nil {
+		return err
+	}
+	if tagged != fileVersion {
+		return fmt.Errorf("%s: %s is %s, VERSION is %s", ErrTagVersionMismatch, tagName, tagged, fileVersion)
+	}
+
+	out := newConsole(cmd)
+	out.Successf("Signature: good %s signature by %s", sig.Format, describeSigner(sig))
+	out.Successf("Version: %s matches VERSION at %s", tagged, commit[:min(len(commit), 12)])
+	return nil
+}
+
+// allowedSigners returns the signers from the flags, or from release.verify
+// with a relative allowed signers file resolved from the repository root
+func allowedSigners(cmd *cobra.Command, verify config.VerifyConfig, v vcs.VersionControlSystem) (config.VerifyConfig, error) {
+	if cmd.Flags().Changed("allowed-signers") || cmd.Flags().Changed("gpg-key") {
+		file, _ := cmd.Flags().GetString("allowed-signers")
+		keys, _ := cmd.Flags().GetStringSlice("gpg-key")
+		verify = config.VerifyConfig{AllowedSignersFile: file, GPGKeys: keys}
+		if err := verify.Validate(); err != nil {
+			return verify, fmt.Errorf("invalid --gpg-key: %w", err)
+		}
+	} else if verify.AllowedSignersFile != "" && !filepath.IsAbs(verify.AllowedSignersFile) {
+		root, err := v.GetRepositoryRoot()
+		if err != nil {
+			return verify, err
+		}
+		verify.AllowedSignersFile = filepath.Join(root, verify.AllowedSignersFile)
+	}
+	if verify.AllowedSignersFile == "" && len(verify.GPGKeys) == 0 {
+		return verify, fmt.Errorf("%s: set release.verify in .versionator.yaml or pass --allowed-signers or --gpg-key", ErrNoAllowedSigners)
+	}
+	return verify, nil
+}
+
+// checkSigner reports whether sig comes from an allowed signer. SSH
+// signatures only verify when their key is in the allowed signers file, so
+// a matched principal is enough; GPG keys are compared by full fingerprint,
+// or by long key ID against a fingerprint's last 16 digits.
+func checkSigner(sig vcs.TagSignature, signers config.VerifyConfig) error {
+	switch sig.Format {
+	case vcs.SignatureFormatSSH:
+		if signers.AllowedSignersFile != "" && sig.Signer != "" {
+			return nil
+		}
+	case vcs.SignatureFormatGPG:
+		for _, key := range signers.GPGKeys {
+			key, err := config.NormalizeGPGKey(key)
+			if err != nil {
+				return err
+			}
+			for _, fingerprint := range sig.Fingerprints {
+				if gpgKeyMatches(key, strings.ToUpper(fingerprint)) {
+					return nil
+				}
+			}
+		}
+	}
+	return fmt.Errorf("%s: %s signature by %s", ErrSignerNotAllowed, sig.Format, describeSigner(sig))
+}
+
+// gpgKeyMatches reports whether a normalized fingerprint or long key ID
+// identifies the key with fingerprint
+func gpgKeyMatches(key, fingerprint string) bool {
+	if len(key) == 16 && len(fingerprint) == 40 {
+		return fingerprint[24:] == key
+	}
+	return fingerprint == key
+}
+
+// describeSigner names the signer and key of sig
+func describeSigner(sig vcs.TagSignature) string {
+	key := strings.Join(sig.Fingerprints, ", ")
+	if sig.Signer == "" {
+		return key
+	}
+	return fmt.Sprintf("%s (%s)", sig.Signer, key)
+}
+
+// taggedVersions returns the version in the tag name and the version in
+// VERSION as committed at the tagged commit, both without prefix
+func taggedVersions(v vcs.VersionControlSystem, verifier vcs.TagVerifier, tagName, commit string) (string, string, error) {
+	name, ok := tagformat.Version(tagName)
+	if !ok {
+		return "", "", fmt.Errorf("tag %q does not follow release.tagFormat", tagName)
+	}
+	tagged, err := version.ParseStrict(name)
+	if err != nil {
+		return "", "", fmt.Errorf("tag %q is not a version: %w", tagName, err)
+	}
+
+	path, err := version.Path()
+	if err != nil {
+		return "", "", err
+	}
+	root, err := v.GetRepositoryRoot()
+	if err != nil {
+		return "", "", err
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return "", "", err
+	}
+	content, err := verifier.ReadFileAt(commit, rel)
+	if err != nil {
+		return "", "", err
+	}
+	fileVersion, err := version.ParseContent(content, tagName+":"+filepath.ToSlash(rel))
+	if err != nil {
+		return "", "", err
+	}
+	if fileVersion.Raw != "" {
+		return tagged.String(), fileVersion.Raw, nil
+	}
+	return tagged.String(), fileVersion.String(), nil
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	gitVCS "github.com/benjaminabbitt/versionator/internal/vcs/git"
+	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verifyingVCS wraps the generated mock with the optional TagVerifier
+// capability, returning a fixed signature and files
+type verifyingVCS struct {
+	*mock.MockVersionControlSystem
+	sig   vcs.TagSignature
+	files map[string]string
+}
+
+func (v *verifyingVCS) VerifyTag(tagName, allowedSignersFile string) (vcs.TagSignature, error) {
+	return v.sig, nil
+}
+
+func (v *verifyingVCS) ReadFileAt(commit, path string) ([]byte, error) {
+	content, ok := v.files[commit+":"+filepath.ToSlash(path)]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return []byte(content), nil
+}
+
+// TestVerifyTag_SignerAndVersion validates release tag verification.
+//
+// Why: Release pipelines must refuse tags signed by unknown keys, and tags
+// whose name disagrees with the VERSION they were cut from.
+//
+// What: A trusted signature on a tag matching VERSION at its commit passes;
+// an untrusted GPG key, a version mismatch, and missing signer
+// configuration fail with their messages.
+func TestVerifyTag_SignerAndVersion(t *testing.T) {
+	ssh := vcs.TagSignature{Format: vcs.SignatureFormatSSH, Signer: "release@example.com", Fingerprints: []string{"SHA256:abc"}}
+	gpg := vcs.TagSignature{Format: vcs.SignatureFormatGPG, Signer: "Release <release@example.com>", Fingerprints: []string{"74C08BB82326AE3C2EEC642C6A32387C04BB2393"}}
+	tests := []struct {
+		name    string
+		config  string
+		args    []string
+		sig     vcs.TagSignature
+		tag     string
+		wantOut string
+		wantErr string
+	}{
+		{name: "ssh signer", config: "release:\n  verify:\n    allowedSignersFile: allowed_signers\n", sig: ssh, tag: "v1.2.3", wantOut: "Version: 1.2.3 matches VERSION"},
+		{name: "gpg key id", args: []string{"--gpg-key", "6A32387C04BB2393"}, sig: gpg, tag: "v1.2.3", wantOut: "good gpg signature by Release <release@example.com>"},
+		{name: "untrusted gpg key", args: []string{"--gpg-key", "0000000000000000"}, sig: gpg, tag: "v1.2.3", wantErr: ErrSignerNotAllowed},
+		{name: "gpg fingerprint", config: "release:\n  verify:\n    gpgKeys: [\"74C0 8BB8 2326 AE3C 2EEC 642C 6A32 387C 04BB 2393\"]\n", sig: gpg, tag: "v1.2.3", wantOut: "good gpg signature"},
+		{name: "short gpg key id rejected", args: []string{"--gpg-key", "04BB2393"}, sig: gpg, tag: "v1.2.3", wantErr: "invalid --gpg-key"},
+		{name: "gpg fingerprint of another key", args: []string{"--gpg-key", "FFFFFFFFFFFFFFFFFFFFFFFF6A32387C04BB2393"}, sig: gpg, tag: "v1.2.3", wantErr: ErrSignerNotAllowed},
+		{name: "version mismatch", args: []string{"--allowed-signers", "allowed_signers"}, sig: ssh, tag: "v1.3.0", wantErr: ErrTagVersionMismatch + ": v1.3.0 is 1.3.0, VERSION is 1.2.3"},
+		{name: "no signers", sig: ssh, tag: "v1.2.3", wantErr: ErrNoAllowedSigners},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition: Tag on a commit whose VERSION is 1.2.3
+			dir := t.TempDir()
+			t.Chdir(dir)
+			require.NoError(t, os.WriteFile("VERSION", []byte("1.2.3\n"), 0644))
+			if tt.config != "" {
+				require.NoError(t, os.WriteFile(".versionator.yaml", []byte(tt.config), 0644))
+			}
+			ctrl := gomock.NewController(t)
+			mockVCS := mock.NewMockVersionControlSystem(ctrl)
+			mockVCS.EXPECT().Name().Return("git").AnyTimes()
+			mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+			mockVCS.EXPECT().GetRepositoryRoot().Return(dir, nil).AnyTimes()
+			mockVCS.EXPECT().GetTagCommit(tt.tag).Return("abc1234def5678", nil).AnyTimes()
+			vcs.UnregisterVCS("git")
+			vcs.RegisterVCS(&verifyingVCS{MockVersionControlSystem: mockVCS, sig: tt.sig, files: map[string]string{"abc1234def5678:VERSION": "1.2.3\n"}})
+			defer func() {
+				vcs.UnregisterVCS("git")
+				vcs.RegisterVCS(gitVCS.NewGitVCSDefault())
+				signers, keys := verifyTagCmd.Flags().Lookup("allowed-signers"), verifyTagCmd.Flags().Lookup("gpg-key")
+				_ = signers.Value.Set("")
+				_ = keys.Value.(pflag.SliceValue).Replace(nil)
+				signers.Changed, keys.Changed = false, false
+			}()
+
+			// Action
+			var out bytes.Buffer
+			rootCmd.SetOut(&out)
+			rootCmd.SetArgs(append([]string{"verify-tag", tt.tag}, tt.args...))
+			defer func() {
+				rootCmd.SetOut(nil)
+				rootCmd.SetArgs(nil)
+			}()
+			err := rootCmd.Execute()
+
+			// Expected
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), tt.wantOut)
+		})
+	}
+}
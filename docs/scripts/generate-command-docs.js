@@ -209,6 +209,7 @@ function generateVariablesDoc(templateVars) {
     { key: 'vcs', title: 'VCS / Git Information', description: 'Version control information' },
     { key: 'commitInfo', title: 'Commit Information', description: 'Details about the current commit' },
     { key: 'buildTimestamps', title: 'Build Timestamps', description: 'Timestamps at build time' },
+    { key: 'buildHost', title: 'Build Host', description: 'The machine running the build, empty unless `env.host: true`' },
   ];
 
   for (const cat of categories) {
@@ -28,6 +28,7 @@ const sidebars: SidebarsConfig = {
         'commands/init',
         'commands/bump',
         'commands/release',
+        'commands/component',
         'commands/config',
         'commands/output',
         'commands/support',
@@ -84,6 +85,7 @@ const sidebars: SidebarsConfig = {
           ],
         },
         'integration/git',
+        'integration/subversion',
         {
           type: 'category',
           label: 'CI/CD',
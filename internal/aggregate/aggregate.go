@@ -0,0 +1,250 @@
+// Package aggregate collects the versions of the components of an umbrella
+// release (git submodules, or subdirectories with their own VERSION file or
+// tags) into one manifest.
+//
+// The same versions are exposed to templates as
+// {{Components.<name>.Version}}, so umbrella build files can pin each
+// component without reading it by hand.
+package aggregate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"gopkg.in/yaml.v3"
+)
+
+// versionFile is the file holding a component version
+const versionFile = "VERSION"
+
+// Format represents a supported manifest output format
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// AvailableFormats returns the names of all supported manifest formats
+func AvailableFormats() []string {
+	return []string{string(FormatJSON), string(FormatYAML)}
+}
+
+// Source locates the version of one component
+type Source struct {
+	// Name identifies the component in the manifest and templates
+	Name string
+	// Path is the component directory relative to the repository root
+	Path string
+	// TagFormat, when set, reads the highest matching tag instead of
+	// Path/VERSION
+	TagFormat string
+}
+
+// Sources returns the components configured under aggregate, sorted by name
+func Sources(cfg config.AggregateConfig) []Source {
+	sources := make([]Source, 0, len(cfg.Components))
+	for _, name := range slices.Sorted(maps.Keys(cfg.Components)) {
+		c := cfg.Components[name]
+		sources = append(sources, Source{Name: name, Path: c.Path, TagFormat: c.TagFormat})
+	}
+	return sources
+}
+
+// Component is the collected version of one component
+type Component struct {
+	Name    string `json:"-" yaml:"-"`
+	Version string `json:"version" yaml:"version"`
+	Path    string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Source is the VERSION file or tag the version was read from
+	Source string `json:"source" yaml:"source"`
+}
+
+// Variables returns the template variables of the component, read as
+// {{Components.<name>.<variable>}}
+func (c Component) Variables() map[string]string {
+	v := version.Parse(c.Version)
+	return map[string]string{
+		"Version":    c.Version,
+		"Major":      v.MajorString(),
+		"Minor":      v.MinorString(),
+		"Patch":      v.PatchString(),
+		"PreRelease": v.PreRelease,
+		"Metadata":   v.BuildMetadata,
+		"Path":       c.Path,
+		"Source":     c.Source,
+	}
+}
+
+// Collect reads the version of every source. Paths are relative to root;
+// tags lists the tag names of the repository for sources with a tag format.
+func Collect(sources []Source, root string, tags []string) ([]Component, error) {
+	components := make([]Component, 0, len(sources))
+	for _, s := range sources {
+		var c Component
+		var err error
+		if s.TagFormat != "" {
+			c, err = fromTags(s, tags)
+		} else {
+			c, err = fromVersionFile(s, root)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %w", s.Name, err)
+		}
+		components = append(components, c)
+	}
+	return components, nil
+}
+
+// CollectActive collects sources relative to the root of the active VCS
+// repository, or the working directory outside one
+func CollectActive(sources []Source) ([]Component, error) {
+	activeVCS := vcs.GetActiveVCS()
+	root := ""
+	if activeVCS != nil {
+		root, _ = activeVCS.GetRepositoryRoot()
+	}
+	if root == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current directory: %w", err)
+		}
+		root = wd
+	}
+
+	var tags []string
+	if slices.ContainsFunc(sources, func(s Source) bool { return s.TagFormat != "" }) {
+		var err error
+		if tags, err = tagNames(activeVCS); err != nil {
+			return nil, err
+		}
+	}
+	return Collect(sources, root, tags)
+}
+
+// tagNames lists the tag names of the repository
+func tagNames(activeVCS vcs.VersionControlSystem) ([]string, error) {
+	if lister, ok := activeVCS.(vcs.TagNameLister); ok {
+		return lister.ListTagNames()
+	}
+	lister, ok := activeVCS.(vcs.TagLister)
+	if !ok {
+		return nil, nil
+	}
+	tags, err := lister.ListTags()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+	}
+	return names, nil
+}
+
+// fromVersionFile reads the VERSION file in the source directory
+func fromVersionFile(s Source, root string) (Component, error) {
+	rel := filepath.ToSlash(filepath.Join(s.Path, versionFile))
+	data, err := os.ReadFile(filepath.Join(root, s.Path, versionFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Component{}, fmt.Errorf("%s: %s", ErrNoComponentVersion, rel)
+	}
+	if err != nil {
+		return Component{}, err
+	}
+	v, err := version.ParseContent(data, rel)
+	if err != nil {
+		return Component{}, err
+	}
+	return Component{Name: s.Name, Version: v.FullString(), Path: s.Path, Source: rel}, nil
+}
+
+// fromTags picks the highest version, by SemVer precedence, among the tags
+// following the source tag format
+func fromTags(s Source, tags []string) (Component, error) {
+	format, err := tagformat.Parse(s.TagFormat)
+	if err != nil {
+		return Component{}, err
+	}
+	var best *version.Version
+	var bestTag string
+	for _, tag := range tags {
+		name, ok := format.Version(tag)
+		if !ok {
+			continue
+		}
+		v, _, err := version.ParseInput(name)
+		if err != nil {
+			continue
+		}
+		if best == nil || v.Compare(best) > 0 {
+			best, bestTag = v, tag
+		}
+	}
+	if best == nil {
+		return Component{}, fmt.Errorf("%s: %s", ErrNoComponentTag, s.TagFormat)
+	}
+	return Component{Name: s.Name, Version: best.FullString(), Path: s.Path, Source: bestTag}, nil
+}
+
+// Values returns the template variables of components keyed by name, read
+// as {{Components.<name>.<variable>}}
+func Values(components []Component) map[string]map[string]string {
+	values := make(map[string]map[string]string, len(components))
+	for _, c := range components {
+		values[c.Name] = c.Variables()
+	}
+	return values
+}
+
+// Manifest is the combined version record of an umbrella release
+type Manifest struct {
+	// Version is the version of the umbrella repository
+	Version    string               `json:"version" yaml:"version"`
+	Components map[string]Component `json:"components" yaml:"components"`
+}
+
+// NewManifest builds the manifest of the umbrella version and its components
+func NewManifest(umbrella string, components []Component) Manifest {
+	m := Manifest{Version: umbrella, Components: make(map[string]Component, len(components))}
+	for _, c := range components {
+		m.Components[c.Name] = c
+	}
+	return m
+}
+
+// Write writes the manifest in the requested format
+func (m Manifest) Write(w io.Writer, format Format) error {
+	var err error
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(m)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err = enc.Encode(m); err == nil {
+			err = enc.Close()
+		}
+	default:
+		return fmt.Errorf("%s: %s (available: %s)", ErrUnsupportedFormat, format, strings.Join(AvailableFormats(), ", "))
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrRenderFailed, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package aggregate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+)
+
+// TestCollect_ReadsVersionFilesAndTags validates both component sources.
+//
+// Why: Submodules carry their own VERSION file, while subtrees of a monorepo
+// are released by tags of the umbrella repository.
+//
+// What: A path component reads its VERSION file; a tag format component
+// picks the highest matching tag, ignoring tags of other formats.
+func TestCollect_ReadsVersionFilesAndTags(t *testing.T) {
+	// Precondition: services/api/VERSION and web/v* tags
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "services", "api"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "services", "api", "VERSION"), []byte("1.4.2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sources := []Source{
+		{Name: "api", Path: "services/api"},
+		{Name: "web", TagFormat: "web/v{{Version}}"},
+	}
+	tags := []string{"v9.0.0", "web/v2.0.0", "web/v2.10.0", "web/v2.9.1"}
+
+	// Action
+	components, err := Collect(sources, root, tags)
+
+	// Expected
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if len(components) != 2 {
+		t.Fatalf("expected 2 components, got %d", len(components))
+	}
+	if c := components[0]; c.Version != "1.4.2" || c.Source != "services/api/VERSION" {
+		t.Errorf("api = %+v, want 1.4.2 from services/api/VERSION", c)
+	}
+	if c := components[1]; c.Version != "2.10.0" || c.Source != "web/v2.10.0" {
+		t.Errorf("web = %+v, want 2.10.0 from web/v2.10.0", c)
+	}
+}
+
+// TestCollect_MissingVersion_Fails validates that a component without a
+// version stops the aggregation.
+//
+// Why: An umbrella manifest silently missing a component would ship a
+// release nobody can reproduce.
+//
+// What: A path without a VERSION file, and a tag format no tag follows, are
+// errors naming the component.
+func TestCollect_MissingVersion_Fails(t *testing.T) {
+	root := t.TempDir()
+	cases := map[string]Source{
+		ErrNoComponentVersion: {Name: "api", Path: "services/api"},
+		ErrNoComponentTag:     {Name: "web", TagFormat: "web/v{{Version}}"},
+	}
+	for want, source := range cases {
+		// Action
+		_, err := Collect([]Source{source}, root, []string{"v1.0.0"})
+
+		// Expected
+		if err == nil || !strings.Contains(err.Error(), want) || !strings.Contains(err.Error(), source.Name) {
+			t.Errorf("Collect(%s) error = %v, want %q", source.Name, err, want)
+		}
+	}
+}
+
+// TestManifest_Write_RendersComponents validates the manifest formats.
+//
+// Why: Umbrella builds feed the manifest to other tools, as JSON or YAML.
+//
+// What: Both formats carry the umbrella version and each component keyed by
+// name; an unknown format is rejected.
+func TestManifest_Write_RendersComponents(t *testing.T) {
+	// Precondition: Components sorted from configuration
+	sources := Sources(config.AggregateConfig{Components: map[string]config.AggregateComponent{
+		"web": {TagFormat: "web/v{{Version}}"},
+		"api": {Path: "services/api"},
+	}})
+	if sources[0].Name != "api" || sources[1].Name != "web" {
+		t.Fatalf("Sources not sorted by name: %+v", sources)
+	}
+	m := NewManifest("3.0.0", []Component{
+		{Name: "api", Version: "1.4.2", Path: "services/api", Source: "services/api/VERSION"},
+	})
+
+	// Action
+	var jsonOut, yamlOut bytes.Buffer
+	jsonErr := m.Write(&jsonOut, FormatJSON)
+	yamlErr := m.Write(&yamlOut, FormatYAML)
+	badErr := m.Write(&bytes.Buffer{}, Format("toml"))
+
+	// Expected
+	if jsonErr != nil || yamlErr != nil {
+		t.Fatalf("Write: %v, %v", jsonErr, yamlErr)
+	}
+	if !strings.Contains(jsonOut.String(), `"version": "3.0.0"`) || !strings.Contains(jsonOut.String(), `"api": {`) {
+		t.Errorf("unexpected JSON manifest:\n%s", jsonOut.String())
+	}
+	if !strings.Contains(yamlOut.String(), "  api:\n    version: 1.4.2\n") {
+		t.Errorf("unexpected YAML manifest:\n%s", yamlOut.String())
+	}
+	if badErr == nil || !strings.Contains(badErr.Error(), ErrUnsupportedFormat) {
+		t.Errorf("expected %q, got %v", ErrUnsupportedFormat, badErr)
+	}
+}
@@ -0,0 +1,12 @@
+// Package aggregate messages - error message constants
+// Exported so tests can compare against them
+package aggregate
+
+// Error messages
+const (
+	ErrNoComponents       = "no components configured (set aggregate.components in .versionator.yaml)"
+	ErrNoComponentVersion = "component has no VERSION file"
+	ErrNoComponentTag     = "no tag follows the component tag format"
+	ErrUnsupportedFormat  = "unsupported manifest format"
+	ErrRenderFailed       = "failed to render manifest"
+)
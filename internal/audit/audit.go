@@ -0,0 +1,277 @@
+// Package audit checks that every place a project records its version agrees
+// with VERSION.
+//
+// Polyglot repositories often carry the version in several manifests at once
+// (package.json, Cargo.toml, pom.xml, pyproject.toml). Configured updates keep
+// them in sync when versionator changes the version, but hand edits and
+// merges still let them drift. The auditor reads each source, compares it
+// against VERSION, and can patch the ones that disagree through the update
+// subsystem.
+package audit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/history"
+	"github.com/benjaminabbitt/versionator/internal/platform"
+	"github.com/benjaminabbitt/versionator/internal/update"
+	"github.com/benjaminabbitt/versionator/internal/version"
+
+	"go.uber.org/zap"
+)
+
+// Status is the outcome of comparing one source against VERSION
+type Status string
+
+const (
+	StatusOK    Status = "ok"
+	StatusDrift Status = "drift"
+	StatusError Status = "error"
+)
+
+// formatXML marks manifests audit reads itself and does not patch; a
+// pom.xml is kept in sync through a configured update instead
+const formatXML = "xml"
+
+// Manifest describes a well-known file that records a project version
+type Manifest struct {
+	// File is the path relative to the repository root
+	File string
+	// Paths are dasel selectors tried in order; the first that resolves is used
+	Paths []string
+	// Format is the file format (json, toml, xml)
+	Format string
+}
+
+// KnownManifests are audited whenever they exist and no configured update
+// already covers them. A manifest that exists but declares no version (e.g. a
+// private package.json) is skipped.
+var KnownManifests = []Manifest{
+	{File: "package.json", Paths: []string{"version"}, Format: "json"},
+	{File: "Cargo.toml", Paths: []string{"package.version", "workspace.package.version"}, Format: "toml"},
+	{File: "pyproject.toml", Paths: []string{"project.version", "tool.poetry.version"}, Format: "toml"},
+	{File: "pom.xml", Paths: []string{"project.version"}, Format: formatXML},
+}
+
+// Finding is the result of auditing one version source
+type Finding struct {
+	// Source names where the value came from (a file, or "latest tag")
+	Source string
+	// Path is the selector within Source, empty for tags
+	Path string
+	// Value is the version recorded by the source
+	Value string
+	// Expected is the value the source should hold
+	Expected string
+	Status   Status
+	// Err is set when Status is StatusError
+	Err error
+	// Fix is the update --fix applies, nil when the source cannot be patched
+	Fix *config.UpdateConfig
+}
+
+// Auditor compares version sources against VERSION
+type Auditor struct {
+	updates []config.UpdateConfig
+	parser  *update.DaselFileParser
+	updater *update.Updater
+}
+
+// NewAuditor creates an Auditor for the given configured updates
+func NewAuditor(updates []config.UpdateConfig, parser *update.DaselFileParser, updater *update.Updater) *Auditor {
+	return &Auditor{
+		updates: updates,
+		parser:  parser,
+		updater: updater,
+	}
+}
+
+// NewAuditorDefault creates an Auditor with the default parser and updater
+func NewAuditorDefault(updates []config.UpdateConfig) *Auditor {
+	parser := update.NewDaselFileParser()
+	return NewAuditor(updates, parser, update.NewUpdater(updates, parser, zap.NewNop()))
+}
+
+// Audit checks every configured update against its rendered template, then
+// every known manifest not covered by an update against v
+func (a *Auditor) Audit(v *version.Version, data emit.TemplateData) []Finding {
+	findings := make([]Finding, 0, len(a.updates)+len(KnownManifests))
+	covered := make(map[string]bool)
+	for _, cfg := range a.updates {
+		if cfg.IsRange() {
+			findings = append(findings, a.auditRanges(cfg, data)...)
+			continue
+		}
+		covered[platform.RepoPath(cfg.File)] = true
+		findings = append(findings, a.auditUpdate(cfg, data))
+	}
+
+	for _, m := range KnownManifests {
+		if covered[platform.RepoPath(m.File)] {
+			continue
+		}
+		if _, err := os.Stat(m.File); err != nil {
+			continue
+		}
+		if f, ok := a.auditManifest(m, v); ok {
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// auditUpdate compares a configured update's current value with what its
+// template renders to
+func (a *Auditor) auditUpdate(cfg config.UpdateConfig, data emit.TemplateData) Finding {
+	f := Finding{Source: cfg.File, Path: cfg.Path}
+	expected, err := a.updater.RenderValue(cfg, data)
+	if err != nil {
+		f.Status, f.Err = StatusError, err
+		return f
+	}
+	f.Expected = expected
+
+	value, err := a.updater.CurrentValue(cfg)
+	if err != nil {
+		f.Status, f.Err = StatusError, err
+		return f
+	}
+	f.Value = value
+	f.Status = StatusOK
+	if value != expected {
+		f.Status = StatusDrift
+		f.Fix = &cfg
+	}
+	return f
+}
+
+// auditRanges checks the dependency ranges a range update covers; a range
+// that no longer admits the rendered version is drift
+func (a *Auditor) auditRanges(cfg config.UpdateConfig, data emit.TemplateData) []Finding {
+	changes, err := a.updater.RangeChanges(cfg, data)
+	if err != nil {
+		return []Finding{{Source: cfg.File, Path: cfg.Path, Status: StatusError, Err: err}}
+	}
+	findings := make([]Finding, 0, len(changes))
+	for _, c := range changes {
+		f := Finding{Source: c.Config.File, Path: c.Config.Path, Value: c.Old, Expected: c.New, Status: StatusOK}
+		if c.Old != c.New {
+			f.Status = StatusDrift
+			f.Fix = &c.Config
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// auditManifest compares a known manifest's version with v. ok is false when
+// the manifest declares no version.
+func (a *Auditor) auditManifest(m Manifest, v *version.Version) (f Finding, ok bool) {
+	f = Finding{Source: m.File, Expected: v.SemVer()}
+
+	var value string
+	var err error
+	if m.Format == formatXML {
+		f.Path = m.Paths[0]
+		value, err = readPOMVersion(m.File)
+	} else {
+		f.Path, value, err = a.selectFirst(m)
+	}
+	if err != nil {
+		f.Status, f.Err = StatusError, err
+		return f, true
+	}
+	if value == "" {
+		return f, false
+	}
+
+	f.Value = value
+	f.Status = StatusOK
+	if !matches(value, v) {
+		f.Status = StatusDrift
+		if m.Format != formatXML {
+			f.Fix = &config.UpdateConfig{File: m.File, Path: f.Path, Template: "{{MajorMinorPatch}}{{PreReleaseWithDash}}", Format: m.Format}
+		}
+	}
+	return f, true
+}
+
+// selectFirst returns the first of m.Paths that resolves to a value
+func (a *Auditor) selectFirst(m Manifest) (path, value string, err error) {
+	data, _, err := a.parser.ReadWithFormat(m.File, m.Format)
+	if err != nil {
+		return "", "", err
+	}
+	for _, p := range m.Paths {
+		if selected, err := a.parser.Select(data, p); err == nil && selected != nil {
+			return p, fmt.Sprint(selected), nil
+		}
+	}
+	return "", "", nil
+}
+
+// pomProject is the subset of a Maven POM needed to read its version
+type pomProject struct {
+	Version string `xml:"version"`
+}
+
+// readPOMVersion reads project/version from a Maven POM. A version inherited
+// from <parent> is not the project's own and is ignored.
+func readPOMVersion(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w", ErrReadManifest, path, err)
+	}
+	var project pomProject
+	if err := xml.Unmarshal(content, &project); err != nil {
+		return "", fmt.Errorf("%s %s: %w", ErrReadManifest, path, err)
+	}
+	return strings.TrimSpace(project.Version), nil
+}
+
+// matches reports whether a manifest value names v. Manifests commonly drop
+// the prefix and build metadata, so either form of the version agrees.
+func matches(value string, v *version.Version) bool {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "v"), "V")
+	return value == v.SemVer() || value == v.String()
+}
+
+// AuditTag compares the highest version tag with VERSION. Only a tag newer
+// than VERSION is drift: VERSION running ahead of the last tag is the normal
+// state between releases. Returns ok false when there are no version tags.
+func AuditTag(v *version.Version, tag *history.Entry) (f Finding, ok bool) {
+	if tag == nil {
+		return Finding{}, false
+	}
+	f = Finding{Source: "latest tag", Value: tag.Tag, Expected: v.FullString(), Status: StatusOK}
+	tagged, err := version.ParseStrict(tag.Version)
+	if err != nil {
+		f.Status, f.Err = StatusError, err
+		return f, true
+	}
+	if tagged.Compare(v) > 0 {
+		f.Status = StatusDrift
+	}
+	return f, true
+}
+
+// Fix patches every drifted finding that has a Fix through the update
+// subsystem, returning the findings it fixed
+func (a *Auditor) Fix(findings []Finding) ([]Finding, error) {
+	var fixed []Finding
+	for _, f := range findings {
+		if f.Status != StatusDrift || f.Fix == nil {
+			continue
+		}
+		if err := a.updater.SetValue(*f.Fix, f.Expected); err != nil {
+			return fixed, fmt.Errorf("%s (%s): %w", f.Source, f.Path, err)
+		}
+		fixed = append(fixed, f)
+	}
+	return fixed, nil
+}
@@ -0,0 +1,161 @@
+package audit
+
+import (
+	"os"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/benjaminabbitt/versionator/internal/history"
+	"github.com/benjaminabbitt/versionator/internal/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditVersion parses s and builds its template data
+func auditVersion(t *testing.T, s string) (*version.Version, emit.TemplateData) {
+	t.Helper()
+	v, err := version.ParseStrict(s)
+	require.NoError(t, err)
+	return v, emit.BuildCompleteTemplateData(v, "", "")
+}
+
+// findingFor returns the finding for source, failing when absent
+func findingFor(t *testing.T, findings []Finding, source string) Finding {
+	t.Helper()
+	for _, f := range findings {
+		if f.Source == source {
+			return f
+		}
+	}
+	require.Failf(t, "finding not found", "no finding for %s in %+v", source, findings)
+	return Finding{}
+}
+
+// TestAudit_KnownManifests_ReportsDrift validates detection across ecosystems.
+//
+// Why: In polyglot repos, manifests edited by hand silently fall out of step
+// with VERSION.
+//
+// What: package.json agrees (with a v prefix), Cargo.toml and pom.xml lag,
+// and pyproject.toml without a version is skipped.
+func TestAudit_KnownManifests_ReportsDrift(t *testing.T) {
+	// Precondition: Four manifests, two of them stale
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("package.json", []byte(`{"name": "app", "version": "v1.2.3"}`), 0644))
+	require.NoError(t, os.WriteFile("Cargo.toml", []byte("[package]\nname = \"app\"\nversion = \"1.2.2\"\n"), 0644))
+	require.NoError(t, os.WriteFile("pyproject.toml", []byte("[project]\nname = \"app\"\n"), 0644))
+	require.NoError(t, os.WriteFile("pom.xml", []byte("<project><parent><version>9.9.9</version></parent><version>1.0.0</version></project>"), 0644))
+	v, data := auditVersion(t, "v1.2.3")
+
+	// Action
+	findings := NewAuditorDefault(nil).Audit(v, data)
+
+	// Expected: pyproject.toml skipped; pom.xml drifts but cannot be fixed
+	require.Len(t, findings, 3)
+	assert.Equal(t, StatusOK, findingFor(t, findings, "package.json").Status)
+	cargo := findingFor(t, findings, "Cargo.toml")
+	assert.Equal(t, StatusDrift, cargo.Status)
+	assert.Equal(t, "package.version", cargo.Path)
+	assert.Equal(t, "1.2.2", cargo.Value)
+	assert.Equal(t, "1.2.3", cargo.Expected)
+	assert.NotNil(t, cargo.Fix)
+	pom := findingFor(t, findings, "pom.xml")
+	assert.Equal(t, StatusDrift, pom.Status)
+	assert.Equal(t, "1.0.0", pom.Value)
+	assert.Nil(t, pom.Fix)
+}
+
+// TestAudit_ConfiguredUpdate_UsesTemplate validates that configured updates
+// take precedence over the built-in manifest check.
+//
+// Why: A project that stamps "1.2" into package.json via an update template is
+// in sync, even though the heuristic check would call it drift.
+//
+// What: package.json covered by an update is compared against its rendered
+// template only.
+func TestAudit_ConfiguredUpdate_UsesTemplate(t *testing.T) {
+	// Precondition: package.json holds Major.Minor, as its update requires
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("package.json", []byte(`{"version": "1.2"}`), 0644))
+	updates := []config.UpdateConfig{{File: "package.json", Path: "version", Template: "{{MajorMinor}}"}}
+	v, data := auditVersion(t, "1.2.3")
+
+	// Action
+	findings := NewAuditorDefault(updates).Audit(v, data)
+
+	// Expected: One finding, in sync
+	require.Len(t, findings, 1)
+	assert.Equal(t, StatusOK, findings[0].Status)
+	assert.Equal(t, "1.2", findings[0].Expected)
+}
+
+// TestAudit_UnparseableManifest_ReportsError validates error reporting.
+func TestAudit_UnparseableManifest_ReportsError(t *testing.T) {
+	// Precondition: Malformed package.json
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("package.json", []byte(`{"version": `), 0644))
+	v, data := auditVersion(t, "1.2.3")
+
+	// Action
+	findings := NewAuditorDefault(nil).Audit(v, data)
+
+	// Expected
+	require.Len(t, findings, 1)
+	assert.Equal(t, StatusError, findings[0].Status)
+	assert.Error(t, findings[0].Err)
+}
+
+// TestFix_DriftedManifests_PatchedThroughUpdater validates --fix.
+//
+// Why: Fixing drift should reuse the update subsystem, preserving TOML
+// comments and ordering.
+//
+// What: Cargo.toml is patched; pom.xml is left alone; a re-audit is clean for
+// the patched file.
+func TestFix_DriftedManifests_PatchedThroughUpdater(t *testing.T) {
+	// Precondition: Stale Cargo.toml with a comment, stale pom.xml
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("Cargo.toml", []byte("# crate\n[package]\nversion = \"1.0.0\"\n"), 0644))
+	pom := "<project><version>1.0.0</version></project>"
+	require.NoError(t, os.WriteFile("pom.xml", []byte(pom), 0644))
+	v, data := auditVersion(t, "2.0.0-rc.1")
+	auditor := NewAuditorDefault(nil)
+
+	// Action
+	fixed, err := auditor.Fix(auditor.Audit(v, data))
+
+	// Expected
+	require.NoError(t, err)
+	require.Len(t, fixed, 1)
+	assert.Equal(t, "Cargo.toml", fixed[0].Source)
+	content, err := os.ReadFile("Cargo.toml")
+	require.NoError(t, err)
+	assert.Equal(t, "# crate\n[package]\nversion = \"2.0.0-rc.1\"\n", string(content))
+	unchanged, err := os.ReadFile("pom.xml")
+	require.NoError(t, err)
+	assert.Equal(t, pom, string(unchanged))
+	assert.Equal(t, StatusOK, findingFor(t, auditor.Audit(v, data), "Cargo.toml").Status)
+}
+
+// TestAuditTag_TagNewerThanVersion_IsDrift validates tag comparison.
+//
+// Why: VERSION ahead of the last tag is normal between releases; behind it
+// means a release was tagged without updating VERSION.
+//
+// What: Older tag is ok, newer tag is drift, no tag yields no finding.
+func TestAuditTag_TagNewerThanVersion_IsDrift(t *testing.T) {
+	v, _ := auditVersion(t, "v1.2.3")
+
+	older, ok := AuditTag(v, &history.Entry{Tag: "v1.2.2", Version: "1.2.2"})
+	require.True(t, ok)
+	assert.Equal(t, StatusOK, older.Status)
+
+	newer, ok := AuditTag(v, &history.Entry{Tag: "v1.3.0", Version: "1.3.0"})
+	require.True(t, ok)
+	assert.Equal(t, StatusDrift, newer.Status)
+	assert.Equal(t, "v1.3.0", newer.Value)
+
+	_, ok = AuditTag(v, nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,10 @@
+// Package audit messages - error message constants
+// Exported so tests can compare against them
+package audit
+
+// Error messages
+const (
+	ErrDrift        = "version drift detected"
+	ErrNotFixable   = "cannot be fixed automatically"
+	ErrReadManifest = "failed to read manifest"
+)
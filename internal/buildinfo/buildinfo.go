@@ -1,5 +1,63 @@
 package buildinfo
 
+import (
+	"runtime"
+	"runtime/debug"
+)
+
 // Version is the versionator version, set at build time via ldflags
 // Example: go build -ldflags "-X github.com/benjaminabbitt/versionator/internal/buildinfo.Version=1.0.0"
 var Version = "dev"
+
+// Commit is the source revision, set at build time via ldflags.
+// When empty, the revision the Go toolchain stamped into the binary is used.
+var Commit = ""
+
+// BuildDate is the UTC build time (RFC 3339), set at build time via ldflags.
+// When empty, the commit time the Go toolchain stamped into the binary is used.
+var BuildDate = ""
+
+// Info describes this versionator binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"buildDate"`
+	Modified  bool   `json:"modified"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+// Get returns the build information, preferring ldflags values and falling
+// back to the VCS settings embedded by `go build`
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		applySettings(&info, bi.Settings)
+	}
+	return info
+}
+
+// applySettings fills fields not set via ldflags from build settings
+func applySettings(info *Info, settings []debug.BuildSetting) {
+	for _, s := range settings {
+		switch s.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = s.Value
+			}
+		case "vcs.time":
+			if info.BuildDate == "" {
+				info.BuildDate = s.Value
+			}
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+}
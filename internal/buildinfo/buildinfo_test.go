@@ -1,6 +1,9 @@
 package buildinfo
 
-import "testing"
+import (
+	"runtime/debug"
+	"testing"
+)
 
 func TestVersion_DefaultValue(t *testing.T) {
 	// Version should have a default value when not set via ldflags
@@ -11,3 +14,49 @@ func TestVersion_DefaultValue(t *testing.T) {
 		t.Errorf("Version default should be 'dev', got %q", Version)
 	}
 }
+
+// TestApplySettings_LdflagsUnset_UsesToolchainVCSStamp validates the fallback.
+//
+// Why: `go install` builds carry no ldflags, but bug reports still need the
+// revision the binary was built from.
+//
+// What: Empty Commit/BuildDate are taken from vcs.revision/vcs.time, and
+// vcs.modified is reported.
+func TestApplySettings_LdflagsUnset_UsesToolchainVCSStamp(t *testing.T) {
+	// Precondition: No ldflags values
+	info := Info{}
+
+	// Action: Apply toolchain settings
+	applySettings(&info, []debug.BuildSetting{
+		{Key: "vcs.revision", Value: "abc123"},
+		{Key: "vcs.time", Value: "2024-01-15T10:30:00Z"},
+		{Key: "vcs.modified", Value: "true"},
+	})
+
+	// Expected: Fields populated from settings
+	if info.Commit != "abc123" || info.BuildDate != "2024-01-15T10:30:00Z" || !info.Modified {
+		t.Errorf("unexpected info: %+v", info)
+	}
+}
+
+// TestApplySettings_LdflagsSet_KeepsLdflags validates precedence.
+//
+// Why: Release builds stamp the exact commit and build time; the toolchain
+// commit time must not replace them.
+//
+// What: Values already set are not overwritten by build settings.
+func TestApplySettings_LdflagsSet_KeepsLdflags(t *testing.T) {
+	// Precondition: ldflags values present
+	info := Info{Commit: "release", BuildDate: "2025-01-01T00:00:00Z"}
+
+	// Action: Apply toolchain settings
+	applySettings(&info, []debug.BuildSetting{
+		{Key: "vcs.revision", Value: "abc123"},
+		{Key: "vcs.time", Value: "2024-01-15T10:30:00Z"},
+	})
+
+	// Expected: ldflags values kept
+	if info.Commit != "release" || info.BuildDate != "2025-01-01T00:00:00Z" {
+		t.Errorf("ldflags values overwritten: %+v", info)
+	}
+}
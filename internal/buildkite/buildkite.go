@@ -0,0 +1,68 @@
+// Package buildkite provides a metadata provider that reads Buildkite build
+// meta-data ({{Meta.buildkite.<key>}}) through the buildkite-agent CLI, so
+// values set by earlier pipeline steps can be stamped into versions.
+package buildkite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+)
+
+// Runner runs buildkite-agent with args and returns its standard output
+type Runner func(ctx context.Context, args ...string) (string, error)
+
+// Provider fetches build meta-data from the Buildkite agent
+type Provider struct {
+	run Runner
+}
+
+// NewProvider creates a Provider with an injected command runner
+func NewProvider(run Runner) *Provider {
+	return &Provider{run: run}
+}
+
+// NewProviderDefault creates a Provider that runs buildkite-agent from PATH
+func NewProviderDefault() *Provider {
+	return NewProvider(func(ctx context.Context, args ...string) (string, error) {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, "buildkite-agent", args...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			return "", fmt.Errorf("buildkite-agent %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+		}
+		return stdout.String(), nil
+	})
+}
+
+// Name returns "buildkite"
+func (p *Provider) Name() string {
+	return "buildkite"
+}
+
+// Types returns the set of plugin types this provider implements
+func (p *Provider) Types() plugin.PluginTypeSet {
+	return plugin.NewPluginTypeSet(plugin.TypeMetadataProvider)
+}
+
+// Fetch returns the build meta-data value for key, without the trailing
+// newline the agent prints
+func (p *Provider) Fetch(ctx context.Context, key string) (string, error) {
+	out, err := p.run(ctx, "meta-data", "get", key)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(out, "\r\n"), nil
+}
+
+func init() {
+	plugin.Register(NewProviderDefault())
+}
@@ -0,0 +1,50 @@
+package buildkite
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+)
+
+// TestFetch_MetaDataKey_RunsAgentAndTrimsNewline validates the Buildkite
+// provider.
+//
+// Why: Pipelines record values such as release names with
+// `buildkite-agent meta-data set`; templates must read them back exactly.
+//
+// What: Fetch runs `meta-data get <key>`, strips the trailing newline, and
+// passes agent failures through. The provider is registered as "buildkite".
+func TestFetch_MetaDataKey_RunsAgentAndTrimsNewline(t *testing.T) {
+	// Precondition: A fake agent that knows one key
+	var calls [][]string
+	p := NewProvider(func(ctx context.Context, args ...string) (string, error) {
+		calls = append(calls, args)
+		if args[2] == "release-name" {
+			return "aurora\n", nil
+		}
+		return "", errors.New("key not found")
+	})
+
+	// Action: Fetch a known and an unknown key
+	value, err := p.Fetch(context.Background(), "release-name")
+	_, missErr := p.Fetch(context.Background(), "missing")
+
+	// Expected: Trimmed value, agent invoked with meta-data get, error kept
+	if err != nil || value != "aurora" {
+		t.Errorf("got %q, %v", value, err)
+	}
+	if !slices.Equal(calls[0], []string{"meta-data", "get", "release-name"}) {
+		t.Errorf("unexpected agent args %v", calls[0])
+	}
+	if missErr == nil {
+		t.Error("expected error for missing key")
+	}
+
+	// Expected: Registered under its name
+	if mp := plugin.GetMetadataProvider("buildkite"); mp == nil || !mp.Types().Contains(plugin.TypeMetadataProvider) {
+		t.Errorf("buildkite provider not registered: %v", mp)
+	}
+}
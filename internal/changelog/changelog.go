@@ -0,0 +1,135 @@
+// Package changelog embeds the released version's changelog section in the
+// release tag annotation, so `git show v1.2.3` says what changed.
+//
+// Versionator does not write changelogs; the section is read from a
+// Markdown file kept by hand (Keep a Changelog) or generated by another tool
+// (git-chglog, ...). It is found by its heading naming the version:
+//
+//	## [1.2.3] - 2024-12-11
+//	## v1.2.3 (2024-12-11)
+//
+// Long sections are truncated at a line boundary, and a footer links to the
+// full file.
+package changelog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Defaults for unset configuration
+const (
+	DefaultFile     = "CHANGELOG.md"
+	DefaultMaxLines = 50
+	DefaultMaxBytes = 4096
+)
+
+// FooterPrefix introduces the link to the full changelog in a tag annotation
+const FooterPrefix = "Full changelog: "
+
+// Section returns the body of the section of content whose heading names
+// version (with or without a v prefix), without the heading and surrounding
+// blank lines, or false when no heading names it. The section ends at the
+// next heading of the same or a higher level.
+func Section(content, version string) (string, bool) {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	start, level := -1, 0
+	end := len(lines)
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		l, text := heading(line)
+		if l == 0 {
+			continue
+		}
+		if start < 0 {
+			if namesVersion(text, version) {
+				start, level = i+1, l
+			}
+			continue
+		}
+		if l <= level {
+			end = i
+			break
+		}
+	}
+	if start < 0 {
+		return "", false
+	}
+	return strings.Trim(strings.Join(lines[start:end], "\n"), "\n"), true
+}
+
+// heading returns the level and text of an ATX Markdown heading, or 0 when
+// line is not one
+func heading(line string) (int, string) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ' && line[level] != '\t') {
+		return 0, ""
+	}
+	return level, line[level:]
+}
+
+// namesVersion reports whether heading text contains version as a word,
+// such as "[1.2.3]" or "v1.2.3 (2024-12-11)"
+func namesVersion(text, version string) bool {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '.' || r == '-' || r == '+')
+	})
+	for _, w := range words {
+		if w == version || w == "v"+version || w == "V"+version {
+			return true
+		}
+	}
+	return false
+}
+
+// Truncate shortens section to at most maxLines lines and maxBytes bytes,
+// dropping whole lines from the end, and returns the number of lines
+// dropped. A limit of zero or less is not applied.
+func Truncate(section string, maxLines, maxBytes int) (string, int) {
+	if section == "" {
+		return "", 0
+	}
+	lines := strings.Split(section, "\n")
+	keep, size := 0, 0
+	for keep < len(lines) {
+		next := size + len(lines[keep])
+		if keep > 0 {
+			next++ // the newline before the line
+		}
+		if (maxLines > 0 && keep == maxLines) || (maxBytes > 0 && next > maxBytes) {
+			break
+		}
+		keep, size = keep+1, next
+	}
+	for keep > 0 && strings.TrimSpace(lines[keep-1]) == "" {
+		keep--
+	}
+	return strings.Join(lines[:keep], "\n"), len(lines) - keep
+}
+
+// Append adds section to message, followed by a note of the lines dropped
+// by Truncate, if any, and a footer linking to the full changelog
+func Append(message, section string, dropped int, link string) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(message, "\n"))
+	if section != "" {
+		b.WriteString("\n\n")
+		b.WriteString(section)
+	}
+	if dropped > 0 {
+		fmt.Fprintf(&b, "\n\n[%d more line(s) not shown]", dropped)
+	}
+	b.WriteString("\n\n")
+	b.WriteString(FooterPrefix + link)
+	return b.String()
+}
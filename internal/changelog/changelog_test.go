@@ -0,0 +1,100 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+)
+
+const sample = "# Changelog\n\n## [Unreleased]\n\n- pending\n\n## [1.2.3] - 2024-12-11\n\n### Added\n\n- feature\n\n```sh\n# not a heading\n```\n\n## [1.2.2] - 2024-11-01\n\n- fix\n"
+
+// TestSection_FindsVersionHeading validates section extraction.
+//
+// Why: The tag must carry the released version's notes, not the
+// unreleased ones or a neighbouring release's.
+//
+// What: Keep a Changelog and git-chglog headings are found with or without
+// the v prefix; the section keeps its subheadings and fenced code and ends
+// at the next heading of its level; a prefix of another version and an
+// absent version are not found.
+func TestSection_FindsVersionHeading(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		version string
+		want    string
+		found   bool
+	}{
+		{name: "keep a changelog", content: sample, version: "1.2.3", want: "### Added\n\n- feature\n\n```sh\n# not a heading\n```", found: true},
+		{name: "last section", content: sample, version: "1.2.2", want: "- fix", found: true},
+		{name: "v prefixed heading", content: "## [v2.0.0](https://example.com/compare) - 2025-01-01\r\n\r\n- break\r\n", version: "2.0.0", want: "- break", found: true},
+		{name: "pre-release", content: "## 2.0.0-rc.1\n- rc\n## 2.0.0\n- final\n", version: "2.0.0-rc.1", want: "- rc", found: true},
+		{name: "other version only", content: "## 1.2.30\n- no\n", version: "1.2.3"},
+		{name: "absent", content: sample, version: "9.9.9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action
+			got, found := Section(tt.content, tt.version)
+
+			// Expected
+			if got != tt.want || found != tt.found {
+				t.Errorf("Section = %q, %v; want %q, %v", got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+// TestTruncate_LimitsLinesAndBytes validates the size limits.
+//
+// Why: Hosting UIs and `git tag -n` show annotations whole; a changelog of
+// a large release would bury the tag.
+//
+// What: Whole lines are kept up to either limit and the rest counted;
+// limits of zero are not applied; trailing blank lines are not kept.
+func TestTruncate_LimitsLinesAndBytes(t *testing.T) {
+	section := "- one\n- two\n\n- three"
+	tests := []struct {
+		name     string
+		maxLines int
+		maxBytes int
+		want     string
+		dropped  int
+	}{
+		{name: "within limits", maxLines: 10, maxBytes: 100, want: section},
+		{name: "line limit", maxLines: 2, want: "- one\n- two", dropped: 2},
+		{name: "byte limit at a line boundary", maxBytes: 14, want: "- one\n- two", dropped: 2},
+		{name: "first line too long", maxBytes: 3, want: "", dropped: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action
+			got, dropped := Truncate(section, tt.maxLines, tt.maxBytes)
+
+			// Expected
+			if got != tt.want || dropped != tt.dropped {
+				t.Errorf("Truncate = %q, %d; want %q, %d", got, dropped, tt.want, tt.dropped)
+			}
+		})
+	}
+}
+
+// TestAppend_AddsSectionAndFooter validates the annotation layout.
+//
+// Why: The subject stays the first line, and readers of a truncated
+// section need to know where the rest is.
+//
+// What: The section follows the message after a blank line, then the
+// dropped line count when truncated, then the footer link.
+func TestAppend_AddsSectionAndFooter(t *testing.T) {
+	// Action
+	got := Append("Release 1.2.3\n", "- feature", 3, "CHANGELOG.md")
+
+	// Expected
+	want := "Release 1.2.3\n\n- feature\n\n[3 more line(s) not shown]\n\n" + FooterPrefix + "CHANGELOG.md"
+	if got != want {
+		t.Errorf("Append = %q, want %q", got, want)
+	}
+	if full := Append("Release 1.2.3", "- feature", 0, "CHANGELOG.md"); strings.Contains(full, "not shown") {
+		t.Errorf("untruncated section has a note: %q", full)
+	}
+}
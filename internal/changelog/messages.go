@@ -0,0 +1,8 @@
+// Package changelog messages - error message constants
+// Exported so tests can compare against them
+package changelog
+
+// Error messages
+const (
+	ErrNoSection = "no changelog section for version"
+)
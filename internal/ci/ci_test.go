@@ -1020,3 +1020,69 @@ func TestAvailableFormatters_ContainsAllExpectedFormatters(t *testing.T) {
 		}
 	}
 }
+
+// TestDockerArgs_SelectedVarsAndLabels_FormatsBuildFlags validates the
+// docker-args output.
+//
+// Why: The flags are spliced into `docker build $(versionator docker-args)`,
+// so order must follow the selection and values must survive the shell.
+//
+// What: Selected variables become --build-arg flags in selection order,
+// labels follow sorted by key, and values with spaces are single-quoted.
+func TestDockerArgs_SelectedVarsAndLabels_FormatsBuildFlags(t *testing.T) {
+	// Precondition: Variables including one with a space
+	vars := map[string]string{"VERSION": "v1.2.3", "GIT_SHA": "abc123", "GIT_BRANCH": "it's main"}
+
+	// Action: Select and format
+	args, err := SelectVariables(vars, []string{"VERSION", "GIT_BRANCH"})
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	out := DockerArgs(args, map[string]string{LabelVersion: "1.2.3", LabelCreated: "2024-01-15T10:30:00Z"})
+
+	// Expected: Build args in order, sorted labels, quoted value
+	want := `--build-arg VERSION=v1.2.3 --build-arg 'GIT_BRANCH=it'\''s main' ` +
+		`--label org.opencontainers.image.created=2024-01-15T10:30:00Z --label org.opencontainers.image.version=1.2.3`
+	if out != want {
+		t.Errorf("DockerArgs =\n%s\nwant\n%s", out, want)
+	}
+}
+
+// TestSelectVariables_UnknownName_ListsAvailable validates selection errors.
+//
+// Why: A typo in --vars should say which names exist rather than silently
+// passing an empty build arg.
+//
+// What: An unknown name fails with the sorted list of variables.
+func TestSelectVariables_UnknownName_ListsAvailable(t *testing.T) {
+	// Precondition: Two variables
+	vars := map[string]string{"VERSION": "1.0.0", "GIT_SHA": "abc"}
+
+	// Action: Select an unknown one
+	_, err := SelectVariables(vars, []string{"SHA"})
+
+	// Expected: Error listing GIT_SHA, VERSION
+	if err == nil || !strings.Contains(err.Error(), "available: GIT_SHA, VERSION") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestWriteEnvFile_BuildArgs_WritesNameValueLines validates the env-file form.
+//
+// Why: podman --build-arg-file and docker --env-file read unquoted NAME=value
+// lines.
+//
+// What: Each build arg is written on its own line in order.
+func TestWriteEnvFile_BuildArgs_WritesNameValueLines(t *testing.T) {
+	// Precondition: Two build args
+	args := []BuildArg{{Name: "VERSION", Value: "1.2.3"}, {Name: "GIT_SHA", Value: "abc"}}
+
+	// Action: Write the env file
+	var buf bytes.Buffer
+	err := WriteEnvFile(&buf, args)
+
+	// Expected: NAME=value lines
+	if err != nil || buf.String() != "VERSION=1.2.3\nGIT_SHA=abc\n" {
+		t.Errorf("WriteEnvFile = %q, %v", buf.String(), err)
+	}
+}
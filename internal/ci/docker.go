@@ -0,0 +1,83 @@
+package ci
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DefaultDockerVars are the build args emitted by `docker-args` by default
+var DefaultDockerVars = []string{"VERSION", "VERSION_SEMVER", "GIT_SHA"}
+
+// OCI image annotation keys set as labels by `docker-args`
+const (
+	LabelVersion  = "org.opencontainers.image.version"
+	LabelRevision = "org.opencontainers.image.revision"
+	LabelCreated  = "org.opencontainers.image.created"
+)
+
+// BuildArg is a single docker/podman build argument
+type BuildArg struct {
+	Name  string
+	Value string
+}
+
+// SelectVariables returns the named variables from vars, in the given order.
+// Unknown names are an error listing the available ones.
+func SelectVariables(vars map[string]string, names []string) ([]BuildArg, error) {
+	selected := make([]BuildArg, 0, len(names))
+	for _, name := range names {
+		value, ok := vars[name]
+		if !ok {
+			available := make([]string, 0, len(vars))
+			for k := range vars {
+				available = append(available, k)
+			}
+			sort.Strings(available)
+			return nil, fmt.Errorf("unknown variable %q (available: %s)", name, strings.Join(available, ", "))
+		}
+		selected = append(selected, BuildArg{Name: name, Value: value})
+	}
+	return selected, nil
+}
+
+// DockerArgs formats build args and labels as docker/podman build flags
+// (--build-arg NAME=value ... --label key=value ...). Labels are sorted by
+// key; values are single-quoted only when the shell would split them.
+func DockerArgs(buildArgs []BuildArg, labels map[string]string) string {
+	parts := make([]string, 0, len(buildArgs)+len(labels))
+	for _, arg := range buildArgs {
+		parts = append(parts, "--build-arg "+shellWord(arg.Name+"="+arg.Value))
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, "--label "+shellWord(k+"="+labels[k]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// WriteEnvFile writes build args as NAME=value lines, the format read by
+// `podman build --build-arg-file` and `docker run --env-file`
+func WriteEnvFile(w io.Writer, buildArgs []BuildArg) error {
+	for _, arg := range buildArgs {
+		if _, err := fmt.Fprintf(w, "%s=%s\n", arg.Name, arg.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shellWord quotes s for POSIX shells when it contains characters that
+// would split or expand it
+func shellWord(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n'\"\\$`!*?[]{}()<>|&;#~") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,112 @@
+// Package clierr builds command errors that tell the user what to do next:
+// the valid input closest to a mistyped one ("did you mean"), the accepted
+// values, or the flag that works around the failure.
+//
+// The suggestions are part of the error text, so they reach the user
+// wherever the error is printed, and errors.Is/As still see the cause.
+package clierr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error is a command error with suggestions
+type Error struct {
+	// Message describes the failure
+	Message string
+	// Suggestion is the valid input the user likely meant, if any
+	Suggestion string
+	// Hints are next steps, one per line after the message
+	Hints []string
+	// Err is the underlying cause, if any
+	Err error
+}
+
+// New returns an error for message with next-step hints
+func New(message string, hints ...string) *Error {
+	return &Error{Message: message, Hints: hints}
+}
+
+// Wrap returns err with next-step hints, keeping err as the cause
+func Wrap(err error, hints ...string) *Error {
+	return &Error{Err: err, Hints: hints}
+}
+
+// UnknownChoice reports input that is none of choices, e.g.
+//
+//	unsupported format "jsn" (did you mean "json"?)
+//	available: go, json, yaml
+func UnknownChoice(message, input string, choices []string) *Error {
+	return &Error{
+		Message:    fmt.Sprintf("%s %q", message, input),
+		Suggestion: Closest(input, choices),
+		Hints:      []string{"available: " + strings.Join(choices, ", ")},
+	}
+}
+
+// NoRepository reports a command that needs a repository run outside one
+func NoRepository(message string) *Error {
+	return New(message, HintNoRepository, HintFromSnapshot)
+}
+
+// Error renders the message, the suggestion and the hints
+func (e *Error) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Message)
+	if e.Err != nil {
+		if e.Message != "" {
+			sb.WriteString(": ")
+		}
+		sb.WriteString(e.Err.Error())
+	}
+	if e.Suggestion != "" {
+		fmt.Fprintf(&sb, " (did you mean %q?)", e.Suggestion)
+	}
+	for _, hint := range e.Hints {
+		sb.WriteString("\n")
+		sb.WriteString(hint)
+	}
+	return sb.String()
+}
+
+// Unwrap returns the underlying cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Closest returns the candidate nearest to input, or "" when none is close
+// enough to be a likely typo
+func Closest(input string, candidates []string) string {
+	best, bestDistance := "", len(input)/3+2
+	for _, c := range candidates {
+		if strings.EqualFold(c, input) {
+			return c
+		}
+		if d := editDistance(strings.ToLower(input), strings.ToLower(c)); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,70 @@
+package clierr
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+)
+
+// TestUnknownChoice_SuggestsClosest validates the "did you mean" suggestion.
+//
+// Why: Mistyped formats and names are the most common CLI mistake; naming
+// the intended value saves a trip to the docs.
+//
+// What: A near miss names the closest choice, a case mismatch names the
+// exact choice, and unrelated input only lists the choices.
+func TestUnknownChoice_SuggestsClosest(t *testing.T) {
+	choices := []string{"go", "json", "yaml"}
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"jsn", "unsupported format \"jsn\" (did you mean \"json\"?)\navailable: go, json, yaml"},
+		{"YAML", "unsupported format \"YAML\" (did you mean \"yaml\"?)\navailable: go, json, yaml"},
+		{"fortran", "unsupported format \"fortran\"\navailable: go, json, yaml"},
+	}
+	for _, tt := range tests {
+		// Action
+		err := UnknownChoice("unsupported format", tt.input, choices)
+
+		// Expected
+		if got := err.Error(); got != tt.want {
+			t.Errorf("UnknownChoice(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestWrap_KeepsCause validates hints on a wrapped error.
+//
+// Why: Callers still match the cause with errors.Is after hints are added.
+//
+// What: The cause is reachable and its text precedes the hints.
+func TestWrap_KeepsCause(t *testing.T) {
+	// Action
+	err := Wrap(fs.ErrNotExist, "run 'versionator init' first")
+
+	// Expected
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Error("errors.Is lost the cause")
+	}
+	if want := "file does not exist\nrun 'versionator init' first"; err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+}
+
+// TestNoRepository_SuggestsNextSteps validates the not-a-repository error.
+//
+// Why: Container builds often run without the .git directory and need to
+// know how to proceed.
+//
+// What: The message is followed by the repository and snapshot hints.
+func TestNoRepository_SuggestsNextSteps(t *testing.T) {
+	// Action
+	err := NoRepository("not in a version control repository")
+
+	// Expected
+	want := "not in a version control repository\n" + HintNoRepository + "\n" + HintFromSnapshot
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+}
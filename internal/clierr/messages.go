@@ -0,0 +1,9 @@
+// Package clierr messages - hint constants
+// Exported so tests can compare against them
+package clierr
+
+// Hints
+const (
+	HintNoRepository = "run it inside a repository, or point --git-dir (or --vcs) at one"
+	HintFromSnapshot = "to render without a repository, capture template data with 'versionator snapshot' and pass --from-snapshot"
+)
@@ -5,6 +5,7 @@ const (
 	ErrNoCommitsSinceTag = "no commits since last tag"
 	ErrNoVCSDetected     = "not in a version control repository"
 	ErrNoBumpDetected    = "no version bump detected in commits"
+	ErrInvalidBumpLevel  = "invalid bump level (must be major, minor, patch, or none)"
 )
 
 // Log messages
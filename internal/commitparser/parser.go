@@ -1,18 +1,56 @@
 package commitparser
 
 import (
+	"fmt"
+	"maps"
 	"regexp"
 	"strings"
 )
 
+// DefaultTypes maps Conventional Commit types to bump levels. Other types
+// (chore, docs, style, refactor, test, ci, perf, build) don't bump.
+var DefaultTypes = map[string]BumpLevel{
+	"feat": BumpMinor,
+	"fix":  BumpPatch,
+}
+
 // Parser handles commit message parsing
 type Parser struct {
-	mode ParseMode
+	mode  ParseMode
+	types map[string]BumpLevel
 }
 
 // NewParser creates a parser with the specified mode
 func NewParser(mode ParseMode) *Parser {
-	return &Parser{mode: mode}
+	return &Parser{mode: mode, types: DefaultTypes}
+}
+
+// WithTypes maps the Conventional Commit types in types (e.g. "perf") to
+// bump levels, over DefaultTypes
+func (p *Parser) WithTypes(types map[string]BumpLevel) *Parser {
+	merged := maps.Clone(DefaultTypes)
+	for commitType, level := range types {
+		merged[strings.ToLower(commitType)] = level
+	}
+	p.types = merged
+	return p
+}
+
+// ParseBumpLevel parses a configured level: "major", "minor", "patch", or
+// "none"
+func ParseBumpLevel(name string) (BumpLevel, error) {
+	switch strings.ToLower(name) {
+	case "major":
+		return BumpMajor, nil
+	case "minor":
+		return BumpMinor, nil
+	case "patch":
+		return BumpPatch, nil
+	case "none":
+		return BumpNone, nil
+	default:
+		return BumpNone, fmt.Errorf("%s: '%s'", ErrInvalidBumpLevel, name)
+	}
 }
 
 // Regular expressions for parsing
@@ -72,16 +110,9 @@ func (p *Parser) ParseCommit(message string) ParsedCommit {
 			if result.IsBreaking {
 				result.BumpLevel = BumpMajor
 			} else {
-				// Map conventional commit types to bump levels
-				switch result.Type {
-				case "feat":
-					result.BumpLevel = BumpMinor
-				case "fix":
-					result.BumpLevel = BumpPatch
-				// Other types (chore, docs, style, refactor, test, ci, perf, build) don't bump
-				default:
-					result.BumpLevel = BumpNone
-				}
+				// Map conventional commit types to bump levels; unmapped
+				// types don't bump
+				result.BumpLevel = p.types[result.Type]
 			}
 			return result
 		}
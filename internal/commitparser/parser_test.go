@@ -665,3 +665,43 @@ func TestBumpLevel_ToVersionLevel_MapsCorrectly(t *testing.T) {
 		})
 	}
 }
+
+// TestParser_WithTypes_OverridesDefaults validates configured type mappings.
+//
+// Why: Teams release performance fixes or demote fixes differently; the
+// mapping from `bump.types` must replace the defaults per type only.
+//
+// What: perf maps to patch and fix to none while feat keeps minor; a
+// breaking change still bumps major whatever its type maps to.
+func TestParser_WithTypes_OverridesDefaults(t *testing.T) {
+	// Precondition: Parser with perf: patch, fix: none
+	parser := NewParser(ModeConventionalCommits).WithTypes(map[string]BumpLevel{"Perf": BumpPatch, "fix": BumpNone})
+
+	// Action/Expected: Each type
+	for message, want := range map[string]BumpLevel{
+		"perf: faster parsing":  BumpPatch,
+		"fix: typo":             BumpNone,
+		"feat: new flag":        BumpMinor,
+		"refactor!: remove API": BumpMajor,
+	} {
+		if got := parser.ParseCommit(message).BumpLevel; got != want {
+			t.Errorf("%q: got %s, want %s", message, got, want)
+		}
+	}
+	if got := NewParser(ModeConventionalCommits).ParseCommit("fix: typo").BumpLevel; got != BumpPatch {
+		t.Errorf("defaults changed by WithTypes: fix got %s", got)
+	}
+}
+
+// TestParseBumpLevel_Names validates configured level names.
+func TestParseBumpLevel_Names(t *testing.T) {
+	// Action/Expected: Known names parse, others fail
+	for name, want := range map[string]BumpLevel{"major": BumpMajor, "Minor": BumpMinor, "patch": BumpPatch, "none": BumpNone} {
+		if got, err := ParseBumpLevel(name); err != nil || got != want {
+			t.Errorf("ParseBumpLevel(%q) = %s, %v", name, got, err)
+		}
+	}
+	if _, err := ParseBumpLevel("skip"); err == nil {
+		t.Error("expected error for skip")
+	}
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// cache holds the configuration parsed during one command invocation, so
+// the many ReadConfig calls of a command read and parse .versionator.yaml
+// once and agree on its content
+var cache struct {
+	mu      sync.Mutex
+	enabled bool
+	path    string
+	config  *Config
+}
+
+// invocation identifies the current command invocation; 0 outside one
+var invocation atomic.Uint64
+
+// invocations counts the invocations begun, so each gets a distinct ID
+var invocations atomic.Uint64
+
+// BeginInvocation starts caching ReadConfig results until EndInvocation,
+// discarding anything cached before
+func BeginInvocation() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.enabled = true
+	cache.path, cache.config = "", nil
+	invocation.Store(invocations.Add(1))
+}
+
+// EndInvocation stops caching, so later reads see the file as it is then
+func EndInvocation() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.enabled = false
+	cache.path, cache.config = "", nil
+	invocation.Store(0)
+}
+
+// Invocation returns an ID for the current command invocation, for caches
+// kept elsewhere that live as long as the configuration cache; 0 outside an
+// invocation, when nothing should be cached
+func Invocation() uint64 {
+	return invocation.Load()
+}
+
+// Invalidate discards the cached configuration; WriteConfig calls it, and
+// so must anything else that rewrites .versionator.yaml mid-invocation
+func Invalidate() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.path, cache.config = "", nil
+}
+
+// cachedConfig returns a copy of the configuration cached for path, or nil
+func cachedConfig(path string) *Config {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if !cache.enabled || cache.config == nil || cache.path != path {
+		return nil
+	}
+	return cloneConfig(cache.config)
+}
+
+// storeConfig caches a copy of cfg, read from path, when caching is enabled
+func storeConfig(path string, cfg *Config) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.enabled {
+		cache.path, cache.config = path, cloneConfig(cfg)
+	}
+}
+
+// configPath returns the absolute path ReadConfig reads, so a change of
+// working directory misses the cache
+func configPath() string {
+	if abs, err := filepath.Abs(configFile); err == nil {
+		return abs
+	}
+	return configFile
+}
+
+// cloneConfig deep-copies cfg, so callers that modify the configuration
+// they read (e.g. before WriteConfig) do not modify the cache
+func cloneConfig(cfg *Config) *Config {
+	return deepCopy(reflect.ValueOf(cfg)).Interface().(*Config)
+}
+
+// deepCopy copies v, following pointers, slices and maps
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return c
+	default:
+		return v
+	}
+}
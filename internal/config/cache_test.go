@@ -0,0 +1,68 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+// TestReadConfig_Invocation_CachesUntilWrite validates the invocation cache.
+//
+// Why: One command reads the configuration many times; parsing it once keeps
+// the reads cheap and consistent, but a command that rewrites it must see
+// its own change.
+//
+// What: Within an invocation a later edit of the file is not seen, changes
+// to a returned copy do not leak into the cache, WriteConfig invalidates it,
+// and after EndInvocation the file is read again.
+func TestReadConfig_Invocation_CachesUntilWrite(t *testing.T) {
+	// Precondition: A config file and an open invocation
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile(configFile, []byte("prefix: v\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	BeginInvocation()
+	t.Cleanup(EndInvocation)
+
+	// Action: Read, modify the copy, and edit the file behind the cache
+	first, err := ReadConfig()
+	if err != nil {
+		t.Fatalf("ReadConfig: %v", err)
+	}
+	first.Custom = map[string]string{"Leaked": "yes"}
+	if err := os.WriteFile(configFile, []byte("prefix: V\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	second, err := ReadConfig()
+
+	// Expected: The cached configuration, unmodified
+	if err != nil {
+		t.Fatalf("ReadConfig: %v", err)
+	}
+	if second.Prefix != "v" || second.Custom != nil {
+		t.Errorf("cached read = prefix %q custom %v, want prefix v without custom", second.Prefix, second.Custom)
+	}
+
+	// Action: Write through WriteConfig
+	second.Custom = map[string]string{"Team": "core"}
+	if err := WriteConfig(second); err != nil {
+		t.Fatalf("WriteConfig: %v", err)
+	}
+	third, _ := ReadConfig()
+
+	// Expected: The written configuration
+	if third.Custom["Team"] != "core" {
+		t.Errorf("read after WriteConfig missed the write: %v", third.Custom)
+	}
+
+	// Action: End the invocation and edit the file
+	EndInvocation()
+	if err := os.WriteFile(configFile, []byte("prefix: V\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fourth, _ := ReadConfig()
+
+	// Expected: The file as it is now
+	if fourth.Prefix != "V" {
+		t.Errorf("read after EndInvocation = prefix %q, want V", fourth.Prefix)
+	}
+}
@@ -2,7 +2,18 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
 
 	"github.com/cbroglie/mustache"
 	"gopkg.in/yaml.v3"
@@ -13,13 +24,66 @@ const configFile = ".versionator.yaml"
 // Config holds configuration for version metadata behavior
 type Config struct {
 	Prefix           string                 `yaml:"prefix"`
+	// AllowedPrefixes restricts the VERSION prefix to these values ("" allows
+	// no prefix); empty allows any of "", "v", "V"
+	AllowedPrefixes  []string               `yaml:"allowedPrefixes,omitempty"`
 	PreRelease       PreReleaseConfig       `yaml:"prerelease"`
 	Metadata         MetadataConfig         `yaml:"metadata"`
 	Release          ReleaseConfig          `yaml:"release"`
 	BranchVersioning BranchVersioningConfig `yaml:"branchVersioning"`
 	Logging          LoggingConfig          `yaml:"logging"`
 	Custom           map[string]string      `yaml:"custom,omitempty"`
+	// CustomFrom lists files of custom variables (vars.env, vars.yaml),
+	// read in order, later files overriding earlier ones; custom overrides
+	// them all
+	CustomFrom       []string               `yaml:"customFrom,omitempty"`
 	Updates          []UpdateConfig         `yaml:"updates,omitempty"`
+	Hooks            HooksConfig            `yaml:"hooks,omitempty"`
+	Issues           IssuesConfig           `yaml:"issues,omitempty"`
+	Java             JavaConfig             `yaml:"java,omitempty"`
+	VCS              VCSConfig              `yaml:"vcs,omitempty"`
+	Env              EnvConfig              `yaml:"env,omitempty"`
+	Emit             EmitConfig             `yaml:"emit,omitempty"`
+	Dates            DatesConfig            `yaml:"dates,omitempty"`
+	Train            TrainConfig            `yaml:"train,omitempty"`
+	// Aggregate lists the submodules or subdirectories `aggregate` combines
+	// into one manifest, also readable as {{Components.<name>.Version}}
+	Aggregate AggregateConfig `yaml:"aggregate,omitempty"`
+	// Bump configures how `bump` picks the level from commit messages
+	Bump BumpConfig `yaml:"bump,omitempty"`
+	// Serve configures the bump webhook of `serve`
+	Serve ServeConfig `yaml:"serve,omitempty"`
+	// MetadataProviders configures values fetched from external systems as
+	// {{Meta.<provider>.<key>}}
+	MetadataProviders MetadataProvidersConfig `yaml:"metadataProviders,omitempty"`
+	// FileMode is the octal mode for files versionator writes (e.g. "0600");
+	// empty uses 0644. Reduced by the umask; ignored on Windows.
+	FileMode string `yaml:"fileMode,omitempty"`
+	// StrictVersion makes an unparseable VERSION file an error instead of
+	// loading it as 0.0.0
+	StrictVersion bool `yaml:"strictVersion,omitempty"`
+	// VersionFileFormat selects the VERSION file layout: "plain" (the bare
+	// version, the default), or "yaml"/"json" holding the version plus extra
+	// fields that become custom template variables
+	VersionFileFormat string `yaml:"versionFileFormat,omitempty"`
+	// Store selects where the version is kept; empty is the VERSION file
+	Store StoreConfig `yaml:"store,omitempty"`
+	// LooseVersions controls non-SemVer input to 'set' and version tags
+	// (01.2.3, 1.2, 1.2.3.4): "normalize" rewrites it as SemVer and reports
+	// the changes, "reject" refuses it; empty accepts what the grammar does
+	LooseVersions string `yaml:"looseVersions,omitempty"`
+	// Scheme selects a versioning plugin (e.g. "four-part" for
+	// Major.Minor.Patch.Revision); empty is Major.Minor.Patch
+	Scheme string `yaml:"scheme,omitempty"`
+	// Offline guarantees no network calls; features that need the network
+	// fail instead (same as --offline)
+	Offline bool `yaml:"offline,omitempty"`
+	// Languages replaces repository language detection for emit --auto,
+	// patch --auto and init (e.g. ["go", "python"])
+	Languages []string `yaml:"languages,omitempty"`
+	// Migrations describes legacy keys translated on read; writing the
+	// config drops the legacy keys
+	Migrations []Migration `yaml:"-"`
 }
 
 // BranchVersioningConfig holds branch-aware versioning configuration
@@ -48,6 +112,143 @@ type ReleaseConfig struct {
 	// BranchPrefix is prepended to the tag name to form the branch name
 	// Default: "release/" (e.g., tag "v1.2.3" -> branch "release/v1.2.3")
 	BranchPrefix string `yaml:"branchPrefix"`
+	// Publish configures `release publish` (hosted release creation)
+	Publish PublishConfig `yaml:"publish,omitempty"`
+	// Manifest records checksums of released files in the tag annotation
+	Manifest ManifestConfig `yaml:"manifest,omitempty"`
+	// Changelog embeds the version's changelog section in the tag annotation
+	Changelog ChangelogConfig `yaml:"changelog,omitempty"`
+	// Monotonic makes bump/set refuse versions that are not greater (by
+	// SemVer precedence) than the highest version tag; --allow-downgrade
+	// overrides it. Default: false
+	Monotonic bool `yaml:"monotonic,omitempty"`
+	// OnConflict resolves a release tag that already exists on another
+	// commit, so unattended pipelines do not fail. Default: fail (or --force)
+	OnConflict TagConflictConfig `yaml:"onConflict,omitempty"`
+	// Trailer stamps release commits and tag annotations with a
+	// "Versionator-Version: <version>" trailer (also: --trailer)
+	Trailer TrailerConfig `yaml:"trailer,omitempty"`
+	// Verify lists the signers `verify-tag` trusts
+	Verify VerifyConfig `yaml:"verify,omitempty"`
+	// TagFormat names release tags, with {{Version}} standing for the
+	// version (e.g. "myapp-v{{Version}}"); tags that do not follow it are
+	// not versions. Default: the prefix followed by the version
+	TagFormat string `yaml:"tagFormat,omitempty"`
+	// TagNamespace confines versionator to the tags under
+	// refs/tags/<namespace>/ (e.g. "team-a"): only those are listed or
+	// counted from, and new tags are created there. Default: every tag
+	TagNamespace string `yaml:"tagNamespace,omitempty"`
+}
+
+// VerifyConfig lists the keys trusted to sign release tags. A tag passes
+// `verify-tag` when it carries a valid signature from one of them.
+type VerifyConfig struct {
+	// AllowedSignersFile is an SSH allowed signers file (the format of
+	// git's gpg.ssh.allowedSignersFile), relative to the repository root
+	AllowedSignersFile string `yaml:"allowedSignersFile,omitempty"`
+	// GPGKeys lists trusted GPG key fingerprints (40 hex digits) or long
+	// key IDs (16); the signing key or its primary key must match one
+	GPGKeys []string `yaml:"gpgKeys,omitempty"`
+}
+
+// gpgKey matches a full GPG fingerprint or a long key ID, normalized
+var gpgKey = regexp.MustCompile(`^(?:[0-9A-F]{16}|[0-9A-F]{40})$`)
+
+// NormalizeGPGKey returns key in upper case without spaces or a 0x prefix.
+// Only full fingerprints and long key IDs are accepted: short key IDs are
+// easily forged.
+func NormalizeGPGKey(key string) (string, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(key, " ", ""))
+	normalized = strings.TrimPrefix(normalized, "0X")
+	if !gpgKey.MatchString(normalized) {
+		return "", fmt.Errorf("%q must be a 40-digit fingerprint or a 16-digit long key ID", key)
+	}
+	return normalized, nil
+}
+
+// Validate checks every GPG key is a fingerprint or long key ID
+func (v VerifyConfig) Validate() error {
+	for i, key := range v.GPGKeys {
+		if _, err := NormalizeGPGKey(key); err != nil {
+			return fmt.Errorf("gpgKeys[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// TrailerConfig selects where `release` writes the Versionator-Version
+// trailer, so tooling can find release commits by trailer search
+type TrailerConfig struct {
+	// Commit adds the trailer to the commit release makes. Default: false
+	Commit bool `yaml:"commit,omitempty"`
+	// Tag adds the trailer to the tag annotation. Default: false
+	Tag bool `yaml:"tag,omitempty"`
+}
+
+// TagConflictConfig selects how `release` picks another tag when the
+// computed one already exists on a different commit. At most one of Bump
+// and Suffix may be set; the --bump-on-conflict and --suffix-on-conflict
+// flags override it.
+type TagConflictConfig struct {
+	// Bump increments this level ("major", "minor", or "patch") in VERSION
+	// until the tag is free
+	Bump string `yaml:"bump,omitempty"`
+	// Suffix is appended to the tag (e.g. ".1"), its trailing number
+	// incremented until the tag is free; VERSION is unchanged
+	Suffix string `yaml:"suffix,omitempty"`
+}
+
+// ManifestConfig controls the artifact manifest appended to release tag
+// annotations. The manifest lists VERSION, files patched by `updates`, the
+// outputs of `emit.targets` that exist, and any additional files with their
+// SHA-256 checksums.
+type ManifestConfig struct {
+	// Enabled appends the manifest to every release tag (also: --manifest)
+	// Default: false
+	Enabled bool `yaml:"enabled"`
+	// Files lists glob patterns of additional files to include
+	// (e.g. "openapi.yaml", or a file emitted outside emit.targets)
+	Files []string `yaml:"files,omitempty"`
+}
+
+// ChangelogConfig controls the changelog section embedded in release tag
+// annotations. The section is read from a Markdown changelog written by
+// hand or another tool, by its heading naming the version.
+type ChangelogConfig struct {
+	// Enabled embeds the section in every release tag (also:
+	// --message-from-changelog). Default: false
+	Enabled bool `yaml:"enabled"`
+	// File is the changelog. Default: "CHANGELOG.md"
+	File string `yaml:"file,omitempty"`
+	// MaxLines and MaxBytes truncate long sections at a line boundary.
+	// Default: 50 lines, 4096 bytes
+	MaxLines int `yaml:"maxLines,omitempty"`
+	MaxBytes int `yaml:"maxBytes,omitempty"`
+	// Link is a Mustache template for the footer's link to the full
+	// changelog (e.g. "https://github.com/acme/app/blob/{{Prefix}}{{MajorMinorPatch}}/CHANGELOG.md").
+	// Default: File
+	Link string `yaml:"link,omitempty"`
+}
+
+// PublishConfig holds configuration for creating releases on a hosting
+// provider (GitHub, GitLab, Gitea) after tagging
+type PublishConfig struct {
+	// Provider selects the hosting API: "github", "gitlab", or "gitea"
+	Provider string `yaml:"provider,omitempty"`
+	// Repository identifies the project ("owner/name"; GitLab accepts nested groups)
+	Repository string `yaml:"repository,omitempty"`
+	// APIURL overrides the provider API base URL (required for self-hosted instances)
+	// Defaults: https://api.github.com, https://gitlab.com/api/v4, https://gitea.com/api/v1
+	APIURL string `yaml:"apiUrl,omitempty"`
+	// TokenEnv names the environment variable holding the API token
+	// Defaults: GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN
+	TokenEnv string `yaml:"tokenEnv,omitempty"`
+	// Title is a Mustache template for the release title (default: the tag name)
+	Title string `yaml:"title,omitempty"`
+	// Notes is a Mustache template for the release description
+	Notes string `yaml:"notes,omitempty"`
+	// Assets lists glob patterns of files to upload to the release
+	Assets []string `yaml:"assets,omitempty"`
 }
 
 // PreReleaseConfig holds pre-release identifier configuration
@@ -97,22 +298,566 @@ type LoggingConfig struct {
 	Output string `yaml:"output"` // console, json, development
 }
 
+// HooksConfig holds configuration for built-in lifecycle hooks
+type HooksConfig struct {
+	// Webhooks are notified after successful bump or tag operations
+	Webhooks []WebhookConfig `yaml:"webhooks,omitempty"`
+	// Scripts are run after successful bump or tag operations, before
+	// webhooks, so their captured variables reach webhook payloads
+	Scripts []ScriptHookConfig `yaml:"scripts,omitempty"`
+}
+
+// ScriptHookConfig holds configuration for a single hook script
+type ScriptHookConfig struct {
+	// Run is a shell command. Template variables are in its environment as
+	// VERSIONATOR_<NAME> (e.g. VERSIONATOR_MAJOR_MINOR_PATCH), and
+	// NAME=value lines it prints become template variables.
+	Run string `yaml:"run"`
+	// Events selects which events run the script ("bump", "tag").
+	// Default: all events
+	Events []string `yaml:"events,omitempty"`
+	// Timeout bounds the script (e.g. "30s"); zero uses 60s
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// WebhookConfig holds configuration for a single webhook notification
+type WebhookConfig struct {
+	// URL is the endpoint to POST to (e.g., a Slack or Teams incoming webhook)
+	URL string `yaml:"url,omitempty"`
+	// URLEnv names an environment variable holding the URL, keeping secrets
+	// out of the config file. Used when URL is empty.
+	URLEnv string `yaml:"urlEnv,omitempty"`
+	// Events selects which events trigger the webhook ("bump", "tag").
+	// Default: all events
+	Events []string `yaml:"events,omitempty"`
+	// Payload is a Mustache template that must render to JSON. Variable values
+	// are JSON-escaped; {{Event}} holds the triggering event name.
+	// Default: {"text": "{{Event}}: {{Prefix}}{{MajorMinorPatch}}{{PreReleaseWithDash}}"}
+	Payload string `yaml:"payload,omitempty"`
+	// Headers are added to the request (e.g., Authorization for generic endpoints).
+	// $VAR references in values are expanded from the environment.
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+// IssuesConfig holds issue-tracker key extraction configuration
+// Keys are collected from commit messages since the last tag and exposed as
+// {{IssueKeys}}, {{IssueCount}}, and the {{#Issues}}...{{/Issues}} section.
+type IssuesConfig struct {
+	// Pattern is a regular expression matching issue keys (e.g., "JIRA-\d+").
+	// Extraction is disabled when empty.
+	Pattern string `yaml:"pattern,omitempty"`
+	// URL is a Mustache template for each issue link; {{Key}} holds the issue key
+	// Example: "https://example.atlassian.net/browse/{{Key}}"
+	URL string `yaml:"url,omitempty"`
+}
+
+// Maven SNAPSHOT modes for JavaConfig.Snapshot
+const (
+	SnapshotAuto   = "auto"
+	SnapshotAlways = "always"
+	SnapshotNever  = "never"
+)
+
+// JavaConfig holds settings for the Maven/Gradle outputs (java, kotlin)
+type JavaConfig struct {
+	// Snapshot appends the Maven "SNAPSHOT" qualifier to development builds:
+	// "auto" for builds that are not exactly at a release tag or have
+	// uncommitted changes, "always" for every build, "never" to disable.
+	// When enabled, `versionator release` strips SNAPSHOT from VERSION.
+	// Default: "never"
+	Snapshot string `yaml:"snapshot,omitempty"`
+}
+
+// SnapshotEnabled reports whether a SNAPSHOT mode other than "never" is set
+func (j JavaConfig) SnapshotEnabled() bool {
+	return j.Snapshot == SnapshotAuto || j.Snapshot == SnapshotAlways
+}
+
+// UseSnapshot reports whether a build gets the SNAPSHOT qualifier; tagged is
+// true for a clean build of a release tag
+func (j JavaConfig) UseSnapshot(tagged bool) bool {
+	return j.Snapshot == SnapshotAlways || j.Snapshot == SnapshotAuto && !tagged
+}
+
+// VCSConfig controls which version control system is used when more than one
+// is available (e.g. a git checkout nested in a Mercurial repository)
+type VCSConfig struct {
+	// Priority lists VCS names in detection order (e.g. [git, hg]); systems
+	// not listed are tried afterwards. The --vcs flag overrides detection.
+	Priority []string `yaml:"priority,omitempty"`
+	// DirtyCheck is "full" (default), "tracked" to ignore untracked files, or
+	// "off" to skip the check for Dirty and UncommittedChanges on very large
+	// checkouts. The --no-dirty-check flag sets "off".
+	DirtyCheck string `yaml:"dirtyCheck,omitempty"`
+	// CountIgnored also counts files matched by .gitignore as uncommitted
+	// changes (dirtyCheck "full" only)
+	CountIgnored bool `yaml:"countIgnored,omitempty"`
+	// DefaultBranch is the ref CommitsAheadOfDefault and CommitsBehindDefault
+	// are counted against (e.g. "upstream/develop"); empty uses the default
+	// branch of origin
+	DefaultBranch string `yaml:"defaultBranch,omitempty"`
+	// TagDistance is "log" (default) to count from the first tagged commit in
+	// log order, or "describe" to pick the tag and count as git describe does
+	TagDistance string `yaml:"tagDistance,omitempty"`
+	// Hosted reads Hash, CommitDate, and BranchName from the hosting API
+	// when there is no repository (e.g. builds from a tarball export)
+	Hosted HostedConfig `yaml:"hosted,omitempty"`
+}
+
+// HostedConfig configures the hosting API fallback for repository
+// information. The commit, and the repository when not set here, come from
+// VERSIONATOR_COMMIT and VERSIONATOR_REPOSITORY or the CI variables.
+type HostedConfig struct {
+	// Provider selects the API: "github" or "gitlab"; empty disables the fallback
+	Provider string `yaml:"provider,omitempty"`
+	// Repository identifies the project ("owner/name"; GitLab accepts nested groups)
+	Repository string `yaml:"repository,omitempty"`
+	// APIURL overrides the provider API base URL (self-hosted instances)
+	// Defaults: GITHUB_API_URL or https://api.github.com, CI_API_V4_URL or https://gitlab.com/api/v4
+	APIURL string `yaml:"apiUrl,omitempty"`
+	// TokenEnv names the environment variable holding the API token
+	// Defaults: GITHUB_TOKEN, GITLAB_TOKEN
+	TokenEnv string `yaml:"tokenEnv,omitempty"`
+}
+
+// EnvConfig controls which environment variables templates may read as
+// {{Env.NAME}}. Nothing is readable unless allowlisted, so templates cannot
+// leak secrets from the build environment.
+type EnvConfig struct {
+	// Allow lists variable names or globs (e.g. [BUILD_ID, "CI_*"])
+	Allow []string `yaml:"allow,omitempty"`
+	// Host enables {{GoVersion}}, {{OS}}, {{Arch}}, {{Hostname}}, and
+	// {{NumCPU}}, describing the build machine; they render empty otherwise
+	Host bool `yaml:"host,omitempty"`
+}
+
+// EmitConfig lists the files `output emit --all` generates
+type EmitConfig struct {
+	Targets []EmitTarget `yaml:"targets,omitempty"`
+	// Default names the target (by name or output) written by
+	// `versionator output version --write`; a single target is the default
+	Default string `yaml:"default,omitempty"`
+	// LineEndings of emitted files: "lf", "crlf", or "native" (crlf on
+	// Windows); empty keeps the endings the template renders
+	LineEndings string `yaml:"lineEndings,omitempty"`
+	// FinalNewline ends emitted files with exactly one line ending (true) or
+	// none (false); unset keeps what the template renders
+	FinalNewline *bool `yaml:"finalNewline,omitempty"`
+	// Gitignore keeps emitted files out of commits: "add" adds outputs that
+	// are not ignored to .gitignore, "verify" warns about them; both warn
+	// when an output is tracked. Empty checks nothing
+	Gitignore string `yaml:"gitignore,omitempty"`
+}
+
+// EmitTarget is one file generated by `output emit --all`
+type EmitTarget struct {
+	// Name identifies the target in the summary (default: the output path)
+	Name string `yaml:"name,omitempty"`
+	// Format is a built-in emit format (e.g. "go", "python")
+	Format string `yaml:"format,omitempty"`
+	// TemplateFile is a Mustache template rendered instead of a format
+	TemplateFile string `yaml:"templateFile,omitempty"`
+	// Output is the file to write
+	Output string `yaml:"output"`
+	// Prefix replaces the version prefix for this target (e.g. "" for no "v")
+	Prefix *string `yaml:"prefix,omitempty"`
+	// PreRelease replaces the prerelease template for this target; an empty
+	// template drops the pre-release
+	PreRelease *string `yaml:"prerelease,omitempty"`
+	// Metadata replaces the metadata template for this target; an empty
+	// template drops the metadata (e.g. for Docker tags)
+	Metadata *string `yaml:"metadata,omitempty"`
+	// Style renders the version as "semver" (default), "pep440", or "nuget"
+	Style string `yaml:"style,omitempty"`
+	// LineEndings replaces emit.lineEndings for this target
+	LineEndings string `yaml:"lineEndings,omitempty"`
+	// FinalNewline replaces emit.finalNewline for this target
+	FinalNewline *bool `yaml:"finalNewline,omitempty"`
+	// Escape is "none" (default) to write variable values as they are, or
+	// "html" or "xml" to escape & < > " and ' for markup outputs
+	Escape string `yaml:"escape,omitempty"`
+}
+
+// DefaultTarget returns the target `versionator output version --write`
+// writes: the one named by Default, or the only target when there is one
+func (e EmitConfig) DefaultTarget() (EmitTarget, bool) {
+	if e.Default == "" {
+		if len(e.Targets) == 1 {
+			return e.Targets[0], true
+		}
+		return EmitTarget{}, false
+	}
+	for _, target := range e.Targets {
+		if target.Name == e.Default || target.Output == e.Default {
+			return target, true
+		}
+	}
+	return EmitTarget{}, false
+}
+
+// MetadataProvidersConfig controls fetching from metadata provider plugins
+type MetadataProvidersConfig struct {
+	// Timeout bounds each fetch (e.g. "10s"); zero uses the default of 5s
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// DatesConfig defines extra date template variables, each a Go time layout
+// applied to the build or commit time (e.g. BuildDateDotted: "2006.01.02")
+type DatesConfig struct {
+	// Build maps variable names to layouts applied to the build time
+	Build map[string]string `yaml:"build,omitempty"`
+	// Commit maps variable names to layouts applied to the commit time
+	Commit map[string]string `yaml:"commit,omitempty"`
+	// Timezone is an IANA zone (e.g. "Europe/Berlin") for these variables;
+	// empty is UTC, matching the built-in date variables
+	Timezone string `yaml:"timezone,omitempty"`
+}
+
+// dateVariableName matches names usable as {{Name}} in templates
+var dateVariableName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// Location returns the time zone for the date variables
+func (d DatesConfig) Location() (*time.Location, error) {
+	if d.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(d.Timezone)
+}
+
+// Validate checks the variable names and layouts, and the time zone
+func (d DatesConfig) Validate() error {
+	if _, err := d.Location(); err != nil {
+		return fmt.Errorf("timezone: %w", err)
+	}
+	for _, source := range []string{"build", "commit"} {
+		formats := d.Build
+		if source == "commit" {
+			formats = d.Commit
+		}
+		for _, name := range slices.Sorted(maps.Keys(formats)) {
+			layout := formats[name]
+			if !dateVariableName.MatchString(name) {
+				return fmt.Errorf("%s.%s: variable names must be letters, digits, or underscores", source, name)
+			}
+			if _, dup := d.Build[name]; dup && source == "commit" {
+				return fmt.Errorf("commit.%s: already defined under build", name)
+			}
+			// A layout without reference-time elements renders as itself
+			if time.Date(1999, 12, 31, 23, 59, 58, 0, time.UTC).Format(layout) == layout {
+				return fmt.Errorf("%s.%s: layout %q has no date elements (use Go's reference time, e.g. 2006.01.02)", source, name, layout)
+			}
+		}
+	}
+	return nil
+}
+
+// TrainConfig schedules a release train: one version level cut at a fixed
+// interval, e.g. a minor release every six weeks
+type TrainConfig struct {
+	// Level is the version level each cut increments (major, minor, or patch)
+	Level string `yaml:"level"`
+	// Every is the interval between cuts in days or weeks (e.g. "14d", "6w")
+	Every string `yaml:"every"`
+	// Start is the date of the first cut (YYYY-MM-DD), in dates.timezone
+	Start string `yaml:"start"`
+	// From is the version released at the first cut
+	From string `yaml:"from"`
+}
+
+// BumpConfig configures `bump`, which picks the level to increment from the
+// commit messages since the last tag
+type BumpConfig struct {
+	// Mode selects the recognized formats: "semver" (+semver: markers),
+	// "conventional" (Conventional Commits), or "all" (default); --mode
+	// overrides it
+	Mode string `yaml:"mode,omitempty"`
+	// Types maps Conventional Commit types to the level they bump ("major",
+	// "minor", "patch", or "none"), over the defaults feat: minor and
+	// fix: patch. Breaking changes always bump major.
+	Types map[string]string `yaml:"types,omitempty"`
+}
+
+// Validate checks the mode and the type levels, ignoring case as bump does
+func (b BumpConfig) Validate() error {
+	switch strings.ToLower(b.Mode) {
+	case "", "all", "semver", "conventional":
+	default:
+		return fmt.Errorf("mode must be 'all', 'semver', or 'conventional', got '%s'", b.Mode)
+	}
+	for commitType, level := range b.Types {
+		switch strings.ToLower(level) {
+		case "major", "minor", "patch", "none":
+		default:
+			return fmt.Errorf("types.%s: must be 'major', 'minor', 'patch', or 'none', got '%s'", commitType, level)
+		}
+	}
+	return nil
+}
+
+// ServeConfig configures `serve`, a webhook endpoint that bumps and tags the
+// version on request (chat-ops release flows)
+type ServeConfig struct {
+	// Address is the listen address. Default: "127.0.0.1:8080"
+	Address string `yaml:"address,omitempty"`
+	// TokenEnv names the environment variable holding the bearer token
+	// requests must present. Default: VERSIONATOR_SERVE_TOKEN
+	TokenEnv string `yaml:"tokenEnv,omitempty"`
+	// Push pushes the tag and release branch after each bump (also: --push)
+	Push bool `yaml:"push,omitempty"`
+	// Levels a request may bump (major, minor, patch, revision).
+	// Default: major, minor, patch
+	Levels []string `yaml:"levels,omitempty"`
+	// Actors allowed to request bumps; empty allows any token holder
+	Actors []string `yaml:"actors,omitempty"`
+	// Branches (glob patterns) bumps are allowed on; empty allows any
+	Branches []string `yaml:"branches,omitempty"`
+	// RequireReason rejects requests without a reason
+	RequireReason bool `yaml:"requireReason,omitempty"`
+}
+
+// Validate checks the serve levels
+func (s ServeConfig) Validate() error {
+	for _, level := range s.Levels {
+		switch level {
+		case "major", "minor", "patch", "revision":
+		default:
+			return fmt.Errorf("levels: must be 'major', 'minor', 'patch', or 'revision', got '%s'", level)
+		}
+	}
+	return nil
+}
+
+// DefaultStoreTokenEnv holds the bearer token of the http version store
+const DefaultStoreTokenEnv = "VERSIONATOR_STORE_TOKEN"
+
+// StoreConfig selects the backend keeping the version, so an organization
+// can centralize version state instead of committing VERSION files
+type StoreConfig struct {
+	// Backend is "file" (VERSION, the default), "git" (tags only), or
+	// "http" (a key-value endpoint)
+	Backend string `yaml:"backend,omitempty"`
+	// URL of the version key for the http backend: GET reads it, PUT writes it
+	URL string `yaml:"url,omitempty"`
+	// TokenEnv names the environment variable holding the bearer token of
+	// the http backend. Default: VERSIONATOR_STORE_TOKEN
+	TokenEnv string `yaml:"tokenEnv,omitempty"`
+}
+
+// Validate checks the backend and that http has a URL
+func (s StoreConfig) Validate() error {
+	switch s.Backend {
+	case "", "file", "git":
+	case "http":
+		if s.URL == "" {
+			return fmt.Errorf("url is required for the http backend")
+		}
+	default:
+		return fmt.Errorf("backend must be 'file', 'git', or 'http', got '%s'", s.Backend)
+	}
+	return nil
+}
+
+// AggregateConfig lists the components of an umbrella release
+type AggregateConfig struct {
+	// Components maps a name (as in {{Components.<name>.Version}}) to where
+	// its version is read
+	Components map[string]AggregateComponent `yaml:"components,omitempty"`
+}
+
+// AggregateComponent locates the version of one component
+type AggregateComponent struct {
+	// Path is the component directory relative to the repository root; its
+	// VERSION file holds the version
+	Path string `yaml:"path,omitempty"`
+	// TagFormat reads the version from the highest tag of this repository
+	// following it instead (e.g. "api/v{{Version}}"), for subtrees tagged
+	// in the umbrella repository
+	TagFormat string `yaml:"tagFormat,omitempty"`
+}
+
+// componentName matches a component name usable in a template
+var componentName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Validate checks the configured components; none is valid
+func (a AggregateConfig) Validate() error {
+	for _, name := range slices.Sorted(maps.Keys(a.Components)) {
+		component := a.Components[name]
+		if !componentName.MatchString(name) {
+			return fmt.Errorf("component name must use letters, digits, '_' or '-', got '%s'", name)
+		}
+		if component.Path == "" && component.TagFormat == "" {
+			return fmt.Errorf("components.%s: path or tagFormat is required", name)
+		}
+		if component.TagFormat != "" {
+			if _, err := tagformat.Parse(component.TagFormat); err != nil {
+				return fmt.Errorf("components.%s tagFormat: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// tagNamespace matches a tag namespace usable as a ref path, such as
+// "team-a" or "org/team-a"
+var tagNamespace = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]*(/[A-Za-z0-9_-][A-Za-z0-9._-]*)*$`)
+
+// trainInterval matches a train interval such as "14d" or "6w"
+var trainInterval = regexp.MustCompile(`^([1-9][0-9]*)([dw])$`)
+
+// Enabled reports whether a train is configured
+func (t TrainConfig) Enabled() bool {
+	return t != TrainConfig{}
+}
+
+// IntervalDays returns the number of days between cuts
+func (t TrainConfig) IntervalDays() (int, error) {
+	m := trainInterval.FindStringSubmatch(t.Every)
+	if m == nil {
+		return 0, fmt.Errorf("every must be a number of days or weeks (e.g. 14d, 6w), got '%s'", t.Every)
+	}
+	n, _ := strconv.Atoi(m[1])
+	if m[2] == "w" {
+		n *= 7
+	}
+	return n, nil
+}
+
+// StartDate returns the first cut date, at midnight in loc
+func (t TrainConfig) StartDate(loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(time.DateOnly, t.Start, loc)
+}
+
+// Validate checks a configured train; an empty one is valid
+func (t TrainConfig) Validate() error {
+	if !t.Enabled() {
+		return nil
+	}
+	switch t.Level {
+	case "major", "minor", "patch":
+	default:
+		return fmt.Errorf("level must be 'major', 'minor', or 'patch', got '%s'", t.Level)
+	}
+	if _, err := t.IntervalDays(); err != nil {
+		return err
+	}
+	if _, err := t.StartDate(time.UTC); err != nil {
+		return fmt.Errorf("start must be a date (YYYY-MM-DD), got '%s'", t.Start)
+	}
+	if t.From == "" {
+		return fmt.Errorf("from is required")
+	}
+	return nil
+}
+
 // UpdateConfig holds configuration for a single structured file update
 // Updates are applied during release to keep manifest files in sync with VERSION
 type UpdateConfig struct {
 	// File is the path to the file to update (relative to repo root)
 	File string `yaml:"file"`
-	// Path is the dasel selector for the value to update (e.g., "package.version")
+	// Path is the dasel selector for the value to update (e.g., "package.version"),
+	// or for .proto files the name of a string file option (e.g., "(version)")
 	Path string `yaml:"path"`
 	// Template is a Mustache template for the new value (e.g., "{{MajorMinorPatch}}")
 	Template string `yaml:"template"`
-	// Format explicitly sets the file format (json, yaml, toml). Auto-detected from extension if empty.
+	// Format explicitly sets the file format (json, yaml, toml, proto, xml, properties). Auto-detected from extension if empty.
 	Format string `yaml:"format,omitempty"`
+	// Type is "value" (default) to write the rendered template, or "range" to
+	// rewrite a dependency range (e.g. "^1.2.0") only when it no longer admits
+	// the rendered version. A range update's File may be a glob; files
+	// without the path are skipped.
+	Type string `yaml:"type,omitempty"`
+}
+
+// Update types
+const (
+	UpdateTypeValue = "value"
+	UpdateTypeRange = "range"
+)
+
+// IsRange reports whether the update rewrites a dependency range
+func (u UpdateConfig) IsRange() bool {
+	return u.Type == UpdateTypeRange
+}
+
+// Path returns the absolute path of the .versionator.yaml ReadConfig reads
+func Path() string {
+	return configPath()
 }
 
-// ReadConfig reads the configuration from .versionator.yaml file
+// ReadConfig reads the configuration from .versionator.yaml file. Within a
+// command invocation (BeginInvocation) the file is parsed once; each call
+// returns its own copy.
 func ReadConfig() (*Config, error) {
-	config := &Config{
+	path := configPath()
+	if cfg := cachedConfig(path); cfg != nil {
+		return cfg, nil
+	}
+	cfg, err := readConfig()
+	if err != nil {
+		return nil, err
+	}
+	storeConfig(path, cfg)
+	return cfg, nil
+}
+
+// ReadConfigFile reads the configuration from the .versionator.yaml at path,
+// for callers that must not depend on the working directory; a missing file
+// reads as the defaults. Unlike ReadConfig the result is never cached.
+func ReadConfigFile(path string) (*Config, error) {
+	return readConfigFile(path)
+}
+
+// FindFile returns the nearest .versionator.yaml from dir towards stopPath
+// (the repository root; empty walks to the filesystem root), or empty when
+// there is none
+func FindFile(dir, stopPath string) string {
+	for {
+		path := filepath.Join(dir, configFile)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir || (stopPath != "" && dir == stopPath) {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// readConfig reads and parses .versionator.yaml
+func readConfig() (*Config, error) {
+	return readConfigFile(configFile)
+}
+
+// readConfigFile reads and parses the configuration file at path
+func readConfigFile(path string) (*Config, error) {
+	config := Default()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Config file doesn't exist, return default config
+			return config, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	err = decodeStrict(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	config.Migrations, err = migrateLegacy(config, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate config file: %w", err)
+	}
+
+	return config, nil
+}
+
+// Default returns the configuration used when no .versionator.yaml exists
+func Default() *Config {
+	return &Config{
 		Prefix: "v", // default prefix
 		PreRelease: PreReleaseConfig{
 			Template: "", // default empty - templates must be explicitly configured
@@ -139,22 +884,6 @@ func ReadConfig() (*Config, error) {
 			Output: "console", // default to human-readable console output
 		},
 	}
-
-	data, err := os.ReadFile(configFile)
-	if err != nil {
-		if os.IsNotExist(err) {
-			// Config file doesn't exist, return default config
-			return config, nil
-		}
-		return nil, fmt.Errorf("failed to read config file: %w", err)
-	}
-
-	err = yaml.Unmarshal(data, config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
-	}
-
-	return config, nil
 }
 
 // ValidateTemplate checks if a Mustache template is syntactically valid
@@ -169,8 +898,48 @@ func ValidateTemplate(template string) error {
 	return nil
 }
 
+// PrefixAllowed reports whether prefix is permitted by AllowedPrefixes
+func (c *Config) PrefixAllowed(prefix string) bool {
+	return len(c.AllowedPrefixes) == 0 || slices.Contains(c.AllowedPrefixes, prefix)
+}
+
+// Validate checks that at most one strategy is set and that it is usable
+func (t TagConflictConfig) Validate() error {
+	if t.Bump != "" && t.Suffix != "" {
+		return fmt.Errorf("bump and suffix are mutually exclusive")
+	}
+	switch t.Bump {
+	case "", "major", "minor", "patch":
+	default:
+		return fmt.Errorf("bump must be 'major', 'minor', or 'patch', got '%s'", t.Bump)
+	}
+	if t.Suffix != "" && !unicode.IsDigit(rune(t.Suffix[len(t.Suffix)-1])) {
+		return fmt.Errorf("suffix must end in a number (e.g. '.1'), got '%s'", t.Suffix)
+	}
+	return nil
+}
+
+// validateLineEndings checks an emit line ending style; empty is valid
+func validateLineEndings(style string) error {
+	switch style {
+	case "", "lf", "crlf", "native":
+		return nil
+	}
+	return fmt.Errorf("must be 'lf', 'crlf', or 'native', got '%s'", style)
+}
+
 // Validate checks if the config is valid, including template syntax
 func (c *Config) Validate() error {
+	for _, p := range c.AllowedPrefixes {
+		if p != "" && p != "v" && p != "V" {
+			return fmt.Errorf("allowedPrefixes: only 'v', 'V', or empty allowed per SemVer convention, got '%s'", p)
+		}
+	}
+	for i, path := range c.CustomFrom {
+		if path == "" {
+			return fmt.Errorf("customFrom[%d]: path is required", i)
+		}
+	}
 	if c.PreRelease.Template != "" {
 		if err := ValidateTemplate(c.PreRelease.Template); err != nil {
 			return fmt.Errorf("prerelease template: %w", err)
@@ -186,9 +955,163 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("branch versioning prerelease template: %w", err)
 		}
 	}
+	if p := c.Release.Publish.Provider; p != "" && p != "github" && p != "gitlab" && p != "gitea" {
+		return fmt.Errorf("release publish provider must be 'github', 'gitlab', or 'gitea', got '%s'", p)
+	}
+	if err := ValidateTemplate(c.Release.Publish.Title); err != nil {
+		return fmt.Errorf("release publish title template: %w", err)
+	}
+	if err := ValidateTemplate(c.Release.Publish.Notes); err != nil {
+		return fmt.Errorf("release publish notes template: %w", err)
+	}
+	for i, hook := range c.Hooks.Webhooks {
+		if hook.URL == "" && hook.URLEnv == "" {
+			return fmt.Errorf("hooks.webhooks[%d]: url or urlEnv is required", i)
+		}
+		for _, event := range hook.Events {
+			if event != "bump" && event != "tag" {
+				return fmt.Errorf("hooks.webhooks[%d]: event must be 'bump' or 'tag', got '%s'", i, event)
+			}
+		}
+		if err := ValidateTemplate(hook.Payload); err != nil {
+			return fmt.Errorf("hooks.webhooks[%d] payload: %w", i, err)
+		}
+	}
+	for i, script := range c.Hooks.Scripts {
+		if script.Run == "" {
+			return fmt.Errorf("hooks.scripts[%d]: run is required", i)
+		}
+		for _, event := range script.Events {
+			if event != "bump" && event != "tag" {
+				return fmt.Errorf("hooks.scripts[%d]: event must be 'bump' or 'tag', got '%s'", i, event)
+			}
+		}
+		if script.Timeout < 0 {
+			return fmt.Errorf("hooks.scripts[%d]: timeout must not be negative, got %s", i, script.Timeout)
+		}
+	}
+	if c.Issues.Pattern != "" {
+		if _, err := regexp.Compile(c.Issues.Pattern); err != nil {
+			return fmt.Errorf("issues pattern: %w", err)
+		}
+	}
+	if err := ValidateTemplate(c.Issues.URL); err != nil {
+		return fmt.Errorf("issues url template: %w", err)
+	}
+	if err := c.Release.OnConflict.Validate(); err != nil {
+		return fmt.Errorf("release onConflict: %w", err)
+	}
+	if err := c.Release.Verify.Validate(); err != nil {
+		return fmt.Errorf("release verify: %w", err)
+	}
+	if c.Release.Changelog.MaxLines < 0 || c.Release.Changelog.MaxBytes < 0 {
+		return fmt.Errorf("release changelog: maxLines and maxBytes must not be negative")
+	}
+	if err := ValidateTemplate(c.Release.Changelog.Link); err != nil {
+		return fmt.Errorf("release changelog link template: %w", err)
+	}
+	if c.Release.TagFormat != "" {
+		if _, err := tagformat.Parse(c.Release.TagFormat); err != nil {
+			return fmt.Errorf("release tagFormat: %w", err)
+		}
+	}
+	if ns := c.Release.TagNamespace; ns != "" && !tagNamespace.MatchString(ns) {
+		return fmt.Errorf("release tagNamespace must be slash-separated names of letters, digits, '.', '_' or '-', got '%s'", ns)
+	}
+	if c.LooseVersions != "" && c.LooseVersions != "normalize" && c.LooseVersions != "reject" {
+		return fmt.Errorf("looseVersions must be 'normalize' or 'reject', got '%s'", c.LooseVersions)
+	}
+	switch c.VersionFileFormat {
+	case "", "plain", "yaml", "json":
+	default:
+		return fmt.Errorf("versionFileFormat must be 'plain', 'yaml', or 'json', got '%s'", c.VersionFileFormat)
+	}
+	switch c.Java.Snapshot {
+	case "", SnapshotAuto, SnapshotAlways, SnapshotNever:
+	default:
+		return fmt.Errorf("java snapshot must be '%s', '%s', or '%s', got '%s'", SnapshotAuto, SnapshotAlways, SnapshotNever, c.Java.Snapshot)
+	}
 	if c.BranchVersioning.Mode != "" && c.BranchVersioning.Mode != "replace" && c.BranchVersioning.Mode != "append" {
 		return fmt.Errorf("branch versioning mode must be 'replace' or 'append', got '%s'", c.BranchVersioning.Mode)
 	}
+	switch c.Emit.Gitignore {
+	case "", "add", "verify":
+	default:
+		return fmt.Errorf("emit.gitignore must be 'add' or 'verify', got '%s'", c.Emit.Gitignore)
+	}
+	if err := validateLineEndings(c.Emit.LineEndings); err != nil {
+		return fmt.Errorf("emit.lineEndings %w", err)
+	}
+	for i, target := range c.Emit.Targets {
+		if err := validateLineEndings(target.LineEndings); err != nil {
+			return fmt.Errorf("emit.targets[%d]: lineEndings %w", i, err)
+		}
+		if target.Output == "" {
+			return fmt.Errorf("emit.targets[%d]: output is required", i)
+		}
+		if (target.Format == "") == (target.TemplateFile == "") {
+			return fmt.Errorf("emit.targets[%d]: exactly one of format and templateFile is required", i)
+		}
+		switch target.Style {
+		case "", "semver", "pep440", "nuget":
+		default:
+			return fmt.Errorf("emit.targets[%d]: style must be 'semver', 'pep440', or 'nuget', got '%s'", i, target.Style)
+		}
+		switch target.Escape {
+		case "", "none", "html", "xml":
+		default:
+			return fmt.Errorf("emit.targets[%d]: escape must be 'none', 'html', or 'xml', got '%s'", i, target.Escape)
+		}
+		if target.PreRelease != nil {
+			if err := ValidateTemplate(*target.PreRelease); err != nil {
+				return fmt.Errorf("emit.targets[%d]: invalid prerelease template: %w", i, err)
+			}
+		}
+		if target.Metadata != nil {
+			if err := ValidateTemplate(*target.Metadata); err != nil {
+				return fmt.Errorf("emit.targets[%d]: invalid metadata template: %w", i, err)
+			}
+		}
+	}
+	if c.Emit.Default != "" {
+		if _, ok := c.Emit.DefaultTarget(); !ok {
+			return fmt.Errorf("emit.default: no target in emit.targets is named '%s'", c.Emit.Default)
+		}
+	}
+	if c.MetadataProviders.Timeout < 0 {
+		return fmt.Errorf("metadataProviders timeout must not be negative, got %s", c.MetadataProviders.Timeout)
+	}
+	switch c.VCS.DirtyCheck {
+	case "", "full", "tracked", "off":
+	default:
+		return fmt.Errorf("vcs.dirtyCheck must be 'full', 'tracked', or 'off', got '%s'", c.VCS.DirtyCheck)
+	}
+	switch c.VCS.TagDistance {
+	case "", "log", "describe":
+	default:
+		return fmt.Errorf("vcs.tagDistance must be 'log' or 'describe', got '%s'", c.VCS.TagDistance)
+	}
+	if p := c.VCS.Hosted.Provider; p != "" && p != "github" && p != "gitlab" {
+		return fmt.Errorf("vcs.hosted.provider must be 'github' or 'gitlab', got '%s'", p)
+	}
+	if err := c.Dates.Validate(); err != nil {
+		return fmt.Errorf("dates: %w", err)
+	}
+	if err := c.Train.Validate(); err != nil {
+		return fmt.Errorf("train: %w", err)
+	}
+	if err := c.Aggregate.Validate(); err != nil {
+		return fmt.Errorf("aggregate: %w", err)
+	}
+	if err := c.Bump.Validate(); err != nil {
+		return fmt.Errorf("bump: %w", err)
+	}
+	if err := c.Serve.Validate(); err != nil {
+		return fmt.Errorf("serve: %w", err)
+	}
+	if err := c.Store.Validate(); err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
 	for i, update := range c.Updates {
 		if update.File == "" {
 			return fmt.Errorf("updates[%d]: file is required", i)
@@ -203,29 +1126,45 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("updates[%d] template: %w", i, err)
 		}
 		if update.Format != "" {
-			validFormats := map[string]bool{"json": true, "yaml": true, "toml": true}
+			validFormats := map[string]bool{"json": true, "yaml": true, "toml": true, "proto": true, "xml": true, "properties": true}
 			if !validFormats[update.Format] {
-				return fmt.Errorf("updates[%d]: format must be 'json', 'yaml', or 'toml', got '%s'", i, update.Format)
+				return fmt.Errorf("updates[%d]: format must be 'json', 'yaml', 'toml', 'proto', 'xml', or 'properties', got '%s'", i, update.Format)
 			}
 		}
+		switch update.Type {
+		case "", UpdateTypeValue, UpdateTypeRange:
+		default:
+			return fmt.Errorf("updates[%d]: type must be 'value' or 'range', got '%s'", i, update.Type)
+		}
 	}
 	return nil
 }
 
+// WriteConfig writes config to .versionator.yaml and invalidates the
+// cached configuration
 func WriteConfig(config *Config) error {
+	content, err := Encode(config)
+	if err != nil {
+		return err
+	}
+	defer Invalidate()
+	return fileperm.WriteFile(configFile, content)
+}
+
+// Encode validates config and renders it as .versionator.yaml content
+func Encode(config *Config) ([]byte, error) {
 	// Validate config before saving
 	if err := config.Validate(); err != nil {
-		return fmt.Errorf("invalid config: %w", err)
+		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
 	data, err := yaml.Marshal(config)
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
 	}
 
 	// Add a comment header
-	content := "# Versionator Configuration\n" + string(data)
-	return os.WriteFile(configFile, []byte(content), FilePermission)
+	return append([]byte("# Versionator Configuration\n"), data...), nil
 }
 
 // SetCustom sets a custom key-value pair in the config
@@ -251,31 +1190,25 @@ func SetCustom(key, value string) error {
 	return WriteConfig(cfg)
 }
 
-// GetCustom returns a custom value by key
+// GetCustom returns a custom value by key, from custom or the customFrom
+// files
 func GetCustom(key string) (string, bool, error) {
-	cfg, err := ReadConfig()
+	custom, err := GetAllCustom()
 	if err != nil {
 		return "", false, err
 	}
-
-	if cfg.Custom == nil {
-		return "", false, nil
-	}
-	value, ok := cfg.Custom[key]
+	value, ok := custom[key]
 	return value, ok, nil
 }
 
-// GetAllCustom returns all custom key-value pairs
+// GetAllCustom returns all custom key-value pairs: those of the customFrom
+// files with custom over them
 func GetAllCustom() (map[string]string, error) {
 	cfg, err := ReadConfig()
 	if err != nil {
 		return nil, err
 	}
-
-	if cfg.Custom == nil {
-		return make(map[string]string), nil
-	}
-	return cfg.Custom, nil
+	return cfg.mergedCustom()
 }
 
 // DeleteCustom removes a custom key from the config
@@ -320,6 +1253,10 @@ func DefaultConfigYAML() string {
 # Set to empty string for no prefix
 prefix: "v"
 
+# Restrict the VERSION prefix across the organization (optional)
+# 'prefix set' and 'prefix disable' reject other values unless --force
+# allowedPrefixes: ["v"]
+
 # Pre-release configuration
 # Pre-release follows SemVer 2.0.0: appended with dash (-)
 # Example output: 1.2.3-build-5
@@ -368,6 +1305,202 @@ release:
   # Tag "v1.2.3" -> Branch "release/v1.2.3"
   branchPrefix: "release/"
 
+  # Hosted release creation for 'versionator release publish' (optional)
+  # publish:
+  #   provider: github            # github, gitlab, or gitea
+  #   repository: owner/name
+  #   apiUrl: ""                  # set for self-hosted GitLab/Gitea/GHES
+  #   tokenEnv: GITHUB_TOKEN      # env var holding the API token
+  #   title: "{{Prefix}}{{MajorMinorPatch}}{{PreReleaseWithDash}}"
+  #   notes: "Release {{MajorMinorPatch}} ({{ShortHash}})"
+  #   assets:
+  #     - "dist/*.tar.gz"
+
+  # Artifact manifest in the tag annotation (optional; also: release --manifest)
+  # Lists VERSION, files patched by 'updates', the outputs of emit.targets,
+  # and the files below with their SHA-256 checksums, in sha256sum format,
+  # for later audits.
+  # manifest:
+  #   enabled: true
+  #   files:
+  #     - "openapi.yaml"
+
+  # Changelog section in the tag annotation (optional; also: release
+  # --message-from-changelog). The section whose heading names the version
+  # ("## [1.2.3] - 2024-12-11") is read from a changelog kept by hand or
+  # another tool, truncated, and followed by a link to the full file.
+  # changelog:
+  #   enabled: true
+  #   file: CHANGELOG.md
+  #   maxLines: 50
+  #   maxBytes: 4096
+  #   link: "https://github.com/acme/app/blob/{{Prefix}}{{MajorMinorPatch}}/CHANGELOG.md"
+
+  # Append "Versionator-Version: <version>" trailers so tooling can find
+  # release commits with trailer search (also: release --trailer commit,tag)
+  # trailer:
+  #   commit: true
+  #   tag: true
+
+  # Refuse bump/set to a version not greater than the highest version tag,
+  # preventing re-releases of old numbers (override: --allow-downgrade)
+  # monotonic: true
+
+  # When the release tag already exists on another commit (optional; default:
+  # fail). bump: increment a level until free; suffix: append .1, .2, ...
+  # onConflict:
+  #   bump: patch       # or: suffix: ".1"
+
+# Files generated by 'output emit --all' (optional)
+# emit:
+#   targets:
+#     - format: go
+#       output: internal/version/version.go
+#     - name: docs
+#       templateFile: docs/version.tmpl.md
+#       output: docs/version.md
+#     - name: docker-tag             # per-target overrides of the same version
+#       templateFile: docker-tag.tmpl
+#       output: .docker-tag
+#       prefix: ""                   # replaces the version prefix
+#       metadata: ""                 # prerelease/metadata templates; "" drops
+#     - format: python
+#       output: src/pkg/_version.py
+#       style: pep440                # semver (default), pep440, or nuget
+#   lineEndings: crlf                # lf, crlf, native; per target too
+#   finalNewline: true               # exactly one final line ending
+#   gitignore: add                   # add outputs to .gitignore (verify: warn)
+#   default: docs                    # target of 'output version --write'
+
+# Hook scripts and webhook notifications after bump/tag (optional)
+# Scripts see variables as $VERSIONATOR_<NAME> and may print NAME=value
+# lines, which become template variables (e.g. for webhook payloads)
+# hooks:
+#   scripts:
+#     - run: ./scripts/upload.sh    # sh -c; cmd /C on Windows
+#       events: [tag]               # bump, tag (default: both)
+#       timeout: 30s                # default 60s
+#   webhooks:
+#     - urlEnv: SLACK_WEBHOOK_URL   # or url: https://...
+#       events: [tag]               # bump, tag (default: both)
+#       payload: '{"text": "Released {{Prefix}}{{MajorMinorPatch}} ({{ShortHash}})"}'
+
+# Issue-tracker keys from commit messages since the last tag (optional)
+# issues:
+#   pattern: 'JIRA-\d+'          # regular expression for issue keys
+#   url: "https://example.atlassian.net/browse/{{Key}}"
+
+# Maven SNAPSHOT qualifier for the java and kotlin emit formats (optional)
+# auto: untagged or dirty builds emit 1.2.3-SNAPSHOT; always; never (default)
+# 'versionator release' strips SNAPSHOT from VERSION before tagging
+# java:
+#   snapshot: auto
+
+# Version control detection order when repositories of several systems nest
+# (optional; --vcs forces one for a single command)
+# vcs:
+#   priority: [git, hg]
+#   hosted:                         # no .git (tarball builds): ask the API
+#     provider: github              # commit from VERSIONATOR_COMMIT or GITHUB_SHA
+
+# Environment variables templates may read as {{Env.NAME}} (optional)
+# Names or globs; nothing is readable unless listed here
+# env:
+#   allow: [BUILD_ID, "CI_*"]
+#   host: true                      # {{GoVersion}} {{OS}} {{Arch}} {{Hostname}} {{NumCPU}}
+
+# Extra date variables as Go layouts of the build or commit time (optional)
+# dates:
+#   build:
+#     BuildDateDotted: "2006.01.02"   # {{BuildDateDotted}} -> 2024.01.15
+#   commit:
+#     CommitWeek: "Mon 02 Jan"
+#   timezone: Europe/Berlin           # default UTC
+
+# Release train: cut one level on a fixed schedule (optional)
+# Exposes {{TrainExpectedVersion}}, {{TrainNextVersion}}, {{TrainNextCutDate}}, ...
+# train:
+#   level: minor        # major, minor, or patch
+#   every: 6w           # days (14d) or weeks (6w)
+#   start: 2024-01-15   # first cut, in dates.timezone
+#   from: 1.0.0         # version released at the first cut
+
+# Components of an umbrella release combined by 'versionator aggregate' (optional)
+# Exposes {{Components.<name>.Version}}, {{Components.<name>.Major}}, ...
+# aggregate:
+#   components:
+#     api:
+#       path: services/api           # reads services/api/VERSION
+#     web:
+#       tagFormat: "web/v{{Version}}" # highest web/v* tag in this repository
+
+# Commit analysis of 'versionator bump' / 'bump --auto' (optional)
+# bump:
+#   mode: conventional           # all (default), semver, or conventional
+#   types:                       # Conventional Commit type -> level
+#     feat: minor                # default
+#     fix: patch                 # default
+#     perf: patch
+#     refactor: none
+
+# Webhook endpoint of 'versionator serve' (optional)
+# POST /bump with "Authorization: Bearer $VERSIONATOR_SERVE_TOKEN" and
+# {"level": "minor", "actor": "alice", "reason": "..."} bumps and tags
+# serve:
+#   address: 127.0.0.1:8080
+#   tokenEnv: VERSIONATOR_SERVE_TOKEN
+#   push: true                 # also push the tag and release branch
+#   levels: [minor, patch]     # default: major, minor, patch
+#   actors: [alice, bob]       # default: anyone with the token
+#   branches: [main]           # default: any branch
+#   requireReason: true
+
+# Values fetched from external systems at render time (optional)
+# Referenced as {{Meta.<provider>.<key>}}, e.g. {{Meta.buildkite.release-name}}
+# metadataProviders:
+#   timeout: 10s   # per fetch, default 5s
+
+# Guarantee no network calls for hermetic builds (optional; same as --offline)
+# Pushing, publishing, webhooks, and metadata providers then fail instead
+# offline: true
+
+# Mode for files versionator writes (optional, default "0644")
+# Use "0600" when emitted files carry sensitive data; ignored on Windows
+# fileMode: "0600"
+
+# Fail on an unparseable VERSION file instead of treating it as 0.0.0
+# strictVersion: true
+
+# VERSION file layout (optional, default plain)
+# yaml/json: the version plus extra fields (channel, build, ...) that become
+# custom template variables; plain VERSION files still load
+# versionFileFormat: yaml
+
+# Files of custom template variables kept by other tooling (optional)
+# .yaml/.yml/.json: a flat mapping; any other name: KEY=VALUE lines (.env)
+# Later files override earlier ones; custom overrides them all
+# customFrom: [vars.env, build/vars.yaml]
+
+# Where the version is kept (optional, default the VERSION file)
+# git: only in tags (the highest tag is the version; bumps tag HEAD)
+# http: GET/PUT of a shared key-value endpoint, bearer token from tokenEnv
+# store:
+#   backend: http
+#   url: https://versions.example.com/v1/keys/my-service
+#   tokenEnv: VERSIONATOR_STORE_TOKEN
+
+# Non-SemVer versions given to 'set' or found in tags (01.2.3, 1.2, 1.2.3.4)
+# normalize: rewrite as SemVer and report each change; reject: refuse them
+# looseVersions: normalize
+
+# Languages for 'output emit --auto', 'patch --auto' and 'init --config'
+# (optional; default: detected from go.mod, package.json, Cargo.toml, ...)
+# languages: [go, python]
+
+# Versioning scheme (optional; default Major.Minor.Patch)
+# four-part: every version is Major.Minor.Patch.Revision
+# scheme: four-part
+
 # Logging configuration
 logging:
   # Output format: console, json, development
@@ -394,7 +1527,7 @@ logging:
 #   {{MetadataWithPlus}}             - With plus prefix (+build.123)
 #
 # VCS/Git Information:
-#   {{Hash}}                         - Full commit hash (40 chars for git)
+#   {{Hash}}                         - Full commit hash (40 chars; 64 for SHA-256)
 #   {{ShortHash}}                    - Short hash (7 chars)
 #   {{MediumHash}}                   - Medium hash (12 chars)
 #   {{BranchName}}                   - Current branch name
@@ -402,13 +1535,21 @@ logging:
 #   {{CommitsSinceTag}}              - Commits since last tag
 #   {{BuildNumber}}                  - Alias for CommitsSinceTag
 #   {{BuildNumberPadded}}            - Padded to 4 digits (0042)
+#   {{CommitsSinceTagPadded(N)}}     - Padded to N digits (000042)
 #   {{UncommittedChanges}}           - Count of dirty files
 #   {{Dirty}}                        - "dirty" if uncommitted changes
 #   {{VersionSourceHash}}            - Hash of last tag's commit
+#   {{HashAlgorithm}}                - Hash algorithm (sha1, sha256)
+#   {{PreviousVersion}}              - Highest tagged version below this one
+#   {{PreviousTag}}                  - Tag of PreviousVersion (v1.2.2)
+#   {{CommitsAheadOfDefault}}        - Commits on HEAD not on the default branch
+#   {{CommitsBehindDefault}}         - Commits on the default branch not on HEAD
 #
 # Commit Author:
 #   {{CommitAuthor}}                 - Commit author name
 #   {{CommitAuthorEmail}}            - Commit author email
+#   {{VersionFileLastChangedBy}}     - Author of the last commit that modified VERSION
+#   {{VersionFileLastChangedDate}}   - Date of that commit (ISO 8601)
 #
 # Commit Timestamps (UTC):
 #   {{CommitDate}}                   - ISO 8601 (2024-01-15T10:30:00Z)
@@ -425,5 +1566,10 @@ logging:
 # Plugin Variables (git plugin):
 #   {{GitShortHash}}                 - Prefixed short hash (git.abc1234)
 #   {{ShaShortHash}}                 - Prefixed short hash (sha.abc1234)
+#
+# Plugin Variables (issues plugin, requires issues.pattern):
+#   {{IssueKeys}}                    - Comma-separated keys (JIRA-12, JIRA-15)
+#   {{IssueCount}}                   - Number of distinct keys
+#   {{#Issues}}{{Key}} {{URL}}{{/Issues}} - One item per key
 `
 }
@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"strings"
 	"testing"
+	"time"
 )
 
 // =============================================================================
@@ -1070,6 +1072,350 @@ func TestConfig_Validate_BranchVersioningMode(t *testing.T) {
 	}
 }
 
+// TestConfig_Validate_VersionFileFormat verifies validation of the VERSION
+// file format.
+func TestConfig_Validate_VersionFileFormat(t *testing.T) {
+	for _, format := range []string{"", "plain", "yaml", "json"} {
+		if err := (&Config{VersionFileFormat: format}).Validate(); err != nil {
+			t.Errorf("Unexpected error for format %q: %v", format, err)
+		}
+	}
+
+	err := (&Config{VersionFileFormat: "toml"}).Validate()
+	if err == nil || !contains(err.Error(), "versionFileFormat") {
+		t.Errorf("Expected error about versionFileFormat, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_Train verifies validation of the release train.
+//
+// Why: A bad interval or date would otherwise only surface as a missing
+// banner variable.
+//
+// What: No train and a complete train pass; an unknown level, a bad
+// interval, a bad date, or a missing from version fail.
+func TestConfig_Validate_Train(t *testing.T) {
+	valid := TrainConfig{Level: "minor", Every: "6w", Start: "2024-01-15", From: "1.0.0"}
+	tests := []struct {
+		name      string
+		train     func(TrainConfig) TrainConfig
+		expectErr bool
+	}{
+		{name: "no train is valid", train: func(TrainConfig) TrainConfig { return TrainConfig{} }},
+		{name: "complete train is valid", train: func(c TrainConfig) TrainConfig { return c }},
+		{name: "unknown level rejected", train: func(c TrainConfig) TrainConfig { c.Level = "build"; return c }, expectErr: true},
+		{name: "bad interval rejected", train: func(c TrainConfig) TrainConfig { c.Every = "6 weeks"; return c }, expectErr: true},
+		{name: "zero interval rejected", train: func(c TrainConfig) TrainConfig { c.Every = "0d"; return c }, expectErr: true},
+		{name: "bad start rejected", train: func(c TrainConfig) TrainConfig { c.Start = "15/01/2024"; return c }, expectErr: true},
+		{name: "missing from rejected", train: func(c TrainConfig) TrainConfig { c.From = ""; return c }, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition
+			config := &Config{Train: tt.train(valid)}
+
+			// Action
+			err := config.Validate()
+
+			// Expected
+			if tt.expectErr && (err == nil || !contains(err.Error(), "train")) {
+				t.Errorf("Expected train error, got: %v", err)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// TestConfig_Validate_ReleaseOnConflict verifies validation of the release
+// tag conflict strategy.
+//
+// Why: A misspelled level or a suffix without a number could only be
+// discovered when a nightly pipeline hits a collision.
+//
+// What: A single valid strategy passes; both at once, an unknown level, or a
+// suffix not ending in a number fail.
+func TestConfig_Validate_ReleaseOnConflict(t *testing.T) {
+	tests := []struct {
+		name      string
+		conflict  TagConflictConfig
+		expectErr bool
+	}{
+		{name: "no strategy is valid", conflict: TagConflictConfig{}},
+		{name: "bump patch is valid", conflict: TagConflictConfig{Bump: "patch"}},
+		{name: "suffix is valid", conflict: TagConflictConfig{Suffix: "-r1"}},
+		{name: "both rejected", conflict: TagConflictConfig{Bump: "minor", Suffix: ".1"}, expectErr: true},
+		{name: "unknown level rejected", conflict: TagConflictConfig{Bump: "build"}, expectErr: true},
+		{name: "suffix without number rejected", conflict: TagConflictConfig{Suffix: "-retry"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition: Config with the strategy
+			config := &Config{Release: ReleaseConfig{OnConflict: tt.conflict}}
+
+			// Action: Validate
+			err := config.Validate()
+
+			// Expected: Error only for invalid strategies
+			if tt.expectErr != (err != nil) {
+				t.Errorf("expectErr=%v, got %v", tt.expectErr, err)
+			}
+			if err != nil && !contains(err.Error(), "release onConflict") {
+				t.Errorf("Expected error about release onConflict, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestBumpConfig_Validate_IgnoresCase verifies bump settings are validated
+// as bump reads them.
+//
+// Why: bump accepts 'feat: Minor'; validation rejecting it would fail every
+// command on a config that works.
+//
+// What: Modes and levels in any case pass; unknown ones fail.
+func TestBumpConfig_Validate_IgnoresCase(t *testing.T) {
+	tests := []struct {
+		name      string
+		bump      BumpConfig
+		expectErr bool
+	}{
+		{name: "title case level is valid", bump: BumpConfig{Types: map[string]string{"feat": "Minor"}}},
+		{name: "upper case mode is valid", bump: BumpConfig{Mode: "CONVENTIONAL"}},
+		{name: "unknown level rejected", bump: BumpConfig{Types: map[string]string{"feat": "Build"}}, expectErr: true},
+		{name: "unknown mode rejected", bump: BumpConfig{Mode: "angular"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action
+			err := tt.bump.Validate()
+
+			// Expected
+			if tt.expectErr != (err != nil) {
+				t.Errorf("expectErr=%v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+// TestConfig_Validate_ReleaseVerifyKeys verifies validation of trusted GPG
+// keys.
+//
+// Why: A short key ID such as 04BB2393 is easily forged by generating a key
+// that ends the same way, so trusting one would accept an attacker's
+// signature.
+//
+// What: Full fingerprints and long key IDs (with spaces or 0x) pass; short
+// key IDs and non-hex values fail.
+func TestConfig_Validate_ReleaseVerifyKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		expectErr bool
+	}{
+		{name: "fingerprint is valid", key: "74C08BB82326AE3C2EEC642C6A32387C04BB2393"},
+		{name: "spaced lower-case fingerprint is valid", key: "74c0 8bb8 2326 ae3c 2eec 642c 6a32 387c 04bb 2393"},
+		{name: "long key ID is valid", key: "0x6A32387C04BB2393"},
+		{name: "short key ID rejected", key: "04BB2393", expectErr: true},
+		{name: "partial fingerprint rejected", key: "2326AE3C2EEC642C6A32387C04BB2393", expectErr: true},
+		{name: "non-hex rejected", key: "release@example.com", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition: Config trusting the key
+			config := &Config{Release: ReleaseConfig{Verify: VerifyConfig{GPGKeys: []string{tt.key}}}}
+
+			// Action: Validate
+			err := config.Validate()
+
+			// Expected: Error only for keys that are not fingerprints or long IDs
+			if tt.expectErr != (err != nil) {
+				t.Errorf("expectErr=%v, got %v", tt.expectErr, err)
+			}
+			if err != nil && !contains(err.Error(), "release verify") {
+				t.Errorf("Expected error about release verify, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestConfig_Validate_EmitTargets verifies validation of emit targets.
+//
+// Why: A target without an output, or with both a format and a template,
+// would fail halfway through `output emit --all`.
+//
+// What: Each target needs an output and exactly one of format and
+// templateFile. Override templates must parse and the style and line
+// endings must be known.
+func TestConfig_Validate_EmitTargets(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name      string
+		target    EmitTarget
+		expectErr bool
+	}{
+		{name: "format target is valid", target: EmitTarget{Format: "go", Output: "version.go"}},
+		{name: "template target is valid", target: EmitTarget{TemplateFile: "v.tmpl", Output: "VERSION.txt"}},
+		{name: "missing output rejected", target: EmitTarget{Format: "go"}, expectErr: true},
+		{name: "neither format nor template rejected", target: EmitTarget{Output: "out"}, expectErr: true},
+		{name: "both format and template rejected", target: EmitTarget{Format: "go", TemplateFile: "v.tmpl", Output: "out"}, expectErr: true},
+		{name: "overrides are valid", target: EmitTarget{Format: "python", Output: "_version.py", Prefix: str(""), Metadata: str(""), PreRelease: str("rc-{{CommitsSinceTag}}"), Style: "pep440"}},
+		{name: "unknown style rejected", target: EmitTarget{Format: "go", Output: "out", Style: "calver"}, expectErr: true},
+		{name: "invalid override template rejected", target: EmitTarget{Format: "go", Output: "out", Metadata: str("{{#Hash}}")}, expectErr: true},
+		{name: "line endings are valid", target: EmitTarget{Format: "go", Output: "out", LineEndings: "crlf"}},
+		{name: "unknown line endings rejected", target: EmitTarget{Format: "go", Output: "out", LineEndings: "cr"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition: Config with the target
+			config := &Config{Emit: EmitConfig{Targets: []EmitTarget{tt.target}}}
+
+			// Action: Validate
+			err := config.Validate()
+
+			// Expected: Error only for invalid targets
+			if tt.expectErr != (err != nil) {
+				t.Errorf("expectErr=%v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+// TestEmitConfig_DefaultTarget verifies which target `output version --write`
+// writes.
+//
+// Why: The common single-file case should need no extra configuration, and
+// a default naming no target would only fail when the command runs.
+//
+// What: emit.default matches a target by name or output; without it, a
+// single target is the default and several are ambiguous. An unknown
+// default fails validation.
+func TestEmitConfig_DefaultTarget(t *testing.T) {
+	goTarget := EmitTarget{Format: "go", Output: "version.go"}
+	docsTarget := EmitTarget{Name: "docs", TemplateFile: "v.tmpl", Output: "docs/version.md"}
+	tests := []struct {
+		name     string
+		emit     EmitConfig
+		expectOK bool
+		expect   EmitTarget
+	}{
+		{name: "single target", emit: EmitConfig{Targets: []EmitTarget{goTarget}}, expectOK: true, expect: goTarget},
+		{name: "several targets without default", emit: EmitConfig{Targets: []EmitTarget{goTarget, docsTarget}}},
+		{name: "default by name", emit: EmitConfig{Targets: []EmitTarget{goTarget, docsTarget}, Default: "docs"}, expectOK: true, expect: docsTarget},
+		{name: "default by output", emit: EmitConfig{Targets: []EmitTarget{goTarget, docsTarget}, Default: "version.go"}, expectOK: true, expect: goTarget},
+		{name: "unknown default", emit: EmitConfig{Targets: []EmitTarget{goTarget}, Default: "web"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action
+			target, ok := tt.emit.DefaultTarget()
+			err := (&Config{Emit: tt.emit}).Validate()
+
+			// Expected
+			if ok != tt.expectOK || target.Output != tt.expect.Output {
+				t.Errorf("DefaultTarget() = %+v, %v", target, ok)
+			}
+			if unknown := tt.emit.Default != "" && !ok; unknown != (err != nil) {
+				t.Errorf("Validate() = %v", err)
+			}
+		})
+	}
+}
+
+// TestConfig_Validate_Dates verifies validation of the date variables.
+//
+// Why: A typo in a layout silently renders the literal text, and a bad
+// name or zone would only surface when a template renders.
+//
+// What: Valid names, layouts, and zones pass; names that are not template
+// identifiers, names defined twice, layouts without date elements, and
+// unknown zones fail.
+func TestConfig_Validate_Dates(t *testing.T) {
+	tests := []struct {
+		name      string
+		dates     DatesConfig
+		expectErr bool
+	}{
+		{name: "empty is valid", dates: DatesConfig{}},
+		{name: "build and commit layouts are valid", dates: DatesConfig{
+			Build:    map[string]string{"BuildDateDotted": "2006.01.02"},
+			Commit:   map[string]string{"CommitWeekday": "Monday"},
+			Timezone: "Europe/Berlin",
+		}},
+		{name: "invalid name rejected", dates: DatesConfig{Build: map[string]string{"Build-Date": "2006"}}, expectErr: true},
+		{name: "name in both rejected", dates: DatesConfig{
+			Build:  map[string]string{"Stamp": "2006"},
+			Commit: map[string]string{"Stamp": "2006"},
+		}, expectErr: true},
+		{name: "layout without elements rejected", dates: DatesConfig{Build: map[string]string{"Stamp": "YYYY.MM.DD"}}, expectErr: true},
+		{name: "unknown timezone rejected", dates: DatesConfig{Timezone: "Mars/Olympus"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition: Config with the dates
+			config := &Config{Dates: tt.dates}
+
+			// Action: Validate
+			err := config.Validate()
+
+			// Expected: Error only for invalid dates
+			if tt.expectErr != (err != nil) {
+				t.Errorf("expectErr=%v, got %v", tt.expectErr, err)
+			}
+			if err != nil && !contains(err.Error(), "dates") {
+				t.Errorf("Expected error about dates, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestConfig_Validate_HookScripts verifies validation of hook scripts.
+//
+// Why: A script without a command or with a misspelled event would silently
+// never run.
+//
+// What: A script needs a run command, events must be bump or tag, and the
+// timeout must not be negative.
+func TestConfig_Validate_HookScripts(t *testing.T) {
+	tests := []struct {
+		name      string
+		script    ScriptHookConfig
+		expectErr bool
+	}{
+		{name: "run only is valid", script: ScriptHookConfig{Run: "./upload.sh"}},
+		{name: "events and timeout are valid", script: ScriptHookConfig{Run: "./upload.sh", Events: []string{"tag"}, Timeout: time.Minute}},
+		{name: "missing run rejected", script: ScriptHookConfig{Events: []string{"tag"}}, expectErr: true},
+		{name: "unknown event rejected", script: ScriptHookConfig{Run: "./upload.sh", Events: []string{"release"}}, expectErr: true},
+		{name: "negative timeout rejected", script: ScriptHookConfig{Run: "./upload.sh", Timeout: -time.Second}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition: Config with the script
+			config := &Config{Hooks: HooksConfig{Scripts: []ScriptHookConfig{tt.script}}}
+
+			// Action: Validate
+			err := config.Validate()
+
+			// Expected: Error only for invalid scripts
+			if tt.expectErr != (err != nil) {
+				t.Errorf("expectErr=%v, got %v", tt.expectErr, err)
+			}
+			if err != nil && !contains(err.Error(), "hooks.scripts[0]") {
+				t.Errorf("Expected error about hooks.scripts[0], got: %v", err)
+			}
+		})
+	}
+}
+
 // TestConfig_Validate_BranchVersioningTemplate verifies validation of the
 // branch versioning prerelease template.
 //
@@ -1393,7 +1739,7 @@ func TestConfig_Validate_UpdatesInvalidTemplate(t *testing.T) {
 // TestConfig_Validate_UpdatesInvalidFormat verifies that updates with
 // invalid format values are rejected.
 //
-// Why: Only json, yaml, and toml formats are supported. Invalid formats
+// Why: Only json, yaml, toml, and proto formats are supported. Invalid formats
 // would cause file parsing failures.
 //
 // What: Invalid format value should fail; valid formats should pass.
@@ -1407,7 +1753,9 @@ func TestConfig_Validate_UpdatesInvalidFormat(t *testing.T) {
 		{name: "json format is valid", format: "json", expectErr: false},
 		{name: "yaml format is valid", format: "yaml", expectErr: false},
 		{name: "toml format is valid", format: "toml", expectErr: false},
-		{name: "xml format is invalid", format: "xml", expectErr: true},
+		{name: "proto format is valid", format: "proto", expectErr: false},
+		{name: "xml format is valid", format: "xml", expectErr: false},
+		{name: "properties format is valid", format: "properties", expectErr: false},
 		{name: "ini format is invalid", format: "ini", expectErr: true},
 	}
 
@@ -1545,3 +1893,176 @@ func containsAt(s, substr string) bool {
 	}
 	return false
 }
+
+// TestPrefixAllowed_Allowlist_RestrictsAndValidates verifies the
+// allowedPrefixes list.
+//
+// Why: An empty list must keep today's behavior, while a configured list must
+// reject everything else, including no prefix unless "" is listed. Entries
+// outside the SemVer convention would allow nothing the parser accepts.
+//
+// What: An empty list allows all; ["v"] allows only "v"; Validate rejects an
+// entry of "release-".
+func TestPrefixAllowed_Allowlist_RestrictsAndValidates(t *testing.T) {
+	// Precondition: Configs with and without an allowlist
+	open := Default()
+	restricted := Default()
+	restricted.AllowedPrefixes = []string{"v"}
+	invalid := Default()
+	invalid.AllowedPrefixes = []string{"v", "release-"}
+
+	// Action & Expected: Allowlist checks
+	if !open.PrefixAllowed("V") || !open.PrefixAllowed("") {
+		t.Error("expected empty allowlist to allow any prefix")
+	}
+	if !restricted.PrefixAllowed("v") || restricted.PrefixAllowed("V") || restricted.PrefixAllowed("") {
+		t.Errorf("expected only 'v' allowed by %v", restricted.AllowedPrefixes)
+	}
+	if err := restricted.Validate(); err != nil {
+		t.Errorf("expected valid allowlist, got %v", err)
+	}
+	if err := invalid.Validate(); err == nil {
+		t.Error("expected Validate to reject 'release-' in allowedPrefixes")
+	}
+}
+
+// TestJavaConfig_SnapshotModes_DecideSnapshotBuilds validates java.snapshot.
+//
+// Why: auto must mark only untagged builds as SNAPSHOT, and a typo in the mode
+// should be reported rather than silently disabling the workflow.
+//
+// What: auto applies to untagged builds, always to all, never (and unset) to
+// none; Validate rejects unknown modes.
+func TestJavaConfig_SnapshotModes_DecideSnapshotBuilds(t *testing.T) {
+	// Precondition: One config per mode
+	auto := JavaConfig{Snapshot: SnapshotAuto}
+	always := JavaConfig{Snapshot: SnapshotAlways}
+	unset := JavaConfig{}
+	invalid := Default()
+	invalid.Java.Snapshot = "sometimes"
+
+	// Action & Expected: Mode decisions
+	if !auto.UseSnapshot(false) || auto.UseSnapshot(true) {
+		t.Error("expected auto to apply only to untagged builds")
+	}
+	if !always.UseSnapshot(true) || !always.UseSnapshot(false) {
+		t.Error("expected always to apply to every build")
+	}
+	if unset.UseSnapshot(false) || unset.SnapshotEnabled() {
+		t.Error("expected an unset mode to disable SNAPSHOT")
+	}
+	if err := invalid.Validate(); err == nil {
+		t.Error("expected Validate to reject java snapshot 'sometimes'")
+	}
+}
+
+// TestReadConfig_MigratesLegacySuffix validates legacy key migration.
+//
+// Why: Configs written by earlier releases keep a 'suffix' block for build
+// metadata; they must keep working until 'component migrate' rewrites them.
+//
+// What: suffix.enabled and suffix.git.hashLength populate the metadata keys
+// and are reported in Migrations; current keys win over legacy ones; an
+// unknown suffix type is an error.
+func TestReadConfig_MigratesLegacySuffix(t *testing.T) {
+	tests := []struct {
+		name         string
+		yaml         string
+		wantTemplate string
+		wantLength   int
+		wantErr      bool
+	}{
+		{name: "legacy only", yaml: "suffix:\n  enabled: true\n  type: git\n  git:\n    hashLength: 8\n", wantTemplate: "{{ShortHash}}", wantLength: 8},
+		{name: "current keys win", yaml: "metadata:\n  template: build\nsuffix:\n  enabled: true\n", wantTemplate: "build", wantLength: 12},
+		{name: "unknown type", yaml: "suffix:\n  enabled: true\n  type: date\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition: Config file with a legacy suffix block
+			t.Chdir(t.TempDir())
+			if err := os.WriteFile(".versionator.yaml", []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			// Action
+			cfg, err := ReadConfig()
+
+			// Expected
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadConfig failed: %v", err)
+			}
+			if cfg.Metadata.Template != tt.wantTemplate || cfg.Metadata.Git.HashLength != tt.wantLength {
+				t.Errorf("metadata = %q/%d, want %q/%d", cfg.Metadata.Template, cfg.Metadata.Git.HashLength, tt.wantTemplate, tt.wantLength)
+			}
+			if len(cfg.Migrations) == 0 {
+				t.Error("expected migrations to be reported")
+			}
+		})
+	}
+}
+
+// TestReadConfig_MigratesLegacyMetadataEnabled validates the second legacy
+// layout.
+//
+// Why: Between the suffix block and metadata.template, metadata was switched
+// on with metadata.enabled and metadata.type; those files must still load
+// under strict parsing and keep producing metadata.
+//
+// What: enabled: true becomes the {{ShortHash}} template unless a template
+// is set; enabled: false adds nothing; each legacy key is reported; an
+// unsupported type is an error.
+func TestReadConfig_MigratesLegacyMetadataEnabled(t *testing.T) {
+	tests := []struct {
+		name           string
+		yaml           string
+		wantTemplate   string
+		wantMigrations []string
+		wantErr        bool
+	}{
+		{name: "enabled", yaml: "metadata:\n  type: git\n  enabled: true\n  git:\n    hashLength: 8\n", wantTemplate: "{{ShortHash}}", wantMigrations: []string{"metadata.enabled", "metadata.type"}},
+		{name: "disabled", yaml: "metadata:\n  enabled: false\n", wantMigrations: []string{"metadata.enabled"}},
+		{name: "template wins", yaml: "metadata:\n  template: build\n  enabled: true\n", wantTemplate: "build", wantMigrations: []string{"metadata.enabled"}},
+		{name: "unknown type", yaml: "metadata:\n  enabled: true\n  type: date\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition
+			t.Chdir(t.TempDir())
+			if err := os.WriteFile(configFile, []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			// Action
+			cfg, err := ReadConfig()
+
+			// Expected
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadConfig failed: %v", err)
+			}
+			if cfg.Metadata.Template != tt.wantTemplate {
+				t.Errorf("metadata.template = %q, want %q", cfg.Metadata.Template, tt.wantTemplate)
+			}
+			var from []string
+			for _, m := range cfg.Migrations {
+				from = append(from, m.From)
+			}
+			if strings.Join(from, ",") != strings.Join(tt.wantMigrations, ",") {
+				t.Errorf("migrations = %v, want %v", from, tt.wantMigrations)
+			}
+		})
+	}
+}
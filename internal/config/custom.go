@@ -0,0 +1,94 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadCustomFile reads custom variables from a file maintained by other
+// tooling: a flat YAML or JSON mapping (.yaml, .yml, .json) or, for any
+// other name, KEY=VALUE lines as in a .env file
+func ReadCustomFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrCustomFileRead, err)
+	}
+
+	var vars map[string]string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		if err := yaml.Unmarshal(data, &vars); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", ErrCustomFileParse, path, err)
+		}
+	default:
+		if vars, err = parseEnvFile(data); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", ErrCustomFileParse, path, err)
+		}
+	}
+
+	for key := range vars {
+		if !isValidTemplateKey(key) {
+			return nil, fmt.Errorf("%s %s: invalid custom variable key '%s'", ErrCustomFileParse, path, key)
+		}
+	}
+	return vars, nil
+}
+
+// parseEnvFile parses KEY=VALUE lines. Blank lines and lines starting with
+// # are skipped, "export " before a key is ignored, double-quoted values
+// take Go escapes, single-quoted values are literal, and unquoted values end
+// at " #".
+func parseEnvFile(data []byte) (map[string]string, error) {
+	vars := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", n)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch {
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			value = unquoted
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		default:
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+		}
+		vars[key] = value
+	}
+	return vars, scanner.Err()
+}
+
+// mergedCustom returns the variables of the customFrom files, in order, with
+// the inline custom variables over them
+func (c *Config) mergedCustom() (map[string]string, error) {
+	merged := map[string]string{}
+	for _, path := range c.CustomFrom {
+		vars, err := ReadCustomFile(path)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(merged, vars)
+	}
+	maps.Copy(merged, c.Custom)
+	return merged, nil
+}
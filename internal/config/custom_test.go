@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestReadCustomFile_EnvFormat validates .env parsing.
+//
+// Why: Variable sets maintained by other tooling are often shell-style .env
+// files, with comments, export and quoting.
+//
+// What: Comments and blank lines are skipped, export is ignored, double
+// quotes take escapes, single quotes are literal, and unquoted values end at
+// an inline comment.
+func TestReadCustomFile_EnvFormat(t *testing.T) {
+	// Precondition: A .env file using each form
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("vars.env", []byte(`# build variables
+AppName=MyApp
+
+export Region = eu-west-1
+Banner="line one\nline two"
+Literal='{{not\n escaped}}'
+Team=payments # owner
+`), 0644)
+
+	// Action
+	vars, err := ReadCustomFile("vars.env")
+
+	// Expected
+	if err != nil {
+		t.Fatalf("ReadCustomFile() error: %v", err)
+	}
+	want := map[string]string{
+		"AppName": "MyApp",
+		"Region":  "eu-west-1",
+		"Banner":  "line one\nline two",
+		"Literal": `{{not\n escaped}}`,
+		"Team":    "payments",
+	}
+	for key, value := range want {
+		if vars[key] != value {
+			t.Errorf("%s = %q, want %q", key, vars[key], value)
+		}
+	}
+	if len(vars) != len(want) {
+		t.Errorf("got %d variables, want %d: %v", len(vars), len(want), vars)
+	}
+}
+
+// TestReadCustomFile_Errors validates rejected files.
+//
+// Why: A malformed or misnamed variable must fail loudly instead of leaving
+// a template variable silently empty.
+//
+// What: A line without "=", an invalid key, and a missing file are errors.
+func TestReadCustomFile_Errors(t *testing.T) {
+	// Precondition: Malformed files
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("bad.env", []byte("AppName\n"), 0644)
+	_ = os.WriteFile("bad.yaml", []byte("app-name: x\n"), 0644)
+
+	for path, want := range map[string]string{
+		"bad.env":     ErrCustomFileParse,
+		"bad.yaml":    ErrCustomFileParse,
+		"missing.env": ErrCustomFileRead,
+	} {
+		// Action
+		_, err := ReadCustomFile(path)
+
+		// Expected
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ReadCustomFile(%q) error = %v, want %q", path, err, want)
+		}
+	}
+}
+
+// TestGetAllCustom_MergesCustomFrom validates precedence between files and
+// inline variables.
+//
+// Why: Shared variable files provide defaults that a repository overrides
+// in .versionator.yaml, and later files refine earlier ones.
+//
+// What: A YAML file overrides the .env file before it, and custom overrides
+// both; keys only in a file are kept.
+func TestGetAllCustom_MergesCustomFrom(t *testing.T) {
+	// Precondition: Two files and an inline override
+	t.Chdir(t.TempDir())
+	_ = os.WriteFile("vars.env", []byte("AppName=FromEnv\nRegion=eu\nTeam=env\n"), 0644)
+	_ = os.WriteFile("vars.yaml", []byte("Region: us\nBuild: 42\nTeam: yaml\n"), 0644)
+	_ = os.WriteFile(".versionator.yaml", []byte(`customFrom: [vars.env, vars.yaml]
+custom:
+  Team: inline
+`), 0644)
+
+	// Action
+	custom, err := GetAllCustom()
+
+	// Expected
+	if err != nil {
+		t.Fatalf("GetAllCustom() error: %v", err)
+	}
+	want := map[string]string{"AppName": "FromEnv", "Region": "us", "Build": "42", "Team": "inline"}
+	for key, value := range want {
+		if custom[key] != value {
+			t.Errorf("%s = %q, want %q", key, custom[key], value)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// legacyConfig holds keys written by earlier versionator releases, laid out
+// as they appeared in .versionator.yaml. They are translated to their
+// current equivalents on read; `config migrate --write` rewrites
+// .versionator.yaml without them.
+type legacyConfig struct {
+	// Suffix was the build metadata block before prerelease and metadata
+	// were split: "suffix: {enabled, type: git, git: {hashLength}}"
+	Suffix *legacySuffixConfig `yaml:"suffix"`
+	// Metadata holds keys the metadata block kept for a release after the
+	// split, before metadata.template replaced them
+	Metadata legacyMetadataConfig `yaml:"metadata"`
+}
+
+// Migration is a legacy key translated on read
+type Migration struct {
+	// From is the legacy key (e.g. "suffix.enabled")
+	From string
+	// To is the current key, with the value written when it is derived
+	// (e.g. "metadata.template ({{ShortHash}})")
+	To string
+}
+
+type legacySuffixConfig struct {
+	Enabled bool      `yaml:"enabled"`
+	Type    string    `yaml:"type"`
+	Git     GitConfig `yaml:"git"`
+}
+
+type legacyMetadataConfig struct {
+	Enabled *bool  `yaml:"enabled"`
+	Type    string `yaml:"type"`
+}
+
+// legacyMigrations translate the legacy layouts, oldest first, so a key set
+// by an older layout is not overridden by a newer one
+var legacyMigrations = []func(config *Config, legacy *legacyConfig) ([]Migration, error){
+	migrateSuffix,
+	migrateMetadataEnabled,
+}
+
+// migrateLegacy applies the legacy keys in data to config, without
+// overriding values already set with the current keys, and returns a
+// migration for each legacy key found
+func migrateLegacy(config *Config, data []byte) ([]Migration, error) {
+	var legacy legacyConfig
+	if err := yaml.Unmarshal(data, &legacy); err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	for _, migrate := range legacyMigrations {
+		found, err := migrate(config, &legacy)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, found...)
+	}
+	return migrations, nil
+}
+
+// migrateSuffix translates the suffix block
+func migrateSuffix(config *Config, legacy *legacyConfig) ([]Migration, error) {
+	s := legacy.Suffix
+	if s == nil {
+		return nil, nil
+	}
+	var migrations []Migration
+	if s.Git.HashLength > 0 && config.Metadata.Git.HashLength == Default().Metadata.Git.HashLength {
+		config.Metadata.Git.HashLength = s.Git.HashLength
+		migrations = append(migrations, Migration{From: "suffix.git.hashLength", To: fmt.Sprintf("metadata.git.hashLength (%d)", s.Git.HashLength)})
+	}
+	if s.Enabled && config.Metadata.Template == "" {
+		if s.Type != "" && s.Type != "git" {
+			return nil, fmt.Errorf("suffix.type %q has no metadata equivalent; set metadata.template instead", s.Type)
+		}
+		config.Metadata.Template = "{{ShortHash}}"
+		migrations = append(migrations, Migration{From: "suffix.enabled", To: "metadata.template ({{ShortHash}})"})
+	}
+	return append(migrations, Migration{From: "suffix", To: "metadata"}), nil
+}
+
+// migrateMetadataEnabled translates metadata.enabled and metadata.type;
+// metadata is now on when metadata.template is set
+func migrateMetadataEnabled(config *Config, legacy *legacyConfig) ([]Migration, error) {
+	m := legacy.Metadata
+	var migrations []Migration
+	if m.Enabled != nil {
+		if *m.Enabled && config.Metadata.Template == "" {
+			if m.Type != "" && m.Type != "git" {
+				return nil, fmt.Errorf("metadata.type %q has no equivalent; set metadata.template instead", m.Type)
+			}
+			config.Metadata.Template = "{{ShortHash}}"
+			migrations = append(migrations, Migration{From: "metadata.enabled", To: "metadata.template ({{ShortHash}})"})
+		} else {
+			migrations = append(migrations, Migration{From: "metadata.enabled", To: "metadata.template"})
+		}
+	}
+	if m.Type != "" {
+		migrations = append(migrations, Migration{From: "metadata.type", To: "metadata.template"})
+	}
+	return migrations, nil
+}
+
+// legacyKey is a key of legacyConfig that Config does not have
+type legacyKey struct {
+	// parent is the Config type holding the key (e.g. MetadataConfig)
+	parent reflect.Type
+	// path is the parent's dotted path, "" at the top level
+	path string
+	name string
+	// typ is the legacy value type
+	typ reflect.Type
+}
+
+// legacyKeys returns the keys of legacyConfig missing from Config, so the
+// strict parser accepts them and the schema marks them deprecated
+func legacyKeys() []legacyKey {
+	var keys []legacyKey
+	var walk func(legacy, current reflect.Type, path string)
+	walk = func(legacy, current reflect.Type, path string) {
+		currentFields := map[string]reflect.Type{}
+		for _, f := range schemaFields(current) {
+			currentFields[f.name] = f.Type
+		}
+		for _, f := range schemaFields(legacy) {
+			if t, ok := currentFields[f.name]; ok && t.Kind() == reflect.Struct && f.Type.Kind() == reflect.Struct {
+				walk(f.Type, t, joinPath(path, f.name))
+			} else if !ok {
+				keys = append(keys, legacyKey{parent: current, path: path, name: f.name, typ: f.Type})
+			}
+		}
+	}
+	walk(reflect.TypeOf(legacyConfig{}), reflect.TypeOf(Config{}), "")
+	return keys
+}
+
+// joinPath appends name to a dotted path
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -2,19 +2,14 @@
 // Exported so tests can compare against them
 package config
 
-import "os"
-
-// File permission constants
-const (
-	// FilePermission is the default permission for created files (owner rw, group/other r)
-	FilePermission os.FileMode = 0644
-)
-
 // Error messages
 const (
 	ErrConfigNotFound       = "config file not found"
 	ErrConfigParseFail      = "failed to parse config file"
 	ErrInvalidTemplateSyntax = "invalid template syntax"
+	ErrUnknownConfigKey      = "unknown config key"
+	ErrCustomFileRead        = "failed to read custom variables file"
+	ErrCustomFileParse       = "failed to parse custom variables file"
 )
 
 // Log messages for structured logging
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/clierr"
+
+	"gopkg.in/yaml.v3"
+)
+
+// decodeStrict decodes data into config, rejecting keys that no field
+// accepts, other than legacy keys, so typos do not silently fall back to
+// defaults
+func decodeStrict(data []byte, config *Config) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			return suggestKnownKeys(typeErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// unknownFieldPattern matches yaml.v3's unknown field error
+var unknownFieldPattern = regexp.MustCompile(`^line (\d+): field (\S+) not found in type (\S+)$`)
+
+// suggestKnownKeys rewrites unknown field errors as unknown key errors that
+// name the closest accepted key
+func suggestKnownKeys(typeErr *yaml.TypeError) error {
+	keys := map[string][]string{}
+	collectKeys(reflect.TypeOf(Config{}), keys)
+	legacy := map[string]bool{}
+	for _, k := range legacyKeys() {
+		legacy[k.parent.String()+"."+k.name] = true
+	}
+
+	var messages []string
+	for _, msg := range typeErr.Errors {
+		m := unknownFieldPattern.FindStringSubmatch(msg)
+		if m == nil {
+			messages = append(messages, msg)
+			continue
+		}
+		if legacy[m[3]+"."+m[2]] {
+			// decoding continued past the key; migrateLegacy reads it
+			continue
+		}
+		msg = fmt.Sprintf("line %s: %s %q", m[1], ErrUnknownConfigKey, m[2])
+		if suggestion := clierr.Closest(m[2], keys[m[3]]); suggestion != "" {
+			msg += fmt.Sprintf(" (did you mean %q?)", suggestion)
+		}
+		messages = append(messages, msg)
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
+
+// collectKeys records the YAML keys of t and every struct type it contains,
+// keyed by type name as yaml.v3 reports it
+func collectKeys(t reflect.Type, keys map[string][]string) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return
+	}
+	if _, seen := keys[t.String()]; seen {
+		return
+	}
+	keys[t.String()] = nil
+	for _, f := range schemaFields(t) {
+		keys[t.String()] = append(keys[t.String()], f.name)
+		collectKeys(f.Type, keys)
+	}
+}
+
+// schemaField is a struct field with its YAML key
+type schemaField struct {
+	reflect.StructField
+	name string
+}
+
+// schemaFields returns the fields of t that appear in YAML, with inline
+// structs flattened
+func schemaFields(t reflect.Type) []schemaField {
+	var fields []schemaField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("yaml")
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") {
+			fields = append(fields, schemaFields(f.Type)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields = append(fields, schemaField{StructField: f, name: name})
+	}
+	return fields
+}
+
+// Schema is a JSON Schema (draft 2020-12) node
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Deprecated           bool               `json:"deprecated,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+}
+
+// schemaEnums lists the accepted values of string keys that Validate
+// restricts, by dotted path ("[]" for list items)
+var schemaEnums = map[string][]string{
+	"allowedPrefixes[]":         {"", "v", "V"},
+	"branchVersioning.mode":     {"replace", "append"},
+	"logging.output":            {"console", "json", "development"},
+	"release.publish.provider":  {"github", "gitlab", "gitea"},
+	"release.onConflict.bump":   {"major", "minor", "patch"},
+	"hooks.webhooks[].events[]": {"bump", "tag"},
+	"hooks.scripts[].events[]":  {"bump", "tag"},
+	"java.snapshot":             {SnapshotAuto, SnapshotAlways, SnapshotNever},
+	"vcs.dirtyCheck":            {"full", "tracked", "off"},
+	"vcs.tagDistance":           {"log", "describe"},
+	"emit.targets[].style":      {"semver", "pep440", "nuget"},
+	"emit.targets[].escape":     {"none", "html", "xml"},
+	"emit.gitignore":            {"add", "verify"},
+	"train.level":               {"major", "minor", "patch"},
+	"bump.mode":                 {"all", "semver", "conventional"},
+	"serve.levels[]":            {"major", "minor", "patch", "revision"},
+	"store.backend":             {"file", "git", "http"},
+	"updates[].format":          {"json", "yaml", "toml", "proto", "xml", "properties"},
+	"updates[].type":            {UpdateTypeValue, UpdateTypeRange},
+	"versionFileFormat":         {"plain", "yaml", "json"},
+	"looseVersions":             {"normalize", "reject"},
+}
+
+// JSONSchema describes .versionator.yaml for editors; keys the strict
+// parser rejects are not allowed, and legacy keys are marked deprecated
+func JSONSchema() *Schema {
+	s := typeSchema(reflect.TypeOf(Config{}), "")
+	s.Schema = "https://json-schema.org/draft/2020-12/schema"
+	s.Title = "versionator configuration (.versionator.yaml)"
+	for _, k := range legacyKeys() {
+		parent := s
+		if k.path != "" {
+			for _, name := range strings.Split(k.path, ".") {
+				parent = parent.Properties[name]
+			}
+		}
+		legacy := typeSchema(k.typ, joinPath(k.path, k.name))
+		legacy.Deprecated = true
+		parent.Properties[k.name] = legacy
+	}
+	return s
+}
+
+// typeSchema returns the schema for values of t at path
+func typeSchema(t reflect.Type, path string) *Schema {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == reflect.TypeOf(time.Duration(0)):
+		// Durations are written as strings such as "30s"
+		return &Schema{Type: "string"}
+	case t.Kind() == reflect.String:
+		return &Schema{Type: "string", Enum: schemaEnums[path]}
+	case t.Kind() == reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case t.Kind() == reflect.Slice:
+		return &Schema{Type: "array", Items: typeSchema(t.Elem(), path+"[]")}
+	case t.Kind() == reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: typeSchema(t.Elem(), path+".*")}
+	case t.Kind() == reflect.Struct:
+		s := &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: false}
+		for _, f := range schemaFields(t) {
+			s.Properties[f.name] = typeSchema(f.Type, joinPath(path, f.name))
+		}
+		return s
+	}
+	return &Schema{}
+}
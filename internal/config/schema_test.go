@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestReadConfig_UnknownKey_SuggestsClosest validates strict parsing.
+//
+// Why: A misspelled key silently fell back to its default, so a typo such
+// as "prerelase" left releases without the intended pre-release.
+//
+// What: Unknown keys at any depth are rejected with their line and the
+// closest known key; a key nothing resembles gets no suggestion; legacy
+// keys are still accepted.
+func TestReadConfig_UnknownKey_SuggestsClosest(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "top level", yaml: "prefix: v\nprerelase:\n  template: alpha\n", wantErr: `line 2: unknown config key "prerelase" (did you mean "prerelease"?)`},
+		{name: "nested", yaml: "release:\n  createBrnach: false\n", wantErr: `line 2: unknown config key "createBrnach" (did you mean "createBranch"?)`},
+		{name: "list item", yaml: "updates:\n  - file: package.json\n    path: version\n    templat: x\n", wantErr: `line 4: unknown config key "templat" (did you mean "template"?)`},
+		{name: "no suggestion", yaml: "colour: blue\n", wantErr: `line 1: unknown config key "colour"`},
+		{name: "legacy key", yaml: "suffix:\n  enabled: false\n"},
+		{name: "empty file", yaml: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition
+			t.Chdir(t.TempDir())
+			if err := os.WriteFile(configFile, []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			// Action
+			_, err := ReadConfig()
+
+			// Expected
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			if strings.HasSuffix(tt.wantErr, `"colour"`) && strings.Contains(err.Error(), "did you mean") {
+				t.Errorf("unexpected suggestion: %v", err)
+			}
+		})
+	}
+}
+
+// TestJSONSchema_DescribesAcceptedKeys validates the exported schema.
+//
+// Why: Editors flag what the schema disallows; it must accept exactly what
+// the strict parser accepts, or editors and versionator disagree.
+//
+// What: Every struct level disallows other keys, nested keys and list
+// items are described, validated values are enums, durations are strings,
+// and legacy keys are deprecated.
+func TestJSONSchema_DescribesAcceptedKeys(t *testing.T) {
+	// Action
+	s := JSONSchema()
+
+	// Expected
+	if s.Schema == "" || s.Type != "object" || s.AdditionalProperties != false {
+		t.Fatalf("root = %+v, want a closed object with $schema", s)
+	}
+	if got := s.Properties["prerelease"].Properties["template"].Type; got != "string" {
+		t.Errorf("prerelease.template type = %q, want string", got)
+	}
+	update := s.Properties["updates"].Items
+	if update == nil || update.AdditionalProperties != false || strings.Join(update.Properties["type"].Enum, ",") != "value,range" {
+		t.Errorf("updates items = %+v, want a closed object with a type enum", update)
+	}
+	if got := s.Properties["custom"].AdditionalProperties.(*Schema).Type; got != "string" {
+		t.Errorf("custom values type = %q, want string", got)
+	}
+	if got := s.Properties["hooks"].Properties["scripts"].Items.Properties["timeout"].Type; got != "string" {
+		t.Errorf("hooks.scripts[].timeout type = %q, want string", got)
+	}
+	if !s.Properties["suffix"].Deprecated || !s.Properties["metadata"].Properties["enabled"].Deprecated {
+		t.Error("expected suffix and metadata.enabled to be deprecated")
+	}
+	if _, ok := s.Properties["Migrations"]; ok {
+		t.Error("unexpected property for a field not read from YAML")
+	}
+}
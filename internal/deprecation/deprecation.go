@@ -0,0 +1,110 @@
+// Package deprecation reports deprecated usage (commands, flags, config
+// keys, template variables) on one channel, so CI logs can be scanned for
+// scripts to migrate before the old names are removed. Notices are written
+// to stderr as "warning: deprecated ..." lines, or as JSON lines when
+// --log-format=json.
+package deprecation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Kind classifies what is deprecated
+type Kind string
+
+const (
+	KindCommand          Kind = "command"
+	KindFlag             Kind = "flag"
+	KindConfigKey        Kind = "config-key"
+	KindTemplateVariable Kind = "template-variable"
+)
+
+// Notice is one deprecated usage
+type Notice struct {
+	Kind Kind `json:"kind"`
+	// Name is what was used (e.g. "config prefix enable", "--strict",
+	// "suffix", "{{CommitUser}}")
+	Name string `json:"name"`
+	// Replacement is what to use instead
+	Replacement string `json:"replacement,omitempty"`
+	// Hint is an optional migration step (e.g. a command to run)
+	Hint string `json:"hint,omitempty"`
+}
+
+// jsonNotice is the JSON line layout; level and msg match the fields of
+// the json log format so one filter finds both
+type jsonNotice struct {
+	Level string `json:"level"`
+	Msg   string `json:"msg"`
+	Notice
+}
+
+var (
+	mu        sync.Mutex
+	out       io.Writer
+	jsonLines bool
+	reported  = map[string]bool{}
+)
+
+// Configure sets where Report writes and whether notices are JSON lines.
+// A nil writer disables Report. Notices already reported are forgotten.
+func Configure(w io.Writer, asJSON bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	out = w
+	jsonLines = asJSON
+	reported = map[string]bool{}
+}
+
+// Report writes n to the configured channel, once per kind and name
+func Report(n Notice) {
+	mu.Lock()
+	defer mu.Unlock()
+	key := string(n.Kind) + "\x00" + n.Name
+	if out == nil || reported[key] {
+		return
+	}
+	reported[key] = true
+	write(out, n)
+}
+
+// Fprint writes n to w in the configured format, for callers with their
+// own destination (e.g. strict template rendering)
+func Fprint(w io.Writer, n Notice) {
+	mu.Lock()
+	defer mu.Unlock()
+	write(w, n)
+}
+
+// write formats n; the caller holds mu
+func write(w io.Writer, n Notice) {
+	if jsonLines {
+		line, err := json.Marshal(jsonNotice{Level: "warn", Msg: "deprecated", Notice: n})
+		if err == nil {
+			fmt.Fprintln(w, string(line))
+			return
+		}
+	}
+	fmt.Fprintln(w, Format(n))
+}
+
+// Format returns the text form of n:
+// "warning: deprecated <kind>: <name> (use <replacement>; <hint>)"
+func Format(n Notice) string {
+	var advice []string
+	if n.Replacement != "" {
+		advice = append(advice, "use "+n.Replacement)
+	}
+	if n.Hint != "" {
+		advice = append(advice, n.Hint)
+	}
+	line := fmt.Sprintf("warning: deprecated %s: %s", strings.ReplaceAll(string(n.Kind), "-", " "), n.Name)
+	if len(advice) > 0 {
+		line += " (" + strings.Join(advice, "; ") + ")"
+	}
+	return line
+}
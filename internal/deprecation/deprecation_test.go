@@ -0,0 +1,74 @@
+package deprecation
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestReport_TextAndJSONLines validates the notice formats.
+//
+// Why: Humans read the text form; CI log scanners parse the JSON form and
+// must be able to rely on its fields.
+//
+// What: Text notices name the kind, the deprecated name, the replacement,
+// and the hint; JSON notices are one object per line with level, msg, kind,
+// name, and replacement; each name is reported once until reconfigured.
+func TestReport_TextAndJSONLines(t *testing.T) {
+	notice := Notice{Kind: KindCommand, Name: "config prefix enable", Replacement: "component prefix enable", Hint: "see docs"}
+	t.Cleanup(func() { Configure(nil, false) })
+
+	// Action: Text, reported twice
+	var text bytes.Buffer
+	Configure(&text, false)
+	Report(notice)
+	Report(notice)
+
+	// Expected
+	want := "warning: deprecated command: config prefix enable (use component prefix enable; see docs)\n"
+	if text.String() != want {
+		t.Errorf("text = %q, want %q", text.String(), want)
+	}
+
+	// Action: JSON lines
+	var lines bytes.Buffer
+	Configure(&lines, true)
+	Report(notice)
+	Report(Notice{Kind: KindConfigKey, Name: "suffix"})
+
+	// Expected
+	got := strings.Split(strings.TrimSpace(lines.String()), "\n")
+	if len(got) != 2 {
+		t.Fatalf("expected 2 JSON lines, got %q", lines.String())
+	}
+	var decoded map[string]string
+	if err := json.Unmarshal([]byte(got[0]), &decoded); err != nil {
+		t.Fatalf("invalid JSON line %q: %v", got[0], err)
+	}
+	for key, value := range map[string]string{"level": "warn", "msg": "deprecated", "kind": "command", "name": "config prefix enable", "replacement": "component prefix enable"} {
+		if decoded[key] != value {
+			t.Errorf("%s = %q, want %q", key, decoded[key], value)
+		}
+	}
+	if strings.Contains(got[1], "replacement") {
+		t.Errorf("expected empty replacement to be omitted: %q", got[1])
+	}
+}
+
+// TestFormat_TemplateVariable validates the strict-mode wording.
+//
+// Why: Scripts already grep strict-mode output for "deprecated template
+// variable"; moving it onto this channel must not change the text.
+//
+// What: A template variable notice reads as before.
+func TestFormat_TemplateVariable(t *testing.T) {
+	// Action
+	got := Format(Notice{Kind: KindTemplateVariable, Name: "{{CommitUser}}", Replacement: "{{CommitAuthor}}"})
+
+	// Expected
+	want := "warning: deprecated template variable: {{CommitUser}} (use {{CommitAuthor}})"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,169 @@
+// Package detect infers which languages a repository uses from the files at
+// its root (go.mod, package.json, Cargo.toml, pom.xml, ...). Each language
+// names the emit format and default output file versionator generates for
+// it, and the manifest that records its version, so `output emit --auto`,
+// `patch --auto` and `init --config` work without per-repository setup.
+//
+// Detection can be replaced by an explicit list under `languages` in
+// .versionator.yaml.
+package detect
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+)
+
+// Language is a language versionator can detect
+type Language struct {
+	// Name identifies the language in config and output (e.g. "go")
+	Name string
+	// Markers are file names or glob patterns at the repository root whose
+	// presence indicates the language
+	Markers []string
+	// EmitFormat is the emit format generated for the language
+	EmitFormat string
+	// EmitOutput is the default file the emit format is written to
+	EmitOutput string
+	// Manifest is the file recording the package version (one of
+	// audit.KnownManifests), empty when the language has none
+	Manifest string
+	// Supersedes names languages this one replaces when both are detected
+	// (TypeScript projects also have a package.json)
+	Supersedes []string
+}
+
+// Languages lists every detectable language, in output order
+var Languages = []Language{
+	{Name: "go", Markers: []string{"go.mod"}, EmitFormat: "go", EmitOutput: "version/version.go"},
+	{Name: "ts", Markers: []string{"tsconfig.json"}, EmitFormat: "ts", EmitOutput: "src/version.ts", Manifest: "package.json", Supersedes: []string{"js"}},
+	{Name: "js", Markers: []string{"package.json"}, EmitFormat: "js", EmitOutput: "src/version.js", Manifest: "package.json"},
+	{Name: "rust", Markers: []string{"Cargo.toml"}, EmitFormat: "rust", EmitOutput: "src/version.rs", Manifest: "Cargo.toml"},
+	{Name: "python", Markers: []string{"pyproject.toml", "setup.py", "setup.cfg"}, EmitFormat: "python", EmitOutput: "_version.py", Manifest: "pyproject.toml"},
+	{Name: "kotlin", Markers: []string{"build.gradle.kts"}, EmitFormat: "kotlin", EmitOutput: "src/main/kotlin/version/Version.kt", Supersedes: []string{"java"}},
+	{Name: "java", Markers: []string{"pom.xml", "build.gradle"}, EmitFormat: "java", EmitOutput: "src/main/java/version/Version.java", Manifest: "pom.xml"},
+	{Name: "csharp", Markers: []string{"*.csproj", "*.sln"}, EmitFormat: "csharp", EmitOutput: "Version.cs"},
+	{Name: "php", Markers: []string{"composer.json"}, EmitFormat: "php", EmitOutput: "src/Version.php"},
+	{Name: "ruby", Markers: []string{"Gemfile", "*.gemspec"}, EmitFormat: "ruby", EmitOutput: "lib/version.rb"},
+	{Name: "swift", Markers: []string{"Package.swift"}, EmitFormat: "swift", EmitOutput: "Sources/Version.swift"},
+	{Name: "dart", Markers: []string{"pubspec.yaml"}, EmitFormat: "dart", EmitOutput: "lib/version.dart"},
+}
+
+// Names returns the names of all detectable languages
+func Names() []string {
+	names := make([]string, len(Languages))
+	for i, l := range Languages {
+		names[i] = l.Name
+	}
+	return names
+}
+
+// Lookup returns the language called name
+func Lookup(name string) (Language, bool) {
+	for _, l := range Languages {
+		if l.Name == name {
+			return l, true
+		}
+	}
+	return Language{}, false
+}
+
+// Detect returns the languages whose markers exist in dir, dropping any
+// superseded by another detected language
+func Detect(dir string) []Language {
+	var found []Language
+	for _, l := range Languages {
+		if hasMarker(dir, l.Markers) {
+			found = append(found, l)
+		}
+	}
+
+	var superseded []string
+	for _, l := range found {
+		superseded = append(superseded, l.Supersedes...)
+	}
+	return slices.DeleteFunc(found, func(l Language) bool {
+		return slices.Contains(superseded, l.Name)
+	})
+}
+
+// hasMarker reports whether any marker matches a file in dir
+func hasMarker(dir string, markers []string) bool {
+	for _, m := range markers {
+		if matches, _ := filepath.Glob(filepath.Join(dir, m)); len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve returns the languages named in override, or those detected in dir
+// when override is empty
+func Resolve(dir string, override []string) ([]Language, error) {
+	if len(override) == 0 {
+		return Detect(dir), nil
+	}
+	langs := make([]Language, 0, len(override))
+	for _, name := range override {
+		l, ok := Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("%s '%s' (known: %s)", ErrUnknownLanguage, name, strings.Join(Names(), ", "))
+		}
+		langs = append(langs, l)
+	}
+	return langs, nil
+}
+
+// ResolveConfig resolves languages for the repository at dir using the
+// languages override in cfg, which may be nil
+func ResolveConfig(dir string, cfg *config.Config) ([]Language, error) {
+	var override []string
+	if cfg != nil {
+		override = cfg.Languages
+	}
+	return Resolve(dir, override)
+}
+
+// EmitTargets returns an emit target per language
+func EmitTargets(langs []Language) []config.EmitTarget {
+	targets := make([]config.EmitTarget, 0, len(langs))
+	for _, l := range langs {
+		targets = append(targets, config.EmitTarget{Name: l.Name, Format: l.EmitFormat, Output: l.EmitOutput})
+	}
+	return targets
+}
+
+// Manifests returns the distinct manifests of langs
+func Manifests(langs []Language) []string {
+	var files []string
+	for _, l := range langs {
+		if l.Manifest != "" && !slices.Contains(files, l.Manifest) {
+			files = append(files, l.Manifest)
+		}
+	}
+	return files
+}
+
+// ScaffoldYAML returns .versionator.yaml content declaring an emit target
+// for each language, or "" when langs is empty
+func ScaffoldYAML(langs []Language) string {
+	if len(langs) == 0 {
+		return ""
+	}
+	names := make([]string, len(langs))
+	for i, l := range langs {
+		names[i] = l.Name
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n# Detected languages: %s\n", strings.Join(names, ", "))
+	b.WriteString("# 'output emit --all' writes these files\n")
+	b.WriteString("emit:\n  targets:\n")
+	for _, t := range EmitTargets(langs) {
+		fmt.Fprintf(&b, "    - name: %s\n      format: %s\n      output: %s\n", t.Name, t.Format, t.Output)
+	}
+	return b.String()
+}
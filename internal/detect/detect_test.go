@@ -0,0 +1,112 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func touch(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func names(langs []Language) []string {
+	out := make([]string, len(langs))
+	for i, l := range langs {
+		out[i] = l.Name
+	}
+	return out
+}
+
+// TestDetect_Markers_FindsLanguages validates marker detection.
+//
+// Why: emit --auto and patch --auto act on whatever is detected; a missed
+// or spurious language writes the wrong files.
+//
+// What: Each repository layout yields its languages in table order;
+// TypeScript supersedes JavaScript, Gradle Kotlin DSL supersedes Java, and
+// glob markers such as *.csproj match.
+func TestDetect_Markers_FindsLanguages(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  []string
+	}{
+		{name: "empty", files: nil, want: []string{}},
+		{name: "go and rust", files: []string{"Cargo.toml", "go.mod"}, want: []string{"go", "rust"}},
+		{name: "javascript", files: []string{"package.json"}, want: []string{"js"}},
+		{name: "typescript supersedes javascript", files: []string{"package.json", "tsconfig.json"}, want: []string{"ts"}},
+		{name: "kotlin supersedes java", files: []string{"build.gradle.kts", "pom.xml"}, want: []string{"kotlin"}},
+		{name: "glob marker", files: []string{"App.csproj"}, want: []string{"csharp"}},
+		{name: "python setup.py", files: []string{"setup.py"}, want: []string{"python"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition
+			dir := t.TempDir()
+			touch(t, dir, tt.files...)
+
+			// Action
+			got := names(Detect(dir))
+
+			// Expected
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Detect = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestResolve_Override_ReplacesDetection validates the languages override.
+//
+// Why: Detection guesses; the languages list in config must win, and a typo
+// in it must be reported rather than ignored.
+//
+// What: An override is used verbatim even when other markers exist; an
+// unknown name fails with the known names listed.
+func TestResolve_Override_ReplacesDetection(t *testing.T) {
+	// Precondition
+	dir := t.TempDir()
+	touch(t, dir, "go.mod")
+
+	// Action
+	langs, err := Resolve(dir, []string{"python", "dart"})
+	_, unknownErr := Resolve(dir, []string{"cobol"})
+
+	// Expected
+	if err != nil || !slices.Equal(names(langs), []string{"python", "dart"}) {
+		t.Errorf("Resolve = %v, %v", names(langs), err)
+	}
+	if unknownErr == nil || !strings.Contains(unknownErr.Error(), ErrUnknownLanguage) {
+		t.Errorf("expected %q, got %v", ErrUnknownLanguage, unknownErr)
+	}
+}
+
+// TestManifests_Deduplicates validates the manifest list for patch --auto.
+//
+// Why: TypeScript and JavaScript share package.json; patching it twice
+// would report the change twice.
+//
+// What: Each manifest appears once and languages without one add nothing.
+func TestManifests_Deduplicates(t *testing.T) {
+	// Precondition
+	ts, _ := Lookup("ts")
+	js, _ := Lookup("js")
+	goLang, _ := Lookup("go")
+
+	// Action
+	got := Manifests([]Language{ts, js, goLang})
+
+	// Expected
+	if !slices.Equal(got, []string{"package.json"}) {
+		t.Errorf("Manifests = %v", got)
+	}
+}
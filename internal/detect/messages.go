@@ -0,0 +1,9 @@
+// Package detect messages - error message constants
+// Exported so tests can compare against them
+package detect
+
+// Error messages
+const (
+	ErrUnknownLanguage = "unknown language"
+	ErrNoLanguages     = "no languages detected (set languages in .versionator.yaml)"
+)
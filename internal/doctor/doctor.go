@@ -0,0 +1,265 @@
+// Package doctor diagnoses the workspace versionator runs in: the git
+// binary, the repository and its history, .versionator.yaml, the VERSION
+// file, and the registered plugins. Each check reports what it found and,
+// when something is wrong, how to fix it.
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+)
+
+// Status is the outcome of a check
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn" // works, but some output will be missing or wrong
+	StatusFail Status = "fail" // commands will fail
+)
+
+// Check is the result of one diagnostic
+type Check struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail"`
+	// Hint says how to fix a warning or failure
+	Hint string `json:"hint,omitempty"`
+}
+
+// Report is the result of every check, in order
+type Report struct {
+	Checks []Check `json:"checks"`
+}
+
+// Failed reports whether any check failed
+func (r Report) Failed() bool {
+	return slices.ContainsFunc(r.Checks, func(c Check) bool { return c.Status == StatusFail })
+}
+
+// Write prints the report as a table, one line per check and its hint on
+// the line below
+func (r Report) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range r.Checks {
+		detail := strings.ReplaceAll(c.Detail, "\n", "; ")
+		fmt.Fprintf(tw, "[%s]\t%s\t%s\n", c.Status, c.Name, detail)
+		if c.Hint != "" {
+			fmt.Fprintf(tw, "\t\t-> %s\n", c.Hint)
+		}
+	}
+	return tw.Flush()
+}
+
+// Run performs every check against the current directory and the VCS
+// detected for it
+func Run() Report {
+	active := vcs.GetActiveVCS()
+	cfg, configCheck := checkConfig()
+	return Report{Checks: []Check{
+		checkGitBinary(exec.LookPath),
+		checkRepository(active),
+		checkHead(active),
+		checkHistory(active),
+		configCheck,
+		checkVersionFile(),
+		checkWritable(),
+		checkPlugins(cfg),
+	}}
+}
+
+// checkGitBinary looks for git on PATH; repositories are read without it,
+// but commits, pushes, and some fallbacks run it
+func checkGitBinary(lookPath func(string) (string, error)) Check {
+	c := Check{Name: "git binary"}
+	path, err := lookPath("git")
+	if err != nil {
+		c.Status, c.Detail = StatusWarn, "git not found on PATH"
+		c.Hint = "install git: release, commit, and push steps run it"
+		return c
+	}
+	c.Status, c.Detail = StatusOK, path
+	return c
+}
+
+// checkRepository reports the detected repository
+func checkRepository(active vcs.VersionControlSystem) Check {
+	c := Check{Name: "repository"}
+	if active == nil {
+		c.Status, c.Detail = StatusWarn, "no repository detected"
+		c.Hint = "run inside a repository (or pass --git-dir); without one, Hash, BranchName, and CommitsSinceTag are empty"
+		return c
+	}
+	root, err := active.GetRepositoryRoot()
+	if err != nil {
+		c.Status, c.Detail = StatusFail, fmt.Sprintf("%s repository: %v", active.Name(), err)
+		return c
+	}
+	c.Status, c.Detail = StatusOK, fmt.Sprintf("%s at %s", active.Name(), root)
+	if bare, ok := active.(vcs.BareRepository); ok && bare.IsBare() {
+		c.Detail += " (bare)"
+	}
+	return c
+}
+
+// checkHead reports the checked out branch; a detached HEAD, as CI
+// checkouts of a tag or pull request have, leaves BranchName empty
+func checkHead(active vcs.VersionControlSystem) Check {
+	c := Check{Name: "HEAD"}
+	if active == nil {
+		c.Status, c.Detail = StatusOK, "no repository"
+		return c
+	}
+	branch, err := active.GetBranchName()
+	switch {
+	case err != nil:
+		c.Status, c.Detail = StatusWarn, err.Error()
+		c.Hint = "make a first commit: versionator reads the commit HEAD points to"
+	case branch == "":
+		c.Status, c.Detail = StatusWarn, "detached HEAD"
+		c.Hint = "check out a branch (CI: check out the branch rather than the commit); BranchName and branch versioning need one"
+	default:
+		c.Status, c.Detail = StatusOK, "on branch "+branch
+	}
+	return c
+}
+
+// checkHistory reports a truncated history, which hides tags and
+// miscounts commits since the last release
+func checkHistory(active vcs.VersionControlSystem) Check {
+	c := Check{Name: "history", Status: StatusOK, Detail: "complete"}
+	if active == nil {
+		c.Detail = "no repository"
+		return c
+	}
+	if shallow, ok := active.(vcs.ShallowRepository); ok && shallow.IsShallow() {
+		c.Status, c.Detail = StatusWarn, "shallow clone"
+		c.Hint = "fetch the full history and tags (git fetch --unshallow --tags, or fetch-depth: 0 in CI); CommitsSinceTag and PreviousVersion need them"
+	}
+	return c
+}
+
+// checkConfig reads and validates .versionator.yaml; the configuration is
+// returned for the checks that depend on it (nil when unreadable)
+func checkConfig() (*config.Config, Check) {
+	c := Check{Name: "config"}
+	path := config.Path()
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		c.Status, c.Detail = StatusOK, "no .versionator.yaml, using defaults"
+		cfg, _ := config.ReadConfig()
+		return cfg, c
+	}
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		c.Status, c.Detail = StatusFail, err.Error()
+		c.Hint = "fix " + path + " (see 'versionator config schema' for the keys)"
+		return nil, c
+	}
+	if err := cfg.Validate(); err != nil {
+		c.Status, c.Detail = StatusFail, err.Error()
+		c.Hint = "fix " + path
+		return cfg, c
+	}
+	if len(cfg.Migrations) > 0 {
+		keys := make([]string, len(cfg.Migrations))
+		for i, m := range cfg.Migrations {
+			keys[i] = m.From
+		}
+		c.Status, c.Detail = StatusWarn, "deprecated keys: "+strings.Join(keys, ", ")
+		c.Hint = "run 'versionator config migrate --write'"
+		return cfg, c
+	}
+	c.Status, c.Detail = StatusOK, path
+	return cfg, c
+}
+
+// checkVersionFile parses VERSION without creating it, as commands do on
+// first use
+func checkVersionFile() Check {
+	c := Check{Name: "VERSION"}
+	path, err := version.Path()
+	if err != nil {
+		c.Status, c.Detail = StatusFail, err.Error()
+		return c
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		c.Status, c.Detail = StatusWarn, "no VERSION file"
+		c.Hint = "run 'versionator init'; otherwise the first command creates " + path + " with 0.0.1"
+		return c
+	}
+	v, err := version.ParseFile(path)
+	if err != nil {
+		c.Status, c.Detail = StatusFail, err.Error()
+		c.Hint = "fix " + path + ": it must hold one version, e.g. 1.2.3 or v1.2.3-rc.1"
+		return c
+	}
+	c.Status, c.Detail = StatusOK, fmt.Sprintf("%s (%s)", v.String(), path)
+	return c
+}
+
+// checkWritable verifies that VERSION and its directory can be written,
+// as bump, set, and release do
+func checkWritable() Check {
+	c := Check{Name: "write access"}
+	path, err := version.Path()
+	if err != nil {
+		c.Status, c.Detail = StatusFail, err.Error()
+		return c
+	}
+	dir := filepath.Dir(path)
+	probe, err := os.CreateTemp(dir, ".versionator-doctor-*")
+	if err != nil {
+		c.Status, c.Detail = StatusFail, fmt.Sprintf("cannot create files in %s", dir)
+		c.Hint = "check the ownership and permissions of " + dir
+		return c
+	}
+	probe.Close()
+	_ = os.Remove(probe.Name())
+	if f, err := os.OpenFile(path, os.O_WRONLY, 0); err == nil {
+		f.Close()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		c.Status, c.Detail = StatusFail, fmt.Sprintf("cannot write %s", path)
+		c.Hint = "check the ownership and permissions of " + path
+		return c
+	}
+	c.Status, c.Detail = StatusOK, dir
+	return c
+}
+
+// checkPlugins lists the registered plugins and VCS backends, and checks
+// that the configured scheme has its versioning plugin
+func checkPlugins(cfg *config.Config) Check {
+	c := Check{Name: "plugins"}
+	var names []string
+	for _, p := range plugin.GetPlugins() {
+		names = append(names, p.Name())
+	}
+	slices.Sort(names)
+	if len(vcs.ListVCS()) == 0 {
+		c.Status, c.Detail = StatusFail, "no VCS backend registered"
+		c.Hint = "this binary was built without VCS support; reinstall versionator"
+		return c
+	}
+	if cfg != nil && cfg.Scheme != "" && plugin.GetVersioningPlugin(cfg.Scheme) == nil {
+		c.Status, c.Detail = StatusFail, fmt.Sprintf("scheme %q has no versioning plugin", cfg.Scheme)
+		c.Hint = "set scheme to one of: " + strings.Join(plugin.ListSchemes(), ", ")
+		return c
+	}
+	c.Status, c.Detail = StatusOK, strings.Join(names, ", ")
+	if c.Detail == "" {
+		c.Detail = "none"
+	}
+	return c
+}
@@ -0,0 +1,172 @@
+package doctor
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+)
+
+// fakeVCS is a repository with a fixed branch and history state
+type fakeVCS struct {
+	vcs.VersionControlSystem
+	branch    string
+	branchErr error
+	shallow   bool
+}
+
+func (f fakeVCS) Name() string                       { return "git" }
+func (f fakeVCS) GetRepositoryRoot() (string, error) { return "/repo", nil }
+func (f fakeVCS) GetBranchName() (string, error)     { return f.branch, f.branchErr }
+func (f fakeVCS) IsShallow() bool                    { return f.shallow }
+
+// TestRepositoryChecks validates the repository, HEAD, and history checks.
+//
+// Why: CI checkouts are often detached and shallow, which silently empties
+// BranchName and miscounts CommitsSinceTag.
+//
+// What: A branch and full history pass; a detached HEAD, a shallow clone,
+// a missing repository, and an unborn HEAD warn with a hint.
+func TestRepositoryChecks(t *testing.T) {
+	tests := []struct {
+		name   string
+		check  func() Check
+		status Status
+	}{
+		{name: "repository found", check: func() Check { return checkRepository(fakeVCS{}) }, status: StatusOK},
+		{name: "no repository", check: func() Check { return checkRepository(nil) }, status: StatusWarn},
+		{name: "on a branch", check: func() Check { return checkHead(fakeVCS{branch: "main"}) }, status: StatusOK},
+		{name: "detached HEAD", check: func() Check { return checkHead(fakeVCS{}) }, status: StatusWarn},
+		{name: "unborn HEAD", check: func() Check { return checkHead(fakeVCS{branchErr: errors.New("reference not found")}) }, status: StatusWarn},
+		{name: "full history", check: func() Check { return checkHistory(fakeVCS{}) }, status: StatusOK},
+		{name: "shallow clone", check: func() Check { return checkHistory(fakeVCS{shallow: true}) }, status: StatusWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action
+			c := tt.check()
+
+			// Expected
+			if c.Status != tt.status {
+				t.Errorf("status = %s, want %s (%+v)", c.Status, tt.status, c)
+			}
+			if c.Status != StatusOK && c.Hint == "" {
+				t.Errorf("%s check has no hint", c.Status)
+			}
+		})
+	}
+}
+
+// TestCheckGitBinary validates the git binary check.
+//
+// Why: Reading works without git, so a missing binary only shows when a
+// release tries to commit or push.
+//
+// What: A binary on PATH passes with its path; none warns.
+func TestCheckGitBinary(t *testing.T) {
+	// Action
+	found := checkGitBinary(func(string) (string, error) { return "/usr/bin/git", nil })
+	missing := checkGitBinary(func(string) (string, error) { return "", errors.New("not found") })
+
+	// Expected
+	if found.Status != StatusOK || found.Detail != "/usr/bin/git" {
+		t.Errorf("found = %+v", found)
+	}
+	if missing.Status != StatusWarn {
+		t.Errorf("missing = %+v", missing)
+	}
+}
+
+// TestWorkspaceChecks validates the config, VERSION, and write checks.
+//
+// Why: These are the files every command reads first; their errors are
+// the most common support questions.
+//
+// What: Valid files pass; invalid config and an unparsable VERSION fail,
+// deprecated keys and a missing VERSION warn, and nothing is created.
+func TestWorkspaceChecks(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        string
+		version       string
+		configStatus  Status
+		versionStatus Status
+	}{
+		{name: "valid files", config: "prefix: v\n", version: "1.2.3\n", configStatus: StatusOK, versionStatus: StatusOK},
+		{name: "no files", configStatus: StatusOK, versionStatus: StatusWarn},
+		{name: "unknown config key", config: "prefx: v\n", version: "1.2.3\n", configStatus: StatusFail, versionStatus: StatusOK},
+		{name: "invalid config value", config: "emit:\n  lineEndings: cr\n", version: "1.2.3\n", configStatus: StatusFail, versionStatus: StatusOK},
+		{name: "unparsable VERSION", version: "not a version\n", configStatus: StatusOK, versionStatus: StatusFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition
+			t.Chdir(t.TempDir())
+			if tt.config != "" {
+				_ = os.WriteFile(".versionator.yaml", []byte(tt.config), 0644)
+			}
+			if tt.version != "" {
+				_ = os.WriteFile("VERSION", []byte(tt.version), 0644)
+			}
+			config.Invalidate()
+
+			// Action
+			_, configCheck := checkConfig()
+			versionCheck := checkVersionFile()
+			writeCheck := checkWritable()
+
+			// Expected
+			if configCheck.Status != tt.configStatus {
+				t.Errorf("config = %+v, want %s", configCheck, tt.configStatus)
+			}
+			if versionCheck.Status != tt.versionStatus {
+				t.Errorf("VERSION = %+v, want %s", versionCheck, tt.versionStatus)
+			}
+			if writeCheck.Status != StatusOK {
+				t.Errorf("write access = %+v", writeCheck)
+			}
+			if _, err := os.Stat("VERSION"); tt.version == "" && err == nil {
+				t.Error("VERSION was created")
+			}
+		})
+	}
+}
+
+// TestReport_Write validates the text report.
+//
+// Why: The report is pasted into bug reports, so each problem must read on
+// its own line with its fix.
+//
+// What: Each check prints its status, name, and detail on one line, and
+// its hint below; Failed is true only with a failed check.
+func TestReport_Write(t *testing.T) {
+	// Precondition
+	report := Report{Checks: []Check{
+		{Name: "config", Status: StatusOK, Detail: ".versionator.yaml"},
+		{Name: "history", Status: StatusWarn, Detail: "shallow clone", Hint: "fetch the full history"},
+	}}
+
+	// Action
+	var buf bytes.Buffer
+	err := report.Write(&buf)
+
+	// Expected
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[1], "[warn]") || !strings.Contains(lines[2], "-> fetch the full history") {
+		t.Errorf("report:\n%s", buf.String())
+	}
+	if report.Failed() {
+		t.Error("warnings must not fail the report")
+	}
+	report.Checks = append(report.Checks, Check{Name: "VERSION", Status: StatusFail})
+	if !report.Failed() {
+		t.Error("a failed check must fail the report")
+	}
+}
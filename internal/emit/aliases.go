@@ -0,0 +1,122 @@
+package emit
+
+import (
+	"io"
+	"regexp"
+	"sync"
+
+	"github.com/benjaminabbitt/versionator/internal/deprecation"
+	"github.com/benjaminabbitt/versionator/internal/logging"
+
+	"go.uber.org/zap"
+)
+
+// VariableAlias is an alternate name for a template variable. Renaming a
+// variable keeps the old name as an alias so existing templates still render;
+// Deprecated aliases are reported in strict mode so users can migrate before
+// the old name is removed.
+type VariableAlias struct {
+	// Name is the alias used in templates (e.g. "CommitUser")
+	Name string
+	// Target is the canonical variable it renders (e.g. "CommitAuthor")
+	Target string
+	// Deprecated marks aliases scheduled for removal
+	Deprecated bool
+}
+
+// variableAliases is the alias registry; add an entry here when renaming a
+// variable instead of duplicating it in the template maps
+var variableAliases = []VariableAlias{
+	{Name: "CommitUser", Target: "CommitAuthor", Deprecated: true},
+	{Name: "CommitUserEmail", Target: "CommitAuthorEmail", Deprecated: true},
+	{Name: "CommitDateTime", Target: "CommitDate", Deprecated: true},
+	{Name: "CommitDateTimeCompact", Target: "CommitDateCompact", Deprecated: true},
+}
+
+// Aliases returns the registered variable aliases
+func Aliases() []VariableAlias {
+	return append([]VariableAlias(nil), variableAliases...)
+}
+
+// LookupAlias returns the alias registered under name, if any
+func LookupAlias(name string) (VariableAlias, bool) {
+	for _, alias := range variableAliases {
+		if alias.Name == name {
+			return alias, true
+		}
+	}
+	return VariableAlias{}, false
+}
+
+// addAliases sets every alias whose target is present in m
+func addAliases[V any](m map[string]V) {
+	for _, alias := range variableAliases {
+		if value, ok := m[alias.Target]; ok {
+			m[alias.Name] = value
+		}
+	}
+}
+
+// templateTagPattern matches the variable name in {{Name}}, {{{Name}}},
+// {{& Name}}, and section tags {{#Name}} / {{^Name}}
+var templateTagPattern = regexp.MustCompile(`\{\{\{?\s*[#^&]?\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}`)
+
+// DeprecatedVariables returns the deprecated aliases a template uses, in
+// order of first use
+func DeprecatedVariables(tmplStr string) []VariableAlias {
+	var used []VariableAlias
+	seen := map[string]bool{}
+	for _, match := range templateTagPattern.FindAllStringSubmatch(tmplStr, -1) {
+		name := match[1]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if alias, ok := LookupAlias(name); ok && alias.Deprecated {
+			used = append(used, alias)
+		}
+	}
+	return used
+}
+
+var (
+	strictMu     sync.Mutex
+	strictWriter io.Writer
+	warned       = map[string]bool{}
+)
+
+// SetStrict enables strict mode: deprecated template variables are reported
+// to w once per name, in the deprecation notice format (JSON lines with
+// --log-format=json). A nil writer disables strict mode.
+func SetStrict(w io.Writer) {
+	strictMu.Lock()
+	defer strictMu.Unlock()
+	strictWriter = w
+	warned = map[string]bool{}
+}
+
+// warnDeprecatedVariables logs deprecated aliases used by a template and, in
+// strict mode, prints a warning for each
+func warnDeprecatedVariables(tmplStr string) {
+	deprecated := DeprecatedVariables(tmplStr)
+	if len(deprecated) == 0 {
+		return
+	}
+
+	strictMu.Lock()
+	defer strictMu.Unlock()
+	for _, alias := range deprecated {
+		logging.GetLogger().Debug(LogDeprecatedVariable,
+			zap.String("variable", alias.Name),
+			zap.String("replacement", alias.Target))
+		if strictWriter == nil || warned[alias.Name] {
+			continue
+		}
+		warned[alias.Name] = true
+		deprecation.Fprint(strictWriter, deprecation.Notice{
+			Kind:        deprecation.KindTemplateVariable,
+			Name:        "{{" + alias.Name + "}}",
+			Replacement: "{{" + alias.Target + "}}",
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package emit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRenderTemplateWithData_DeprecatedAlias_RendersAndWarnsInStrictMode
+// validates the variable alias registry.
+//
+// Why: Renaming a variable must not break templates that use the old name,
+// but users need a signal to migrate before the alias is removed.
+//
+// What: A deprecated alias renders its target's value; strict mode prints one
+// warning per alias naming the replacement, and no warning without it.
+func TestRenderTemplateWithData_DeprecatedAlias_RendersAndWarnsInStrictMode(t *testing.T) {
+	// Precondition: Template data with an author, strict mode writing to a buffer
+	data := TemplateData{CommitAuthor: "Ada", CommitDate: "2024-01-15T10:00:00Z"}
+	var warnings bytes.Buffer
+	SetStrict(&warnings)
+	t.Cleanup(func() { SetStrict(nil) })
+
+	// Action: Render a template using a deprecated alias twice
+	result, err := RenderTemplateWithData("{{CommitUser}}/{{ CommitUser }}/{{CommitDate}}", data)
+
+	// Expected: Alias renders the target, one warning naming the replacement
+	if err != nil {
+		t.Fatalf("RenderTemplateWithData failed: %v", err)
+	}
+	if result != "Ada/Ada/2024-01-15T10:00:00Z" {
+		t.Errorf("expected alias to render target value, got %q", result)
+	}
+	if got := strings.Count(warnings.String(), WarnDeprecatedVariable); got != 1 {
+		t.Errorf("expected one warning, got %d: %q", got, warnings.String())
+	}
+	if !strings.Contains(warnings.String(), "{{CommitAuthor}}") {
+		t.Errorf("expected warning to name the replacement, got %q", warnings.String())
+	}
+
+	// Action: Render outside strict mode
+	SetStrict(nil)
+	warnings.Reset()
+	_, err = RenderTemplateWithData("{{CommitUser}}", data)
+
+	// Expected: No warning
+	if err != nil || warnings.Len() != 0 {
+		t.Errorf("expected silent render outside strict mode, got %q (%v)", warnings.String(), err)
+	}
+}
+
+// TestTemplateDataToStringMap_CustomVariable_OverridesAlias validates alias
+// precedence.
+//
+// Why: Custom variables are documented to override built-ins; an alias must
+// not shadow a user's own variable of the same name.
+//
+// What: Aliases appear in the string map, and a custom variable with an
+// alias's name wins.
+func TestTemplateDataToStringMap_CustomVariable_OverridesAlias(t *testing.T) {
+	// Precondition: Custom variable named like an alias
+	data := TemplateData{
+		CommitAuthor:      "Ada",
+		CommitAuthorEmail: "ada@example.com",
+		Custom:            map[string]string{"CommitUser": "release-bot"},
+	}
+
+	// Action: Build the string map
+	m := TemplateDataToStringMap(data)
+
+	// Expected: Custom wins, other aliases resolve to their targets
+	if m["CommitUser"] != "release-bot" {
+		t.Errorf("expected custom CommitUser, got %q", m["CommitUser"])
+	}
+	if m["CommitUserEmail"] != "ada@example.com" {
+		t.Errorf("expected CommitUserEmail alias, got %q", m["CommitUserEmail"])
+	}
+}
@@ -0,0 +1,91 @@
+package emit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"sync"
+)
+
+// DataSnapshot is the resolved template data captured by `versionator
+// snapshot` on a machine with repository access, for replay by later build
+// stages without one (e.g. a Docker build without .git)
+type DataSnapshot struct {
+	// Version is the full version string, with prefix, pre-release, and metadata
+	Version string `json:"version"`
+	// Data is the template data every command renders from
+	Data TemplateData `json:"data"`
+}
+
+var (
+	frozenMu sync.RWMutex
+	frozen   *DataSnapshot
+)
+
+// UseDataSnapshot makes BuildTemplateDataFromVersion return the captured data
+// instead of querying the VCS and the clock; nil restores live data
+func UseDataSnapshot(s *DataSnapshot) {
+	frozenMu.Lock()
+	defer frozenMu.Unlock()
+	frozen = s
+}
+
+// frozenData returns a copy of the active snapshot's data
+func frozenData() (TemplateData, bool) {
+	frozenMu.RLock()
+	defer frozenMu.RUnlock()
+	if frozen == nil {
+		return TemplateData{}, false
+	}
+	data := frozen.Data
+	data.Custom = maps.Clone(data.Custom)
+	data.PluginVariables = maps.Clone(data.PluginVariables)
+	return data, true
+}
+
+// WriteDataSnapshot writes s as indented JSON
+func WriteDataSnapshot(w io.Writer, s *DataSnapshot) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// ReadDataSnapshot reads a snapshot written by WriteDataSnapshot
+func ReadDataSnapshot(path string) (*DataSnapshot, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var s DataSnapshot
+	if err := json.Unmarshal(content, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	if s.Version == "" {
+		return nil, fmt.Errorf("snapshot %s has no version", path)
+	}
+	return &s, nil
+}
+
+// ReadTemplateData reads template data from a JSON file: a snapshot written
+// by `versionator snapshot`, or the bare data object (field names as in
+// `vars`, custom variables under "Custom")
+func ReadTemplateData(path string) (TemplateData, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return TemplateData{}, fmt.Errorf("failed to read template data: %w", err)
+	}
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(content, &probe); err != nil {
+		return TemplateData{}, fmt.Errorf("failed to parse template data %s: %w", path, err)
+	}
+	var data TemplateData
+	if raw, ok := probe["data"]; ok {
+		content = raw
+	}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return TemplateData{}, fmt.Errorf("failed to parse template data %s: %w", path, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,127 @@
+package emit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/version"
+)
+
+// TestDataSnapshot_RoundTrip_ReplacesLiveData validates snapshot replay.
+//
+// Why: Build stages without repository access (e.g. Docker builds without
+// .git) must render exactly the versions computed where the VCS was
+// available.
+//
+// What: A written snapshot reads back unchanged; while in use,
+// BuildTemplateDataFromVersion returns its data regardless of the version
+// passed, and clearing it restores live data.
+func TestDataSnapshot_RoundTrip_ReplacesLiveData(t *testing.T) {
+	// Precondition: Snapshot of resolved data with VCS fields
+	snapshot := &DataSnapshot{
+		Version: "v1.2.3-rc.1",
+		Data: TemplateData{
+			Major: "1", Minor: "2", Patch: "3", MajorMinorPatch: "1.2.3", Prefix: "v",
+			ShortHash: "abc1234", BranchName: "main", CommitsSinceTag: "4",
+			BuildDateTimeCompact: "20240115103045",
+			Custom:               map[string]string{"Team": "core"},
+		},
+	}
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	var buf bytes.Buffer
+	if err := WriteDataSnapshot(&buf, snapshot); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action: Read it back and use it
+	read, err := ReadDataSnapshot(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	UseDataSnapshot(read)
+	defer UseDataSnapshot(nil)
+	data := BuildTemplateDataFromVersion(&version.Version{Major: 9})
+	data.Custom["Team"] = "changed"
+	again := BuildTemplateDataFromVersion(&version.Version{Major: 9})
+	UseDataSnapshot(nil)
+	live := BuildTemplateDataFromVersion(&version.Version{Major: 9})
+
+	// Expected: Captured data, isolated copies, then live data again
+	if read.Version != "v1.2.3-rc.1" {
+		t.Errorf("Version = %q", read.Version)
+	}
+	if data.MajorMinorPatch != "1.2.3" || data.ShortHash != "abc1234" || data.BuildDateTimeCompact != "20240115103045" {
+		t.Errorf("unexpected snapshot data: %+v", data)
+	}
+	if again.Custom["Team"] != "core" {
+		t.Errorf("snapshot data was mutated through a copy: %q", again.Custom["Team"])
+	}
+	if live.Major != "9" {
+		t.Errorf("expected live data after clearing, got Major %q", live.Major)
+	}
+}
+
+// TestReadDataSnapshot_MissingVersion_ReturnsError validates that incomplete
+// snapshots are rejected.
+//
+// Why: Replaying a snapshot without a version would silently render 0.0.0.
+//
+// What: A JSON document without "version" fails to load.
+func TestReadDataSnapshot_MissingVersion_ReturnsError(t *testing.T) {
+	// Precondition: Snapshot file without a version
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := os.WriteFile(path, []byte(`{"data": {"Major": "1"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action: Read it
+	_, err := ReadDataSnapshot(path)
+
+	// Expected: Error
+	if err == nil {
+		t.Error("expected error for snapshot without version")
+	}
+}
+
+// TestReadTemplateData_SnapshotOrBareData validates template data files.
+//
+// Why: `render` tests templates against hand-written data as well as
+// captured snapshots, so both shapes must load.
+//
+// What: A snapshot yields its data; a bare object yields its fields and
+// custom variables.
+func TestReadTemplateData_SnapshotOrBareData(t *testing.T) {
+	// Precondition: A snapshot file and a bare data file
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "snapshot.json")
+	barePath := filepath.Join(dir, "data.json")
+	if err := os.WriteFile(snapshotPath, []byte(`{"version": "1.2.3", "data": {"Major": "1", "ShortHash": "abc1234"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(barePath, []byte(`{"MajorMinorPatch": "2.0.0", "Custom": {"Team": "core"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action: Read both
+	fromSnapshot, err := ReadTemplateData(snapshotPath)
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	bare, err := ReadTemplateData(barePath)
+	if err != nil {
+		t.Fatalf("bare: %v", err)
+	}
+
+	// Expected: Fields from each shape
+	if fromSnapshot.Major != "1" || fromSnapshot.ShortHash != "abc1234" {
+		t.Errorf("unexpected snapshot data: %+v", fromSnapshot)
+	}
+	if bare.MajorMinorPatch != "2.0.0" || bare.Custom["Team"] != "core" {
+		t.Errorf("unexpected bare data: %+v", bare)
+	}
+}
@@ -0,0 +1,49 @@
+package emit
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/benjaminabbitt/versionator/internal/aggregate"
+)
+
+// componentReferencePattern matches {{Components.<name>...}} in any tag form
+var componentReferencePattern = regexp.MustCompile(`\{\{\{?\s*[#^&/]?\s*Components\.`)
+
+var (
+	componentsMu     sync.RWMutex
+	componentSources []aggregate.Source
+)
+
+// SetComponents sets the components {{Components.<name>.Version}} reads
+// (aggregate.components); nil configures none
+func SetComponents(sources []aggregate.Source) {
+	componentsMu.Lock()
+	defer componentsMu.Unlock()
+	componentSources = sources
+}
+
+// referencesComponents reports whether any template reads {{Components}}
+func referencesComponents(templates ...string) bool {
+	for _, tmpl := range templates {
+		if componentReferencePattern.MatchString(tmpl) {
+			return true
+		}
+	}
+	return false
+}
+
+// componentValues collects the configured component versions, keyed by
+// component then variable; VERSION files and tags are only read when a
+// template references them
+func componentValues() (map[string]map[string]string, error) {
+	componentsMu.RLock()
+	sources := componentSources
+	componentsMu.RUnlock()
+
+	components, err := aggregate.CollectActive(sources)
+	if err != nil {
+		return nil, err
+	}
+	return aggregate.Values(components), nil
+}
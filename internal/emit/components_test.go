@@ -0,0 +1,42 @@
+package emit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/aggregate"
+)
+
+// TestRenderTemplateWithData_ComponentsNamespace_ReadsComponentVersions
+// validates {{Components.*}}.
+//
+// Why: Umbrella build files pin the version of every component; reading
+// them from aggregate.components keeps the pins in step with the components.
+//
+// What: A referenced component resolves from its VERSION file, both in the
+// template and in a custom variable built on it.
+func TestRenderTemplateWithData_ComponentsNamespace_ReadsComponentVersions(t *testing.T) {
+	// Precondition: api/VERSION in a directory outside any repository
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll("api", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("api", "VERSION"), []byte("2.3.4-beta.1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	SetComponents([]aggregate.Source{{Name: "api", Path: "api"}})
+	t.Cleanup(func() { SetComponents(nil) })
+	data := TemplateData{Custom: map[string]string{"ApiImage": "api:{{Components.api.Version}}"}}
+
+	// Action
+	result, err := RenderTemplateWithData("{{Components.api.Major}}.{{Components.api.Minor}} {{Components.api.PreRelease}} {{ApiImage}}", data)
+
+	// Expected
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if want := "2.3 beta.1 api:2.3.4-beta.1"; result != want {
+		t.Errorf("got %q, want %q", result, want)
+	}
+}
@@ -0,0 +1,79 @@
+package emit
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/cbroglie/mustache"
+)
+
+// resolveComputedCustom renders custom variables whose values are templates
+// (e.g. Channel: "{{#Dirty}}dev{{/Dirty}}{{^Dirty}}prod{{/Dirty}}") against
+// m, replacing the raw value. Computed variables may reference each other;
+// references are resolved first, and cycles are reported as errors.
+func resolveComputedCustom(m map[string]interface{}, custom map[string]string) error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+
+	var resolve func(name string, chain []string) error
+	resolve = func(name string, chain []string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("%s: %s", ErrCustomVariableCycle, strings.Join(append(chain, name), " -> "))
+		}
+
+		raw := custom[name]
+		// Plugin variables override custom ones of the same name; only
+		// values still holding the raw custom template are computed
+		if current, ok := m[name].(string); !ok || current != raw || !strings.Contains(raw, "{{") {
+			state[name] = done
+			return nil
+		}
+
+		state[name] = visiting
+		chain = append(chain, name)
+		for _, match := range templateTagPattern.FindAllStringSubmatch(raw, -1) {
+			if _, isCustom := custom[match[1]]; isCustom {
+				if err := resolve(match[1], chain); err != nil {
+					return err
+				}
+			}
+		}
+
+		rendered, err := mustache.RenderRaw(raw, true, m)
+		if err != nil {
+			return fmt.Errorf("failed to render custom variable %s: %w", name, err)
+		}
+		m[name] = rendered
+		state[name] = done
+		return nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(custom)) {
+		if err := resolve(name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResolveCustomVars returns data's custom variables with computed values
+// rendered, as templates see them
+func ResolveCustomVars(data TemplateData) (map[string]string, error) {
+	m, err := NewRenderer(data).variables()
+	if err != nil {
+		return nil, err
+	}
+	resolved := make(map[string]string, len(data.Custom))
+	for name := range data.Custom {
+		resolved[name] = fmt.Sprint(m[name])
+	}
+	return resolved, nil
+}
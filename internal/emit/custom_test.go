@@ -0,0 +1,62 @@
+package emit
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRenderTemplateWithData_ComputedCustom_RendersAgainstBuiltins validates
+// computed custom variables.
+//
+// Why: Derived values such as a release channel are reused across many
+// templates; declaring them once in config avoids repeating the logic.
+//
+// What: A custom value containing a template is rendered against built-in
+// data, and may build on another computed custom variable.
+func TestRenderTemplateWithData_ComputedCustom_RendersAgainstBuiltins(t *testing.T) {
+	// Precondition: Dirty build with chained computed variables
+	data := TemplateData{
+		MajorMinorPatch: "1.2.3",
+		Dirty:           "dirty",
+		Custom: map[string]string{
+			"Channel":  "{{#Dirty}}dev{{/Dirty}}{{^Dirty}}prod{{/Dirty}}",
+			"Artifact": "app-{{MajorMinorPatch}}-{{Channel}}",
+			"Team":     "core",
+		},
+	}
+
+	// Action: Render a template using the computed variable
+	result, err := RenderTemplateWithData("{{Artifact}} {{Team}}", data)
+
+	// Expected: Both levels rendered, plain values untouched
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if result != "app-1.2.3-dev core" {
+		t.Errorf("got %q, want %q", result, "app-1.2.3-dev core")
+	}
+}
+
+// TestRenderTemplateWithData_CustomCycle_ReturnsError validates cycle detection.
+//
+// Why: Mutually referencing variables have no value; silently rendering a
+// partial result would ship a wrong version string.
+//
+// What: A cycle between custom variables fails rendering with an error that
+// names the chain.
+func TestRenderTemplateWithData_CustomCycle_ReturnsError(t *testing.T) {
+	// Precondition: A and B reference each other
+	data := TemplateData{Custom: map[string]string{
+		"A": "{{B}}-a",
+		"B": "{{A}}-b",
+	}}
+
+	// Action: Render
+	_, err := RenderTemplateWithData("{{A}}", data)
+
+	// Expected: Cycle error naming the chain
+	if err == nil || !strings.Contains(err.Error(), ErrCustomVariableCycle) ||
+		!strings.Contains(err.Error(), "A -> B -> A") {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package emit
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	datesMu           sync.RWMutex
+	buildDateFormats  map[string]string
+	commitDateFormats map[string]string
+	dateLocation      = time.UTC
+)
+
+// SetDateFormats sets the extra date variables (dates in .versionator.yaml):
+// each maps a variable name to a Go time layout applied to the build or
+// commit time in loc. A nil loc is UTC.
+func SetDateFormats(build, commit map[string]string, loc *time.Location) {
+	datesMu.Lock()
+	defer datesMu.Unlock()
+	if loc == nil {
+		loc = time.UTC
+	}
+	buildDateFormats, commitDateFormats, dateLocation = build, commit, loc
+}
+
+// customDates formats buildTime and commitTime with the configured layouts.
+// Commit variables are empty when the commit time is unknown, like
+// {{CommitDate}}.
+func customDates(buildTime, commitTime time.Time) map[string]string {
+	datesMu.RLock()
+	defer datesMu.RUnlock()
+
+	if len(buildDateFormats) == 0 && len(commitDateFormats) == 0 {
+		return nil
+	}
+	dates := make(map[string]string, len(buildDateFormats)+len(commitDateFormats))
+	for name, layout := range buildDateFormats {
+		dates[name] = buildTime.In(dateLocation).Format(layout)
+	}
+	for name, layout := range commitDateFormats {
+		if commitTime.IsZero() {
+			dates[name] = ""
+			continue
+		}
+		dates[name] = commitTime.In(dateLocation).Format(layout)
+	}
+	return dates
+}
@@ -0,0 +1,48 @@
+package emit
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCustomDates_ConfiguredLayouts_FormatBuildAndCommitTime validates the
+// configured date variables.
+//
+// Why: Teams stamp versions with dates in house formats (2024.01.15, week
+// numbers, local time) that the fixed built-in variables do not cover.
+//
+// What: Build and commit layouts format their own time in the configured
+// zone, render through templates, and commit variables are empty when the
+// commit time is unknown.
+func TestCustomDates_ConfiguredLayouts_FormatBuildAndCommitTime(t *testing.T) {
+	// Precondition: One build and one commit format in UTC+1
+	berlin := time.FixedZone("CET", 60*60)
+	SetDateFormats(map[string]string{"BuildDateDotted": "2006.01.02"}, map[string]string{"CommitStamp": "02 Jan 15:04"}, berlin)
+	t.Cleanup(func() { SetDateFormats(nil, nil, nil) })
+	build := time.Date(2024, 1, 15, 23, 30, 0, 0, time.UTC)
+	commit := time.Date(2024, 1, 14, 9, 5, 0, 0, time.UTC)
+
+	// Action: Format both times
+	dates := customDates(build, commit)
+
+	// Expected: Each layout applied to its own time, in the zone
+	if dates["BuildDateDotted"] != "2024.01.16" || dates["CommitStamp"] != "14 Jan 10:05" {
+		t.Errorf("unexpected dates: %v", dates)
+	}
+
+	// Action: Render through a template
+	out, err := RenderTemplateWithData("{{BuildDateDotted}}/{{CommitStamp}}", TemplateData{Dates: dates})
+
+	// Expected: Variables resolve like built-ins
+	if err != nil || out != "2024.01.16/14 Jan 10:05" {
+		t.Errorf("got %q, %v", out, err)
+	}
+
+	// Action: No commit time (outside a repository)
+	dates = customDates(build, time.Time{})
+
+	// Expected: Commit variable empty, build variable set
+	if v, ok := dates["CommitStamp"]; !ok || v != "" || dates["BuildDateDotted"] == "" {
+		t.Errorf("unexpected dates without commit: %v", dates)
+	}
+}
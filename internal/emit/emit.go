@@ -4,14 +4,15 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/cbroglie/mustache"
-
-	"github.com/benjaminabbitt/versionator/internal/plugin"
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+	"github.com/benjaminabbitt/versionator/internal/history"
 	"github.com/benjaminabbitt/versionator/internal/vcs"
 	"github.com/benjaminabbitt/versionator/internal/version"
 )
@@ -27,8 +28,10 @@ const (
 	FormatJSON      Format = "json"
 	FormatYAML      Format = "yaml"
 	FormatGo        Format = "go"
+	FormatGoHTTP    Format = "go-http"
 	FormatC         Format = "c"
 	FormatCHeader   Format = "c-header"
+	FormatCFirmware Format = "c-firmware" // C header with integer/BCD version registers
 	FormatCPP       Format = "cpp"
 	FormatCPPHeader Format = "cpp-header"
 	FormatJS        Format = "js"
@@ -41,6 +44,7 @@ const (
 	FormatRuby      Format = "ruby"
 	FormatRust      Format = "rust"
 	FormatDart      Format = "dart"
+	FormatBadge     Format = "badge" // shields.io endpoint JSON
 )
 
 // templateFiles maps formats to their template file names
@@ -50,8 +54,10 @@ var templateFiles = map[Format]string{
 	FormatJSON:      "templates/json.tmpl",
 	FormatYAML:      "templates/yaml.tmpl",
 	FormatGo:        "templates/go.tmpl",
+	FormatGoHTTP:    "templates/go-http.tmpl",
 	FormatC:         "templates/c.tmpl",
 	FormatCHeader:   "templates/c-header.tmpl",
+	FormatCFirmware: "templates/c-firmware.tmpl",
 	FormatCPP:       "templates/cpp.tmpl",
 	FormatCPPHeader: "templates/cpp-header.tmpl",
 	FormatJS:        "templates/js.tmpl",
@@ -64,6 +70,7 @@ var templateFiles = map[Format]string{
 	FormatRuby:      "templates/ruby.tmpl",
 	FormatRust:      "templates/rust.tmpl",
 	FormatDart:      "templates/dart.tmpl",
+	FormatBadge:     "templates/badge.tmpl",
 }
 
 // TemplateData holds the data passed to templates
@@ -77,6 +84,20 @@ type TemplateData struct {
 	MajorMinor      string // Major.Minor (e.g., "1.2")
 	Prefix          string // Version prefix (e.g., "v")
 
+	// Version arithmetic, for upgrade notes and compatibility ranges
+	// (e.g., ">={{MajorMinorPatch}} <{{NextMajor}}")
+	MajorPlusOne string // Major + 1 (e.g., "2")
+	MinorPlusOne string // Minor + 1 (e.g., "3")
+	PatchPlusOne string // Patch + 1 (e.g., "4")
+	NextMajor    string // Next major version (e.g., "2.0.0")
+	NextMinor    string // Next minor version (e.g., "1.3.0")
+	NextPatch    string // Next patch version (e.g., "1.2.4")
+
+	// Numeric encodings, for firmware and bootloader version registers
+	VersionInt string // Major*10000 + Minor*100 + Patch (e.g., "10203"); empty when a component is 100 or more
+	VersionHex string // One byte per component, 0x00MMmmpp (e.g., "0x01020C" for 1.2.12); empty when a component is 256 or more
+	VersionBCD string // Two BCD digits per component (e.g., "0x010212" for 1.2.12); empty when a component is 100 or more
+
 	// Rendered pre-release (from template config, dash-separated items)
 	PreRelease         string // Rendered pre-release (e.g., "alpha-5")
 	PreReleaseWithDash string // With leading dash (e.g., "-alpha-5")
@@ -88,7 +109,7 @@ type TemplateData struct {
 	MetadataWithPlus string // With leading plus (e.g., "+20241211103045.4846bcd2e133")
 
 	// VCS/Git info
-	Hash               string // Full commit hash (40 chars for git)
+	Hash               string // Full commit hash (40 chars for SHA-1 git, 64 for SHA-256)
 	ShortHash          string // Short commit hash (7 chars)
 	MediumHash         string // Medium commit hash (12 chars)
 	BranchName         string // Current branch name (e.g., "feature/foo")
@@ -98,12 +119,28 @@ type TemplateData struct {
 	BuildNumberPadded  string // Padded commits since tag, 4 digits (e.g., "0012")
 	UncommittedChanges string // Count of uncommitted changes (e.g., "3")
 	Dirty              string // "dirty" if uncommitted changes > 0, empty otherwise
+	IsReleaseBuild     string // "true" if HEAD is exactly at a version tag and the tree is clean, empty otherwise
 	VersionSourceHash  string // Hash of the commit the last tag points to
+	HashAlgorithm      string // Identifier algorithm: "sha1", "sha256", or "revision"
+	PreviousVersion    string // Highest tagged version strictly lower than this one (e.g., "1.2.2")
+	PreviousTag        string // Tag of PreviousVersion (e.g., "v1.2.2")
+
+	// Distance to the default branch (empty when it cannot be determined)
+	CommitsAheadOfDefault string // Commits on HEAD not on the default branch (e.g., "7")
+	CommitsBehindDefault  string // Commits on the default branch not on HEAD (e.g., "3")
+
+	// Branch inferred for a detached HEAD (BranchName when on a branch)
+	InferredBranchName       string // Most likely branch (e.g., "main")
+	InferredBranchConfidence string // "exact", "high", "medium", "low", or "none"
 
 	// Commit author info
 	CommitAuthor      string // Name of the commit author
 	CommitAuthorEmail string // Email of the commit author
 
+	// VERSION file history (from the last commit that modified VERSION)
+	VersionFileLastChangedBy   string // Author name of that commit
+	VersionFileLastChangedDate string // Author date of that commit, ISO 8601: 2024-01-15T10:30:00Z
+
 	// Commit timestamps (all in UTC)
 	CommitDate        string // ISO 8601 format: 2024-01-15T10:30:00Z
 	CommitDateCompact string // Compact format: 20240115103045 (YYYYMMDDHHmmss)
@@ -130,6 +167,22 @@ type TemplateData struct {
 	// Example dirty:  ".20240115103045"
 	DateTimeDirty string
 
+	// Build host, empty unless enabled with env.host (see SetHostVariables)
+	GoVersion string // Go toolchain: go1.22.1
+	OS        string // runtime.GOOS: linux
+	Arch      string // runtime.GOARCH: amd64
+	Hostname  string // build machine name
+	NumCPU    string // logical CPUs: 8
+
+	// Obfuscated version for `emit --obfuscate` (see Obfuscate), as
+	// comma-separated byte values; empty unless obfuscation was requested
+	ObfuscatedVersion string
+	ObfuscationKey    string
+
+	// Dates holds the date variables defined under dates in
+	// .versionator.yaml (see SetDateFormats)
+	Dates map[string]string
+
 	// Custom holds arbitrary key-value pairs from config and --set flags
 	Custom map[string]string
 
@@ -144,8 +197,10 @@ func SupportedFormats() []string {
 		string(FormatJSON),
 		string(FormatYAML),
 		string(FormatGo),
+		string(FormatGoHTTP),
 		string(FormatC),
 		string(FormatCHeader),
+		string(FormatCFirmware),
 		string(FormatCPP),
 		string(FormatCPPHeader),
 		string(FormatJS),
@@ -158,6 +213,7 @@ func SupportedFormats() []string {
 		string(FormatRuby),
 		string(FormatRust),
 		string(FormatDart),
+		string(FormatBadge),
 	}
 }
 
@@ -200,6 +256,16 @@ func dirtyFlag(uncommittedChanges int) string {
 	return ""
 }
 
+// releaseBuildFlag returns "true" when HEAD is exactly at a version tag and
+// the tree is clean, empty otherwise, so templates can branch on it with
+// {{#IsReleaseBuild}}...{{/IsReleaseBuild}} and {{^IsReleaseBuild}}
+func releaseBuildFlag(commitsSinceTag, uncommittedChanges int) string {
+	if commitsSinceTag == 0 && uncommittedChanges == 0 {
+		return "true"
+	}
+	return ""
+}
+
 // dateTimeDirtyFlag returns "." + compactDateTime when the tree is dirty,
 // empty string when clean. Concentrates the compose-with-dot pattern in one
 // place so callers don't have to coordinate the separator with the value.
@@ -228,8 +294,16 @@ type VCSInfo struct {
 	CommitsSinceTag    int
 	UncommittedChanges int
 	VersionSourceHash  string
+	HashAlgorithm      string
 	CommitAuthor       string
 	CommitAuthorEmail  string
+	VersionFileChange  vcs.FileChange
+	TagNames           []string
+	// Commits HEAD is ahead of and behind the default branch; -1 when unknown
+	CommitsAheadOfDefault int
+	CommitsBehindDefault  int
+	// Branch inferred for a detached HEAD
+	InferredBranch vcs.InferredBranch
 }
 
 // formattedVCSFields holds pre-formatted VCS fields for template rendering
@@ -238,12 +312,16 @@ type formattedVCSFields struct {
 	BuildNumberPadded  string
 	UncommittedChanges string
 	Dirty              string
+	IsReleaseBuild     string
 	CommitDate         string
 	CommitDateCompact  string
 	CommitDateShort    string
 	CommitYear         string
 	CommitMonth        string
 	CommitDay          string
+
+	CommitsAheadOfDefault string
+	CommitsBehindDefault  string
 }
 
 // formatVCSFields converts VCSInfo to formatted string fields for templates
@@ -251,6 +329,7 @@ func formatVCSFields(info VCSInfo) formattedVCSFields {
 	f := formattedVCSFields{
 		UncommittedChanges: strconv.Itoa(info.UncommittedChanges),
 		Dirty:              dirtyFlag(info.UncommittedChanges),
+		IsReleaseBuild:     releaseBuildFlag(info.CommitsSinceTag, info.UncommittedChanges),
 	}
 
 	// Format commits since tag
@@ -259,6 +338,12 @@ func formatVCSFields(info VCSInfo) formattedVCSFields {
 		f.BuildNumberPadded = fmt.Sprintf("%04d", info.CommitsSinceTag)
 	}
 
+	// Format distance to the default branch
+	if info.CommitsAheadOfDefault >= 0 {
+		f.CommitsAheadOfDefault = strconv.Itoa(info.CommitsAheadOfDefault)
+		f.CommitsBehindDefault = strconv.Itoa(info.CommitsBehindDefault)
+	}
+
 	// Format commit date fields
 	if !info.CommitDate.IsZero() {
 		f.CommitDate = info.CommitDate.Format(time.RFC3339)
@@ -280,6 +365,8 @@ type formattedBuildTime struct {
 	Year        string
 	Month       string
 	Day         string
+	// Time is the build time itself, for the configured date formats
+	Time time.Time
 }
 
 // formatBuildTime creates formatted build time fields from current UTC time
@@ -292,21 +379,26 @@ func formatBuildTime() formattedBuildTime {
 		Year:        now.Format("2006"),
 		Month:       now.Format("01"),
 		Day:         now.Format("02"),
+		Time:        now,
 	}
 }
 
 // getVCSInfo retrieves all VCS information sequentially
 // Returns empty/zero values if not in a VCS repository
 func getVCSInfo() VCSInfo {
-	info := VCSInfo{CommitsSinceTag: -1} // -1 indicates no tags
+	// -1 indicates no tags / no default branch
+	info := VCSInfo{CommitsSinceTag: -1, CommitsAheadOfDefault: -1, CommitsBehindDefault: -1}
 
 	activeVCS := vcs.GetActiveVCS()
 	if activeVCS == nil {
+		info = hostedVCSInfo(info)
+		info.InferredBranch = inferBranch(info.BranchName, nil)
 		return info
 	}
 
 	// Get identifiers (all from same commit, but different lengths)
-	if id, err := activeVCS.GetVCSIdentifier(40); err == nil {
+	info.HashAlgorithm = vcs.HashAlgorithm(activeVCS)
+	if id, err := activeVCS.GetVCSIdentifier(vcs.MaxIdentifierLength(activeVCS)); err == nil {
 		info.Identifier = id
 		info.IdentifierShort = id[:min(7, len(id))]
 		info.IdentifierMedium = id[:min(12, len(id))]
@@ -316,6 +408,7 @@ func getVCSInfo() VCSInfo {
 	if branch, err := activeVCS.GetBranchName(); err == nil {
 		info.BranchName = branch
 	}
+	info.InferredBranch = inferBranch(info.BranchName, activeVCS)
 
 	// Get commit date
 	if date, err := activeVCS.GetCommitDate(); err == nil {
@@ -332,9 +425,12 @@ func getVCSInfo() VCSInfo {
 		info.VersionSourceHash = hash
 	}
 
-	// Get uncommitted changes count
-	if count, err := activeVCS.GetUncommittedChanges(); err == nil {
-		info.UncommittedChanges = count
+	// Get uncommitted changes count, unless the check is turned off for
+	// large checkouts
+	if vcs.DirtyCheck() != vcs.DirtyCheckOff {
+		if count, err := activeVCS.GetUncommittedChanges(); err == nil {
+			info.UncommittedChanges = count
+		}
 	}
 
 	// Get commit author info
@@ -345,9 +441,112 @@ func getVCSInfo() VCSInfo {
 		info.CommitAuthorEmail = email
 	}
 
+	// Get the last change to VERSION
+	if history, ok := activeVCS.(vcs.FileHistory); ok {
+		if change, err := history.LastChange(versionFileRelPath(activeVCS)); err == nil {
+			info.VersionFileChange = change
+		}
+	}
+
+	info.TagNames = tagNames(activeVCS)
+
+	// Get the distance to the default branch
+	if distance, ok := activeVCS.(vcs.BranchDistance); ok {
+		info.CommitsAheadOfDefault, info.CommitsBehindDefault = defaultBranchDistance(distance)
+	}
+
 	return info
 }
 
+// inferBranch returns branch as exact, or infers one for a detached HEAD
+// (or no repository) from CI variables, the refs, and the reflog
+func inferBranch(branch string, activeVCS vcs.VersionControlSystem) vcs.InferredBranch {
+	if branch != "" {
+		return vcs.InferredBranch{Name: branch, Confidence: vcs.BranchConfidenceExact}
+	}
+	return vcs.InferBranch(activeVCS)
+}
+
+// defaultBranchDistance counts the commits HEAD is ahead of and behind the
+// configured default branch, or the remote's default branch when none is
+// configured. Returns -1, -1 when either cannot be determined.
+func defaultBranchDistance(distance vcs.BranchDistance) (ahead, behind int) {
+	base := vcs.DefaultBranch()
+	if base == "" {
+		detected, err := distance.RemoteDefaultBranch()
+		if err != nil {
+			return -1, -1
+		}
+		base = detected
+	}
+	ahead, behind, err := distance.CommitsAheadBehind(base)
+	if err != nil {
+		return -1, -1
+	}
+	return ahead, behind
+}
+
+// tagNames lists every tag in the repository, preferring the cheap
+// TagNameLister over a full TagLister walk. Returns nil when the VCS
+// can list neither.
+func tagNames(activeVCS vcs.VersionControlSystem) []string {
+	if lister, ok := activeVCS.(vcs.TagNameLister); ok {
+		if names, err := lister.ListTagNames(); err == nil {
+			return names
+		}
+		return nil
+	}
+	if lister, ok := activeVCS.(vcs.TagLister); ok {
+		if tags, err := lister.ListTags(); err == nil {
+			names := make([]string, len(tags))
+			for i, t := range tags {
+				names[i] = t.Name
+			}
+			return names
+		}
+	}
+	return nil
+}
+
+// setPreviousRelease sets PreviousVersion and PreviousTag from the highest
+// tag strictly lower than v; both stay empty when no tag precedes it
+func setPreviousRelease(data *TemplateData, v *version.Version, tags []string) {
+	refs := make([]vcs.TagRef, len(tags))
+	for i, name := range tags {
+		refs[i] = vcs.TagRef{Name: name}
+	}
+	if previous := history.Previous(history.Build(refs), v); previous != nil {
+		data.PreviousVersion = previous.Version
+		data.PreviousTag = previous.Tag
+	}
+}
+
+// versionFileRelPath returns the VERSION file's path relative to the
+// repository root, falling back to VERSION at the root
+func versionFileRelPath(activeVCS vcs.VersionControlSystem) string {
+	path, err := version.Path()
+	if err != nil {
+		return "VERSION"
+	}
+	root, err := activeVCS.GetRepositoryRoot()
+	if err != nil {
+		return "VERSION"
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "VERSION"
+	}
+	return rel
+}
+
+// formatRFC3339 formats t as ISO 8601, or empty for the zero time
+func formatRFC3339(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
 // RenderTemplate renders a custom Mustache template with the given version
 func RenderTemplate(tmplStr string, versionStr string) (string, error) {
 	// Parse the version
@@ -363,7 +562,7 @@ func RenderTemplate(tmplStr string, versionStr string) (string, error) {
 		Major:           sv.MajorString(),
 		Minor:           sv.MinorString(),
 		Patch:           sv.PatchString(),
-		MajorMinorPatch: sv.String(),
+		MajorMinorPatch: sv.CoreVersion(),
 		MajorMinor:      sv.MajorMinor(),
 		Prefix:          sv.Prefix,
 
@@ -382,12 +581,26 @@ func RenderTemplate(tmplStr string, versionStr string) (string, error) {
 		BuildNumberPadded:  vcsFields.BuildNumberPadded,
 		UncommittedChanges: vcsFields.UncommittedChanges,
 		Dirty:              vcsFields.Dirty,
+		IsReleaseBuild:     vcsFields.IsReleaseBuild,
 		VersionSourceHash:  vcsInfo.VersionSourceHash,
+		HashAlgorithm:      vcsInfo.HashAlgorithm,
+
+		// Distance to the default branch
+		CommitsAheadOfDefault: vcsFields.CommitsAheadOfDefault,
+		CommitsBehindDefault:  vcsFields.CommitsBehindDefault,
+
+		// Branch inferred for a detached HEAD
+		InferredBranchName:       vcsInfo.InferredBranch.Name,
+		InferredBranchConfidence: vcsInfo.InferredBranch.Confidence,
 
 		// Commit author info
 		CommitAuthor:      vcsInfo.CommitAuthor,
 		CommitAuthorEmail: vcsInfo.CommitAuthorEmail,
 
+		// VERSION file history
+		VersionFileLastChangedBy:   vcsInfo.VersionFileChange.Author,
+		VersionFileLastChangedDate: formatRFC3339(vcsInfo.VersionFileChange.Date),
+
 		// Commit timestamps
 		CommitDate:        vcsFields.CommitDate,
 		CommitDateCompact: vcsFields.CommitDateCompact,
@@ -405,14 +618,18 @@ func RenderTemplate(tmplStr string, versionStr string) (string, error) {
 		BuildDay:             buildTime.Day,
 
 		DateTimeDirty: dateTimeDirtyFlag(vcsInfo.UncommittedChanges, buildTime.DateCompact),
-	}
 
-	result, err := mustache.Render(tmplStr, data)
-	if err != nil {
-		return "", fmt.Errorf("failed to render template: %w", err)
+		Dates: customDates(buildTime.Time, vcsInfo.CommitDate),
 	}
-
-	return result, nil
+	setPreRelease(&data, sv.PreRelease, "-")
+	setMetadata(&data, sv.BuildMetadata)
+	setComputedVersionFields(&data, &sv)
+	setPreviousRelease(&data, &sv, vcsInfo.TagNames)
+	setHostFields(&data)
+
+	// Through the Renderer, so plugin variables such as {{Pep440Version}}
+	// (used by the python format) are available
+	return RenderTemplateWithData(tmplStr, data)
 }
 
 // ValidateOutputPath checks if an output file path is valid
@@ -468,7 +685,7 @@ func WriteToFile(content, filepath string) error {
 	if err := ValidateOutputPath(filepath); err != nil {
 		return err
 	}
-	if err := os.WriteFile(filepath, []byte(content), FilePermission); err != nil {
+	if err := fileperm.WriteFile(filepath, []byte(content)); err != nil {
 		return fmt.Errorf("failed to write file %s: %w", filepath, err)
 	}
 	return nil
@@ -500,13 +717,19 @@ func GetEmbeddedTemplate(format Format) (string, error) {
 
 // BuildTemplateDataFromVersion creates TemplateData from Version
 // This allows rendering templates directly from VERSION data
+// While a DataSnapshot is in use, its data is returned unchanged
 func BuildTemplateDataFromVersion(v *version.Version) TemplateData {
+	// A snapshot (--from-snapshot) replaces all resolved data
+	if data, ok := frozenData(); ok {
+		return data
+	}
+
 	// Get VCS information and format fields
 	vcsInfo := getVCSInfo()
 	vcsFields := formatVCSFields(vcsInfo)
 	buildTime := formatBuildTime()
 
-	return TemplateData{
+	data := TemplateData{
 		// Version components
 		Major:           strconv.Itoa(v.Major),
 		Minor:           strconv.Itoa(v.Minor),
@@ -531,12 +754,26 @@ func BuildTemplateDataFromVersion(v *version.Version) TemplateData {
 		BuildNumberPadded:  vcsFields.BuildNumberPadded,
 		UncommittedChanges: vcsFields.UncommittedChanges,
 		Dirty:              vcsFields.Dirty,
+		IsReleaseBuild:     vcsFields.IsReleaseBuild,
 		VersionSourceHash:  vcsInfo.VersionSourceHash,
+		HashAlgorithm:      vcsInfo.HashAlgorithm,
+
+		// Distance to the default branch
+		CommitsAheadOfDefault: vcsFields.CommitsAheadOfDefault,
+		CommitsBehindDefault:  vcsFields.CommitsBehindDefault,
+
+		// Branch inferred for a detached HEAD
+		InferredBranchName:       vcsInfo.InferredBranch.Name,
+		InferredBranchConfidence: vcsInfo.InferredBranch.Confidence,
 
 		// Commit author info
 		CommitAuthor:      vcsInfo.CommitAuthor,
 		CommitAuthorEmail: vcsInfo.CommitAuthorEmail,
 
+		// VERSION file history
+		VersionFileLastChangedBy:   vcsInfo.VersionFileChange.Author,
+		VersionFileLastChangedDate: formatRFC3339(vcsInfo.VersionFileChange.Date),
+
 		// Commit timestamps
 		CommitDate:        vcsFields.CommitDate,
 		CommitDateCompact: vcsFields.CommitDateCompact,
@@ -554,23 +791,63 @@ func BuildTemplateDataFromVersion(v *version.Version) TemplateData {
 		BuildDay:             buildTime.Day,
 
 		DateTimeDirty: dateTimeDirtyFlag(vcsInfo.UncommittedChanges, buildTime.DateCompact),
+
+		Dates: customDates(buildTime.Time, vcsInfo.CommitDate),
+
+		// Structured VERSION file fields (config custom vars and --set
+		// override them when merged later)
+		Custom: maps.Clone(v.Fields),
 	}
+	setComputedVersionFields(&data, v)
+	setPreviousRelease(&data, v, vcsInfo.TagNames)
+	setHostFields(&data)
+	return data
 }
 
-// RenderTemplateWithData renders a Mustache template with TemplateData
-func RenderTemplateWithData(tmplStr string, data TemplateData) (string, error) {
-	// Convert to map to support custom variables
-	dataMap := templateDataToMap(data)
-	result, err := mustache.Render(tmplStr, dataMap)
-	if err != nil {
-		return "", fmt.Errorf("failed to render template: %w", err)
+// setComputedVersionFields sets the fields computed from v's numbers:
+// version arithmetic and numeric encodings
+func setComputedVersionFields(data *TemplateData, v *version.Version) {
+	data.MajorPlusOne = strconv.Itoa(v.Major + 1)
+	data.MinorPlusOne = strconv.Itoa(v.Minor + 1)
+	data.PatchPlusOne = strconv.Itoa(v.Patch + 1)
+	data.NextMajor = nextVersion(v, (*version.Version).IncrementMajor)
+	data.NextMinor = nextVersion(v, (*version.Version).IncrementMinor)
+	data.NextPatch = nextVersion(v, (*version.Version).IncrementPatch)
+
+	// A component too wide for its field would collide with another version
+	// (1.2.100 and 1.3.0), so the encoding is left empty instead
+	if max(v.Major, v.Minor, v.Patch) < 100 {
+		data.VersionInt = strconv.Itoa(v.Major*10000 + v.Minor*100 + v.Patch)
+		data.VersionBCD = fmt.Sprintf("0x%02d%02d%02d", v.Major, v.Minor, v.Patch)
 	}
-	return result, nil
+	if max(v.Major, v.Minor, v.Patch) < 256 {
+		data.VersionHex = fmt.Sprintf("0x%02X%02X%02X", v.Major, v.Minor, v.Patch)
+	}
+}
+
+// nextVersion returns the core version after applying increment to a copy
+// of v (e.g. 1.2.3 -> 2.0.0 for IncrementMajor)
+func nextVersion(v *version.Version, increment func(*version.Version)) string {
+	next := *v
+	increment(&next)
+	return next.CoreVersion()
+}
+
+// RenderTemplateWithData renders a Mustache template with TemplateData. To
+// render several templates against the same data, use a Renderer.
+func RenderTemplateWithData(tmplStr string, data TemplateData) (string, error) {
+	return NewRenderer(data).Render(tmplStr)
 }
 
 // templateDataToMap converts TemplateData to a map for Mustache rendering
 // This allows custom variables to be used alongside built-in variables
 func templateDataToMap(data TemplateData) map[string]interface{} {
+	return buildTemplateMap(data, collectPluginValues)
+}
+
+// buildTemplateMap converts TemplateData to a map for Mustache rendering,
+// taking plugin variables from plugins
+func buildTemplateMap(data TemplateData, plugins func(pluginContext) pluginValues) map[string]interface{} {
 	m := map[string]interface{}{
 		// Version components
 		"Major":           data.Major,
@@ -580,6 +857,19 @@ func templateDataToMap(data TemplateData) map[string]interface{} {
 		"MajorMinor":      data.MajorMinor,
 		"Prefix":          data.Prefix,
 
+		// Version arithmetic
+		"MajorPlusOne": data.MajorPlusOne,
+		"MinorPlusOne": data.MinorPlusOne,
+		"PatchPlusOne": data.PatchPlusOne,
+		"NextMajor":    data.NextMajor,
+		"NextMinor":    data.NextMinor,
+		"NextPatch":    data.NextPatch,
+
+		// Numeric encodings
+		"VersionInt": data.VersionInt,
+		"VersionHex": data.VersionHex,
+		"VersionBCD": data.VersionBCD,
+
 		// Pre-release
 		"PreRelease":         data.PreRelease,
 		"PreReleaseWithDash": data.PreReleaseWithDash,
@@ -601,19 +891,31 @@ func templateDataToMap(data TemplateData) map[string]interface{} {
 		"BuildNumberPadded":  data.BuildNumberPadded,
 		"UncommittedChanges": data.UncommittedChanges,
 		"Dirty":              data.Dirty,
+		"IsReleaseBuild":     data.IsReleaseBuild,
 		"VersionSourceHash":  data.VersionSourceHash,
+		"HashAlgorithm":      data.HashAlgorithm,
+		"PreviousVersion":    data.PreviousVersion,
+		"PreviousTag":        data.PreviousTag,
+
+		// Distance to the default branch
+		"CommitsAheadOfDefault": data.CommitsAheadOfDefault,
+		"CommitsBehindDefault":  data.CommitsBehindDefault,
+
+		// Branch inferred for a detached HEAD
+		"InferredBranchName":       data.InferredBranchName,
+		"InferredBranchConfidence": data.InferredBranchConfidence,
 
 		// Commit author
 		"CommitAuthor":      data.CommitAuthor,
-		"CommitUser":        data.CommitAuthor, // Alias for CommitAuthor
 		"CommitAuthorEmail": data.CommitAuthorEmail,
-		"CommitUserEmail":   data.CommitAuthorEmail, // Alias for CommitAuthorEmail
+
+		// VERSION file history
+		"VersionFileLastChangedBy":   data.VersionFileLastChangedBy,
+		"VersionFileLastChangedDate": data.VersionFileLastChangedDate,
 
 		// Commit timestamps
 		"CommitDate":          data.CommitDate,
-		"CommitDateTime":      data.CommitDate, // Alias for CommitDate
 		"CommitDateCompact":   data.CommitDateCompact,
-		"CommitDateTimeCompact": data.CommitDateCompact, // Alias for CommitDateCompact
 		"CommitDateShort":     data.CommitDateShort,
 		"CommitYear":          data.CommitYear,
 		"CommitMonth":         data.CommitMonth,
@@ -628,21 +930,50 @@ func templateDataToMap(data TemplateData) map[string]interface{} {
 		"BuildDay":             data.BuildDay,
 
 		"DateTimeDirty": data.DateTimeDirty,
+
+		// Build host
+		"GoVersion": data.GoVersion,
+		"OS":        data.OS,
+		"Arch":      data.Arch,
+		"Hostname":  data.Hostname,
+		"NumCPU":    data.NumCPU,
+
+		// Identifier sections: {{#PreReleaseParts}}{{.}}{{/PreReleaseParts}}
+		"PreReleaseParts": splitIdentifiers(data.PreRelease, preReleaseSeparators),
+		"MetadataParts":   splitIdentifiers(data.Metadata, metadataSeparators),
+
+		// Set by Obfuscate (emit --obfuscate)
+		"ObfuscatedVersion": data.ObfuscatedVersion,
+		"ObfuscationKey":    data.ObfuscationKey,
+
+		// Allowlisted environment variables: {{Env.BUILD_ID}}
+		"Env": envVariables(),
 	}
 
+	// Configured date formats: {{BuildDateDotted}}
+	for k, v := range data.Dates {
+		m[k] = v
+	}
+
+	// Aliases (renamed variables) before custom variables, so custom wins
+	addAliases(m)
+
 	// Merge custom variables (they can override built-ins if desired)
 	for k, v := range data.Custom {
 		m[k] = v
 	}
 
 	// Merge plugin-provided variables
-	// Pass ShortHash as context so plugins can create prefixed variants
-	pluginVars := plugin.GetAllTemplateVariables(map[string]string{
-		"ShortHash":  data.ShortHash,
-		"MediumHash": data.MediumHash,
-		"Hash":       data.Hash,
-	})
-	for k, v := range pluginVars {
+	// Pass every variable as context so plugins can create prefixed hash
+	// variants, derive other version schemes, or build on branch and custom
+	// variables
+	pluginVals := plugins(newPluginContext(data))
+	for k, v := range pluginVals.vars {
+		m[k] = v
+	}
+
+	// Merge plugin-provided list sections (e.g., {{#Issues}}...{{/Issues}})
+	for k, v := range pluginVals.sections {
 		m[k] = v
 	}
 
@@ -684,36 +1015,45 @@ func MergeCustomVars(data *TemplateData, extraVars map[string]string) {
 // prereleaseTemplate: Mustache template for PreRelease (use DASHES as separators)
 // metadataTemplate: Mustache template for Metadata (use DOTS as separators)
 func BuildCompleteTemplateData(v *version.Version, prereleaseTemplate, metadataTemplate string) TemplateData {
+	return BuildCompleteRenderer(v, prereleaseTemplate, metadataTemplate).Data()
+}
+
+// BuildCompleteRenderer is BuildCompleteTemplateData returning a Renderer
+// over the result, so further templates reuse the work already done
+func BuildCompleteRenderer(v *version.Version, prereleaseTemplate, metadataTemplate string) *Renderer {
 	// Build base template data
 	data := BuildTemplateDataFromVersion(v)
+	r := NewRenderer(data)
 
 	// Render PreRelease from template
 	// IMPORTANT: The template should use DASHES (-) to separate identifiers per SemVer 2.0.0
 	if prereleaseTemplate != "" {
-		prerelease, err := RenderTemplateWithData(prereleaseTemplate, data)
+		prerelease, err := r.Render(prereleaseTemplate)
 		if err == nil {
 			prerelease = strings.TrimSpace(prerelease)
 			data.PreRelease = prerelease
 			if prerelease != "" {
 				data.PreReleaseWithDash = "-" + prerelease
 			}
+			r.SetData(data)
 		}
 	}
 
 	// Render Metadata from template
 	// IMPORTANT: The template should use DOTS (.) to separate identifiers per SemVer 2.0.0
 	if metadataTemplate != "" {
-		metadata, err := RenderTemplateWithData(metadataTemplate, data)
+		metadata, err := r.Render(metadataTemplate)
 		if err == nil {
 			metadata = strings.TrimSpace(metadata)
 			data.Metadata = metadata
 			if metadata != "" {
 				data.MetadataWithPlus = "+" + metadata
 			}
+			r.SetData(data)
 		}
 	}
 
-	return data
+	return r
 }
 
 // TemplateDataToStringMap converts TemplateData to map[string]string for use with mode package
@@ -727,6 +1067,19 @@ func TemplateDataToStringMap(data TemplateData) map[string]string {
 		"MajorMinor":      data.MajorMinor,
 		"Prefix":          data.Prefix,
 
+		// Version arithmetic
+		"MajorPlusOne": data.MajorPlusOne,
+		"MinorPlusOne": data.MinorPlusOne,
+		"PatchPlusOne": data.PatchPlusOne,
+		"NextMajor":    data.NextMajor,
+		"NextMinor":    data.NextMinor,
+		"NextPatch":    data.NextPatch,
+
+		// Numeric encodings
+		"VersionInt": data.VersionInt,
+		"VersionHex": data.VersionHex,
+		"VersionBCD": data.VersionBCD,
+
 		// Pre-release
 		"PreRelease":         data.PreRelease,
 		"PreReleaseWithDash": data.PreReleaseWithDash,
@@ -748,12 +1101,28 @@ func TemplateDataToStringMap(data TemplateData) map[string]string {
 		"BuildNumberPadded":  data.BuildNumberPadded,
 		"UncommittedChanges": data.UncommittedChanges,
 		"Dirty":              data.Dirty,
+		"IsReleaseBuild":     data.IsReleaseBuild,
 		"VersionSourceHash":  data.VersionSourceHash,
+		"HashAlgorithm":      data.HashAlgorithm,
+		"PreviousVersion":    data.PreviousVersion,
+		"PreviousTag":        data.PreviousTag,
+
+		// Distance to the default branch
+		"CommitsAheadOfDefault": data.CommitsAheadOfDefault,
+		"CommitsBehindDefault":  data.CommitsBehindDefault,
+
+		// Branch inferred for a detached HEAD
+		"InferredBranchName":       data.InferredBranchName,
+		"InferredBranchConfidence": data.InferredBranchConfidence,
 
 		// Commit author
 		"CommitAuthor":      data.CommitAuthor,
 		"CommitAuthorEmail": data.CommitAuthorEmail,
 
+		// VERSION file history
+		"VersionFileLastChangedBy":   data.VersionFileLastChangedBy,
+		"VersionFileLastChangedDate": data.VersionFileLastChangedDate,
+
 		// Commit timestamps
 		"CommitDate":        data.CommitDate,
 		"CommitDateCompact": data.CommitDateCompact,
@@ -771,8 +1140,21 @@ func TemplateDataToStringMap(data TemplateData) map[string]string {
 		"BuildDay":             data.BuildDay,
 
 		"DateTimeDirty": data.DateTimeDirty,
+
+		// Build host
+		"GoVersion": data.GoVersion,
+		"OS":        data.OS,
+		"Arch":      data.Arch,
+		"Hostname":  data.Hostname,
+		"NumCPU":    data.NumCPU,
+	}
+
+	for k, v := range data.Dates {
+		m[k] = v
 	}
 
+	addAliases(m)
+
 	// Merge custom variables
 	for k, v := range data.Custom {
 		m[k] = v
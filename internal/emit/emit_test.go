@@ -1,6 +1,10 @@
 package emit
 
 import (
+	"encoding/json"
+	"errors"
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
 	"strings"
@@ -135,6 +139,75 @@ func TestBuildTemplateDataFromVersion(t *testing.T) {
 	}
 }
 
+// TestRenderTemplate_VersionArithmetic validates the computed next-version
+// variables.
+//
+// Why: Upgrade notes and dependency ranges need the next version; computing
+// it in shell around versionator is error-prone (minor and patch must reset).
+//
+// What: 1.2.3-rc.1 yields the +1 components, next versions with lower parts
+// reset and no pre-release, and renders a ">=1.2.3 <2.0.0" range.
+func TestRenderTemplate_VersionArithmetic(t *testing.T) {
+	// Precondition: A pre-release version
+	vd := &version.Version{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1"}
+
+	// Action
+	data := BuildTemplateDataFromVersion(vd)
+	got, err := RenderTemplateWithData(">={{MajorMinorPatch}} <{{NextMajor}}", data)
+
+	// Expected
+	for name, pair := range map[string][2]string{
+		"MajorPlusOne": {data.MajorPlusOne, "2"},
+		"MinorPlusOne": {data.MinorPlusOne, "3"},
+		"PatchPlusOne": {data.PatchPlusOne, "4"},
+		"NextMajor":    {data.NextMajor, "2.0.0"},
+		"NextMinor":    {data.NextMinor, "1.3.0"},
+		"NextPatch":    {data.NextPatch, "1.2.4"},
+	} {
+		if pair[0] != pair[1] {
+			t.Errorf("expected %s=%s, got %s", name, pair[1], pair[0])
+		}
+	}
+	if err != nil || got != ">=1.2.3 <2.0.0" {
+		t.Errorf("expected range >=1.2.3 <2.0.0, got %q (%v)", got, err)
+	}
+	if vd.Major != 1 || vd.PreRelease != "rc.1" {
+		t.Errorf("source version modified: %+v", vd)
+	}
+}
+
+// TestBuildTemplateData_NumericEncodings validates the single-number
+// version variables.
+//
+// Why: These values end up in firmware registers; a component wider than
+// its field makes two versions encode alike (1.2.100 and 1.3.0 as 10300) or
+// overflows the register.
+//
+// What: 1.2.12 encodes as 10212, 0x01020C and 0x010212; a component of 100
+// or more leaves VersionInt and VersionBCD empty, and one of 256 or more
+// VersionHex too.
+func TestBuildTemplateData_NumericEncodings(t *testing.T) {
+	tests := []struct {
+		version           version.Version
+		integer, hex, bcd string
+	}{
+		{version.Version{Major: 1, Minor: 2, Patch: 12}, "10212", "0x01020C", "0x010212"},
+		{version.Version{Major: 99, Minor: 99, Patch: 99}, "999999", "0x636363", "0x999999"},
+		{version.Version{Major: 1, Minor: 2, Patch: 100}, "", "0x010264", ""},
+		{version.Version{Major: 300, Minor: 1, Patch: 1}, "", "", ""},
+	}
+	for _, tt := range tests {
+		// Action
+		data := BuildTemplateDataFromVersion(&tt.version)
+
+		// Expected
+		if data.VersionInt != tt.integer || data.VersionHex != tt.hex || data.VersionBCD != tt.bcd {
+			t.Errorf("%s: got %q, %q, %q; want %q, %q, %q", tt.version.String(),
+				data.VersionInt, data.VersionHex, data.VersionBCD, tt.integer, tt.hex, tt.bcd)
+		}
+	}
+}
+
 // TestEmitToFile validates the complete workflow of rendering and writing
 // version output to a file.
 //
@@ -279,6 +352,33 @@ func TestRender_CHeader(t *testing.T) {
 	}
 }
 
+// TestRender_CFirmware validates the firmware header.
+//
+// Why: Bootloaders and firmware version registers compare versions as
+// integers; the header must expose the numeric encodings as constants.
+//
+// What: 1.2.12 renders VERSION_INT 10212, VERSION_HEX 0x01020C, and
+// VERSION_BCD 0x010212.
+func TestRender_CFirmware(t *testing.T) {
+	// Action
+	result, err := Render(FormatCFirmware, "1.2.12")
+
+	// Expected
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`#define VERSION "1.2.12"`,
+		"#define VERSION_INT 10212UL",
+		"#define VERSION_HEX 0x01020CUL",
+		"#define VERSION_BCD 0x010212UL",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q, got: %s", want, result)
+		}
+	}
+}
+
 // TestRender_CPP validates C++ source code format output.
 //
 // Why: C++ projects may use namespaces for version constants.
@@ -537,6 +637,78 @@ func TestRender_Dart(t *testing.T) {
 	}
 }
 
+// TestRender_Badge_ProducesShieldsEndpointJSON validates the badge format.
+//
+// Why: shields.io rejects endpoint documents that are not valid JSON or lack
+// schemaVersion, and a pre-release should be visibly different on the badge.
+//
+// What: A release renders a blue badge with the core version; a pre-release
+// renders an orange badge including the pre-release.
+func TestRender_Badge_ProducesShieldsEndpointJSON(t *testing.T) {
+	// Precondition: Badge template, release and pre-release data
+	tmpl, err := GetEmbeddedTemplate(FormatBadge)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	release := TemplateData{MajorMinorPatch: "1.2.3", Prefix: "v"}
+	pre := TemplateData{MajorMinorPatch: "2.0.0", PreRelease: "rc.1", PreReleaseWithDash: "-rc.1"}
+
+	for _, tc := range []struct {
+		data    TemplateData
+		message string
+		color   string
+	}{
+		{release, "1.2.3", "blue"},
+		{pre, "2.0.0-rc.1", "orange"},
+	} {
+		// Action: Render and parse
+		out, err := RenderTemplateWithData(tmpl, tc.data)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var badge map[string]any
+		if err := json.Unmarshal([]byte(out), &badge); err != nil {
+			t.Fatalf("badge is not valid JSON: %v\n%s", err, out)
+		}
+
+		// Expected: shields.io endpoint fields
+		if badge["schemaVersion"] != float64(1) || badge["label"] != "version" ||
+			badge["message"] != tc.message || badge["color"] != tc.color {
+			t.Errorf("unexpected badge: %v", badge)
+		}
+	}
+}
+
+// TestRender_GoHTTP validates the Go HTTP handler format.
+//
+// Why: Services import the generated package directly; output that does not
+// parse as Go breaks their build, and the handler is the point of the format.
+//
+// What: Render should produce a parseable Go file declaring Handler, Info, and
+// Path, with the version constant filled in.
+func TestRender_GoHTTP(t *testing.T) {
+	// Precondition: Version string and go-http format
+	// Action: Render
+	result, err := Render(FormatGoHTTP, "1.2.3")
+
+	// Expected: Valid Go source with the handler API
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "version.go", result, 0)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, result)
+	}
+	for _, name := range []string{"Handler", "Info", "Path", "BuildInfo"} {
+		if file.Scope.Lookup(name) == nil {
+			t.Errorf("expected %s to be declared", name)
+		}
+	}
+	if !strings.Contains(result, `Version    = "1.2.3`) {
+		t.Errorf("expected Version constant, got: %s", result)
+	}
+}
+
 // TestRenderTemplate_MajorMinor validates the two-component version shorthand.
 //
 // Why: Some systems (like Docker tags) use Major.Minor without patch.
@@ -668,7 +840,7 @@ func TestGetEmbeddedTemplate(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if !strings.Contains(template, "{{MajorMinorPatch}}") {
+	if !strings.Contains(template, "{{Pep440Version}}") {
 		t.Errorf("expected template placeholder, got: %s", template)
 	}
 	if !strings.Contains(template, "__version__") {
@@ -693,6 +865,7 @@ func TestIsValidFormat(t *testing.T) {
 		{"go", true},
 		{"c", true},
 		{"c-header", true},
+		{"c-firmware", true},
 		{"cpp", true},
 		{"cpp-header", true},
 		{"js", true},
@@ -1088,6 +1261,76 @@ func TestGetVCSInfo_WithMockVCS(t *testing.T) {
 	}
 }
 
+// fileHistoryVCS adds vcs.FileHistory to the generated mock
+type fileHistoryVCS struct {
+	*mock.MockVersionControlSystem
+	changes map[string]vcs.FileChange
+}
+
+func (f *fileHistoryVCS) LastChange(path string) (vcs.FileChange, error) {
+	return f.changes[path], nil
+}
+
+// TestBuildCompleteTemplateData_FileHistory_SetsVersionFileLastChanged
+// validates the VERSION file history variables.
+//
+// Why: Teams embed who bumped the version last into release metadata; the
+// lookup must use VERSION's path relative to the repository root.
+//
+// What: VERSION in a subdirectory; the VCS reports its last change under
+// "sub/VERSION", which populates both variables.
+func TestBuildCompleteTemplateData_FileHistory_SetsVersionFileLastChanged(t *testing.T) {
+	// Precondition: VERSION in root/sub, a VCS with history for sub/VERSION
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sub, "VERSION"), []byte("1.2.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(sub)
+
+	ctrl := gomock.NewController(t)
+	mockVCS := mock.NewMockVersionControlSystem(ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(root, nil).AnyTimes()
+	mockVCS.EXPECT().GetVCSIdentifier(gomock.Any()).Return("", errors.New("no commits")).AnyTimes()
+	mockVCS.EXPECT().GetBranchName().Return("main", nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitDate().Return(time.Time{}, errors.New("no commits")).AnyTimes()
+	mockVCS.EXPECT().GetCommitsSinceTag().Return(0, nil).AnyTimes()
+	mockVCS.EXPECT().GetLastTagCommit().Return("", nil).AnyTimes()
+	mockVCS.EXPECT().GetUncommittedChanges().Return(0, nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitAuthor().Return("Test Author", nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitAuthorEmail().Return("test@example.com", nil).AnyTimes()
+	bumped := time.Date(2024, 1, 10, 9, 15, 0, 0, time.UTC)
+	historyVCS := &fileHistoryVCS{
+		MockVersionControlSystem: mockVCS,
+		changes:                  map[string]vcs.FileChange{"sub/VERSION": {Author: "Release Manager", Date: bumped}},
+	}
+
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(historyVCS)
+	defer func() {
+		vcs.UnregisterVCS("git")
+		vcs.RegisterVCS(gitVCS.NewGitVCSDefault())
+	}()
+
+	// Action
+	v := version.Parse("1.2.3")
+	data := BuildCompleteTemplateData(&v, "", "")
+	rendered, err := RenderTemplateWithData("{{VersionFileLastChangedBy}} {{VersionFileLastChangedDate}}", data)
+
+	// Expected
+	if err != nil {
+		t.Fatalf("RenderTemplateWithData() error: %v", err)
+	}
+	if rendered != "Release Manager 2024-01-10T09:15:00Z" {
+		t.Errorf("unexpected rendering %q", rendered)
+	}
+}
+
 // TestFormatVCSFields validates VCS field formatting with various inputs.
 //
 // Why: VCS data must be formatted consistently for templates.
@@ -1210,6 +1453,34 @@ func TestFormatBuildTime(t *testing.T) {
 // Tests verifying expected failure modes and error messages.
 // =============================================================================
 
+// TestRender_Python_Pep440Version validates the Python version module.
+//
+// Why: pip and setuptools reject SemVer pre-releases such as 1.2.3-rc.1, so
+// a package reading __version__ from the emitted module could not be built.
+//
+// What: __version__ holds the PEP 440 form of the version, with build
+// metadata as the local label.
+func TestRender_Python_Pep440Version(t *testing.T) {
+	tests := map[string]string{
+		"1.2.3":                  `__version__ = "1.2.3"`,
+		"1.2.3-rc.1":             `__version__ = "1.2.3rc1"`,
+		"1.2.3-alpha+build.42":   `__version__ = "1.2.3a0+build.42"`,
+		"v1.2.3-feature-login-5": `__version__ = "1.2.3.dev5+feature.login"`,
+	}
+	for versionStr, want := range tests {
+		// Action
+		got, err := Render(FormatPython, versionStr)
+
+		// Expected
+		if err != nil {
+			t.Fatalf("Render(%q) error: %v", versionStr, err)
+		}
+		if !strings.Contains(got, want) {
+			t.Errorf("Render(%q) = %q, want it to contain %s", versionStr, got, want)
+		}
+	}
+}
+
 // TestRender_InvalidFormat validates error handling for unsupported formats.
 //
 // Why: Users may mistype format names. Clear error messages help them
@@ -1832,6 +2103,70 @@ func TestRenderTemplateWithData_DateTimeDirty(t *testing.T) {
 	}
 }
 
+// TestReleaseBuildFlag_TaggedAndClean_IsTrue validates release detection.
+//
+// Why: Tagged builds must emit clean versions while every other build gets a
+// dev suffix; a dirty checkout of a tag is not a release.
+//
+// What: Only zero commits since the tag and zero uncommitted changes yield
+// "true"; no tag (-1), later commits, or a dirty tree yield empty.
+func TestReleaseBuildFlag_TaggedAndClean_IsTrue(t *testing.T) {
+	tests := []struct {
+		name             string
+		commits, changes int
+		expected         string
+	}{
+		{name: "at tag and clean", commits: 0, changes: 0, expected: "true"},
+		{name: "at tag but dirty", commits: 0, changes: 2, expected: ""},
+		{name: "commits after tag", commits: 3, changes: 0, expected: ""},
+		{name: "no tag", commits: -1, changes: 0, expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action: Compute the flag
+			got := releaseBuildFlag(tt.commits, tt.changes)
+
+			// Expected: "true" only for a clean tagged build
+			if got != tt.expected {
+				t.Errorf("releaseBuildFlag(%d, %d) = %q, want %q", tt.commits, tt.changes, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRenderTemplateWithData_IsReleaseBuild_BranchesPreRelease validates
+// that pre-release and metadata templates can branch on IsReleaseBuild.
+//
+// Why: The point of the flag is that one template yields 1.2.3 for tagged
+// builds and a dev version for everything else.
+//
+// What: With the inverted section, a release build renders no pre-release or
+// metadata, and a non-release build renders both.
+func TestRenderTemplateWithData_IsReleaseBuild_BranchesPreRelease(t *testing.T) {
+	// Precondition: Templates suffixing only non-release builds
+	data := TemplateData{CommitsSinceTag: "3", ShortHash: "abc1234"}
+	prerelease := "{{^IsReleaseBuild}}dev-{{CommitsSinceTag}}{{/IsReleaseBuild}}"
+
+	for _, release := range []string{"true", ""} {
+		data.IsReleaseBuild = release
+
+		// Action: Render the pre-release template
+		out, err := RenderTemplateWithData(prerelease, data)
+
+		// Expected: Empty for release builds, dev suffix otherwise
+		if err != nil {
+			t.Fatalf("render failed: %v", err)
+		}
+		want := "dev-3"
+		if release == "true" {
+			want = ""
+		}
+		if out != want {
+			t.Errorf("IsReleaseBuild=%q: got %q, want %q", release, out, want)
+		}
+	}
+}
+
 // TestFormatPreReleaseNumber validates pre-release number formatting.
 //
 // Why: Pre-release numbers may be negative (-1 indicates none) or zero.
@@ -1894,3 +2229,150 @@ func mustParseTime(s string) (t time.Time) {
 	t, _ = time.Parse(time.RFC3339, s)
 	return
 }
+
+// tagNameVCS adds vcs.TagNameLister to the generated mock
+type tagNameVCS struct {
+	*mock.MockVersionControlSystem
+	names []string
+}
+
+func (t *tagNameVCS) ListTagNames() ([]string, error) {
+	return t.names, nil
+}
+
+// TestBuildCompleteTemplateData_TagNames_SetsPreviousRelease validates the
+// PreviousVersion and PreviousTag variables.
+//
+// Why: Changelog headers ("Changes since v1.2.2") need the release right
+// before the current version, chosen by precedence rather than tag order,
+// and non-version tags must not be mistaken for releases.
+//
+// What: Tags out of order, including a newer release and a non-version tag;
+// rendering 1.3.0 picks v1.2.10-rc.1 (numeric, not lexical, precedence),
+// and 1.0.0 leaves both variables empty.
+func TestBuildCompleteTemplateData_TagNames_SetsPreviousRelease(t *testing.T) {
+	// Precondition: A VCS listing tags around the current version
+	ctrl := gomock.NewController(t)
+	mockVCS := mock.NewMockVersionControlSystem(ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(t.TempDir(), nil).AnyTimes()
+	mockVCS.EXPECT().GetVCSIdentifier(gomock.Any()).Return("", errors.New("no commits")).AnyTimes()
+	mockVCS.EXPECT().GetBranchName().Return("main", nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitDate().Return(time.Time{}, errors.New("no commits")).AnyTimes()
+	mockVCS.EXPECT().GetCommitsSinceTag().Return(0, nil).AnyTimes()
+	mockVCS.EXPECT().GetLastTagCommit().Return("", nil).AnyTimes()
+	mockVCS.EXPECT().GetUncommittedChanges().Return(0, nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitAuthor().Return("", nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitAuthorEmail().Return("", nil).AnyTimes()
+	taggedVCS := &tagNameVCS{
+		MockVersionControlSystem: mockVCS,
+		names:                    []string{"v1.10.0", "deploy-prod", "v1.2.2", "v1.0.0", "v1.2.10-rc.1"},
+	}
+
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(taggedVCS)
+	defer func() {
+		vcs.UnregisterVCS("git")
+		vcs.RegisterVCS(gitVCS.NewGitVCSDefault())
+	}()
+
+	// Action
+	current := version.Parse("1.3.0")
+	rendered, err := RenderTemplateWithData("{{PreviousVersion}} {{PreviousTag}}", BuildCompleteTemplateData(&current, "", ""))
+	first := version.Parse("1.0.0")
+	firstData := BuildCompleteTemplateData(&first, "", "")
+
+	// Expected
+	if err != nil {
+		t.Fatalf("RenderTemplateWithData() error: %v", err)
+	}
+	if rendered != "1.2.10-rc.1 v1.2.10-rc.1" {
+		t.Errorf("unexpected rendering %q", rendered)
+	}
+	if firstData.PreviousVersion != "" || firstData.PreviousTag != "" {
+		t.Errorf("expected no previous release for 1.0.0, got %q/%q", firstData.PreviousVersion, firstData.PreviousTag)
+	}
+}
+
+// branchDistanceVCS adds vcs.BranchDistance to the generated mock, counting
+// distances from a fixed table keyed by base ref
+type branchDistanceVCS struct {
+	*mock.MockVersionControlSystem
+	remoteDefault string
+	distances     map[string][2]int
+}
+
+func (b *branchDistanceVCS) RemoteDefaultBranch() (string, error) {
+	return b.remoteDefault, nil
+}
+
+func (b *branchDistanceVCS) CommitsAheadBehind(base string) (int, int, error) {
+	d, ok := b.distances[base]
+	if !ok {
+		return 0, 0, errors.New("unknown ref " + base)
+	}
+	return d[0], d[1], nil
+}
+
+// TestBuildCompleteTemplateData_BranchDistance_UsesConfiguredDefault
+// validates CommitsAheadOfDefault and CommitsBehindDefault.
+//
+// Why: Feature branches put their drift from the default branch into
+// pre-release metadata; teams whose integration branch is not origin's
+// default configure vcs.defaultBranch instead.
+//
+// What: Without configuration the remote's default branch is compared; with
+// vcs.defaultBranch set that ref is used; an unknown ref leaves both empty.
+func TestBuildCompleteTemplateData_BranchDistance_UsesConfiguredDefault(t *testing.T) {
+	// Precondition: A VCS with distances to origin/main and upstream/develop
+	ctrl := gomock.NewController(t)
+	mockVCS := mock.NewMockVersionControlSystem(ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	mockVCS.EXPECT().GetRepositoryRoot().Return(t.TempDir(), nil).AnyTimes()
+	mockVCS.EXPECT().GetVCSIdentifier(gomock.Any()).Return("", errors.New("no commits")).AnyTimes()
+	mockVCS.EXPECT().GetBranchName().Return("feature/x", nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitDate().Return(time.Time{}, errors.New("no commits")).AnyTimes()
+	mockVCS.EXPECT().GetCommitsSinceTag().Return(0, nil).AnyTimes()
+	mockVCS.EXPECT().GetLastTagCommit().Return("", nil).AnyTimes()
+	mockVCS.EXPECT().GetUncommittedChanges().Return(0, nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitAuthor().Return("", nil).AnyTimes()
+	mockVCS.EXPECT().GetCommitAuthorEmail().Return("", nil).AnyTimes()
+	distanceVCS := &branchDistanceVCS{
+		MockVersionControlSystem: mockVCS,
+		remoteDefault:            "origin/main",
+		distances:                map[string][2]int{"origin/main": {7, 3}, "upstream/develop": {2, 0}},
+	}
+
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(distanceVCS)
+	defer func() {
+		vcs.UnregisterVCS("git")
+		vcs.RegisterVCS(gitVCS.NewGitVCSDefault())
+		vcs.SetDefaultBranch("")
+	}()
+	v := version.Parse("1.2.3")
+	distance := func() string {
+		data := BuildCompleteTemplateData(&v, "", "")
+		return data.CommitsAheadOfDefault + "/" + data.CommitsBehindDefault
+	}
+
+	// Action: Render with the detected, configured, and an unknown base
+	detected := distance()
+	vcs.SetDefaultBranch("upstream/develop")
+	configured := distance()
+	vcs.SetDefaultBranch("origin/gone")
+	unknown := distance()
+
+	// Expected
+	if detected != "7/3" {
+		t.Errorf("detected default: got %q, want 7/3", detected)
+	}
+	if configured != "2/0" {
+		t.Errorf("configured default: got %q, want 2/0", configured)
+	}
+	if unknown != "/" {
+		t.Errorf("unknown default: got %q, want empty values", unknown)
+	}
+}
@@ -0,0 +1,50 @@
+package emit
+
+import (
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+var (
+	envMu        sync.RWMutex
+	envAllowlist []string
+)
+
+// SetEnvAllowlist sets the environment variable names {{Env.NAME}} may read.
+// Entries are exact names or globs (e.g. "CI_*"); nil allows none.
+func SetEnvAllowlist(patterns []string) {
+	envMu.Lock()
+	defer envMu.Unlock()
+	envAllowlist = patterns
+}
+
+// envVariables returns the allowlisted environment variables, read at render
+// time so templates see the environment of the current process
+func envVariables() map[string]string {
+	envMu.RLock()
+	defer envMu.RUnlock()
+
+	vars := map[string]string{}
+	if len(envAllowlist) == 0 {
+		return vars
+	}
+	for _, entry := range os.Environ() {
+		name, value, ok := strings.Cut(entry, "=")
+		if ok && envAllowed(name) {
+			vars[name] = value
+		}
+	}
+	return vars
+}
+
+// envAllowed reports whether name matches an allowlist entry
+func envAllowed(name string) bool {
+	for _, pattern := range envAllowlist {
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
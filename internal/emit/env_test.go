@@ -0,0 +1,41 @@
+package emit
+
+import "testing"
+
+// TestRenderTemplateWithData_EnvNamespace_ResolvesAllowlisted validates {{Env.*}}.
+//
+// Why: CI values like BUILD_ID should be usable in templates without
+// declaring each one via --set, but arbitrary variables (tokens, secrets)
+// must not be readable by any template.
+//
+// What: Allowlisted names and glob matches resolve; other variables and
+// everything with an empty allowlist render empty.
+func TestRenderTemplateWithData_EnvNamespace_ResolvesAllowlisted(t *testing.T) {
+	// Precondition: Environment with allowed and secret variables
+	t.Setenv("BUILD_ID", "42")
+	t.Setenv("CI_PIPELINE", "nightly")
+	t.Setenv("SECRET_TOKEN", "hunter2")
+	SetEnvAllowlist([]string{"BUILD_ID", "CI_*"})
+	t.Cleanup(func() { SetEnvAllowlist(nil) })
+	tmpl := "{{Env.BUILD_ID}}/{{Env.CI_PIPELINE}}/{{Env.SECRET_TOKEN}}"
+
+	// Action: Render with the allowlist
+	result, err := RenderTemplateWithData(tmpl, TemplateData{})
+
+	// Expected: Only allowlisted values
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if result != "42/nightly/" {
+		t.Errorf("got %q, want %q", result, "42/nightly/")
+	}
+
+	// Action: Render without an allowlist
+	SetEnvAllowlist(nil)
+	result, err = RenderTemplateWithData(tmpl, TemplateData{})
+
+	// Expected: Nothing resolves
+	if err != nil || result != "//" {
+		t.Errorf("got %q (%v), want %q", result, err, "//")
+	}
+}
@@ -0,0 +1,78 @@
+package emit
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// formatAliases maps the other names users type for a format to it
+var formatAliases = map[string]Format{
+	"py":         FormatPython,
+	"golang":     FormatGo,
+	"javascript": FormatJS,
+	"typescript": FormatTS,
+	"yml":        FormatYAML,
+	"rb":         FormatRuby,
+	"rs":         FormatRust,
+	"kt":         FormatKotlin,
+	"cs":         FormatCSharp,
+	"c++":        FormatCPP,
+}
+
+// formatExtensions maps output file extensions to the format they hold
+var formatExtensions = map[string]Format{
+	".py":    FormatPython,
+	".json":  FormatJSON,
+	".yaml":  FormatYAML,
+	".yml":   FormatYAML,
+	".go":    FormatGo,
+	".c":     FormatC,
+	".h":     FormatCHeader,
+	".cpp":   FormatCPP,
+	".cc":    FormatCPP,
+	".cxx":   FormatCPP,
+	".hpp":   FormatCPPHeader,
+	".hh":    FormatCPPHeader,
+	".js":    FormatJS,
+	".mjs":   FormatJS,
+	".cjs":   FormatJS,
+	".ts":    FormatTS,
+	".java":  FormatJava,
+	".kt":    FormatKotlin,
+	".cs":    FormatCSharp,
+	".php":   FormatPHP,
+	".swift": FormatSwift,
+	".rb":    FormatRuby,
+	".rs":    FormatRust,
+	".dart":  FormatDart,
+}
+
+// ResolveFormat returns the format called name, which may be an alias
+// (py, golang, javascript, yml, ...); names are case-insensitive
+func ResolveFormat(name string) (Format, bool) {
+	name = strings.ToLower(name)
+	if IsValidFormat(name) {
+		return Format(name), true
+	}
+	f, ok := formatAliases[name]
+	return f, ok
+}
+
+// FormatAliases returns the aliases as "alias=format", sorted by alias
+func FormatAliases() []string {
+	aliases := make([]string, 0, len(formatAliases))
+	for _, alias := range slices.Sorted(maps.Keys(formatAliases)) {
+		aliases = append(aliases, fmt.Sprintf("%s=%s", alias, formatAliases[alias]))
+	}
+	return aliases
+}
+
+// FormatForFile returns the format a file named path holds, judged by its
+// extension (version.py is python), or false when the extension is unknown
+func FormatForFile(path string) (Format, bool) {
+	f, ok := formatExtensions[strings.ToLower(filepath.Ext(path))]
+	return f, ok
+}
@@ -0,0 +1,62 @@
+package emit
+
+import "testing"
+
+// TestResolveFormat_AcceptsAliases validates format aliases.
+//
+// Why: Users type the names they know from other tools (py, golang, yml).
+//
+// What: Aliases and case variants resolve to their format; unknown names
+// do not.
+func TestResolveFormat_AcceptsAliases(t *testing.T) {
+	tests := map[string]Format{
+		"python":     FormatPython,
+		"py":         FormatPython,
+		"golang":     FormatGo,
+		"JavaScript": FormatJS,
+		"yml":        FormatYAML,
+		"JSON":       FormatJSON,
+	}
+	for name, want := range tests {
+		// Action
+		got, ok := ResolveFormat(name)
+
+		// Expected
+		if !ok || got != want {
+			t.Errorf("ResolveFormat(%q) = %q, %v; want %q", name, got, ok, want)
+		}
+	}
+	if _, ok := ResolveFormat("perl"); ok {
+		t.Error("ResolveFormat(\"perl\") should fail")
+	}
+}
+
+// TestFormatForFile_UsesExtension validates format inference from a path.
+//
+// Why: emit --output version.py should not also need "python".
+//
+// What: Known extensions map to their format regardless of case; unknown
+// or missing extensions do not.
+func TestFormatForFile_UsesExtension(t *testing.T) {
+	tests := map[string]Format{
+		"pkg/_version.py":    FormatPython,
+		"version/version.go": FormatGo,
+		"include/VERSION.H":  FormatCHeader,
+		"deploy/version.yml": FormatYAML,
+		"src/version.rs":     FormatRust,
+	}
+	for path, want := range tests {
+		// Action
+		got, ok := FormatForFile(path)
+
+		// Expected
+		if !ok || got != want {
+			t.Errorf("FormatForFile(%q) = %q, %v; want %q", path, got, ok, want)
+		}
+	}
+	for _, path := range []string{"VERSION", "notes.txt"} {
+		if _, ok := FormatForFile(path); ok {
+			t.Errorf("FormatForFile(%q) should fail", path)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package emit
+
+import (
+	"fmt"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+)
+
+// Modes of the .gitignore guard on emitted files
+const (
+	GitignoreAdd    = "add"    // add unignored outputs to .gitignore
+	GitignoreVerify = "verify" // report unignored outputs
+)
+
+// GitignoreModes returns the accepted .gitignore guard modes
+func GitignoreModes() []string {
+	return []string{GitignoreAdd, GitignoreVerify}
+}
+
+// IgnoreReport lists what the .gitignore guard found among emitted files
+type IgnoreReport struct {
+	// Tracked outputs are committed: every build changes them
+	Tracked []string
+	// Unignored outputs are neither ignored nor tracked (verify mode)
+	Unignored []string
+	// Added outputs were added to .gitignore (add mode)
+	Added []string
+}
+
+// GuardIgnored checks that emitted files are kept out of commits. Tracked
+// files are always reported, since ignore rules do not apply to them; other
+// files not ignored are added to .gitignore (add) or reported (verify).
+// Outputs are not checked without a mode, or when the active VCS cannot
+// tell ignored files.
+func GuardIgnored(mode string, paths []string) (IgnoreReport, error) {
+	var report IgnoreReport
+	if mode == "" {
+		return report, nil
+	}
+	checker, ok := vcs.GetActiveVCS().(vcs.IgnoreChecker)
+	if !ok {
+		return report, nil
+	}
+
+	for _, path := range paths {
+		if path == "" || path == StdoutPath {
+			continue
+		}
+		tracked, err := checker.IsTracked(path)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", path, err)
+		}
+		if tracked {
+			report.Tracked = append(report.Tracked, path)
+			continue
+		}
+		ignored, err := checker.IsIgnored(path)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", path, err)
+		}
+		switch {
+		case ignored:
+		case mode == GitignoreAdd:
+			if err := checker.Ignore(path); err != nil {
+				return report, fmt.Errorf("%s: %w", path, err)
+			}
+			report.Added = append(report.Added, path)
+		default:
+			report.Unignored = append(report.Unignored, path)
+		}
+	}
+	return report, nil
+}
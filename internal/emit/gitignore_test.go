@@ -0,0 +1,75 @@
+package emit
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	gitVCS "github.com/benjaminabbitt/versionator/internal/vcs/git"
+	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
+	"github.com/golang/mock/gomock"
+)
+
+// ignoreVCS adds IgnoreChecker to the generated mock
+type ignoreVCS struct {
+	*mock.MockVersionControlSystem
+	tracked map[string]bool
+	ignored map[string]bool
+}
+
+func (v *ignoreVCS) IsIgnored(path string) (bool, error) { return v.ignored[path], nil }
+func (v *ignoreVCS) IsTracked(path string) (bool, error) { return v.tracked[path], nil }
+func (v *ignoreVCS) Ignore(path string) error {
+	v.ignored[path] = true
+	return nil
+}
+
+// TestGuardIgnored_Modes validates the .gitignore guard on emitted files.
+//
+// Why: Build-stamped files committed by accident make every build a diff;
+// emit should add them to .gitignore or warn, and always flag tracked ones.
+//
+// What: A tracked output is reported in both modes; an unignored output is
+// added to .gitignore in add mode and reported in verify mode; ignored
+// outputs and stdout are left alone; without a mode nothing is checked.
+func TestGuardIgnored_Modes(t *testing.T) {
+	// Precondition: One tracked, one ignored and one plain output
+	ctrl := gomock.NewController(t)
+	mockVCS := mock.NewMockVersionControlSystem(ctrl)
+	mockVCS.EXPECT().Name().Return("git").AnyTimes()
+	mockVCS.EXPECT().IsRepository().Return(true).AnyTimes()
+	checker := &ignoreVCS{
+		MockVersionControlSystem: mockVCS,
+		tracked:                  map[string]bool{"version.go": true},
+		ignored:                  map[string]bool{"build/info.txt": true},
+	}
+	vcs.UnregisterVCS("git")
+	vcs.RegisterVCS(checker)
+	defer func() {
+		vcs.UnregisterVCS("git")
+		vcs.RegisterVCS(gitVCS.NewGitVCSDefault())
+	}()
+	paths := []string{"version.go", "build/info.txt", "_version.py", StdoutPath}
+
+	// Action: Verify, then add, then no mode
+	verified, verifyErr := GuardIgnored(GitignoreVerify, paths)
+	added, addErr := GuardIgnored(GitignoreAdd, paths)
+	off, offErr := GuardIgnored("", paths)
+
+	// Expected
+	if verifyErr != nil || addErr != nil || offErr != nil {
+		t.Fatalf("unexpected errors: %v, %v, %v", verifyErr, addErr, offErr)
+	}
+	if !slices.Equal(verified.Tracked, []string{"version.go"}) || !slices.Equal(verified.Unignored, []string{"_version.py"}) || verified.Added != nil {
+		t.Errorf("verify report = %+v", verified)
+	}
+	if !slices.Equal(added.Tracked, []string{"version.go"}) || !slices.Equal(added.Added, []string{"_version.py"}) || added.Unignored != nil {
+		t.Errorf("add report = %+v", added)
+	}
+	if !checker.ignored["_version.py"] {
+		t.Error("expected _version.py to be ignored after add")
+	}
+	if off.Tracked != nil || off.Added != nil || off.Unignored != nil {
+		t.Errorf("expected no checks without a mode, got %+v", off)
+	}
+}
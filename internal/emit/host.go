@@ -0,0 +1,68 @@
+package emit
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/benjaminabbitt/versionator/internal/offline"
+)
+
+var (
+	hostMu      sync.RWMutex
+	hostEnabled bool
+
+	// goVersionOnce caches the toolchain version, which costs a subprocess
+	goVersionOnce sync.Once
+	goVersion     string
+)
+
+// SetHostVariables turns the build host variables ({{GoVersion}}, {{OS}},
+// {{Arch}}, {{Hostname}}, {{NumCPU}}) on or off. They are off by default, so
+// versions do not reveal the build machine unless a team asks for it.
+func SetHostVariables(on bool) {
+	hostMu.Lock()
+	defer hostMu.Unlock()
+	hostEnabled = on
+}
+
+// setHostFields fills the build host variables of data when enabled
+func setHostFields(data *TemplateData) {
+	hostMu.RLock()
+	enabled := hostEnabled
+	hostMu.RUnlock()
+	if !enabled {
+		return
+	}
+
+	data.GoVersion = toolchainGoVersion()
+	data.OS = runtime.GOOS
+	data.Arch = runtime.GOARCH
+	data.NumCPU = strconv.Itoa(runtime.NumCPU())
+	if hostname, err := os.Hostname(); err == nil {
+		data.Hostname = hostname
+	}
+}
+
+// toolchainGoVersion returns the version of the go command on PATH, which
+// builds the project, or the Go version versionator was built with when
+// there is none
+func toolchainGoVersion() string {
+	goVersionOnce.Do(func() {
+		goVersion = runtime.Version()
+		cmd := exec.Command("go", "env", "GOVERSION")
+		if offline.Enabled() {
+			// Never download the toolchain go.mod asks for
+			cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local")
+		}
+		if out, err := cmd.Output(); err == nil {
+			if v := strings.TrimSpace(string(out)); v != "" {
+				goVersion = v
+			}
+		}
+	})
+	return goVersion
+}
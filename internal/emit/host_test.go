@@ -0,0 +1,50 @@
+package emit
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/version"
+)
+
+// TestBuildTemplateData_HostVariables_OptIn validates the build host variables.
+//
+// Why: Some teams embed the build machine in informational versions, but
+// host names must not leak into versions of everyone else.
+//
+// What: With SetHostVariables(true), OS, Arch, NumCPU, GoVersion, and
+// Hostname are filled; by default they render empty.
+func TestBuildTemplateData_HostVariables_OptIn(t *testing.T) {
+	// Precondition
+	v := version.Parse("1.2.3")
+	tmpl := "{{OS}}/{{Arch}}/{{NumCPU}}/{{GoVersion}}/{{Hostname}}"
+
+	// Action: Default
+	result, err := RenderTemplateWithData(tmpl, BuildTemplateDataFromVersion(&v))
+
+	// Expected: Nothing
+	if err != nil || result != "////" {
+		t.Errorf("got %q (%v), want %q", result, err, "////")
+	}
+
+	// Action: Enabled
+	SetHostVariables(true)
+	t.Cleanup(func() { SetHostVariables(false) })
+	data := BuildTemplateDataFromVersion(&v)
+	result, err = RenderTemplateWithData(tmpl, data)
+
+	// Expected: Host values
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if data.OS != runtime.GOOS || data.Arch != runtime.GOARCH || data.NumCPU == "" {
+		t.Errorf("data = %q %q %q", data.OS, data.Arch, data.NumCPU)
+	}
+	if !strings.HasPrefix(data.GoVersion, "go") {
+		t.Errorf("GoVersion = %q", data.GoVersion)
+	}
+	if !strings.HasPrefix(result, runtime.GOOS+"/"+runtime.GOARCH+"/") {
+		t.Errorf("got %q", result)
+	}
+}
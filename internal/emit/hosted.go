@@ -0,0 +1,35 @@
+package emit
+
+import (
+	"github.com/benjaminabbitt/versionator/internal/hosting"
+	"github.com/benjaminabbitt/versionator/internal/logging"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+
+	"go.uber.org/zap"
+)
+
+// hostedVCSInfo fills the commit fields of info from the hosting API
+// fallback (vcs.hosted) when there is no repository, e.g. in a build from
+// a source tarball. A failed lookup is logged and leaves info unchanged.
+func hostedVCSInfo(info VCSInfo) VCSInfo {
+	commit, ok, err := hosting.Current()
+	if !ok {
+		return info
+	}
+	if err != nil {
+		logging.GetLogger().Warn(LogHostedLookupFailed, zap.Error(err))
+		return info
+	}
+	info.Identifier = commit.SHA
+	info.HashAlgorithm = vcs.HashAlgorithmSHA1
+	if len(commit.SHA) == 64 {
+		info.HashAlgorithm = vcs.HashAlgorithmSHA256
+	}
+	info.IdentifierShort = commit.SHA[:min(7, len(commit.SHA))]
+	info.IdentifierMedium = commit.SHA[:min(12, len(commit.SHA))]
+	info.BranchName = commit.Branch
+	info.CommitDate = commit.Date
+	info.CommitAuthor = commit.Author
+	info.CommitAuthorEmail = commit.AuthorEmail
+	return info
+}
@@ -0,0 +1,56 @@
+package emit
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/hosting"
+)
+
+// TestHostedVCSInfo_FillsCommitFields validates the hosting API fallback.
+//
+// Why: Builds from a source tarball have no repository, yet emitted
+// versions should still carry the hash, commit date, and branch.
+//
+// What: With vcs.hosted configured, the commit fields come from the API;
+// a failed lookup leaves them empty.
+func TestHostedVCSInfo_FillsCommitFields(t *testing.T) {
+	// Precondition: API knowing one commit
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/app/commits/4f2a9c1e0b7d" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, `{"sha":"4f2a9c1e0b7d3a5c8e6f2b1d9a7c4e3f5b8d0a2c","commit":{"author":{"name":"Ada","email":"ada@example.com","date":"2024-01-15T08:30:00Z"}}}`)
+	}))
+	defer server.Close()
+	t.Setenv(hosting.EnvBranch, "release/1.x")
+	defer hosting.Configure(hosting.Options{}, "")
+	hosting.Configure(hosting.Options{Provider: "github", APIURL: server.URL, Repository: "acme/app", Commit: "4f2a9c1e0b7d"}, "")
+
+	// Action
+	info := hostedVCSInfo(VCSInfo{CommitsSinceTag: -1})
+
+	// Expected
+	if info.Identifier != "4f2a9c1e0b7d3a5c8e6f2b1d9a7c4e3f5b8d0a2c" || info.IdentifierShort != "4f2a9c1" || info.IdentifierMedium != "4f2a9c1e0b7d" {
+		t.Errorf("identifiers = %q, %q, %q", info.Identifier, info.IdentifierShort, info.IdentifierMedium)
+	}
+	if info.BranchName != "release/1.x" || !info.CommitDate.Equal(time.Date(2024, 1, 15, 8, 30, 0, 0, time.UTC)) || info.CommitAuthor != "Ada" {
+		t.Errorf("info = %+v", info)
+	}
+	if info.CommitsSinceTag != -1 {
+		t.Errorf("CommitsSinceTag = %d, want -1 (unknown)", info.CommitsSinceTag)
+	}
+
+	// Action: Unknown commit
+	hosting.Configure(hosting.Options{Provider: "github", APIURL: server.URL, Repository: "acme/app", Commit: "0000000"}, "")
+	info = hostedVCSInfo(VCSInfo{})
+
+	// Expected
+	if info.Identifier != "" {
+		t.Errorf("Identifier = %q, want empty", info.Identifier)
+	}
+}
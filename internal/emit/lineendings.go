@@ -0,0 +1,66 @@
+package emit
+
+import (
+	"runtime"
+	"strings"
+)
+
+// Line ending styles for emitted files
+const (
+	LineEndingsLF     = "lf"
+	LineEndingsCRLF   = "crlf"
+	LineEndingsNative = "native" // crlf on Windows, lf elsewhere
+)
+
+// LineEndingStyles returns the accepted line ending styles
+func LineEndingStyles() []string {
+	return []string{LineEndingsLF, LineEndingsCRLF, LineEndingsNative}
+}
+
+// TextFormat controls the line endings and final newline of an emitted
+// file, for toolchains (e.g. .bat, .ps1, .rc files on Windows) that insist
+// on them. The zero value keeps the content as the template renders it.
+type TextFormat struct {
+	// LineEndings is lf, crlf, or native; empty keeps the rendered endings
+	LineEndings string
+	// FinalNewline, when set, ends the content with exactly one line ending
+	// (true) or none (false)
+	FinalNewline *bool
+}
+
+// Override returns f with the settings o sets replacing those of f
+func (f TextFormat) Override(o TextFormat) TextFormat {
+	if o.LineEndings != "" {
+		f.LineEndings = o.LineEndings
+	}
+	if o.FinalNewline != nil {
+		f.FinalNewline = o.FinalNewline
+	}
+	return f
+}
+
+// Apply returns content with the line endings and final newline of f
+func (f TextFormat) Apply(content string) string {
+	eol := "\n"
+	if f.LineEndings == "" {
+		// Keep the endings; a final newline matches those already used
+		if strings.Contains(content, "\r\n") {
+			eol = "\r\n"
+		}
+	} else {
+		if f.LineEndings == LineEndingsCRLF || f.LineEndings == LineEndingsNative && runtime.GOOS == "windows" {
+			eol = "\r\n"
+		}
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+		if eol != "\n" {
+			content = strings.ReplaceAll(content, "\n", eol)
+		}
+	}
+	if f.FinalNewline != nil {
+		content = strings.TrimRight(content, "\r\n")
+		if *f.FinalNewline {
+			content += eol
+		}
+	}
+	return content
+}
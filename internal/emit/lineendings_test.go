@@ -0,0 +1,59 @@
+package emit
+
+import "testing"
+
+// TestTextFormat_Apply validates line ending and final newline control.
+//
+// Why: Windows toolchains reject LF-only .bat and .rc files, and some
+// linters reject a missing (or an extra) final newline.
+//
+// What: A style normalizes every line ending; FinalNewline adds or strips
+// exactly one ending of the chosen style; the zero value changes nothing.
+func TestTextFormat_Apply(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name    string
+		format  TextFormat
+		content string
+		want    string
+	}{
+		{name: "zero value keeps content", content: "a\r\nb\nc", want: "a\r\nb\nc"},
+		{name: "crlf converts mixed endings", format: TextFormat{LineEndings: LineEndingsCRLF}, content: "a\r\nb\nc\n", want: "a\r\nb\r\nc\r\n"},
+		{name: "lf converts crlf", format: TextFormat{LineEndings: LineEndingsLF}, content: "a\r\nb\r\n", want: "a\nb\n"},
+		{name: "final newline added", format: TextFormat{FinalNewline: &yes}, content: "a\nb", want: "a\nb\n"},
+		{name: "extra final newlines collapsed", format: TextFormat{FinalNewline: &yes}, content: "a\n\n\n", want: "a\n"},
+		{name: "final newline stripped", format: TextFormat{FinalNewline: &no}, content: "a\r\nb\r\n", want: "a\r\nb"},
+		{name: "final newline follows existing crlf", format: TextFormat{FinalNewline: &yes}, content: "a\r\nb", want: "a\r\nb\r\n"},
+		{name: "crlf with final newline", format: TextFormat{LineEndings: LineEndingsCRLF, FinalNewline: &yes}, content: "a\nb", want: "a\r\nb\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action
+			got := tt.format.Apply(tt.content)
+
+			// Expected
+			if got != tt.want {
+				t.Errorf("Apply(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTextFormat_Override validates per-target overrides.
+//
+// Why: A target sets only what differs from the emit-wide settings.
+//
+// What: Set fields of the override win; unset fields keep the base.
+func TestTextFormat_Override(t *testing.T) {
+	// Precondition
+	no := false
+	base := TextFormat{LineEndings: LineEndingsLF, FinalNewline: &no}
+
+	// Action
+	got := base.Override(TextFormat{LineEndings: LineEndingsCRLF})
+
+	// Expected
+	if got.LineEndings != LineEndingsCRLF || got.FinalNewline != &no {
+		t.Errorf("Override = %+v", got)
+	}
+}
@@ -2,14 +2,6 @@
 // Exported so tests can compare against them
 package emit
 
-import "os"
-
-// File permission constants
-const (
-	// FilePermission is the default permission for created files (owner rw, group/other r)
-	FilePermission os.FileMode = 0644
-)
-
 // Error messages
 const (
 	ErrUnsupportedFormat     = "unsupported format"
@@ -19,11 +11,23 @@ const (
 	ErrOutputPathIsDirectory = "is a directory, not a file"
 	ErrParentDirNotExist     = "does not exist"
 	ErrParentNotDirectory    = "is not a directory"
+	ErrCustomVariableCycle   = "custom variables reference each other in a cycle"
+	ErrInvalidSummaryFormat  = "invalid summary format"
+	ErrInvalidStreamFormat   = "invalid stream format"
+	ErrUnknownStyle          = "unknown version style"
+	ErrZeroPaddedPreRelease  = "numeric pre-release identifier is zero-padded"
 )
 
 // Log messages for structured logging
 const (
-	LogTemplateRendered = "template_rendered"
-	LogTemplateWritten  = "template_written"
-	LogEmitCompleted    = "emit_completed"
+	LogTemplateRendered   = "template_rendered"
+	LogTemplateWritten    = "template_written"
+	LogEmitCompleted      = "emit_completed"
+	LogDeprecatedVariable = "deprecated_variable"
+	LogHostedLookupFailed = "hosted_lookup_failed"
+)
+
+// Warning messages
+const (
+	WarnDeprecatedVariable = "deprecated template variable"
 )
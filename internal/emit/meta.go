@@ -0,0 +1,34 @@
+package emit
+
+import (
+	"regexp"
+
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+)
+
+// metaReferencePattern matches {{Meta.<provider>.<key>}} in any tag form
+var metaReferencePattern = regexp.MustCompile(`\{\{\{?\s*[#^&/]?\s*Meta\.([A-Za-z0-9_-]+)\.([A-Za-z0-9_-]+)\s*\}`)
+
+// metadataValues fetches the metadata provider values the templates
+// reference, keyed by provider then key, so values nobody uses are never
+// requested from external systems
+func metadataValues(templates ...string) (map[string]map[string]string, error) {
+	values := map[string]map[string]string{}
+	for _, tmpl := range templates {
+		for _, match := range metaReferencePattern.FindAllStringSubmatch(tmpl, -1) {
+			provider, key := match[1], match[2]
+			if _, done := values[provider][key]; done {
+				continue
+			}
+			value, err := plugin.FetchMetadata(provider, key)
+			if err != nil {
+				return nil, err
+			}
+			if values[provider] == nil {
+				values[provider] = map[string]string{}
+			}
+			values[provider][key] = value
+		}
+	}
+	return values, nil
+}
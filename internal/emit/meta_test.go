@@ -0,0 +1,66 @@
+package emit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+)
+
+// stubMetadataProvider serves fixed values and records requested keys
+type stubMetadataProvider struct {
+	values    map[string]string
+	requested []string
+}
+
+func (s *stubMetadataProvider) Name() string { return "farm" }
+
+func (s *stubMetadataProvider) Types() plugin.PluginTypeSet {
+	return plugin.NewPluginTypeSet(plugin.TypeMetadataProvider)
+}
+
+func (s *stubMetadataProvider) Fetch(ctx context.Context, key string) (string, error) {
+	s.requested = append(s.requested, key)
+	return s.values[key], nil
+}
+
+// TestRenderTemplateWithData_MetaReference_FetchesOnlyReferencedKeys
+// validates {{Meta.<provider>.<key>}} rendering.
+//
+// Why: External systems are slow and may charge per request; only values a
+// template actually uses should be requested.
+//
+// What: Referenced keys, including those in computed custom variables,
+// render; unreferenced keys are never fetched; an unknown provider fails
+// the render.
+func TestRenderTemplateWithData_MetaReference_FetchesOnlyReferencedKeys(t *testing.T) {
+	// Precondition: A provider with two keys
+	provider := &stubMetadataProvider{values: map[string]string{"build": "1042", "unused": "x", "channel": "beta"}}
+	plugin.Register(provider)
+	t.Cleanup(func() {
+		plugin.Unregister("farm")
+		plugin.ResetMetadataCache()
+	})
+	data := TemplateData{MajorMinorPatch: "1.2.3", Custom: map[string]string{"Channel": "{{Meta.farm.channel}}"}}
+
+	// Action: Render a template using one key directly and one via custom
+	out, err := RenderTemplateWithData("{{MajorMinorPatch}}+{{Meta.farm.build}}.{{Channel}}", data)
+
+	// Expected: Both rendered, the unused key never fetched
+	if err != nil || out != "1.2.3+1042.beta" {
+		t.Errorf("got %q, %v", out, err)
+	}
+	for _, key := range provider.requested {
+		if key == "unused" {
+			t.Error("unreferenced key was fetched")
+		}
+	}
+
+	// Action: Reference an unregistered provider
+	_, err = RenderTemplateWithData("{{Meta.nowhere.build}}", TemplateData{})
+
+	// Expected: Render fails
+	if err == nil {
+		t.Error("expected error for unknown provider")
+	}
+}
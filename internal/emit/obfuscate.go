@@ -0,0 +1,76 @@
+package emit
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// obfuscationKeyLength is the number of key bytes cycled over the version
+const obfuscationKeyLength = 16
+
+// obfuscatedTemplateFiles maps formats to templates that store the version
+// XOR-encoded and decode it in a generated accessor function
+var obfuscatedTemplateFiles = map[Format]string{
+	FormatGo:      "templates/obfuscated/go.tmpl",
+	FormatPython:  "templates/obfuscated/python.tmpl",
+	FormatJS:      "templates/obfuscated/js.tmpl",
+	FormatTS:      "templates/obfuscated/ts.tmpl",
+	FormatJava:    "templates/obfuscated/java.tmpl",
+	FormatCSharp:  "templates/obfuscated/csharp.tmpl",
+	FormatRust:    "templates/obfuscated/rust.tmpl",
+	FormatCHeader: "templates/obfuscated/c-header.tmpl",
+}
+
+// ObfuscatedFormats returns the formats that support --obfuscate, sorted
+func ObfuscatedFormats() []string {
+	formats := make([]string, 0, len(obfuscatedTemplateFiles))
+	for format := range obfuscatedTemplateFiles {
+		formats = append(formats, string(format))
+	}
+	slices.Sort(formats)
+	return formats
+}
+
+// GetObfuscatedTemplate returns the obfuscated template for a format
+func GetObfuscatedTemplate(format Format) (string, error) {
+	filename, ok := obfuscatedTemplateFiles[format]
+	if !ok {
+		return "", fmt.Errorf("format %s does not support obfuscation (supported: %s)",
+			format, strings.Join(ObfuscatedFormats(), ", "))
+	}
+	content, err := templateFS.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template %s: %w", filename, err)
+	}
+	return string(content), nil
+}
+
+// Obfuscate sets ObfuscatedVersion and ObfuscationKey on data: the full
+// version (core, pre-release, metadata) XOR-encoded with a key derived from
+// it, as comma-separated byte values. Byte i decodes as
+// data[i] ^ key[i % len(key)]. This keeps the version out of `strings`
+// output; it is not encryption.
+func Obfuscate(data *TemplateData) {
+	plain := []byte(data.MajorMinorPatch + data.PreReleaseWithDash + data.MetadataWithPlus)
+	sum := sha256.Sum256(append([]byte("versionator:"), plain...))
+	key := sum[:obfuscationKeyLength]
+
+	encoded := make([]byte, len(plain))
+	for i, b := range plain {
+		encoded[i] = b ^ key[i%len(key)]
+	}
+	data.ObfuscatedVersion = byteList(encoded)
+	data.ObfuscationKey = byteList(key)
+}
+
+// byteList formats bytes as a comma-separated list of decimal values
+func byteList(b []byte) string {
+	values := make([]string, len(b))
+	for i, v := range b {
+		values[i] = strconv.Itoa(int(v))
+	}
+	return strings.Join(values, ", ")
+}
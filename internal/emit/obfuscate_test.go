@@ -0,0 +1,116 @@
+package emit
+
+import (
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// decodeByteLists reverses Obfuscate using the same rule as the generated
+// accessors: data[i] ^ key[i % len(key)].
+func decodeByteLists(t *testing.T, data, key string) string {
+	t.Helper()
+	parse := func(list string) []byte {
+		var out []byte
+		for _, field := range strings.Split(list, ", ") {
+			n, err := strconv.Atoi(field)
+			if err != nil {
+				t.Fatalf("invalid byte %q: %v", field, err)
+			}
+			out = append(out, byte(n))
+		}
+		return out
+	}
+	encoded, k := parse(data), parse(key)
+	plain := make([]byte, len(encoded))
+	for i, b := range encoded {
+		plain[i] = b ^ k[i%len(k)]
+	}
+	return string(plain)
+}
+
+// TestObfuscate_FullVersion_DecodesToOriginal validates the encoding.
+//
+// Why: Generated accessors decode the byte lists at runtime; if the encoding
+// does not round-trip, programs report a garbled version.
+//
+// What: Decoding ObfuscatedVersion with ObfuscationKey yields the full
+// version including pre-release and metadata.
+func TestObfuscate_FullVersion_DecodesToOriginal(t *testing.T) {
+	// Precondition: Template data for a pre-release with metadata
+	data := TemplateData{
+		MajorMinorPatch:    "1.2.3",
+		PreReleaseWithDash: "-rc.1",
+		MetadataWithPlus:   "+abc1234",
+	}
+
+	// Action: Obfuscate
+	Obfuscate(&data)
+
+	// Expected: Round trip yields the version; the key has the fixed length
+	if got := decodeByteLists(t, data.ObfuscatedVersion, data.ObfuscationKey); got != "1.2.3-rc.1+abc1234" {
+		t.Errorf("decoded %q, want %q", got, "1.2.3-rc.1+abc1234")
+	}
+	if n := len(strings.Split(data.ObfuscationKey, ", ")); n != obfuscationKeyLength {
+		t.Errorf("key has %d bytes, want %d", n, obfuscationKeyLength)
+	}
+}
+
+// TestGetObfuscatedTemplate_AllFormats_HideVersion validates the templates.
+//
+// Why: The point of the option is that the version does not appear verbatim
+// in the generated source (and therefore in the compiled binary).
+//
+// What: Every obfuscated format renders without the plain version and
+// references both byte lists; the Go variant parses.
+func TestGetObfuscatedTemplate_AllFormats_HideVersion(t *testing.T) {
+	for _, format := range ObfuscatedFormats() {
+		// Precondition: Obfuscated data for a known version
+		data := TemplateData{MajorMinorPatch: "10.20.30"}
+		Obfuscate(&data)
+		tmpl, err := GetObfuscatedTemplate(Format(format))
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+
+		// Action: Render the obfuscated template
+		result, err := RenderTemplateWithData(tmpl, data)
+		if err != nil {
+			t.Fatalf("%s: render: %v", format, err)
+		}
+
+		// Expected: Byte lists present, plain version absent
+		if strings.Contains(result, "10.20.30") {
+			t.Errorf("%s: plain version leaked:\n%s", format, result)
+		}
+		if !strings.Contains(result, data.ObfuscatedVersion) || !strings.Contains(result, data.ObfuscationKey) {
+			t.Errorf("%s: byte lists missing:\n%s", format, result)
+		}
+		if format == string(FormatGo) {
+			if _, err := parser.ParseFile(token.NewFileSet(), "version.go", result, 0); err != nil {
+				t.Errorf("go: output does not parse: %v", err)
+			}
+		}
+	}
+}
+
+// TestGetObfuscatedTemplate_UnsupportedFormat_ReturnsError validates rejection.
+//
+// Why: Formats like json or yaml are data files with no accessor to hide the
+// version behind; silently emitting them plain would defeat the option.
+//
+// What: Requesting an obfuscated template for json returns an error naming
+// the supported formats.
+func TestGetObfuscatedTemplate_UnsupportedFormat_ReturnsError(t *testing.T) {
+	// Precondition: A data-only format
+	// Action: Request its obfuscated template
+	_, err := GetObfuscatedTemplate(FormatJSON)
+
+	// Expected: Error listing supported formats
+	if err == nil || !strings.Contains(err.Error(), "does not support obfuscation") ||
+		!strings.Contains(err.Error(), "python") {
+		t.Errorf("expected unsupported-format error, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package emit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/nuget"
+	"github.com/benjaminabbitt/versionator/internal/pep440"
+)
+
+// Version styles an emit target can render the version in
+const (
+	StyleSemVer = "semver"
+	StylePEP440 = "pep440"
+	StyleNuGet  = "nuget"
+)
+
+// Overrides replace parts of the version for one emit target, so targets
+// rendered from the same base version can differ (e.g. a Docker tag without
+// build metadata). Nil fields keep the base value.
+type Overrides struct {
+	// Prefix replaces the version prefix
+	Prefix *string
+	// PreRelease is a Mustache template replacing the pre-release; an empty
+	// template drops it
+	PreRelease *string
+	// Metadata is a Mustache template replacing the metadata; an empty
+	// template drops it
+	Metadata *string
+	// Style converts the pre-release and metadata to another version scheme
+	// ("semver", "pep440", or "nuget"); empty is semver
+	Style string
+}
+
+// ApplyOverrides returns a copy of data with o applied. Override templates
+// render against the base data, then the style converts the result.
+func ApplyOverrides(data TemplateData, o Overrides) (TemplateData, error) {
+	base := NewRenderer(data)
+	if o.Prefix != nil {
+		data.Prefix = *o.Prefix
+	}
+	if o.PreRelease != nil {
+		prerelease, err := base.Render(*o.PreRelease)
+		if err != nil {
+			return data, fmt.Errorf("prerelease: %w", err)
+		}
+		setPreRelease(&data, strings.TrimSpace(prerelease), "-")
+	}
+	if o.Metadata != nil {
+		metadata, err := base.Render(*o.Metadata)
+		if err != nil {
+			return data, fmt.Errorf("metadata: %w", err)
+		}
+		setMetadata(&data, strings.TrimSpace(metadata))
+	}
+
+	switch o.Style {
+	case "", StyleSemVer:
+	case StylePEP440:
+		// PEP 440 segments follow the release directly: 1.2.3rc1, 1.2.3.dev5
+		suffix, local := pep440.Split(data.PreRelease, data.Metadata)
+		setPreRelease(&data, suffix, "")
+		setMetadata(&data, local)
+	case StyleNuGet:
+		// NuGet ignores build metadata, so it is dropped
+		setPreRelease(&data, nuget.Label(data.PreRelease), "-")
+		setMetadata(&data, "")
+	default:
+		return data, fmt.Errorf("%s: %q", ErrUnknownStyle, o.Style)
+	}
+	return data, nil
+}
+
+// setPreRelease sets the pre-release and its separated form
+func setPreRelease(data *TemplateData, prerelease, separator string) {
+	data.PreRelease = prerelease
+	data.PreReleaseWithDash = ""
+	if prerelease != "" {
+		data.PreReleaseWithDash = separator + prerelease
+	}
+}
+
+// setMetadata sets the metadata and its separated form
+func setMetadata(data *TemplateData, metadata string) {
+	data.Metadata = metadata
+	data.MetadataWithPlus = ""
+	if metadata != "" {
+		data.MetadataWithPlus = "+" + metadata
+	}
+}
@@ -0,0 +1,76 @@
+package emit
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestApplyOverrides_RendersTargetVersions validates per-target overrides.
+//
+// Why: One `emit --all` run feeds targets with different rules: Docker tags
+// cannot contain '+', Python needs PEP 440 and NuGet drops build metadata.
+//
+// What: Prefix is replaced literally; prerelease and metadata templates
+// render against the base data and "" drops the part; styles convert the
+// result; no overrides leave the data unchanged.
+func TestApplyOverrides_RendersTargetVersions(t *testing.T) {
+	// Precondition: 1.2.3-rc.1+abc1234 with a v prefix
+	str := func(s string) *string { return &s }
+	base := TemplateData{
+		MajorMinorPatch:    "1.2.3",
+		Prefix:             "v",
+		PreRelease:         "rc.1",
+		PreReleaseWithDash: "-rc.1",
+		Metadata:           "abc1234",
+		MetadataWithPlus:   "+abc1234",
+		ShortHash:          "abc1234",
+		CommitsSinceTag:    "7",
+	}
+
+	tests := []struct {
+		name      string
+		overrides Overrides
+		want      string
+	}{
+		{name: "no overrides", overrides: Overrides{}, want: "v1.2.3-rc.1+abc1234"},
+		{name: "docker tag drops prefix and metadata", overrides: Overrides{Prefix: str(""), Metadata: str("")}, want: "1.2.3-rc.1"},
+		{name: "prerelease template", overrides: Overrides{PreRelease: str("beta.{{CommitsSinceTag}}")}, want: "v1.2.3-beta.7+abc1234"},
+		{name: "pep440 style", overrides: Overrides{Prefix: str(""), Style: StylePEP440}, want: "1.2.3rc1+abc1234"},
+		{name: "nuget style", overrides: Overrides{Prefix: str(""), Style: StyleNuGet}, want: "1.2.3-rc.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action: Apply and render the full version
+			data, err := ApplyOverrides(base, tt.overrides)
+			if err != nil {
+				t.Fatalf("ApplyOverrides failed: %v", err)
+			}
+			got, err := RenderTemplateWithData("{{Prefix}}{{MajorMinorPatch}}{{PreReleaseWithDash}}{{MetadataWithPlus}}", data)
+
+			// Expected: Target version
+			if err != nil {
+				t.Fatalf("RenderTemplateWithData failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestApplyOverrides_UnknownStyle_ReturnsError validates style checking.
+//
+// Why: A misspelled style would otherwise emit SemVer where another scheme
+// was expected.
+//
+// What: An unknown style fails with ErrUnknownStyle.
+func TestApplyOverrides_UnknownStyle_ReturnsError(t *testing.T) {
+	// Action
+	_, err := ApplyOverrides(TemplateData{}, Overrides{Style: "calver"})
+
+	// Expected
+	if err == nil || !strings.Contains(err.Error(), ErrUnknownStyle) {
+		t.Errorf("expected %q, got %v", ErrUnknownStyle, err)
+	}
+}
@@ -0,0 +1,111 @@
+package emit
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// paddedTagPattern matches {{CommitsSinceTagPadded(N)}} and its
+// {{BuildNumberPadded(N)}} alias in any tag form; the submatch is the
+// variable name Mustache looks up
+var paddedTagPattern = regexp.MustCompile(`\{\{\{?\s*[#^&]?\s*((?:CommitsSinceTag|BuildNumber)Padded\(([1-9][0-9]?)\))\s*\}`)
+
+// paddedValues returns the {{CommitsSinceTagPadded(N)}} variables the
+// templates reference: the commit count zero-padded to N digits, empty when
+// the count is unknown
+func paddedValues(data TemplateData, templates ...string) map[string]string {
+	values := map[string]string{}
+	for _, tmpl := range templates {
+		for _, match := range paddedTagPattern.FindAllStringSubmatch(tmpl, -1) {
+			values[match[1]] = padCount(data.CommitsSinceTag, match[2])
+		}
+	}
+	return values
+}
+
+// padCount zero-pads a formatted commit count to width digits
+func padCount(count, width string) string {
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		return ""
+	}
+	w, _ := strconv.Atoi(width)
+	return fmt.Sprintf("%0*d", w, n)
+}
+
+// zeroPaddedTagPattern matches the variables rendering a zero-padded number
+var zeroPaddedTagPattern = regexp.MustCompile(`^\{\{\{?\s*&?\s*(?:BuildNumberPadded|(?:CommitsSinceTag|BuildNumber)Padded\([0-9]+\))\s*\}\}\}?$`)
+
+// ZeroPaddedPreRelease returns the dot-separated identifiers of a
+// pre-release template that render as zero-padded numbers. SemVer forbids
+// leading zeros in numeric pre-release identifiers, and numeric identifiers
+// already compare numerically, so padding only belongs in alphanumeric
+// identifiers (build0042) or in build metadata.
+func ZeroPaddedPreRelease(tmplStr string) []string {
+	var found []string
+	for _, identifier := range templateIdentifiers(tmplStr) {
+		padded, numeric := false, true
+		for _, part := range splitTags(identifier) {
+			switch {
+			case zeroPaddedTagPattern.MatchString(part):
+				padded = true
+			case strings.HasPrefix(part, "{{"), strings.Trim(part, "0123456789") != "":
+				numeric = false
+			}
+		}
+		literalPadded := len(identifier) > 1 && identifier[0] == '0'
+		if numeric && (padded || literalPadded) {
+			found = append(found, identifier)
+		}
+	}
+	return found
+}
+
+// templateIdentifiers splits a template on the dots outside its tags
+func templateIdentifiers(tmplStr string) []string {
+	var identifiers []string
+	var current strings.Builder
+	for _, part := range splitTags(tmplStr) {
+		if strings.HasPrefix(part, "{{") {
+			current.WriteString(part)
+			continue
+		}
+		pieces := strings.Split(part, ".")
+		for i, piece := range pieces {
+			if i > 0 {
+				identifiers = append(identifiers, current.String())
+				current.Reset()
+			}
+			current.WriteString(piece)
+		}
+	}
+	return append(identifiers, current.String())
+}
+
+// splitTags splits a template into its tags and the literal text between
+// them
+func splitTags(tmplStr string) []string {
+	var parts []string
+	for tmplStr != "" {
+		start := strings.Index(tmplStr, "{{")
+		if start < 0 {
+			return append(parts, tmplStr)
+		}
+		end := strings.Index(tmplStr[start:], "}}")
+		if end < 0 {
+			return append(parts, tmplStr)
+		}
+		end += start + 2
+		if end < len(tmplStr) && tmplStr[end] == '}' {
+			end++
+		}
+		if start > 0 {
+			parts = append(parts, tmplStr[:start])
+		}
+		parts = append(parts, tmplStr[start:end])
+		tmplStr = tmplStr[end:]
+	}
+	return parts
+}
@@ -0,0 +1,76 @@
+package emit
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestRenderer_CommitsSinceTagPadded validates configurable padding.
+//
+// Why: BuildNumberPadded is fixed at 4 digits; projects with more commits
+// between releases need wider counts for text ordering to hold.
+//
+// What: The width comes from the tag, BuildNumberPadded(N) is an alias,
+// custom variables may use it, and an unknown count renders empty.
+func TestRenderer_CommitsSinceTagPadded(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   TemplateData
+		tmpl   string
+		expect string
+	}{
+		{name: "width six", data: TemplateData{CommitsSinceTag: "42"}, tmpl: "build{{CommitsSinceTagPadded(6)}}", expect: "build000042"},
+		{name: "alias", data: TemplateData{CommitsSinceTag: "42"}, tmpl: "{{BuildNumberPadded(3)}}", expect: "042"},
+		{name: "count wider than width", data: TemplateData{CommitsSinceTag: "12345"}, tmpl: "{{CommitsSinceTagPadded(2)}}", expect: "12345"},
+		{name: "custom variable", data: TemplateData{CommitsSinceTag: "7", Custom: map[string]string{"Build": "b{{CommitsSinceTagPadded(5)}}"}}, tmpl: "{{Build}}", expect: "b00007"},
+		{name: "unknown count", data: TemplateData{}, tmpl: "[{{CommitsSinceTagPadded(6)}}]", expect: "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action
+			got, err := NewRenderer(tt.data).Render(tt.tmpl)
+
+			// Expected
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if got != tt.expect {
+				t.Errorf("Render(%q) = %q, want %q", tt.tmpl, got, tt.expect)
+			}
+		})
+	}
+}
+
+// TestZeroPaddedPreRelease validates the pre-release padding lint.
+//
+// Why: SemVer forbids leading zeros in numeric pre-release identifiers, so
+// rc.0042 is not a valid version, while build0042 is.
+//
+// What: Only dot-separated identifiers made of padded variables and digits
+// are reported; dots inside tags do not split identifiers.
+func TestZeroPaddedPreRelease(t *testing.T) {
+	tests := []struct {
+		tmpl   string
+		expect []string
+	}{
+		{tmpl: "rc.{{BuildNumberPadded}}", expect: []string{"{{BuildNumberPadded}}"}},
+		{tmpl: "{{CommitsSinceTagPadded(6)}}.{{ShortHash}}", expect: []string{"{{CommitsSinceTagPadded(6)}}"}},
+		{tmpl: "rc.0042", expect: []string{"0042"}},
+		{tmpl: "rc.1{{BuildNumberPadded(3)}}", expect: []string{"1{{BuildNumberPadded(3)}}"}},
+		{tmpl: "build{{CommitsSinceTagPadded(6)}}", expect: nil},
+		{tmpl: "build-{{BuildNumberPadded}}", expect: nil},
+		{tmpl: "rc.{{CommitsSinceTag}}.0", expect: nil},
+		{tmpl: "{{Components.api.Version}}", expect: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tmpl, func(t *testing.T) {
+			// Action
+			got := ZeroPaddedPreRelease(tt.tmpl)
+
+			// Expected
+			if !reflect.DeepEqual(got, tt.expect) {
+				t.Errorf("ZeroPaddedPreRelease(%q) = %q, want %q", tt.tmpl, got, tt.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package emit
+
+import "strings"
+
+// Identifier is one element of the {{#PreReleaseParts}} and {{#MetadataParts}}
+// sections. {{.}} renders its value; Index, First, and Last support joining
+// with other separators, e.g.
+// {{#PreReleaseParts}}{{^First}}_{{/First}}{{.}}{{/PreReleaseParts}}
+type Identifier struct {
+	Value string
+	Index int
+	First bool
+	Last  bool
+}
+
+// String returns the identifier value, so {{.}} renders it
+func (i Identifier) String() string {
+	return i.Value
+}
+
+// preReleaseSeparators split pre-release identifiers; versionator templates
+// separate pre-release items with dashes, SemVer with dots
+const preReleaseSeparators = ".-"
+
+// metadataSeparators split build metadata identifiers
+const metadataSeparators = "."
+
+// splitIdentifiers splits s on any of seps into section elements, skipping
+// empty identifiers
+func splitIdentifiers(s, seps string) []Identifier {
+	values := strings.FieldsFunc(s, func(r rune) bool {
+		return strings.ContainsRune(seps, r)
+	})
+	identifiers := make([]Identifier, len(values))
+	for i, value := range values {
+		identifiers[i] = Identifier{
+			Value: value,
+			Index: i,
+			First: i == 0,
+			Last:  i == len(values)-1,
+		}
+	}
+	return identifiers
+}
@@ -0,0 +1,61 @@
+package emit
+
+import "testing"
+
+// TestRenderTemplateWithData_IdentifierSections_RejoinParts validates the
+// PreReleaseParts and MetadataParts sections.
+//
+// Why: Ecosystems disagree on separators (SemVer dots, NuGet dashes, Python
+// underscores); templates need the identifiers individually to re-join them.
+//
+// What: {{.}} renders each identifier; First and Last allow joining without
+// a stray separator; pre-release splits on dots and dashes, metadata on
+// dots; empty values render nothing.
+func TestRenderTemplateWithData_IdentifierSections_RejoinParts(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		data     TemplateData
+		want     string
+	}{
+		{
+			name:     "each pre-release identifier",
+			template: "{{#PreReleaseParts}}[{{.}}]{{/PreReleaseParts}}",
+			data:     TemplateData{PreRelease: "rc-1.build"},
+			want:     "[rc][1][build]",
+		},
+		{
+			name:     "joined with underscores",
+			template: "{{#PreReleaseParts}}{{^First}}_{{/First}}{{.}}{{/PreReleaseParts}}",
+			data:     TemplateData{PreRelease: "alpha.2"},
+			want:     "alpha_2",
+		},
+		{
+			name:     "metadata keeps dashes within identifiers",
+			template: "{{#MetadataParts}}{{Value}}{{^Last}}/{{/Last}}{{/MetadataParts}}",
+			data:     TemplateData{Metadata: "feature-x.abc1234"},
+			want:     "feature-x/abc1234",
+		},
+		{
+			name:     "empty pre-release",
+			template: "v{{#PreReleaseParts}}-{{.}}{{/PreReleaseParts}}",
+			data:     TemplateData{},
+			want:     "v",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action: Render
+			got, err := RenderTemplateWithData(tt.template, tt.data)
+
+			// Expected: Identifiers re-joined
+			if err != nil {
+				t.Fatalf("RenderTemplateWithData failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package emit
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+
+	"github.com/cbroglie/mustache"
+)
+
+// pluginContext is the template data passed to template providers, every
+// variable as a string; their variables depend only on it, so they are
+// collected once per context, identified by key
+type pluginContext struct {
+	vars map[string]string
+	key  string
+}
+
+// newPluginContext builds the provider context for data
+func newPluginContext(data TemplateData) pluginContext {
+	vars := TemplateDataToStringMap(data)
+	var key strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(vars)) {
+		key.WriteString(name)
+		key.WriteByte(0)
+		key.WriteString(vars[name])
+		key.WriteByte(0)
+	}
+	return pluginContext{vars: vars, key: key.String()}
+}
+
+// pluginValues holds what the template providers return for one context
+type pluginValues struct {
+	vars     map[string]string
+	sections map[string][]map[string]string
+}
+
+// collectPluginValues queries every template provider with c
+func collectPluginValues(c pluginContext) pluginValues {
+	return pluginValues{
+		vars:     plugin.GetAllTemplateVariables(c.vars),
+		sections: plugin.GetAllTemplateSections(c.vars),
+	}
+}
+
+// Escape modes for variable values in rendered output
+const (
+	// EscapeNone writes values as they are (default); branch names and
+	// custom values with & or < survive into generated code
+	EscapeNone = "none"
+	// EscapeHTML replaces & < > " and ' with entities, as Mustache does by
+	// default; {{{Var}}} stays raw
+	EscapeHTML = "html"
+	// EscapeXML is EscapeHTML; its entities are valid XML
+	EscapeXML = "xml"
+)
+
+// Renderer renders several templates against the same TemplateData, as one
+// emit does for the pre-release, metadata, and output templates. The
+// variable map is built once per data instead of once per template, plugin
+// variables are collected once per distinct plugin context, and parsed
+// templates are reused. A Renderer is not safe for concurrent use.
+type Renderer struct {
+	data    TemplateData
+	vars    map[string]interface{}
+	plugins map[string]pluginValues
+	parsed  map[parsedKey]*mustache.Template
+	escape  bool
+	// sections are lists added by the caller, e.g. checksummed files
+	sections map[string][]map[string]string
+}
+
+// parsedKey identifies a parsed template; escaping is fixed when parsing
+type parsedKey struct {
+	tmpl   string
+	escape bool
+}
+
+// NewRenderer returns a Renderer for data that writes values raw
+func NewRenderer(data TemplateData) *Renderer {
+	return &Renderer{
+		data:    data,
+		plugins: map[string]pluginValues{},
+		parsed:  map[parsedKey]*mustache.Template{},
+	}
+}
+
+// Data returns the data templates are rendered with
+func (r *Renderer) Data() TemplateData {
+	return r.data
+}
+
+// SetData replaces the data templates are rendered with (e.g. once the
+// pre-release is rendered); parsed templates and plugin values are kept
+func (r *Renderer) SetData(data TemplateData) {
+	r.data = data
+	r.vars = nil
+}
+
+// With returns a Renderer for data that shares r's parsed templates and
+// plugin values, e.g. for emit targets that each override the version
+func (r *Renderer) With(data TemplateData) *Renderer {
+	return &Renderer{data: data, plugins: r.plugins, parsed: r.parsed, escape: r.escape, sections: r.sections}
+}
+
+// WithSections returns a Renderer sharing r's data and state whose
+// templates also see sections, each a list for {{#Name}}...{{/Name}}
+// (e.g. the files of a checksum manifest)
+func (r *Renderer) WithSections(sections map[string][]map[string]string) *Renderer {
+	extended := *r
+	extended.sections = sections
+	return &extended
+}
+
+// WithEscape returns a Renderer sharing r's data and state that escapes
+// values per mode (EscapeNone, EscapeHTML, EscapeXML; empty is EscapeNone),
+// e.g. for an emit target that writes HTML or XML
+func (r *Renderer) WithEscape(mode string) *Renderer {
+	escaped := *r
+	escaped.escape = mode == EscapeHTML || mode == EscapeXML
+	return &escaped
+}
+
+// Render renders a Mustache template with the Renderer's data
+func (r *Renderer) Render(tmplStr string) (string, error) {
+	warnDeprecatedVariables(tmplStr)
+
+	vars, err := r.variables()
+	if err != nil {
+		return "", err
+	}
+	// {{Meta.<provider>.<key>}} values are fetched only when referenced
+	if metaReferencePattern.MatchString(tmplStr) {
+		meta, err := metadataValues(append([]string{tmplStr}, slices.Collect(maps.Values(r.data.Custom))...)...)
+		if err != nil {
+			return "", err
+		}
+		vars = maps.Clone(vars)
+		vars["Meta"] = meta
+	}
+	// {{Components.<name>.Version}} reads component versions only when
+	// referenced, unless a custom variable already did
+	if _, done := vars["Components"]; !done && referencesComponents(tmplStr) {
+		components, err := componentValues()
+		if err != nil {
+			return "", err
+		}
+		vars = maps.Clone(vars)
+		vars["Components"] = components
+	}
+	if len(r.sections) > 0 {
+		vars = maps.Clone(vars)
+		for name, items := range r.sections {
+			vars[name] = items
+		}
+	}
+	// {{CommitsSinceTagPadded(N)}} names its width, so each is added as
+	// referenced
+	if padded := paddedValues(r.data, tmplStr); len(padded) > 0 {
+		vars = maps.Clone(vars)
+		for name, value := range padded {
+			vars[name] = value
+		}
+	}
+
+	key := parsedKey{tmpl: tmplStr, escape: r.escape}
+	tmpl, ok := r.parsed[key]
+	if !ok {
+		if tmpl, err = mustache.ParseStringRaw(tmplStr, !r.escape); err != nil {
+			return "", fmt.Errorf("failed to render template: %w", err)
+		}
+		r.parsed[key] = tmpl
+	}
+	result, err := tmpl.Render(vars)
+	if err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return result, nil
+}
+
+// variables returns the variable map for the current data, with metadata
+// referenced by custom variables fetched and computed custom variables
+// resolved
+func (r *Renderer) variables() (map[string]interface{}, error) {
+	if r.vars != nil {
+		return r.vars, nil
+	}
+
+	m := buildTemplateMap(r.data, r.pluginValues)
+	meta, err := metadataValues(slices.Collect(maps.Values(r.data.Custom))...)
+	if err != nil {
+		return nil, err
+	}
+	m["Meta"] = meta
+	if referencesComponents(slices.Collect(maps.Values(r.data.Custom))...) {
+		if m["Components"], err = componentValues(); err != nil {
+			return nil, err
+		}
+	}
+	for name, value := range paddedValues(r.data, slices.Collect(maps.Values(r.data.Custom))...) {
+		m[name] = value
+	}
+	if err := resolveComputedCustom(m, r.data.Custom); err != nil {
+		return nil, err
+	}
+	r.vars = m
+	return m, nil
+}
+
+// pluginValues returns the template provider values for c, collecting them
+// on first use
+func (r *Renderer) pluginValues(c pluginContext) pluginValues {
+	if values, ok := r.plugins[c.key]; ok {
+		return values
+	}
+	values := collectPluginValues(c)
+	r.plugins[c.key] = values
+	return values
+}
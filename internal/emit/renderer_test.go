@@ -0,0 +1,232 @@
+package emit
+
+import (
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+)
+
+// countingProvider is a template provider that counts its calls
+type countingProvider struct {
+	calls int
+}
+
+func (p *countingProvider) Name() string { return "counting-test" }
+
+func (p *countingProvider) Types() plugin.PluginTypeSet {
+	return plugin.NewPluginTypeSet(plugin.TypeTemplateProvider)
+}
+
+func (p *countingProvider) GetTemplateVariables(context map[string]string) map[string]string {
+	p.calls++
+	return map[string]string{
+		"CountedPreRelease": "pre=" + context["PreRelease"],
+		"CountedChannel":    context["BranchName"] + "/" + context["Channel"],
+	}
+}
+
+// registerCountingProvider registers a countingProvider for one test
+func registerCountingProvider(tb testing.TB) *countingProvider {
+	tb.Helper()
+	p := &countingProvider{}
+	plugin.Register(p)
+	tb.Cleanup(func() { plugin.Unregister(p.Name()) })
+	return p
+}
+
+// TestRenderer_ReusesPluginValuesPerContext validates memoization.
+//
+// Why: emit renders the pre-release, metadata, and output templates against
+// the same data; querying every plugin for each template repeats work (and
+// external calls) whose result cannot change.
+//
+// What: Several renders with the same data query the provider once; new data
+// with a different pre-release queries it again and sees the new value;
+// the result matches RenderTemplateWithData.
+func TestRenderer_ReusesPluginValuesPerContext(t *testing.T) {
+	// Precondition: A counting provider and base data
+	provider := registerCountingProvider(t)
+	data := TemplateData{MajorMinorPatch: "1.2.3", ShortHash: "abc1234"}
+	r := NewRenderer(data)
+
+	// Action: Three templates against the same data
+	for _, tmpl := range []string{"{{MajorMinorPatch}}", "{{CountedPreRelease}}", "{{ShortHash}}"} {
+		if _, err := r.Render(tmpl); err != nil {
+			t.Fatalf("Render(%q) failed: %v", tmpl, err)
+		}
+	}
+
+	// Expected: One provider query
+	if provider.calls != 1 {
+		t.Errorf("provider queried %d times, want 1", provider.calls)
+	}
+
+	// Action: Data with a pre-release
+	data.PreRelease = "rc.1"
+	r.SetData(data)
+	got, err := r.Render("{{CountedPreRelease}}")
+
+	// Expected: Queried again for the new context
+	if err != nil || got != "pre=rc.1" {
+		t.Errorf("Render = %q, %v; want pre=rc.1", got, err)
+	}
+	if provider.calls != 2 {
+		t.Errorf("provider queried %d times, want 2", provider.calls)
+	}
+	if want, _ := RenderTemplateWithData("{{CountedPreRelease}}", data); got != want {
+		t.Errorf("Renderer %q differs from RenderTemplateWithData %q", got, want)
+	}
+}
+
+// TestRenderer_ProvidersSeeAllVariables validates the provider context.
+//
+// Why: Providers derive variables from more than hashes and the version,
+// e.g. a release channel from the branch or a custom variable.
+//
+// What: A provider reads BranchName and a custom variable from its context.
+func TestRenderer_ProvidersSeeAllVariables(t *testing.T) {
+	// Precondition
+	registerCountingProvider(t)
+	r := NewRenderer(TemplateData{BranchName: "main", Custom: map[string]string{"Channel": "stable"}})
+
+	// Action
+	got, err := r.Render("{{CountedChannel}}")
+
+	// Expected
+	if err != nil || got != "main/stable" {
+		t.Errorf("Render = %q, %v; want main/stable", got, err)
+	}
+}
+
+// TestRenderer_With_KeepsDataSeparate validates derived renderers.
+//
+// Why: emit targets each override the version; a derived renderer must not
+// leak one target's data into another or into the base.
+//
+// What: With renders its own data; the base still renders the original.
+func TestRenderer_With_KeepsDataSeparate(t *testing.T) {
+	// Precondition: Base renderer with a computed custom variable
+	base := NewRenderer(TemplateData{
+		MajorMinorPatch: "1.2.3",
+		Custom:          map[string]string{"Label": "v{{MajorMinorPatch}}"},
+	})
+	if _, err := base.Render("{{Label}}"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action
+	derived, err := base.With(TemplateData{MajorMinorPatch: "2.0.0", Custom: map[string]string{"Label": "v{{MajorMinorPatch}}"}}).Render("{{Label}}")
+	again, _ := base.Render("{{Label}}")
+
+	// Expected
+	if err != nil || derived != "v2.0.0" {
+		t.Errorf("derived = %q, %v; want v2.0.0", derived, err)
+	}
+	if again != "v1.2.3" {
+		t.Errorf("base = %q, want v1.2.3", again)
+	}
+}
+
+// benchmarkTemplates are the templates one emit renders: pre-release,
+// metadata, and a built-in output format
+var benchmarkTemplates = []string{
+	"alpha-{{CommitsSinceTag}}",
+	"{{BuildDateTimeCompact}}.{{ShortHash}}",
+	"",
+}
+
+// benchmarkData returns data shaped like a real emit
+func benchmarkData(b *testing.B) TemplateData {
+	b.Helper()
+	json, err := GetEmbeddedTemplate(FormatJSON)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkTemplates[2] = json
+	return TemplateData{
+		Major: "1", Minor: "2", Patch: "3", MajorMinorPatch: "1.2.3", MajorMinor: "1.2",
+		Hash: "abc1234def0123456789abc1234def0123456789", ShortHash: "abc1234", MediumHash: "abc1234def01",
+		BranchName: "main", EscapedBranchName: "main", CommitsSinceTag: "5", BuildDateTimeCompact: "20241211103045",
+		Custom: map[string]string{"AppName": "demo", "Channel": "{{#Dirty}}dev{{/Dirty}}{{^Dirty}}prod{{/Dirty}}"},
+	}
+}
+
+// BenchmarkRenderTemplateWithData_PerTemplate measures rendering an emit's
+// templates one call at a time, the way emit rendered them before Renderer.
+//
+// Why: Baseline for BenchmarkRenderer_Shared.
+//
+// What: Each template rebuilds the variable map and queries every plugin.
+func BenchmarkRenderTemplateWithData_PerTemplate(b *testing.B) {
+	data := benchmarkData(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, tmpl := range benchmarkTemplates {
+			if _, err := RenderTemplateWithData(tmpl, data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkRenderer_Shared measures rendering the same templates through
+// one Renderer.
+//
+// Why: emit renders three or four templates per invocation; sharing the
+// variable map, plugin values, and parsed templates should cut time and
+// allocations per emit well below the per-template baseline.
+//
+// What: One Renderer per emit renders all templates.
+func BenchmarkRenderer_Shared(b *testing.B) {
+	data := benchmarkData(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := NewRenderer(data)
+		for _, tmpl := range benchmarkTemplates {
+			if _, err := r.Render(tmpl); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// TestRenderer_WithEscape validates escaping of variable values.
+//
+// Why: Branch names and custom values may hold & or <; generated code
+// needs them as-is, HTML and XML outputs need them escaped.
+//
+// What: Values render raw by default and after WithEscape(EscapeNone);
+// html and xml escape them, and the escaped Renderer leaves r raw.
+func TestRenderer_WithEscape(t *testing.T) {
+	// Precondition
+	r := NewRenderer(TemplateData{BranchName: "fix/a&b<c>", Custom: map[string]string{"Quoted": `"{{BranchName}}"`}})
+	tmpl := "{{BranchName}} {{Quoted}}"
+
+	tests := []struct {
+		name     string
+		renderer *Renderer
+		expect   string
+	}{
+		{name: "default", renderer: r, expect: `fix/a&b<c> "fix/a&b<c>"`},
+		{name: "none", renderer: r.WithEscape(EscapeNone), expect: `fix/a&b<c> "fix/a&b<c>"`},
+		{name: "html", renderer: r.WithEscape(EscapeHTML), expect: "fix/a&amp;b&lt;c&gt; &#34;fix/a&amp;b&lt;c&gt;&#34;"},
+		{name: "xml", renderer: r.WithEscape(EscapeXML), expect: "fix/a&amp;b&lt;c&gt; &#34;fix/a&amp;b&lt;c&gt;&#34;"},
+		{name: "raw after escaping", renderer: r, expect: `fix/a&b<c> "fix/a&b<c>"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action
+			got, err := tt.renderer.Render(tmpl)
+
+			// Expected
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if got != tt.expect {
+				t.Errorf("Render = %q, want %q", got, tt.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package emit
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SnapshotQualifier is the Maven qualifier marking development builds
+const SnapshotQualifier = "SNAPSHOT"
+
+// IsSnapshotFormat reports whether format is a Maven/Gradle output that
+// honors the java.snapshot setting
+func IsSnapshotFormat(format Format) bool {
+	return format == FormatJava || format == FormatKotlin
+}
+
+// IsSnapshotFile reports whether path is a Maven/Gradle build file whose
+// configured updates honor the java.snapshot setting
+func IsSnapshotFile(path string) bool {
+	switch filepath.Base(filepath.FromSlash(path)) {
+	case "pom.xml", "gradle.properties":
+		return true
+	}
+	return false
+}
+
+// IsTaggedBuild reports whether data describes a clean build of the commit
+// the last release tag points to
+func IsTaggedBuild(data TemplateData) bool {
+	return data.CommitsSinceTag == "0" && data.Dirty == ""
+}
+
+// AppendSnapshot adds the SNAPSHOT qualifier to the pre-release of data
+// (1.2.3 -> 1.2.3-SNAPSHOT, 1.2.3-rc-1 -> 1.2.3-rc-1-SNAPSHOT) unless it is
+// already present
+func AppendSnapshot(data *TemplateData) {
+	if HasSnapshot(data.PreRelease) {
+		return
+	}
+	if data.PreRelease == "" {
+		data.PreRelease = SnapshotQualifier
+	} else {
+		data.PreRelease += "-" + SnapshotQualifier
+	}
+	data.PreReleaseWithDash = "-" + data.PreRelease
+}
+
+// HasSnapshot reports whether a pre-release contains the SNAPSHOT qualifier
+func HasSnapshot(prerelease string) bool {
+	for _, token := range prereleaseIdentifier.FindAllString(prerelease, -1) {
+		if strings.EqualFold(strings.TrimLeft(token, ".-"), SnapshotQualifier) {
+			return true
+		}
+	}
+	return false
+}
+
+// prereleaseIdentifier matches one pre-release identifier with the separator
+// before it
+var prereleaseIdentifier = regexp.MustCompile(`[.-]?[^.-]+`)
+
+// StripSnapshot removes the SNAPSHOT qualifier (any case) from a pre-release
+// ("rc.1-SNAPSHOT" -> "rc.1", "SNAPSHOT" -> "")
+func StripSnapshot(prerelease string) string {
+	var b strings.Builder
+	for _, token := range prereleaseIdentifier.FindAllString(prerelease, -1) {
+		identifier := strings.TrimLeft(token, ".-")
+		if strings.EqualFold(identifier, SnapshotQualifier) {
+			continue
+		}
+		if b.Len() == 0 {
+			token = identifier
+		}
+		b.WriteString(token)
+	}
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package emit
+
+import "testing"
+
+// TestAppendSnapshot_StripSnapshot_RoundTripsQualifier validates the Maven
+// SNAPSHOT qualifier helpers.
+//
+// Why: Development builds gain -SNAPSHOT at emit time and releases drop it
+// from VERSION; neither may duplicate the qualifier or damage the remaining
+// pre-release identifiers.
+//
+// What: AppendSnapshot appends once and keeps PreReleaseWithDash in sync;
+// StripSnapshot removes the qualifier in any case and position.
+func TestAppendSnapshot_StripSnapshot_RoundTripsQualifier(t *testing.T) {
+	// Precondition: Data with and without a pre-release
+	plain := TemplateData{}
+	rc := TemplateData{PreRelease: "rc-1", PreReleaseWithDash: "-rc-1"}
+
+	// Action: Append (twice for the pre-release)
+	AppendSnapshot(&plain)
+	AppendSnapshot(&rc)
+	AppendSnapshot(&rc)
+
+	// Expected: Qualifier appended once
+	if plain.PreRelease != "SNAPSHOT" || plain.PreReleaseWithDash != "-SNAPSHOT" {
+		t.Errorf("expected SNAPSHOT pre-release, got %+v", plain)
+	}
+	if rc.PreReleaseWithDash != "-rc-1-SNAPSHOT" {
+		t.Errorf("expected -rc-1-SNAPSHOT, got %q", rc.PreReleaseWithDash)
+	}
+
+	// Action & Expected: Strip
+	for prerelease, want := range map[string]string{
+		"SNAPSHOT":        "",
+		"rc.1-SNAPSHOT":   "rc.1",
+		"snapshot.alpha":  "alpha",
+		"alpha-SNAPSHOTS": "alpha-SNAPSHOTS",
+	} {
+		if got := StripSnapshot(prerelease); got != want {
+			t.Errorf("StripSnapshot(%q) = %q, want %q", prerelease, got, want)
+		}
+	}
+}
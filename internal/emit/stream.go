@@ -0,0 +1,55 @@
+package emit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StdoutPath as the output path streams rendered targets to stdout instead
+// of writing files
+const StdoutPath = "-"
+
+// Stream formats for `output emit --all --output -`
+const (
+	StreamYAML = "yaml"
+	StreamJSON = "json"
+)
+
+// Document is one rendered target, streamed instead of written, so build
+// systems (Bazel rules, Nix builders) can place the files themselves
+type Document struct {
+	Target  string `json:"target" yaml:"target"`
+	Path    string `json:"path" yaml:"path"`
+	Content string `json:"content" yaml:"content"`
+}
+
+// WriteStream prints docs as one YAML document each (StreamYAML) or as a
+// JSON array (StreamJSON)
+func WriteStream(w io.Writer, format string, docs []Document) error {
+	switch format {
+	case StreamJSON:
+		if docs == nil {
+			docs = []Document{}
+		}
+		out, err := json.MarshalIndent(docs, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode emit stream: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	case StreamYAML, "":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		for _, doc := range docs {
+			if err := enc.Encode(doc); err != nil {
+				return fmt.Errorf("failed to encode emit stream: %w", err)
+			}
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("%s: %q (use %s or %s)", ErrInvalidStreamFormat, format, StreamYAML, StreamJSON)
+	}
+}
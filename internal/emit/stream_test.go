@@ -0,0 +1,49 @@
+package emit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestWriteStream_YAML_OneDocumentPerTarget validates the YAML stream.
+//
+// Why: Orchestrators read the stream document by document; multi-line file
+// content must survive the round trip byte for byte.
+//
+// What: Two documents are separated by "---" and decode to the original
+// targets; unknown formats fail.
+func TestWriteStream_YAML_OneDocumentPerTarget(t *testing.T) {
+	// Precondition
+	docs := []Document{
+		{Target: "python", Path: "_version.py", Content: "__version__ = \"1.2.3\"\n"},
+		{Target: "tag", Path: "TAG", Content: "v1.2.3"},
+	}
+
+	// Action
+	var out bytes.Buffer
+	err := WriteStream(&out, StreamYAML, docs)
+
+	// Expected
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec := yaml.NewDecoder(strings.NewReader(out.String()))
+	for i, want := range docs {
+		var got Document
+		if err := dec.Decode(&got); err != nil {
+			t.Fatalf("document %d: %v\n%s", i, err, out.String())
+		}
+		if got != want {
+			t.Errorf("document %d = %+v, want %+v", i, got, want)
+		}
+	}
+	if !strings.Contains(out.String(), "\n---\n") {
+		t.Errorf("expected a document separator:\n%s", out.String())
+	}
+	if err := WriteStream(&out, "toml", docs); err == nil || !strings.Contains(err.Error(), ErrInvalidStreamFormat) {
+		t.Errorf("expected %s, got %v", ErrInvalidStreamFormat, err)
+	}
+}
@@ -0,0 +1,74 @@
+package emit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+)
+
+// Summary formats for `output emit --summary`
+const (
+	SummaryTable = "table"
+	SummaryJSON  = "json"
+)
+
+// Written describes one file written by emit
+type Written struct {
+	Target  string `json:"target"`
+	Format  string `json:"format"`
+	Path    string `json:"path"`
+	Bytes   int    `json:"bytes"`
+	Changed bool   `json:"changed"`
+}
+
+// WriteIfChanged writes content to path unless the file already holds
+// exactly that content, so unchanged outputs keep their timestamps. It
+// reports the bytes in content and whether the file changed.
+func WriteIfChanged(content, path string) (int, bool, error) {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, []byte(content)) {
+		return len(content), false, nil
+	}
+	if err := WriteToFile(content, path); err != nil {
+		return 0, false, err
+	}
+	return len(content), true, nil
+}
+
+// WriteSummary prints the written files as a table (SummaryTable) or a JSON
+// array (SummaryJSON)
+func WriteSummary(w io.Writer, format string, written []Written) error {
+	switch format {
+	case SummaryJSON:
+		if written == nil {
+			written = []Written{}
+		}
+		out, err := json.MarshalIndent(written, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode emit summary: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	case SummaryTable, "":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "TARGET\tFORMAT\tPATH\tBYTES\tSTATUS")
+		changed := 0
+		for _, f := range written {
+			status := "unchanged"
+			if f.Changed {
+				status = "changed"
+				changed++
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", f.Target, f.Format, f.Path, f.Bytes, status)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintf(w, "%d file(s) written, %d changed\n", len(written), changed)
+		return err
+	default:
+		return fmt.Errorf("%s: %q (use %s or %s)", ErrInvalidSummaryFormat, format, SummaryTable, SummaryJSON)
+	}
+}
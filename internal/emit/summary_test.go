@@ -0,0 +1,109 @@
+package emit
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestWriteIfChanged_SameContent_LeavesFileUnchanged validates change
+// detection for emitted files.
+//
+// Why: Rewriting identical files bumps their timestamps and triggers
+// needless rebuilds; the summary must also say which files really changed.
+//
+// What: The first write reports changed, an identical write reports
+// unchanged, and new content reports changed again; bytes is the content
+// length each time.
+func TestWriteIfChanged_SameContent_LeavesFileUnchanged(t *testing.T) {
+	// Precondition: No file yet
+	path := filepath.Join(t.TempDir(), "version.txt")
+
+	steps := []struct {
+		content string
+		changed bool
+	}{
+		{"1.0.0\n", true},
+		{"1.0.0\n", false},
+		{"1.0.1\n", true},
+	}
+	for i, step := range steps {
+		// Action: Write the content
+		n, changed, err := WriteIfChanged(step.content, path)
+
+		// Expected: Change reported only when the content differs
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if n != len(step.content) || changed != step.changed {
+			t.Errorf("step %d: got (%d, %v), want (%d, %v)", i, n, changed, len(step.content), step.changed)
+		}
+		got, _ := os.ReadFile(path)
+		if string(got) != step.content {
+			t.Errorf("step %d: file holds %q", i, got)
+		}
+	}
+}
+
+// TestWriteSummary_Formats_ReportWrittenFiles validates the summary output.
+//
+// Why: CI logs need a readable table and scripts need stable JSON to see
+// what emit generated.
+//
+// What: The table lists each file with its status and a totals line; JSON
+// is an array of the entries (empty, not null, when nothing was written);
+// unknown formats fail.
+func TestWriteSummary_Formats_ReportWrittenFiles(t *testing.T) {
+	// Precondition: One changed and one unchanged file
+	written := []Written{
+		{Target: "go", Format: "go", Path: "version.go", Bytes: 42, Changed: true},
+		{Target: "docs", Format: "template", Path: "docs/VERSION.md", Bytes: 7},
+	}
+
+	// Action: Print the table
+	var table bytes.Buffer
+	err := WriteSummary(&table, SummaryTable, written)
+
+	// Expected: Header, rows, and totals
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "TARGET") {
+		t.Fatalf("unexpected table:\n%s", table.String())
+	}
+	if !strings.Contains(lines[1], "version.go") || !strings.HasSuffix(lines[1], "changed") {
+		t.Errorf("unexpected row: %q", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], "unchanged") {
+		t.Errorf("unexpected row: %q", lines[2])
+	}
+	if lines[3] != "2 file(s) written, 1 changed" {
+		t.Errorf("unexpected totals: %q", lines[3])
+	}
+
+	// Action: Print JSON, with entries and with none
+	var full, empty bytes.Buffer
+	_ = WriteSummary(&full, SummaryJSON, written)
+	_ = WriteSummary(&empty, SummaryJSON, nil)
+
+	// Expected: Round-trips the entries; empty array when none
+	var decoded []Written
+	if err := json.Unmarshal(full.Bytes(), &decoded); err != nil || len(decoded) != 2 || decoded[0] != written[0] {
+		t.Errorf("unexpected JSON %s (%v)", full.String(), err)
+	}
+	if strings.TrimSpace(empty.String()) != "[]" {
+		t.Errorf("expected [], got %q", empty.String())
+	}
+
+	// Action: Unknown format
+	err = WriteSummary(&bytes.Buffer{}, "xml", written)
+
+	// Expected: Refused
+	if err == nil || !strings.Contains(err.Error(), ErrInvalidSummaryFormat) {
+		t.Errorf("expected %q, got %v", ErrInvalidSummaryFormat, err)
+	}
+}
@@ -0,0 +1,89 @@
+// Package fileperm is the single place that decides the mode of files
+// versionator writes (VERSION, config, emitted sources, CI outputs).
+package fileperm
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/benjaminabbitt/versionator/internal/platform"
+)
+
+// Default is the mode for created files (owner rw, group/other r), reduced
+// by the process umask as usual
+const Default os.FileMode = 0644
+
+var (
+	modeMu   sync.RWMutex
+	mode     = Default
+	explicit bool
+)
+
+// Configure sets the mode for written files from an octal string such as
+// "0600". An empty string restores Default.
+//
+// A configured mode is also applied to files that already exist (still
+// reduced by the umask), so switching to 0600 tightens earlier outputs.
+func Configure(value string) error {
+	if value == "" {
+		SetMode(Default, false)
+		return nil
+	}
+	parsed, err := strconv.ParseUint(value, 8, 32)
+	if err != nil || parsed > 0o777 {
+		return fmt.Errorf("%s: %q (expected octal such as 0644 or 0600)", ErrInvalidMode, value)
+	}
+	SetMode(os.FileMode(parsed), true)
+	return nil
+}
+
+// SetMode sets the mode for written files; explicit modes are also applied
+// to existing files
+func SetMode(m os.FileMode, isExplicit bool) {
+	modeMu.Lock()
+	defer modeMu.Unlock()
+	mode = m
+	explicit = isExplicit
+}
+
+// Mode returns the mode for written files
+func Mode() os.FileMode {
+	modeMu.RLock()
+	defer modeMu.RUnlock()
+	return mode
+}
+
+// WriteFile writes data to path with the configured mode
+func WriteFile(path string, data []byte) error {
+	if err := os.WriteFile(path, data, Mode()); err != nil {
+		return err
+	}
+	return enforce(path)
+}
+
+// Create creates or truncates path with the configured mode
+func Create(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, Mode())
+	if err != nil {
+		return nil, err
+	}
+	if err := enforce(path); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+// enforce applies an explicitly configured mode to path, which os.WriteFile
+// only uses when it creates the file
+func enforce(path string) error {
+	modeMu.RLock()
+	m, isExplicit := mode, explicit
+	modeMu.RUnlock()
+	if !isExplicit {
+		return nil
+	}
+	return platform.Chmod(path, m)
+}
@@ -0,0 +1,110 @@
+package fileperm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/platform"
+)
+
+// TestConfigure_ExplicitMode_AppliesToNewAndExistingFiles validates the mode.
+//
+// Why: Outputs can carry sensitive build data; a configured 0600 must hold
+// for files that already existed with 0644, not only for new ones.
+//
+// What: With 0600 configured, WriteFile and Create leave both new and
+// pre-existing files at 0600 (umask permitting).
+func TestConfigure_ExplicitMode_AppliesToNewAndExistingFiles(t *testing.T) {
+	if !platform.SupportsPermissions {
+		t.Skip("permission bits do not apply on this platform")
+	}
+	// Precondition: An existing world-readable file and mode 0600
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing")
+	if err := os.WriteFile(existing, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Configure("0600"); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	t.Cleanup(func() { _ = Configure("") })
+
+	// Action: Overwrite the existing file and create a new one
+	if err := WriteFile(existing, []byte("new")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	created := filepath.Join(dir, "created")
+	file, err := Create(created)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	file.Close()
+
+	// Expected: Both files are owner-only
+	for _, path := range []string{existing, created} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := info.Mode().Perm(); got != 0600 {
+			t.Errorf("%s: mode %o, want 600", filepath.Base(path), got)
+		}
+	}
+}
+
+// TestConfigure_Default_LeavesExistingModes validates the default.
+//
+// Why: Without configuration versionator must not change modes it did not
+// create (e.g. an executable script kept in sync with VERSION).
+//
+// What: With no mode configured, overwriting a 0755 file keeps 0755.
+func TestConfigure_Default_LeavesExistingModes(t *testing.T) {
+	if !platform.SupportsPermissions {
+		t.Skip("permission bits do not apply on this platform")
+	}
+	// Precondition: Default mode and an executable file
+	if err := Configure(""); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "script.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action: Overwrite it
+	if err := WriteFile(path, []byte("#!/bin/sh\necho 1\n")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	// Expected: Mode unchanged
+	info, _ := os.Stat(path)
+	if got := info.Mode().Perm(); got != 0755 {
+		t.Errorf("mode %o, want 755", got)
+	}
+}
+
+// TestConfigure_InvalidMode_ReturnsError validates parsing.
+//
+// Why: A typo such as "644x" or a decimal "420" must not silently produce an
+// unexpected mode.
+//
+// What: Non-octal or out-of-range values are rejected and Mode is unchanged.
+func TestConfigure_InvalidMode_ReturnsError(t *testing.T) {
+	for _, value := range []string{"644x", "0999", "01777", "rw-r--r--"} {
+		// Action: Configure an invalid value
+		err := Configure(value)
+
+		// Expected: Error, default mode kept
+		if err == nil || !strings.Contains(err.Error(), ErrInvalidMode) {
+			t.Errorf("%q: expected invalid mode error, got %v", value, err)
+		}
+		if Mode() != Default {
+			t.Errorf("%q: mode changed to %o", value, Mode())
+		}
+	}
+}
@@ -0,0 +1,8 @@
+// Package fileperm messages - error message constants
+// Exported so tests can compare against them
+package fileperm
+
+// Error messages
+const (
+	ErrInvalidMode = "invalid file mode"
+)
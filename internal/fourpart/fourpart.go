@@ -0,0 +1,47 @@
+// Package fourpart provides the "four-part" versioning scheme, for projects
+// that version as Major.Minor.Patch.Revision everywhere (e.g. .NET assembly
+// and Windows file versions).
+//
+// With `scheme: four-part` in .versionator.yaml, VERSION files must have four
+// segments, the revision can be bumped and set like any other level
+// ('bump revision', 'set-component revision 7'), and emitted versions carry
+// all four segments. Bumping a higher level resets the revision to 0.
+package fourpart
+
+import "github.com/benjaminabbitt/versionator/internal/plugin"
+
+// Scheme is the name selecting this scheme in .versionator.yaml
+const Scheme = "four-part"
+
+// Plugin declares the four-part versioning scheme
+type Plugin struct{}
+
+// NewPlugin creates a Plugin
+func NewPlugin() *Plugin {
+	return &Plugin{}
+}
+
+// Name returns "four-part"
+func (p *Plugin) Name() string {
+	return Scheme
+}
+
+// Types returns the set of plugin types this plugin implements
+func (p *Plugin) Types() plugin.PluginTypeSet {
+	return plugin.NewPluginTypeSet(plugin.TypeVersioning)
+}
+
+// Scheme returns "four-part"
+func (p *Plugin) Scheme() string {
+	return Scheme
+}
+
+// Segments returns 4: Major.Minor.Patch.Revision
+func (p *Plugin) Segments() int {
+	return 4
+}
+
+// Auto-registration as a versioning plugin
+func init() {
+	plugin.Register(NewPlugin())
+}
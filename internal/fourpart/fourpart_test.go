@@ -0,0 +1,30 @@
+package fourpart
+
+import (
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+)
+
+// TestInit_RegistersFourPartScheme validates auto-registration.
+//
+// Why: `scheme: four-part` only works if importing the package registers the
+// plugin under that name.
+//
+// What: The registry resolves "four-part" to a versioning plugin with four
+// segments.
+func TestInit_RegistersFourPartScheme(t *testing.T) {
+	// Action: Look up the scheme
+	p := plugin.GetVersioningPlugin(Scheme)
+
+	// Expected: Registered, typed as versioning, four segments
+	if p == nil {
+		t.Fatal("four-part scheme not registered")
+	}
+	if !p.Types().Contains(plugin.TypeVersioning) {
+		t.Errorf("expected TypeVersioning, got %v", p.Types().Slice())
+	}
+	if p.Segments() != 4 {
+		t.Errorf("expected 4 segments, got %d", p.Segments())
+	}
+}
@@ -0,0 +1,272 @@
+// Package history builds and renders the release history of a repository
+// from its version tags.
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+)
+
+// Format represents a supported graph output format
+type Format string
+
+const (
+	FormatASCII Format = "ascii"
+	FormatDOT   Format = "dot"
+	FormatJSON  Format = "json"
+)
+
+// AvailableFormats returns the names of all supported graph formats
+func AvailableFormats() []string {
+	return []string{string(FormatASCII), string(FormatDOT), string(FormatJSON)}
+}
+
+// Entry is a single version tag in the history
+type Entry struct {
+	Tag      string    `json:"tag"`
+	Version  string    `json:"version"`
+	Commit   string    `json:"commit"`
+	Date     time.Time `json:"date"`
+	Branches []string  `json:"branches"`
+}
+
+// Build converts VCS tags into history entries. Tags that do not follow
+// release.tagFormat or do not parse as versions (per the looseVersions mode)
+// are skipped. Entries keep the order of the input tags.
+func Build(tags []vcs.TagRef) []Entry {
+	entries := make([]Entry, 0, len(tags))
+	for _, t := range tags {
+		name, ok := tagformat.Version(t.Name)
+		if !ok {
+			continue
+		}
+		v, _, err := version.ParseInput(name)
+		if err != nil {
+			continue
+		}
+		branches := t.Branches
+		if len(branches) == 0 {
+			branches = []string{DetachedBranch}
+		}
+		entries = append(entries, Entry{
+			Tag:      t.Name,
+			Version:  v.String(),
+			Commit:   t.Commit,
+			Date:     t.Date,
+			Branches: branches,
+		})
+	}
+	return entries
+}
+
+// ByBranch groups entries by branch, preserving entry order within each branch.
+// Returns the grouped entries and the sorted list of branch names.
+func ByBranch(entries []Entry) (map[string][]Entry, []string) {
+	groups := make(map[string][]Entry)
+	for _, e := range entries {
+		for _, b := range e.Branches {
+			groups[b] = append(groups[b], e)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return groups, names
+}
+
+// Latest returns the entries with the highest released version and the
+// highest pre-release version by SemVer precedence. When branch is non-empty,
+// only entries on that branch are considered. Either result may be nil.
+func Latest(entries []Entry, branch string) (release, prerelease *Entry) {
+	var bestRelease, bestPre *version.Version
+	for i := range entries {
+		e := &entries[i]
+		if branch != "" && !slices.Contains(e.Branches, branch) {
+			continue
+		}
+		v, err := version.ParseStrict(e.Version)
+		if err != nil {
+			continue
+		}
+		if v.IsPreRelease() {
+			if bestPre == nil || v.Compare(bestPre) > 0 {
+				bestPre, prerelease = v, e
+			}
+		} else if bestRelease == nil || v.Compare(bestRelease) > 0 {
+			bestRelease, release = v, e
+		}
+	}
+	return release, prerelease
+}
+
+// Highest returns the entry with the highest version by SemVer precedence,
+// release or pre-release, or nil when there are no entries
+func Highest(entries []Entry) *Entry {
+	release, prerelease := Latest(entries, "")
+	if release == nil || prerelease == nil {
+		if release != nil {
+			return release
+		}
+		return prerelease
+	}
+	pre, rel := version.Parse(prerelease.Version), version.Parse(release.Version)
+	if pre.Compare(&rel) > 0 {
+		return prerelease
+	}
+	return release
+}
+
+// Previous returns the entry with the highest version strictly lower than
+// current by SemVer precedence, or nil when no tag precedes it. Pre-release
+// tags count, so 1.3.0-rc.1 precedes 1.3.0.
+func Previous(entries []Entry, current *version.Version) *Entry {
+	var best *version.Version
+	var previous *Entry
+	for i := range entries {
+		e := &entries[i]
+		v, err := version.ParseStrict(e.Version)
+		if err != nil || v.Compare(current) >= 0 {
+			continue
+		}
+		if best == nil || v.Compare(best) > 0 {
+			best, previous = v, e
+		}
+	}
+	return previous
+}
+
+// Render writes the history in the requested format
+func Render(w io.Writer, entries []Entry, format Format) error {
+	switch format {
+	case FormatASCII:
+		return renderASCII(w, entries)
+	case FormatDOT:
+		return renderDOT(w, entries)
+	case FormatJSON:
+		return renderJSON(w, entries)
+	default:
+		return fmt.Errorf("%s: %s (available: %s)", ErrUnsupportedFormat, format, strings.Join(AvailableFormats(), ", "))
+	}
+}
+
+// renderASCII draws one timeline per branch, oldest tag first
+func renderASCII(w io.Writer, entries []Entry) error {
+	if len(entries) == 0 {
+		_, err := fmt.Fprintln(w, "No version tags found")
+		return err
+	}
+
+	groups, names := ByBranch(entries)
+	var sb strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%s\n", name)
+		for j, e := range groups[name] {
+			if j > 0 {
+				sb.WriteString("|\n")
+			}
+			fmt.Fprintf(&sb, "* %s  %s  (%s)\n", formatDate(e.Date), e.Tag, shortCommit(e.Commit))
+		}
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("%s: %w", ErrRenderFailed, err)
+	}
+	return nil
+}
+
+// renderDOT emits a Graphviz digraph with one cluster per branch and edges
+// linking consecutive tags on that branch
+func renderDOT(w io.Writer, entries []Entry) error {
+	groups, names := ByBranch(entries)
+
+	var sb strings.Builder
+	sb.WriteString("digraph versions {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+
+	for _, e := range entries {
+		fmt.Fprintf(&sb, "  %q [label=%q];\n", e.Tag, e.Tag+"\n"+formatDate(e.Date))
+	}
+
+	for i, name := range names {
+		fmt.Fprintf(&sb, "  subgraph %q {\n", fmt.Sprintf("cluster_%d", i))
+		fmt.Fprintf(&sb, "    label=%q;\n", name)
+		branch := groups[name]
+		for _, e := range branch {
+			fmt.Fprintf(&sb, "    %q;\n", e.Tag)
+		}
+		for j := 1; j < len(branch); j++ {
+			fmt.Fprintf(&sb, "    %q -> %q;\n", branch[j-1].Tag, branch[j].Tag)
+		}
+		sb.WriteString("  }\n")
+	}
+	sb.WriteString("}\n")
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("%s: %w", ErrRenderFailed, err)
+	}
+	return nil
+}
+
+// renderJSON emits the flat tag list along with the per-branch grouping
+func renderJSON(w io.Writer, entries []Entry) error {
+	if entries == nil {
+		entries = []Entry{}
+	}
+
+	groups, _ := ByBranch(entries)
+	branches := make(map[string][]string, len(groups))
+	for name, group := range groups {
+		tags := make([]string, len(group))
+		for i, e := range group {
+			tags[i] = e.Tag
+		}
+		branches[name] = tags
+	}
+
+	out := struct {
+		Tags     []Entry             `json:"tags"`
+		Branches map[string][]string `json:"branches"`
+	}{
+		Tags:     entries,
+		Branches: branches,
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("%s: %w", ErrRenderFailed, err)
+	}
+	return nil
+}
+
+// formatDate renders a tag date, tolerating tags whose commit date is unknown
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "unknown   "
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+// shortCommit truncates a commit hash for display
+func shortCommit(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
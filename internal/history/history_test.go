@@ -0,0 +1,348 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+)
+
+// sampleTags returns a small release history spanning two branches
+func sampleTags() []vcs.TagRef {
+	return []vcs.TagRef{
+		{Name: "v1.0.0", Commit: "aaaaaaaaaaaaaaaaaaaa", Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Branches: []string{"feature", "main"}},
+		{Name: "not-a-version", Commit: "bbbbbbbbbbbbbbbbbbbb", Date: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), Branches: []string{"main"}},
+		{Name: "v1.1.0", Commit: "cccccccccccccccccccc", Date: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC), Branches: []string{"main"}},
+		{Name: "v2.0.0-rc.1", Commit: "dddddddddddddddddddd", Date: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), Branches: []string{"feature"}},
+	}
+}
+
+// TestBuild_SkipsNonVersionTags validates that Build only keeps tags that
+// parse as versions.
+//
+// Why: Repositories often carry tags unrelated to releases (deploy markers,
+// experiments). Including them would clutter the release cadence graph.
+//
+// What: Given a mix of version and non-version tags, only version tags are
+// returned, in input order, with the prefix stripped from Version.
+func TestBuild_SkipsNonVersionTags(t *testing.T) {
+	// Precondition: Tags including one that is not a version
+	tags := sampleTags()
+
+	// Action: Build history entries
+	entries := Build(tags)
+
+	// Expected: Three version entries in input order
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	want := []string{"v1.0.0", "v1.1.0", "v2.0.0-rc.1"}
+	for i, e := range entries {
+		if e.Tag != want[i] {
+			t.Errorf("entries[%d].Tag = %q, want %q", i, e.Tag, want[i])
+		}
+	}
+	if entries[0].Version != "1.0.0" {
+		t.Errorf("expected Version 1.0.0, got %q", entries[0].Version)
+	}
+}
+
+// TestBuild_NoBranches_GroupsUnderDetached validates that tags not reachable
+// from any local branch are still reported.
+//
+// Why: Tags on deleted or remote-only branches are part of the release history
+// and should not silently disappear from the graph.
+//
+// What: A tag with no branches is assigned to DetachedBranch.
+func TestBuild_NoBranches_GroupsUnderDetached(t *testing.T) {
+	// Precondition: A tag with no containing branches
+	tags := []vcs.TagRef{{Name: "v0.1.0", Commit: "abc"}}
+
+	// Action: Build history entries
+	entries := Build(tags)
+
+	// Expected: The entry is grouped under the detached pseudo-branch
+	if len(entries) != 1 || len(entries[0].Branches) != 1 || entries[0].Branches[0] != DetachedBranch {
+		t.Errorf("expected entry on %q, got %+v", DetachedBranch, entries)
+	}
+}
+
+// TestRender_ASCII_ShowsTimelinePerBranch validates the ASCII timeline layout.
+//
+// Why: The ASCII graph is the default human-readable output and must show
+// each branch's tags in chronological order.
+//
+// What: Each branch is printed as a header followed by its tags, with dates
+// and short commit hashes.
+func TestRender_ASCII_ShowsTimelinePerBranch(t *testing.T) {
+	// Precondition: Entries on two branches
+	entries := Build(sampleTags())
+
+	// Action: Render ASCII
+	var buf bytes.Buffer
+	if err := Render(&buf, entries, FormatASCII); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	// Expected: Branches are sorted and tags listed in order
+	expected := `feature
+* 2024-01-02  v1.0.0  (aaaaaaa)
+|
+* 2024-03-04  v2.0.0-rc.1  (ddddddd)
+
+main
+* 2024-01-02  v1.0.0  (aaaaaaa)
+|
+* 2024-02-03  v1.1.0  (ccccccc)
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected ASCII output:\n%s\nwant:\n%s", buf.String(), expected)
+	}
+}
+
+// TestRender_ASCII_NoEntries_PrintsMessage validates the empty history case.
+//
+// Why: Running graph in a repository without releases should explain the
+// empty output rather than printing nothing.
+//
+// What: With no entries, a "No version tags found" message is printed.
+func TestRender_ASCII_NoEntries_PrintsMessage(t *testing.T) {
+	// Precondition: No entries
+	var buf bytes.Buffer
+
+	// Action: Render ASCII
+	if err := Render(&buf, nil, FormatASCII); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	// Expected: Explanatory message
+	if !strings.Contains(buf.String(), "No version tags found") {
+		t.Errorf("expected empty-history message, got %q", buf.String())
+	}
+}
+
+// TestRender_DOT_ProducesDigraphWithBranchEdges validates the Graphviz output.
+//
+// Why: DOT output is fed to graphviz; nodes and edges must be well formed and
+// edges must follow each branch's chronological order.
+//
+// What: Output is a digraph with a node per tag and an edge between
+// consecutive tags on each branch.
+func TestRender_DOT_ProducesDigraphWithBranchEdges(t *testing.T) {
+	// Precondition: Entries on two branches
+	entries := Build(sampleTags())
+
+	// Action: Render DOT
+	var buf bytes.Buffer
+	if err := Render(&buf, entries, FormatDOT); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	out := buf.String()
+
+	// Expected: Digraph wrapper, branch clusters, and per-branch edges
+	for _, want := range []string{
+		"digraph versions {",
+		`label="main";`,
+		`label="feature";`,
+		`"v1.0.0" -> "v1.1.0";`,
+		`"v1.0.0" -> "v2.0.0-rc.1";`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `"v1.1.0" -> "v2.0.0-rc.1"`) {
+		t.Errorf("DOT output links tags from different branches:\n%s", out)
+	}
+}
+
+// TestRender_JSON_IncludesTagsAndBranches validates the JSON structure.
+//
+// Why: JSON output feeds dashboards, so its shape is a contract.
+//
+// What: Output contains the flat tag list and a branch-to-tags mapping.
+func TestRender_JSON_IncludesTagsAndBranches(t *testing.T) {
+	// Precondition: Entries on two branches
+	entries := Build(sampleTags())
+
+	// Action: Render JSON
+	var buf bytes.Buffer
+	if err := Render(&buf, entries, FormatJSON); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	// Expected: Decodes into tags and branches
+	var out struct {
+		Tags     []Entry             `json:"tags"`
+		Branches map[string][]string `json:"branches"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(out.Tags) != 3 {
+		t.Errorf("expected 3 tags, got %d", len(out.Tags))
+	}
+	if got := strings.Join(out.Branches["main"], ","); got != "v1.0.0,v1.1.0" {
+		t.Errorf("main branch tags = %q, want v1.0.0,v1.1.0", got)
+	}
+}
+
+// TestRender_UnsupportedFormat_ReturnsError validates format validation.
+//
+// Why: A typo in --format should fail loudly instead of producing no output.
+//
+// What: An unknown format returns an error mentioning ErrUnsupportedFormat.
+func TestRender_UnsupportedFormat_ReturnsError(t *testing.T) {
+	// Precondition: An unknown format
+	var buf bytes.Buffer
+
+	// Action: Render with the unknown format
+	err := Render(&buf, nil, Format("svg"))
+
+	// Expected: Error naming the problem
+	if err == nil || !strings.Contains(err.Error(), ErrUnsupportedFormat) {
+		t.Errorf("expected %q error, got %v", ErrUnsupportedFormat, err)
+	}
+}
+
+// TestLatest_UsesPrecedenceNotTagOrder validates that Latest picks the
+// highest version by SemVer precedence, not the most recent tag.
+//
+// Why: Hotfix tags on maintenance branches (e.g., v1.9.1 tagged after v1.10.0)
+// are newer by date but are not the latest release.
+//
+// What: Given out-of-order tags, the highest release and pre-release are
+// returned independently.
+func TestLatest_UsesPrecedenceNotTagOrder(t *testing.T) {
+	// Precondition: v1.10.0 tagged before hotfix v1.9.1; rc.10 before rc.2
+	entries := Build([]vcs.TagRef{
+		{Name: "v1.10.0", Branches: []string{"main"}},
+		{Name: "v2.0.0-rc.10", Branches: []string{"next"}},
+		{Name: "v1.9.1", Branches: []string{"release/1.9"}},
+		{Name: "v2.0.0-rc.2", Branches: []string{"next"}},
+	})
+
+	// Action: Find latest across all branches
+	release, prerelease := Latest(entries, "")
+
+	// Expected: v1.10.0 and v2.0.0-rc.10
+	if release == nil || release.Tag != "v1.10.0" {
+		t.Errorf("expected latest release v1.10.0, got %+v", release)
+	}
+	if prerelease == nil || prerelease.Tag != "v2.0.0-rc.10" {
+		t.Errorf("expected latest pre-release v2.0.0-rc.10, got %+v", prerelease)
+	}
+}
+
+// TestLatest_BranchScope_OnlyConsidersBranch validates --branch scoping.
+//
+// Why: Maintenance branches need their own "latest" to compute the next hotfix.
+//
+// What: Only entries containing the branch are considered; a branch without
+// pre-releases yields a nil pre-release.
+func TestLatest_BranchScope_OnlyConsidersBranch(t *testing.T) {
+	// Precondition: Sample history on main and feature
+	entries := Build(sampleTags())
+
+	// Action: Find latest on main
+	release, prerelease := Latest(entries, "main")
+
+	// Expected: v1.1.0 and no pre-release
+	if release == nil || release.Tag != "v1.1.0" {
+		t.Errorf("expected latest release v1.1.0 on main, got %+v", release)
+	}
+	if prerelease != nil {
+		t.Errorf("expected no pre-release on main, got %+v", prerelease)
+	}
+}
+
+// TestHighest_PreReleaseAboveRelease_ReturnsPreRelease validates that Highest
+// compares releases and pre-releases together.
+//
+// Why: The downgrade guard must reject re-using a number that was already
+// shipped as a release candidate, not only as a final release.
+//
+// What: v2.0.0-rc.1 outranks v1.1.0; an empty history yields nil.
+func TestHighest_PreReleaseAboveRelease_ReturnsPreRelease(t *testing.T) {
+	// Precondition: Sample history whose newest tag is a pre-release
+	entries := Build(sampleTags())
+
+	// Action: Find the highest entry
+	highest := Highest(entries)
+
+	// Expected: The release candidate, and nil without entries
+	if highest == nil || highest.Tag != "v2.0.0-rc.1" {
+		t.Errorf("expected highest v2.0.0-rc.1, got %+v", highest)
+	}
+	if Highest(nil) != nil {
+		t.Error("expected nil for empty history")
+	}
+}
+
+// TestPrevious_ReturnsHighestStrictlyLower validates that Previous picks the
+// highest version below the current one, regardless of tag order.
+//
+// Why: Changelog headers ("Changes since v1.1.0") and upgrade paths need the
+// release immediately before the current version, even when newer tags
+// exist on other branches.
+//
+// What: Versions between, above, and below the sample tags resolve to the
+// expected entry, and nothing precedes the first release.
+func TestPrevious_ReturnsHighestStrictlyLower(t *testing.T) {
+	// Precondition: Sample history with v1.0.0, v1.1.0 and v2.0.0-rc.1
+	entries := Build(sampleTags())
+
+	tests := []struct {
+		current string
+		want    string
+	}{
+		{"1.1.0", "v1.0.0"},
+		{"1.2.0", "v1.1.0"},
+		{"2.0.0", "v2.0.0-rc.1"},
+		{"2.0.0-rc.1", "v1.1.0"},
+		{"1.0.0", ""},
+	}
+	for _, tt := range tests {
+		// Action: Find the entry preceding the current version
+		current := version.Parse(tt.current)
+		previous := Previous(entries, &current)
+
+		// Expected: The highest strictly lower tag, or nil
+		got := ""
+		if previous != nil {
+			got = previous.Tag
+		}
+		if got != tt.want {
+			t.Errorf("Previous(%s) = %q, want %q", tt.current, got, tt.want)
+		}
+	}
+}
+
+// TestBuild_TagFormat_KeepsOnlyMatchingTags validates release.tagFormat.
+//
+// Why: A monorepo component's history must not include other components'
+// releases, and its tag names carry more than the version.
+//
+// What: With "myapp-v{{Version}}", only myapp tags are kept, and Version
+// holds the bare version.
+func TestBuild_TagFormat_KeepsOnlyMatchingTags(t *testing.T) {
+	// Precondition: Tags of two components and a plain version tag
+	if err := tagformat.Set("myapp-v{{Version}}"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = tagformat.Set("") }()
+	tags := []vcs.TagRef{{Name: "myapp-v1.0.0"}, {Name: "other-v2.0.0"}, {Name: "v3.0.0"}, {Name: "myapp-v1.1.0"}}
+
+	// Action
+	entries := Build(tags)
+
+	// Expected
+	if len(entries) != 2 || entries[0].Version != "1.0.0" || entries[1].Tag != "myapp-v1.1.0" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
@@ -0,0 +1,10 @@
+package history
+
+// Error messages
+const (
+	ErrUnsupportedFormat = "unsupported graph format"
+	ErrRenderFailed      = "failed to render graph"
+)
+
+// DetachedBranch is the group name used for tags not reachable from any local branch
+const DetachedBranch = "(detached)"
@@ -0,0 +1,295 @@
+// Package hosting reads commit information from a hosting provider API
+// (GitHub, GitLab) for builds without a local repository, such as a source
+// tarball export, so Hash, CommitDate, and BranchName are still filled in.
+//
+// The repository and commit come from the environment the build runs in:
+// VERSIONATOR_REPOSITORY and VERSIONATOR_COMMIT, or the variables GitHub
+// Actions and GitLab CI set.
+package hosting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/offline"
+)
+
+// Environment variables naming the build's repository, commit, and branch
+// outside CI, or overriding the CI variables
+const (
+	EnvRepository = "VERSIONATOR_REPOSITORY"
+	EnvCommit     = "VERSIONATOR_COMMIT"
+	EnvBranch     = "VERSIONATOR_BRANCH"
+)
+
+// Commit is the commit information read from the API
+type Commit struct {
+	SHA         string
+	Branch      string
+	Author      string
+	AuthorEmail string
+	// Date is the author date, in UTC, as git reports for CommitDate
+	Date time.Time
+}
+
+// Options configures a lookup. Empty fields are completed from the
+// environment by FromEnv.
+type Options struct {
+	// Provider is "github" or "gitlab"
+	Provider string
+	// APIURL is the API base URL; empty selects the provider default
+	APIURL string
+	// Repository is the project ("owner/name"; GitLab accepts nested groups)
+	Repository string
+	Commit     string
+	Branch     string
+	// Token authenticates requests; public projects need none
+	Token string
+	// Client performs HTTP requests; nil uses a client with a 10s timeout
+	Client *http.Client
+}
+
+// provider describes one hosting API
+type provider struct {
+	defaultAPIURL   string
+	defaultTokenEnv string
+	// env lists, per option, the CI variables read in order
+	apiURLEnv, repositoryEnv, commitEnv, branchEnv []string
+	lookup                                         func(api apiClient, opts Options) (Commit, error)
+}
+
+var providers = map[string]provider{
+	"github": {
+		defaultAPIURL:   "https://api.github.com",
+		defaultTokenEnv: "GITHUB_TOKEN",
+		apiURLEnv:       []string{"GITHUB_API_URL"},
+		repositoryEnv:   []string{"GITHUB_REPOSITORY"},
+		commitEnv:       []string{"GITHUB_SHA"},
+		// GITHUB_HEAD_REF is the source branch of a pull request build
+		branchEnv: []string{"GITHUB_HEAD_REF", "GITHUB_REF_NAME"},
+		lookup:    lookupGitHub,
+	},
+	"gitlab": {
+		defaultAPIURL:   "https://gitlab.com/api/v4",
+		defaultTokenEnv: "GITLAB_TOKEN",
+		apiURLEnv:       []string{"CI_API_V4_URL"},
+		repositoryEnv:   []string{"CI_PROJECT_PATH"},
+		commitEnv:       []string{"CI_COMMIT_SHA"},
+		branchEnv:       []string{"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "CI_COMMIT_BRANCH"},
+		lookup:          lookupGitLab,
+	},
+}
+
+// AvailableProviders returns the names of supported providers
+func AvailableProviders() []string {
+	return []string{"github", "gitlab"}
+}
+
+// DefaultTokenEnv returns the environment variable conventionally holding
+// the token for the named provider
+func DefaultTokenEnv(name string) string {
+	return providers[name].defaultTokenEnv
+}
+
+// FromEnv fills the empty options of opts from getenv: VERSIONATOR_* first,
+// then the provider's CI variables
+func FromEnv(opts Options, getenv func(string) string) Options {
+	p := providers[opts.Provider]
+	first := func(current string, names ...string) string {
+		for _, name := range names {
+			if current != "" {
+				return current
+			}
+			current = getenv(name)
+		}
+		return current
+	}
+	opts.APIURL = first(opts.APIURL, p.apiURLEnv...)
+	opts.Repository = first(opts.Repository, append([]string{EnvRepository}, p.repositoryEnv...)...)
+	opts.Commit = first(opts.Commit, append([]string{EnvCommit}, p.commitEnv...)...)
+	opts.Branch = first(opts.Branch, append([]string{EnvBranch}, p.branchEnv...)...)
+	return opts
+}
+
+// Lookup reads the commit opts names from the provider API. Without a
+// branch from the environment, the branches whose head is the commit are
+// asked for.
+func Lookup(opts Options) (Commit, error) {
+	p, ok := providers[opts.Provider]
+	if !ok {
+		return Commit{}, fmt.Errorf("%s: %q (available: %s)", ErrUnknownProvider, opts.Provider, strings.Join(AvailableProviders(), ", "))
+	}
+	if opts.Repository == "" {
+		return Commit{}, fmt.Errorf("%s", ErrMissingRepository)
+	}
+	if opts.Commit == "" {
+		return Commit{}, fmt.Errorf("%s", ErrMissingCommit)
+	}
+	if err := offline.Require("vcs.hosted"); err != nil {
+		return Commit{}, err
+	}
+	if opts.APIURL == "" {
+		opts.APIURL = p.defaultAPIURL
+	}
+	opts.APIURL = strings.TrimSuffix(opts.APIURL, "/")
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	api := apiClient{client: opts.Client, token: opts.Token, gitlab: opts.Provider == "gitlab"}
+	return p.lookup(api, opts)
+}
+
+// lookupGitHub reads GET /repos/{repo}/commits/{sha}
+func lookupGitHub(api apiClient, opts Options) (Commit, error) {
+	var commit struct {
+		SHA    string `json:"sha"`
+		Commit struct {
+			Author struct {
+				Name  string    `json:"name"`
+				Email string    `json:"email"`
+				Date  time.Time `json:"date"`
+			} `json:"author"`
+		} `json:"commit"`
+	}
+	base := fmt.Sprintf("%s/repos/%s/commits/%s", opts.APIURL, opts.Repository, url.PathEscape(opts.Commit))
+	if err := api.get(base, &commit); err != nil {
+		return Commit{}, err
+	}
+	c := Commit{
+		SHA:         commit.SHA,
+		Branch:      opts.Branch,
+		Author:      commit.Commit.Author.Name,
+		AuthorEmail: commit.Commit.Author.Email,
+		Date:        commit.Commit.Author.Date.UTC(),
+	}
+	if c.Branch == "" {
+		var branches []struct {
+			Name string `json:"name"`
+		}
+		if err := api.get(base+"/branches-where-head", &branches); err == nil && len(branches) > 0 {
+			c.Branch = branches[0].Name
+		}
+	}
+	return c, nil
+}
+
+// lookupGitLab reads GET /projects/{path}/repository/commits/{sha}
+func lookupGitLab(api apiClient, opts Options) (Commit, error) {
+	var commit struct {
+		ID          string    `json:"id"`
+		AuthorName  string    `json:"author_name"`
+		AuthorEmail string    `json:"author_email"`
+		AuthoredAt  time.Time `json:"authored_date"`
+	}
+	base := fmt.Sprintf("%s/projects/%s/repository/commits/%s", opts.APIURL, url.PathEscape(opts.Repository), url.PathEscape(opts.Commit))
+	if err := api.get(base, &commit); err != nil {
+		return Commit{}, err
+	}
+	c := Commit{
+		SHA:         commit.ID,
+		Branch:      opts.Branch,
+		Author:      commit.AuthorName,
+		AuthorEmail: commit.AuthorEmail,
+		Date:        commit.AuthoredAt.UTC(),
+	}
+	if c.Branch == "" {
+		var refs []struct {
+			Name string `json:"name"`
+		}
+		if err := api.get(base+"/refs?type=branch", &refs); err == nil && len(refs) > 0 {
+			c.Branch = refs[0].Name
+		}
+	}
+	return c, nil
+}
+
+// apiClient performs JSON GET requests, authenticated when a token is set
+type apiClient struct {
+	client *http.Client
+	token  string
+	gitlab bool
+}
+
+// get decodes the JSON response of url into out; non-2xx responses are
+// errors including the response status
+func (c apiClient) get(url string, out any) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrRequestFailed, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		if c.gitlab {
+			req.Header.Set("PRIVATE-TOKEN", c.token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrRequestFailed, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrRequestFailed, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: GET %s: %s", ErrRequestFailed, url, resp.Status)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%s: invalid response: %w", ErrRequestFailed, err)
+	}
+	return nil
+}
+
+// settings holds the fallback configured for this invocation and its
+// cached result, including a failure, so the API is asked once per run
+var settings struct {
+	mu       sync.Mutex
+	opts     Options
+	tokenEnv string
+	done     bool
+	commit   Commit
+	err      error
+}
+
+// Configure enables the fallback for provider (empty disables it), with
+// the API token read from tokenEnv (empty: the provider default) at lookup
+func Configure(opts Options, tokenEnv string) {
+	settings.mu.Lock()
+	defer settings.mu.Unlock()
+	settings.opts, settings.tokenEnv = opts, tokenEnv
+	settings.done, settings.commit, settings.err = false, Commit{}, nil
+}
+
+// Current returns the commit of the configured fallback, looked up once.
+// ok is false when no provider is configured.
+func Current() (commit Commit, ok bool, err error) {
+	settings.mu.Lock()
+	defer settings.mu.Unlock()
+	if settings.opts.Provider == "" {
+		return Commit{}, false, nil
+	}
+	if !settings.done {
+		opts := FromEnv(settings.opts, os.Getenv)
+		tokenEnv := settings.tokenEnv
+		if tokenEnv == "" {
+			tokenEnv = DefaultTokenEnv(opts.Provider)
+		}
+		if opts.Token == "" && tokenEnv != "" {
+			opts.Token = os.Getenv(tokenEnv)
+		}
+		settings.commit, settings.err = Lookup(opts)
+		settings.done = true
+	}
+	return settings.commit, true, settings.err
+}
@@ -0,0 +1,210 @@
+package hosting
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/offline"
+)
+
+// fakeAPI serves canned JSON responses keyed by escaped path (with query),
+// recording the authentication headers it received
+func fakeAPI(t *testing.T, responses map[string]string, headers *http.Header) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if headers != nil {
+			*headers = r.Header.Clone()
+		}
+		key := r.URL.EscapedPath()
+		if r.URL.RawQuery != "" {
+			key += "?" + r.URL.RawQuery
+		}
+		resp, ok := responses[key]
+		if !ok {
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestLookup_GitHub validates the GitHub commit lookup.
+//
+// Why: A tarball build has no .git, but the commit it was exported from is
+// known to CI and to the API.
+//
+// What: Hash, author, and author date come from the commit endpoint; with
+// no branch in the environment, the branch whose head is the commit is used.
+func TestLookup_GitHub(t *testing.T) {
+	// Precondition
+	var headers http.Header
+	server := fakeAPI(t, map[string]string{
+		"/repos/acme/app/commits/4f2a9c1":                     `{"sha":"4f2a9c1e0b7d3a5c8e6f2b1d9a7c4e3f5b8d0a2c","commit":{"author":{"name":"Ada","email":"ada@example.com","date":"2024-01-15T10:30:00+02:00"}}}`,
+		"/repos/acme/app/commits/4f2a9c1/branches-where-head": `[{"name":"main"}]`,
+	}, &headers)
+
+	// Action
+	commit, err := Lookup(Options{Provider: "github", APIURL: server.URL, Repository: "acme/app", Commit: "4f2a9c1", Token: "secret"})
+
+	// Expected
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	want := Commit{
+		SHA:         "4f2a9c1e0b7d3a5c8e6f2b1d9a7c4e3f5b8d0a2c",
+		Branch:      "main",
+		Author:      "Ada",
+		AuthorEmail: "ada@example.com",
+		Date:        time.Date(2024, 1, 15, 8, 30, 0, 0, time.UTC),
+	}
+	if commit != want {
+		t.Errorf("commit = %+v, want %+v", commit, want)
+	}
+	if headers.Get("Authorization") != "Bearer secret" {
+		t.Errorf("Authorization = %q", headers.Get("Authorization"))
+	}
+}
+
+// TestLookup_GitLab validates the GitLab commit lookup.
+//
+// Why: GitLab addresses nested projects by their escaped path and
+// authenticates with PRIVATE-TOKEN.
+//
+// What: The branch from the environment is kept; the commit fields come
+// from the commits endpoint of the escaped project path.
+func TestLookup_GitLab(t *testing.T) {
+	// Precondition
+	var headers http.Header
+	server := fakeAPI(t, map[string]string{
+		"/projects/group%2Fsub%2Fapp/repository/commits/abc1234": `{"id":"abc1234def","author_name":"Ada","author_email":"ada@example.com","authored_date":"2024-01-15T08:30:00Z"}`,
+	}, &headers)
+
+	// Action
+	commit, err := Lookup(Options{Provider: "gitlab", APIURL: server.URL + "/", Repository: "group/sub/app", Commit: "abc1234", Branch: "feature/x", Token: "secret"})
+
+	// Expected
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if commit.SHA != "abc1234def" || commit.Branch != "feature/x" || commit.Author != "Ada" {
+		t.Errorf("commit = %+v", commit)
+	}
+	if headers.Get("PRIVATE-TOKEN") != "secret" {
+		t.Errorf("PRIVATE-TOKEN = %q", headers.Get("PRIVATE-TOKEN"))
+	}
+}
+
+// TestLookup_Errors validates failed lookups.
+//
+// Why: A misconfigured fallback must say what is missing rather than
+// silently render an empty Hash.
+//
+// What: Unknown providers, a missing repository or commit, API errors, and
+// offline mode fail with their messages.
+func TestLookup_Errors(t *testing.T) {
+	server := fakeAPI(t, map[string]string{}, nil)
+	tests := []struct {
+		name    string
+		opts    Options
+		offline bool
+		wantErr string
+	}{
+		{name: "unknown provider", opts: Options{Provider: "gitea"}, wantErr: ErrUnknownProvider},
+		{name: "no repository", opts: Options{Provider: "github", Commit: "abc"}, wantErr: ErrMissingRepository},
+		{name: "no commit", opts: Options{Provider: "github", Repository: "acme/app"}, wantErr: ErrMissingCommit},
+		{name: "unknown commit", opts: Options{Provider: "github", APIURL: server.URL, Repository: "acme/app", Commit: "abc"}, wantErr: ErrRequestFailed},
+		{name: "offline", opts: Options{Provider: "github", Repository: "acme/app", Commit: "abc"}, offline: true, wantErr: offline.ErrNetworkDisabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition
+			offline.Set(tt.offline)
+			defer offline.Set(false)
+
+			// Action
+			_, err := Lookup(tt.opts)
+
+			// Expected
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestFromEnv_Precedence validates where options come from.
+//
+// Why: CI sets its own variables, but tarball builds outside CI need a
+// provider-neutral way to name the commit, and configuration must win.
+//
+// What: Set options are kept; VERSIONATOR_* wins over the CI variables;
+// the pull request source branch wins over the ref name.
+func TestFromEnv_Precedence(t *testing.T) {
+	// Precondition
+	env := map[string]string{
+		"GITHUB_REPOSITORY": "acme/ci",
+		"GITHUB_SHA":        "cisha",
+		"GITHUB_HEAD_REF":   "feature/x",
+		"GITHUB_REF_NAME":   "42/merge",
+		"GITHUB_API_URL":    "https://ghe.example.com/api/v3",
+		EnvCommit:           "exported",
+	}
+
+	// Action
+	opts := FromEnv(Options{Provider: "github", Repository: "acme/app"}, func(name string) string { return env[name] })
+
+	// Expected
+	want := Options{Provider: "github", APIURL: "https://ghe.example.com/api/v3", Repository: "acme/app", Commit: "exported", Branch: "feature/x"}
+	if opts != want {
+		t.Errorf("opts = %+v, want %+v", opts, want)
+	}
+}
+
+// TestCurrent_CachesLookup validates the per-invocation cache.
+//
+// Why: Templates are rendered many times per command; each render must
+// not call the API again.
+//
+// What: Without a provider the fallback is off; with one, the API is asked
+// once and the token is read from the configured variable.
+func TestCurrent_CachesLookup(t *testing.T) {
+	// Precondition
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Authorization") != "Bearer from-env" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_, _ = io.WriteString(w, `{"sha":"abc1234"}`)
+	}))
+	defer server.Close()
+	t.Setenv("CUSTOM_TOKEN", "from-env")
+	t.Setenv(EnvBranch, "main")
+	defer Configure(Options{}, "")
+
+	// Action / Expected: Disabled
+	Configure(Options{}, "")
+	if _, ok, _ := Current(); ok {
+		t.Fatal("fallback should be disabled without a provider")
+	}
+
+	// Action
+	Configure(Options{Provider: "github", APIURL: server.URL, Repository: "acme/app", Commit: "abc1234"}, "CUSTOM_TOKEN")
+	first, ok, err := Current()
+	second, _, _ := Current()
+
+	// Expected
+	if !ok || err != nil || first.SHA != "abc1234" || second != first {
+		t.Errorf("Current = %+v, %v, %v", first, ok, err)
+	}
+	if calls != 1 {
+		t.Errorf("API called %d times, want 1", calls)
+	}
+}
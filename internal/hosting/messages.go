@@ -0,0 +1,11 @@
+// Package hosting messages - error message constants
+// Exported so tests can compare against them
+package hosting
+
+// Error messages
+const (
+	ErrUnknownProvider   = "unknown hosting provider"
+	ErrMissingRepository = "hosting repository is not set (vcs.hosted.repository, VERSIONATOR_REPOSITORY, or the CI variables)"
+	ErrMissingCommit     = "hosting commit is not set (VERSIONATOR_COMMIT, or the CI variables)"
+	ErrRequestFailed     = "hosting API request failed"
+)
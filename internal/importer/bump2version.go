@@ -0,0 +1,198 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// bumpVersionDefaults are bump2version's default settings; a source that
+// keeps them needs no translation
+var bumpVersionDefaults = map[string]string{
+	"parse":     `(?P<major>\d+)\.(?P<minor>\d+)\.(?P<patch>\d+)`,
+	"serialize": "{major}.{minor}.{patch}",
+	"search":    "{current_version}",
+	"replace":   "{new_version}",
+}
+
+// structuredVersionPaths are the version selectors of common manifests that
+// `updates` can edit; other files are patched by text search and replace,
+// which versionator does not do
+var structuredVersionPaths = map[string]string{
+	"package.json":   "version",
+	"composer.json":  "version",
+	"Cargo.toml":     "package.version",
+	"pyproject.toml": "project.version",
+	"Chart.yaml":     "version",
+}
+
+// iniSection is one [section] of an INI file, in file order
+type iniSection struct {
+	name   string
+	values map[string]string
+	keys   []string
+}
+
+// FromBump2Version translates a .bumpversion.cfg (or the [bumpversion]
+// sections of setup.cfg) into a versionator configuration. Files with a
+// known structured version field become updates; text search-and-replace
+// targets are reported.
+func FromBump2Version(data []byte) (*Result, error) {
+	sections, err := parseINI(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrParseSource, err)
+	}
+
+	r := newResult()
+	found := false
+	for _, section := range sections {
+		kind, target, _ := strings.Cut(section.name, ":")
+		if kind != "bumpversion" {
+			// setup.cfg holds other tools' sections too
+			continue
+		}
+		if target == "" {
+			found = true
+			bumpVersionGlobal(r, section)
+			continue
+		}
+
+		kind, target, _ = strings.Cut(target, ":")
+		// bump-my-version allows a label: [bumpversion:file(label):path]
+		kind, _, _ = strings.Cut(kind, "(")
+		setting := section.name
+		switch kind {
+		case "file":
+			bumpVersionFile(r, setting, target, section)
+		case "glob":
+			r.unsupported(setting, "text search and replace across %q: render the files with `versionator output emit`", target)
+		case "part":
+			if values := section.values["values"]; values != "" {
+				r.approximated(setting, "values %s: versionator pre-release labels are free-form; set them with `versionator config prerelease set`", strings.Join(strings.Fields(values), ", "))
+			} else {
+				r.unsupported(setting, "custom version parts have no versionator equivalent")
+			}
+		default:
+			r.unsupported(setting, "no versionator equivalent")
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%s", ErrNoBumpVersion)
+	}
+	return r, nil
+}
+
+// bumpVersionGlobal maps the [bumpversion] section
+func bumpVersionGlobal(r *Result, section iniSection) {
+	for _, key := range section.keys {
+		value := section.values[key]
+		switch key {
+		case "current_version":
+			r.approximated(key, "versionator keeps the version in VERSION; run `versionator set %s`", value)
+
+		case "tag_name":
+			prefix, ok := strings.CutSuffix(value, "{new_version}")
+			if ok && (prefix == "" || prefix == "v" || prefix == "V") {
+				r.Config.Prefix = prefix
+				r.translated(key, "prefix: %q", prefix)
+			} else {
+				r.unsupported(key, "%q: versionator tags are the version with an optional 'v' or 'V' prefix", value)
+			}
+
+		case "tag":
+			if strings.EqualFold(value, "true") {
+				r.translated(key, "tag releases with `versionator release`")
+			}
+
+		case "commit":
+			if strings.EqualFold(value, "true") {
+				r.approximated(key, "`versionator release` commits VERSION and updated files before tagging")
+			}
+
+		case "parse", "serialize", "search", "replace":
+			if strings.Join(strings.Fields(value), " ") == bumpVersionDefaults[key] {
+				r.translated(key, "default matches versionator's SemVer handling")
+			} else {
+				r.unsupported(key, "custom %s %q: versionator versions are SemVer 2.0.0", key, value)
+			}
+
+		default:
+			r.unsupported(key, "no versionator equivalent")
+		}
+	}
+}
+
+// bumpVersionFile maps a [bumpversion:file:...] section to an update when
+// the file is a known manifest patched with the default search pattern
+func bumpVersionFile(r *Result, setting, file string, section iniSection) {
+	selector, structured := structuredVersionPaths[path.Base(file)]
+	search := section.values["search"]
+	customSearch := search != "" && search != bumpVersionDefaults["search"] && !strings.Contains(search, `"version"`)
+
+	if !structured || customSearch {
+		r.unsupported(setting, "text search and replace in %s: updates only edit JSON, YAML, and TOML fields; render the file with `versionator output emit`", file)
+		return
+	}
+
+	update := updateFor(file, selector)
+	if path.Base(file) == "pyproject.toml" {
+		// pip only installs PEP 440 versions (1.2.3rc1, not 1.2.3-rc.1)
+		update.Template = "{{Pep440Version}}"
+	}
+	r.Config.Updates = append(r.Config.Updates, update)
+
+	if path.Base(file) == "pyproject.toml" {
+		r.approximated(setting, "updates: %s %s (Poetry projects use tool.poetry.version)", file, selector)
+	} else {
+		r.translated(setting, "updates: %s %s", file, selector)
+	}
+}
+
+// parseINI reads the configparser subset bump2version uses: [sections],
+// "key = value" or "key: value" pairs, indented continuation lines, and
+// '#' / ';' comments
+func parseINI(data []byte) ([]iniSection, error) {
+	var sections []iniSection
+	var current *iniSection
+	lastKey := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";"):
+			continue
+
+		case line[0] == ' ' || line[0] == '\t':
+			if current == nil || lastKey == "" {
+				return nil, fmt.Errorf("line %d: continuation without a key", lineNumber)
+			}
+			if current.values[lastKey] != "" {
+				current.values[lastKey] += "\n"
+			}
+			current.values[lastKey] += trimmed
+
+		case strings.HasPrefix(trimmed, "["):
+			name, ok := strings.CutSuffix(trimmed, "]")
+			if !ok {
+				return nil, fmt.Errorf("line %d: unterminated section %q", lineNumber, trimmed)
+			}
+			sections = append(sections, iniSection{name: strings.TrimPrefix(name, "["), values: map[string]string{}})
+			current, lastKey = &sections[len(sections)-1], ""
+
+		default:
+			i := strings.IndexAny(trimmed, "=:")
+			if current == nil || i < 0 {
+				return nil, fmt.Errorf("line %d: expected key = value", lineNumber)
+			}
+			lastKey = strings.ToLower(strings.TrimSpace(trimmed[:i]))
+			current.keys = append(current.keys, lastKey)
+			current.values[lastKey] = strings.TrimSpace(trimmed[i+1:])
+		}
+	}
+	return sections, scanner.Err()
+}
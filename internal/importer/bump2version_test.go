@@ -0,0 +1,70 @@
+package importer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestFromBump2Version_StructuredFilesBecomeUpdates validates the
+// bump2version translation.
+//
+// Why: bump2version patches files by text search and replace; only files
+// with a structured version field can become versionator updates, and the
+// rest must be reported rather than dropped.
+//
+// What: A setup.cfg with a [bumpversion] section yields prefix "v", updates
+// for Cargo.toml and pyproject.toml (rendered as a PEP 440 version), and an
+// unsupported note for a Python source file; a file
+// without a [bumpversion] section is rejected with ErrNoBumpVersion.
+func TestFromBump2Version_StructuredFilesBecomeUpdates(t *testing.T) {
+	// Precondition: setup.cfg with unrelated and bumpversion sections
+	source := `[metadata]
+name = widget
+
+[bumpversion]
+current_version = 0.4.1
+tag_name = v{new_version}
+serialize =
+	{major}.{minor}.{patch}
+
+[bumpversion:file:Cargo.toml]
+
+[bumpversion:file:pyproject.toml]
+
+[bumpversion:file:src/widget/__init__.py]
+search = __version__ = "{current_version}"
+`
+
+	// Action: Translate
+	result, err := FromBump2Version([]byte(source))
+
+	// Expected: Prefix and manifests translated, Python file reported
+	if err != nil {
+		t.Fatalf("FromBump2Version failed: %v", err)
+	}
+	if result.Config.Prefix != "v" {
+		t.Errorf("expected prefix 'v', got %q", result.Config.Prefix)
+	}
+	updates := result.Config.Updates
+	if len(updates) != 2 || updates[0].File != "Cargo.toml" || updates[0].Path != "package.version" {
+		t.Fatalf("expected Cargo.toml and pyproject.toml updates, got %+v", updates)
+	}
+	if updates[1].Path != "project.version" || updates[1].Template != "{{Pep440Version}}" {
+		t.Errorf("expected pyproject.toml project.version rendered as {{Pep440Version}}, got %+v", updates[1])
+	}
+	if !slices.Contains(settings(result.Unsupported), "bumpversion:file:src/widget/__init__.py") {
+		t.Errorf("expected __init__.py to be reported unsupported, got %v", result.Unsupported)
+	}
+	if slices.Contains(settings(result.Unsupported), "serialize") {
+		t.Error("default multi-line serialize should translate")
+	}
+
+	// Action: Translate a file without a [bumpversion] section
+	_, err = FromBump2Version([]byte("[metadata]\nname = widget\n"))
+
+	// Expected: Rejected
+	if err == nil || !strings.Contains(err.Error(), ErrNoBumpVersion) {
+		t.Errorf("expected %q, got %v", ErrNoBumpVersion, err)
+	}
+}
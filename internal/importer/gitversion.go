@@ -0,0 +1,177 @@
+package importer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// gitVersionBumpMessages are GitVersion's default commit message patterns;
+// they correspond to the +semver: markers `versionator bump` understands
+var gitVersionBumpMessages = map[string]string{
+	"major-version-bump-message": `\+semver:\s?(breaking|major)`,
+	"minor-version-bump-message": `\+semver:\s?(feature|minor)`,
+	"patch-version-bump-message": `\+semver:\s?(fix|patch)`,
+	"no-bump-message":            `\+semver:\s?(none|skip)`,
+}
+
+// gitVersionDefaultBranches are the regexes GitVersion uses for its built-in
+// branch names when a branches entry does not override regex
+var gitVersionDefaultBranches = map[string]string{
+	"main":    `^master$|^main$`,
+	"master":  `^master$|^main$`,
+	"release": `^releases?[/-]`,
+	"hotfix":  `^hotfix(es)?[/-]`,
+	"support": `^support[/-]`,
+}
+
+// FromGitVersion translates a GitVersion.yml (v5 or v6) into a versionator
+// configuration. Branch-aware versioning is always enabled, since GitVersion
+// labels non-main branches by default.
+func FromGitVersion(data []byte) (*Result, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrParseSource, err)
+	}
+
+	r := newResult()
+	cfg := r.Config
+	cfg.BranchVersioning.Enabled = true
+
+	for _, key := range sortedKeys(raw) {
+		value := raw[key]
+		switch key {
+		case "tag-prefix":
+			pattern := asString(value)
+			if prefix, ok := prefixFromPattern(pattern); ok {
+				cfg.Prefix = prefix
+				r.translated(key, "prefix: %q", prefix)
+			} else {
+				r.unsupported(key, "%q: versionator prefixes are 'v', 'V', or empty", pattern)
+			}
+
+		case "mode":
+			gitVersionMode(r, key, asString(value))
+
+		case "workflow":
+			workflow := asString(value)
+			switch {
+			case strings.HasPrefix(workflow, "TrunkBased"):
+				r.approximated(key, "%s: run `versionator bump` on main to version from commit messages", workflow)
+			case strings.HasPrefix(workflow, "GitFlow"), strings.HasPrefix(workflow, "GitHubFlow"):
+				r.approximated(key, "%s: branch-aware versioning enabled; see branchVersioning.mainBranches", workflow)
+			default:
+				r.unsupported(key, "unknown workflow %q", workflow)
+			}
+
+		case "branches":
+			branches, ok := value.(map[string]any)
+			if !ok {
+				r.unsupported(key, "expected a mapping of branch configurations")
+				continue
+			}
+			gitVersionBranches(r, branches)
+
+		case "next-version":
+			r.approximated(key, "not configuration in versionator; run `versionator set %s`", asString(value))
+
+		case "commit-message-incrementing":
+			if asString(value) == "Disabled" {
+				r.approximated(key, "Disabled: do not run `versionator bump`; use `versionator bump <level>` instead")
+			} else {
+				r.translated(key, "`versionator bump` reads +semver: markers and Conventional Commits")
+			}
+
+		case "major-version-bump-message", "minor-version-bump-message", "patch-version-bump-message", "no-bump-message":
+			if asString(value) == gitVersionBumpMessages[key] {
+				r.translated(key, "default pattern matches the +semver: markers `versionator bump` reads")
+			} else {
+				r.unsupported(key, "custom pattern %q: `versionator bump` only reads +semver: markers and Conventional Commits", asString(value))
+			}
+
+		case "assembly-versioning-scheme", "assembly-file-versioning-scheme",
+			"assembly-versioning-format", "assembly-file-versioning-format", "assembly-informational-format":
+			r.unsupported(key, "use `versionator output emit` with {{AssemblyVersion}} or a custom template")
+
+		default:
+			r.unsupported(key, "no versionator equivalent")
+		}
+	}
+	return r, nil
+}
+
+// gitVersionMode maps a GitVersion deployment mode
+func gitVersionMode(r *Result, key, mode string) {
+	switch mode {
+	case "ContinuousDelivery", "ManualDeployment":
+		r.approximated(key, "%s: pre-release is computed at output time (prerelease.stable: false); tag releases with `versionator release`", mode)
+	case "ContinuousDeployment":
+		r.Config.PreRelease.Template = "{{CommitsSinceTag}}"
+		r.approximated(key, "%s: prerelease.template %q numbers every commit", mode, r.Config.PreRelease.Template)
+	case "Mainline", "TrunkBased", "ContinuousDeployment/Mainline":
+		r.approximated(key, "%s: run `versionator bump` on main to version from commit messages", mode)
+	default:
+		r.unsupported(key, "unknown mode %q", mode)
+	}
+}
+
+// gitVersionBranches collects main-branch patterns and reports per-branch
+// settings; versionator has one pre-release template for all other branches
+func gitVersionBranches(r *Result, branches map[string]any) {
+	var mainBranches []string
+	for _, name := range sortedKeys(branches) {
+		setting := "branches." + name
+		branch, _ := branches[name].(map[string]any)
+
+		regex := asString(branch["regex"])
+		if regex == "" {
+			regex = gitVersionDefaultBranches[name]
+		}
+
+		// GitVersion v5 uses "tag", v6 "label"; an empty label means a clean version
+		label, hasLabel := branch["label"]
+		if !hasLabel {
+			label, hasLabel = branch["tag"]
+		}
+		isMain := asBool(branch["is-mainline"]) || asBool(branch["is-main-branch"]) ||
+			asBool(branch["is-release-branch"]) || (hasLabel && asString(label) == "")
+		if !hasLabel && !isMain && (name == "main" || name == "master" || name == "release" || name == "support") {
+			isMain = true
+		}
+
+		if isMain {
+			globs, err := branchGlobs(regex)
+			if err != nil || regex == "" {
+				r.unsupported(setting+".regex", "%q: list matching branches in branchVersioning.mainBranches by hand", regex)
+			} else {
+				mainBranches = append(mainBranches, globs...)
+				r.translated(setting, "branchVersioning.mainBranches: %s", strings.Join(globs, ", "))
+			}
+			if hasLabel && asString(label) != "" {
+				r.approximated(setting+".label", "%q dropped: main branches produce clean versions", asString(label))
+			}
+		} else if hasLabel {
+			switch asString(label) {
+			case "{BranchName}", "useBranchName":
+				r.translated(setting+".label", "branchVersioning.prereleaseTemplate uses {{EscapedBranchName}}")
+			default:
+				r.approximated(setting+".label", "%q: versionator labels all non-main branches with branchVersioning.prereleaseTemplate", asString(label))
+			}
+		}
+
+		for _, key := range sortedKeys(branch) {
+			switch key {
+			case "regex", "label", "tag", "is-mainline", "is-main-branch", "is-release-branch":
+				continue
+			}
+			r.unsupported(setting+"."+key, "no versionator equivalent")
+		}
+	}
+
+	if len(mainBranches) > 0 {
+		slices.Sort(mainBranches)
+		r.Config.BranchVersioning.MainBranches = slices.Compact(mainBranches)
+	}
+}
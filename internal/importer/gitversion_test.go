@@ -0,0 +1,122 @@
+package importer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestFromGitVersion_TranslatesPrefixBranchesAndMode validates the GitVersion
+// translation of the settings with a versionator equivalent.
+//
+// Why: Teams migrating from GitVersion expect their tag prefix and main-branch
+// definitions to carry over; anything that cannot carry over must be reported
+// rather than silently dropped.
+//
+// What: A typical GitFlow GitVersion.yml yields prefix "v", main branches from
+// the main and release regexes, and unsupported notes for settings with no
+// equivalent.
+func TestFromGitVersion_TranslatesPrefixBranchesAndMode(t *testing.T) {
+	// Precondition: A GitVersion.yml with prefix, mode, branches, and extras
+	source := `
+tag-prefix: '[vV]?'
+mode: ContinuousDeployment
+major-version-bump-message: '\+semver:\s?(breaking|major)'
+minor-version-bump-message: 'feat:'
+ignore:
+  sha: []
+branches:
+  main:
+    regex: ^master$|^main$
+    label: ''
+    increment: Patch
+  release:
+    regex: ^releases?[/-]
+    label: beta
+    is-release-branch: true
+  feature:
+    regex: ^features?[/-]
+    label: '{BranchName}'
+`
+
+	// Action: Translate
+	result, err := FromGitVersion([]byte(source))
+
+	// Expected: Prefix, branches, and mode translated; extras reported
+	if err != nil {
+		t.Fatalf("FromGitVersion failed: %v", err)
+	}
+	cfg := result.Config
+	if cfg.Prefix != "v" {
+		t.Errorf("expected prefix 'v', got %q", cfg.Prefix)
+	}
+	if !cfg.BranchVersioning.Enabled {
+		t.Error("expected branch versioning to be enabled")
+	}
+	want := []string{"main", "master", "release-*", "release/*", "releases-*", "releases/*"}
+	if !slices.Equal(cfg.BranchVersioning.MainBranches, want) {
+		t.Errorf("expected main branches %v, got %v", want, cfg.BranchVersioning.MainBranches)
+	}
+	if cfg.PreRelease.Template != "{{CommitsSinceTag}}" {
+		t.Errorf("expected ContinuousDeployment pre-release template, got %q", cfg.PreRelease.Template)
+	}
+
+	unsupported := settings(result.Unsupported)
+	for _, setting := range []string{"ignore", "minor-version-bump-message", "branches.main.increment"} {
+		if !slices.Contains(unsupported, setting) {
+			t.Errorf("expected %q to be reported unsupported, got %v", setting, unsupported)
+		}
+	}
+	if slices.Contains(unsupported, "major-version-bump-message") {
+		t.Error("default bump message should translate, not be unsupported")
+	}
+	if !slices.Contains(settings(result.Approximated), "branches.release.label") {
+		t.Errorf("expected dropped release label to be reported, got %v", result.Approximated)
+	}
+}
+
+// TestBranchGlobs_CommonPatterns_TranslateOrReject validates regex-to-glob
+// translation of branch patterns.
+//
+// Why: Branch regexes must become globs for branchVersioning.mainBranches; a
+// pattern that cannot be expressed as a glob must be rejected, not guessed.
+//
+// What: Anchored literals, alternation, optional groups, and trailing
+// wildcards translate; character-class repetition such as \d+ is rejected.
+func TestBranchGlobs_CommonPatterns_TranslateOrReject(t *testing.T) {
+	tests := []struct {
+		regex string
+		want  []string
+	}{
+		{`^main$`, []string{"main"}},
+		{`^hotfix(es)?[/-]`, []string{"hotfix/*", "hotfix-*", "hotfixes/*", "hotfixes-*"}},
+		{`^support[/-](?<BranchName>.+)`, []string{"support/*", "support-*"}},
+		{`release`, []string{"*release*"}},
+		{`^v\d+$`, nil},
+	}
+
+	for _, tt := range tests {
+		// Action: Translate the pattern
+		got, err := branchGlobs(tt.regex)
+
+		// Expected: The listed globs, or ErrUnsupportedGlob
+		if tt.want == nil {
+			if err == nil || !strings.Contains(err.Error(), ErrUnsupportedGlob) {
+				t.Errorf("%s: expected %q, got %v (%v)", tt.regex, ErrUnsupportedGlob, got, err)
+			}
+			continue
+		}
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v (%v)", tt.regex, tt.want, got, err)
+		}
+	}
+}
+
+// settings returns the setting names of notes
+func settings(notes []Note) []string {
+	var names []string
+	for _, n := range notes {
+		names = append(names, n.Setting)
+	}
+	return names
+}
@@ -0,0 +1,230 @@
+// Package importer translates other versioning tools' configuration into the
+// nearest .versionator.yaml equivalent.
+//
+// Each translator starts from config.Default() and records, per source
+// setting, whether it was translated directly, approximated, or has no
+// equivalent, so the migration report can say what needs a human decision.
+package importer
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+)
+
+// versionTemplate renders the full SemVer version for manifest updates
+const versionTemplate = "{{MajorMinorPatch}}{{PreReleaseWithDash}}"
+
+// Note explains what happened to one source setting
+type Note struct {
+	// Setting is the source key (e.g. "tag-prefix", "branches.release.regex")
+	Setting string
+	// Message describes the translation or why there is none
+	Message string
+}
+
+// Result is a translated configuration and its migration report
+type Result struct {
+	// Config is the translated configuration
+	Config *config.Config
+	// Translated lists settings with a direct equivalent
+	Translated []Note
+	// Approximated lists settings mapped to the nearest, not exact, equivalent
+	Approximated []Note
+	// Unsupported lists settings with no equivalent; they were ignored
+	Unsupported []Note
+}
+
+func newResult() *Result {
+	return &Result{Config: config.Default()}
+}
+
+func (r *Result) translated(setting, format string, args ...any) {
+	r.Translated = append(r.Translated, Note{Setting: setting, Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *Result) approximated(setting, format string, args ...any) {
+	r.Approximated = append(r.Approximated, Note{Setting: setting, Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *Result) unsupported(setting, format string, args ...any) {
+	r.Unsupported = append(r.Unsupported, Note{Setting: setting, Message: fmt.Sprintf(format, args...)})
+}
+
+// sortedKeys returns map keys in order, so reports are deterministic
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// asString renders a scalar YAML value as a string
+func asString(value any) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// asBool reports whether a YAML value is boolean true
+func asBool(value any) bool {
+	b, ok := value.(bool)
+	return ok && b
+}
+
+// prefixFromPattern maps a tag prefix (literal or simple regex such as
+// "[vV]?") to the versionator prefix; ok is false when there is no equivalent
+func prefixFromPattern(pattern string) (prefix string, ok bool) {
+	switch strings.TrimSuffix(pattern, "?") {
+	case "":
+		return "", true
+	case "v", "[vV]", "[Vv]":
+		return "v", true
+	case "V":
+		return "V", true
+	}
+	return "", false
+}
+
+// branchGlobs translates a branch-name regular expression to glob patterns.
+// It understands the subset used in practice: anchors, literal text,
+// alternation, optional characters or groups ("s?", "(es)?"), character
+// classes of literals ("[/-]"), and a trailing ".+" / ".*" or named capture
+// group; anything else returns ErrUnsupportedGlob.
+func branchGlobs(regex string) ([]string, error) {
+	p := &globParser{src: regex}
+	alternatives, err := p.sequence()
+	if err != nil || p.pos != len(p.src) {
+		return nil, fmt.Errorf("%s: %q", ErrUnsupportedGlob, regex)
+	}
+
+	var globs []string
+	for _, alt := range alternatives {
+		// Unanchored patterns match anywhere in the name
+		if !strings.HasPrefix(alt, "^") {
+			alt = "*" + alt
+		}
+		alt = strings.TrimPrefix(alt, "^")
+		switch {
+		case strings.HasSuffix(alt, "$"):
+			alt = strings.TrimSuffix(alt, "$")
+		case !strings.HasSuffix(alt, "*"):
+			alt += "*"
+		}
+		if strings.ContainsAny(alt, "^$") || strings.Trim(alt, "*") == "" {
+			return nil, fmt.Errorf("%s: %q", ErrUnsupportedGlob, regex)
+		}
+		if !slices.Contains(globs, alt) {
+			globs = append(globs, alt)
+		}
+	}
+	return globs, nil
+}
+
+// maxGlobs bounds the expansion of optional parts and classes
+const maxGlobs = 16
+
+// globParser expands a restricted regular expression into literal strings
+type globParser struct {
+	src string
+	pos int
+}
+
+// sequence parses alternatives separated by '|' up to ')' or end of input
+func (p *globParser) sequence() ([]string, error) {
+	var result []string
+	current := []string{""}
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch c {
+		case ')':
+			return append(result, current...), nil
+		case '|':
+			p.pos++
+			result = append(result, current...)
+			current = []string{""}
+			continue
+		}
+
+		atom, err := p.atom()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos < len(p.src) && p.src[p.pos] == '?' {
+			p.pos++
+			atom = append([]string{""}, atom...)
+		}
+
+		var next []string
+		for _, prefix := range current {
+			for _, a := range atom {
+				next = append(next, prefix+a)
+			}
+		}
+		if len(next) > maxGlobs {
+			return nil, fmt.Errorf("%s", ErrUnsupportedGlob)
+		}
+		current = next
+	}
+	return append(result, current...), nil
+}
+
+// atom parses a single element and returns the strings it can match
+func (p *globParser) atom() ([]string, error) {
+	c := p.src[p.pos]
+	switch {
+	case c == '^':
+		p.pos++
+		return []string{"^"}, nil
+	case c == '$':
+		p.pos++
+		return []string{"$"}, nil
+	case c == '.' && p.pos+1 < len(p.src) && (p.src[p.pos+1] == '+' || p.src[p.pos+1] == '*'):
+		p.pos += 2
+		return []string{"*"}, nil
+	case c == '\\' && p.pos+1 < len(p.src) && strings.IndexByte("/-._", p.src[p.pos+1]) >= 0:
+		p.pos += 2
+		return []string{string(p.src[p.pos-1])}, nil
+	case c == '[':
+		end := strings.IndexByte(p.src[p.pos:], ']')
+		if end < 0 {
+			return nil, fmt.Errorf("%s", ErrUnsupportedGlob)
+		}
+		class := strings.ReplaceAll(p.src[p.pos+1:p.pos+end], `\`, "")
+		p.pos += end + 1
+		var chars []string
+		for _, r := range class {
+			if !isLiteral(byte(r)) {
+				return nil, fmt.Errorf("%s", ErrUnsupportedGlob)
+			}
+			chars = append(chars, string(r))
+		}
+		return chars, nil
+	case c == '(':
+		p.pos++
+		// Named or non-capturing groups: (?<Name>...), (?P<Name>...), (?:...)
+		if strings.HasPrefix(p.src[p.pos:], "?") {
+			end := strings.IndexAny(p.src[p.pos:], ">:")
+			if end < 0 {
+				return nil, fmt.Errorf("%s", ErrUnsupportedGlob)
+			}
+			p.pos += end + 1
+		}
+		inner, err := p.sequence()
+		if err != nil || p.pos >= len(p.src) || p.src[p.pos] != ')' {
+			return nil, fmt.Errorf("%s", ErrUnsupportedGlob)
+		}
+		p.pos++
+		return inner, nil
+	case isLiteral(c):
+		p.pos++
+		return []string{string(c)}, nil
+	}
+	return nil, fmt.Errorf("%s", ErrUnsupportedGlob)
+}
+
+// isLiteral reports whether c may appear literally in a branch glob
+func isLiteral(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '/' || c == '-' || c == '_'
+}
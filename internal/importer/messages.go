@@ -0,0 +1,9 @@
+package importer
+
+// Error messages
+const (
+	ErrParseSource     = "failed to parse configuration"
+	ErrUnsupportedGlob = "cannot translate branch pattern to a glob"
+	ErrNoReleaseConfig = "no semantic-release configuration found"
+	ErrNoBumpVersion   = "no [bumpversion] section found"
+)
@@ -0,0 +1,244 @@
+package importer
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FromSemanticRelease translates a semantic-release configuration (.releaserc
+// in JSON or YAML, or a package.json with a "release" key) into a versionator
+// configuration. Release branches become main branches, pre-release branches
+// are labelled by branchVersioning.prereleaseTemplate, and distribution
+// channels, which versionator has no equivalent for, are reported per branch.
+func FromSemanticRelease(data []byte) (*Result, error) {
+	// JSON is a subset of YAML, so one decoder reads every .releaserc form
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrParseSource, err)
+	}
+	if release, ok := raw["release"].(map[string]any); ok {
+		raw = release
+	} else if _, isPackage := raw["version"]; isPackage {
+		return nil, fmt.Errorf("%s: package.json has no \"release\" key", ErrNoReleaseConfig)
+	}
+
+	r := newResult()
+	cfg := r.Config
+
+	if _, ok := raw["branches"]; !ok {
+		r.approximated("branches", "not set: semantic-release's defaults are close to versionator's mainBranches (%s)", strings.Join(cfg.BranchVersioning.MainBranches, ", "))
+	}
+
+	for _, key := range sortedKeys(raw) {
+		value := raw[key]
+		switch key {
+		case "branches":
+			semanticReleaseBranches(r, value)
+
+		case "tagFormat":
+			format := asString(value)
+			prefix, ok := strings.CutSuffix(format, "${version}")
+			if ok && (prefix == "" || prefix == "v" || prefix == "V") {
+				cfg.Prefix = prefix
+				r.translated(key, "prefix: %q", prefix)
+			} else {
+				r.unsupported(key, "%q: versionator tags are the version with an optional 'v' or 'V' prefix", format)
+			}
+
+		case "plugins":
+			semanticReleasePlugins(r, value)
+
+		case "repositoryUrl":
+			url := asString(value)
+			if repository, ok := repositoryFromURL(url); ok {
+				cfg.Release.Publish.Repository = repository
+				r.translated(key, "release.publish.repository: %s", repository)
+			} else {
+				r.unsupported(key, "cannot determine owner/name from %q", url)
+			}
+
+		case "dryRun":
+			r.approximated(key, "use --dry-run on `versionator release`")
+
+		default:
+			r.unsupported(key, "no versionator equivalent")
+		}
+	}
+	return r, nil
+}
+
+// semanticReleaseBranches maps release branches to main branches and reports
+// pre-release labels, maintenance ranges, and channels
+func semanticReleaseBranches(r *Result, value any) {
+	entries, ok := value.([]any)
+	if !ok {
+		entries = []any{value}
+	}
+
+	var mainBranches []string
+	for _, entry := range entries {
+		name, branch := asString(entry), map[string]any(nil)
+		if m, ok := entry.(map[string]any); ok {
+			name, branch = asString(m["name"]), m
+		}
+		setting := "branches." + name
+		if !isBranchGlob(name) {
+			r.unsupported(setting, "%q is not a simple glob; list matching branches in branchVersioning.mainBranches by hand", name)
+			continue
+		}
+
+		if pre, ok := branch["prerelease"]; ok && pre != false {
+			// prerelease: true and "${name}" both label with the branch name
+			label, _ := pre.(string)
+			if label == "" || strings.Contains(label, "${name") {
+				r.translated(setting, "pre-release branch labelled by branchVersioning.prereleaseTemplate ({{EscapedBranchName}})")
+			} else {
+				r.approximated(setting+".prerelease", "%q: versionator labels pre-release branches by name via branchVersioning.prereleaseTemplate", label)
+			}
+		} else {
+			mainBranches = append(mainBranches, name)
+			r.translated(setting, "branchVersioning.mainBranches: %s", name)
+		}
+
+		for _, key := range sortedKeys(branch) {
+			switch key {
+			case "name", "prerelease":
+			case "channel":
+				if channel := branch[key]; channel != false && asString(channel) != "" {
+					r.approximated(setting+".channel", "releases to channel %q: versionator has no distribution channels; pass it to your publish step", asString(channel))
+				}
+			case "range":
+				r.approximated(setting+".range", "%q: versionator does not restrict maintenance branches to a range", asString(branch[key]))
+			default:
+				r.unsupported(setting+"."+key, "no versionator equivalent")
+			}
+		}
+	}
+
+	r.Config.BranchVersioning.Enabled = true
+	if len(mainBranches) > 0 {
+		slices.Sort(mainBranches)
+		r.Config.BranchVersioning.MainBranches = slices.Compact(mainBranches)
+	}
+}
+
+// semanticReleasePlugins maps plugins to updates and release publishing
+func semanticReleasePlugins(r *Result, value any) {
+	entries, _ := value.([]any)
+	for _, entry := range entries {
+		// A plugin is "name" or ["name", {options}]
+		name, options := asString(entry), map[string]any(nil)
+		if pair, ok := entry.([]any); ok && len(pair) > 0 {
+			name = asString(pair[0])
+			if len(pair) > 1 {
+				options, _ = pair[1].(map[string]any)
+			}
+		}
+		setting := "plugins." + name
+		publish := &r.Config.Release.Publish
+
+		switch name {
+		case "@semantic-release/commit-analyzer":
+			r.translated(setting, "`versionator bump` reads Conventional Commits")
+			for _, key := range sortedKeys(options) {
+				r.unsupported(setting+"."+key, "commit analysis is not configurable")
+			}
+
+		case "@semantic-release/npm":
+			file := path.Join(asString(options["pkgRoot"]), "package.json")
+			r.Config.Updates = append(r.Config.Updates, updateFor(file, "version"))
+			r.translated(setting, "updates: %s version", file)
+			if options["npmPublish"] != false {
+				r.approximated(setting+".npmPublish", "versionator does not publish packages; run `npm publish` after `versionator release`")
+			}
+
+		case "@semantic-release/github", "@semantic-release/gitlab", "@semantic-release/gitea":
+			publish.Provider = strings.TrimPrefix(name, "@semantic-release/")
+			r.translated(setting, "release.publish.provider: %s", publish.Provider)
+			for _, key := range sortedKeys(options) {
+				switch key {
+				case "assets":
+					publish.Assets = append(publish.Assets, assetPaths(options[key])...)
+					r.translated(setting+".assets", "release.publish.assets")
+				case "githubApiUrl", "giteaUrl":
+					publish.APIURL = asString(options[key])
+					r.translated(setting+"."+key, "release.publish.apiUrl")
+				case "gitlabUrl":
+					publish.APIURL = strings.TrimSuffix(asString(options[key]), "/") + "/api/v4"
+					r.translated(setting+"."+key, "release.publish.apiUrl: %s", publish.APIURL)
+				default:
+					r.unsupported(setting+"."+key, "no versionator equivalent")
+				}
+			}
+
+		case "@semantic-release/git":
+			r.approximated(setting, "`versionator release` commits VERSION and the files listed in updates before tagging")
+
+		case "@semantic-release/release-notes-generator", "@semantic-release/changelog":
+			r.unsupported(setting, "versionator does not generate release notes; set release.publish.notes to a template")
+
+		default:
+			r.unsupported(setting, "no versionator equivalent")
+		}
+	}
+}
+
+// updateFor is a manifest update that writes the full version at path
+func updateFor(file, selector string) config.UpdateConfig {
+	return config.UpdateConfig{File: file, Path: selector, Template: versionTemplate}
+}
+
+// assetPaths collects glob patterns from a semantic-release assets option:
+// a string, a list of strings, or a list of {path: ...} objects
+func assetPaths(value any) []string {
+	entries, ok := value.([]any)
+	if !ok {
+		entries = []any{value}
+	}
+	var paths []string
+	for _, entry := range entries {
+		if m, ok := entry.(map[string]any); ok {
+			entry = m["path"]
+		}
+		if p := asString(entry); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// repositoryFromURL extracts "owner/name" from an https, ssh, or scp-style
+// repository URL
+func repositoryFromURL(url string) (string, bool) {
+	url = strings.TrimSuffix(url, ".git")
+	var repoPath string
+	if _, rest, ok := strings.Cut(url, "://"); ok {
+		_, repoPath, _ = strings.Cut(rest, "/")
+	} else {
+		_, repoPath, _ = strings.Cut(url, ":")
+	}
+	repoPath = strings.Trim(repoPath, "/")
+	if !strings.Contains(repoPath, "/") {
+		return "", false
+	}
+	return repoPath, true
+}
+
+// isBranchGlob reports whether name is a plain branch name or '*' glob
+func isBranchGlob(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; !isLiteral(c) && c != '.' && c != '*' {
+			return false
+		}
+	}
+	return true
+}
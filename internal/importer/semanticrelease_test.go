@@ -0,0 +1,58 @@
+package importer
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestFromSemanticRelease_MapsBranchesPluginsAndChannels validates the
+// semantic-release translation.
+//
+// Why: Release branches, the npm and GitHub plugins, and the tag format have
+// versionator equivalents; channels do not, and must be reported per branch
+// so the migration does not silently lose where releases were published.
+//
+// What: A .releaserc yields main branches, a package.json update, GitHub
+// publishing, prefix "v", and a channel note; a package.json without a
+// "release" key is rejected with ErrNoReleaseConfig.
+func TestFromSemanticRelease_MapsBranchesPluginsAndChannels(t *testing.T) {
+	// Precondition: A .releaserc with branches, channels, and plugins
+	source := `{
+  "branches": ["main", {"name": "next", "channel": "next"}, {"name": "beta", "prerelease": true}],
+  "tagFormat": "v${version}",
+  "repositoryUrl": "https://github.com/acme/widget.git",
+  "plugins": ["@semantic-release/commit-analyzer", ["@semantic-release/npm", {"pkgRoot": "web"}], "@semantic-release/github"]
+}`
+
+	// Action: Translate
+	result, err := FromSemanticRelease([]byte(source))
+
+	// Expected: Branches, plugins, and prefix translated; channel reported
+	if err != nil {
+		t.Fatalf("FromSemanticRelease failed: %v", err)
+	}
+	cfg := result.Config
+	if cfg.Prefix != "v" {
+		t.Errorf("expected prefix 'v', got %q", cfg.Prefix)
+	}
+	if !slices.Equal(cfg.BranchVersioning.MainBranches, []string{"main", "next"}) {
+		t.Errorf("expected main branches [main next], got %v", cfg.BranchVersioning.MainBranches)
+	}
+	if len(cfg.Updates) != 1 || cfg.Updates[0].File != "web/package.json" || cfg.Updates[0].Path != "version" {
+		t.Errorf("expected web/package.json version update, got %+v", cfg.Updates)
+	}
+	if cfg.Release.Publish.Provider != "github" || cfg.Release.Publish.Repository != "acme/widget" {
+		t.Errorf("expected github acme/widget, got %+v", cfg.Release.Publish)
+	}
+	if !slices.Contains(settings(result.Approximated), "branches.next.channel") {
+		t.Errorf("expected channel to be reported, got %v", result.Approximated)
+	}
+
+	// Action: Translate a package.json without semantic-release configuration
+	_, err = FromSemanticRelease([]byte(`{"name": "widget", "version": "1.0.0"}`))
+
+	// Expected: Rejected
+	if err == nil {
+		t.Errorf("expected %q for package.json without release key", ErrNoReleaseConfig)
+	}
+}
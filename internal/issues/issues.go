@@ -0,0 +1,168 @@
+// Package issues provides a template provider that extracts issue-tracker
+// keys (e.g., Jira "PROJ-123") from commit messages since the last tag.
+package issues
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cbroglie/mustache"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+)
+
+// ConfigLoader returns the issue extraction settings
+type ConfigLoader func() (config.IssuesConfig, error)
+
+// MessageLoader returns commit messages since the last tag, newest first
+type MessageLoader func() ([]string, error)
+
+// Provider exposes issue keys as template variables and sections
+type Provider struct {
+	loadConfig   ConfigLoader
+	loadMessages MessageLoader
+
+	// The last collection and the invocation it belongs to, so variables
+	// and sections of one command walk the commits once
+	mu          sync.Mutex
+	collected   *collection
+	collectedIn uint64
+}
+
+// collection is the result of collect
+type collection struct {
+	keys []string
+	cfg  config.IssuesConfig
+	ok   bool
+}
+
+// NewProvider creates a Provider with injected config and message loaders
+func NewProvider(configLoader ConfigLoader, messageLoader MessageLoader) *Provider {
+	return &Provider{loadConfig: configLoader, loadMessages: messageLoader}
+}
+
+// NewProviderDefault creates a Provider that reads .versionator.yaml and the
+// active VCS
+func NewProviderDefault() *Provider {
+	return NewProvider(
+		func() (config.IssuesConfig, error) {
+			cfg, err := config.ReadConfig()
+			if err != nil {
+				return config.IssuesConfig{}, err
+			}
+			return cfg.Issues, nil
+		},
+		func() ([]string, error) {
+			activeVCS := vcs.GetActiveVCS()
+			if activeVCS == nil {
+				return nil, nil
+			}
+			return activeVCS.GetCommitMessagesSinceTag()
+		},
+	)
+}
+
+// Name returns "issues"
+func (p *Provider) Name() string {
+	return "issues"
+}
+
+// Types returns the set of plugin types this provider implements
+func (p *Provider) Types() plugin.PluginTypeSet {
+	return plugin.NewPluginTypeSet(plugin.TypeTemplateProvider)
+}
+
+// GetTemplateVariables returns IssueKeys (comma-separated) and IssueCount.
+// Returns nil when extraction is not configured or fails.
+func (p *Provider) GetTemplateVariables(context map[string]string) map[string]string {
+	keys, _, ok := p.collect()
+	if !ok {
+		return nil
+	}
+	return map[string]string{
+		"IssueKeys":  strings.Join(keys, ", "),
+		"IssueCount": strconv.Itoa(len(keys)),
+	}
+}
+
+// GetTemplateSections returns the Issues section with one item per key.
+// Each item has Key and URL (rendered from issues.url, empty if unset).
+func (p *Provider) GetTemplateSections(context map[string]string) map[string][]map[string]string {
+	keys, cfg, ok := p.collect()
+	if !ok {
+		return nil
+	}
+	items := make([]map[string]string, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, map[string]string{"Key": key, "URL": issueURL(cfg.URL, key)})
+	}
+	return map[string][]map[string]string{"Issues": items}
+}
+
+// collect loads configuration and extracts keys; ok is false when extraction
+// is disabled or any step fails, so templates render without issue variables.
+// Within a command invocation (config.Invocation) the result is reused.
+func (p *Provider) collect() ([]string, config.IssuesConfig, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	invocation := config.Invocation()
+	if p.collected == nil || invocation == 0 || p.collectedIn != invocation {
+		keys, cfg, ok := p.extractKeys()
+		p.collected, p.collectedIn = &collection{keys: keys, cfg: cfg, ok: ok}, invocation
+	}
+	return p.collected.keys, p.collected.cfg, p.collected.ok
+}
+
+// extractKeys does the work of collect
+func (p *Provider) extractKeys() ([]string, config.IssuesConfig, bool) {
+	cfg, err := p.loadConfig()
+	if err != nil || cfg.Pattern == "" {
+		return nil, cfg, false
+	}
+	re, err := regexp.Compile(cfg.Pattern)
+	if err != nil {
+		return nil, cfg, false
+	}
+	messages, err := p.loadMessages()
+	if err != nil {
+		return nil, cfg, false
+	}
+	return Extract(messages, re), cfg, true
+}
+
+// Extract returns the distinct issue keys matched in messages. Messages are
+// expected newest first (as returned by the VCS); keys are returned in the
+// order they were first referenced, oldest first.
+func Extract(messages []string, re *regexp.Regexp) []string {
+	keys := []string{}
+	for _, msg := range slices.Backward(messages) {
+		for _, key := range re.FindAllString(msg, -1) {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// issueURL renders the URL template for a key, returning empty on failure
+func issueURL(tmpl, key string) string {
+	if tmpl == "" {
+		return ""
+	}
+	url, err := mustache.RenderRaw(tmpl, true, map[string]string{"Key": key})
+	if err != nil {
+		return ""
+	}
+	return url
+}
+
+// Auto-registration as a template provider plugin
+func init() {
+	plugin.Register(NewProviderDefault())
+}
@@ -0,0 +1,138 @@
+package issues
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+)
+
+// staticProvider builds a Provider from fixed settings and messages
+func staticProvider(cfg config.IssuesConfig, messages []string, err error) *Provider {
+	return NewProvider(
+		func() (config.IssuesConfig, error) { return cfg, nil },
+		func() ([]string, error) { return messages, err },
+	)
+}
+
+// TestExtract_DistinctKeysOldestFirst validates key extraction.
+//
+// Why: Release notes list each referenced issue once, in the order work
+// happened, while the VCS returns messages newest first.
+//
+// What: Keys from multiple messages are de-duplicated and ordered by first
+// reference in chronological order.
+func TestExtract_DistinctKeysOldestFirst(t *testing.T) {
+	// Precondition: Messages newest first, with a repeated key
+	messages := []string{
+		"fix: crash on empty input (JIRA-15)",
+		"feat: add export JIRA-12 JIRA-13",
+		"chore: prep for JIRA-12",
+	}
+
+	// Action: Extract with a Jira pattern
+	keys := Extract(messages, regexp.MustCompile(`JIRA-\d+`))
+
+	// Expected: Distinct keys in chronological order
+	want := []string{"JIRA-12", "JIRA-13", "JIRA-15"}
+	if !slices.Equal(keys, want) {
+		t.Errorf("Extract() = %v, want %v", keys, want)
+	}
+}
+
+// TestProvider_VariablesAndSections validates the exposed template data.
+//
+// Why: Templates use {{IssueKeys}} for one-line summaries and the
+// {{#Issues}} section for linked release-note lists.
+//
+// What: IssueKeys is comma-separated, IssueCount is the number of keys, and
+// each Issues item carries Key and the rendered URL.
+func TestProvider_VariablesAndSections(t *testing.T) {
+	// Precondition: Pattern and URL template configured
+	p := staticProvider(config.IssuesConfig{
+		Pattern: `[A-Z][A-Z0-9]+-\d+`,
+		URL:     "https://tracker.example/browse/{{Key}}",
+	}, []string{"OPS-7: rotate keys", "feat: API-1 pagination"}, nil)
+
+	// Action: Get variables and sections
+	vars := p.GetTemplateVariables(nil)
+	sections := p.GetTemplateSections(nil)
+
+	// Expected: Both keys present and linked
+	if vars["IssueKeys"] != "API-1, OPS-7" || vars["IssueCount"] != "2" {
+		t.Errorf("unexpected variables: %v", vars)
+	}
+	items := sections["Issues"]
+	if len(items) != 2 || items[0]["Key"] != "API-1" || items[0]["URL"] != "https://tracker.example/browse/API-1" {
+		t.Errorf("unexpected sections: %v", sections)
+	}
+}
+
+// TestProvider_DisabledOrFailing_ReturnsNil validates graceful degradation.
+//
+// Why: Template rendering must not fail (or touch the VCS) for projects that
+// never configured issue extraction.
+//
+// What: No pattern, an invalid pattern, or a message loading error all yield
+// no variables and no sections.
+func TestProvider_DisabledOrFailing_ReturnsNil(t *testing.T) {
+	// Precondition: Providers that cannot extract keys
+	providers := map[string]*Provider{
+		"no pattern":      staticProvider(config.IssuesConfig{}, []string{"JIRA-1"}, nil),
+		"invalid pattern": staticProvider(config.IssuesConfig{Pattern: "("}, []string{"JIRA-1"}, nil),
+		"loader error":    staticProvider(config.IssuesConfig{Pattern: `JIRA-\d+`}, nil, errors.New("boom")),
+	}
+
+	for name, p := range providers {
+		// Action: Get variables and sections
+		vars := p.GetTemplateVariables(nil)
+		sections := p.GetTemplateSections(nil)
+
+		// Expected: Nothing exposed
+		if vars != nil || sections != nil {
+			t.Errorf("%s: expected nil, got vars=%v sections=%v", name, vars, sections)
+		}
+	}
+}
+
+// TestProvider_Invocation_CollectsOnce validates caching of extracted keys.
+//
+// Why: A template using {{IssueKeys}} and {{#Issues}} asks for variables and
+// sections separately; each would otherwise read the config and walk the
+// commits since the last tag again.
+//
+// What: Within one invocation the messages are loaded once for variables
+// and sections; a new invocation loads them again, and outside an
+// invocation nothing is cached.
+func TestProvider_Invocation_CollectsOnce(t *testing.T) {
+	// Precondition: A provider counting message loads
+	loads := 0
+	p := NewProvider(
+		func() (config.IssuesConfig, error) { return config.IssuesConfig{Pattern: `JIRA-\d+`}, nil },
+		func() ([]string, error) {
+			loads++
+			return []string{"feat: JIRA-1"}, nil
+		},
+	)
+	t.Cleanup(config.EndInvocation)
+
+	// Action: Variables and sections in two invocations, then outside one
+	for range 2 {
+		config.BeginInvocation()
+		p.GetTemplateVariables(nil)
+		p.GetTemplateSections(nil)
+		config.EndInvocation()
+	}
+	p.GetTemplateVariables(nil)
+	vars := p.GetTemplateVariables(nil)
+
+	// Expected: One load per invocation, one per call outside
+	if loads != 4 {
+		t.Errorf("expected 4 message loads, got %d", loads)
+	}
+	if vars["IssueKeys"] != "JIRA-1" {
+		t.Errorf("unexpected variables: %v", vars)
+	}
+}
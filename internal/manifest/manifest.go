@@ -0,0 +1,116 @@
+// Package manifest records which files accompanied a release.
+//
+// A manifest lists file paths with their SHA-256 checksums. It is appended to
+// the release tag annotation in `sha256sum` format, so an audit can later
+// confirm which generated or patched artifacts correspond to a tag:
+//
+//	git tag -l --format='%(contents)' v1.2.3 | sed -n '/^Artifacts/,$p' | tail -n +2 | sha256sum -c
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Header introduces the manifest section in a tag annotation
+const Header = "Artifacts (sha256):"
+
+// Entry is a single file in a manifest
+type Entry struct {
+	// Path is the file path, slash-separated, as given to Build
+	Path string
+	// SHA256 is the hex-encoded SHA-256 checksum of the file contents
+	SHA256 string
+}
+
+// Build checksums the given files. Paths are de-duplicated and sorted so the
+// manifest is stable regardless of the order files were updated in.
+func Build(paths []string) ([]Entry, error) {
+	return BuildAt("", paths)
+}
+
+// BuildAt checksums files given relative to dir, listing them by those
+// relative paths, as Build does for the working directory
+func BuildAt(dir string, paths []string) ([]Entry, error) {
+	seen := make(map[string]bool)
+	entries := make([]Entry, 0, len(paths))
+	for _, path := range paths {
+		path = filepath.ToSlash(filepath.Clean(path))
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", ErrReadFile, path, err)
+		}
+		sum := sha256.Sum256(data)
+		entries = append(entries, Entry{Path: path, SHA256: hex.EncodeToString(sum[:])})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+	return entries, nil
+}
+
+// Format renders entries as a manifest section: the Header line followed by
+// one `<checksum>  <path>` line per entry. Returns empty for no entries.
+func Format(entries []Entry) string {
+	if len(entries) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(Header)
+	for _, e := range entries {
+		fmt.Fprintf(&b, "\n%s  %s", e.SHA256, e.Path)
+	}
+	return b.String()
+}
+
+// Digest returns a single hex-encoded SHA-256 over entries, as built by
+// Build: the checksum of their `<checksum>  <path>` lines. It changes when
+// any file's contents or path does, so it can key caches on a set of files.
+func Digest(entries []Entry) string {
+	h := sha256.New()
+	for _, e := range entries {
+		fmt.Fprintf(h, "%s  %s\n", e.SHA256, e.Path)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Parse extracts manifest entries from a tag annotation produced with Format.
+// Lines after the Header that are not checksum lines end the section.
+func Parse(message string) []Entry {
+	var entries []Entry
+	inManifest := false
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if !inManifest {
+			inManifest = line == Header
+			continue
+		}
+		sum, path, ok := strings.Cut(line, "  ")
+		if !ok || len(sum) != sha256.Size*2 || path == "" {
+			break
+		}
+		entries = append(entries, Entry{Path: path, SHA256: sum})
+	}
+	return entries
+}
+
+// Append adds a manifest section for entries to a tag message, separated by a
+// blank line. The message is returned unchanged when there are no entries.
+func Append(message string, entries []Entry) string {
+	section := Format(entries)
+	if section == "" {
+		return message
+	}
+	return strings.TrimRight(message, "\n") + "\n\n" + section
+}
@@ -0,0 +1,102 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestBuild_SortsDeduplicatesAndRoundTrips validates manifest construction.
+//
+// Why: The manifest is compared across audits, so it must be stable no matter
+// what order files were patched in, and must be readable back from a tag.
+//
+// What: Duplicate paths collapse, entries sort by path, checksums match
+// sha256sum output, and Parse recovers the entries from an appended message.
+func TestBuild_SortsDeduplicatesAndRoundTrips(t *testing.T) {
+	// Precondition: Two files in a temp directory
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.MkdirAll("pkg", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("VERSION", []byte("1.2.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("pkg", "version.go"), []byte("package pkg\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action: Build with duplicates and unsorted input, then append and parse
+	entries, err := Build([]string{"VERSION", "pkg/version.go", "./VERSION"})
+	message := Append("Release 1.2.3\n", entries)
+	parsed := Parse(message + "\n\nSigned-off-by: someone")
+
+	// Expected: Two sorted entries with sha256sum-compatible lines
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Path != "VERSION" || entries[1].Path != "pkg/version.go" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	// sha256("1.2.3\n")
+	const versionSum = "d82f34ae9aa41bc4a0cb529a1ac0898fed09d6b479fb1cc44cb66c34f15ee84d"
+	if !strings.HasPrefix(message, "Release 1.2.3\n\n"+Header+"\n") {
+		t.Errorf("unexpected message layout %q", message)
+	}
+	if len(parsed) != 2 || parsed[0] != entries[0] || parsed[1] != entries[1] {
+		t.Errorf("Parse did not round-trip: %+v", parsed)
+	}
+	if entries[0].SHA256 != versionSum {
+		t.Errorf("unexpected VERSION checksum %s", entries[0].SHA256)
+	}
+}
+
+// TestBuild_MissingFile_ReturnsError validates missing artifact handling.
+//
+// Why: A manifest that silently skips a file would misrepresent the release.
+//
+// What: Build fails naming the missing path; Append leaves messages without
+// entries unchanged.
+func TestBuild_MissingFile_ReturnsError(t *testing.T) {
+	// Precondition: Empty directory
+	t.Chdir(t.TempDir())
+
+	// Action: Build a manifest for a file that does not exist
+	_, err := Build([]string{"dist/app.tar.gz"})
+
+	// Expected: Read error naming the file; empty manifests add nothing
+	if err == nil || !strings.Contains(err.Error(), ErrReadFile) || !strings.Contains(err.Error(), "dist/app.tar.gz") {
+		t.Errorf("expected %q for dist/app.tar.gz, got %v", ErrReadFile, err)
+	}
+	if got := Append("Release 1.0.0", nil); got != "Release 1.0.0" {
+		t.Errorf("expected unchanged message, got %q", got)
+	}
+}
+
+// TestDigest_ChangesWithContentsAndPaths validates the combined checksum.
+//
+// Why: CI cache keys hash input files; the key must change whenever a file
+// does, and only then.
+//
+// What: Equal entries give equal digests; a changed checksum or path gives
+// a different one.
+func TestDigest_ChangesWithContentsAndPaths(t *testing.T) {
+	// Precondition
+	sum := strings.Repeat("a", 64)
+	base := []Entry{{Path: "go.sum", SHA256: sum}}
+
+	// Action
+	same := Digest([]Entry{{Path: "go.sum", SHA256: sum}})
+	changed := Digest([]Entry{{Path: "go.sum", SHA256: strings.Repeat("b", 64)}})
+	moved := Digest([]Entry{{Path: "sub/go.sum", SHA256: sum}})
+
+	// Expected
+	if got := Digest(base); got != same || len(got) != 64 {
+		t.Errorf("Digest not stable: %s vs %s", got, same)
+	}
+	if changed == same || moved == same {
+		t.Error("Digest did not change with contents or path")
+	}
+}
@@ -0,0 +1,6 @@
+package manifest
+
+// Error messages
+const (
+	ErrReadFile = "failed to read manifest file"
+)
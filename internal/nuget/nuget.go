@@ -0,0 +1,123 @@
+// Package nuget provides a template provider that renders the current
+// version as a NuGet package version ({{NuGetVersion}}), so .NET pipelines
+// can pass it to `dotnet pack` without post-processing.
+//
+// NuGet ignores build metadata when comparing packages and nuget.org rejects
+// package versions that differ only in it, so metadata is dropped.
+// Pre-release identifiers are restricted to ASCII letters, digits, and
+// hyphens, numeric identifiers lose leading zeros, and the pre-release is
+// shortened to MaxPreReleaseLength characters. Shortening cuts the text
+// before the trailing numeric identifiers, so builds that differ only in a
+// counter (feature-x.42, feature-x.43) keep distinct versions.
+package nuget
+
+import (
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+)
+
+// MaxPreReleaseLength is the longest pre-release label kept; NuGet 2 clients
+// reject longer labels
+const MaxPreReleaseLength = 20
+
+// Convert builds a NuGet package version from a release (e.g. "1.2.3") and a
+// SemVer pre-release (e.g. "feature/login.007")
+func Convert(release, prerelease string) string {
+	if label := Label(prerelease); label != "" {
+		return release + "-" + label
+	}
+	return release
+}
+
+// Label converts a SemVer pre-release to a NuGet pre-release label, without
+// the leading "-"
+func Label(prerelease string) string {
+	label := normalize(prerelease)
+	if len(label) <= MaxPreReleaseLength {
+		return label
+	}
+	head, tail := splitCounter(label)
+	switch {
+	case tail == "":
+		return normalize(head[:MaxPreReleaseLength])
+	case head == "":
+		return tail
+	}
+	// Keep at least one character of the head, so the label still starts
+	// with the branch or stage name
+	budget := max(MaxPreReleaseLength-len(tail)-1, 1)
+	return normalize(head[:min(budget, len(head))]) + "." + tail
+}
+
+// splitCounter splits a normalized label before its trailing numeric
+// identifiers ("feature-x.rc.4.2" into "feature-x.rc" and "4.2")
+func splitCounter(label string) (head, tail string) {
+	identifiers := strings.Split(label, ".")
+	i := len(identifiers)
+	for i > 0 && strings.Trim(identifiers[i-1], "0123456789") == "" {
+		i--
+	}
+	return strings.Join(identifiers[:i], "."), strings.Join(identifiers[i:], ".")
+}
+
+// normalize rewrites a pre-release as dot-separated identifiers of ASCII
+// letters, digits, and hyphens, without empty identifiers, leading zeros on
+// numbers, or trailing hyphens
+func normalize(prerelease string) string {
+	var identifiers []string
+	for _, id := range strings.Split(prerelease, ".") {
+		id = strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' {
+				return r
+			}
+			return '-'
+		}, id)
+		id = strings.Trim(id, "-")
+		if id == "" {
+			continue
+		}
+		if strings.Trim(id, "0123456789") == "" {
+			if id = strings.TrimLeft(id, "0"); id == "" {
+				id = "0"
+			}
+		}
+		identifiers = append(identifiers, id)
+	}
+	return strings.Join(identifiers, ".")
+}
+
+// Provider exposes the NuGet version as a template variable
+type Provider struct{}
+
+// NewProvider creates a Provider
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+// Name returns "nuget"
+func (p *Provider) Name() string {
+	return "nuget"
+}
+
+// Types returns the set of plugin types this provider implements
+func (p *Provider) Types() plugin.PluginTypeSet {
+	return plugin.NewPluginTypeSet(plugin.TypeTemplateProvider)
+}
+
+// GetTemplateVariables returns NuGetVersion built from the MajorMinorPatch
+// and PreRelease context variables; nil without a version
+func (p *Provider) GetTemplateVariables(context map[string]string) map[string]string {
+	release := context["MajorMinorPatch"]
+	if release == "" {
+		return nil
+	}
+	return map[string]string{
+		"NuGetVersion": Convert(release, context["PreRelease"]),
+	}
+}
+
+// Auto-registration as a template provider plugin
+func init() {
+	plugin.Register(NewProvider())
+}
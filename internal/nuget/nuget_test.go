@@ -0,0 +1,91 @@
+package nuget
+
+import "testing"
+
+// TestConvert_PreRelease_NormalizesForNuGet validates the SemVer to NuGet
+// mapping.
+//
+// Why: dotnet pack and nuget.org reject labels with characters outside
+// [0-9A-Za-z-] and treat versions differing only in metadata as duplicates;
+// pipelines would otherwise rewrite the version by hand.
+//
+// What: Valid labels pass through, invalid characters become hyphens,
+// leading zeros are dropped, and long labels are cut to
+// MaxPreReleaseLength without a trailing separator, keeping the trailing
+// numeric identifiers.
+func TestConvert_PreRelease_NormalizesForNuGet(t *testing.T) {
+	tests := []struct {
+		prerelease string
+		want       string
+	}{
+		{"", "1.2.3"},
+		{"rc.1", "1.2.3-rc.1"},
+		{"beta-2", "1.2.3-beta-2"},
+		{"build.007", "1.2.3-build.7"},
+		{"feature/login_page", "1.2.3-feature-login-page"},
+		{"..alpha..", "1.2.3-alpha"},
+		{"feature-very-long-branch-name.42", "1.2.3-feature-very-long.42"},
+		{"alpha.beta.gamma.delta.9", "1.2.3-alpha.beta.gamma.d.9"},
+		{"abcdefghijklmnopqrs.1", "1.2.3-abcdefghijklmnopqr.1"},
+		{"abcdefghijklmnopqrstuvwxyz", "1.2.3-abcdefghijklmnopqrst"},
+		{"feature-very-long-branch-name.20261016.123456", "1.2.3-feat.20261016.123456"},
+	}
+
+	for _, tt := range tests {
+		// Action: Convert
+		got := Convert("1.2.3", tt.prerelease)
+
+		// Expected: NuGet-safe version
+		if got != tt.want {
+			t.Errorf("Convert(%q) = %q, want %q", tt.prerelease, got, tt.want)
+		}
+	}
+}
+
+// TestLabel_LongPreRelease_KeepsCounter validates shortening of long labels.
+//
+// Why: CI builds of one branch differ only in their trailing build counter;
+// cutting it off gives every build the same package version, which
+// nuget.org rejects as a duplicate.
+//
+// What: Two long pre-releases differing only in the counter convert to
+// different labels that both fit MaxPreReleaseLength.
+func TestLabel_LongPreRelease_KeepsCounter(t *testing.T) {
+	// Action: Convert two consecutive builds
+	first := Label("feature-very-long-branch-name.42")
+	second := Label("feature-very-long-branch-name.43")
+
+	// Expected: Distinct labels within the limit
+	if first == second {
+		t.Errorf("expected distinct labels, both are %q", first)
+	}
+	for _, label := range []string{first, second} {
+		if len(label) > MaxPreReleaseLength {
+			t.Errorf("label %q longer than %d characters", label, MaxPreReleaseLength)
+		}
+	}
+}
+
+// TestProvider_GetTemplateVariables_DropsMetadata validates the plugin.
+//
+// Why: Templates read {{NuGetVersion}}; build metadata must not reach the
+// package version.
+//
+// What: With version context, NuGetVersion omits the metadata; without a
+// version, no variables are returned.
+func TestProvider_GetTemplateVariables_DropsMetadata(t *testing.T) {
+	// Precondition: Provider
+	p := NewProvider()
+
+	// Action: Get variables with and without version context
+	vars := p.GetTemplateVariables(map[string]string{"MajorMinorPatch": "2.0.0", "PreRelease": "beta.3", "Metadata": "abc1234"})
+	empty := p.GetTemplateVariables(map[string]string{"ShortHash": "abc1234"})
+
+	// Expected: Pre-release kept, metadata dropped; nothing without a version
+	if vars["NuGetVersion"] != "2.0.0-beta.3" {
+		t.Errorf("expected NuGetVersion 2.0.0-beta.3, got %q", vars["NuGetVersion"])
+	}
+	if empty != nil {
+		t.Errorf("expected no variables without a version, got %v", empty)
+	}
+}
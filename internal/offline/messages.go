@@ -0,0 +1,8 @@
+// Package offline messages - error message constants
+// Exported so tests can compare against them
+package offline
+
+// Error messages
+const (
+	ErrNetworkDisabled = "network access disabled by offline mode"
+)
@@ -0,0 +1,57 @@
+// Package offline guarantees that versionator makes no network calls when
+// --offline (or offline: true in .versionator.yaml) is set, for hermetic
+// builds. Features that need the network check Require and fail fast; the
+// default HTTP transport is also replaced so any request that slips past a
+// check is refused rather than sent.
+package offline
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+var (
+	mu        sync.Mutex
+	enabled   bool
+	transport http.RoundTripper
+)
+
+// refusingTransport fails every request without touching the network
+type refusingTransport struct{}
+
+func (refusingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("%s: HTTP %s %s", ErrNetworkDisabled, req.Method, req.URL.Host)
+}
+
+// Set turns offline mode on or off
+func Set(on bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	if on == enabled {
+		return
+	}
+	enabled = on
+	if on {
+		transport = http.DefaultTransport
+		http.DefaultTransport = refusingTransport{}
+	} else {
+		http.DefaultTransport = transport
+	}
+}
+
+// Enabled reports whether offline mode is on
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return enabled
+}
+
+// Require returns an error naming feature when offline mode is on, so
+// features that need the network fail before doing any work
+func Require(feature string) error {
+	if Enabled() {
+		return fmt.Errorf("%s: %s needs network access (remove --offline)", ErrNetworkDisabled, feature)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package offline
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestSet_Enabled_RefusesNetworkFeaturesAndHTTP validates offline mode.
+//
+// Why: Hermetic builds rely on versionator making no network calls, even
+// from a feature that forgot to check.
+//
+// What: While enabled, Require fails naming the feature and HTTP requests
+// through the default transport never reach the server; disabling restores
+// both.
+func TestSet_Enabled_RefusesNetworkFeaturesAndHTTP(t *testing.T) {
+	// Precondition: A local server counting requests
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hits++ }))
+	defer server.Close()
+	t.Cleanup(func() { Set(false) })
+
+	// Action: Enable offline mode, check a feature, and send a request
+	Set(true)
+	requireErr := Require("webhook")
+	_, httpErr := (&http.Client{}).Get(server.URL)
+
+	// Expected: Both refused; nothing reached the server
+	if requireErr == nil || !strings.Contains(requireErr.Error(), "webhook") || !strings.Contains(requireErr.Error(), ErrNetworkDisabled) {
+		t.Errorf("unexpected Require error: %v", requireErr)
+	}
+	if httpErr == nil || !strings.Contains(httpErr.Error(), ErrNetworkDisabled) {
+		t.Errorf("expected refused request, got %v", httpErr)
+	}
+	if hits != 0 {
+		t.Errorf("server received %d requests", hits)
+	}
+
+	// Action: Disable offline mode
+	Set(false)
+	resp, err := (&http.Client{}).Get(server.URL)
+
+	// Expected: Network features allowed again
+	if err != nil {
+		t.Fatalf("request failed after disabling offline mode: %v", err)
+	}
+	_ = resp.Body.Close()
+	if Require("webhook") != nil || hits != 1 {
+		t.Errorf("expected network allowed, hits=%d", hits)
+	}
+}
@@ -0,0 +1,185 @@
+// Package pep440 provides a template provider that renders the current
+// version as a Python PEP 440 version ({{Pep440Version}}), so Python
+// packaging files receive versions pip and setuptools accept.
+//
+// SemVer pre-release identifiers map to PEP 440 segments: alpha/a, beta/b,
+// and rc/c/pre/preview become a, b, and rc; dev and post/rev/r become .devN
+// and .postN. Other identifiers (e.g. branch names) cannot be expressed as a
+// pre-release, so the version becomes a .devN release, ordered before the
+// final release, with the identifiers kept in the local version label.
+// Build metadata becomes the local version label.
+package pep440
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+)
+
+// preReleaseLabels maps accepted spellings to the normalized PEP 440 label
+var preReleaseLabels = map[string]string{
+	"a": "a", "alpha": "a",
+	"b": "b", "beta": "b",
+	"c": "rc", "rc": "rc", "pre": "rc", "preview": "rc",
+}
+
+// postReleaseLabels are the accepted spellings of a post-release
+var postReleaseLabels = map[string]bool{"post": true, "rev": true, "r": true}
+
+// labelNumber splits an identifier like "rc1" into label and number
+var labelNumber = regexp.MustCompile(`^([a-z]+)([0-9]+)$`)
+
+// Convert builds a normalized PEP 440 version from a release (e.g. "1.2.3"),
+// a SemVer pre-release (e.g. "rc.1"), and build metadata (e.g. "abc1234")
+func Convert(release, prerelease, metadata string) string {
+	suffix, local := Split(prerelease, metadata)
+	if local != "" {
+		return release + suffix + "+" + local
+	}
+	return release + suffix
+}
+
+// Split converts a SemVer pre-release and build metadata to the PEP 440
+// segments that follow the release (e.g. "rc1", ".dev5") and the local
+// version label, without its "+"
+func Split(prerelease, metadata string) (suffix, localLabel string) {
+	var pre, post, dev string
+	var local []string
+
+	tokens := tokenize(prerelease)
+	for i := 0; i < len(tokens); i++ {
+		label := tokens[i]
+		if isNumber(label) {
+			local = append(local, normalizeNumber(label))
+			continue
+		}
+		number := ""
+		if i+1 < len(tokens) && isNumber(tokens[i+1]) {
+			number = tokens[i+1]
+			i++
+		}
+
+		switch {
+		case preReleaseLabels[label] != "" && pre == "":
+			pre = preReleaseLabels[label] + normalizeNumber(number)
+		case label == "dev" && dev == "":
+			dev = ".dev" + normalizeNumber(number)
+		case postReleaseLabels[label] && post == "":
+			post = ".post" + normalizeNumber(number)
+		default:
+			local = append(local, label)
+			if number != "" {
+				local = append(local, normalizeNumber(number))
+			}
+		}
+	}
+
+	// Unrecognized identifiers still mark a pre-release: order it before the
+	// final release as a dev release numbered by the last number, if any
+	if pre == "" && dev == "" && post == "" && len(local) > 0 {
+		number := ""
+		for i := len(local) - 1; i >= 0; i-- {
+			if isNumber(local[i]) {
+				number = local[i]
+				local = append(local[:i], local[i+1:]...)
+				break
+			}
+		}
+		dev = ".dev" + normalizeNumber(number)
+	}
+
+	local = append(local, localSegments(metadata)...)
+	return pre + post + dev, strings.Join(local, ".")
+}
+
+// tokenize lowercases a pre-release, splits it on separators, and splits
+// known labels from an attached number ("rc1" -> "rc", "1")
+func tokenize(prerelease string) []string {
+	var tokens []string
+	for _, part := range splitSeparators(strings.ToLower(prerelease)) {
+		if m := labelNumber.FindStringSubmatch(part); m != nil && isKnownLabel(m[1]) {
+			tokens = append(tokens, m[1], m[2])
+			continue
+		}
+		tokens = append(tokens, part)
+	}
+	return tokens
+}
+
+// localSegments converts build metadata to local version segments, which
+// may only contain lowercase ASCII letters and digits
+func localSegments(metadata string) []string {
+	var segments []string
+	for _, part := range splitSeparators(strings.ToLower(metadata)) {
+		part = strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, part)
+		if part != "" {
+			segments = append(segments, part)
+		}
+	}
+	return segments
+}
+
+// splitSeparators splits on the separators PEP 440 normalizes away
+func splitSeparators(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == '.' || r == '-' || r == '_' || r == '+'
+	})
+}
+
+func isKnownLabel(label string) bool {
+	return preReleaseLabels[label] != "" || postReleaseLabels[label] || label == "dev"
+}
+
+func isNumber(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// normalizeNumber drops leading zeros; a missing number is 0
+func normalizeNumber(n string) string {
+	n = strings.TrimLeft(n, "0")
+	if n == "" {
+		return "0"
+	}
+	return n
+}
+
+// Provider exposes the PEP 440 version as a template variable
+type Provider struct{}
+
+// NewProvider creates a Provider
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+// Name returns "pep440"
+func (p *Provider) Name() string {
+	return "pep440"
+}
+
+// Types returns the set of plugin types this provider implements
+func (p *Provider) Types() plugin.PluginTypeSet {
+	return plugin.NewPluginTypeSet(plugin.TypeTemplateProvider)
+}
+
+// GetTemplateVariables returns Pep440Version built from the MajorMinorPatch,
+// PreRelease, and Metadata context variables; nil without a version
+func (p *Provider) GetTemplateVariables(context map[string]string) map[string]string {
+	release := context["MajorMinorPatch"]
+	if release == "" {
+		return nil
+	}
+	return map[string]string{
+		"Pep440Version": Convert(release, context["PreRelease"], context["Metadata"]),
+	}
+}
+
+// Auto-registration as a template provider plugin
+func init() {
+	plugin.Register(NewProvider())
+}
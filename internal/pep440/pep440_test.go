@@ -0,0 +1,68 @@
+package pep440
+
+import "testing"
+
+// TestConvert_SemVerPreReleaseAndMetadata_NormalizesToPEP440 validates the
+// SemVer to PEP 440 mapping.
+//
+// Why: pip rejects SemVer pre-releases such as "1.2.3-feature-foo.5"; Python
+// packaging files must receive a PEP 440 version that still orders
+// pre-releases before the final release.
+//
+// What: Known labels normalize (alpha.1 -> a1, RC-01 -> rc1, preview -> rc0),
+// dev/post segments are placed in PEP 440 order, metadata becomes the local
+// label, and unknown labels become a dev release with a local label.
+func TestConvert_SemVerPreReleaseAndMetadata_NormalizesToPEP440(t *testing.T) {
+	tests := []struct {
+		prerelease, metadata string
+		want                 string
+	}{
+		{"", "", "1.2.3"},
+		{"alpha.1", "", "1.2.3a1"},
+		{"a1", "", "1.2.3a1"},
+		{"beta", "", "1.2.3b0"},
+		{"RC-01", "", "1.2.3rc1"},
+		{"preview", "", "1.2.3rc0"},
+		{"rc.1", "local.abc1234", "1.2.3rc1+local.abc1234"},
+		{"dev.4", "", "1.2.3.dev4"},
+		{"rc.2.dev.7", "", "1.2.3rc2.dev7"},
+		{"post.1", "", "1.2.3.post1"},
+		{"feature-foo-5", "", "1.2.3.dev5+feature.foo"},
+		{"5", "", "1.2.3.dev5"},
+		{"", "Build_42.ABC", "1.2.3+build.42.abc"},
+	}
+
+	for _, tt := range tests {
+		// Action: Convert
+		got := Convert("1.2.3", tt.prerelease, tt.metadata)
+
+		// Expected: Normalized PEP 440 version
+		if got != tt.want {
+			t.Errorf("Convert(%q, %q) = %q, want %q", tt.prerelease, tt.metadata, got, tt.want)
+		}
+	}
+}
+
+// TestProvider_GetTemplateVariables_UsesVersionContext validates the plugin.
+//
+// Why: Templates read {{Pep440Version}}; the provider must derive it from the
+// version parts emit passes as plugin context.
+//
+// What: With version context, Pep440Version is set; without a version, no
+// variables are returned.
+func TestProvider_GetTemplateVariables_UsesVersionContext(t *testing.T) {
+	// Precondition: Provider
+	p := NewProvider()
+
+	// Action: Get variables with and without version context
+	vars := p.GetTemplateVariables(map[string]string{"MajorMinorPatch": "2.0.0", "PreRelease": "beta.3"})
+	empty := p.GetTemplateVariables(map[string]string{"ShortHash": "abc1234"})
+
+	// Expected: Version converted; nothing without a version
+	if vars["Pep440Version"] != "2.0.0b3" {
+		t.Errorf("expected Pep440Version 2.0.0b3, got %q", vars["Pep440Version"])
+	}
+	if empty != nil {
+		t.Errorf("expected no variables without a version, got %v", empty)
+	}
+}
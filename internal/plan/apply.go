@@ -0,0 +1,209 @@
+package plan
+
+import (
+	"fmt"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/logging"
+	"github.com/benjaminabbitt/versionator/internal/manifest"
+	"github.com/benjaminabbitt/versionator/internal/offline"
+	"github.com/benjaminabbitt/versionator/internal/trailer"
+	"github.com/benjaminabbitt/versionator/internal/update"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/version"
+	"go.uber.org/zap"
+)
+
+// VersionStore reads and writes the VERSION file
+type VersionStore interface {
+	Load() (*version.Version, error)
+	SetVersion(versionString string) error
+}
+
+// FileEditor reads and writes values in structured files
+type FileEditor interface {
+	CurrentValue(cfg config.UpdateConfig) (string, error)
+	SetValue(cfg config.UpdateConfig, value string) error
+}
+
+// Applier executes plans
+type Applier struct {
+	store  VersionStore
+	editor FileEditor
+	vcs    vcs.VersionControlSystem
+}
+
+// NewApplier creates an Applier (IoC constructor accepting dependencies).
+// vcsImpl may be nil for plans that do not commit, tag or branch.
+func NewApplier(store VersionStore, editor FileEditor, vcsImpl vcs.VersionControlSystem) *Applier {
+	return &Applier{store: store, editor: editor, vcs: vcsImpl}
+}
+
+// NewApplierDefault creates an Applier using the VERSION file, the updates
+// file parser, and the active VCS
+func NewApplierDefault() *Applier {
+	editor := update.NewUpdater(nil, update.NewDaselFileParser(), zap.NewNop())
+	return NewApplier(versionFileStore{}, editor, vcs.GetActiveVCS())
+}
+
+// versionFileStore adapts the version package functions to VersionStore
+type versionFileStore struct{}
+
+func (versionFileStore) Load() (*version.Version, error) { return version.Load() }
+func (versionFileStore) SetVersion(s string) error       { return version.SetVersion(s) }
+
+// Check verifies the repository still matches the state the plan was computed
+// against: VERSION, every planned file value, and (for plans that commit, tag
+// or branch) HEAD, with the planned tag and branch still free
+func (a *Applier) Check(p *Plan) error {
+	current, err := a.store.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load version: %w", err)
+	}
+	if current.FullString() != p.Version.Old {
+		return fmt.Errorf("%s (plan: %s, current: %s)", ErrStaleVersion, p.Version.Old, current.FullString())
+	}
+
+	for _, f := range p.Files {
+		value, err := a.editor.CurrentValue(f.updateConfig())
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.File, err)
+		}
+		if value != f.Old {
+			return fmt.Errorf("%s: %s %s (plan: %s, current: %s)", ErrStaleFile, f.File, f.Path, f.Old, value)
+		}
+	}
+
+	if p.Commit == nil && p.Tag == nil && p.Branch == "" && p.Push == nil {
+		return nil
+	}
+	if a.vcs == nil {
+		return fmt.Errorf("%s", ErrNoVCS)
+	}
+	if p.Head != "" {
+		head, err := a.vcs.GetVCSIdentifier(vcs.MaxIdentifierLength(a.vcs))
+		if err != nil {
+			return fmt.Errorf("error reading HEAD: %w", err)
+		}
+		if head != p.Head {
+			return fmt.Errorf("%s (plan: %s, current: %s)", ErrStaleHead, p.Head, head)
+		}
+	}
+	if p.Tag != nil && !p.Tag.Force {
+		exists, err := a.vcs.TagExists(p.Tag.Name)
+		if err != nil {
+			return fmt.Errorf("error checking if tag exists: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("%s: %s", ErrStaleTag, p.Tag.Name)
+		}
+	}
+	if p.Branch != "" {
+		exists, err := a.vcs.BranchExists(p.Branch)
+		if err != nil {
+			return fmt.Errorf("error checking if branch exists: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("%s: %s", ErrStaleBranch, p.Branch)
+		}
+	}
+	if p.Push != nil {
+		if err := offline.Require("pushing a release"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply checks the plan is current, then writes VERSION, writes file values,
+// makes the planned commit, creates the tag and branch, and pushes them
+func (a *Applier) Apply(p *Plan) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if err := a.Check(p); err != nil {
+		return err
+	}
+
+	// Leave VERSION untouched when the plan keeps it, as release and patch do
+	if p.Version.New != p.Version.Old {
+		if err := a.store.SetVersion(p.Version.New); err != nil {
+			return err
+		}
+	}
+
+	for i, f := range p.Files {
+		if err := a.editor.SetValue(f.updateConfig(), f.New); err != nil {
+			return fmt.Errorf("files[%d] (%s): %w", i, f.File, err)
+		}
+	}
+
+	if p.Commit != nil {
+		if p.Commit.Amend {
+			if err := a.vcs.AmendCommit(p.Commit.Files); err != nil {
+				return fmt.Errorf("failed to amend commit: %w", err)
+			}
+		} else if err := a.vcs.CommitFiles(p.Commit.Files, p.Commit.Message); err != nil {
+			return fmt.Errorf("failed to commit: %w", err)
+		}
+	}
+
+	if p.Tag != nil {
+		message, err := a.tagMessage(p.Tag)
+		if err != nil {
+			return err
+		}
+		if err := a.vcs.CreateTag(p.Tag.Name, message); err != nil {
+			return fmt.Errorf("failed to create tag: %w", err)
+		}
+	}
+
+	if p.Branch != "" {
+		if err := a.vcs.CreateBranch(p.Branch); err != nil {
+			return fmt.Errorf("failed to create branch: %w", err)
+		}
+	}
+
+	if p.Push != nil {
+		if err := a.vcs.PushTag(p.Push.Tag); err != nil {
+			return fmt.Errorf("failed to push tag: %w", err)
+		}
+		if p.Push.Branch != "" {
+			if err := a.vcs.PushBranch(p.Push.Branch); err != nil {
+				return fmt.Errorf("failed to push branch: %w", err)
+			}
+		}
+	}
+
+	logging.GetLogger().Info(LogPlanApplied,
+		zap.String("command", p.Command),
+		zap.String("from", p.Version.Old),
+		zap.String("to", p.Version.New))
+	return nil
+}
+
+// tagMessage returns the annotation for t: its message, then the checksums
+// of the manifest files as committed, then the trailer
+func (a *Applier) tagMessage(t *TagAction) (string, error) {
+	message := t.Message
+	if len(t.Manifest) > 0 {
+		root, err := a.vcs.GetRepositoryRoot()
+		if err != nil {
+			return "", err
+		}
+		entries, err := manifest.BuildAt(root, t.Manifest)
+		if err != nil {
+			return "", fmt.Errorf("error building release manifest: %w", err)
+		}
+		message = manifest.Append(message, entries)
+	}
+	if t.Trailer != "" {
+		message = trailer.Append(message, t.Trailer)
+	}
+	return message, nil
+}
+
+// updateConfig returns the update configuration addressing this change
+func (f FileChange) updateConfig() config.UpdateConfig {
+	return config.UpdateConfig{File: f.File, Path: f.Path, Format: f.Format}
+}
@@ -0,0 +1,21 @@
+package plan
+
+// Error messages
+const (
+	ErrReadPlan          = "failed to read plan"
+	ErrInvalidPlan       = "invalid plan"
+	ErrUnsupportedSchema = "unsupported plan schema version"
+	ErrUnsupportedFormat = "unsupported plan format"
+	ErrStaleVersion      = "plan is stale: VERSION has changed since the plan was created"
+	ErrStaleFile         = "plan is stale: file value has changed since the plan was created"
+	ErrStaleHead         = "plan is stale: HEAD has moved since the plan was created"
+	ErrStaleTag          = "plan is stale: tag has been created since the plan was created"
+	ErrStaleBranch       = "plan is stale: branch has been created since the plan was created"
+	ErrNoVCS             = "plan requires a version control repository"
+	ErrCommandMismatch   = "plan was created by a different command"
+)
+
+// Log messages for structured logging
+const (
+	LogPlanApplied = "plan_applied"
+)
@@ -0,0 +1,155 @@
+// Package plan describes the changes a mutating command intends to make, as a
+// machine-readable document that can be reviewed and applied later.
+//
+// `versionator bump --plan json > plan.json` records the VERSION change, the
+// values `updates` would write, and the commit the bump would amend, without
+// touching the repository. `versionator bump --apply-plan plan.json` executes
+// exactly those changes, refusing if VERSION, any planned file value, or HEAD
+// has moved since the plan was created. Release plans also record the tag and
+// branch to create and whether to push them.
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// SchemaVersion is the plan document format version
+const SchemaVersion = 1
+
+// FormatJSON is the only supported --plan output format
+const FormatJSON = "json"
+
+// Plan is a reviewable description of a mutating command's changes
+type Plan struct {
+	// SchemaVersion identifies the document format
+	SchemaVersion int `json:"schemaVersion"`
+	// Command is the command that produced the plan (e.g. "bump patch")
+	Command string `json:"command"`
+	// Head is the commit the plan was computed against; set when the plan
+	// commits, so applying on a different HEAD is refused
+	Head string `json:"head,omitempty"`
+	// Version is the VERSION file change
+	Version VersionChange `json:"version"`
+	// Files lists values written into other files by `updates`
+	Files []FileChange `json:"files,omitempty"`
+	// Commit describes the commit made after writing files, if any
+	Commit *CommitAction `json:"commit,omitempty"`
+	// Tag describes the tag created after the commit, if any
+	Tag *TagAction `json:"tag,omitempty"`
+	// Branch is a branch created at HEAD after tagging, if any
+	Branch string `json:"branch,omitempty"`
+	// Push describes the tag and branch pushed to the remote last, if any
+	Push *PushAction `json:"push,omitempty"`
+	// Events lists lifecycle hook events fired after applying (e.g. "bump")
+	Events []string `json:"events,omitempty"`
+}
+
+// VersionChange is the VERSION file's content before and after
+type VersionChange struct {
+	File string `json:"file"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// FileChange is one value written by an `updates` entry
+type FileChange struct {
+	File   string `json:"file"`
+	Path   string `json:"path"`
+	Format string `json:"format,omitempty"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+}
+
+// CommitAction is a commit made as part of the plan
+type CommitAction struct {
+	// Amend amends HEAD (keeping its message) instead of creating a commit
+	Amend bool `json:"amend"`
+	// Files are staged before committing
+	Files []string `json:"files"`
+	// Message is the commit message for new commits
+	Message string `json:"message,omitempty"`
+}
+
+// TagAction is an annotated tag created as part of the plan
+type TagAction struct {
+	// Name is the tag name, including any prefix
+	Name string `json:"name"`
+	// Message is the annotation, before any manifest and trailer
+	Message string `json:"message"`
+	// Manifest lists files, relative to the repository root, whose checksums
+	// are appended to the message when the tag is created
+	Manifest []string `json:"manifest,omitempty"`
+	// Trailer is the version recorded in a Versionator-Version trailer
+	// ending the message, if any
+	Trailer string `json:"trailer,omitempty"`
+	// Force replaces an existing tag of the same name
+	Force bool `json:"force,omitempty"`
+}
+
+// PushAction pushes refs to the remote after everything else
+type PushAction struct {
+	Tag    string `json:"tag"`
+	Branch string `json:"branch,omitempty"`
+}
+
+// Family returns the top-level command name ("bump" for "bump patch")
+func (p *Plan) Family() string {
+	family, _, _ := strings.Cut(p.Command, " ")
+	return family
+}
+
+// Validate checks the plan is complete and uses a supported schema
+func (p *Plan) Validate() error {
+	if p.SchemaVersion != SchemaVersion {
+		return fmt.Errorf("%s: %d (supported: %d)", ErrUnsupportedSchema, p.SchemaVersion, SchemaVersion)
+	}
+	if p.Command == "" || p.Version.File == "" || p.Version.New == "" {
+		return fmt.Errorf("%s: command and version.file/new are required", ErrInvalidPlan)
+	}
+	for i, f := range p.Files {
+		if f.File == "" || f.Path == "" {
+			return fmt.Errorf("%s: files[%d]: file and path are required", ErrInvalidPlan, i)
+		}
+	}
+	if p.Commit != nil && len(p.Commit.Files) == 0 {
+		return fmt.Errorf("%s: commit.files is required", ErrInvalidPlan)
+	}
+	if p.Tag != nil && p.Tag.Name == "" {
+		return fmt.Errorf("%s: tag.name is required", ErrInvalidPlan)
+	}
+	if p.Push != nil && p.Push.Tag == "" {
+		return fmt.Errorf("%s: push.tag is required", ErrInvalidPlan)
+	}
+	return nil
+}
+
+// Encode writes the plan in the given format
+func (p *Plan) Encode(w io.Writer, format string) error {
+	if format != FormatJSON {
+		return fmt.Errorf("%s: %q (supported: %s)", ErrUnsupportedFormat, format, FormatJSON)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// Read loads and validates a plan document
+func Read(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrReadPlan, err)
+	}
+
+	var p Plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%s %s: %w", ErrReadPlan, path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
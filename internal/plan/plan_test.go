@@ -0,0 +1,175 @@
+package plan
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
+	"github.com/benjaminabbitt/versionator/internal/version"
+	"github.com/golang/mock/gomock"
+)
+
+// fakeStore is an in-memory VersionStore
+type fakeStore struct {
+	current string
+}
+
+func (f *fakeStore) Load() (*version.Version, error) {
+	v := version.Parse(f.current)
+	return &v, nil
+}
+
+func (f *fakeStore) SetVersion(s string) error {
+	f.current = s
+	return nil
+}
+
+// fakeEditor is an in-memory FileEditor keyed by "file:path"
+type fakeEditor map[string]string
+
+func (f fakeEditor) CurrentValue(cfg config.UpdateConfig) (string, error) {
+	value, ok := f[cfg.File+":"+cfg.Path]
+	if !ok {
+		return "", errors.New("path not found")
+	}
+	return value, nil
+}
+
+func (f fakeEditor) SetValue(cfg config.UpdateConfig, value string) error {
+	f[cfg.File+":"+cfg.Path] = value
+	return nil
+}
+
+// TestRead_ValidatesSchemaAndRequiredFields validates plan loading.
+//
+// Why: A plan from a newer versionator, or a hand-edited one missing fields,
+// must be rejected before anything is changed.
+//
+// What: A complete plan loads; an unknown schema version and a missing
+// version.new are rejected with their error constants.
+func TestRead_ValidatesSchemaAndRequiredFields(t *testing.T) {
+	// Precondition: Three plan files
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	valid := write("valid.json", `{"schemaVersion": 1, "command": "bump patch", "version": {"file": "VERSION", "old": "1.0.0", "new": "1.0.1"}}`)
+	future := write("future.json", `{"schemaVersion": 2, "command": "bump", "version": {"file": "VERSION", "new": "1.0.1"}}`)
+	partial := write("partial.json", `{"schemaVersion": 1, "command": "bump", "version": {"file": "VERSION"}}`)
+
+	// Action: Read each plan
+	p, validErr := Read(valid)
+	_, futureErr := Read(future)
+	_, partialErr := Read(partial)
+
+	// Expected: Only the complete plan loads
+	if validErr != nil || p.Family() != "bump" || p.Version.New != "1.0.1" {
+		t.Errorf("expected valid plan, got %+v (%v)", p, validErr)
+	}
+	if futureErr == nil || !strings.Contains(futureErr.Error(), ErrUnsupportedSchema) {
+		t.Errorf("expected %q, got %v", ErrUnsupportedSchema, futureErr)
+	}
+	if partialErr == nil || !strings.Contains(partialErr.Error(), ErrInvalidPlan) {
+		t.Errorf("expected %q, got %v", ErrInvalidPlan, partialErr)
+	}
+}
+
+// TestApply_StaleFileValue_ChangesNothing validates pre-apply checks.
+//
+// Why: A plan is an approval of specific changes; if any file moved since,
+// applying part of it would leave the repository half-bumped.
+//
+// What: When a planned file value no longer matches, Apply fails with
+// ErrStaleFile and writes neither VERSION nor the file; when it matches,
+// both are written.
+func TestApply_StaleFileValue_ChangesNothing(t *testing.T) {
+	// Precondition: VERSION matches the plan, package.json does not
+	store := &fakeStore{current: "1.0.0"}
+	editor := fakeEditor{"package.json:version": "0.9.0"}
+	applier := NewApplier(store, editor, nil)
+	p := &Plan{
+		SchemaVersion: SchemaVersion,
+		Command:       "bump patch",
+		Version:       VersionChange{File: "VERSION", Old: "1.0.0", New: "1.0.1"},
+		Files:         []FileChange{{File: "package.json", Path: "version", Old: "1.0.0", New: "1.0.1"}},
+	}
+
+	// Action: Apply with a stale file value
+	err := applier.Apply(p)
+
+	// Expected: Refused, nothing written
+	if err == nil || !strings.Contains(err.Error(), ErrStaleFile) {
+		t.Fatalf("expected %q, got %v", ErrStaleFile, err)
+	}
+	if store.current != "1.0.0" || editor["package.json:version"] != "0.9.0" {
+		t.Errorf("stale plan modified state: VERSION=%s package.json=%s", store.current, editor["package.json:version"])
+	}
+
+	// Action: Apply once the file matches
+	editor["package.json:version"] = "1.0.0"
+	err = applier.Apply(p)
+
+	// Expected: Both values written
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if store.current != "1.0.1" || editor["package.json:version"] != "1.0.1" {
+		t.Errorf("unexpected state: VERSION=%s package.json=%s", store.current, editor["package.json:version"])
+	}
+}
+
+// TestApply_Release_CommitsThenTagsBranchesAndPushes validates release plans.
+//
+// Why: The tag must mark the release commit, and nothing may be pushed before
+// everything exists locally.
+//
+// What: Apply commits, creates the tag with its trailer, creates the branch,
+// then pushes the tag and branch, in that order; VERSION is left alone when
+// the plan keeps it.
+func TestApply_Release_CommitsThenTagsBranchesAndPushes(t *testing.T) {
+	// Precondition: A release plan at HEAD abc123, tag and branch free
+	ctrl := gomock.NewController(t)
+	vcsImpl := mock.NewMockVersionControlSystem(ctrl)
+	vcsImpl.EXPECT().GetVCSIdentifier(gomock.Any()).Return("abc123", nil)
+	vcsImpl.EXPECT().TagExists("v1.0.0").Return(false, nil)
+	vcsImpl.EXPECT().BranchExists("release/v1.0.0").Return(false, nil)
+	gomock.InOrder(
+		vcsImpl.EXPECT().CommitFiles([]string{"package.json"}, "Release 1.0.0").Return(nil),
+		vcsImpl.EXPECT().CreateTag("v1.0.0", "Release 1.0.0\n\nVersionator-Version: 1.0.0").Return(nil),
+		vcsImpl.EXPECT().CreateBranch("release/v1.0.0").Return(nil),
+		vcsImpl.EXPECT().PushTag("v1.0.0").Return(nil),
+		vcsImpl.EXPECT().PushBranch("release/v1.0.0").Return(nil),
+	)
+	store := &fakeStore{current: "1.0.0"}
+	editor := fakeEditor{"package.json:version": "0.9.0"}
+	p := &Plan{
+		SchemaVersion: SchemaVersion,
+		Command:       "release push",
+		Head:          "abc123",
+		Version:       VersionChange{File: "VERSION", Old: "1.0.0", New: "1.0.0"},
+		Files:         []FileChange{{File: "package.json", Path: "version", Old: "0.9.0", New: "1.0.0"}},
+		Commit:        &CommitAction{Files: []string{"package.json"}, Message: "Release 1.0.0"},
+		Tag:           &TagAction{Name: "v1.0.0", Message: "Release 1.0.0", Trailer: "1.0.0"},
+		Branch:        "release/v1.0.0",
+		Push:          &PushAction{Tag: "v1.0.0", Branch: "release/v1.0.0"},
+	}
+
+	// Action
+	err := NewApplier(store, editor, vcsImpl).Apply(p)
+
+	// Expected: The mock verified the order
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if editor["package.json:version"] != "1.0.0" {
+		t.Errorf("package.json = %s, want 1.0.0", editor["package.json:version"])
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package platform
+
+import "os"
+
+// SupportsPermissions reports whether file permission bits apply
+const SupportsPermissions = false
+
+// Chmod is a no-op where permission bits do not apply (e.g. Windows, whose
+// ACLs are inherited from the directory)
+func Chmod(path string, m os.FileMode) error {
+	return nil
+}
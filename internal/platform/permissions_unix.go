@@ -0,0 +1,29 @@
+//go:build unix
+
+package platform
+
+import (
+	"os"
+	"sync"
+	"syscall"
+)
+
+// SupportsPermissions reports whether file permission bits apply
+const SupportsPermissions = true
+
+var umaskMu sync.Mutex
+
+// umask returns the process umask. Reading it requires setting it, so the
+// original value is restored immediately.
+func umask() os.FileMode {
+	umaskMu.Lock()
+	defer umaskMu.Unlock()
+	m := syscall.Umask(0)
+	syscall.Umask(m)
+	return os.FileMode(m)
+}
+
+// Chmod sets path to m reduced by the umask, as file creation would
+func Chmod(path string, m os.FileMode) error {
+	return os.Chmod(path, m&^umask())
+}
@@ -0,0 +1,63 @@
+// Package platform is the single place that decides OS-specific behavior:
+// the shell scripts run in, how paths from config and the VCS compare, the
+// executable suffix, and whether file permission bits apply. Hooks, patch,
+// release and the file writers go through it, so they behave the same on
+// Linux, macOS, and Windows runners (cmd or PowerShell).
+package platform
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// IsWindows reports whether versionator runs on Windows
+func IsWindows() bool {
+	return runtime.GOOS == "windows"
+}
+
+// Shell returns the shell scripts are run with and the flag that passes it
+// a command line: sh -c, or cmd /C on Windows, which is available on every
+// Windows runner whatever shell invoked versionator
+func Shell() (name, flag string) {
+	if IsWindows() {
+		return "cmd", "/C"
+	}
+	return "sh", "-c"
+}
+
+// ShellCommand returns a command running script in the platform shell
+func ShellCommand(ctx context.Context, script string) *exec.Cmd {
+	shell, flag := Shell()
+	return exec.CommandContext(ctx, shell, flag, script)
+}
+
+// RepoPath returns path in the form VCS tools report: cleaned, with forward
+// slashes. On Windows, config may name files with either separator; compare
+// them with VCS output through RepoPath.
+func RepoPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// SamePath reports whether two repository paths name the same file; names
+// are case-insensitive on Windows
+func SamePath(a, b string) bool {
+	if IsWindows() {
+		return strings.EqualFold(RepoPath(a), RepoPath(b))
+	}
+	return RepoPath(a) == RepoPath(b)
+}
+
+// Executable returns the file name of the program name on this platform
+// (name.exe on Windows)
+func Executable(name string) string {
+	if IsWindows() && !strings.HasSuffix(strings.ToLower(name), ".exe") {
+		return name + ".exe"
+	}
+	return name
+}
@@ -0,0 +1,121 @@
+package platform
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestShellCommand_RunsScript validates the platform shell.
+//
+// Why: Hook scripts must run with the same command line on Linux, macOS,
+// and Windows runners, including PowerShell ones, where sh does not exist.
+//
+// What: An echo of NAME=value and an environment variable prints the
+// expected line through the platform shell.
+func TestShellCommand_RunsScript(t *testing.T) {
+	// Precondition: A variable in the script environment
+	script := "echo NAME=$VERSIONATOR_TEST_VALUE"
+	if IsWindows() {
+		script = "echo NAME=%VERSIONATOR_TEST_VALUE%"
+	}
+	cmd := ShellCommand(context.Background(), script)
+	cmd.Env = append(os.Environ(), "VERSIONATOR_TEST_VALUE=1.2.3")
+
+	// Action
+	out, err := cmd.Output()
+
+	// Expected: The expanded line, whatever the line ending
+	if err != nil {
+		t.Fatalf("shell failed: %v", err)
+	}
+	if got := strings.TrimRight(string(out), "\r\n "); got != "NAME=1.2.3" {
+		t.Errorf("got %q, want NAME=1.2.3", got)
+	}
+}
+
+// TestRepoPath_NormalizesToVCSForm validates path normalization.
+//
+// Why: git reports dirty files as src/pkg/version.py while config may say
+// ./src/pkg/version.py, or src\pkg\version.py on Windows; release must treat
+// them as the same file.
+//
+// What: Paths are cleaned and use forward slashes; native separators are
+// converted; empty stays empty.
+func TestRepoPath_NormalizesToVCSForm(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "./package.json", want: "package.json"},
+		{path: "src/../src/pkg/version.py", want: "src/pkg/version.py"},
+		{path: filepath.Join("src", "pkg", "version.py"), want: "src/pkg/version.py"},
+		{path: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		// Action
+		got := RepoPath(tt.path)
+
+		// Expected
+		if got != tt.want {
+			t.Errorf("RepoPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+	if !SamePath("./VERSION", "VERSION") {
+		t.Error("expected ./VERSION and VERSION to be the same path")
+	}
+}
+
+// TestExecutable_AddsSuffixOnWindows validates executable names.
+//
+// Why: Acceptance tests look for the built binary, which is versionator.exe
+// on Windows.
+//
+// What: The suffix is added only on Windows and never twice.
+func TestExecutable_AddsSuffixOnWindows(t *testing.T) {
+	// Action
+	got := Executable("versionator")
+	again := Executable(got)
+
+	// Expected
+	want := "versionator"
+	if IsWindows() {
+		want = "versionator.exe"
+	}
+	if got != want || again != want {
+		t.Errorf("Executable = %q, %q; want %q", got, again, want)
+	}
+}
+
+// TestChmod_AppliesUmask validates permission handling.
+//
+// Why: Where permission bits apply, a configured mode must be honored the
+// way file creation would; elsewhere Chmod must succeed without effect.
+//
+// What: With permissions, a 0600 request yields 0600 (umask permitting);
+// without, Chmod returns nil.
+func TestChmod_AppliesUmask(t *testing.T) {
+	// Precondition: A world-readable file
+	path := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action
+	err := Chmod(path, 0600)
+
+	// Expected
+	if err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+	if !SupportsPermissions {
+		return
+	}
+	info, _ := os.Stat(path)
+	if got := info.Mode().Perm(); got != 0600 {
+		t.Errorf("mode %o, want 600", got)
+	}
+}
@@ -0,0 +1,9 @@
+// Package plugin messages - error message constants
+// Exported so tests can compare against them
+package plugin
+
+// Error messages
+const (
+	ErrUnknownMetadataProvider = "unknown metadata provider"
+	ErrMetadataTimeout         = "metadata fetch timed out"
+)
@@ -0,0 +1,85 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/offline"
+)
+
+// DefaultMetadataTimeout bounds each metadata fetch unless configured
+const DefaultMetadataTimeout = 5 * time.Second
+
+// metadataKey identifies a cached value
+type metadataKey struct {
+	provider string
+	key      string
+}
+
+// metadataResult is a cached fetch, including failures, so a slow or broken
+// system is asked once per run rather than once per template
+type metadataResult struct {
+	value string
+	err   error
+}
+
+var (
+	metadataMu      sync.Mutex
+	metadataCache   = map[metadataKey]metadataResult{}
+	metadataTimeout = DefaultMetadataTimeout
+)
+
+// SetMetadataTimeout sets how long each metadata fetch may take; zero or
+// less restores DefaultMetadataTimeout
+func SetMetadataTimeout(d time.Duration) {
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+	if d <= 0 {
+		d = DefaultMetadataTimeout
+	}
+	metadataTimeout = d
+}
+
+// ResetMetadataCache forgets every fetched value
+func ResetMetadataCache() {
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+	metadataCache = map[metadataKey]metadataResult{}
+}
+
+// FetchMetadata returns key from the named metadata provider. Results are
+// cached for the life of the process; each fetch is cancelled after the
+// metadata timeout.
+func FetchMetadata(provider, key string) (string, error) {
+	if err := offline.Require(fmt.Sprintf("metadata provider %q", provider)); err != nil {
+		return "", err
+	}
+
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+
+	id := metadataKey{provider: provider, key: key}
+	if cached, ok := metadataCache[id]; ok {
+		return cached.value, cached.err
+	}
+
+	var result metadataResult
+	if mp := GetMetadataProvider(provider); mp == nil {
+		result.err = fmt.Errorf("%s %q", ErrUnknownMetadataProvider, provider)
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), metadataTimeout)
+		result.value, result.err = mp.Fetch(ctx, key)
+		cancel()
+		if errors.Is(result.err, context.DeadlineExceeded) {
+			result.err = fmt.Errorf("%s after %s", ErrMetadataTimeout, metadataTimeout)
+		}
+		if result.err != nil {
+			result.err = fmt.Errorf("metadata %s.%s: %w", provider, key, result.err)
+		}
+	}
+	metadataCache[id] = result
+	return result.value, result.err
+}
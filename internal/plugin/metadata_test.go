@@ -0,0 +1,94 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFetchMetadata_RepeatedKey_FetchesOnce validates metadata caching.
+//
+// Why: A value may appear in the pre-release, the metadata, and several
+// emitted files; asking a build farm for it each time is slow and can return
+// different answers within one run.
+//
+// What: The same key is fetched once, including a failed fetch; an unknown
+// provider fails with ErrUnknownMetadataProvider; Unregister removes the
+// provider from lookup.
+func TestFetchMetadata_RepeatedKey_FetchesOnce(t *testing.T) {
+	// Precondition: One provider with one known key
+	cleanup := saveAndClearRegistry()
+	defer cleanup()
+	ResetMetadataCache()
+	t.Cleanup(ResetMetadataCache)
+	provider := &mockMetadataProvider{
+		mockPlugin: mockPlugin{name: "farm", types: NewPluginTypeSet(TypeMetadataProvider)},
+		values:     map[string]string{"build": "1042"},
+	}
+	Register(provider)
+
+	// Action: Fetch a key twice and a missing key twice
+	first, err1 := FetchMetadata("farm", "build")
+	second, err2 := FetchMetadata("farm", "build")
+	_, missErr1 := FetchMetadata("farm", "nope")
+	_, missErr2 := FetchMetadata("farm", "nope")
+
+	// Expected: One fetch per key; the failure is remembered
+	if first != "1042" || second != "1042" || err1 != nil || err2 != nil {
+		t.Errorf("got %q/%v and %q/%v", first, err1, second, err2)
+	}
+	if missErr1 == nil || missErr2 == nil || !strings.Contains(missErr1.Error(), "farm.nope") {
+		t.Errorf("expected cached failure naming farm.nope, got %v and %v", missErr1, missErr2)
+	}
+	if provider.fetches != 2 {
+		t.Errorf("expected 2 fetches, got %d", provider.fetches)
+	}
+
+	// Action: Fetch from an unknown provider
+	_, unknownErr := FetchMetadata("artifactory", "build")
+
+	// Expected: Reported as unknown
+	if unknownErr == nil || !strings.Contains(unknownErr.Error(), ErrUnknownMetadataProvider) {
+		t.Errorf("expected %q, got %v", ErrUnknownMetadataProvider, unknownErr)
+	}
+
+	// Action: Unregister the provider
+	Unregister("farm")
+
+	// Expected: No longer found
+	if GetMetadataProvider("farm") != nil {
+		t.Error("expected provider to be unregistered")
+	}
+}
+
+// TestFetchMetadata_SlowProvider_TimesOut validates the fetch timeout.
+//
+// Why: An unreachable artifact repository must not hang a build forever.
+//
+// What: A provider that never answers is cancelled after the configured
+// timeout and the error says the fetch timed out.
+func TestFetchMetadata_SlowProvider_TimesOut(t *testing.T) {
+	// Precondition: A provider that blocks and a short timeout
+	cleanup := saveAndClearRegistry()
+	defer cleanup()
+	ResetMetadataCache()
+	t.Cleanup(ResetMetadataCache)
+	SetMetadataTimeout(20 * time.Millisecond)
+	t.Cleanup(func() { SetMetadataTimeout(0) })
+	Register(&mockMetadataProvider{
+		mockPlugin: mockPlugin{name: "slow", types: NewPluginTypeSet(TypeMetadataProvider)},
+		block:      true,
+	})
+
+	// Action: Fetch
+	start := time.Now()
+	_, err := FetchMetadata("slow", "build")
+
+	// Expected: Timed out promptly
+	if err == nil || !strings.Contains(err.Error(), ErrMetadataTimeout) {
+		t.Errorf("expected %q, got %v", ErrMetadataTimeout, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("fetch took %s", elapsed)
+	}
+}
@@ -1,5 +1,13 @@
 package plugin
 
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+)
+
 // PluginType represents the type of plugin capability.
 // Values match interface names for reflective discovery.
 type PluginType string
@@ -16,6 +24,13 @@ const (
 
 	// TypeHook indicates a lifecycle hook plugin
 	TypeHook PluginType = "Hook"
+
+	// TypeVersioning indicates a version scheme plugin
+	TypeVersioning PluginType = "Versioning"
+
+	// TypeMetadataProvider indicates a plugin that fetches values from an
+	// external system at render time
+	TypeMetadataProvider PluginType = "MetadataProvider"
 )
 
 // PluginTypeSet represents a set of plugin types
@@ -60,15 +75,100 @@ type TemplateProvider interface {
 	Plugin
 
 	// GetTemplateVariables returns plugin-specific template variables
-	// The context map provides access to existing variables the plugin might need
-	// (e.g., ShortHash for creating prefixed hash variables)
+	// The context map holds every built-in and custom template variable as a
+	// string (e.g., ShortHash for creating prefixed hash variables, BranchName
+	// or a custom variable to derive others from). Providers must not modify it.
 	GetTemplateVariables(context map[string]string) map[string]string
 }
 
+// Prioritized is an optional extension of TemplateProvider that orders
+// providers when several set the same variable. Providers are applied in
+// ascending priority, then by name, so the higher priority wins; providers
+// without it have priority 0.
+type Prioritized interface {
+	// Priority returns the provider's priority
+	Priority() int
+}
+
+// providerPriority returns p's priority, 0 unless it implements Prioritized
+func providerPriority(p TemplateProvider) int {
+	if pp, ok := p.(Prioritized); ok {
+		return pp.Priority()
+	}
+	return 0
+}
+
+// sortTemplateProviders orders the template providers for collision
+// resolution, independent of registration (package init) order
+func (r *Registry) sortTemplateProviders() {
+	slices.SortStableFunc(r.templateProviders, func(a, b TemplateProvider) int {
+		if c := cmp.Compare(providerPriority(a), providerPriority(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name(), b.Name())
+	})
+}
+
+// SectionProvider is an optional extension of TemplateProvider for plugins
+// that expose lists, rendered with Mustache sections ({{#Name}}...{{/Name}})
+type SectionProvider interface {
+	TemplateProvider
+
+	// GetTemplateSections returns lists keyed by section name; each item is a
+	// map of the variables available inside the section
+	GetTemplateSections(context map[string]string) map[string][]map[string]string
+}
+
+// Event identifies a lifecycle point at which hooks are run
+type Event string
+
+const (
+	// EventBump fires after the VERSION file has been bumped
+	EventBump Event = "bump"
+
+	// EventTag fires after a release tag has been created
+	EventTag Event = "tag"
+)
+
+// Hook is an interface for plugins that react to lifecycle events
+type Hook interface {
+	Plugin
+
+	// OnEvent is called after the event has completed successfully.
+	// vars holds the template variables for the resulting version.
+	OnEvent(event Event, vars map[string]string) error
+}
+
+// VersioningPlugin is an interface for plugins that define a version scheme,
+// selected with `scheme:` in .versionator.yaml
+type VersioningPlugin interface {
+	Plugin
+
+	// Scheme returns the name the scheme is selected by (e.g. "four-part")
+	Scheme() string
+
+	// Segments returns the number of numeric core segments every version
+	// must have: 3 (Major.Minor.Patch) or 4 (Major.Minor.Patch.Revision)
+	Segments() int
+}
+
+// MetadataProvider is an interface for plugins that fetch values from
+// external systems (build farms, artifact repositories). Templates reference
+// values as {{Meta.<name>.<key>}}; only referenced keys are fetched, through
+// FetchMetadata's cache and timeout.
+type MetadataProvider interface {
+	Plugin
+
+	// Fetch returns the value for key. It must return when ctx is done.
+	Fetch(ctx context.Context, key string) (string, error)
+}
+
 // Registry holds all registered plugins
 type Registry struct {
 	plugins           []Plugin
 	templateProviders []TemplateProvider
+	hooks             []Hook
+	metadataProviders []MetadataProvider
 }
 
 // globalRegistry is the default plugin registry
@@ -81,16 +181,45 @@ func Register(p Plugin) {
 	// Also register as template provider if it implements the interface
 	if tp, ok := p.(TemplateProvider); ok {
 		globalRegistry.templateProviders = append(globalRegistry.templateProviders, tp)
+		globalRegistry.sortTemplateProviders()
 	}
+
+	// Also register as hook if it implements the interface
+	if h, ok := p.(Hook); ok {
+		globalRegistry.hooks = append(globalRegistry.hooks, h)
+	}
+
+	// Also register as metadata provider if it implements the interface
+	if mp, ok := p.(MetadataProvider); ok {
+		globalRegistry.metadataProviders = append(globalRegistry.metadataProviders, mp)
+	}
+}
+
+// Unregister removes every plugin with the given name from the global registry
+func Unregister(name string) {
+	globalRegistry.plugins = slices.DeleteFunc(globalRegistry.plugins, func(p Plugin) bool {
+		return p.Name() == name
+	})
+	globalRegistry.templateProviders = slices.DeleteFunc(globalRegistry.templateProviders, func(p TemplateProvider) bool {
+		return p.Name() == name
+	})
+	globalRegistry.hooks = slices.DeleteFunc(globalRegistry.hooks, func(p Hook) bool {
+		return p.Name() == name
+	})
+	globalRegistry.metadataProviders = slices.DeleteFunc(globalRegistry.metadataProviders, func(p MetadataProvider) bool {
+		return p.Name() == name
+	})
 }
 
 // RegisterTemplateProvider adds a template provider to the global registry
 func RegisterTemplateProvider(provider TemplateProvider) {
 	globalRegistry.templateProviders = append(globalRegistry.templateProviders, provider)
+	globalRegistry.sortTemplateProviders()
 	globalRegistry.plugins = append(globalRegistry.plugins, provider)
 }
 
-// GetAllTemplateVariables collects template variables from all registered plugins
+// GetAllTemplateVariables collects template variables from all registered
+// plugins; on collisions the provider applied last (see Prioritized) wins
 func GetAllTemplateVariables(context map[string]string) map[string]string {
 	result := make(map[string]string)
 	for _, provider := range globalRegistry.templateProviders {
@@ -102,16 +231,84 @@ func GetAllTemplateVariables(context map[string]string) map[string]string {
 	return result
 }
 
+// GetAllTemplateSections collects list sections from all registered template
+// providers that implement SectionProvider
+func GetAllTemplateSections(context map[string]string) map[string][]map[string]string {
+	result := make(map[string][]map[string]string)
+	for _, provider := range globalRegistry.templateProviders {
+		sp, ok := provider.(SectionProvider)
+		if !ok {
+			continue
+		}
+		for k, v := range sp.GetTemplateSections(context) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// RunHooks notifies every registered hook of an event. All hooks run even if
+// some fail; the returned error joins each hook's failure.
+func RunHooks(event Event, vars map[string]string) error {
+	var errs []error
+	for _, h := range globalRegistry.hooks {
+		if err := h.OnEvent(event, vars); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetHooks returns all registered hooks
+func GetHooks() []Hook {
+	return globalRegistry.hooks
+}
+
 // GetPlugins returns all registered plugins
 func GetPlugins() []Plugin {
 	return globalRegistry.plugins
 }
 
-// GetTemplateProviders returns all registered template providers
+// GetTemplateProviders returns all registered template providers, in the
+// order their variables are applied
 func GetTemplateProviders() []TemplateProvider {
 	return globalRegistry.templateProviders
 }
 
+// GetMetadataProvider returns the registered metadata provider with the
+// given name, or nil if there is none
+func GetMetadataProvider(name string) MetadataProvider {
+	for _, mp := range globalRegistry.metadataProviders {
+		if mp.Name() == name {
+			return mp
+		}
+	}
+	return nil
+}
+
+// GetVersioningPlugin returns the registered versioning plugin for scheme,
+// or nil if there is none
+func GetVersioningPlugin(scheme string) VersioningPlugin {
+	for _, p := range globalRegistry.plugins {
+		if vp, ok := p.(VersioningPlugin); ok && vp.Scheme() == scheme {
+			return vp
+		}
+	}
+	return nil
+}
+
+// ListSchemes returns the schemes of all registered versioning plugins, sorted
+func ListSchemes() []string {
+	var schemes []string
+	for _, p := range globalRegistry.plugins {
+		if vp, ok := p.(VersioningPlugin); ok {
+			schemes = append(schemes, vp.Scheme())
+		}
+	}
+	slices.Sort(schemes)
+	return schemes
+}
+
 // GetPluginsByType returns all plugins that implement a specific type
 func GetPluginsByType(pluginType PluginType) []Plugin {
 	var result []Plugin
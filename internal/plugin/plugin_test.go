@@ -1,6 +1,8 @@
 package plugin
 
 import (
+	"context"
+	"errors"
 	"testing"
 )
 
@@ -33,16 +35,86 @@ func (m *mockTemplateProvider) GetTemplateVariables(context map[string]string) m
 	return m.variables
 }
 
+// mockPrioritizedProvider is a template provider with a priority
+type mockPrioritizedProvider struct {
+	mockTemplateProvider
+	priority int
+}
+
+func (m *mockPrioritizedProvider) Priority() int { return m.priority }
+
+// mockSectionProvider is a template provider that also exposes list sections
+type mockSectionProvider struct {
+	mockTemplateProvider
+	sections map[string][]map[string]string
+}
+
+func (m *mockSectionProvider) GetTemplateSections(context map[string]string) map[string][]map[string]string {
+	return m.sections
+}
+
+// mockHook is a hook for testing that records the events it receives and
+// optionally fails.
+type mockHook struct {
+	mockPlugin
+	events []Event
+	err    error
+}
+
+func (m *mockHook) OnEvent(event Event, vars map[string]string) error {
+	m.events = append(m.events, event)
+	return m.err
+}
+
+// mockVersioning is a versioning plugin for testing
+type mockVersioning struct {
+	mockPlugin
+	scheme   string
+	segments int
+}
+
+func (m *mockVersioning) Scheme() string { return m.scheme }
+
+func (m *mockVersioning) Segments() int { return m.segments }
+
+// mockMetadataProvider is a metadata provider for testing that counts its
+// fetches and can block until its context is done
+type mockMetadataProvider struct {
+	mockPlugin
+	values  map[string]string
+	block   bool
+	fetches int
+}
+
+func (m *mockMetadataProvider) Fetch(ctx context.Context, key string) (string, error) {
+	m.fetches++
+	if m.block {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+	value, ok := m.values[key]
+	if !ok {
+		return "", errors.New("no such key")
+	}
+	return value, nil
+}
+
 // saveAndClearRegistry saves the current global registry state and clears it.
 // Returns a cleanup function that restores the original state.
 func saveAndClearRegistry() func() {
 	oldPlugins := globalRegistry.plugins
 	oldProviders := globalRegistry.templateProviders
+	oldHooks := globalRegistry.hooks
+	oldMetadataProviders := globalRegistry.metadataProviders
 	globalRegistry.plugins = nil
 	globalRegistry.templateProviders = nil
+	globalRegistry.hooks = nil
+	globalRegistry.metadataProviders = nil
 	return func() {
 		globalRegistry.plugins = oldPlugins
 		globalRegistry.templateProviders = oldProviders
+		globalRegistry.hooks = oldHooks
+		globalRegistry.metadataProviders = oldMetadataProviders
 	}
 }
 
@@ -502,4 +574,159 @@ func TestPluginTypeConstants(t *testing.T) {
 	if TypeHook != "Hook" {
 		t.Errorf("expected TypeHook='Hook', got '%s'", TypeHook)
 	}
+	if TypeVersioning != "Versioning" {
+		t.Errorf("expected TypeVersioning='Versioning', got '%s'", TypeVersioning)
+	}
+}
+
+// TestRunHooks_AllHooksRunAndErrorsJoined validates hook dispatch.
+//
+// Why: A failing notification hook must not prevent other hooks from running,
+// but its failure still needs to be reported.
+//
+// What: Register a failing and a succeeding hook, run an event, verify both
+// received it and the error from the failing hook is returned.
+func TestRunHooks_AllHooksRunAndErrorsJoined(t *testing.T) {
+	// Precondition: Clear registry and register two hooks, the first failing
+	cleanup := saveAndClearRegistry()
+	defer cleanup()
+	failing := &mockHook{mockPlugin: mockPlugin{name: "failing", types: NewPluginTypeSet(TypeHook)}, err: errors.New("boom")}
+	ok := &mockHook{mockPlugin: mockPlugin{name: "ok", types: NewPluginTypeSet(TypeHook)}}
+	Register(failing)
+	Register(ok)
+
+	// Action: Run the tag event
+	err := RunHooks(EventTag, map[string]string{"Major": "1"})
+
+	// Expected: Both hooks ran; error reports the failure
+	if len(failing.events) != 1 || len(ok.events) != 1 || ok.events[0] != EventTag {
+		t.Errorf("expected both hooks to receive EventTag, got %v and %v", failing.events, ok.events)
+	}
+	if err == nil || err.Error() != "boom" {
+		t.Errorf("expected joined error 'boom', got %v", err)
+	}
+	if len(GetHooks()) != 2 {
+		t.Errorf("expected 2 hooks, got %d", len(GetHooks()))
+	}
+}
+
+// TestUnregister_RemovesPluginFromAllLists validates plugin removal.
+//
+// Why: Tests and embedders need to swap plugins (as with vcs.UnregisterVCS)
+// without leaking them into unrelated code paths.
+//
+// What: Register a hook, unregister it by name, verify it is gone from both
+// the plugin and hook lists.
+func TestUnregister_RemovesPluginFromAllLists(t *testing.T) {
+	// Precondition: Clear registry and register a hook
+	cleanup := saveAndClearRegistry()
+	defer cleanup()
+	Register(&mockHook{mockPlugin: mockPlugin{name: "notify", types: NewPluginTypeSet(TypeHook)}})
+
+	// Action: Unregister by name
+	Unregister("notify")
+
+	// Expected: No plugins or hooks remain
+	if len(GetPlugins()) != 0 || len(GetHooks()) != 0 {
+		t.Errorf("expected empty registry, got %d plugins and %d hooks", len(GetPlugins()), len(GetHooks()))
+	}
+}
+
+// TestGetAllTemplateVariables_CollisionsFollowPriority validates collision
+// resolution.
+//
+// Why: Registration order follows package init order, which changes as
+// imports change; which provider wins a shared variable must not.
+//
+// What: Without priorities the later name wins regardless of registration
+// order; a higher priority wins over both.
+func TestGetAllTemplateVariables_CollisionsFollowPriority(t *testing.T) {
+	// Precondition: Two providers setting Shared, registered in reverse name order
+	cleanup := saveAndClearRegistry()
+	defer cleanup()
+	provider := func(name string) *mockTemplateProvider {
+		return &mockTemplateProvider{
+			mockPlugin: mockPlugin{name: name, types: NewPluginTypeSet(TypeTemplateProvider)},
+			variables:  map[string]string{"Shared": name},
+		}
+	}
+	Register(provider("zeta"))
+	Register(provider("alpha"))
+
+	// Action
+	byName := GetAllTemplateVariables(nil)["Shared"]
+	Register(&mockPrioritizedProvider{mockTemplateProvider: *provider("override"), priority: 10})
+	byPriority := GetAllTemplateVariables(nil)["Shared"]
+
+	// Expected
+	if byName != "zeta" {
+		t.Errorf("expected zeta to win by name, got %q", byName)
+	}
+	if byPriority != "override" {
+		t.Errorf("expected the prioritized provider to win, got %q", byPriority)
+	}
+}
+
+// TestGetAllTemplateSections_OnlySectionProvidersContribute validates section
+// collection.
+//
+// Why: List variables (e.g., issue keys for release notes) cannot be expressed
+// as flat strings, so providers opt in to sections via SectionProvider.
+//
+// What: Register a plain template provider and a section provider; only the
+// section provider's lists are returned.
+func TestGetAllTemplateSections_OnlySectionProvidersContribute(t *testing.T) {
+	// Precondition: One plain provider and one section provider
+	cleanup := saveAndClearRegistry()
+	defer cleanup()
+	Register(&mockTemplateProvider{
+		mockPlugin: mockPlugin{name: "plain", types: NewPluginTypeSet(TypeTemplateProvider)},
+		variables:  map[string]string{"Plain": "x"},
+	})
+	Register(&mockSectionProvider{
+		mockTemplateProvider: mockTemplateProvider{
+			mockPlugin: mockPlugin{name: "lists", types: NewPluginTypeSet(TypeTemplateProvider)},
+		},
+		sections: map[string][]map[string]string{"Items": {{"Key": "A-1"}, {"Key": "A-2"}}},
+	})
+
+	// Action: Collect sections
+	sections := GetAllTemplateSections(nil)
+
+	// Expected: Only the section provider's list
+	if len(sections) != 1 || len(sections["Items"]) != 2 || sections["Items"][1]["Key"] != "A-2" {
+		t.Errorf("unexpected sections: %v", sections)
+	}
+}
+
+// TestGetVersioningPlugin_ByScheme_ReturnsMatchingPlugin validates scheme
+// lookup.
+//
+// Why: `scheme:` in .versionator.yaml names a versioning plugin; an unknown
+// name must be detectable so it can be reported with the available ones.
+//
+// What: Register two versioning plugins and a hook; lookup by scheme returns
+// the match or nil, and ListSchemes lists only versioning plugins, sorted.
+func TestGetVersioningPlugin_ByScheme_ReturnsMatchingPlugin(t *testing.T) {
+	// Precondition: Two schemes and an unrelated hook
+	cleanup := saveAndClearRegistry()
+	defer cleanup()
+	Register(&mockVersioning{mockPlugin: mockPlugin{name: "four", types: NewPluginTypeSet(TypeVersioning)}, scheme: "four-part", segments: 4})
+	Register(&mockVersioning{mockPlugin: mockPlugin{name: "calver", types: NewPluginTypeSet(TypeVersioning)}, scheme: "calver", segments: 3})
+	Register(&mockHook{mockPlugin: mockPlugin{name: "notify", types: NewPluginTypeSet(TypeHook)}})
+
+	// Action: Look up known and unknown schemes
+	found := GetVersioningPlugin("four-part")
+	missing := GetVersioningPlugin("five-part")
+
+	// Expected: The four-part plugin, nil, and both scheme names
+	if found == nil || found.Segments() != 4 {
+		t.Errorf("expected four-part plugin, got %v", found)
+	}
+	if missing != nil {
+		t.Errorf("expected nil for unknown scheme, got %v", missing)
+	}
+	if got := ListSchemes(); len(got) != 2 || got[0] != "calver" || got[1] != "four-part" {
+		t.Errorf("expected [calver four-part], got %v", got)
+	}
 }
@@ -0,0 +1,8 @@
+// Package preset messages - error message constants
+// Exported so tests can compare against them
+package preset
+
+// Error messages
+const (
+	ErrUnknownPreset = "unknown preset"
+)
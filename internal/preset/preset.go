@@ -0,0 +1,179 @@
+// Package preset holds the stack presets of `init --preset`: a complete
+// .versionator.yaml per ecosystem, with the tag pattern, pre-release and
+// metadata templates, emit targets and manifest updates that ecosystem
+// expects, so a new project is configured in one step.
+package preset
+
+// Preset is a starting configuration for one kind of project
+type Preset struct {
+	// Name identifies the preset on the command line (e.g. "go-service")
+	Name string
+	// Description is shown in help and errors
+	Description string
+	// Prefix is the VERSION prefix init writes unless --prefix is given
+	Prefix string
+	// Config is the .versionator.yaml content
+	Config string
+}
+
+// Presets lists every preset, in help order
+var Presets = []Preset{
+	{
+		Name:        "go-service",
+		Description: "Go service: v-prefixed module tags, dev builds marked with the commit",
+		Prefix:      "v",
+		Config: `# Versionator configuration: go-service preset
+# See https://github.com/benjaminabbitt/versionator for documentation
+
+# Go module tags must be v-prefixed (v1.2.3)
+prefix: "v"
+
+release:
+  tagFormat: "v{{Version}}"
+
+# A clean build of a release tag is exactly the tag (1.2.0); every other
+# build is a dev build of the next version (1.2.0-dev-3+20241211103045.abc1234)
+prerelease:
+  template: "{{^IsReleaseBuild}}dev-{{CommitsSinceTag}}{{/IsReleaseBuild}}"
+  stable: false
+metadata:
+  template: "{{^IsReleaseBuild}}{{BuildDateTimeCompact}}.{{ShortHash}}{{/IsReleaseBuild}}"
+  stable: false
+
+# 'output emit --all' writes the version package the service embeds
+emit:
+  targets:
+    - name: go
+      format: go
+      output: internal/version/version.go
+`,
+	},
+	{
+		Name:        "node-lib",
+		Description: "npm library: package.json kept in sync, npm-style v tags",
+		Config: `# Versionator configuration: node-lib preset
+# See https://github.com/benjaminabbitt/versionator for documentation
+
+# package.json versions have no prefix; tags follow 'npm version' (v1.2.3)
+prefix: ""
+
+release:
+  tagFormat: "v{{Version}}"
+
+# Pre-releases are published to the registry (1.2.0-beta.1), so they are
+# kept in VERSION: 'config prerelease set beta.1'. The registry ignores
+# build metadata, so none is added.
+prerelease:
+  template: ""
+  stable: true
+metadata:
+  template: ""
+  stable: false
+
+# 'output emit --all' writes a version module for the library
+emit:
+  targets:
+    - name: js
+      format: js
+      output: src/version.js
+
+# 'patch' and 'release' keep package.json in sync with VERSION
+updates:
+  - file: package.json
+    path: version
+    template: "{{MajorMinorPatch}}{{PreReleaseWithDash}}"
+`,
+	},
+	{
+		Name:        "python-package",
+		Description: "Python package: PEP 440 versions in pyproject.toml and _version.py",
+		Config: `# Versionator configuration: python-package preset
+# See https://github.com/benjaminabbitt/versionator for documentation
+
+# Python versions have no prefix; tags are v-prefixed (v1.2.3)
+prefix: ""
+
+release:
+  tagFormat: "v{{Version}}"
+
+# Pre-releases are published to the index, so they are kept in VERSION:
+# 'config prerelease set rc.1' publishes 1.2.0rc1. The index rejects
+# local versions, so no build metadata is added.
+prerelease:
+  template: ""
+  stable: true
+metadata:
+  template: ""
+  stable: false
+
+# 'output emit --all' writes the version module, in PEP 440 form
+emit:
+  targets:
+    - name: python
+      format: python
+      output: _version.py
+      style: pep440
+
+# 'patch' and 'release' keep pyproject.toml in sync with VERSION
+updates:
+  - file: pyproject.toml
+    path: project.version
+    template: "{{Pep440Version}}"
+`,
+	},
+	{
+		Name:        "rust-crate",
+		Description: "Rust crate: Cargo.toml kept in sync, cargo-release style v tags",
+		Config: `# Versionator configuration: rust-crate preset
+# See https://github.com/benjaminabbitt/versionator for documentation
+
+# Cargo versions have no prefix; tags follow cargo-release (v1.2.3)
+prefix: ""
+
+release:
+  tagFormat: "v{{Version}}"
+
+# Pre-releases are published to crates.io (1.2.0-beta.1), so they are kept
+# in VERSION: 'config prerelease set beta.1'. crates.io ignores build
+# metadata, so none is added.
+prerelease:
+  template: ""
+  stable: true
+metadata:
+  template: ""
+  stable: false
+
+# 'output emit --all' writes a version module for the crate
+emit:
+  targets:
+    - name: rust
+      format: rust
+      output: src/version.rs
+
+# 'patch' and 'release' keep Cargo.toml in sync with VERSION
+updates:
+  - file: Cargo.toml
+    path: package.version
+    template: "{{MajorMinorPatch}}{{PreReleaseWithDash}}"
+`,
+	},
+}
+
+// Names returns the names of all presets
+func Names() []string {
+	names := make([]string, len(Presets))
+	for i, p := range Presets {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// Lookup returns the preset called name
+func Lookup(name string) (Preset, bool) {
+	for _, p := range Presets {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Preset{}, false
+}
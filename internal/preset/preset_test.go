@@ -0,0 +1,64 @@
+package preset
+
+import (
+	"os"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+)
+
+// TestPresets_ConfigsAreValid validates every preset's configuration.
+//
+// Why: init writes the preset verbatim; a key the config parser rejects
+// would leave a new project with a broken .versionator.yaml.
+//
+// What: Each preset parses strictly, passes validation, sets its tag
+// format and an emit target, and its prefix matches the VERSION prefix
+// init writes.
+func TestPresets_ConfigsAreValid(t *testing.T) {
+	for _, p := range Presets {
+		t.Run(p.Name, func(t *testing.T) {
+			// Precondition
+			t.Chdir(t.TempDir())
+			if err := os.WriteFile(".versionator.yaml", []byte(p.Config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			config.Invalidate()
+			t.Cleanup(config.Invalidate)
+
+			// Action
+			cfg, err := config.ReadConfig()
+
+			// Expected
+			if err != nil {
+				t.Fatalf("ReadConfig failed: %v", err)
+			}
+			if err := cfg.Validate(); err != nil {
+				t.Errorf("Validate failed: %v", err)
+			}
+			if cfg.Release.TagFormat == "" || len(cfg.Emit.Targets) == 0 {
+				t.Errorf("tagFormat = %q, emit targets = %v", cfg.Release.TagFormat, cfg.Emit.Targets)
+			}
+			if cfg.Prefix != p.Prefix {
+				t.Errorf("config prefix = %q, preset prefix = %q", cfg.Prefix, p.Prefix)
+			}
+		})
+	}
+}
+
+// TestLookup validates finding presets by name.
+//
+// Why: init reports an unknown preset with the available names.
+//
+// What: Every listed name is found; other names are not.
+func TestLookup(t *testing.T) {
+	// Action / Expected
+	for _, name := range Names() {
+		if p, ok := Lookup(name); !ok || p.Name != name {
+			t.Errorf("Lookup(%q) = %v, %v", name, p.Name, ok)
+		}
+	}
+	if _, ok := Lookup("cobol-app"); ok {
+		t.Error("Lookup found an unknown preset")
+	}
+}
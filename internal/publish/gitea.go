@@ -0,0 +1,73 @@
+package publish
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+)
+
+// GiteaPublisher creates releases through the Gitea REST API (also used by
+// Forgejo and Codeberg)
+type GiteaPublisher struct {
+	api        apiClient
+	apiURL     string
+	repository string
+}
+
+func newGiteaPublisher(opts Options) Publisher {
+	return &GiteaPublisher{
+		api: apiClient{
+			client:     opts.Client,
+			authHeader: "Authorization",
+			authValue:  "token " + opts.Token,
+		},
+		apiURL:     opts.APIURL,
+		repository: opts.Repository,
+	}
+}
+
+// Name returns "gitea"
+func (p *GiteaPublisher) Name() string {
+	return "gitea"
+}
+
+// Publish creates the release and attaches each asset to it
+func (p *GiteaPublisher) Publish(release Release) (*Result, error) {
+	var created struct {
+		ID      int64  `json:"id"`
+		HTMLURL string `json:"html_url"`
+	}
+	payload := map[string]any{
+		"tag_name":   release.TagName,
+		"name":       release.Title,
+		"body":       release.Notes,
+		"prerelease": release.PreRelease,
+	}
+	releasesURL := fmt.Sprintf("%s/repos/%s/releases", p.apiURL, p.repository)
+	if err := p.api.postJSON(releasesURL, payload, &created); err != nil {
+		return nil, err
+	}
+
+	result := &Result{URL: created.HTMLURL}
+
+	for _, path := range release.Assets {
+		name := filepath.Base(path)
+		data, err := readAsset(path)
+		if err != nil {
+			return result, err
+		}
+		body, contentType, err := multipartFile("attachment", name, data)
+		if err != nil {
+			return result, err
+		}
+
+		assetURL := fmt.Sprintf("%s/%d/assets?name=%s", releasesURL, created.ID, url.QueryEscape(name))
+		if err := p.api.do(http.MethodPost, assetURL, contentType, body, nil); err != nil {
+			return result, fmt.Errorf("%s %s: %w", ErrAssetUpload, name, err)
+		}
+		result.Assets = append(result.Assets, name)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package publish
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// GitHubPublisher creates releases through the GitHub REST API
+type GitHubPublisher struct {
+	api        apiClient
+	apiURL     string
+	repository string
+}
+
+func newGitHubPublisher(opts Options) Publisher {
+	return &GitHubPublisher{
+		api: apiClient{
+			client:     opts.Client,
+			authHeader: "Authorization",
+			authValue:  "Bearer " + opts.Token,
+		},
+		apiURL:     opts.APIURL,
+		repository: opts.Repository,
+	}
+}
+
+// Name returns "github"
+func (p *GitHubPublisher) Name() string {
+	return "github"
+}
+
+// Publish creates the release and uploads assets to its upload URL
+func (p *GitHubPublisher) Publish(release Release) (*Result, error) {
+	var created struct {
+		HTMLURL   string `json:"html_url"`
+		UploadURL string `json:"upload_url"`
+	}
+	payload := map[string]any{
+		"tag_name":   release.TagName,
+		"name":       release.Title,
+		"body":       release.Notes,
+		"prerelease": release.PreRelease,
+	}
+	if err := p.api.postJSON(fmt.Sprintf("%s/repos/%s/releases", p.apiURL, p.repository), payload, &created); err != nil {
+		return nil, err
+	}
+
+	result := &Result{URL: created.HTMLURL}
+
+	// upload_url is an RFC 6570 template: ".../assets{?name,label}"
+	uploadURL := created.UploadURL
+	if i := strings.Index(uploadURL, "{"); i >= 0 {
+		uploadURL = uploadURL[:i]
+	}
+
+	for _, path := range release.Assets {
+		name := filepath.Base(path)
+		data, err := readAsset(path)
+		if err != nil {
+			return result, err
+		}
+		err = p.api.do(http.MethodPost, uploadURL+"?name="+url.QueryEscape(name), "application/octet-stream", bytes.NewReader(data), nil)
+		if err != nil {
+			return result, fmt.Errorf("%s %s: %w", ErrAssetUpload, name, err)
+		}
+		result.Assets = append(result.Assets, name)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,86 @@
+package publish
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// GitLabPublisher creates releases through the GitLab REST API (v4)
+type GitLabPublisher struct {
+	api        apiClient
+	apiURL     string
+	repository string
+}
+
+func newGitLabPublisher(opts Options) Publisher {
+	return &GitLabPublisher{
+		api: apiClient{
+			client:     opts.Client,
+			authHeader: "PRIVATE-TOKEN",
+			authValue:  opts.Token,
+		},
+		apiURL:     opts.APIURL,
+		repository: opts.Repository,
+	}
+}
+
+// Name returns "gitlab"
+func (p *GitLabPublisher) Name() string {
+	return "gitlab"
+}
+
+// Publish creates the release, then uploads each asset to the project and
+// links it from the release
+func (p *GitLabPublisher) Publish(release Release) (*Result, error) {
+	project := p.apiURL + "/projects/" + url.PathEscape(p.repository)
+
+	var created struct {
+		Links struct {
+			Self string `json:"self"`
+		} `json:"_links"`
+	}
+	payload := map[string]any{
+		"tag_name":    release.TagName,
+		"name":        release.Title,
+		"description": release.Notes,
+	}
+	if err := p.api.postJSON(project+"/releases", payload, &created); err != nil {
+		return nil, err
+	}
+
+	result := &Result{URL: created.Links.Self}
+
+	// Uploaded file URLs are relative to the project's web URL
+	webURL := strings.TrimSuffix(p.apiURL, "/api/v4") + "/" + p.repository
+
+	for _, path := range release.Assets {
+		name := filepath.Base(path)
+		data, err := readAsset(path)
+		if err != nil {
+			return result, err
+		}
+		body, contentType, err := multipartFile("file", name, data)
+		if err != nil {
+			return result, err
+		}
+
+		var upload struct {
+			URL string `json:"url"`
+		}
+		if err := p.api.do(http.MethodPost, project+"/uploads", contentType, body, &upload); err != nil {
+			return result, fmt.Errorf("%s %s: %w", ErrAssetUpload, name, err)
+		}
+
+		link := map[string]any{"name": name, "url": webURL + upload.URL}
+		linksURL := fmt.Sprintf("%s/releases/%s/assets/links", project, url.PathEscape(release.TagName))
+		if err := p.api.postJSON(linksURL, link, nil); err != nil {
+			return result, fmt.Errorf("%s %s: %w", ErrAssetUpload, name, err)
+		}
+		result.Assets = append(result.Assets, name)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,10 @@
+package publish
+
+// Error messages
+const (
+	ErrUnknownProvider   = "unknown release provider"
+	ErrMissingRepository = "release publish repository is not configured"
+	ErrMissingToken      = "release publish token is not set"
+	ErrRequestFailed     = "release API request failed"
+	ErrAssetUpload       = "failed to upload release asset"
+)
@@ -0,0 +1,210 @@
+// Package publish creates releases on hosted VCS providers (GitHub, GitLab,
+// Gitea) through their REST APIs.
+package publish
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Release describes the release to create for an existing tag
+type Release struct {
+	TagName    string
+	Title      string
+	Notes      string
+	PreRelease bool
+	// Assets are local file paths to upload after the release is created
+	Assets []string
+}
+
+// Result describes a created release
+type Result struct {
+	// URL is the web URL of the release, when the provider returns one
+	URL string
+	// Assets lists the names of uploaded assets
+	Assets []string
+}
+
+// Publisher creates releases on a hosting provider
+type Publisher interface {
+	// Name returns the provider name (e.g., "github")
+	Name() string
+	// Publish creates the release and uploads its assets
+	Publish(release Release) (*Result, error)
+}
+
+// Options configures a Publisher
+type Options struct {
+	// APIURL is the API base URL; empty selects the provider default
+	APIURL string
+	// Repository is the project identifier ("owner/name")
+	Repository string
+	// Token authenticates API requests
+	Token string
+	// Client performs HTTP requests; nil uses a client with a 60s timeout
+	Client *http.Client
+}
+
+// providerInfo holds per-provider defaults and the constructor
+type providerInfo struct {
+	defaultAPIURL   string
+	defaultTokenEnv string
+	create          func(opts Options) Publisher
+}
+
+var providers = map[string]providerInfo{
+	"github": {"https://api.github.com", "GITHUB_TOKEN", newGitHubPublisher},
+	"gitlab": {"https://gitlab.com/api/v4", "GITLAB_TOKEN", newGitLabPublisher},
+	"gitea":  {"https://gitea.com/api/v1", "GITEA_TOKEN", newGiteaPublisher},
+}
+
+// AvailableProviders returns the sorted names of supported providers
+func AvailableProviders() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultTokenEnv returns the environment variable conventionally holding
+// the token for the named provider
+func DefaultTokenEnv(provider string) string {
+	return providers[provider].defaultTokenEnv
+}
+
+// NewPublisher returns the Publisher for the named provider
+func NewPublisher(provider string, opts Options) (Publisher, error) {
+	info, ok := providers[provider]
+	if !ok {
+		return nil, fmt.Errorf("%s: %q (available: %s)", ErrUnknownProvider, provider, strings.Join(AvailableProviders(), ", "))
+	}
+	if opts.Repository == "" {
+		return nil, fmt.Errorf(ErrMissingRepository)
+	}
+	if opts.Token == "" {
+		return nil, fmt.Errorf(ErrMissingToken)
+	}
+	if opts.APIURL == "" {
+		opts.APIURL = info.defaultAPIURL
+	}
+	opts.APIURL = strings.TrimSuffix(opts.APIURL, "/")
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 60 * time.Second}
+	}
+	return info.create(opts), nil
+}
+
+// apiClient performs authenticated JSON requests against a provider API
+type apiClient struct {
+	client     *http.Client
+	authHeader string
+	authValue  string
+}
+
+// do sends a request and decodes a JSON response into out (if non-nil).
+// Non-2xx responses are returned as errors including the response body.
+func (c *apiClient) do(method, url, contentType string, body io.Reader, out any) error {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrRequestFailed, err)
+	}
+	req.Header.Set(c.authHeader, c.authValue)
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrRequestFailed, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: %s %s: %s: %s", ErrRequestFailed, method, url, resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("%s: invalid response: %w", ErrRequestFailed, err)
+		}
+	}
+	return nil
+}
+
+// postJSON sends payload as a JSON body
+func (c *apiClient) postJSON(url string, payload, out any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrRequestFailed, err)
+	}
+	return c.do(http.MethodPost, url, "application/json", bytes.NewReader(data), out)
+}
+
+// readAsset reads an asset file for upload. Assets are buffered so requests
+// carry a Content-Length, which the upload endpoints require.
+func readAsset(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrAssetUpload, err)
+	}
+	return data, nil
+}
+
+// multipartFile encodes data as a single-file multipart/form-data body,
+// returning the body and its content type
+func multipartFile(field, name string, data []byte) (*bytes.Buffer, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile(field, name)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", ErrAssetUpload, err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", ErrAssetUpload, err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", ErrAssetUpload, err)
+	}
+	return &buf, w.FormDataContentType(), nil
+}
+
+// ExpandAssets resolves asset glob patterns to a de-duplicated list of files.
+// A pattern that matches nothing is an error, so a broken build does not
+// silently produce a release without its artifacts.
+func ExpandAssets(patterns []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match asset pattern %q", pattern)
+		}
+		for _, m := range matches {
+			if info, err := os.Stat(m); err != nil || info.IsDir() || seen[m] {
+				continue
+			}
+			seen[m] = true
+			files = append(files, m)
+		}
+	}
+	return files, nil
+}
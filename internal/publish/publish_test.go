@@ -0,0 +1,280 @@
+package publish
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordedRequest captures what a fake provider API received
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   string
+}
+
+// fakeAPI serves canned JSON responses keyed by "METHOD path" and records requests
+type fakeAPI struct {
+	mu        sync.Mutex
+	requests  []recordedRequest
+	responses map[string]string
+	server    *httptest.Server
+}
+
+func newFakeAPI(t *testing.T, responses map[string]string) *fakeAPI {
+	t.Helper()
+	f := &fakeAPI{responses: responses}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		f.mu.Lock()
+		f.requests = append(f.requests, recordedRequest{
+			Method: r.Method,
+			Path:   r.URL.EscapedPath(),
+			Query:  r.URL.RawQuery,
+			Header: r.Header.Clone(),
+			Body:   string(body),
+		})
+		f.mu.Unlock()
+
+		resp, ok := f.responses[r.Method+" "+r.URL.EscapedPath()]
+		if !ok {
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, strings.ReplaceAll(resp, "{{server}}", f.server.URL))
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// writeAsset creates a file to upload and returns its path
+func writeAsset(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write asset: %v", err)
+	}
+	return path
+}
+
+// TestGitHubPublisher_Publish_CreatesReleaseAndUploadsAssets validates the
+// GitHub release flow.
+//
+// Why: GitHub requires creating the release first, then uploading assets to
+// the templated upload_url from the response.
+//
+// What: The create request carries tag, title, notes, prerelease flag, and a
+// bearer token; the asset is POSTed to the upload URL with its name.
+func TestGitHubPublisher_Publish_CreatesReleaseAndUploadsAssets(t *testing.T) {
+	// Precondition: Fake GitHub API returning an upload_url template
+	api := newFakeAPI(t, map[string]string{
+		"POST /repos/acme/widget/releases": `{"html_url":"https://github.com/acme/widget/releases/v1.2.3","upload_url":"{{server}}/uploads/1/assets{?name,label}"}`,
+		"POST /uploads/1/assets":           `{}`,
+	})
+	asset := writeAsset(t, "widget.tar.gz", "binary")
+	p, err := NewPublisher("github", Options{APIURL: api.server.URL, Repository: "acme/widget", Token: "s3cret"})
+	if err != nil {
+		t.Fatalf("NewPublisher failed: %v", err)
+	}
+
+	// Action: Publish a pre-release with one asset
+	result, err := p.Publish(Release{TagName: "v1.2.3-rc.1", Title: "v1.2.3-rc.1", Notes: "notes", PreRelease: true, Assets: []string{asset}})
+
+	// Expected: Release created, asset uploaded, URL returned
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if result.URL != "https://github.com/acme/widget/releases/v1.2.3" {
+		t.Errorf("unexpected URL %q", result.URL)
+	}
+	if len(api.requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(api.requests))
+	}
+	create := api.requests[0]
+	if create.Header.Get("Authorization") != "Bearer s3cret" {
+		t.Errorf("unexpected auth header %q", create.Header.Get("Authorization"))
+	}
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(create.Body), &payload); err != nil {
+		t.Fatalf("invalid create payload: %v", err)
+	}
+	if payload["tag_name"] != "v1.2.3-rc.1" || payload["prerelease"] != true || payload["body"] != "notes" {
+		t.Errorf("unexpected create payload: %v", payload)
+	}
+	upload := api.requests[1]
+	if upload.Query != "name=widget.tar.gz" || upload.Body != "binary" {
+		t.Errorf("unexpected upload request: %+v", upload)
+	}
+}
+
+// TestGitLabPublisher_Publish_UploadsAndLinksAssets validates the GitLab flow.
+//
+// Why: GitLab releases do not hold files directly; assets are uploaded to the
+// project and then linked from the release.
+//
+// What: The project path is URL-encoded, the PRIVATE-TOKEN header is sent,
+// and the asset link points at the uploaded file under the project web URL.
+func TestGitLabPublisher_Publish_UploadsAndLinksAssets(t *testing.T) {
+	// Precondition: Fake GitLab API under /api/v4
+	api := newFakeAPI(t, map[string]string{
+		"POST /api/v4/projects/group%2Fsub%2Fwidget/releases":                     `{"_links":{"self":"https://gitlab.example/group/sub/widget/-/releases/v1.0.0"}}`,
+		"POST /api/v4/projects/group%2Fsub%2Fwidget/uploads":                      `{"url":"/uploads/abc/widget.zip"}`,
+		"POST /api/v4/projects/group%2Fsub%2Fwidget/releases/v1.0.0/assets/links": `{}`,
+	})
+	asset := writeAsset(t, "widget.zip", "zip")
+	p, err := NewPublisher("gitlab", Options{APIURL: api.server.URL + "/api/v4", Repository: "group/sub/widget", Token: "glpat"})
+	if err != nil {
+		t.Fatalf("NewPublisher failed: %v", err)
+	}
+
+	// Action: Publish with one asset
+	result, err := p.Publish(Release{TagName: "v1.0.0", Title: "v1.0.0", Assets: []string{asset}})
+
+	// Expected: Three requests; link URL built from the web URL
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if len(result.Assets) != 1 || result.Assets[0] != "widget.zip" {
+		t.Errorf("unexpected assets: %v", result.Assets)
+	}
+	if len(api.requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(api.requests))
+	}
+	if api.requests[0].Header.Get("PRIVATE-TOKEN") != "glpat" {
+		t.Errorf("missing PRIVATE-TOKEN header")
+	}
+	wantLink := api.server.URL + "/group/sub/widget/uploads/abc/widget.zip"
+	if !strings.Contains(api.requests[2].Body, wantLink) {
+		t.Errorf("link body %q does not contain %q", api.requests[2].Body, wantLink)
+	}
+}
+
+// TestGiteaPublisher_Publish_AttachesAssetsByReleaseID validates the Gitea flow.
+//
+// Why: Gitea attaches assets to a release by its numeric ID.
+//
+// What: The asset is POSTed as multipart to /releases/{id}/assets with the
+// "token" authorization scheme.
+func TestGiteaPublisher_Publish_AttachesAssetsByReleaseID(t *testing.T) {
+	// Precondition: Fake Gitea API returning release ID 42
+	api := newFakeAPI(t, map[string]string{
+		"POST /repos/acme/widget/releases":           `{"id":42,"html_url":"https://gitea.example/acme/widget/releases/tag/v2.0.0"}`,
+		"POST /repos/acme/widget/releases/42/assets": `{}`,
+	})
+	asset := writeAsset(t, "widget.bin", "bin")
+	p, err := NewPublisher("gitea", Options{APIURL: api.server.URL, Repository: "acme/widget", Token: "tok"})
+	if err != nil {
+		t.Fatalf("NewPublisher failed: %v", err)
+	}
+
+	// Action: Publish with one asset
+	_, err = p.Publish(Release{TagName: "v2.0.0", Assets: []string{asset}})
+
+	// Expected: Multipart upload to the release's asset endpoint
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if api.requests[0].Header.Get("Authorization") != "token tok" {
+		t.Errorf("unexpected auth header %q", api.requests[0].Header.Get("Authorization"))
+	}
+	upload := api.requests[1]
+	if !strings.HasPrefix(upload.Header.Get("Content-Type"), "multipart/form-data") || !strings.Contains(upload.Body, `name="attachment"`) {
+		t.Errorf("expected multipart attachment upload, got %+v", upload)
+	}
+}
+
+// TestPublish_APIError_ReturnsResponseBody validates error reporting.
+//
+// Why: Provider errors (bad token, missing tag) are only diagnosable from the
+// response body.
+//
+// What: A non-2xx response returns an error containing the status and body.
+func TestPublish_APIError_ReturnsResponseBody(t *testing.T) {
+	// Precondition: Fake API with no routes (every request 404s)
+	api := newFakeAPI(t, map[string]string{})
+	p, _ := NewPublisher("github", Options{APIURL: api.server.URL, Repository: "acme/widget", Token: "x"})
+
+	// Action: Publish
+	_, err := p.Publish(Release{TagName: "v1.0.0"})
+
+	// Expected: Error with status and body
+	if err == nil || !strings.Contains(err.Error(), "404") || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected 404 error with body, got %v", err)
+	}
+}
+
+// TestNewPublisher_InvalidOptions_ReturnsError validates option checks.
+//
+// Why: Misconfiguration should fail before any tag is pushed.
+//
+// What: Unknown providers, missing repository, and missing token are rejected.
+func TestNewPublisher_InvalidOptions_ReturnsError(t *testing.T) {
+	// Precondition: Invalid option combinations
+	tests := []struct {
+		provider string
+		opts     Options
+		want     string
+	}{
+		{"bitbucket", Options{Repository: "a/b", Token: "t"}, ErrUnknownProvider},
+		{"github", Options{Token: "t"}, ErrMissingRepository},
+		{"gitea", Options{Repository: "a/b"}, ErrMissingToken},
+	}
+
+	for _, tt := range tests {
+		// Action: Construct the publisher
+		_, err := NewPublisher(tt.provider, tt.opts)
+
+		// Expected: Error naming the problem
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("NewPublisher(%q) error = %v, want %q", tt.provider, err, tt.want)
+		}
+	}
+}
+
+// TestExpandAssets_ResolvesGlobsAndRejectsEmptyMatches validates asset globbing.
+//
+// Why: A release missing its artifacts is worse than a failed publish.
+//
+// What: Globs expand to files (directories skipped, duplicates removed); a
+// pattern with no matches is an error.
+func TestExpandAssets_ResolvesGlobsAndRejectsEmptyMatches(t *testing.T) {
+	// Precondition: Two archives and a directory
+	dir := t.TempDir()
+	for _, name := range []string{"a.tar.gz", "b.tar.gz"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "c.tar.gz"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action: Expand overlapping patterns
+	files, err := ExpandAssets([]string{filepath.Join(dir, "*.tar.gz"), filepath.Join(dir, "a.*")})
+
+	// Expected: Both files once, no directory
+	if err != nil {
+		t.Fatalf("ExpandAssets failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Errorf("expected 2 files, got %v", files)
+	}
+
+	// Action: Expand a pattern matching nothing
+	_, err = ExpandAssets([]string{filepath.Join(dir, "*.zip")})
+
+	// Expected: Error
+	if err == nil || !strings.Contains(err.Error(), "no files match") {
+		t.Errorf("expected no-match error, got %v", err)
+	}
+}
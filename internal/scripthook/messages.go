@@ -0,0 +1,8 @@
+package scripthook
+
+// Error messages
+const (
+	ErrScriptFailed    = "hook script failed"
+	ErrScriptTimeout   = "hook script timed out"
+	ErrConfigReadError = "failed to read hook script configuration"
+)
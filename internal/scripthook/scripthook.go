@@ -0,0 +1,182 @@
+// Package scripthook provides a built-in hook that runs configured scripts
+// after successful bump or tag operations. Every template variable is passed
+// in the script's environment as VERSIONATOR_<NAME>, and NAME=value lines the
+// script prints become template variables for the hooks that follow and for
+// templates rendered later in the same run.
+package scripthook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/platform"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+)
+
+// DefaultTimeout bounds a script without a configured timeout
+const DefaultTimeout = 60 * time.Second
+
+// EnvPrefix starts the name of every variable passed to scripts
+const EnvPrefix = "VERSIONATOR_"
+
+// ConfigLoader returns the scripts to run
+type ConfigLoader func() ([]config.ScriptHookConfig, error)
+
+// Hook runs configured scripts on lifecycle events
+type Hook struct {
+	loadConfig ConfigLoader
+	stderr     io.Writer
+
+	mu       sync.Mutex
+	captured map[string]string
+}
+
+// capturePattern matches a NAME=value line printed by a script
+var capturePattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_]*)=(.*)$`)
+
+// NewHook creates a Hook with an injected config loader; scripts' stderr
+// goes to stderr
+func NewHook(loader ConfigLoader, stderr io.Writer) *Hook {
+	return &Hook{loadConfig: loader, stderr: stderr, captured: map[string]string{}}
+}
+
+// NewHookDefault creates a Hook that reads scripts from .versionator.yaml
+func NewHookDefault() *Hook {
+	return NewHook(func() ([]config.ScriptHookConfig, error) {
+		cfg, err := config.ReadConfig()
+		if err != nil {
+			return nil, err
+		}
+		return cfg.Hooks.Scripts, nil
+	}, os.Stderr)
+}
+
+// Name returns "scripthook"
+func (h *Hook) Name() string {
+	return "scripthook"
+}
+
+// Types returns the set of plugin types this hook implements
+func (h *Hook) Types() plugin.PluginTypeSet {
+	return plugin.NewPluginTypeSet(plugin.TypeHook, plugin.TypeTemplateProvider)
+}
+
+// OnEvent runs every configured script subscribed to event, in order. Each
+// script's captured variables are added to vars, so later scripts and hooks
+// see them. The first failing script stops the rest.
+func (h *Hook) OnEvent(event plugin.Event, vars map[string]string) error {
+	scripts, err := h.loadConfig()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrConfigReadError, err)
+	}
+
+	for i, script := range scripts {
+		if len(script.Events) > 0 && !slices.Contains(script.Events, string(event)) {
+			continue
+		}
+		captured, err := h.Run(script, event, vars)
+		if err != nil {
+			return fmt.Errorf("hooks.scripts[%d]: %w", i, err)
+		}
+		maps.Copy(vars, captured)
+		h.mu.Lock()
+		maps.Copy(h.captured, captured)
+		h.mu.Unlock()
+	}
+	return nil
+}
+
+// GetTemplateVariables returns the variables scripts have captured so far
+func (h *Hook) GetTemplateVariables(context map[string]string) map[string]string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return maps.Clone(h.captured)
+}
+
+// Run runs one script with vars in its environment and returns the
+// NAME=value lines it printed
+func (h *Hook) Run(script config.ScriptHookConfig, event plugin.Event, vars map[string]string) (map[string]string, error) {
+	timeout := script.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd := platform.ShellCommand(ctx, script.Run)
+	cmd.Env = append(os.Environ(), Environment(event, vars)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = h.stderr
+	// Children of a killed shell may hold stdout open; stop waiting for them
+	cmd.WaitDelay = time.Second
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%s: %q after %s", ErrScriptTimeout, script.Run, timeout)
+		}
+		return nil, fmt.Errorf("%s: %q: %w", ErrScriptFailed, script.Run, err)
+	}
+	return ParseCaptured(stdout.String()), nil
+}
+
+// Environment returns vars as VERSIONATOR_<NAME>=value entries, sorted, with
+// VERSIONATOR_EVENT holding the event
+func Environment(event plugin.Event, vars map[string]string) []string {
+	env := make([]string, 0, len(vars)+1)
+	for _, name := range slices.Sorted(maps.Keys(vars)) {
+		env = append(env, EnvPrefix+EnvName(name)+"="+vars[name])
+	}
+	return append(env, EnvPrefix+"EVENT="+string(event))
+}
+
+// EnvName converts a template variable name to upper snake case:
+// MajorMinorPatch -> MAJOR_MINOR_PATCH, BuildDateTimeUTC ->
+// BUILD_DATE_TIME_UTC, Pep440Version -> PEP440_VERSION
+func EnvName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			b.WriteByte('_')
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// ParseCaptured returns the NAME=value lines in output; other lines are
+// ignored, so scripts may also print progress
+func ParseCaptured(output string) map[string]string {
+	captured := map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		if m := capturePattern.FindStringSubmatch(strings.TrimRight(line, "\r")); m != nil {
+			captured[m[1]] = m[2]
+		}
+	}
+	return captured
+}
+
+// Auto-registration as a hook and template provider plugin
+func init() {
+	plugin.Register(NewHookDefault())
+}
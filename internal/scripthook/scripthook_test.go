@@ -0,0 +1,99 @@
+package scripthook
+
+import (
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+)
+
+// staticLoader returns a ConfigLoader serving the given scripts
+func staticLoader(scripts ...config.ScriptHookConfig) ConfigLoader {
+	return func() ([]config.ScriptHookConfig, error) {
+		return scripts, nil
+	}
+}
+
+// TestEnvName_TemplateVariables_UpperSnakeCase validates environment names.
+//
+// Why: Scripts read variables by a predictable name; acronyms and digits
+// must not split into unreadable pieces.
+//
+// What: Camel case splits at word boundaries, acronyms stay together, and
+// digits stay with the preceding word.
+func TestEnvName_TemplateVariables_UpperSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Major":            "MAJOR",
+		"MajorMinorPatch":  "MAJOR_MINOR_PATCH",
+		"ShortHash":        "SHORT_HASH",
+		"BuildDateTimeUTC": "BUILD_DATE_TIME_UTC",
+		"Pep440Version":    "PEP440_VERSION",
+		"Custom-Name":      "CUSTOM_NAME",
+	}
+	for name, want := range tests {
+		// Action: Convert
+		got := EnvName(name)
+
+		// Expected: Upper snake case
+		if got != want {
+			t.Errorf("EnvName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+// TestOnEvent_Scripts_SeeVariablesAndCaptureOutput validates script hooks.
+//
+// Why: Scripts that upload artifacts or compute values need the version in
+// their environment and must hand results (e.g. a download URL) to the steps
+// that follow, such as a webhook payload.
+//
+// What: A script sees VERSIONATOR_* variables and the event; NAME=value lines
+// it prints are added to the variables the next script and later hooks see
+// and are offered as template variables; other lines are ignored; scripts
+// for other events do not run; a failing or slow script is an error.
+func TestOnEvent_Scripts_SeeVariablesAndCaptureOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("scripts use sh syntax")
+	}
+
+	// Precondition: A capturing script, a consumer, and a bump-only script
+	h := NewHook(staticLoader(
+		config.ScriptHookConfig{Run: `echo "uploading"; echo "DownloadURL=https://dl.example/$VERSIONATOR_MAJOR_MINOR_PATCH/$VERSIONATOR_EVENT"`},
+		config.ScriptHookConfig{Run: `echo "Seen=$VERSIONATOR_DOWNLOAD_URL"`},
+		config.ScriptHookConfig{Run: `echo "Bumped=yes"`, Events: []string{"bump"}},
+	), io.Discard)
+	vars := map[string]string{"MajorMinorPatch": "1.2.3"}
+
+	// Action: Fire the tag event
+	err := h.OnEvent(plugin.EventTag, vars)
+
+	// Expected: Values captured, chained, and exposed; bump script skipped
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vars["DownloadURL"] != "https://dl.example/1.2.3/tag" || vars["Seen"] != vars["DownloadURL"] {
+		t.Errorf("unexpected vars: %v", vars)
+	}
+	if _, ran := vars["Bumped"]; ran {
+		t.Error("bump-only script ran on tag")
+	}
+	if got := h.GetTemplateVariables(nil); got["DownloadURL"] != vars["DownloadURL"] || len(got) != 2 {
+		t.Errorf("unexpected template variables: %v", got)
+	}
+
+	// Action: A failing and a slow script
+	_, failErr := h.Run(config.ScriptHookConfig{Run: "exit 3"}, plugin.EventTag, nil)
+	_, slowErr := h.Run(config.ScriptHookConfig{Run: "sleep 5", Timeout: 50 * time.Millisecond}, plugin.EventTag, nil)
+
+	// Expected: Both reported
+	if failErr == nil || !strings.Contains(failErr.Error(), ErrScriptFailed) {
+		t.Errorf("expected %q, got %v", ErrScriptFailed, failErr)
+	}
+	if slowErr == nil || !strings.Contains(slowErr.Error(), ErrScriptTimeout) {
+		t.Errorf("expected %q, got %v", ErrScriptTimeout, slowErr)
+	}
+}
@@ -0,0 +1,15 @@
+// Package serve messages - error message constants
+// Exported so tests can compare against them
+package serve
+
+// Error messages
+const (
+	ErrUnauthorized     = "missing or invalid bearer token"
+	ErrInvalidRequest   = "invalid bump request"
+	ErrLevelNotAllowed  = "bump level not allowed"
+	ErrActorRequired    = "actor is required"
+	ErrActorNotAllowed  = "actor not allowed to bump"
+	ErrReasonRequired   = "reason is required"
+	ErrBranchNotAllowed = "bumps are not allowed on this branch"
+	ErrBumpInProgress   = "another bump is in progress"
+)
@@ -0,0 +1,186 @@
+// Package serve is the HTTP side of `versionator serve`: an authenticated
+// webhook endpoint that accepts bump requests from chat-ops bots and CI,
+// checks them against a policy, and has the version bumped and tagged.
+//
+//	POST /bump
+//	Authorization: Bearer <token>
+//	{"level": "minor", "actor": "alice", "reason": "weekly release"}
+//
+//	200 {"previous": "1.2.0", "version": "1.3.0", "tag": "v1.3.0"}
+//
+// The bump itself is supplied by the caller (Handler.Bump), so the endpoint
+// applies exactly what the CLI would.
+package serve
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/benjaminabbitt/versionator/internal/branch"
+)
+
+// BumpPath is the endpoint accepting bump requests
+const BumpPath = "/bump"
+
+// maxRequestBytes bounds a request body; bump requests are a few fields
+const maxRequestBytes = 64 << 10
+
+// DefaultLevels are the levels a bump may request when the policy names none
+var DefaultLevels = []string{"major", "minor", "patch"}
+
+// BumpRequest is the body of a bump request
+type BumpRequest struct {
+	// Level is the version level to increment (e.g. "minor")
+	Level string `json:"level"`
+	// Actor identifies who asked for the bump (e.g. a chat user name)
+	Actor string `json:"actor"`
+	// Reason says why; recorded in the tag annotation
+	Reason string `json:"reason,omitempty"`
+}
+
+// BumpResult is the body of a successful bump response
+type BumpResult struct {
+	Previous string `json:"previous"`
+	Version  string `json:"version"`
+	Tag      string `json:"tag"`
+}
+
+// errorResponse is the body of a failed request
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Policy decides which bump requests are applied
+type Policy struct {
+	// Levels a request may name; empty allows DefaultLevels
+	Levels []string
+	// Actors allowed to bump; empty allows any actor holding the token
+	Actors []string
+	// Branches (glob patterns) the repository must be on; empty allows any
+	Branches []string
+	// RequireReason rejects requests without a reason
+	RequireReason bool
+}
+
+// Check returns why req is not allowed on the current branch, or nil
+func (p Policy) Check(req BumpRequest, currentBranch string) error {
+	levels := p.Levels
+	if len(levels) == 0 {
+		levels = DefaultLevels
+	}
+	if !slices.Contains(levels, req.Level) {
+		return fmt.Errorf("%s: %q (allowed: %s)", ErrLevelNotAllowed, req.Level, strings.Join(levels, ", "))
+	}
+	if req.Actor == "" {
+		return errors.New(ErrActorRequired)
+	}
+	if len(p.Actors) > 0 && !slices.Contains(p.Actors, req.Actor) {
+		return fmt.Errorf("%s: %q", ErrActorNotAllowed, req.Actor)
+	}
+	if p.RequireReason && req.Reason == "" {
+		return errors.New(ErrReasonRequired)
+	}
+	if len(p.Branches) > 0 && !branch.IsMainBranch(currentBranch, p.Branches) {
+		return fmt.Errorf("%s: %q (allowed: %s)", ErrBranchNotAllowed, currentBranch, strings.Join(p.Branches, ", "))
+	}
+	return nil
+}
+
+// Handler serves bump requests
+type Handler struct {
+	// Token authenticates requests as "Authorization: Bearer <Token>"
+	Token string
+	// Policy is checked before each bump
+	Policy Policy
+	// Branch returns the repository's current branch for the policy
+	Branch func() (string, error)
+	// Bump increments the version and tags it
+	Bump func(req BumpRequest) (BumpResult, error)
+
+	// mu serializes bumps; a request arriving during one is refused
+	mu sync.Mutex
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != BumpPath {
+		writeError(w, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+		return
+	}
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	var req BumpRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("%s: %v", ErrInvalidRequest, err))
+		return
+	}
+	req.Level = strings.ToLower(strings.TrimSpace(req.Level))
+	req.Actor = singleLine(req.Actor)
+	req.Reason = singleLine(req.Reason)
+
+	if !h.mu.TryLock() {
+		writeError(w, http.StatusConflict, ErrBumpInProgress)
+		return
+	}
+	defer h.mu.Unlock()
+
+	currentBranch := ""
+	if h.Branch != nil {
+		var err error
+		if currentBranch, err = h.Branch(); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	if err := h.Policy.Check(req, currentBranch); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	result, err := h.Bump(req)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// authorized reports whether r carries the bearer token, compared in
+// constant time
+func (h *Handler) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && h.Token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.Token)) == 1
+}
+
+// singleLine collapses whitespace, so request fields cannot add lines (or
+// trailers) to the tag annotation they are recorded in
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
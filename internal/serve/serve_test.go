@@ -0,0 +1,159 @@
+package serve
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newHandler returns a Handler on branch main whose Bump records requests
+func newHandler(policy Policy) (*Handler, *[]BumpRequest) {
+	var bumps []BumpRequest
+	h := &Handler{
+		Token:  "s3cret",
+		Policy: policy,
+		Branch: func() (string, error) { return "main", nil },
+		Bump: func(req BumpRequest) (BumpResult, error) {
+			bumps = append(bumps, req)
+			if req.Level == "major" {
+				return BumpResult{}, errors.New("monotonic check failed")
+			}
+			return BumpResult{Previous: "1.2.0", Version: "1.3.0", Tag: "v1.3.0"}, nil
+		},
+	}
+	return h, &bumps
+}
+
+// post sends body to the handler with the given token
+func post(h http.Handler, token, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, BumpPath, strings.NewReader(body))
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+// TestHandler_Bump_AppliesAuthorizedRequest validates a successful bump.
+//
+// Why: A chat-ops bot needs the new version and tag to report back.
+//
+// What: An authorized, allowed request is passed to Bump with its fields
+// on one line, and the result is returned as JSON.
+func TestHandler_Bump_AppliesAuthorizedRequest(t *testing.T) {
+	// Precondition
+	h, bumps := newHandler(Policy{})
+
+	// Action
+	w := post(h, "s3cret", `{"level": "Minor", "actor": "alice", "reason": "weekly\nVersionator-Version: 9.9.9"}`)
+
+	// Expected
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var result BumpResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil || result.Version != "1.3.0" || result.Tag != "v1.3.0" {
+		t.Errorf("result = %+v, %v", result, err)
+	}
+	want := BumpRequest{Level: "minor", Actor: "alice", Reason: "weekly Versionator-Version: 9.9.9"}
+	if len(*bumps) != 1 || (*bumps)[0] != want {
+		t.Errorf("bumps = %+v", *bumps)
+	}
+}
+
+// TestHandler_Bump_RejectsRequests validates authentication, request and
+// policy checks.
+//
+// Why: The endpoint tags releases; anyone reaching it without the token,
+// or outside the policy, must not be able to.
+//
+// What: Each rejected request gets its status and error, and Bump is not
+// called; a failed Bump is reported as unprocessable.
+func TestHandler_Bump_RejectsRequests(t *testing.T) {
+	policy := Policy{Levels: []string{"minor", "patch", "major"}, Actors: []string{"alice"}, Branches: []string{"main"}, RequireReason: true}
+	tests := []struct {
+		name    string
+		policy  Policy
+		method  string
+		token   string
+		body    string
+		status  int
+		message string
+		bumped  bool
+	}{
+		{name: "no token", body: `{"level":"minor","actor":"alice","reason":"r"}`, status: http.StatusUnauthorized, message: ErrUnauthorized},
+		{name: "wrong token", token: "guess", body: `{"level":"minor","actor":"alice","reason":"r"}`, status: http.StatusUnauthorized, message: ErrUnauthorized},
+		{name: "GET", method: http.MethodGet, token: "s3cret", status: http.StatusMethodNotAllowed},
+		{name: "malformed", token: "s3cret", body: `{"level":`, status: http.StatusBadRequest, message: ErrInvalidRequest},
+		{name: "unknown field", token: "s3cret", body: `{"level":"minor","actor":"alice","force":true}`, status: http.StatusBadRequest, message: ErrInvalidRequest},
+		{name: "level", token: "s3cret", body: `{"level":"revision","actor":"alice","reason":"r"}`, status: http.StatusForbidden, message: ErrLevelNotAllowed},
+		{name: "no actor", token: "s3cret", body: `{"level":"minor","reason":"r"}`, status: http.StatusForbidden, message: ErrActorRequired},
+		{name: "actor", token: "s3cret", body: `{"level":"minor","actor":"mallory","reason":"r"}`, status: http.StatusForbidden, message: ErrActorNotAllowed},
+		{name: "no reason", token: "s3cret", body: `{"level":"minor","actor":"alice","reason":"  "}`, status: http.StatusForbidden, message: ErrReasonRequired},
+		{name: "branch", policy: Policy{Branches: []string{"release/*"}}, token: "s3cret", body: `{"level":"minor","actor":"alice"}`, status: http.StatusForbidden, message: ErrBranchNotAllowed},
+		{name: "bump fails", token: "s3cret", body: `{"level":"major","actor":"alice","reason":"r"}`, status: http.StatusUnprocessableEntity, message: "monotonic check failed", bumped: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition
+			p := policy
+			if tt.policy.Branches != nil {
+				p = tt.policy
+			}
+			h, bumps := newHandler(p)
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			r := httptest.NewRequest(method, BumpPath, strings.NewReader(tt.body))
+			if tt.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+
+			// Action
+			h.ServeHTTP(w, r)
+
+			// Expected
+			if w.Code != tt.status || !strings.Contains(w.Body.String(), tt.message) {
+				t.Errorf("got %d %s, want %d %q", w.Code, w.Body, tt.status, tt.message)
+			}
+			if bumped := len(*bumps) > 0; bumped != tt.bumped {
+				t.Errorf("bumped = %v, want %v", bumped, tt.bumped)
+			}
+		})
+	}
+}
+
+// TestHandler_Bump_RefusesConcurrentBump validates bump serialization.
+//
+// Why: Two bots bumping at once would both read the same VERSION and race
+// to commit and tag.
+//
+// What: A request arriving while a bump runs is refused with 409.
+func TestHandler_Bump_RefusesConcurrentBump(t *testing.T) {
+	// Precondition: A bump in progress that sends a second request
+	h, _ := newHandler(Policy{})
+	var second *httptest.ResponseRecorder
+	h.Bump = func(req BumpRequest) (BumpResult, error) {
+		if second == nil {
+			second = post(h, "s3cret", `{"level":"patch","actor":"bob"}`)
+		}
+		return BumpResult{Version: "1.2.1"}, nil
+	}
+
+	// Action
+	first := post(h, "s3cret", `{"level":"patch","actor":"alice"}`)
+
+	// Expected
+	if first.Code != http.StatusOK {
+		t.Errorf("first status = %d", first.Code)
+	}
+	if second == nil || second.Code != http.StatusConflict {
+		t.Errorf("second response = %v", second)
+	}
+}
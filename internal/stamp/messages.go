@@ -0,0 +1,14 @@
+// Package stamp messages - error message constants
+// Exported so tests can compare against them
+package stamp
+
+// Error messages
+const (
+	ErrNotGoBinary     = "not a Go binary with build information"
+	ErrVersionMismatch = "binary version does not match"
+	ErrNoVersion       = "no version embedded in the binary"
+	ErrCommitMismatch  = "binary was built from another commit"
+	ErrNoRevision      = "no commit recorded in the binary (built with -buildvcs=false or outside a repository?)"
+	ErrDirty           = "binary was built from a tree with uncommitted changes"
+	ErrDirtyUnknown    = "binary does not record whether its tree was clean"
+)
@@ -0,0 +1,259 @@
+// Package stamp checks the build information the Go toolchain embeds in
+// binaries against the release being shipped, as a post-build QA gate: a
+// binary built from a dirty tree, from another commit, or carrying another
+// version is caught before it is published.
+package stamp
+
+import (
+	"bytes"
+	"debug/buildinfo"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+	"unicode"
+)
+
+// Source names where an embedded version was found
+const (
+	SourceModule  = "module"  // main module version (go install, Go 1.24+ VCS stamping)
+	SourceLdflags = "ldflags" // -X <pkg>.<name>Version=... linker flag
+	SourceStrings = "strings" // literal in the binary (e.g. an emitted version.go)
+)
+
+// Embedded is a version found in a binary
+type Embedded struct {
+	Source string
+	// Name is the -X variable for ldflags versions
+	Name  string
+	Value string
+}
+
+// Info is the build information read from a Go binary
+type Info struct {
+	Path      string
+	GoVersion string
+	// Module is the main module path
+	Module   string
+	Revision string
+	// Modified reports uncommitted changes at build time; nil when the
+	// binary does not record it
+	Modified *bool
+	// Versions lists the versions embedded by the toolchain or linker flags
+	Versions []Embedded
+}
+
+// Read reads the build information of the Go binary at path
+func Read(path string) (Info, error) {
+	bi, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return Info{}, fmt.Errorf("%s: %s: %w", ErrNotGoBinary, path, err)
+	}
+	info := fromBuildInfo(bi)
+	info.Path = path
+	return info, nil
+}
+
+// fromBuildInfo extracts the fields checked by Verify. A -X linker flag
+// whose variable ends in "commit" stands in for a missing vcs.revision.
+func fromBuildInfo(bi *debug.BuildInfo) Info {
+	info := Info{GoVersion: bi.GoVersion, Module: bi.Main.Path}
+	if v := bi.Main.Version; v != "" && v != "(devel)" {
+		info.Versions = append(info.Versions, Embedded{Source: SourceModule, Value: v})
+	}
+	var ldflagsCommit string
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.Revision = s.Value
+		case "vcs.modified":
+			modified := s.Value == "true"
+			info.Modified = &modified
+		case "-ldflags":
+			for _, v := range linkerVariables(s.Value) {
+				lower := strings.ToLower(v.Name[strings.LastIndex(v.Name, ".")+1:])
+				switch {
+				case strings.HasSuffix(lower, "version"):
+					info.Versions = append(info.Versions, Embedded{Source: SourceLdflags, Name: v.Name, Value: v.Value})
+				case strings.HasSuffix(lower, "commit") && ldflagsCommit == "":
+					ldflagsCommit = v.Value
+				}
+			}
+		}
+	}
+	if info.Revision == "" {
+		info.Revision = ldflagsCommit
+	}
+	return info
+}
+
+// linkerVariable is an -X name=value linker flag
+type linkerVariable struct {
+	Name, Value string
+}
+
+// linkerVariables returns the -X assignments of an -ldflags value
+func linkerVariables(ldflags string) []linkerVariable {
+	var vars []linkerVariable
+	fields := strings.Fields(ldflags)
+	for i := 0; i < len(fields); i++ {
+		var assignment string
+		switch {
+		case fields[i] == "-X" && i+1 < len(fields):
+			i++
+			assignment = fields[i]
+		case strings.HasPrefix(fields[i], "-X="):
+			assignment = strings.TrimPrefix(fields[i], "-X=")
+		default:
+			continue
+		}
+		if name, value, ok := strings.Cut(strings.Trim(assignment, `'"`), "="); ok {
+			vars = append(vars, linkerVariable{Name: name, Value: value})
+		}
+	}
+	return vars
+}
+
+// Expect is what a release binary must carry; empty fields are not checked
+type Expect struct {
+	// Version, without prefix
+	Version string
+	// Commit is the full or abbreviated revision
+	Commit     string
+	AllowDirty bool
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Check  string
+	OK     bool
+	Detail string
+}
+
+// Verify checks info against expect. contains reports whether the binary
+// holds a string literal, for versions compiled in rather than linked in;
+// it may be nil.
+func Verify(info Info, expect Expect, contains func(string) bool) []Result {
+	var results []Result
+	if expect.Version != "" {
+		results = append(results, verifyVersion(info, expect.Version, contains))
+	}
+	if expect.Commit != "" {
+		results = append(results, verifyCommit(info, expect.Commit))
+	}
+	if !expect.AllowDirty {
+		results = append(results, verifyClean(info))
+	}
+	return results
+}
+
+// verifyVersion passes when any embedded version, or failing that a
+// literal in the binary, equals want
+func verifyVersion(info Info, want string, contains func(string) bool) Result {
+	r := Result{Check: "Version"}
+	for _, e := range info.Versions {
+		if sameVersion(e.Value, want) {
+			r.OK, r.Detail = true, fmt.Sprintf("%s (%s)", e.Value, describe(e))
+			return r
+		}
+	}
+	if contains != nil && contains(want) {
+		r.OK, r.Detail = true, fmt.Sprintf("%s (%s)", want, SourceStrings)
+		return r
+	}
+	if len(info.Versions) == 0 {
+		r.Detail = fmt.Sprintf("%s: expected %s", ErrNoVersion, want)
+		return r
+	}
+	found := make([]string, len(info.Versions))
+	for i, e := range info.Versions {
+		found[i] = fmt.Sprintf("%s (%s)", e.Value, describe(e))
+	}
+	r.Detail = fmt.Sprintf("%s: expected %s, found %s", ErrVersionMismatch, want, strings.Join(found, ", "))
+	return r
+}
+
+// describe names the source of an embedded version
+func describe(e Embedded) string {
+	if e.Name != "" {
+		return e.Source + " " + e.Name
+	}
+	return e.Source
+}
+
+// sameVersion compares versions ignoring a prefix (v, release-, ...) and
+// the +dirty suffix Go adds to module versions of modified trees, which
+// the clean check reports on its own
+func sameVersion(embedded, want string) bool {
+	strip := func(s string) string {
+		return strings.TrimLeftFunc(s, func(r rune) bool { return !unicode.IsDigit(r) })
+	}
+	embedded = strings.TrimSuffix(embedded, "+dirty")
+	return strip(embedded) == strip(want)
+}
+
+// verifyCommit passes when the recorded revision and want agree, either
+// being an abbreviation of the other
+func verifyCommit(info Info, want string) Result {
+	r := Result{Check: "Commit"}
+	if info.Revision == "" {
+		r.Detail = ErrNoRevision
+		return r
+	}
+	got, want := strings.ToLower(info.Revision), strings.ToLower(want)
+	if strings.HasPrefix(got, want) || strings.HasPrefix(want, got) {
+		r.OK, r.Detail = true, info.Revision
+		return r
+	}
+	r.Detail = fmt.Sprintf("%s: expected %s, found %s", ErrCommitMismatch, want, info.Revision)
+	return r
+}
+
+// verifyClean passes when the binary records a clean tree
+func verifyClean(info Info) Result {
+	r := Result{Check: "Clean"}
+	switch {
+	case info.Modified == nil:
+		r.Detail = ErrDirtyUnknown
+	case *info.Modified:
+		r.Detail = ErrDirty
+	default:
+		r.OK, r.Detail = true, "no uncommitted changes"
+	}
+	return r
+}
+
+// FileContains returns a function reporting whether the file at path holds
+// a string as a literal, not as part of a longer version (1.2.3 does not
+// match 1.2.30 or 1.2.3-rc.1)
+func FileContains(path string) (func(string) bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return func(s string) bool {
+		return containsVersion(data, []byte(s))
+	}, nil
+}
+
+// containsVersion reports whether data holds v with no version character
+// directly before or after it
+func containsVersion(data, v []byte) bool {
+	if len(v) == 0 {
+		return false
+	}
+	digitOrDot := func(b byte) bool { return b >= '0' && b <= '9' || b == '.' }
+	for offset := 0; ; {
+		i := bytes.Index(data[offset:], v)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(v)
+		before := start == 0 || !digitOrDot(data[start-1])
+		after := end == len(data) || !(digitOrDot(data[end]) || data[end] == '-' || data[end] == '+')
+		if before && after {
+			return true
+		}
+		offset = start + 1
+	}
+}
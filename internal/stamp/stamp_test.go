@@ -0,0 +1,140 @@
+package stamp
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFromBuildInfo_ReadsVCSAndLdflags validates build information parsing.
+//
+// Why: Release builds record their version in different places: the module
+// version, or one of many -X variable names.
+//
+// What: The module version and every -X ...Version value are collected;
+// vcs.revision and vcs.modified are read, an -X ...Commit value standing in
+// for a missing revision.
+func TestFromBuildInfo_ReadsVCSAndLdflags(t *testing.T) {
+	// Precondition
+	bi := &debug.BuildInfo{
+		GoVersion: "go1.25.1",
+		Main:      debug.Module{Path: "example.com/app", Version: "v1.2.3"},
+		Settings: []debug.BuildSetting{
+			{Key: "-ldflags", Value: `-s -X main.VERSION=1.2.3 -X=example.com/app/internal/buildinfo.AppVersion=v1.2.3 -X 'main.GitCommit=abc1234'`},
+			{Key: "vcs.modified", Value: "false"},
+		},
+	}
+
+	// Action
+	info := fromBuildInfo(bi)
+
+	// Expected
+	assert.Equal(t, "example.com/app", info.Module)
+	assert.Equal(t, []Embedded{
+		{Source: SourceModule, Value: "v1.2.3"},
+		{Source: SourceLdflags, Name: "main.VERSION", Value: "1.2.3"},
+		{Source: SourceLdflags, Name: "example.com/app/internal/buildinfo.AppVersion", Value: "v1.2.3"},
+	}, info.Versions)
+	assert.Equal(t, "abc1234", info.Revision)
+	require.NotNil(t, info.Modified)
+	assert.False(t, *info.Modified)
+}
+
+// TestVerify_Checks validates the release checks.
+//
+// Why: The gate must catch a binary built from a dirty tree, another
+// commit, or another version, and only those.
+//
+// What: Each check passes or fails with its message; prefixes, the +dirty
+// module suffix, and abbreviated commits still match.
+func TestVerify_Checks(t *testing.T) {
+	clean, dirty := false, true
+	built := Info{
+		Revision: "4f2a9c1e0b7d3a5c8e6f2b1d9a7c4e3f5b8d0a2c",
+		Modified: &clean,
+		Versions: []Embedded{{Source: SourceModule, Value: "v1.2.3"}},
+	}
+	tests := []struct {
+		name    string
+		info    Info
+		expect  Expect
+		wantErr string
+	}{
+		{name: "matching release", info: built, expect: Expect{Version: "1.2.3", Commit: "4f2a9c1"}},
+		{name: "other version", info: built, expect: Expect{Version: "1.2.4"}, wantErr: ErrVersionMismatch},
+		{name: "no version", info: Info{Modified: &clean}, expect: Expect{Version: "1.2.3"}, wantErr: ErrNoVersion},
+		{name: "other commit", info: built, expect: Expect{Commit: "0123456"}, wantErr: ErrCommitMismatch},
+		{name: "no revision", info: Info{Modified: &clean}, expect: Expect{Commit: "4f2a9c1"}, wantErr: ErrNoRevision},
+		{name: "dirty tree", info: Info{Modified: &dirty, Versions: []Embedded{{Source: SourceModule, Value: "v1.2.3+dirty"}}}, expect: Expect{Version: "1.2.3"}, wantErr: ErrDirty},
+		{name: "dirty tree allowed", info: Info{Modified: &dirty}, expect: Expect{AllowDirty: true}},
+		{name: "clean state unknown", info: Info{}, wantErr: ErrDirtyUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action
+			results := Verify(tt.info, tt.expect, nil)
+
+			// Expected: Only the named check fails
+			var failures []string
+			for _, r := range results {
+				if !r.OK {
+					failures = append(failures, r.Detail)
+				}
+			}
+			if tt.wantErr == "" {
+				assert.Empty(t, failures)
+				return
+			}
+			require.Len(t, failures, 1)
+			assert.Contains(t, failures[0], tt.wantErr)
+		})
+	}
+}
+
+// TestVerify_VersionLiteral validates the fallback to compiled-in literals.
+//
+// Why: Versions emitted as Go constants appear in neither the module
+// version nor the linker flags.
+//
+// What: A literal found in the binary passes the version check; a longer
+// version containing it does not.
+func TestVerify_VersionLiteral(t *testing.T) {
+	// Precondition
+	data := []byte("\x00app-1.2.30\x00Version1.2.3-rc.1\x00")
+	contains := func(s string) bool { return containsVersion(data, []byte(s)) }
+
+	// Action / Expected
+	assert.False(t, Verify(Info{}, Expect{Version: "1.2.3", AllowDirty: true}, contains)[0].OK)
+	data = append(data, "version 1.2.3\x00"...)
+	assert.True(t, Verify(Info{}, Expect{Version: "1.2.3", AllowDirty: true}, contains)[0].OK)
+}
+
+// TestRead_GoAndOtherFiles validates reading binaries.
+//
+// Why: Pointing the gate at the wrong file must fail clearly, not pass.
+//
+// What: The running test binary reads with its Go version; a text file is
+// rejected as not a Go binary.
+func TestRead_GoAndOtherFiles(t *testing.T) {
+	// Precondition
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	text := filepath.Join(t.TempDir(), "app")
+	require.NoError(t, os.WriteFile(text, []byte("#!/bin/sh\n"), 0644))
+
+	// Action
+	info, err := Read(exe)
+	_, textErr := Read(text)
+
+	// Expected
+	require.NoError(t, err)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	require.Error(t, textErr)
+	assert.True(t, strings.HasPrefix(textErr.Error(), ErrNotGoBinary))
+}
@@ -0,0 +1,8 @@
+// Package tagformat messages - error message constants
+// Exported so tests can compare against them
+package tagformat
+
+// Error messages
+const (
+	ErrInvalidFormat = "tag format must contain {{Version}} exactly once"
+)
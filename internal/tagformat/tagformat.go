@@ -0,0 +1,200 @@
+// Package tagformat maps versions to release tag names and back, for
+// repositories whose tags are not bare versions: "myapp-v{{Version}}" in a
+// monorepo, or "release/{{Version}}".
+//
+// Without a configured format, tags are the prefix followed by the version
+// (v1.2.3) and every tag is a candidate version.
+//
+// A namespace (release.tagNamespace) further confines versionator to the
+// tags under refs/tags/<namespace>/, so teams sharing a repository neither
+// see nor create each other's tags.
+package tagformat
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Placeholder marks where the version goes in a tag format
+const Placeholder = "{{Version}}"
+
+// Format is a parsed tag format: the text around the version
+type Format struct {
+	before string
+	after  string
+}
+
+// Parse parses a tag format such as "myapp-v{{Version}}"
+func Parse(template string) (Format, error) {
+	if strings.Count(template, Placeholder) != 1 {
+		return Format{}, fmt.Errorf("%s, got %q", ErrInvalidFormat, template)
+	}
+	before, after, _ := strings.Cut(template, Placeholder)
+	return Format{before: before, after: after}, nil
+}
+
+// Name returns the tag for version
+func (f Format) Name(version string) string {
+	return f.before + version + f.after
+}
+
+// Version returns the version part of tag, or false when tag does not
+// follow the format
+func (f Format) Version(tag string) (string, bool) {
+	if len(tag) <= len(f.before)+len(f.after) ||
+		!strings.HasPrefix(tag, f.before) || !strings.HasSuffix(tag, f.after) {
+		return "", false
+	}
+	return tag[len(f.before) : len(tag)-len(f.after)], true
+}
+
+// Glob returns a git tag pattern matching the tags of the format
+func (f Format) Glob() string {
+	return f.before + "*" + f.after
+}
+
+// Scope is a tag format and namespace. The package functions use the
+// configured one (Current); callers that must not depend on it, such as
+// the Go API reading several workspaces, build their own with NewScope.
+type Scope struct {
+	format    *Format // nil uses prefixed versions
+	namespace string  // with its trailing slash; empty when every tag is visible
+}
+
+// NewScope builds a Scope from release.tagFormat and release.tagNamespace;
+// empty values mean prefixed versions and every tag
+func NewScope(template, ns string) (Scope, error) {
+	var s Scope
+	if template != "" {
+		f, err := Parse(template)
+		if err != nil {
+			return Scope{}, err
+		}
+		s.format = &f
+	}
+	if ns = strings.Trim(ns, "/"); ns != "" {
+		s.namespace = ns + "/"
+	}
+	return s, nil
+}
+
+// Namespace returns the namespace with its trailing slash, or empty
+func (s Scope) Namespace() string {
+	return s.namespace
+}
+
+// InNamespace reports whether tag lies in the namespace; every tag does
+// without one
+func (s Scope) InNamespace(tag string) bool {
+	return strings.HasPrefix(tag, s.namespace)
+}
+
+// Namespaced returns name inside the namespace, for tags named outside the
+// format such as nightly tags
+func (s Scope) Namespaced(name string) string {
+	return s.namespace + name
+}
+
+// Name returns the tag for version: the format, or prefix followed by the
+// version
+func (s Scope) Name(prefix, version string) string {
+	if s.format != nil {
+		return s.Namespaced(s.format.Name(version))
+	}
+	return s.Namespaced(prefix + version)
+}
+
+// Version returns the version part of tag under the format, or false when
+// tag does not follow it or lies outside the namespace. Without a format
+// the whole tag (less the namespace) is returned, for the version parser to
+// accept or reject.
+func (s Scope) Version(tag string) (string, bool) {
+	if !s.InNamespace(tag) {
+		return "", false
+	}
+	tag = tag[len(s.namespace):]
+	if s.format != nil {
+		return s.format.Version(tag)
+	}
+	return tag, true
+}
+
+// Glob returns a git tag pattern for the format and namespace, or empty
+// when every tag is a candidate
+func (s Scope) Glob() string {
+	if s.format != nil {
+		return s.namespace + s.format.Glob()
+	}
+	if s.namespace != "" {
+		return s.namespace + "*"
+	}
+	return ""
+}
+
+// current is the configured scope
+var current atomic.Pointer[Scope]
+
+// Current returns the configured tag format and namespace
+func Current() Scope {
+	if s := current.Load(); s != nil {
+		return *s
+	}
+	return Scope{}
+}
+
+// Set configures the tag format from release.tagFormat; empty restores
+// prefixed versions
+func Set(template string) error {
+	s, err := NewScope(template, "")
+	if err != nil {
+		return err
+	}
+	s.namespace = Current().namespace
+	current.Store(&s)
+	return nil
+}
+
+// SetNamespace confines tags to release.tagNamespace (e.g. "team-a" for
+// refs/tags/team-a/*); empty makes every tag visible again
+func SetNamespace(ns string) {
+	s, _ := NewScope("", ns)
+	s.format = Current().format
+	current.Store(&s)
+}
+
+// Namespace returns the configured namespace with its trailing slash, or
+// empty
+func Namespace() string {
+	return Current().Namespace()
+}
+
+// InNamespace reports whether tag lies in the configured namespace; every
+// tag does without one
+func InNamespace(tag string) bool {
+	return Current().InNamespace(tag)
+}
+
+// Namespaced returns name inside the configured namespace, for tags named
+// outside the format such as nightly tags
+func Namespaced(name string) string {
+	return Current().Namespaced(name)
+}
+
+// Name returns the tag for version: the configured format, or prefix
+// followed by the version
+func Name(prefix, version string) string {
+	return Current().Name(prefix, version)
+}
+
+// Version returns the version part of tag under the configured format, or
+// false when tag does not follow it or lies outside the namespace
+func Version(tag string) (string, bool) {
+	return Current().Version(tag)
+}
+
+// Glob returns a git tag pattern for the configured format and namespace,
+// or empty when every tag is a candidate
+func Glob() string {
+	return Current().Glob()
+}
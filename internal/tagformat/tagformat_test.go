@@ -0,0 +1,188 @@
+package tagformat
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestFormat_RoundTripsVersions validates mapping versions to tags and back.
+//
+// Why: The same format names new release tags and finds the version in
+// existing ones; a tag versionator creates must parse back to its version,
+// and another component's tags must not.
+//
+// What: For monorepo and path-style formats, Name and Version round-trip;
+// tags with a different prefix, or nothing in the version slot, are
+// rejected; Glob matches the same tags for git.
+func TestFormat_RoundTripsVersions(t *testing.T) {
+	tests := []struct {
+		template string
+		tag      string
+		glob     string
+		foreign  []string
+	}{
+		{template: "myapp-v{{Version}}", tag: "myapp-v1.2.3-rc.1", glob: "myapp-v*", foreign: []string{"other-v1.2.3", "v1.2.3", "myapp-v"}},
+		{template: "release/{{Version}}", tag: "release/1.2.3-rc.1", glob: "release/*", foreign: []string{"nightly/1.3.0", "1.2.3"}},
+		{template: "{{Version}}-final", tag: "1.2.3-rc.1-final", glob: "*-final", foreign: []string{"1.2.3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			// Precondition
+			f, err := Parse(tt.template)
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+
+			// Action
+			name := f.Name("1.2.3-rc.1")
+			version, ok := f.Version(name)
+
+			// Expected
+			if name != tt.tag {
+				t.Errorf("Name() = %q, want %q", name, tt.tag)
+			}
+			if !ok || version != "1.2.3-rc.1" {
+				t.Errorf("Version(%q) = %q, %v", name, version, ok)
+			}
+			if f.Glob() != tt.glob {
+				t.Errorf("Glob() = %q, want %q", f.Glob(), tt.glob)
+			}
+			for _, tag := range tt.foreign {
+				if v, ok := f.Version(tag); ok {
+					t.Errorf("Version(%q) = %q, want no match", tag, v)
+				}
+			}
+		})
+	}
+}
+
+// TestParse_RequiresOnePlaceholder validates format checking.
+//
+// Why: Without the placeholder every release would get the same tag; with
+// two, the version could not be read back unambiguously.
+//
+// What: Formats with zero or two placeholders are rejected.
+func TestParse_RequiresOnePlaceholder(t *testing.T) {
+	for _, template := range []string{"myapp", "{{Version}}-{{Version}}"} {
+		// Action
+		_, err := Parse(template)
+
+		// Expected
+		if err == nil || !strings.Contains(err.Error(), ErrInvalidFormat) {
+			t.Errorf("Parse(%q) error = %v, want %s", template, err, ErrInvalidFormat)
+		}
+	}
+}
+
+// TestSet_DefaultsToPrefixedVersions validates the package-level helpers.
+//
+// Why: Repositories without release.tagFormat must keep their v1.2.3 tags
+// and must keep every tag as a version candidate.
+//
+// What: Unset, Name prefixes and Version passes tags through; once set, the
+// format applies; setting empty restores the default.
+func TestSet_DefaultsToPrefixedVersions(t *testing.T) {
+	defer func() { _ = Set("") }()
+
+	// Action / Expected: Default
+	if got := Name("v", "1.2.3"); got != "v1.2.3" {
+		t.Errorf("Name() = %q, want v1.2.3", got)
+	}
+	if got, ok := Version("anything"); !ok || got != "anything" || Glob() != "" {
+		t.Errorf("Version() = %q, %v; Glob() = %q", got, ok, Glob())
+	}
+
+	// Action / Expected: Configured
+	if err := Set("myapp-v{{Version}}"); err != nil {
+		t.Fatal(err)
+	}
+	if got := Name("v", "1.2.3"); got != "myapp-v1.2.3" {
+		t.Errorf("Name() = %q, want myapp-v1.2.3", got)
+	}
+	if _, ok := Version("v1.2.3"); ok {
+		t.Error("expected v1.2.3 not to match the configured format")
+	}
+
+	// Action / Expected: Cleared
+	if err := Set(""); err != nil {
+		t.Fatal(err)
+	}
+	if got := Name("v", "1.2.3"); got != "v1.2.3" {
+		t.Errorf("Name() = %q after clearing, want v1.2.3", got)
+	}
+}
+
+// TestSetNamespace_ConfinesTags validates namespace isolation.
+//
+// Why: Teams sharing a repository must not pick up each other's releases as
+// their latest version, nor create tags outside their own namespace.
+//
+// What: With a namespace, Name places tags under it, Version only accepts
+// tags inside it, and Glob limits git to it, alone or with a format.
+func TestSetNamespace_ConfinesTags(t *testing.T) {
+	// Precondition: Namespace team-a
+	SetNamespace("team-a/")
+	defer SetNamespace("")
+	defer func() { _ = Set("") }()
+
+	// Action / Expected: Prefixed versions
+	if got := Name("v", "1.2.3"); got != "team-a/v1.2.3" {
+		t.Errorf("Name() = %q, want team-a/v1.2.3", got)
+	}
+	if got, ok := Version("team-a/v1.2.3"); !ok || got != "v1.2.3" {
+		t.Errorf("Version() = %q, %v; want v1.2.3", got, ok)
+	}
+	for _, tag := range []string{"v1.2.3", "team-b/v1.2.3", "team-ab/v1.2.3"} {
+		if _, ok := Version(tag); ok || InNamespace(tag) {
+			t.Errorf("expected %q outside the namespace", tag)
+		}
+	}
+	if Glob() != "team-a/*" {
+		t.Errorf("Glob() = %q, want team-a/*", Glob())
+	}
+
+	// Action / Expected: With a format
+	if err := Set("api-{{Version}}"); err != nil {
+		t.Fatal(err)
+	}
+	if got := Name("v", "1.2.3"); got != "team-a/api-1.2.3" {
+		t.Errorf("Name() = %q, want team-a/api-1.2.3", got)
+	}
+	if got, ok := Version("team-a/api-1.2.3"); !ok || got != "1.2.3" {
+		t.Errorf("Version() = %q, %v; want 1.2.3", got, ok)
+	}
+	if Glob() != "team-a/api-*" {
+		t.Errorf("Glob() = %q, want team-a/api-*", Glob())
+	}
+}
+
+// TestNewScope_IndependentOfConfigured validates explicit scopes.
+//
+// Why: The Go API reads workspaces with different tag formats from one
+// process; their scopes must not depend on, or change, the configured one.
+//
+// What: A scope built with NewScope maps tags under its own format and
+// namespace while the configured scope keeps prefixed versions; a template
+// without the placeholder is rejected.
+func TestNewScope_IndependentOfConfigured(t *testing.T) {
+	// Action
+	s, err := NewScope("api-{{Version}}", "/team-a/")
+
+	// Expected
+	if err != nil {
+		t.Fatalf("NewScope() error: %v", err)
+	}
+	if got, ok := s.Version("team-a/api-1.2.3"); !ok || got != "1.2.3" {
+		t.Errorf("Version() = %q, %v; want 1.2.3", got, ok)
+	}
+	if s.Glob() != "team-a/api-*" || s.Name("v", "1.2.3") != "team-a/api-1.2.3" {
+		t.Errorf("Glob() = %q, Name() = %q", s.Glob(), s.Name("v", "1.2.3"))
+	}
+	if Glob() != "" || Name("v", "1.2.3") != "v1.2.3" {
+		t.Errorf("configured scope changed: Glob() = %q, Name() = %q", Glob(), Name("v", "1.2.3"))
+	}
+	if _, err := NewScope("api", ""); err == nil {
+		t.Error("expected an error without the placeholder")
+	}
+}
@@ -0,0 +1,10 @@
+// Package testlang messages - error message constants
+// Exported so tests can compare against them
+package testlang
+
+// Error messages
+const (
+	ErrNoContainerEngine = "no container engine found"
+	ErrNoExample         = "no runnable example"
+	ErrVersionMismatch   = "example did not print the injected version"
+)
@@ -0,0 +1,214 @@
+// Package testlang runs the example projects under examples/ end to end: each
+// is built in its container with a freshly built versionator, run, and its
+// output checked for the injected version. It backs the `testlang` developer
+// command (built with -tags devtools), so a change that breaks a language's
+// emit, link, or patch integration is caught automatically.
+package testlang
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// DefaultVersion is written to each staged project's VERSION file; it is
+// unlikely to appear in an example by accident
+const DefaultVersion = "9.8.7"
+
+// containerFile is the file that marks an example as runnable
+const containerFile = "Containerfile"
+
+// CommandRunner runs name with args in dir, with extra environment entries,
+// and returns combined output
+type CommandRunner func(ctx context.Context, dir string, env []string, name string, args ...string) (string, error)
+
+// Options configures a Harness
+type Options struct {
+	// ExamplesDir holds one directory per language (default "examples")
+	ExamplesDir string
+	// SourceDir is the versionator module built into each container
+	// (default ".")
+	SourceDir string
+	// Engine is the container CLI; empty picks podman, then docker
+	Engine string
+	// Version is written to VERSION and expected in the output
+	// (default DefaultVersion)
+	Version string
+	// Log receives progress lines; nil discards them
+	Log io.Writer
+}
+
+// Result is the outcome for one language
+type Result struct {
+	Language string
+	// Output is what the example printed when run
+	Output string
+	// Err is nil when the example printed the injected version
+	Err error
+}
+
+// Harness builds and runs example projects in containers
+type Harness struct {
+	run    CommandRunner
+	opts   Options
+	binary string
+}
+
+// NewHarness creates a Harness with an injected command runner
+func NewHarness(run CommandRunner, opts Options) *Harness {
+	if opts.ExamplesDir == "" {
+		opts.ExamplesDir = "examples"
+	}
+	if opts.SourceDir == "" {
+		opts.SourceDir = "."
+	}
+	if opts.Version == "" {
+		opts.Version = DefaultVersion
+	}
+	if opts.Log == nil {
+		opts.Log = io.Discard
+	}
+	return &Harness{run: run, opts: opts}
+}
+
+// NewHarnessDefault creates a Harness that runs real commands
+func NewHarnessDefault(opts Options) *Harness {
+	return NewHarness(func(ctx context.Context, dir string, env []string, name string, args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), env...)
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		err := cmd.Run()
+		return out.String(), err
+	}, opts)
+}
+
+// Languages returns the example directories that have a Containerfile, sorted
+func (h *Harness) Languages() ([]string, error) {
+	entries, err := os.ReadDir(h.opts.ExamplesDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list examples: %w", err)
+	}
+	var languages []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(h.opts.ExamplesDir, entry.Name(), containerFile)); err == nil {
+			languages = append(languages, entry.Name())
+		}
+	}
+	slices.Sort(languages)
+	return languages, nil
+}
+
+// Run builds, runs, and checks each language in turn. The versionator binary
+// is built once and shared.
+func (h *Harness) Run(ctx context.Context, languages []string) ([]Result, error) {
+	engine, err := h.engine()
+	if err != nil {
+		return nil, err
+	}
+	work, err := os.MkdirTemp("", "versionator-testlang-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(work)
+
+	if err := h.buildBinary(ctx, work); err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(languages))
+	for _, language := range languages {
+		fmt.Fprintf(h.opts.Log, "==> %s\n", language)
+		output, err := h.runLanguage(ctx, engine, work, language)
+		results = append(results, Result{Language: language, Output: output, Err: err})
+	}
+	return results, nil
+}
+
+// engine returns the configured container CLI or the first one on PATH
+func (h *Harness) engine() (string, error) {
+	if h.opts.Engine != "" {
+		return h.opts.Engine, nil
+	}
+	for _, candidate := range []string{"podman", "docker"} {
+		if _, err := exec.LookPath(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("%s (install podman or docker, or pass --engine)", ErrNoContainerEngine)
+}
+
+// buildBinary cross-compiles versionator for Linux containers into work
+func (h *Harness) buildBinary(ctx context.Context, work string) error {
+	h.binary = filepath.Join(work, "versionator")
+	env := []string{"CGO_ENABLED=0", "GOOS=linux", "GOARCH=" + runtime.GOARCH}
+	fmt.Fprintf(h.opts.Log, "Building versionator from %s\n", h.opts.SourceDir)
+	if out, err := h.run(ctx, h.opts.SourceDir, env, "go", "build", "-o", h.binary, "."); err != nil {
+		return fmt.Errorf("failed to build versionator: %w\n%s", err, out)
+	}
+	return nil
+}
+
+// runLanguage stages a copy of the example with a known VERSION, builds its
+// image, runs `make run` with the local versionator mounted over the one in
+// the image, and checks the printed version
+func (h *Harness) runLanguage(ctx context.Context, engine, work, language string) (string, error) {
+	source := filepath.Join(h.opts.ExamplesDir, language)
+	if _, err := os.Stat(filepath.Join(source, containerFile)); err != nil {
+		return "", fmt.Errorf("%s: %s", ErrNoExample, source)
+	}
+	staged := filepath.Join(work, language)
+	if err := os.CopyFS(staged, os.DirFS(source)); err != nil {
+		return "", fmt.Errorf("failed to stage %s: %w", source, err)
+	}
+	if err := os.WriteFile(filepath.Join(staged, "VERSION"), []byte(h.opts.Version+"\n"), 0644); err != nil {
+		return "", err
+	}
+	staged, err := filepath.Abs(staged)
+	if err != nil {
+		return "", err
+	}
+
+	image := "versionator-testlang-" + language
+	if out, err := h.run(ctx, staged, nil, engine, "build", "-t", image, "-f", containerFile, "."); err != nil {
+		return out, fmt.Errorf("image build failed: %w", err)
+	}
+	output, err := h.run(ctx, staged, nil, engine, "run", "--rm",
+		"-v", staged+":/workspace",
+		"-v", h.binary+":/usr/local/bin/versionator:ro",
+		"-w", "/workspace",
+		image, "make", "run")
+	if err != nil {
+		return output, fmt.Errorf("make run failed: %w", err)
+	}
+	return output, h.check(output)
+}
+
+// check requires a "Version: <version>" line, the form every example prints
+func (h *Harness) check(output string) error {
+	want := regexp.MustCompile(`(?m)^Version: [vV]?` + regexp.QuoteMeta(h.opts.Version) + `\b`)
+	if want.MatchString(output) {
+		return nil
+	}
+	printed := "nothing"
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "Version: ") {
+			printed = fmt.Sprintf("%q", line)
+			break
+		}
+	}
+	return fmt.Errorf("%s: want Version: %s, printed %s", ErrVersionMismatch, h.opts.Version, printed)
+}
@@ -0,0 +1,110 @@
+package testlang
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeEngine records commands and answers `make run` with a per-language
+// output, reading the staged VERSION as a real example would
+type fakeEngine struct {
+	commands []string
+	printed  map[string]string
+}
+
+func (f *fakeEngine) run(ctx context.Context, dir string, env []string, name string, args ...string) (string, error) {
+	f.commands = append(f.commands, name+" "+strings.Join(args, " "))
+	if name == "podman" && args[0] == "run" {
+		data, err := os.ReadFile(filepath.Join(dir, "VERSION"))
+		if err != nil {
+			return "", err
+		}
+		return strings.ReplaceAll(f.printed[filepath.Base(dir)], "$VERSION", strings.TrimSpace(string(data))), nil
+	}
+	return "", nil
+}
+
+// writeExample creates examples/<language> with a Containerfile
+func writeExample(t *testing.T, examples, language string) {
+	t.Helper()
+	dir := filepath.Join(examples, language)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, containerFile), []byte("FROM scratch\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestHarnessRun_Examples_PassOnlyWhenInjectedVersionPrinted validates the
+// language matrix harness.
+//
+// Why: A language integration that stops injecting the version (a renamed
+// emit format, a broken link flag) must show up as a failing language rather
+// than as a sample that quietly prints a stale version.
+//
+// What: Languages() lists only directories with a Containerfile; each
+// language is staged with VERSION, its image built, and `make run` run with
+// the local binary mounted; a language passes only when it prints
+// "Version: <injected>", and the binary is built once for all languages.
+func TestHarnessRun_Examples_PassOnlyWhenInjectedVersionPrinted(t *testing.T) {
+	// Precondition: Two examples and a directory without a Containerfile
+	examples := t.TempDir()
+	writeExample(t, examples, "go")
+	writeExample(t, examples, "rust")
+	if err := os.MkdirAll(filepath.Join(examples, "notes"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	engine := &fakeEngine{printed: map[string]string{
+		"go":   "Sample Go Application\nVersion: v$VERSION\n",
+		"rust": "Building with version: $VERSION\nVersion: 0.0.0\n",
+	}}
+	h := NewHarness(engine.run, Options{ExamplesDir: examples, Engine: "podman"})
+
+	// Action: Discover and run the languages
+	languages, err := h.Languages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := h.Run(context.Background(), languages)
+
+	// Expected: go passes, rust fails on the stale version
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(languages, []string{"go", "rust"}) {
+		t.Fatalf("expected [go rust], got %v", languages)
+	}
+	if results[0].Err != nil {
+		t.Errorf("go: unexpected failure %v", results[0].Err)
+	}
+	if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), ErrVersionMismatch) || !strings.Contains(results[1].Err.Error(), "Version: 0.0.0") {
+		t.Errorf("rust: expected version mismatch, got %v", results[1].Err)
+	}
+
+	// Expected: One binary build, then build and run per language
+	builds := 0
+	for _, c := range engine.commands {
+		if strings.HasPrefix(c, "go build") {
+			builds++
+		}
+	}
+	if builds != 1 || len(engine.commands) != 5 {
+		t.Errorf("unexpected commands: %q", engine.commands)
+	}
+	if run := engine.commands[2]; !strings.Contains(run, ":/usr/local/bin/versionator:ro") || !strings.HasSuffix(run, "versionator-testlang-go make run") {
+		t.Errorf("unexpected run command: %q", run)
+	}
+
+	// Action: Run a language with no example
+	results, _ = h.Run(context.Background(), []string{"cobol"})
+
+	// Expected: Reported per language
+	if results[0].Err == nil || !strings.Contains(results[0].Err.Error(), ErrNoExample) {
+		t.Errorf("expected %q, got %v", ErrNoExample, results[0].Err)
+	}
+}
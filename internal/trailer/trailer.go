@@ -0,0 +1,79 @@
+// Package trailer stamps release commit messages and tag annotations with a
+// git trailer naming the released version:
+//
+//	Versionator-Version: 1.2.3
+//
+// Tooling can then find release commits by trailer rather than by tag name
+// heuristics:
+//
+//	git log --format='%H %(trailers:key=Versionator-Version,valueonly)' | grep ' .'
+package trailer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Key is the trailer token
+const Key = "Versionator-Version"
+
+// Places a trailer can be written to
+const (
+	PlaceCommit = "commit"
+	PlaceTag    = "tag"
+)
+
+// trailerLine matches a "Token: value" line, as git interpret-trailers
+// recognizes them
+var trailerLine = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*:\s`)
+
+// Append adds the version trailer to message. When the last paragraph is
+// already a trailer block (e.g. Signed-off-by), the trailer joins it;
+// otherwise it starts a new paragraph. A message that already carries the
+// trailer is returned unchanged.
+func Append(message, version string) string {
+	if _, ok := Parse(message); ok {
+		return message
+	}
+	line := Key + ": " + version
+
+	message = strings.TrimRight(message, "\n")
+	if message == "" {
+		return line
+	}
+	// A single paragraph is the subject, never a trailer block
+	if strings.Contains(message, "\n\n") && isTrailerBlock(lastParagraph(message)) {
+		return message + "\n" + line
+	}
+	return message + "\n\n" + line
+}
+
+// Parse returns the version recorded by the trailer in message
+func Parse(message string) (string, bool) {
+	for _, line := range strings.Split(lastParagraph(strings.TrimRight(message, "\n")), "\n") {
+		value, ok := strings.CutPrefix(strings.TrimRight(line, "\r"), Key+":")
+		if ok {
+			return strings.TrimSpace(value), true
+		}
+	}
+	return "", false
+}
+
+// lastParagraph returns the text after the last blank line
+func lastParagraph(message string) string {
+	message = strings.ReplaceAll(message, "\r\n", "\n")
+	if i := strings.LastIndex(message, "\n\n"); i >= 0 {
+		return message[i+2:]
+	}
+	return message
+}
+
+// isTrailerBlock reports whether every line of paragraph is a trailer
+func isTrailerBlock(paragraph string) bool {
+	for _, line := range strings.Split(paragraph, "\n") {
+		if !trailerLine.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package trailer
+
+import "testing"
+
+// TestAppend_PlacesTrailerInLastParagraph validates trailer placement.
+//
+// Why: git only reads trailers from the last paragraph of a message; a
+// trailer anywhere else is invisible to %(trailers) and interpret-trailers.
+//
+// What: A subject-only message gains a new paragraph; an existing trailer
+// block is extended; a manifest section is followed by a new paragraph; a
+// message already stamped is unchanged.
+func TestAppend_PlacesTrailerInLastParagraph(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{name: "subject only", message: "Release 1.2.3\n", want: "Release 1.2.3\n\nVersionator-Version: 1.2.3"},
+		{name: "subject like a trailer", message: "Release: 1.2.3", want: "Release: 1.2.3\n\nVersionator-Version: 1.2.3"},
+		{name: "existing trailer block", message: "Release 1.2.3\n\nSigned-off-by: A <a@example.com>", want: "Release 1.2.3\n\nSigned-off-by: A <a@example.com>\nVersionator-Version: 1.2.3"},
+		{name: "after manifest", message: "Release 1.2.3\n\nArtifacts (sha256):\nabc  VERSION", want: "Release 1.2.3\n\nArtifacts (sha256):\nabc  VERSION\n\nVersionator-Version: 1.2.3"},
+		{name: "already stamped", message: "Release 1.2.3\n\nVersionator-Version: 1.2.3", want: "Release 1.2.3\n\nVersionator-Version: 1.2.3"},
+		{name: "empty message", message: "", want: "Versionator-Version: 1.2.3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action
+			got := Append(tt.message, "1.2.3")
+
+			// Expected
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParse_ReadsTrailerValue validates reading the trailer back.
+//
+// Why: Tooling identifies release commits and their versions from it.
+//
+// What: The value is read from the last paragraph, including CRLF
+// messages; a mention in the body is not a trailer.
+func TestParse_ReadsTrailerValue(t *testing.T) {
+	// Action
+	value, ok := Parse("Release 2.0.0-rc.1\r\n\r\nVersionator-Version: 2.0.0-rc.1\r\n")
+	_, inBody := Parse("Versionator-Version: 1.0.0 was wrong\n\nFix the release")
+
+	// Expected
+	if !ok || value != "2.0.0-rc.1" {
+		t.Errorf("Parse = %q, %v; want 2.0.0-rc.1, true", value, ok)
+	}
+	if inBody {
+		t.Error("expected no trailer outside the last paragraph")
+	}
+}
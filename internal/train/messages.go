@@ -0,0 +1,11 @@
+// Package train messages - error message constants
+// Exported so tests can compare against them
+package train
+
+// Error messages
+const (
+	ErrNoTrain      = "no release train configured (set train in .versionator.yaml)"
+	ErrInvalidStart = "invalid train start date"
+	ErrInvalidFrom  = "invalid train from version"
+	ErrIncrement    = "cannot advance train version"
+)
@@ -0,0 +1,199 @@
+// Package train computes release train schedules: one version level cut at
+// a fixed interval from a start date (e.g. a minor release every six weeks
+// starting 2024-01-15 at 1.0.0). From the schedule alone it derives the
+// version the train says should be current, and when the next cut is due.
+//
+// The schedule is exposed as template variables, so banners and dashboards
+// can show the upcoming cut without consulting a calendar.
+package train
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+	"github.com/benjaminabbitt/versionator/internal/version"
+)
+
+// Schedule is a parsed release train
+type Schedule struct {
+	// Level is the version level each cut increments
+	Level version.VersionLevel
+	// IntervalDays is the number of days between cuts
+	IntervalDays int
+	// Start is the date of the first cut, at midnight in its time zone
+	Start time.Time
+	// From is the version released at the first cut
+	From *version.Version
+}
+
+// NewSchedule parses a train configuration; dates are calendar days in loc
+func NewSchedule(cfg config.TrainConfig, loc *time.Location) (*Schedule, error) {
+	if !cfg.Enabled() {
+		return nil, fmt.Errorf("%s", ErrNoTrain)
+	}
+	level, err := version.ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := cfg.IntervalDays()
+	if err != nil {
+		return nil, err
+	}
+	start, err := cfg.StartDate(loc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrInvalidStart, err)
+	}
+	from, err := version.ParseStrict(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrInvalidFrom, err)
+	}
+	return &Schedule{Level: level, IntervalDays: interval, Start: start, From: from}, nil
+}
+
+// NewScheduleFromConfig reads the train from cfg, using the dates time zone
+func NewScheduleFromConfig(cfg *config.Config) (*Schedule, error) {
+	loc, err := cfg.Dates.Location()
+	if err != nil {
+		return nil, err
+	}
+	return NewSchedule(cfg.Train, loc)
+}
+
+// Status is the position of the train on a given day
+type Status struct {
+	// Started is false before the first cut
+	Started bool
+	// Current is the version the schedule says was cut last; nil before the
+	// first cut
+	Current *version.Version
+	// LastCut is the date of the Current cut
+	LastCut time.Time
+	// Next is the version of the next cut
+	Next *version.Version
+	// NextCut is the date of the next cut
+	NextCut time.Time
+	// DaysToNextCut counts calendar days until NextCut (at least 1 once
+	// started: on a cut day, the next cut is a full interval away)
+	DaysToNextCut int
+}
+
+// At returns the train status on the calendar day of now
+func (s *Schedule) At(now time.Time) (Status, error) {
+	elapsed := daysBetween(s.Start, now.In(s.Start.Location()))
+	if elapsed < 0 {
+		return Status{
+			Next:          s.versionAt(0),
+			NextCut:       s.Start,
+			DaysToNextCut: -elapsed,
+		}, nil
+	}
+
+	cuts := elapsed / s.IntervalDays
+	current := s.versionAt(cuts)
+	next := s.versionAt(cuts + 1)
+	if current == nil || next == nil {
+		return Status{}, fmt.Errorf("%s: %s", ErrIncrement, s.From.FullString())
+	}
+	return Status{
+		Started:       true,
+		Current:       current,
+		LastCut:       s.Start.AddDate(0, 0, cuts*s.IntervalDays),
+		Next:          next,
+		NextCut:       s.Start.AddDate(0, 0, (cuts+1)*s.IntervalDays),
+		DaysToNextCut: (cuts+1)*s.IntervalDays - elapsed,
+	}, nil
+}
+
+// versionAt returns From incremented n times at the train level, or nil if
+// the increment fails
+func (s *Schedule) versionAt(n int) *version.Version {
+	v := *s.From
+	if n > 0 {
+		if err := v.IncrementLevelBy(s.Level, n); err != nil {
+			return nil
+		}
+	}
+	return &v
+}
+
+// daysBetween counts calendar days from the date of a to the date of b,
+// ignoring the time of day and DST shifts
+func daysBetween(a, b time.Time) int {
+	dateA := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	dateB := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(dateB.Sub(dateA).Hours() / 24)
+}
+
+// Variables returns the template variables for a status
+func (st Status) Variables() map[string]string {
+	vars := map[string]string{
+		"TrainExpectedVersion": "",
+		"TrainLastCutDate":     "",
+		"TrainNextVersion":     st.Next.FullString(),
+		"TrainNextCutDate":     st.NextCut.Format(time.DateOnly),
+		"TrainDaysToNextCut":   strconv.Itoa(st.DaysToNextCut),
+	}
+	if st.Started {
+		vars["TrainExpectedVersion"] = st.Current.FullString()
+		vars["TrainLastCutDate"] = st.LastCut.Format(time.DateOnly)
+	}
+	return vars
+}
+
+// ConfigLoader returns the configuration holding the train
+type ConfigLoader func() (*config.Config, error)
+
+// Provider exposes the configured train as template variables
+type Provider struct {
+	loadConfig ConfigLoader
+	now        func() time.Time
+}
+
+// NewProvider creates a Provider with an injected config loader and clock
+func NewProvider(loader ConfigLoader, now func() time.Time) *Provider {
+	return &Provider{loadConfig: loader, now: now}
+}
+
+// NewProviderDefault creates a Provider reading .versionator.yaml at the
+// current time
+func NewProviderDefault() *Provider {
+	return NewProvider(config.ReadConfig, time.Now)
+}
+
+// Name returns "train"
+func (p *Provider) Name() string {
+	return "train"
+}
+
+// Types returns the set of plugin types this provider implements
+func (p *Provider) Types() plugin.PluginTypeSet {
+	return plugin.NewPluginTypeSet(plugin.TypeTemplateProvider)
+}
+
+// GetTemplateVariables returns the Train* variables, or none when no train
+// is configured or it cannot be computed
+func (p *Provider) GetTemplateVariables(context map[string]string) map[string]string {
+	cfg, err := p.loadConfig()
+	if err != nil || cfg == nil || !cfg.Train.Enabled() {
+		return nil
+	}
+	schedule, err := NewScheduleFromConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: train: %v\n", err)
+		return nil
+	}
+	status, err := schedule.At(p.now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: train: %v\n", err)
+		return nil
+	}
+	return status.Variables()
+}
+
+func init() {
+	plugin.Register(NewProviderDefault())
+}
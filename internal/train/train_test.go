@@ -0,0 +1,153 @@
+package train
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+)
+
+func sixWeekMinor(t *testing.T) *Schedule {
+	t.Helper()
+	s, err := NewSchedule(config.TrainConfig{Level: "minor", Every: "6w", Start: "2024-01-15", From: "1.0.0"}, time.UTC)
+	if err != nil {
+		t.Fatalf("NewSchedule: %v", err)
+	}
+	return s
+}
+
+// TestSchedule_At_ComputesExpectedVersionAndNextCut validates the schedule
+// arithmetic.
+//
+// Why: Banners show the expected version and the days to the next cut; an
+// off-by-one on a cut day would announce the wrong release.
+//
+// What: Before the start, the first cut is next. On a cut day that cut is
+// current and the next is a full interval away. Between cuts the remaining
+// days count down.
+func TestSchedule_At_ComputesExpectedVersionAndNextCut(t *testing.T) {
+	// Precondition: Minor every six weeks from 2024-01-15 at 1.0.0
+	s := sixWeekMinor(t)
+
+	tests := []struct {
+		name     string
+		now      string
+		started  bool
+		current  string
+		next     string
+		nextCut  string
+		daysLeft int
+	}{
+		{name: "before start", now: "2024-01-10", next: "1.0.0", nextCut: "2024-01-15", daysLeft: 5},
+		{name: "first cut day", now: "2024-01-15", started: true, current: "1.0.0", next: "1.1.0", nextCut: "2024-02-26", daysLeft: 42},
+		{name: "day before second cut", now: "2024-02-25", started: true, current: "1.0.0", next: "1.1.0", nextCut: "2024-02-26", daysLeft: 1},
+		{name: "third cut day", now: "2024-04-08", started: true, current: "1.2.0", next: "1.3.0", nextCut: "2024-05-20", daysLeft: 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Action: Place the train on the day, late in the evening
+			now, _ := time.Parse(time.DateTime, tt.now+" 23:30:00")
+			st, err := s.At(now)
+
+			// Expected: Position on the schedule
+			if err != nil {
+				t.Fatalf("At: %v", err)
+			}
+			if st.Started != tt.started {
+				t.Errorf("Started = %v, want %v", st.Started, tt.started)
+			}
+			if tt.started && st.Current.FullString() != tt.current {
+				t.Errorf("Current = %s, want %s", st.Current.FullString(), tt.current)
+			}
+			if st.Next.FullString() != tt.next || st.NextCut.Format(time.DateOnly) != tt.nextCut {
+				t.Errorf("Next = %s on %s, want %s on %s", st.Next.FullString(), st.NextCut.Format(time.DateOnly), tt.next, tt.nextCut)
+			}
+			if st.DaysToNextCut != tt.daysLeft {
+				t.Errorf("DaysToNextCut = %d, want %d", st.DaysToNextCut, tt.daysLeft)
+			}
+		})
+	}
+}
+
+// TestSchedule_At_UsesScheduleTimeZone validates that cut days follow the
+// configured zone.
+//
+// Why: A build at 23:30 UTC is already the next day in Tokyo; the train
+// must count days where the team schedules its cuts.
+//
+// What: With a Tokyo schedule, 2024-02-25 23:30 UTC lands on the cut day.
+func TestSchedule_At_UsesScheduleTimeZone(t *testing.T) {
+	// Precondition: Schedule in Asia/Tokyo
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	s, err := NewSchedule(config.TrainConfig{Level: "minor", Every: "6w", Start: "2024-01-15", From: "1.0.0"}, loc)
+	if err != nil {
+		t.Fatalf("NewSchedule: %v", err)
+	}
+
+	// Action
+	st, err := s.At(time.Date(2024, 2, 25, 23, 30, 0, 0, time.UTC))
+
+	// Expected: Second cut is current
+	if err != nil || st.Current.FullString() != "1.1.0" {
+		t.Errorf("expected 1.1.0 current, got %v, %v", st.Current, err)
+	}
+}
+
+// TestNewSchedule_Invalid_ReturnsError validates schedule parsing errors.
+//
+// Why: A missing train or an unparsable from version must be reported
+// rather than produce a nonsense schedule.
+//
+// What: No train and a bad from version both fail with the package errors.
+func TestNewSchedule_Invalid_ReturnsError(t *testing.T) {
+	// Action
+	_, noTrain := NewSchedule(config.TrainConfig{}, time.UTC)
+	_, badFrom := NewSchedule(config.TrainConfig{Level: "minor", Every: "2w", Start: "2024-01-15", From: "one"}, time.UTC)
+
+	// Expected
+	if noTrain == nil || noTrain.Error() != ErrNoTrain {
+		t.Errorf("expected %q, got %v", ErrNoTrain, noTrain)
+	}
+	if badFrom == nil {
+		t.Error("expected error for bad from version")
+	}
+}
+
+// TestProvider_GetTemplateVariables_ExposesTrain validates the plugin.
+//
+// Why: Banners read {{TrainNextVersion}} and {{TrainDaysToNextCut}} without
+// running a separate command.
+//
+// What: With a train configured, the Train* variables reflect the injected
+// clock; without one, no variables are returned.
+func TestProvider_GetTemplateVariables_ExposesTrain(t *testing.T) {
+	// Precondition: Provider with a fixed clock
+	cfg := &config.Config{Train: config.TrainConfig{Level: "patch", Every: "14d", Start: "2024-01-01", From: "2.3.0"}}
+	now := func() time.Time { return time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC) }
+	p := NewProvider(func() (*config.Config, error) { return cfg, nil }, now)
+	none := NewProvider(func() (*config.Config, error) { return &config.Config{}, nil }, now)
+
+	// Action
+	vars := p.GetTemplateVariables(nil)
+
+	// Expected: Schedule as of 2024-01-20
+	want := map[string]string{
+		"TrainExpectedVersion": "2.3.1",
+		"TrainLastCutDate":     "2024-01-15",
+		"TrainNextVersion":     "2.3.2",
+		"TrainNextCutDate":     "2024-01-29",
+		"TrainDaysToNextCut":   "9",
+	}
+	for k, v := range want {
+		if vars[k] != v {
+			t.Errorf("%s = %q, want %q", k, vars[k], v)
+		}
+	}
+	if got := none.GetTemplateVariables(nil); got != nil {
+		t.Errorf("expected no variables without a train, got %v", got)
+	}
+}
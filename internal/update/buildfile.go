@@ -0,0 +1,136 @@
+package update
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+)
+
+// readBuildFile reads a file edited in place, reporting a missing file as
+// ErrFileNotFound
+func readBuildFile(filePath string) ([]byte, error) {
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s: %s", ErrFileNotFound, filePath)
+		}
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+	return raw, nil
+}
+
+// xmlElementSpan returns the byte offsets of the text of the element at
+// path, a dotted list of element names from the document root
+// ("project.version"). Elements of the same name deeper in the document,
+// such as a dependency's version, do not match.
+func xmlElementSpan(raw []byte, path string) (start, end int, err error) {
+	names := strings.Split(path, ".")
+	decoder := xml.NewDecoder(bytes.NewReader(raw))
+	var open []string
+	found := false
+	for {
+		offset := int(decoder.InputOffset())
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			return 0, 0, fmt.Errorf("%s: %s", ErrPathNotFound, path)
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("%s: %w", ErrFileParseFailed, err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			open = append(open, t.Name.Local)
+			if !found && slices.Equal(open, names) {
+				found, start = true, int(decoder.InputOffset())
+			}
+		case xml.EndElement:
+			if found && slices.Equal(open, names) {
+				return start, offset, nil
+			}
+			if len(open) > 0 {
+				open = open[:len(open)-1]
+			}
+		}
+	}
+}
+
+// XMLElement returns the text of the element at path (e.g. "project.version"
+// in a Maven pom.xml)
+func (p *DaselFileParser) XMLElement(filePath string, path string) (string, error) {
+	raw, err := readBuildFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	start, end, err := xmlElementSpan(raw, path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(raw[start:end])), nil
+}
+
+// UpdateXMLElement replaces the text of the element at path, leaving the
+// rest of the file untouched
+func (p *DaselFileParser) UpdateXMLElement(filePath string, path string, newValue string) error {
+	raw, err := readBuildFile(filePath)
+	if err != nil {
+		return err
+	}
+	start, end, err := xmlElementSpan(raw, path)
+	if err != nil {
+		return err
+	}
+	var escaped bytes.Buffer
+	if err := xml.EscapeText(&escaped, []byte(newValue)); err != nil {
+		return err
+	}
+	result := append(append(append([]byte{}, raw[:start]...), escaped.Bytes()...), raw[end:]...)
+	return fileperm.WriteFile(filePath, result)
+}
+
+// propertyLine matches a `key=value` or `key: value` line of a Java
+// properties file (e.g. gradle.properties), capturing the text before the
+// value, the value, and the trailing whitespace
+func propertyLine(key string) *regexp.Regexp {
+	return regexp.MustCompile(`(?m)^([ \t]*` + regexp.QuoteMeta(key) + `[ \t]*[=:][ \t]*)(.*?)([ \t]*\r?)$`)
+}
+
+// Property returns the value of key in a properties file. As in Java, the
+// last declaration wins.
+func (p *DaselFileParser) Property(filePath string, key string) (string, error) {
+	raw, err := readBuildFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	matches := propertyLine(key).FindAllSubmatch(raw, -1)
+	if matches == nil {
+		return "", fmt.Errorf("%s: property %s", ErrPathNotFound, key)
+	}
+	return string(matches[len(matches)-1][2]), nil
+}
+
+// UpdateProperty rewrites the value of key in a properties file, leaving the
+// rest of the file untouched. Every declaration of the key is updated.
+func (p *DaselFileParser) UpdateProperty(filePath string, key string, newValue string) error {
+	raw, err := readBuildFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	re := propertyLine(key)
+	if !re.Match(raw) {
+		return fmt.Errorf("%s: property %s", ErrPathNotFound, key)
+	}
+	result := re.ReplaceAllFunc(raw, func(line []byte) []byte {
+		m := re.FindSubmatch(line)
+		return append(append(append([]byte{}, m[1]...), newValue...), m[3]...)
+	})
+	return fileperm.WriteFile(filePath, result)
+}
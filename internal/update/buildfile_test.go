@@ -0,0 +1,131 @@
+package update
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pomSource = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <parent>
+    <groupId>com.acme</groupId>
+    <version>7.0.0</version>
+  </parent>
+  <artifactId>billing</artifactId>
+  <!-- kept in lockstep with VERSION -->
+  <version>1.0.0</version>
+  <dependencies>
+    <dependency>
+      <artifactId>ledger</artifactId>
+      <version>3.1.0</version>
+    </dependency>
+  </dependencies>
+</project>
+`
+
+const gradleProperties = `org.gradle.jvmargs=-Xmx2g
+# kept in lockstep with VERSION
+version = 1.0.0
+kotlinVersion=2.0.0
+`
+
+func TestUpdater_UpdateFiles_POM_RewritesProjectVersionOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "pom.xml")
+	require.NoError(t, os.WriteFile(filePath, []byte(pomSource), 0644))
+
+	configs := []config.UpdateConfig{
+		{File: filePath, Path: "project.version", Template: "{{MajorMinorPatch}}{{PreReleaseWithDash}}"},
+	}
+	updater := NewUpdater(configs, NewDaselFileParser(), newTestLogger(t))
+
+	require.NoError(t, updater.ValidateConfig())
+	require.NoError(t, updater.UpdateFiles(emit.TemplateData{MajorMinorPatch: "1.4.0", PreReleaseWithDash: "-rc.1"}))
+
+	result, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(pomSource, "<version>1.0.0</version>", "<version>1.4.0-rc.1</version>", 1), string(result))
+
+	current, err := updater.CurrentValue(configs[0])
+	require.NoError(t, err)
+	assert.Equal(t, "1.4.0-rc.1", current)
+}
+
+func TestUpdater_UpdateFiles_Properties_RewritesKeyOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "gradle.properties")
+	require.NoError(t, os.WriteFile(filePath, []byte(gradleProperties), 0644))
+
+	configs := []config.UpdateConfig{
+		{File: filePath, Path: "version", Template: "{{MajorMinorPatch}}"},
+	}
+	updater := NewUpdater(configs, NewDaselFileParser(), newTestLogger(t))
+
+	require.NoError(t, updater.ValidateConfig())
+	require.NoError(t, updater.UpdateFiles(emit.TemplateData{MajorMinorPatch: "1.4.0"}))
+
+	result, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(gradleProperties, "version = 1.0.0", "version = 1.4.0", 1), string(result))
+}
+
+func TestDaselFileParser_BuildFiles_Missing_ReturnsError(t *testing.T) {
+	tmpDir := t.TempDir()
+	pomPath := filepath.Join(tmpDir, "pom.xml")
+	propertiesPath := filepath.Join(tmpDir, "gradle.properties")
+	require.NoError(t, os.WriteFile(pomPath, []byte(pomSource), 0644))
+	require.NoError(t, os.WriteFile(propertiesPath, []byte(gradleProperties), 0644))
+
+	parser := NewDaselFileParser()
+	_, xmlErr := parser.XMLElement(pomPath, "version")
+	_, propertyErr := parser.Property(propertiesPath, "group")
+
+	require.Error(t, xmlErr)
+	assert.Contains(t, xmlErr.Error(), ErrPathNotFound)
+	require.Error(t, propertyErr)
+	assert.Contains(t, propertyErr.Error(), ErrPathNotFound)
+}
+
+// TestUpdater_SetSnapshot_MavenAndGradleFilesOnly validates java.snapshot
+// for updates.
+//
+// Why: A development build written to pom.xml without -SNAPSHOT looks like
+// a release to Maven, which never re-resolves it.
+//
+// What: With SetSnapshot, pom.xml and gradle.properties get the qualifier;
+// package.json does not.
+func TestUpdater_SetSnapshot_MavenAndGradleFilesOnly(t *testing.T) {
+	// Precondition
+	tmpDir := t.TempDir()
+	pomPath := filepath.Join(tmpDir, "pom.xml")
+	propertiesPath := filepath.Join(tmpDir, "gradle.properties")
+	packagePath := filepath.Join(tmpDir, "package.json")
+	require.NoError(t, os.WriteFile(pomPath, []byte(pomSource), 0644))
+	require.NoError(t, os.WriteFile(propertiesPath, []byte(gradleProperties), 0644))
+	require.NoError(t, os.WriteFile(packagePath, []byte(`{"version": "1.0.0"}`), 0644))
+	template := "{{MajorMinorPatch}}{{PreReleaseWithDash}}"
+	configs := []config.UpdateConfig{
+		{File: pomPath, Path: "project.version", Template: template},
+		{File: propertiesPath, Path: "version", Template: template},
+		{File: packagePath, Path: "version", Template: template},
+	}
+	updater := NewUpdater(configs, NewDaselFileParser(), newTestLogger(t))
+	updater.SetSnapshot(true)
+
+	// Action
+	require.NoError(t, updater.UpdateFiles(emit.TemplateData{MajorMinorPatch: "1.4.0"}))
+
+	// Expected
+	for i, want := range []string{"1.4.0-SNAPSHOT", "1.4.0-SNAPSHOT", "1.4.0"} {
+		current, err := updater.CurrentValue(configs[i])
+		require.NoError(t, err)
+		assert.Equal(t, want, current, configs[i].File)
+	}
+}
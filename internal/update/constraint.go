@@ -0,0 +1,126 @@
+package update
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/version"
+)
+
+// rangeOperators are the comparators a dependency range may start with,
+// longest first so ">=" is not read as a bare version
+var rangeOperators = []string{">=", "^", "~", "="}
+
+// dependencyRange is a single-comparator dependency range such as "^1.2.0",
+// read with npm semantics: a partial version ("1", "1.2") is an x-range,
+// a full bare version is exact
+type dependencyRange struct {
+	// protocol is kept verbatim (e.g. "workspace:")
+	protocol string
+	operator string
+	// parts is the number of numeric components written (1-3)
+	parts int
+	// min is the lowest admitted version, nil for "*" or "x"
+	min *version.Version
+}
+
+// parseRange parses a dependency range
+func parseRange(s string) (dependencyRange, error) {
+	r := dependencyRange{}
+	rest := strings.TrimSpace(s)
+	if strings.HasPrefix(rest, "workspace:") {
+		r.protocol, rest = "workspace:", strings.TrimPrefix(rest, "workspace:")
+	}
+	if rest == "" || rest == "*" || rest == "x" {
+		return r, nil
+	}
+	for _, op := range rangeOperators {
+		if strings.HasPrefix(rest, op) {
+			r.operator, rest = op, strings.TrimSpace(strings.TrimPrefix(rest, op))
+			break
+		}
+	}
+
+	core, pre, _ := strings.Cut(rest, "-")
+	fields := strings.Split(strings.TrimPrefix(core, "v"), ".")
+	if len(fields) > 3 || (pre != "" && len(fields) != 3) {
+		return r, fmt.Errorf("%s: %q", ErrUnsupportedRange, s)
+	}
+	nums := make([]int, 3)
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return r, fmt.Errorf("%s: %q", ErrUnsupportedRange, s)
+		}
+		nums[i] = n
+	}
+	r.parts = len(fields)
+	r.min = &version.Version{Major: nums[0], Minor: nums[1], Patch: nums[2], PreRelease: pre}
+	return r, nil
+}
+
+// admits reports whether v satisfies the range
+func (r dependencyRange) admits(v *version.Version) bool {
+	if r.min == nil {
+		return true
+	}
+	if v.Compare(r.min) < 0 {
+		return false
+	}
+	sameMajor := v.Major == r.min.Major
+	sameMinor := sameMajor && v.Minor == r.min.Minor
+	switch r.operator {
+	case ">=":
+		return true
+	case "^":
+		switch {
+		case r.min.Major > 0 || r.parts == 1:
+			return sameMajor
+		case r.min.Minor > 0 || r.parts == 2:
+			return sameMinor
+		default:
+			return sameMinor && v.Patch == r.min.Patch
+		}
+	case "~":
+		if r.parts == 1 {
+			return sameMajor
+		}
+		return sameMinor
+	default:
+		switch r.parts {
+		case 1:
+			return sameMajor
+		case 2:
+			return sameMinor
+		default:
+			return v.Compare(r.min) == 0
+		}
+	}
+}
+
+// RewriteRange returns current unchanged when it admits target, and
+// otherwise the same range moved to target, keeping its protocol, operator,
+// and precision (e.g. "^1.2.0" -> "^2.0.0", "~1.2" -> "~1.3")
+func RewriteRange(current, target string) (string, error) {
+	r, err := parseRange(current)
+	if err != nil {
+		return "", err
+	}
+	v, err := version.ParseStrict(target)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ErrInvalidRangeTarget, err)
+	}
+	if r.admits(v) {
+		return current, nil
+	}
+
+	base := strings.TrimPrefix(target, v.Prefix)
+	switch r.parts {
+	case 1:
+		base = strconv.Itoa(v.Major)
+	case 2:
+		base = fmt.Sprintf("%d.%d", v.Major, v.Minor)
+	}
+	return r.protocol + r.operator + base, nil
+}
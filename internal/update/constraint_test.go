@@ -0,0 +1,64 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRewriteRange validates range rewriting.
+//
+// Why: A dependent must only be touched when its range stops admitting the
+// library's version; rewriting ranges that still match churns every
+// package.json in a monorepo on each patch release.
+//
+// What: Ranges that admit the target are returned unchanged; others move to
+// the target keeping protocol, operator, and precision.
+func TestRewriteRange(t *testing.T) {
+	tests := []struct {
+		current, target, want string
+	}{
+		{"^1.2.0", "1.3.0", "^1.2.0"},
+		{"^1.2.0", "2.0.0", "^2.0.0"},
+		{"^0.2.0", "0.3.0", "^0.3.0"},
+		{"^0.2.0", "0.2.5", "^0.2.0"},
+		{"~1.2.0", "1.2.9", "~1.2.0"},
+		{"~1.2.0", "1.3.0", "~1.3.0"},
+		{"~1.2", "1.3.0", "~1.3"},
+		{"1.2.3", "1.2.4", "1.2.4"},
+		{"1", "1.9.0", "1"},
+		{"1", "2.0.0", "2"},
+		{">=1.0.0", "3.0.0", ">=1.0.0"},
+		{"workspace:^1.0.0", "2.0.0", "workspace:^2.0.0"},
+		{"workspace:*", "2.0.0", "workspace:*"},
+		{"^1.2.0", "2.0.0-rc.1", "^2.0.0-rc.1"},
+		{"^1.2.0", "v2.0.0", "^2.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.current+"->"+tt.target, func(t *testing.T) {
+			// Action
+			got, err := RewriteRange(tt.current, tt.target)
+
+			// Expected
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestRewriteRange_Unsupported validates that compound and non-version
+// ranges are reported instead of overwritten.
+//
+// Why: Silently replacing ">=1.0.0 <2.0.0" or a git URL with "^2.0.0" would
+// change what the dependent resolves to.
+//
+// What: Unsupported ranges and targets that are not versions return errors.
+func TestRewriteRange_Unsupported(t *testing.T) {
+	for _, current := range []string{">=1.0.0 <2.0.0", "git+https://example.com/lib.git", "^1.2.3.4"} {
+		_, err := RewriteRange(current, "2.0.0")
+		assert.ErrorContains(t, err, ErrUnsupportedRange, current)
+	}
+	_, err := RewriteRange("^1.0.0", "latest")
+	assert.ErrorContains(t, err, ErrInvalidRangeTarget)
+}
@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+
 	"github.com/pelletier/go-toml/v2"
 	"github.com/tomwright/dasel/v3"
 	"gopkg.in/yaml.v3"
@@ -21,6 +24,15 @@ const (
 	FormatJSON Format = "json"
 	FormatYAML Format = "yaml"
 	FormatTOML Format = "toml"
+	// FormatProto is a Protocol Buffers source file; the path names a string
+	// file option such as "(version)"
+	FormatProto Format = "proto"
+	// FormatXML is an XML file such as a Maven pom.xml; the path is a dotted
+	// list of element names from the root ("project.version")
+	FormatXML Format = "xml"
+	// FormatProperties is a Java properties file such as gradle.properties;
+	// the path is a key
+	FormatProperties Format = "properties"
 )
 
 // FileParser provides operations on structured files (JSON, YAML, TOML)
@@ -96,7 +108,7 @@ func (p *DaselFileParser) Write(path string, data any, format Format) error {
 		return fmt.Errorf("%s: %w", ErrFileWriteFailed, err)
 	}
 
-	if err := os.WriteFile(path, content, FilePermission); err != nil {
+	if err := fileperm.WriteFile(path, content); err != nil {
 		return fmt.Errorf("%s: %w", ErrFileWriteFailed, err)
 	}
 
@@ -138,6 +150,12 @@ func (p *DaselFileParser) detectFormat(path string, explicitFormat string) (Form
 			return FormatYAML, nil
 		case "toml":
 			return FormatTOML, nil
+		case "proto":
+			return FormatProto, nil
+		case "xml":
+			return FormatXML, nil
+		case "properties":
+			return FormatProperties, nil
 		default:
 			return "", fmt.Errorf("%s: %s", ErrUnsupportedFormat, explicitFormat)
 		}
@@ -151,6 +169,12 @@ func (p *DaselFileParser) detectFormat(path string, explicitFormat string) (Form
 		return FormatYAML, nil
 	case ".toml":
 		return FormatTOML, nil
+	case ".proto":
+		return FormatProto, nil
+	case ".xml":
+		return FormatXML, nil
+	case ".properties":
+		return FormatProperties, nil
 	default:
 		return "", fmt.Errorf("%s: cannot detect format from extension %s", ErrUnsupportedFormat, ext)
 	}
@@ -234,5 +258,134 @@ func (p *DaselFileParser) UpdateTOMLValue(filePath string, path string, newValue
 		return fmt.Errorf("could not find value %q to replace in %s", oldStr, filePath)
 	}
 
-	return os.WriteFile(filePath, result, FilePermission)
+	return fileperm.WriteFile(filePath, result)
+}
+
+// UpdateYAMLValue does a targeted value replacement in a YAML file,
+// preserving comments, key order, and indentation (e.g. info.version in an
+// OpenAPI spec). Paths that are not plain dotted keys to a scalar fall back
+// to a full parse-modify-serialize.
+func (p *DaselFileParser) UpdateYAMLValue(filePath string, path string, newValue string) error {
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("%s: %s: %w", ErrFileParseFailed, filePath, err)
+	}
+
+	node := yamlScalarAt(&doc, path)
+	if node == nil {
+		return p.putAndWrite(filePath, path, newValue, FormatYAML)
+	}
+	if node.Value == newValue {
+		return nil
+	}
+
+	start, end, ok := yamlScalarSpan(raw, node)
+	if !ok {
+		return p.putAndWrite(filePath, path, newValue, FormatYAML)
+	}
+
+	replacement := yamlScalarText(node.Style, newValue)
+	result := append(append(append([]byte{}, raw[:start]...), replacement...), raw[end:]...)
+	return fileperm.WriteFile(filePath, result)
+}
+
+// putAndWrite rewrites the whole file with the value at path replaced
+func (p *DaselFileParser) putAndWrite(filePath string, path string, newValue string, format Format) error {
+	data, _, err := p.ReadWithFormat(filePath, string(format))
+	if err != nil {
+		return err
+	}
+	dataMap, ok := data.(map[string]any)
+	if !ok {
+		return fmt.Errorf("file content is not a map structure")
+	}
+	if err := p.Put(&dataMap, path, newValue); err != nil {
+		return err
+	}
+	return p.Write(filePath, dataMap, format)
+}
+
+// yamlScalarAt follows a dotted key path through mappings to a scalar, or
+// returns nil
+func yamlScalarAt(doc *yaml.Node, path string) *yaml.Node {
+	node := doc
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		node = node.Content[0]
+	}
+	for _, key := range strings.Split(path, ".") {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	if node.Kind != yaml.ScalarNode {
+		return nil
+	}
+	return node
+}
+
+// yamlScalarSpan locates the source text of a plain or quoted single-line
+// scalar in raw
+func yamlScalarSpan(raw []byte, node *yaml.Node) (int, int, bool) {
+	lines := bytes.SplitAfter(raw, []byte("\n"))
+	if node.Line < 1 || node.Line > len(lines) {
+		return 0, 0, false
+	}
+	offset := 0
+	for _, line := range lines[:node.Line-1] {
+		offset += len(line)
+	}
+	// Columns count characters, not bytes
+	line := []rune(string(lines[node.Line-1]))
+	if node.Column < 1 || node.Column > len(line) {
+		return 0, 0, false
+	}
+	start := offset + len(string(line[:node.Column-1]))
+
+	var token string
+	switch node.Style {
+	case 0:
+		token = node.Value
+	case yaml.DoubleQuotedStyle:
+		token = `"` + node.Value + `"`
+	case yaml.SingleQuotedStyle:
+		token = `'` + node.Value + `'`
+	default:
+		return 0, 0, false
+	}
+	if !bytes.HasPrefix(raw[start:], []byte(token)) {
+		return 0, 0, false
+	}
+	return start, start + len(token), true
+}
+
+// yamlScalarText renders value in the original quoting style, quoting a
+// plain value that would otherwise not read back as the same string
+func yamlScalarText(style yaml.Style, value string) string {
+	switch style {
+	case yaml.SingleQuotedStyle:
+		return `'` + strings.ReplaceAll(value, `'`, `''`) + `'`
+	case yaml.DoubleQuotedStyle:
+		return strconv.Quote(value)
+	}
+	var parsed any
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil || parsed != value {
+		return strconv.Quote(value)
+	}
+	return value
 }
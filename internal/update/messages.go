@@ -1,19 +1,17 @@
 package update
 
-import "os"
-
-// FilePermission is the default permission for written files
-const FilePermission os.FileMode = 0644
-
 // Error messages
 const (
-	ErrFileNotFound      = "file not found"
-	ErrPathNotFound      = "path not found in file"
-	ErrInvalidSelector   = "invalid selector syntax"
-	ErrUnsupportedFormat = "unsupported file format"
-	ErrFileParseFailed   = "failed to parse file"
-	ErrFileWriteFailed   = "failed to write file"
-	ErrTemplateRender    = "failed to render template"
+	ErrFileNotFound       = "file not found"
+	ErrPathNotFound       = "path not found in file"
+	ErrInvalidSelector    = "invalid selector syntax"
+	ErrUnsupportedFormat  = "unsupported file format"
+	ErrFileParseFailed    = "failed to parse file"
+	ErrFileWriteFailed    = "failed to write file"
+	ErrTemplateRender     = "failed to render template"
+	ErrUnsupportedRange   = "unsupported dependency range"
+	ErrInvalidRangeTarget = "range template must render a version"
+	ErrNoFilesMatch       = "no files match"
 )
 
 // Log messages for structured logging
@@ -0,0 +1,54 @@
+package update
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+)
+
+// protoOption matches a string file option such as
+// `option (version) = "1.2.3";`, capturing the text before the value, the
+// value, and the text after it
+func protoOption(name string) *regexp.Regexp {
+	return regexp.MustCompile(`(?m)^([ \t]*option\s+` + regexp.QuoteMeta(name) + `\s*=\s*")([^"]*)("\s*;)`)
+}
+
+// ProtoOption returns the value of the string option name (e.g. "(version)")
+// declared in a .proto file
+func (p *DaselFileParser) ProtoOption(filePath string, name string) (string, error) {
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%s: %s", ErrFileNotFound, filePath)
+		}
+		return "", fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	m := protoOption(name).FindSubmatch(raw)
+	if m == nil {
+		return "", fmt.Errorf("%s: option %s", ErrPathNotFound, name)
+	}
+	return string(m[2]), nil
+}
+
+// UpdateProtoOption rewrites the value of the string option name in a .proto
+// file, leaving the rest of the file untouched. Every declaration of the
+// option is updated.
+func (p *DaselFileParser) UpdateProtoOption(filePath string, name string, newValue string) error {
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	re := protoOption(name)
+	if !re.Match(raw) {
+		return fmt.Errorf("%s: option %s", ErrPathNotFound, name)
+	}
+	result := re.ReplaceAllFunc(raw, func(decl []byte) []byte {
+		m := re.FindSubmatch(decl)
+		return append(append(append([]byte{}, m[1]...), newValue...), m[3]...)
+	})
+	return fileperm.WriteFile(filePath, result)
+}
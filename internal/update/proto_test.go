@@ -0,0 +1,66 @@
+package update
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/emit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const protoSource = `syntax = "proto3";
+
+package acme.billing.v1;
+
+// API version, kept in lockstep with VERSION
+option (version) = "1.0.0";
+option go_package = "example.com/acme/billing/v1";
+
+message Invoice {
+  string version = 1; // not an option
+}
+`
+
+func TestUpdater_UpdateFiles_Proto_RewritesOptionOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "billing.proto")
+	require.NoError(t, os.WriteFile(filePath, []byte(protoSource), 0644))
+
+	configs := []config.UpdateConfig{
+		{File: filePath, Path: "(version)", Template: "{{MajorMinorPatch}}"},
+	}
+	updater := NewUpdater(configs, NewDaselFileParser(), newTestLogger(t))
+
+	require.NoError(t, updater.ValidateConfig())
+	require.NoError(t, updater.UpdateFiles(emit.TemplateData{MajorMinorPatch: "1.4.0"}))
+
+	result, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	expected := `option (version) = "1.4.0";`
+	assert.Contains(t, string(result), expected)
+	assert.Equal(t, len(protoSource)+len(expected)-len(`option (version) = "1.0.0";`), len(result))
+	assert.Contains(t, string(result), `option go_package = "example.com/acme/billing/v1";`)
+	assert.Contains(t, string(result), "// API version, kept in lockstep with VERSION")
+
+	current, err := updater.CurrentValue(configs[0])
+	require.NoError(t, err)
+	assert.Equal(t, "1.4.0", current)
+}
+
+func TestDaselFileParser_ProtoOption_Missing_ReturnsError(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "billing.proto")
+	require.NoError(t, os.WriteFile(filePath, []byte(protoSource), 0644))
+
+	parser := NewDaselFileParser()
+	_, err := parser.ProtoOption(filePath, "(api_version)")
+	updateErr := parser.UpdateProtoOption(filePath, "(api_version)", "2.0.0")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrPathNotFound)
+	require.Error(t, updateErr)
+	assert.Contains(t, updateErr.Error(), ErrPathNotFound)
+}
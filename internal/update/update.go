@@ -2,6 +2,7 @@ package update
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/benjaminabbitt/versionator/internal/config"
 	"github.com/benjaminabbitt/versionator/internal/emit"
@@ -24,6 +25,7 @@ type Updater struct {
 	parser        *DaselFileParser
 	logger        *zap.Logger
 	updatedFiles  []string
+	snapshot      bool
 }
 
 // NewUpdater creates an Updater (IoC constructor accepting dependencies)
@@ -46,11 +48,33 @@ func NewUpdaterDefault(configs []config.UpdateConfig) (*Updater, error) { //noli
 	return NewUpdater(configs, NewDaselFileParser(), logger), nil
 }
 
+// SetSnapshot makes updates of Maven/Gradle build files (pom.xml,
+// gradle.properties) render with the SNAPSHOT qualifier, as java.snapshot
+// decides for the build
+func (u *Updater) SetSnapshot(snapshot bool) {
+	u.snapshot = snapshot
+}
+
+// RenderValue renders the value cfg writes for data, with the SNAPSHOT
+// qualifier when SetSnapshot applies to cfg's file
+func (u *Updater) RenderValue(cfg config.UpdateConfig, data emit.TemplateData) (string, error) {
+	if u.snapshot && emit.IsSnapshotFile(cfg.File) {
+		emit.AppendSnapshot(&data)
+	}
+	return emit.RenderTemplateWithData(cfg.Template, data)
+}
+
 // UpdateFiles applies all configured updates using the given template data
 func (u *Updater) UpdateFiles(data emit.TemplateData) error {
 	u.updatedFiles = make([]string, 0)
 
 	for i, cfg := range u.configs {
+		if cfg.IsRange() {
+			if err := u.updateRanges(cfg, data); err != nil {
+				return fmt.Errorf("updates[%d] (%s): %w", i, cfg.File, err)
+			}
+			continue
+		}
 		if err := u.updateSingleFile(cfg, data); err != nil {
 			return fmt.Errorf("updates[%d] (%s): %w", i, cfg.File, err)
 		}
@@ -68,52 +92,190 @@ func (u *Updater) UpdateFiles(data emit.TemplateData) error {
 // updateSingleFile applies a single update configuration
 func (u *Updater) updateSingleFile(cfg config.UpdateConfig, data emit.TemplateData) error {
 	// Render the template to get the new value
-	newValue, err := emit.RenderTemplateWithData(cfg.Template, data)
+	newValue, err := u.RenderValue(cfg, data)
 	if err != nil {
 		return fmt.Errorf("%s: %w", ErrTemplateRender, err)
 	}
 
-	// Detect format
-	var format Format
-	if cfg.Format != "" {
-		format, err = u.parser.detectFormat(cfg.File, cfg.Format)
-	} else {
-		format, err = u.parser.detectFormat(cfg.File, "")
+	return u.SetValue(cfg, newValue)
+}
+
+// updateRanges rewrites the dependency ranges of a range update that no
+// longer admit the rendered version
+func (u *Updater) updateRanges(cfg config.UpdateConfig, data emit.TemplateData) error {
+	changes, err := u.RangeChanges(cfg, data)
+	if err != nil {
+		return err
+	}
+	for _, c := range changes {
+		if c.Old == c.New {
+			continue
+		}
+		if err := u.SetValue(c.Config, c.New); err != nil {
+			return fmt.Errorf("%s: %w", c.Config.File, err)
+		}
+		u.updatedFiles = append(u.updatedFiles, c.Config.File)
+		u.logger.Info(LogFileUpdated,
+			zap.String("file", c.Config.File),
+			zap.String("path", c.Config.Path),
+			zap.String("range", c.New),
+		)
 	}
+	return nil
+}
+
+// RangeChanges resolves a range update to one change per file matching its
+// glob that has a value at its path. New equals Old where the range already
+// admits the rendered version.
+func (u *Updater) RangeChanges(cfg config.UpdateConfig, data emit.TemplateData) ([]ValueChange, error) {
+	target, err := emit.RenderTemplateWithData(cfg.Template, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrTemplateRender, err)
+	}
+	files, err := filepath.Glob(cfg.File)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s %s", ErrNoFilesMatch, cfg.File)
+	}
+
+	var changes []ValueChange
+	for _, file := range files {
+		fileCfg := cfg
+		fileCfg.File = filepath.ToSlash(file)
+		old, err := u.CurrentValue(fileCfg)
+		if err != nil {
+			// Not every package in a monorepo depends on the library
+			u.logger.Debug(LogUpdateSkipped, zap.String("file", fileCfg.File), zap.Error(err))
+			continue
+		}
+		newRange, err := RewriteRange(old, target)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fileCfg.File, err)
+		}
+		changes = append(changes, ValueChange{Config: fileCfg, Old: old, New: newRange})
+	}
+	return changes, nil
+}
+
+// SetValue writes an already-rendered value at cfg.Path in cfg.File
+func (u *Updater) SetValue(cfg config.UpdateConfig, newValue string) error {
+	// Detect format
+	format, err := u.parser.detectFormat(cfg.File, cfg.Format)
 	if err != nil {
 		return err
 	}
 
-	// TOML: use targeted text replacement to preserve comments and ordering
-	if format == FormatTOML {
+	// TOML/YAML: use targeted text replacement to preserve comments and ordering
+	switch format {
+	case FormatTOML:
 		return u.parser.UpdateTOMLValue(cfg.File, cfg.Path, newValue)
+	case FormatYAML:
+		return u.parser.UpdateYAMLValue(cfg.File, cfg.Path, newValue)
+	case FormatProto:
+		return u.parser.UpdateProtoOption(cfg.File, cfg.Path, newValue)
+	case FormatXML:
+		return u.parser.UpdateXMLElement(cfg.File, cfg.Path, newValue)
+	case FormatProperties:
+		return u.parser.UpdateProperty(cfg.File, cfg.Path, newValue)
 	}
 
-	// JSON/YAML: parse-modify-serialize (these preserve formatting well enough)
+	// JSON: parse-modify-serialize (preserves formatting well enough)
+	dataMap, format, err := u.readMap(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := u.parser.Put(&dataMap, cfg.Path, newValue); err != nil {
+		return err
+	}
+
+	if err := u.parser.Write(cfg.File, dataMap, format); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CurrentValue returns the value at cfg.Path in cfg.File, formatted as a string
+func (u *Updater) CurrentValue(cfg config.UpdateConfig) (string, error) {
+	if format, err := u.parser.detectFormat(cfg.File, cfg.Format); err == nil {
+		switch format {
+		case FormatProto:
+			return u.parser.ProtoOption(cfg.File, cfg.Path)
+		case FormatXML:
+			return u.parser.XMLElement(cfg.File, cfg.Path)
+		case FormatProperties:
+			return u.parser.Property(cfg.File, cfg.Path)
+		}
+	}
+
+	dataMap, _, err := u.readMap(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := u.parser.Select(dataMap, cfg.Path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(value), nil
+}
+
+// readMap reads cfg.File (honouring an explicit format) as a map
+func (u *Updater) readMap(cfg config.UpdateConfig) (map[string]any, Format, error) {
 	var fileData any
+	var format Format
+	var err error
 	if cfg.Format != "" {
 		fileData, format, err = u.parser.ReadWithFormat(cfg.File, cfg.Format)
 	} else {
 		fileData, format, err = u.parser.Read(cfg.File)
 	}
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 
 	dataMap, ok := fileData.(map[string]any)
 	if !ok {
-		return fmt.Errorf("file content is not a map structure")
+		return nil, "", fmt.Errorf("file content is not a map structure")
 	}
+	return dataMap, format, nil
+}
 
-	if err := u.parser.Put(&dataMap, cfg.Path, newValue); err != nil {
-		return err
-	}
+// ValueChange describes a planned edit for one update configuration
+type ValueChange struct {
+	Config config.UpdateConfig
+	Old    string
+	New    string
+}
 
-	if err := u.parser.Write(cfg.File, dataMap, format); err != nil {
-		return err
+// PlanUpdates renders every configured update against data without writing
+// anything, returning the current and rendered value for each (for a range
+// update, for each file it covers)
+func (u *Updater) PlanUpdates(data emit.TemplateData) ([]ValueChange, error) {
+	changes := make([]ValueChange, 0, len(u.configs))
+	for i, cfg := range u.configs {
+		if cfg.IsRange() {
+			ranges, err := u.RangeChanges(cfg, data)
+			if err != nil {
+				return nil, fmt.Errorf("updates[%d] (%s): %w", i, cfg.File, err)
+			}
+			changes = append(changes, ranges...)
+			continue
+		}
+		newValue, err := u.RenderValue(cfg, data)
+		if err != nil {
+			return nil, fmt.Errorf("updates[%d] (%s): %s: %w", i, cfg.File, ErrTemplateRender, err)
+		}
+		oldValue, err := u.CurrentValue(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("updates[%d] (%s): %w", i, cfg.File, err)
+		}
+		changes = append(changes, ValueChange{Config: cfg, Old: oldValue, New: newValue})
 	}
-
-	return nil
+	return changes, nil
 }
 
 // ValidateConfig checks all update configurations are valid
@@ -121,8 +283,21 @@ func (u *Updater) ValidateConfig() error {
 	u.logger.Debug(LogValidatingConfig, zap.Int("count", len(u.configs)))
 
 	for i, cfg := range u.configs {
+		if cfg.IsRange() {
+			if files, err := filepath.Glob(cfg.File); err != nil || len(files) == 0 {
+				return fmt.Errorf("updates[%d]: %s %s", i, ErrNoFilesMatch, cfg.File)
+			}
+			continue
+		}
+
 		// Check file exists
-		_, _, err := u.parser.Read(cfg.File)
+		var err error
+		switch format, _ := u.parser.detectFormat(cfg.File, cfg.Format); format {
+		case FormatProto, FormatXML, FormatProperties:
+			_, err = u.CurrentValue(cfg)
+		default:
+			_, _, err = u.parser.Read(cfg.File)
+		}
 		if err != nil {
 			return fmt.Errorf("updates[%d]: %w", i, err)
 		}
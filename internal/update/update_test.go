@@ -257,6 +257,49 @@ appVersion: 1.0.0
 	assert.Equal(t, "2.0.0-alpha.1", readBack.(map[string]any)["appVersion"])
 }
 
+func TestUpdater_UpdateFiles_YAML_OpenAPIPreservesLayout(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "openapi.yaml")
+	content := `openapi: 3.1.0
+# Billing API
+info:
+  title: Billing
+  version: '1.0.0' # bumped by versionator
+paths: {}
+`
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+
+	configs := []config.UpdateConfig{
+		{File: filePath, Path: "info.version", Template: "{{MajorMinorPatch}}"},
+	}
+	updater := NewUpdater(configs, NewDaselFileParser(), newTestLogger(t))
+
+	err := updater.UpdateFiles(emit.TemplateData{MajorMinorPatch: "1.1.0"})
+
+	require.NoError(t, err)
+	result, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(content, "'1.0.0'", "'1.1.0'", 1), string(result))
+}
+
+func TestUpdater_UpdateFiles_YAML_QuotesAmbiguousPlainValue(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "Chart.yaml")
+	require.NoError(t, os.WriteFile(filePath, []byte("appVersion: 1.0.0\n"), 0644))
+
+	configs := []config.UpdateConfig{
+		{File: filePath, Path: "appVersion", Template: "{{Major}}.{{Minor}}"},
+	}
+	updater := NewUpdater(configs, NewDaselFileParser(), newTestLogger(t))
+
+	err := updater.UpdateFiles(emit.TemplateData{Major: "2", Minor: "0"})
+
+	require.NoError(t, err)
+	result, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "appVersion: \"2.0\"\n", string(result))
+}
+
 func TestUpdater_UpdateFiles_FileNotFound_ReturnsError(t *testing.T) {
 	configs := []config.UpdateConfig{
 		{
@@ -415,3 +458,43 @@ func TestUpdater_UpdateFiles_EmptyConfigs_Success(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, updater.GetFilesToCommit())
 }
+
+// TestUpdater_UpdateFiles_RangeRule validates range updates across a
+// monorepo.
+//
+// Why: When a library bumps major, every package depending on it must move
+// its range, while packages that already admit the version or do not
+// depend on it are left alone.
+//
+// What: A range rule over packages/*/package.json rewrites only the stale
+// range, skips the package without the dependency, and reports only the
+// rewritten file for commit.
+func TestUpdater_UpdateFiles_RangeRule(t *testing.T) {
+	// Precondition: Three packages
+	t.Chdir(t.TempDir())
+	packages := map[string]string{
+		"app":   `{"dependencies": {"acmelib": "^1.2.0"}}`,
+		"web":   `{"dependencies": {"acmelib": "^2.0.0"}}`,
+		"tools": `{"dependencies": {}}`,
+	}
+	for name, content := range packages {
+		require.NoError(t, os.MkdirAll(filepath.Join("packages", name), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join("packages", name, "package.json"), []byte(content), 0644))
+	}
+	configs := []config.UpdateConfig{
+		{File: "packages/*/package.json", Path: "dependencies.acmelib", Template: "{{MajorMinorPatch}}", Type: config.UpdateTypeRange},
+	}
+	updater := NewUpdater(configs, NewDaselFileParser(), newTestLogger(t))
+
+	// Action
+	err := updater.UpdateFiles(emit.TemplateData{MajorMinorPatch: "2.0.0"})
+
+	// Expected
+	require.NoError(t, err)
+	assert.Equal(t, []string{"packages/app/package.json"}, updater.GetFilesToCommit())
+	for name, want := range map[string]string{"app": "^2.0.0", "web": "^2.0.0"} {
+		got, err := updater.CurrentValue(config.UpdateConfig{File: "packages/" + name + "/package.json", Path: "dependencies.acmelib"})
+		require.NoError(t, err)
+		assert.Equal(t, want, got, name)
+	}
+}
@@ -0,0 +1,121 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newBareRepo creates a worktree repository with a tagged commit and a later
+// commit, clones it with `git clone --bare`, and returns the bare path
+func newBareRepo(t *testing.T) string {
+	t.Helper()
+	work := t.TempDir()
+	bare := filepath.Join(t.TempDir(), "project.git")
+
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Fixture Author", "GIT_AUTHOR_EMAIL=fixture@example.com",
+			"GIT_COMMITTER_NAME=Fixture Author", "GIT_COMMITTER_EMAIL=fixture@example.com",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v: %s", strings.Join(args, " "), err, out)
+		}
+	}
+
+	run(work, "init", "-q")
+	if err := os.WriteFile(filepath.Join(work, "VERSION"), []byte("1.4.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run(work, "add", "VERSION")
+	run(work, "commit", "-q", "-m", "initial commit")
+	run(work, "tag", "-a", "v1.4.0", "-m", "Release 1.4.0")
+	run(work, "commit", "-q", "--allow-empty", "-m", "feat: after release")
+	run(work, "clone", "-q", "--bare", work, bare)
+	return bare
+}
+
+// TestBare_LocateFromInsideGitDir_ReadsHistory validates detection of a bare
+// repository from its own directory.
+//
+// Why: Server-side hooks (post-receive) run with the bare repository as the
+// working directory, where there is no .git to find.
+//
+// What: The bare directory is located as the repository root, tag and commit
+// information resolve, there are no dirty files, and VERSION is read from HEAD.
+func TestBare_LocateFromInsideGitDir_ReadsHistory(t *testing.T) {
+	// Precondition: Bare clone; GIT_DIR unset
+	bare := newBareRepo(t)
+	t.Setenv("GIT_DIR", "")
+	v := NewGitVCSDefault()
+
+	// Action: Locate the repository and read from it
+	found := v.locate(bare)
+	hash, hashErr := v.GetVCSIdentifier(7)
+	count, _ := v.GetCommitsSinceTag()
+	tag, _ := v.GetLastTag()
+	dirty, dirtyErr := v.GetDirtyFiles()
+	content, readErr := v.ReadHeadFile("VERSION")
+
+	// Expected: Bare repository with readable history
+	if !found || !v.IsBare() || v.repoRoot != bare {
+		t.Fatalf("expected bare repo at %s, got found=%v bare=%v root=%s", bare, found, v.bare, v.repoRoot)
+	}
+	if hashErr != nil || len(hash) != 7 {
+		t.Errorf("unexpected identifier %q (%v)", hash, hashErr)
+	}
+	if count != 1 || tag != "v1.4.0" {
+		t.Errorf("unexpected tag info: count=%d tag=%q", count, tag)
+	}
+	if dirtyErr != nil || len(dirty) != 0 {
+		t.Errorf("expected no dirty files, got %v (%v)", dirty, dirtyErr)
+	}
+	if readErr != nil || string(content) != "1.4.0\n" {
+		t.Errorf("unexpected VERSION %q (%v)", content, readErr)
+	}
+}
+
+// TestBare_GitDirEnv_TakesPrecedence validates GIT_DIR handling.
+//
+// Why: Automation often runs from an unrelated directory and points at the
+// repository explicitly, as git itself allows.
+//
+// What: With GIT_DIR set (absolute or relative), the repository is found from
+// any working directory; a GIT_DIR that is not a repository is not found.
+func TestBare_GitDirEnv_TakesPrecedence(t *testing.T) {
+	// Precondition: Bare clone and an unrelated directory
+	bare := newBareRepo(t)
+	elsewhere := t.TempDir()
+
+	// Action: Locate via absolute and relative GIT_DIR
+	t.Setenv("GIT_DIR", bare)
+	absolute := NewGitVCSDefault()
+	absFound := absolute.locate(elsewhere)
+
+	t.Setenv("GIT_DIR", filepath.Base(bare))
+	relative := NewGitVCSDefault()
+	relFound := relative.locate(filepath.Dir(bare))
+
+	t.Setenv("GIT_DIR", elsewhere)
+	invalid := NewGitVCSDefault()
+	invalidFound := invalid.locate(elsewhere)
+
+	// Expected: Both valid forms resolve to the bare repo; the invalid one does not
+	if !absFound || absolute.repoRoot != bare || !absolute.bare {
+		t.Errorf("absolute GIT_DIR: found=%v root=%s bare=%v", absFound, absolute.repoRoot, absolute.bare)
+	}
+	if !relFound || relative.repoRoot != bare || !relative.bare {
+		t.Errorf("relative GIT_DIR: found=%v root=%s bare=%v", relFound, relative.repoRoot, relative.bare)
+	}
+	if invalidFound {
+		t.Error("expected non-repository GIT_DIR not to be found")
+	}
+	if commit, err := absolute.GetLastTagCommit(); err != nil || commit == "" {
+		t.Errorf("expected tag commit via GIT_DIR, got %q (%v)", commit, err)
+	}
+}
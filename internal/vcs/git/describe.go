@@ -0,0 +1,170 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// maxDescribeCandidates is the number of tags weighed before the nearest
+// is chosen (git describe --candidates default)
+const maxDescribeCandidates = 10
+
+// namedTag is a version tag on a commit
+type namedTag struct {
+	name      string
+	annotated bool
+	tagged    time.Time // tagger date; zero for lightweight tags
+}
+
+// preferredTo reports whether t names the commit rather than other, as git
+// chooses between tags on one commit: annotated over lightweight, then the
+// newer annotated tag, then the first name in ref order
+func (t namedTag) preferredTo(other namedTag) bool {
+	if t.annotated != other.annotated {
+		return t.annotated
+	}
+	if t.annotated && !t.tagged.Equal(other.tagged) {
+		return t.tagged.After(other.tagged)
+	}
+	return t.name < other.name
+}
+
+// describeCandidate is a tag met while walking back from HEAD, with the
+// number of walked commits it does not reach
+type describeCandidate struct {
+	tag    namedTag
+	commit plumbing.Hash
+	depth  int
+	flag   uint
+}
+
+// describeWalk visits commits newest first by committer date, as git's
+// describe walk does, carrying for each commit the candidates that reach it
+type describeWalk struct {
+	repo  Repository
+	queue []*object.Commit
+	seen  map[plumbing.Hash]bool
+	flags map[plumbing.Hash]uint
+	count int
+}
+
+// push queues c after every queued commit at least as new
+func (w *describeWalk) push(c *object.Commit) {
+	when := c.Committer.When.Unix()
+	i := sort.Search(len(w.queue), func(i int) bool { return w.queue[i].Committer.When.Unix() < when })
+	w.queue = append(w.queue, nil)
+	copy(w.queue[i+1:], w.queue[i:])
+	w.queue[i] = c
+}
+
+// pop removes the newest queued commit
+func (w *describeWalk) pop() *object.Commit {
+	c := w.queue[0]
+	w.queue = w.queue[1:]
+	w.count++
+	return c
+}
+
+// pushParents queues the unseen parents of c and passes its flags on to
+// them; parents missing from a shallow clone are skipped
+func (w *describeWalk) pushParents(c *object.Commit) error {
+	for _, h := range c.ParentHashes {
+		w.flags[h] |= w.flags[c.Hash]
+		if w.seen[h] {
+			continue
+		}
+		w.seen[h] = true
+		parent, err := w.repo.CommitObject(h)
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get commit object: %w", err)
+		}
+		w.push(parent)
+	}
+	return nil
+}
+
+// describe finds the tag git describe --tags names for head: of the first
+// maxDescribeCandidates tags met walking back by commit date, the one with
+// the fewest commits reachable from head but not from the tag (ties go to
+// the tag met first). The walk stops at DefaultMaxCommitDepth commits.
+func describe(repo Repository, head *object.Commit, tags map[plumbing.Hash]namedTag) (*TagInfo, error) {
+	w := &describeWalk{
+		repo:  repo,
+		seen:  map[plumbing.Hash]bool{head.Hash: true},
+		flags: map[plumbing.Hash]uint{},
+	}
+	w.push(head)
+
+	var candidates []*describeCandidate
+	var gaveUpOn *object.Commit
+	for len(w.queue) > 0 && w.count < DefaultMaxCommitDepth {
+		c := w.pop()
+		if tag, ok := tags[c.Hash]; ok {
+			if len(candidates) == maxDescribeCandidates {
+				gaveUpOn = c
+				break
+			}
+			candidate := &describeCandidate{tag: tag, commit: c.Hash, depth: w.count - 1, flag: 1 << len(candidates)}
+			candidates = append(candidates, candidate)
+			w.flags[c.Hash] |= candidate.flag
+		}
+		for _, candidate := range candidates {
+			if w.flags[c.Hash]&candidate.flag == 0 {
+				candidate.depth++
+			}
+		}
+		if err := w.pushParents(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(candidates) == 0 {
+		return &TagInfo{CommitsSinceTag: w.count}, nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].depth < candidates[j].depth })
+	best := candidates[0]
+
+	// Later candidates were not weighed; finish counting the commits the
+	// best one does not reach
+	if gaveUpOn != nil {
+		w.push(gaveUpOn)
+		w.count--
+		for len(w.queue) > 0 && w.count < DefaultMaxCommitDepth {
+			c := w.pop()
+			if w.flags[c.Hash]&best.flag != 0 {
+				if w.allReach(best.flag) {
+					break
+				}
+			} else {
+				best.depth++
+			}
+			if err := w.pushParents(c); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &TagInfo{
+		CommitsSinceTag:   best.depth,
+		LastTagName:       best.tag.name,
+		LastTagCommitHash: best.commit.String(),
+	}, nil
+}
+
+// allReach reports whether every queued commit carries flag
+func (w *describeWalk) allReach(flag uint) bool {
+	for _, c := range w.queue {
+		if w.flags[c.Hash]&flag == 0 {
+			return false
+		}
+	}
+	return true
+}
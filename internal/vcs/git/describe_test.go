@@ -0,0 +1,201 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+)
+
+// describeFixture builds a repository commit by commit with fixed dates, so
+// the date-ordered walk is reproducible
+type describeFixture struct {
+	t    *testing.T
+	dir  string
+	date int
+}
+
+func newDescribeFixture(t *testing.T) *describeFixture {
+	t.Helper()
+	f := &describeFixture{t: t, dir: t.TempDir(), date: 1700000000}
+	f.git("init", "-q", "-b", "main")
+	return f
+}
+
+// git runs git in the fixture with the next commit date
+func (f *describeFixture) git(args ...string) string {
+	f.t.Helper()
+	date := fmt.Sprintf("@%d +0000", f.date)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = f.dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Fixture Author", "GIT_AUTHOR_EMAIL=fixture@example.com",
+		"GIT_COMMITTER_NAME=Fixture Author", "GIT_COMMITTER_EMAIL=fixture@example.com",
+		"GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date,
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		f.t.Fatalf("git %s failed: %v: %s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commit makes an empty commit an hour after the previous one
+func (f *describeFixture) commit(message string) {
+	f.t.Helper()
+	f.date += 3600
+	f.git("commit", "-q", "--allow-empty", "-m", message)
+}
+
+// merge merges branches into the current branch (an octopus merge with
+// more than one)
+func (f *describeFixture) merge(branches ...string) {
+	f.t.Helper()
+	f.date += 3600
+	f.git(append([]string{"merge", "-q", "--no-ff", "-m", "merge"}, branches...)...)
+}
+
+// describe returns the tag and distance git describe reports for HEAD
+func (f *describeFixture) describe() (string, int) {
+	f.t.Helper()
+	out := f.git("describe", "--tags", "--long", "HEAD")
+	parts := strings.Split(out, "-")
+	count, err := strconv.Atoi(parts[len(parts)-2])
+	if err != nil {
+		f.t.Fatalf("unexpected git describe output %q", out)
+	}
+	return strings.Join(parts[:len(parts)-2], "-"), count
+}
+
+// TestTagDistanceDescribe_MatchesGitDescribe validates describe mode
+// against the git CLI.
+//
+// Why: Walking log order stops at the first tagged commit on the first
+// path it follows; after merges that can be an older tag than the one git
+// describe, and so most CI tooling, reports.
+//
+// What: In each topology, the tag and commits since it match git describe
+// --tags --long.
+func TestTagDistanceDescribe_MatchesGitDescribe(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(f *describeFixture)
+	}{
+		{name: "linear", build: func(f *describeFixture) {
+			f.commit("a")
+			f.git("tag", "v1.0.0")
+			f.commit("b")
+			f.git("tag", "-a", "v1.1.0", "-m", "1.1.0")
+			f.commit("c")
+			f.commit("d")
+		}},
+		{name: "octopus merge", build: func(f *describeFixture) {
+			f.commit("a")
+			f.git("tag", "v1.0.0")
+			f.git("branch", "side1")
+			f.git("branch", "side2")
+			f.commit("b")
+			f.git("checkout", "-q", "side1")
+			f.commit("c")
+			f.git("tag", "v1.1.0")
+			f.commit("d")
+			f.commit("e")
+			f.git("checkout", "-q", "side2")
+			f.commit("f")
+			f.commit("g")
+			f.git("tag", "-a", "v1.0.1", "-m", "1.0.1")
+			f.git("checkout", "-q", "main")
+			f.merge("side1", "side2")
+			f.commit("i")
+		}},
+		{name: "more tags than candidates", build: func(f *describeFixture) {
+			f.commit("a")
+			f.git("tag", "v0.1.0")
+			f.git("branch", "side")
+			f.git("checkout", "-q", "side")
+			for i := 2; i <= 13; i++ {
+				f.commit(fmt.Sprintf("side %d", i))
+				f.git("tag", fmt.Sprintf("v0.%d.0", i))
+			}
+			f.git("checkout", "-q", "main")
+			f.commit("m1")
+			f.commit("m2")
+			f.merge("side")
+			f.commit("after")
+		}},
+		{name: "tags sharing a commit", build: func(f *describeFixture) {
+			f.commit("a")
+			f.git("tag", "v2.0.0")
+			f.git("tag", "-a", "v1.9.0", "-m", "1.9.0")
+			f.git("tag", "v1.8.0")
+			f.commit("b")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition
+			f := newDescribeFixture(t)
+			tt.build(f)
+			wantTag, wantCount := f.describe()
+			vcs.SetTagDistance(vcs.TagDistanceDescribe)
+			t.Cleanup(func() { vcs.SetTagDistance("") })
+			v := NewGitVCSDefault()
+			v.repoRoot = f.dir
+
+			// Action
+			count, err := v.GetCommitsSinceTag()
+			tag, _ := v.GetLastTag()
+			tagCommit, _ := v.GetLastTagCommit()
+
+			// Expected
+			if err != nil {
+				t.Fatalf("GetCommitsSinceTag failed: %v", err)
+			}
+			if tag != wantTag || count != wantCount {
+				t.Errorf("got %s-%d, git describe says %s-%d", tag, count, wantTag, wantCount)
+			}
+			if want := f.git("rev-parse", wantTag+"^{commit}"); tagCommit != want {
+				t.Errorf("tag commit = %s, want %s", tagCommit, want)
+			}
+		})
+	}
+}
+
+// TestTagDistanceLog_IsDefault validates that log mode is unchanged.
+//
+// Why: Existing builds must not change version numbers unless describe
+// mode is chosen.
+//
+// What: After an octopus merge, log mode counts to the first tagged commit
+// along the first parents, unlike git describe.
+func TestTagDistanceLog_IsDefault(t *testing.T) {
+	// Precondition: main tagged v1.0.0, merged with a side branch tagged later
+	f := newDescribeFixture(t)
+	f.commit("a")
+	f.git("tag", "v1.0.0")
+	f.git("branch", "side")
+	f.commit("b")
+	f.git("checkout", "-q", "side")
+	f.commit("c")
+	f.commit("d")
+	f.git("tag", "v1.1.0")
+	f.git("checkout", "-q", "main")
+	f.merge("side")
+	v := NewGitVCSDefault()
+	v.repoRoot = f.dir
+
+	// Action
+	tag, _ := v.GetLastTag()
+
+	// Expected
+	if vcs.TagDistance() != vcs.TagDistanceLog {
+		t.Errorf("default mode = %s", vcs.TagDistance())
+	}
+	if described, _ := f.describe(); tag == described {
+		t.Errorf("log mode found %s, the same tag as git describe", tag)
+	}
+}
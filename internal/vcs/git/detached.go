@@ -0,0 +1,71 @@
+package git
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// reflogDepth bounds how many reflog entries ReflogBranch reads
+const reflogDepth = 100
+
+// ReflogBranch returns the branch of the most recent "checkout: moving from
+// <branch> to ..." reflog entry, skipping checkouts between detached
+// commits. Uses the git CLI; go-git does not read the reflog.
+func (g *GitVersionControlSystem) ReflogBranch() (string, error) {
+	root, err := g.GetRepositoryRoot()
+	if err != nil {
+		return "", err
+	}
+	cli := &gitCLI{root: root}
+
+	out, err := cli.run(nil, "log", "--walk-reflogs", "--format=%gs", "-n", strconv.Itoa(reflogDepth), "HEAD", "--")
+	if err != nil {
+		return "", nil
+	}
+	for _, line := range strings.Split(out, "\n") {
+		rest, ok := strings.CutPrefix(line, "checkout: moving from ")
+		if !ok {
+			continue
+		}
+		from, _, ok := strings.Cut(rest, " to ")
+		if !ok {
+			continue
+		}
+		if _, err := cli.run(nil, "rev-parse", "--verify", "--quiet", "refs/heads/"+from); err == nil {
+			return from, nil
+		}
+	}
+	return "", nil
+}
+
+// BranchesContainingHead lists the local and remote-tracking branches whose
+// history contains HEAD, with remote names stripped (origin/main -> main)
+func (g *GitVersionControlSystem) BranchesContainingHead() ([]string, error) {
+	root, err := g.GetRepositoryRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := (&gitCLI{root: root}).run(nil, "for-each-ref", "--contains", "HEAD",
+		"--format=%(refname)", "refs/heads", "refs/remotes")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, ref := range strings.Split(out, "\n") {
+		var name string
+		if local, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+			name = local
+		} else if remote, ok := strings.CutPrefix(ref, "refs/remotes/"); ok {
+			_, name, _ = strings.Cut(remote, "/")
+		}
+		// Skip origin/HEAD, which only points at the remote default branch
+		if name == "" || name == "HEAD" {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return slices.Compact(names), nil
+}
@@ -0,0 +1,56 @@
+package git
+
+import (
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+// TestDetachedHeadHints_ReflogAndContainingBranches validates the hints
+// used to infer the branch of a detached HEAD.
+//
+// Why: CI systems check out a detached HEAD, leaving GetBranchName empty;
+// the reflog and the refs containing HEAD still point at the branch.
+//
+// What: After detaching from feature, ReflogBranch names feature and
+// BranchesContainingHead lists local and remote branches, remote names
+// stripped and origin/HEAD skipped.
+func TestDetachedHeadHints_ReflogAndContainingBranches(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	// Precondition: HEAD detached from feature; origin/main at the same commit
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	h.CreateCommit("base")
+	h.setRef("refs/remotes/origin/main", h.headHash())
+	for _, args := range [][]string{
+		{"symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/main"},
+		{"checkout", "-q", "-b", "feature"},
+		{"checkout", "-q", "--detach"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = h.dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	g := NewGitVCSDefault()
+
+	// Action: Read the hints
+	branch, branchErr := g.GetBranchName()
+	reflog, reflogErr := g.ReflogBranch()
+	containing, containingErr := g.BranchesContainingHead()
+
+	// Expected: Detached, reflog names feature, all three branches contain HEAD
+	if branchErr != nil || branch != "" {
+		t.Errorf("GetBranchName() = %q, %v; want detached", branch, branchErr)
+	}
+	if reflogErr != nil || reflog != "feature" {
+		t.Errorf("ReflogBranch() = %q, %v; want feature", reflog, reflogErr)
+	}
+	if want := []string{"feature", "main", "master"}; containingErr != nil || !slices.Equal(containing, want) {
+		t.Errorf("BranchesContainingHead() = %v, %v; want %v", containing, containingErr, want)
+	}
+}
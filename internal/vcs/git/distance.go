@@ -0,0 +1,57 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// fallbackDefaultBranches are tried in order when origin/HEAD is not set,
+// which is the case for clones made by `git init` plus `git fetch`
+var fallbackDefaultBranches = []string{"origin/main", "origin/master"}
+
+// RemoteDefaultBranch returns origin's default branch (e.g. "origin/main")
+// from refs/remotes/origin/HEAD, falling back to origin/main and
+// origin/master. go-git cannot count commits across a merge base cheaply,
+// so this and CommitsAheadBehind use the git CLI.
+func (g *GitVersionControlSystem) RemoteDefaultBranch() (string, error) {
+	root, err := g.GetRepositoryRoot()
+	if err != nil {
+		return "", err
+	}
+	cli := &gitCLI{root: root}
+
+	if ref, err := cli.run(nil, "symbolic-ref", "--short", "refs/remotes/origin/HEAD"); err == nil && ref != "" {
+		return ref, nil
+	}
+	for _, ref := range fallbackDefaultBranches {
+		if _, err := cli.run(nil, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err == nil {
+			return ref, nil
+		}
+	}
+	return "", fmt.Errorf("no default branch found for origin")
+}
+
+// CommitsAheadBehind counts the commits on each side of HEAD...base
+func (g *GitVersionControlSystem) CommitsAheadBehind(base string) (ahead, behind int, err error) {
+	root, err := g.GetRepositoryRoot()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	out, err := (&gitCLI{root: root}).run(nil, "rev-list", "--left-right", "--count", "HEAD..."+base, "--")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to compare HEAD with %s: %w", base, err)
+	}
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", out)
+	}
+	if ahead, err = strconv.Atoi(fields[0]); err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", out)
+	}
+	if behind, err = strconv.Atoi(fields[1]); err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", out)
+	}
+	return ahead, behind, nil
+}
@@ -0,0 +1,104 @@
+package git
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// setRef points name at hash in the test repository
+func (h *TestHelper) setRef(name string, hash plumbing.Hash) {
+	h.t.Helper()
+	if err := h.repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(name), hash)); err != nil {
+		h.t.Fatalf("failed to set %s: %v", name, err)
+	}
+}
+
+// headHash returns the commit HEAD points to
+func (h *TestHelper) headHash() plumbing.Hash {
+	h.t.Helper()
+	head, err := h.repo.Head()
+	if err != nil {
+		h.t.Fatalf("failed to read HEAD: %v", err)
+	}
+	return head.Hash()
+}
+
+// TestCommitsAheadBehind_DivergedBranches_CountsBothSides validates the
+// commit distance between HEAD and origin's default branch.
+//
+// Why: Long-lived feature branches report how far they have drifted from
+// the default branch in pre-release metadata; both directions matter.
+//
+// What: origin/main has one commit HEAD lacks, HEAD has two commits
+// origin/main lacks; without origin/HEAD, origin/main is detected.
+func TestCommitsAheadBehind_DivergedBranches_CountsBothSides(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	// Precondition: master and origin/main diverge from a shared base
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	h.CreateCommit("base")
+	base := h.headHash()
+	h.CreateCommit("upstream change")
+	h.setRef("refs/remotes/origin/main", h.headHash())
+	h.setRef("refs/heads/master", base)
+	h.CreateCommit("feature one")
+	h.CreateCommit("feature two")
+	g := NewGitVCSDefault()
+
+	// Action: Detect the default branch and compare HEAD with it
+	ref, refErr := g.RemoteDefaultBranch()
+	ahead, behind, err := g.CommitsAheadBehind(ref)
+
+	// Expected: origin/main, two ahead, one behind
+	if refErr != nil || ref != "origin/main" {
+		t.Fatalf("RemoteDefaultBranch() = %q, %v; want origin/main", ref, refErr)
+	}
+	if err != nil {
+		t.Fatalf("CommitsAheadBehind() error: %v", err)
+	}
+	if ahead != 2 || behind != 1 {
+		t.Errorf("CommitsAheadBehind() = %d, %d; want 2, 1", ahead, behind)
+	}
+}
+
+// TestRemoteDefaultBranch_OriginHEAD_FollowsSymbolicRef validates that the
+// remote's advertised default branch wins over the main/master fallbacks.
+//
+// Why: Repositories whose default branch is develop or trunk would
+// otherwise be compared against a stale origin/main.
+//
+// What: origin/HEAD points at origin/develop while origin/main also exists;
+// without any remote the lookup fails.
+func TestRemoteDefaultBranch_OriginHEAD_FollowsSymbolicRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	// Precondition: A repository with no remote-tracking branches
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	h.CreateCommit("initial commit")
+	g := NewGitVCSDefault()
+	if _, err := g.RemoteDefaultBranch(); err == nil {
+		t.Error("expected an error without a remote")
+	}
+
+	// Action: Add origin/main, origin/develop and origin/HEAD -> develop
+	h.setRef("refs/remotes/origin/main", h.headHash())
+	h.setRef("refs/remotes/origin/develop", h.headHash())
+	symbolic := plumbing.NewSymbolicReference("refs/remotes/origin/HEAD", "refs/remotes/origin/develop")
+	if err := h.repo.Storer.SetReference(symbolic); err != nil {
+		t.Fatal(err)
+	}
+	ref, err := g.RemoteDefaultBranch()
+
+	// Expected: The symbolic ref's target
+	if err != nil || ref != "origin/develop" {
+		t.Errorf("RemoteDefaultBranch() = %q, %v; want origin/develop", ref, err)
+	}
+}
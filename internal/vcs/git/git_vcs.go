@@ -6,17 +6,21 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-git/go-billy/v5/osfs"
 	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
 	"github.com/go-git/go-git/v5/plumbing/object"
 
+	"github.com/benjaminabbitt/versionator/internal/offline"
 	"github.com/benjaminabbitt/versionator/internal/plugin"
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
 	"github.com/benjaminabbitt/versionator/internal/vcs"
 )
 
@@ -29,11 +33,14 @@ const DefaultMaxCommitDepth = 10000
 
 // GitVersionControlSystem implements VersionControlSystem for Git
 type GitVersionControlSystem struct {
-	repoRoot   string
+	repoRoot   string           // worktree root, or the git directory for bare repositories
+	gitDir     string           // git directory; empty means repoRoot/.git
+	bare       bool             // true when the repository has no working tree
 	repo       Repository       // cached repository (interface)
 	repoOpener RepositoryOpener // injected repository opener
 	tagInfo    *TagInfo         // cached tag information
 	tagInfoErr error            // cached error from tag info fetch
+	tags       *tagformat.Scope // tag format and namespace; nil uses the configured ones
 }
 
 // TagInfo holds pre-computed tag-related information from a single walk
@@ -56,6 +63,31 @@ func NewGitVCSDefault() *GitVersionControlSystem {
 	return NewGitVCS(DefaultRepositoryOpener)
 }
 
+// NewGitVCSAt creates a GitVersionControlSystem for the repository
+// containing dir, for callers that must not depend on the working directory
+func NewGitVCSAt(dir string) (*GitVersionControlSystem, error) {
+	g := NewGitVCSDefault()
+	if !g.locate(dir) {
+		return nil, fmt.Errorf("not a git repository")
+	}
+	return g, nil
+}
+
+// SetTagScope makes the backend read tags under s instead of the configured
+// release.tagFormat and release.tagNamespace
+func (g *GitVersionControlSystem) SetTagScope(s tagformat.Scope) {
+	g.tags = &s
+	g.tagInfo, g.tagInfoErr = nil, nil
+}
+
+// tagScope returns the tag format and namespace the backend reads tags under
+func (g *GitVersionControlSystem) tagScope() tagformat.Scope {
+	if g.tags != nil {
+		return *g.tags
+	}
+	return tagformat.Current()
+}
+
 // Name returns "git"
 func (g *GitVersionControlSystem) Name() string {
 	return "git"
@@ -86,12 +118,7 @@ func (g *GitVersionControlSystem) IsRepository() bool {
 		return false
 	}
 
-	root := g.findGitDir(cwd)
-	if root != "" {
-		g.repoRoot = root
-		return true
-	}
-	return false
+	return g.locate(cwd)
 }
 
 // GetRepositoryRoot returns the root directory of the git repository
@@ -105,13 +132,72 @@ func (g *GitVersionControlSystem) GetRepositoryRoot() (string, error) {
 		return "", fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	root := g.findGitDir(cwd)
-	if root == "" {
+	if !g.locate(cwd) {
 		return "", fmt.Errorf("not a git repository")
 	}
+	return g.repoRoot, nil
+}
+
+// IsBare reports whether the repository has no working tree (a bare
+// repository, or GIT_DIR pointing at one)
+func (g *GitVersionControlSystem) IsBare() bool {
+	if _, err := g.GetRepositoryRoot(); err != nil {
+		return false
+	}
+	return g.bare
+}
+
+// IsShallow reports whether the repository is a shallow clone: git records
+// the truncated history's boundary commits in the shallow file
+func (g *GitVersionControlSystem) IsShallow() bool {
+	if _, err := g.GetRepositoryRoot(); err != nil {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(g.gitDir, "shallow"))
+	return err == nil
+}
+
+// ReadHeadFile returns the contents of path (relative to the repository root)
+// as committed at HEAD. Used to read VERSION from bare repositories.
+func (g *GitVersionControlSystem) ReadHeadFile(path string) ([]byte, error) {
+	if cli, ok := g.cliFallback(); ok {
+		out, err := cli.run(nil, "show", "HEAD:"+filepath.ToSlash(path))
+		if err != nil {
+			return nil, err
+		}
+		return []byte(out), nil
+	}
+
+	repo, err := g.openRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD reference: %w", err)
+	}
 
-	g.repoRoot = root
-	return root, nil
+	return readCommitFile(repo, ref.Hash(), path, "HEAD")
+}
+
+// readCommitFile returns the contents of path as committed at hash; rev
+// names the commit in errors
+func readCommitFile(repo Repository, hash plumbing.Hash, path, rev string) ([]byte, error) {
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit object: %w", err)
+	}
+
+	file, err := commit.File(filepath.ToSlash(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s at %s: %w", path, rev, err)
+	}
+	contents, err := file.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s at %s: %w", path, rev, err)
+	}
+	return []byte(contents), nil
 }
 
 // IsWorkingDirectoryClean checks if there are no uncommitted changes
@@ -125,28 +211,68 @@ func (g *GitVersionControlSystem) IsWorkingDirectoryClean() (bool, error) {
 	return len(files) == 0, nil
 }
 
-// GetVCSIdentifier returns a short hash of the current commit
-func (g *GitVersionControlSystem) GetVCSIdentifier(length int) (string, error) {
-	if length < 1 || length > 40 {
-		return "", fmt.Errorf("invalid hash length: %d (must be between 1 and 40)", length)
-	}
-
+// HashAlgorithm returns the repository object format ("sha1" or "sha256")
+func (g *GitVersionControlSystem) HashAlgorithm() string {
 	repo, err := g.openRepository()
 	if err != nil {
-		return "", err
+		return vcs.HashAlgorithmSHA1
 	}
-
-	ref, err := repo.Head()
+	cfg, err := repo.Config()
 	if err != nil {
-		return "", fmt.Errorf("failed to get HEAD reference: %w", err)
+		return vcs.HashAlgorithmSHA1
+	}
+	if cfg.Extensions.ObjectFormat != "" {
+		return string(cfg.Extensions.ObjectFormat)
+	}
+	// go-git only populates Extensions when writing config; read the raw
+	// section so repositories created by the git CLI are detected
+	if cfg.Raw != nil {
+		if format := cfg.Raw.Section("extensions").Option("objectformat"); format != "" {
+			return strings.ToLower(format)
+		}
 	}
+	return vcs.HashAlgorithmSHA1
+}
 
-	commit, err := repo.CommitObject(ref.Hash())
-	if err != nil {
-		return "", fmt.Errorf("failed to get commit object: %w", err)
+// MaxIdentifierLength returns the full commit hash length for the repository
+// object format (40 for SHA-1, 64 for SHA-256)
+func (g *GitVersionControlSystem) MaxIdentifierLength() int {
+	if g.HashAlgorithm() == vcs.HashAlgorithmSHA256 {
+		return sha256HexLength
+	}
+	return vcs.DefaultIdentifierLength
+}
+
+// GetVCSIdentifier returns a short hash of the current commit
+func (g *GitVersionControlSystem) GetVCSIdentifier(length int) (string, error) {
+	if maxLength := g.MaxIdentifierLength(); length < 1 || length > maxLength {
+		return "", fmt.Errorf("invalid hash length: %d (must be between 1 and %d)", length, maxLength)
 	}
 
-	fullHash := commit.Hash.String()
+	var fullHash string
+	if cli, ok := g.cliFallback(); ok {
+		hash, err := cli.commitOf("HEAD")
+		if err != nil {
+			return "", fmt.Errorf("failed to get HEAD reference: %w", err)
+		}
+		fullHash = hash
+	} else {
+		repo, err := g.openRepository()
+		if err != nil {
+			return "", err
+		}
+
+		ref, err := repo.Head()
+		if err != nil {
+			return "", fmt.Errorf("failed to get HEAD reference: %w", err)
+		}
+
+		commit, err := repo.CommitObject(ref.Hash())
+		if err != nil {
+			return "", fmt.Errorf("failed to get commit object: %w", err)
+		}
+		fullHash = commit.Hash.String()
+	}
 	if length > len(fullHash) {
 		length = len(fullHash)
 	}
@@ -156,6 +282,10 @@ func (g *GitVersionControlSystem) GetVCSIdentifier(length int) (string, error) {
 
 // CreateTag creates a git tag
 func (g *GitVersionControlSystem) CreateTag(tagName, message string) error {
+	if cli, ok := g.cliFallback(); ok {
+		return cli.createTag(tagName, message)
+	}
+
 	repo, err := g.openRepository()
 	if err != nil {
 		return err
@@ -223,6 +353,14 @@ func (g *GitVersionControlSystem) TagExists(tagName string) (bool, error) {
 // lightweight tags, the ref hash IS the commit. Returns an error if the tag
 // does not exist.
 func (g *GitVersionControlSystem) GetTagCommit(tagName string) (string, error) {
+	if cli, ok := g.cliFallback(); ok {
+		commit, err := cli.commitOf("refs/tags/" + tagName)
+		if err != nil {
+			return "", fmt.Errorf("tag %q not found", tagName)
+		}
+		return commit, nil
+	}
+
 	repo, err := g.openRepository()
 	if err != nil {
 		return "", err
@@ -262,6 +400,10 @@ func (g *GitVersionControlSystem) GetTagCommit(tagName string) (string, error) {
 
 // CreateBranch creates a branch with the specified name from the current HEAD
 func (g *GitVersionControlSystem) CreateBranch(branchName string) error {
+	if cli, ok := g.cliFallback(); ok {
+		return cli.createBranch(branchName)
+	}
+
 	repo, err := g.openRepository()
 	if err != nil {
 		return err
@@ -318,6 +460,14 @@ func (g *GitVersionControlSystem) BranchExists(branchName string) (bool, error)
 // GetBranchCommit returns the commit SHA the named branch points to. Returns
 // an error if the branch does not exist.
 func (g *GitVersionControlSystem) GetBranchCommit(branchName string) (string, error) {
+	if cli, ok := g.cliFallback(); ok {
+		commit, err := cli.commitOf("refs/heads/" + branchName)
+		if err != nil {
+			return "", fmt.Errorf("branch %q not found", branchName)
+		}
+		return commit, nil
+	}
+
 	repo, err := g.openRepository()
 	if err != nil {
 		return "", err
@@ -368,6 +518,10 @@ func (g *GitVersionControlSystem) GetBranchName() (string, error) {
 
 // GetCommitDate returns the date of the current commit
 func (g *GitVersionControlSystem) GetCommitDate() (time.Time, error) {
+	if cli, ok := g.cliFallback(); ok {
+		return cli.commitDate()
+	}
+
 	repo, err := g.openRepository()
 	if err != nil {
 		return time.Time{}, err
@@ -407,7 +561,8 @@ func (g *GitVersionControlSystem) GetUncommittedChanges() (int, error) {
 	return len(files), nil
 }
 
-// GetLastTag returns the most recent semver tag
+// GetLastTag returns the most recent tag reachable from HEAD that follows
+// release.tagFormat (any tag when unset)
 // Returns empty string if no tags exist
 func (g *GitVersionControlSystem) GetLastTag() (string, error) {
 	info, err := g.getTagInfo()
@@ -428,6 +583,10 @@ func (g *GitVersionControlSystem) GetLastTagCommit() (string, error) {
 
 // GetCommitAuthor returns the name of the current commit's author
 func (g *GitVersionControlSystem) GetCommitAuthor() (string, error) {
+	if cli, ok := g.cliFallback(); ok {
+		return cli.headField("%an")
+	}
+
 	repo, err := g.openRepository()
 	if err != nil {
 		return "", err
@@ -448,6 +607,10 @@ func (g *GitVersionControlSystem) GetCommitAuthor() (string, error) {
 
 // GetCommitAuthorEmail returns the email of the current commit's author
 func (g *GitVersionControlSystem) GetCommitAuthorEmail() (string, error) {
+	if cli, ok := g.cliFallback(); ok {
+		return cli.headField("%ae")
+	}
+
 	repo, err := g.openRepository()
 	if err != nil {
 		return "", err
@@ -466,6 +629,40 @@ func (g *GitVersionControlSystem) GetCommitAuthorEmail() (string, error) {
 	return commit.Author.Email, nil
 }
 
+// LastChange returns the author and date of the most recent commit reachable
+// from HEAD that modified path (relative to the repository root)
+func (g *GitVersionControlSystem) LastChange(path string) (vcs.FileChange, error) {
+	path = filepath.ToSlash(path)
+	if cli, ok := g.cliFallback(); ok {
+		return cli.lastChange(path)
+	}
+
+	repo, err := g.openRepository()
+	if err != nil {
+		return vcs.FileChange{}, err
+	}
+
+	ref, err := repo.Head()
+	if err != nil {
+		return vcs.FileChange{}, fmt.Errorf("failed to get HEAD reference: %w", err)
+	}
+
+	commitIter, err := repo.Log(&git.LogOptions{From: ref.Hash(), FileName: &path})
+	if err != nil {
+		return vcs.FileChange{}, fmt.Errorf("failed to get commit log: %w", err)
+	}
+
+	var change vcs.FileChange
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		change = vcs.FileChange{Author: c.Author.Name, Date: c.Author.When.UTC()}
+		return errStopIteration
+	})
+	if err != nil && !errors.Is(err, errStopIteration) {
+		return vcs.FileChange{}, fmt.Errorf("failed to iterate commits: %w", err)
+	}
+	return change, nil
+}
+
 // GetCommitMessagesSinceTag returns all commit messages since the most recent tag
 // Returns commit messages newest first, empty slice if on tagged commit or no tags
 func (g *GitVersionControlSystem) GetCommitMessagesSinceTag() ([]string, error) {
@@ -479,6 +676,10 @@ func (g *GitVersionControlSystem) GetCommitMessagesSinceTag() ([]string, error)
 		return []string{}, nil
 	}
 
+	if cli, ok := g.cliFallback(); ok {
+		return cli.commitMessages(info.CommitsSinceTag)
+	}
+
 	repo, err := g.openRepository()
 	if err != nil {
 		return nil, err
@@ -513,7 +714,10 @@ func (g *GitVersionControlSystem) GetCommitMessagesSinceTag() ([]string, error)
 	return messages, nil
 }
 
-// GetDirtyFiles returns the list of files with uncommitted changes.
+// GetDirtyFiles returns the list of files with uncommitted changes, one per
+// `git status --porcelain` line: an untracked directory is a single entry.
+// Untracked files are left out in vcs.DirtyCheckTracked mode; ignored files
+// are included when vcs.CountIgnored is set.
 // Uses git CLI which natively respects .gitignore and skips ignored directories
 // during the walk, avoiding performance issues with large ignored trees
 // (e.g., .cargo-container/) and permission errors on unreadable files.
@@ -523,7 +727,20 @@ func (g *GitVersionControlSystem) GetDirtyFiles() ([]string, error) {
 		return nil, err
 	}
 
-	cmd := exec.Command("git", "status", "--porcelain")
+	// Bare repositories have no working tree, so nothing can be dirty
+	if g.bare {
+		return nil, nil
+	}
+
+	args := []string{"status", "--porcelain"}
+	switch {
+	case vcs.DirtyCheck() == vcs.DirtyCheckTracked:
+		// Skip the scan for untracked files, the slow part on huge checkouts
+		args = append(args, "--untracked-files=no")
+	case vcs.CountIgnored():
+		args = append(args, "--ignored=matching")
+	}
+	cmd := exec.Command("git", args...)
 	cmd.Dir = root
 	out, err := cmd.Output()
 	if err != nil {
@@ -531,7 +748,9 @@ func (g *GitVersionControlSystem) GetDirtyFiles() ([]string, error) {
 	}
 
 	var files []string
-	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+	// Trim only the trailing newline: the first line may start with a space
+	// (e.g. " M file" for an unstaged change)
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
 		if line == "" {
 			continue
 		}
@@ -545,6 +764,10 @@ func (g *GitVersionControlSystem) GetDirtyFiles() ([]string, error) {
 
 // CommitFiles stages and commits the specified files with the given message
 func (g *GitVersionControlSystem) CommitFiles(files []string, message string) error {
+	if cli, ok := g.cliFallback(); ok {
+		return cli.commit(files, message, false)
+	}
+
 	repo, err := g.openRepository()
 	if err != nil {
 		return err
@@ -591,6 +814,10 @@ func (g *GitVersionControlSystem) CommitFiles(files []string, message string) er
 
 // AmendCommit stages the specified files and amends the last commit
 func (g *GitVersionControlSystem) AmendCommit(files []string) error {
+	if cli, ok := g.cliFallback(); ok {
+		return cli.commit(files, "", true)
+	}
+
 	repo, err := g.openRepository()
 	if err != nil {
 		return err
@@ -642,6 +869,9 @@ func (g *GitVersionControlSystem) GetHooksPath() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if g.gitDir != "" {
+		return filepath.Join(g.gitDir, "hooks"), nil
+	}
 	return filepath.Join(root, ".git", "hooks"), nil
 }
 
@@ -693,13 +923,43 @@ func (g *GitVersionControlSystem) isFileIgnored(matcher gitignore.Matcher, fileP
 	return matcher.Match(pathComponents, false)
 }
 
-func (g *GitVersionControlSystem) findGitDir(startPath string) string {
-	currentPath := startPath
+// repoLocation describes where a repository's files live
+type repoLocation struct {
+	root   string // worktree root, or the git directory for bare repositories
+	gitDir string
+	bare   bool
+}
 
+// findRepository locates the repository for startPath. GIT_DIR (and
+// GIT_WORK_TREE) take precedence, as they do for git itself; otherwise the
+// search walks up from startPath for a directory containing .git, or a
+// directory that is itself a bare repository.
+func findRepository(startPath string) (repoLocation, bool) {
+	if gitDir := os.Getenv("GIT_DIR"); gitDir != "" {
+		if !filepath.IsAbs(gitDir) {
+			gitDir = filepath.Join(startPath, gitDir)
+		}
+		gitDir = filepath.Clean(gitDir)
+		if !isGitDir(gitDir) {
+			return repoLocation{}, false
+		}
+		if workTree := os.Getenv("GIT_WORK_TREE"); workTree != "" {
+			return repoLocation{root: filepath.Clean(workTree), gitDir: gitDir}, true
+		}
+		if filepath.Base(gitDir) == ".git" && !isBareConfig(gitDir) {
+			return repoLocation{root: filepath.Dir(gitDir), gitDir: gitDir}, true
+		}
+		return repoLocation{root: gitDir, gitDir: gitDir, bare: true}, true
+	}
+
+	currentPath := startPath
 	for {
 		gitPath := filepath.Join(currentPath, ".git")
 		if info, err := os.Stat(gitPath); err == nil && info.IsDir() {
-			return currentPath
+			return repoLocation{root: currentPath, gitDir: gitPath}, true
+		}
+		if isGitDir(currentPath) && isBareConfig(currentPath) {
+			return repoLocation{root: currentPath, gitDir: currentPath, bare: true}, true
 		}
 
 		parentPath := filepath.Dir(currentPath)
@@ -709,7 +969,49 @@ func (g *GitVersionControlSystem) findGitDir(startPath string) string {
 		currentPath = parentPath
 	}
 
-	return ""
+	return repoLocation{}, false
+}
+
+// findGitDir returns the repository root for startPath, or empty if none
+func (g *GitVersionControlSystem) findGitDir(startPath string) string {
+	loc, ok := findRepository(startPath)
+	if !ok {
+		return ""
+	}
+	return loc.root
+}
+
+// locate finds the repository for startPath and records its location
+func (g *GitVersionControlSystem) locate(startPath string) bool {
+	loc, ok := findRepository(startPath)
+	if !ok {
+		return false
+	}
+	g.repoRoot, g.gitDir, g.bare = loc.root, loc.gitDir, loc.bare
+	return true
+}
+
+// isGitDir reports whether path looks like a git directory (HEAD, objects, refs)
+func isGitDir(path string) bool {
+	for _, name := range []string{"HEAD", "objects", "refs"} {
+		if _, err := os.Stat(filepath.Join(path, name)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// isBareConfig reports whether the git directory's config sets core.bare
+func isBareConfig(gitDir string) bool {
+	data, err := os.ReadFile(filepath.Join(gitDir, "config"))
+	if err != nil {
+		return false
+	}
+	cfg := gitconfig.NewConfig()
+	if err := cfg.Unmarshal(data); err != nil {
+		return false
+	}
+	return cfg.Core.IsBare
 }
 
 func (g *GitVersionControlSystem) openRepository() (Repository, error) {
@@ -723,7 +1025,14 @@ func (g *GitVersionControlSystem) openRepository() (Repository, error) {
 		return nil, err
 	}
 
-	repo, err := g.repoOpener(root)
+	// Bare repositories and custom GIT_DIR locations are opened by git
+	// directory; go-git treats a path without .git as a bare repository
+	openPath := root
+	if g.gitDir != "" && g.gitDir != filepath.Join(root, ".git") {
+		openPath = g.gitDir
+	}
+
+	repo, err := g.repoOpener(openPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open git repository: %w", err)
 	}
@@ -749,6 +1058,10 @@ func (g *GitVersionControlSystem) getTagInfo() (*TagInfo, error) {
 
 // computeTagInfo does the actual work of walking commits to find tag info
 func (g *GitVersionControlSystem) computeTagInfo() (*TagInfo, error) {
+	if cli, ok := g.cliFallback(); ok {
+		return cli.tagInfo()
+	}
+
 	repo, err := g.openRepository()
 	if err != nil {
 		return nil, err
@@ -765,23 +1078,29 @@ func (g *GitVersionControlSystem) computeTagInfo() (*TagInfo, error) {
 		return nil, fmt.Errorf("failed to get commit object: %w", err)
 	}
 
-	// Build a map of commit hash -> tag name (for both lightweight and annotated tags)
-	tagMap := make(map[plumbing.Hash]string)
+	// Build a map of commit hash -> tag (for both lightweight and annotated
+	// tags), skipping tags that do not follow release.tagFormat
+	tagMap := make(map[plumbing.Hash]namedTag)
+	scope := g.tagScope()
 	tags, err := repo.Tags()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tags: %w", err)
 	}
 
 	err = tags.ForEach(func(ref *plumbing.Reference) error {
-		tagName := ref.Name().Short()
+		tag := namedTag{name: ref.Name().Short()}
+		if _, ok := scope.Version(tag.name); !ok {
+			return nil
+		}
 
-		// For annotated tags, get the target commit
-		tagObj, err := repo.TagObject(ref.Hash())
-		if err == nil {
-			tagMap[tagObj.Target] = tagName
-		} else {
-			// Lightweight tag - ref.Hash() is the commit
-			tagMap[ref.Hash()] = tagName
+		// Lightweight tags point at the commit; annotated tags at a tag
+		// object naming it. Of several tags on one commit, keep git's choice.
+		target := ref.Hash()
+		if tagObj, err := repo.TagObject(ref.Hash()); err == nil {
+			target, tag.annotated, tag.tagged = tagObj.Target, true, tagObj.Tagger.When
+		}
+		if existing, ok := tagMap[target]; !ok || tag.preferredTo(existing) {
+			tagMap[target] = tag
 		}
 		return nil
 	})
@@ -794,6 +1113,10 @@ func (g *GitVersionControlSystem) computeTagInfo() (*TagInfo, error) {
 		return &TagInfo{CommitsSinceTag: -1}, nil
 	}
 
+	if vcs.TagDistance() == vcs.TagDistanceDescribe {
+		return describe(repo, headCommit, tagMap)
+	}
+
 	// Walk commits from HEAD until we find a tagged commit (with depth limit)
 	commitIter, err := repo.Log(&git.LogOptions{From: headCommit.Hash})
 	if err != nil {
@@ -804,10 +1127,10 @@ func (g *GitVersionControlSystem) computeTagInfo() (*TagInfo, error) {
 	var result *TagInfo
 
 	err = commitIter.ForEach(func(c *object.Commit) error {
-		if tagName, ok := tagMap[c.Hash]; ok {
+		if tag, ok := tagMap[c.Hash]; ok {
 			result = &TagInfo{
 				CommitsSinceTag:   count,
-				LastTagName:       tagName,
+				LastTagName:       tag.name,
 				LastTagCommitHash: c.Hash.String(),
 			}
 			return errStopIteration
@@ -833,6 +1156,144 @@ func (g *GitVersionControlSystem) computeTagInfo() (*TagInfo, error) {
 	return nil, fmt.Errorf("failed to iterate commits: %w", err)
 }
 
+// ListTags returns every tag in the repository (in release.tagNamespace,
+// when set) with its peeled commit, commit date, and the local branches
+// whose history contains it. Branch membership is computed by walking each
+// branch up to DefaultMaxCommitDepth commits.
+func (g *GitVersionControlSystem) ListTags() ([]vcs.TagRef, error) {
+	if cli, ok := g.cliFallback(); ok {
+		return cli.listTags()
+	}
+
+	repo, err := g.openRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	var result []vcs.TagRef
+	scope := g.tagScope()
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		if !scope.InNamespace(ref.Name().Short()) {
+			return nil
+		}
+		commitHash := ref.Hash()
+		if tagObj, err := repo.TagObject(ref.Hash()); err == nil {
+			commitHash = tagObj.Target
+		}
+
+		tag := vcs.TagRef{
+			Name:   ref.Name().Short(),
+			Commit: commitHash.String(),
+		}
+		if commit, err := repo.CommitObject(commitHash); err == nil {
+			tag.Date = commit.Author.When.UTC()
+		}
+		result = append(result, tag)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate tags: %w", err)
+	}
+
+	if err := g.annotateTagBranches(repo, result); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].Date.Equal(result[j].Date) {
+			return result[i].Date.Before(result[j].Date)
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// ListTagNames returns the short name of every tag in the tag namespace,
+// sorted by name. Unlike ListTags it never resolves commits or walks branches.
+func (g *GitVersionControlSystem) ListTagNames() ([]string, error) {
+	if cli, ok := g.cliFallback(); ok {
+		return cli.listTagNames()
+	}
+
+	repo, err := g.openRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	var names []string
+	scope := g.tagScope()
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		if name := ref.Name().Short(); scope.InNamespace(name) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate tags: %w", err)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// annotateTagBranches fills in TagRef.Branches by walking each local branch
+func (g *GitVersionControlSystem) annotateTagBranches(repo Repository, tags []vcs.TagRef) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	branches, err := repo.Branches()
+	if err != nil {
+		return fmt.Errorf("failed to get branches: %w", err)
+	}
+
+	wanted := make(map[string][]int, len(tags))
+	for i, t := range tags {
+		wanted[t.Commit] = append(wanted[t.Commit], i)
+	}
+
+	err = branches.ForEach(func(branch *plumbing.Reference) error {
+		commitIter, err := repo.Log(&git.LogOptions{From: branch.Hash()})
+		if err != nil {
+			return fmt.Errorf("failed to get commit log for %s: %w", branch.Name().Short(), err)
+		}
+
+		count := 0
+		err = commitIter.ForEach(func(c *object.Commit) error {
+			for _, i := range wanted[c.Hash.String()] {
+				tags[i].Branches = append(tags[i].Branches, branch.Name().Short())
+			}
+			count++
+			if count >= DefaultMaxCommitDepth {
+				return errStopIteration
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopIteration) {
+			return fmt.Errorf("failed to iterate commits: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to iterate branches: %w", err)
+	}
+
+	for i := range tags {
+		sort.Strings(tags[i].Branches)
+	}
+	return nil
+}
+
 // GetHashLength returns the configured hash length from config file or environment variable
 // Priority: 1) Config file, 2) VERSIONATOR_HASH_LENGTH env var, 3) Default (7)
 func GetHashLength() int {
@@ -911,6 +1372,9 @@ func TagExists(tagName string) (bool, error) {
 
 // PushTag pushes a tag to the remote repository
 func (g *GitVersionControlSystem) PushTag(tagName string) error {
+	if err := offline.Require("git push"); err != nil {
+		return err
+	}
 	root, err := g.GetRepositoryRoot()
 	if err != nil {
 		return err
@@ -927,6 +1391,9 @@ func (g *GitVersionControlSystem) PushTag(tagName string) error {
 
 // PushBranch pushes a branch to the remote repository
 func (g *GitVersionControlSystem) PushBranch(branchName string) error {
+	if err := offline.Require("git push"); err != nil {
+		return err
+	}
 	root, err := g.GetRepositoryRoot()
 	if err != nil {
 		return err
@@ -941,6 +1408,22 @@ func (g *GitVersionControlSystem) PushBranch(branchName string) error {
 	return nil
 }
 
+// Checkout switches the working tree to the named local branch
+func (g *GitVersionControlSystem) Checkout(branchName string) error {
+	root, err := g.GetRepositoryRoot()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("git", "checkout", "--quiet", branchName, "--")
+	cmd.Dir = root
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to check out branch: %w: %s", err, string(output))
+	}
+	return nil
+}
+
 // Auto-registration as both VCS and plugin
 func init() {
 	gitVCS := NewGitVCSDefault()
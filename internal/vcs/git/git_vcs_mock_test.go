@@ -5,11 +5,16 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	gitconfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/config"
 	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs"
 )
 
 // Tests using mock repository to verify GitVersionControlSystem behavior
@@ -51,6 +56,44 @@ func TestMock_GetVCSIdentifier_Success(t *testing.T) {
 	}
 }
 
+// TestMock_HashAlgorithm_FollowsObjectFormat validates object format detection.
+//
+// Why: Repositories created with --object-format=sha256 have 64-character
+// commit hashes; a fixed 40-character limit would reject valid lengths.
+//
+// What: Without an object format extension the repository is sha1 and lengths
+// above 40 are rejected; with extensions.objectformat=sha256 the algorithm is
+// sha256 and the limit rises to 64.
+func TestMock_HashAlgorithm_FollowsObjectFormat(t *testing.T) {
+	// Precondition: Repository with a known HEAD commit and default config
+	commitHash := plumbing.NewHash("abc123def456789012345678901234567890dead")
+	mock := NewMockRepository()
+	mock.HeadRef = plumbing.NewHashReference(plumbing.HEAD, commitHash)
+	mock.Commits[commitHash] = MakeTestCommit(commitHash, "test commit", "Test", "test@test.com", time.Now())
+	v := NewGitVCS(MockRepositoryOpener(mock))
+	v.repoRoot = "/fake/path"
+
+	// Action / Expected: SHA-1 defaults
+	if v.HashAlgorithm() != "sha1" || v.MaxIdentifierLength() != 40 {
+		t.Errorf("expected sha1/40, got %s/%d", v.HashAlgorithm(), v.MaxIdentifierLength())
+	}
+	if _, err := v.GetVCSIdentifier(41); err == nil {
+		t.Error("expected error for length 41 in a SHA-1 repository")
+	}
+
+	// Precondition: Repository configured for SHA-256
+	mock.RepoConfig = gitconfig.NewConfig()
+	mock.RepoConfig.Extensions.ObjectFormat = config.SHA256
+
+	// Action / Expected: SHA-256 algorithm and length limit
+	if v.HashAlgorithm() != "sha256" || v.MaxIdentifierLength() != 64 {
+		t.Errorf("expected sha256/64, got %s/%d", v.HashAlgorithm(), v.MaxIdentifierLength())
+	}
+	if _, err := v.GetVCSIdentifier(65); err == nil || !strings.Contains(err.Error(), "between 1 and 64") {
+		t.Errorf("expected length 65 to be rejected, got %v", err)
+	}
+}
+
 // TestMock_IsWorkingDirectoryClean_Clean validates clean working directory detection.
 //
 // Why: Clean working directory status is critical for release workflows - releases should
@@ -645,6 +688,76 @@ func TestMock_GetDirtyFiles_Success(t *testing.T) {
 	}
 }
 
+// TestMock_GetDirtyFiles_TrackedMode_IgnoresUntracked validates the fast
+// dirty check.
+//
+// Why: Searching for untracked files dominates git status on very large
+// checkouts; the tracked mode trades them for speed.
+//
+// What: With an untracked and a modified file, the tracked mode reports only
+// the modified file; the full mode reports both.
+func TestMock_GetDirtyFiles_TrackedMode_IgnoresUntracked(t *testing.T) {
+	// Precondition: Real git repo with a modified and an untracked file
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	h.CreateCommit("initial commit")
+	os.WriteFile(filepath.Join(h.dir, "test.txt"), []byte("modified"), 0644)
+	os.WriteFile(filepath.Join(h.dir, "untracked.txt"), []byte("new"), 0644)
+
+	v := NewGitVCS(DefaultRepositoryOpener)
+	v.repoRoot = h.dir
+	defer vcs.SetDirtyCheck("")
+
+	// Action
+	vcs.SetDirtyCheck(vcs.DirtyCheckTracked)
+	tracked, trackedErr := v.GetDirtyFiles()
+	vcs.SetDirtyCheck(vcs.DirtyCheckFull)
+	full, fullErr := v.GetDirtyFiles()
+
+	// Expected
+	if trackedErr != nil || len(tracked) != 1 || tracked[0] != "test.txt" {
+		t.Errorf("tracked mode: expected [test.txt], got %v, %v", tracked, trackedErr)
+	}
+	if fullErr != nil || len(full) != 2 {
+		t.Errorf("full mode: expected 2 files, got %v, %v", full, fullErr)
+	}
+}
+
+// TestMock_GetDirtyFiles_CountIgnored validates counting ignored files.
+//
+// Why: Dirty must agree with git status by default, so ignored build output
+// never marks a build dirty; teams that want any stray file to count can
+// opt in.
+//
+// What: An ignored file is not dirty by default and is listed with
+// CountIgnored.
+func TestMock_GetDirtyFiles_CountIgnored(t *testing.T) {
+	// Precondition: Real git repo with an ignored file
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	os.WriteFile(filepath.Join(h.dir, ".gitignore"), []byte("*.log\n"), 0644)
+	exec.Command("git", "-C", h.dir, "add", ".gitignore").Run()
+	h.CreateCommit("initial commit")
+	os.WriteFile(filepath.Join(h.dir, "build.log"), []byte("output"), 0644)
+
+	v := NewGitVCS(DefaultRepositoryOpener)
+	v.repoRoot = h.dir
+	defer vcs.SetCountIgnored(false)
+
+	// Action
+	respected, respectedErr := v.GetDirtyFiles()
+	vcs.SetCountIgnored(true)
+	counted, countedErr := v.GetDirtyFiles()
+
+	// Expected
+	if respectedErr != nil || len(respected) != 0 {
+		t.Errorf("default: expected no dirty files, got %v, %v", respected, respectedErr)
+	}
+	if countedErr != nil || len(counted) != 1 || counted[0] != "build.log" {
+		t.Errorf("CountIgnored: expected [build.log], got %v, %v", counted, countedErr)
+	}
+}
+
 // TestMock_GetCommitMessagesSinceTag_WithCommits validates message collection with commits.
 //
 // Why: Commit messages since the last tag are used for changelog generation and
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
 	"github.com/benjaminabbitt/versionator/internal/vcs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -376,6 +377,52 @@ func TestGetCommitAuthorEmail_ReturnsAuthorEmail(t *testing.T) {
 	}
 }
 
+// TestLastChange_VersionFile_ReturnsLastModifyingCommit validates file history.
+//
+// Why: {{VersionFileLastChangedBy}} attributes the last version bump, which is
+// usually not the HEAD commit.
+//
+// What: VERSION committed by one author, then an unrelated commit by another;
+// LastChange reports the first. A path with no history yields a zero value.
+func TestLastChange_VersionFile_ReturnsLastModifyingCommit(t *testing.T) {
+	// Precondition: VERSION committed by "Release Manager", then a later commit
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	bumped := time.Date(2024, 1, 10, 9, 15, 0, 0, time.UTC)
+	if err := os.WriteFile(filepath.Join(h.dir, "VERSION"), []byte("1.2.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wt, err := h.repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wt.Add("VERSION"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wt.Commit("chore: bump", &git.CommitOptions{
+		Author: &object.Signature{Name: "Release Manager", Email: "rm@example.com", When: bumped},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	h.CreateCommit("feat: unrelated")
+
+	// Action
+	v := NewGitVCSDefault()
+	change, err := v.LastChange("VERSION")
+	untracked, untrackedErr := v.LastChange("missing.txt")
+
+	// Expected
+	if err != nil {
+		t.Fatalf("LastChange() error: %v", err)
+	}
+	if change.Author != "Release Manager" || !change.Date.Equal(bumped) {
+		t.Errorf("expected Release Manager at %v, got %q at %v", bumped, change.Author, change.Date)
+	}
+	if untrackedErr != nil || untracked.Author != "" || !untracked.Date.IsZero() {
+		t.Errorf("expected zero change for untracked file, got %+v (%v)", untracked, untrackedErr)
+	}
+}
+
 // =============================================================================
 // KEY VARIATIONS
 // Tests demonstrating important alternate flows and common use patterns.
@@ -1162,6 +1209,51 @@ func TestCreateBranch_MultipleBranches(t *testing.T) {
 	}
 }
 
+// TestCheckout_SwitchesBranch validates checking out a branch.
+//
+// Why: cut-release commits VERSION on the release branch and then on
+// trunk, so each commit must land on the branch checked out for it.
+//
+// What: Check out a created branch, commit on it, and switch back; the
+// commit moves only the checked out branch.
+func TestCheckout_SwitchesBranch(t *testing.T) {
+	// Precondition: A git repository on its default branch, and a release branch
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	h.CreateCommit("initial commit")
+	vcs := NewGitVCSDefault()
+	trunk, err := vcs.GetBranchName()
+	if err != nil {
+		t.Fatalf("GetBranchName() error: %v", err)
+	}
+	if err := vcs.CreateBranch("release/1.3"); err != nil {
+		t.Fatalf("CreateBranch() error: %v", err)
+	}
+
+	// Action: Commit on the release branch, then return to trunk
+	if err := vcs.Checkout("release/1.3"); err != nil {
+		t.Fatalf("Checkout() error: %v", err)
+	}
+	h.CreateCommit("on release")
+	onRelease, _ := vcs.GetBranchName()
+	if err := vcs.Checkout(trunk); err != nil {
+		t.Fatalf("Checkout() error: %v", err)
+	}
+
+	// Expected: The commit is on the release branch only
+	if onRelease != "release/1.3" {
+		t.Errorf("branch after checkout = %q, want release/1.3", onRelease)
+	}
+	if current, _ := vcs.GetBranchName(); current != trunk {
+		t.Errorf("branch = %q, want %q", current, trunk)
+	}
+	releaseCommit, _ := vcs.GetBranchCommit("release/1.3")
+	trunkCommit, _ := vcs.GetBranchCommit(trunk)
+	if releaseCommit == trunkCommit {
+		t.Error("commit on the release branch moved trunk")
+	}
+}
+
 // =============================================================================
 // MINUTIAE
 // Tests covering configuration, environment variables, and utility functions.
@@ -1848,3 +1940,180 @@ func TestExtractCommitInfo_ExtractsAllFields(t *testing.T) {
 		t.Errorf("Message mismatch: expected multiline message, got %q", info.Message)
 	}
 }
+
+// TestListTags_MultipleBranches_ReturnsTagsWithBranches validates that
+// ListTags enumerates every tag with its commit and containing branches.
+//
+// Why: The graph command renders release history per branch, which requires
+// all tags (not just the nearest one) along with branch membership.
+//
+// What: Create an annotated tag, a side branch, and a lightweight tag; verify
+// both tags are returned in order with peeled commits and correct branches.
+func TestListTags_MultipleBranches_ReturnsTagsWithBranches(t *testing.T) {
+	// Precondition: v1.0.0 (annotated) on a commit shared by master and release,
+	// v1.1.0 (lightweight) on a later commit only on master
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	h.CreateCommit("initial commit")
+	h.CreateTag("v1.0.0", "Release 1.0.0")
+	head, _ := h.repo.Head()
+	firstHash := head.Hash()
+	if err := h.repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/release", firstHash)); err != nil {
+		t.Fatalf("failed to create branch: %v", err)
+	}
+	h.CreateCommit("second commit")
+	h.CreateLightweightTag("v1.1.0")
+
+	// Action: List all tags
+	tags, err := NewGitVCSDefault().ListTags()
+
+	// Expected: Both tags, peeled to commits, with their containing branches
+	if err != nil {
+		t.Fatalf("ListTags() error: %v", err)
+	}
+	if len(tags) != 2 {
+		t.Fatalf("expected 2 tags, got %d: %+v", len(tags), tags)
+	}
+	if tags[0].Name != "v1.0.0" || tags[1].Name != "v1.1.0" {
+		t.Errorf("unexpected tag order: %s, %s", tags[0].Name, tags[1].Name)
+	}
+	if tags[0].Commit != firstHash.String() {
+		t.Errorf("annotated tag not peeled: expected %s, got %s", firstHash, tags[0].Commit)
+	}
+	if tags[0].Date.IsZero() {
+		t.Error("expected commit date to be set")
+	}
+	if got := tags[0].Branches; len(got) != 2 || got[0] != "master" || got[1] != "release" {
+		t.Errorf("v1.0.0 branches = %v, want [master release]", got)
+	}
+	if got := tags[1].Branches; len(got) != 1 || got[0] != "master" {
+		t.Errorf("v1.1.0 branches = %v, want [master]", got)
+	}
+}
+
+// TestListTags_NoTags_ReturnsEmpty validates ListTags on an untagged repository.
+//
+// Why: New repositories have no releases; this must not be an error.
+//
+// What: ListTags returns no tags and no error.
+func TestListTags_NoTags_ReturnsEmpty(t *testing.T) {
+	// Precondition: A repository with a commit but no tags
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	h.CreateCommit("initial commit")
+
+	// Action: List all tags
+	tags, err := NewGitVCSDefault().ListTags()
+
+	// Expected: Empty result, no error
+	if err != nil {
+		t.Fatalf("ListTags() error: %v", err)
+	}
+	if len(tags) != 0 {
+		t.Errorf("expected no tags, got %+v", tags)
+	}
+}
+
+// TestGetLastTag_TagFormat_SkipsForeignTags validates that tag discovery
+// honours release.tagFormat.
+//
+// Why: In a monorepo every component tags the same history; counting from
+// another component's tag would reset CommitsSinceTag and IsReleaseBuild.
+//
+// What: myapp-v1.0.0 is one commit behind HEAD and other-v2.0.0 is at HEAD;
+// with the format "myapp-v{{Version}}" the last tag is myapp-v1.0.0, one
+// commit back.
+func TestGetLastTag_TagFormat_SkipsForeignTags(t *testing.T) {
+	// Precondition: Component tags on consecutive commits
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	h.CreateCommit("first")
+	h.CreateLightweightTag("myapp-v1.0.0")
+	h.CreateCommit("second")
+	h.CreateLightweightTag("other-v2.0.0")
+	if err := tagformat.Set("myapp-v{{Version}}"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = tagformat.Set("") }()
+
+	// Action
+	g := NewGitVCSDefault()
+	tag, err := g.GetLastTag()
+	count, countErr := g.GetCommitsSinceTag()
+
+	// Expected
+	if err != nil || countErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", err, countErr)
+	}
+	if tag != "myapp-v1.0.0" || count != 1 {
+		t.Errorf("GetLastTag() = %q with %d commits since, want myapp-v1.0.0 with 1", tag, count)
+	}
+}
+
+// TestTagNamespace_IsolatesTeams validates release.tagNamespace.
+//
+// Why: Teams sharing a repository must count commits from, and list, only
+// their own releases.
+//
+// What: team-a/v1.0.0 is one commit behind HEAD, with team-b/v2.0.0 and
+// v3.0.0 at HEAD; in namespace team-a the last tag is team-a/v1.0.0, one
+// commit back, and it is the only tag listed.
+func TestTagNamespace_IsolatesTeams(t *testing.T) {
+	// Precondition: Tags of two teams and an unnamespaced tag
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	h.CreateCommit("first")
+	h.CreateLightweightTag("team-a/v1.0.0")
+	h.CreateCommit("second")
+	h.CreateLightweightTag("team-b/v2.0.0")
+	h.CreateTag("v3.0.0", "Release 3.0.0")
+	tagformat.SetNamespace("team-a")
+	defer tagformat.SetNamespace("")
+
+	// Action
+	g := NewGitVCSDefault()
+	tag, err := g.GetLastTag()
+	count, countErr := g.GetCommitsSinceTag()
+	names, namesErr := g.ListTagNames()
+	tags, tagsErr := g.ListTags()
+
+	// Expected
+	if err != nil || countErr != nil || namesErr != nil || tagsErr != nil {
+		t.Fatalf("unexpected errors: %v, %v, %v, %v", err, countErr, namesErr, tagsErr)
+	}
+	if tag != "team-a/v1.0.0" || count != 1 {
+		t.Errorf("GetLastTag() = %q with %d commits since, want team-a/v1.0.0 with 1", tag, count)
+	}
+	if len(names) != 1 || names[0] != "team-a/v1.0.0" {
+		t.Errorf("ListTagNames() = %v, want [team-a/v1.0.0]", names)
+	}
+	if len(tags) != 1 || tags[0].Name != "team-a/v1.0.0" {
+		t.Errorf("ListTags() = %+v, want only team-a/v1.0.0", tags)
+	}
+}
+
+// TestListTagNames_ReturnsSortedNames validates the cheap tag name listing.
+//
+// Why: Rendering PreviousVersion runs on every emit, so it must not pay for
+// the commit and branch resolution ListTags performs.
+//
+// What: Annotated and lightweight tags are both listed, sorted by name.
+func TestListTagNames_ReturnsSortedNames(t *testing.T) {
+	// Precondition: One annotated and one lightweight tag
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	h.CreateCommit("initial commit")
+	h.CreateLightweightTag("v1.1.0")
+	h.CreateTag("v1.0.0", "Release 1.0.0")
+
+	// Action: List tag names
+	names, err := NewGitVCSDefault().ListTagNames()
+
+	// Expected: Both names, sorted
+	if err != nil {
+		t.Fatalf("ListTagNames() error: %v", err)
+	}
+	if len(names) != 2 || names[0] != "v1.0.0" || names[1] != "v1.1.0" {
+		t.Errorf("ListTagNames() = %v, want [v1.0.0 v1.1.0]", names)
+	}
+}
@@ -0,0 +1,112 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+)
+
+// IsIgnored reports whether the ignore rules match path, tracked or not.
+// Uses the git CLI, which applies every ignore source (.gitignore files,
+// .git/info/exclude, core.excludesFile).
+func (g *GitVersionControlSystem) IsIgnored(path string) (bool, error) {
+	root, rel, err := g.repoPath(path)
+	if err != nil {
+		return false, err
+	}
+	_, err = (&gitCLI{root: root}).run(nil, "check-ignore", "--quiet", "--no-index", "--", rel)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// IsTracked reports whether path is in the index
+func (g *GitVersionControlSystem) IsTracked(path string) (bool, error) {
+	root, rel, err := g.repoPath(path)
+	if err != nil {
+		return false, err
+	}
+	out, err := (&gitCLI{root: root}).run(nil, "ls-files", "--cached", "--", rel)
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+// Ignore appends a rule anchored at the repository root that matches
+// exactly path to the root .gitignore, creating it when missing
+func (g *GitVersionControlSystem) Ignore(path string) error {
+	root, rel, err := g.repoPath(path)
+	if err != nil {
+		return err
+	}
+	gitignore := filepath.Join(root, ".gitignore")
+	existing, err := os.ReadFile(gitignore)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read .gitignore: %w", err)
+	}
+
+	rule := "/" + escapeIgnoreRule(rel) + "\n"
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		rule = "\n" + rule
+	}
+	f, err := os.OpenFile(gitignore, os.O_APPEND|os.O_CREATE|os.O_WRONLY, fileperm.Mode())
+	if err != nil {
+		return fmt.Errorf("failed to open .gitignore: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(rule); err != nil {
+		return fmt.Errorf("failed to update .gitignore: %w", err)
+	}
+	return nil
+}
+
+// escapeIgnoreRule backslash-escapes rel so a .gitignore rule matches it
+// literally: glob characters, characters special at the start of a rule, and
+// leading and trailing spaces, which git would otherwise strip
+func escapeIgnoreRule(rel string) string {
+	leading := len(rel) - len(strings.TrimLeft(rel, " "))
+	trailing := len(strings.TrimRight(rel, " "))
+	var b strings.Builder
+	for i, r := range rel {
+		switch {
+		case strings.ContainsRune(`\*?[!#`, r):
+			b.WriteByte('\\')
+		case r == ' ' && (i < leading || i >= trailing):
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// repoPath returns the repository root and path relative to it, with
+// forward slashes, resolving symlinks so both agree
+func (g *GitVersionControlSystem) repoPath(path string) (root, rel string, err error) {
+	root, err = g.GetRepositoryRoot()
+	if err != nil {
+		return "", "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", "", err
+	}
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+		abs = filepath.Join(dir, filepath.Base(abs))
+	}
+	rel, err = filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", "", fmt.Errorf("%s is outside the repository", path)
+	}
+	return root, filepath.ToSlash(rel), nil
+}
@@ -0,0 +1,108 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestIgnoreChecker_TrackedIgnoredAndAdded validates the ignore capability
+// behind emit's .gitignore guard.
+//
+// Why: Generated files stamped at build time must stay out of commits;
+// emit has to tell an ignored output from an untracked or committed one.
+//
+// What: The committed test.txt is tracked and not ignored; a new file is
+// neither until Ignore adds an anchored rule, after which it is ignored and
+// an existing .gitignore without a final newline is kept intact.
+func TestIgnoreChecker_TrackedIgnoredAndAdded(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	// Precondition: A committed file, a generated file, and a .gitignore
+	// without a final newline
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	h.CreateCommit("base")
+	_ = os.MkdirAll(filepath.Join(h.dir, "gen"), 0755)
+	_ = os.WriteFile(filepath.Join(h.dir, "gen", "version.go"), []byte("package gen\n"), 0644)
+	_ = os.WriteFile(filepath.Join(h.dir, ".gitignore"), []byte("*.log"), 0644)
+	g := NewGitVCSDefault()
+
+	// Action / Expected: Tracked file
+	if tracked, err := g.IsTracked("test.txt"); err != nil || !tracked {
+		t.Errorf("IsTracked(test.txt) = %v, %v; want true", tracked, err)
+	}
+	if ignored, err := g.IsIgnored("test.txt"); err != nil || ignored {
+		t.Errorf("IsIgnored(test.txt) = %v, %v; want false", ignored, err)
+	}
+
+	// Action / Expected: Generated file before and after Ignore
+	generated := filepath.Join(h.dir, "gen", "version.go")
+	if tracked, err := g.IsTracked(generated); err != nil || tracked {
+		t.Errorf("IsTracked(gen/version.go) = %v, %v; want false", tracked, err)
+	}
+	if ignored, err := g.IsIgnored(generated); err != nil || ignored {
+		t.Errorf("IsIgnored(gen/version.go) = %v, %v; want false before Ignore", ignored, err)
+	}
+	if err := g.Ignore(generated); err != nil {
+		t.Fatalf("Ignore() error: %v", err)
+	}
+	if ignored, err := g.IsIgnored(generated); err != nil || !ignored {
+		t.Errorf("IsIgnored(gen/version.go) = %v, %v; want true after Ignore", ignored, err)
+	}
+	content, _ := os.ReadFile(filepath.Join(h.dir, ".gitignore"))
+	if string(content) != "*.log\n/gen/version.go\n" {
+		t.Errorf(".gitignore = %q", content)
+	}
+}
+
+// TestIgnore_SpecialCharacters_MatchesExactPath validates rule escaping.
+//
+// Why: An output named like a glob would otherwise produce a rule that
+// ignores other files, or misses the output itself.
+//
+// What: After Ignore, each file with glob characters, a leading '#' or '!',
+// or leading and trailing spaces is ignored, and a sibling the unescaped
+// rules would match is not.
+func TestIgnore_SpecialCharacters_MatchesExactPath(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	if runtime.GOOS == "windows" {
+		t.Skip("file names with glob characters are not valid on Windows")
+	}
+
+	// Precondition: Files named with special characters, and a sibling
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	h.CreateCommit("base")
+	_ = os.MkdirAll(filepath.Join(h.dir, "gen"), 0755)
+	names := []string{"*.go", "v[1].go", "#notes", "!keep", " lead.txt", "trail.txt "}
+	for _, name := range append(names, "other.go", "v1.go") {
+		_ = os.WriteFile(filepath.Join(h.dir, "gen", name), []byte("x\n"), 0644)
+	}
+	g := NewGitVCSDefault()
+
+	// Action
+	for _, name := range names {
+		if err := g.Ignore(filepath.Join(h.dir, "gen", name)); err != nil {
+			t.Fatalf("Ignore(%q) error: %v", name, err)
+		}
+	}
+
+	// Expected
+	for _, name := range names {
+		if ignored, err := g.IsIgnored(filepath.Join(h.dir, "gen", name)); err != nil || !ignored {
+			t.Errorf("IsIgnored(%q) = %v, %v; want true", name, ignored, err)
+		}
+	}
+	for _, name := range []string{"other.go", "v1.go"} {
+		if ignored, err := g.IsIgnored(filepath.Join(h.dir, "gen", name)); err != nil || ignored {
+			t.Errorf("IsIgnored(%q) = %v, %v; want false", name, ignored, err)
+		}
+	}
+}
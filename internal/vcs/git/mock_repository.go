@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/storer"
@@ -48,6 +49,10 @@ type MockRepository struct {
 	// Worktree configuration
 	MockWorktree *MockWorktree
 	WorktreeErr  error
+
+	// Config configuration (nil RepoConfig means an empty default config)
+	RepoConfig *gitconfig.Config
+	ConfigErr  error
 }
 
 // NewMockRepository creates a new MockRepository with sensible defaults.
@@ -120,6 +125,16 @@ func (m *MockRepository) Worktree() (Worktree, error) {
 	return m.MockWorktree, nil
 }
 
+func (m *MockRepository) Config() (*gitconfig.Config, error) {
+	if m.ConfigErr != nil {
+		return nil, m.ConfigErr
+	}
+	if m.RepoConfig == nil {
+		return gitconfig.NewConfig(), nil
+	}
+	return m.RepoConfig, nil
+}
+
 // MockWorktree is a test double for the Worktree interface.
 type MockWorktree struct {
 	StatusResult git.Status
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/storer"
@@ -38,6 +39,9 @@ type Repository interface {
 
 	// Worktree returns the repository worktree
 	Worktree() (Worktree, error)
+
+	// Config returns the repository configuration
+	Config() (*gitconfig.Config, error)
 }
 
 // Worktree abstracts git worktree operations for testability.
@@ -109,6 +113,10 @@ func (r *GoGitRepository) Worktree() (Worktree, error) {
 	return &GoGitWorktree{wt: wt}, nil
 }
 
+func (r *GoGitRepository) Config() (*gitconfig.Config, error) {
+	return r.repo.Config()
+}
+
 // GoGitWorktree wraps go-git's *git.Worktree to implement the Worktree interface.
 type GoGitWorktree struct {
 	wt *git.Worktree
@@ -0,0 +1,286 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+)
+
+// sha256HexLength is the length of a hex-encoded SHA-256 object ID
+const sha256HexLength = 64
+
+// gitCLI runs hash-dependent operations through the git command line.
+//
+// go-git selects its hash size at compile time, so a single binary cannot read
+// both SHA-1 and SHA-256 repositories: on SHA-256 repositories (created with
+// `git init --object-format=sha256`) it truncates object IDs and fails to load
+// commits. Reference names and configuration are still read correctly, so
+// only operations that need object IDs or commit contents are routed here.
+type gitCLI struct {
+	root string
+	tags tagformat.Scope // tag format and namespace tags are read under
+}
+
+// cliFallback returns a git CLI runner when the repository uses an object
+// format go-git cannot read; ok is false for SHA-1 repositories
+func (g *GitVersionControlSystem) cliFallback() (*gitCLI, bool) {
+	if g.HashAlgorithm() != vcs.HashAlgorithmSHA256 {
+		return nil, false
+	}
+	root, err := g.GetRepositoryRoot()
+	if err != nil {
+		return nil, false
+	}
+	return &gitCLI{root: root, tags: g.tagScope()}, true
+}
+
+// run executes git with args in the repository root and returns trimmed stdout
+func (c *gitCLI) run(env []string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = c.root
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// headField returns a single `git log` format field for HEAD
+func (c *gitCLI) headField(format string) (string, error) {
+	return c.run(nil, "log", "-1", "--format="+format, "HEAD")
+}
+
+// commitOf resolves a revision to its full commit ID
+func (c *gitCLI) commitOf(rev string) (string, error) {
+	return c.run(nil, "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+}
+
+// headAuthorEnv returns environment overrides that attribute new objects to
+// HEAD's author, matching the go-git code path
+func (c *gitCLI) headAuthorEnv() ([]string, error) {
+	name, err := c.headField("%an")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit object: %w", err)
+	}
+	email, err := c.headField("%ae")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit object: %w", err)
+	}
+	return []string{
+		"GIT_AUTHOR_NAME=" + name, "GIT_AUTHOR_EMAIL=" + email,
+		"GIT_COMMITTER_NAME=" + name, "GIT_COMMITTER_EMAIL=" + email,
+	}, nil
+}
+
+// commitDate returns HEAD's author date in UTC
+func (c *gitCLI) commitDate() (time.Time, error) {
+	out, err := c.headField("%aI")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get commit object: %w", err)
+	}
+	date, err := time.Parse(time.RFC3339, out)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse commit date %q: %w", out, err)
+	}
+	return date.UTC(), nil
+}
+
+// tagInfo finds the nearest tag reachable from HEAD that follows
+// release.tagFormat
+func (c *gitCLI) tagInfo() (*TagInfo, error) {
+	listArgs := []string{"tag", "--list"}
+	describeArgs := []string{"describe", "--tags", "--long"}
+	if glob := c.tags.Glob(); glob != "" {
+		listArgs = append(listArgs, glob)
+		describeArgs = append(describeArgs, "--match", glob)
+	}
+
+	tags, err := c.run(nil, listArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	if tags == "" {
+		return &TagInfo{CommitsSinceTag: -1}, nil
+	}
+
+	described, err := c.run(nil, append(describeArgs, "HEAD")...)
+	if err != nil {
+		// No tagged ancestor: count commits up to the depth limit
+		count, err := c.run(nil, "rev-list", "--count", "--max-count="+strconv.Itoa(DefaultMaxCommitDepth), "HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate commits: %w", err)
+		}
+		n, _ := strconv.Atoi(count)
+		return &TagInfo{CommitsSinceTag: n}, nil
+	}
+
+	// Format: <tag>-<count>-g<abbrev>; the tag itself may contain dashes
+	parts := strings.Split(described, "-")
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("unexpected git describe output %q", described)
+	}
+	tagName := strings.Join(parts[:len(parts)-2], "-")
+	count, err := strconv.Atoi(parts[len(parts)-2])
+	if err != nil {
+		return nil, fmt.Errorf("unexpected git describe output %q", described)
+	}
+	commit, err := c.commitOf("refs/tags/" + tagName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to peel tag %q to commit: %w", tagName, err)
+	}
+	return &TagInfo{CommitsSinceTag: count, LastTagName: tagName, LastTagCommitHash: commit}, nil
+}
+
+// commitMessages returns the messages of the last n commits, newest first
+func (c *gitCLI) commitMessages(n int) ([]string, error) {
+	out, err := c.run(nil, "log", "-z", "--format=%B", "-n", strconv.Itoa(n), "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit log: %w", err)
+	}
+	messages := make([]string, 0, n)
+	for _, msg := range strings.Split(out, "\x00") {
+		if msg != "" {
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
+// lastChange returns the author and date of the last commit that modified path
+func (c *gitCLI) lastChange(path string) (vcs.FileChange, error) {
+	out, err := c.run(nil, "log", "-1", "--format=%an%x00%aI", "HEAD", "--", path)
+	if err != nil {
+		return vcs.FileChange{}, fmt.Errorf("failed to get commit log: %w", err)
+	}
+	if out == "" {
+		return vcs.FileChange{}, nil
+	}
+	author, iso, _ := strings.Cut(out, "\x00")
+	date, err := time.Parse(time.RFC3339, iso)
+	if err != nil {
+		return vcs.FileChange{}, fmt.Errorf("failed to parse commit date %q: %w", iso, err)
+	}
+	return vcs.FileChange{Author: author, Date: date.UTC()}, nil
+}
+
+// createTag creates an annotated tag at HEAD, tagged by HEAD's author. The
+// message keeps lines starting with '#' (Markdown headings of an embedded
+// changelog), as go-git does, rather than stripping them as comments.
+func (c *gitCLI) createTag(tagName, message string) error {
+	env, err := c.headAuthorEnv()
+	if err != nil {
+		return err
+	}
+	if _, err := c.run(env, "tag", "-a", "--cleanup=whitespace", tagName, "-m", message, "HEAD"); err != nil {
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+	return nil
+}
+
+// createBranch points refs/heads/<branchName> at HEAD
+func (c *gitCLI) createBranch(branchName string) error {
+	if _, err := c.run(nil, "update-ref", "refs/heads/"+branchName, "HEAD"); err != nil {
+		return fmt.Errorf("failed to create branch: %w", err)
+	}
+	return nil
+}
+
+// commit stages files and commits them as HEAD's author; with amend, HEAD's
+// message is reused and the author date is reset
+func (c *gitCLI) commit(files []string, message string, amend bool) error {
+	env, err := c.headAuthorEnv()
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if _, err := c.run(nil, "add", "--", file); err != nil {
+			return fmt.Errorf("failed to stage file %s: %w", file, err)
+		}
+	}
+
+	args := []string{"commit", "--no-verify", "-m", message}
+	if amend {
+		args = []string{"commit", "--no-verify", "--amend", "--no-edit", "--date=now"}
+	}
+	if _, err := c.run(env, args...); err != nil {
+		if amend {
+			return fmt.Errorf("failed to amend commit: %w", err)
+		}
+		return fmt.Errorf("failed to create commit: %w", err)
+	}
+	return nil
+}
+
+// tagRefs returns the ref prefix holding the visible tags: refs/tags, or
+// the namespace under it
+func (c *gitCLI) tagRefs() string {
+	return strings.TrimSuffix("refs/tags/"+c.tags.Namespace(), "/")
+}
+
+// listTagNames returns the short name of every tag, sorted by name
+func (c *gitCLI) listTagNames() ([]string, error) {
+	out, err := c.run(nil, "for-each-ref", c.tagRefs(), "--sort=refname", "--format=%(refname:short)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// listTags returns every tag with its peeled commit, date, and containing
+// local branches
+func (c *gitCLI) listTags() ([]vcs.TagRef, error) {
+	out, err := c.run(nil, "for-each-ref", c.tagRefs(), "--format=%(refname:short)%00%(objectname)%00%(*objectname)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	var result []vcs.TagRef
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 3 {
+			continue
+		}
+		tag := vcs.TagRef{Name: fields[0], Commit: fields[1]}
+		if fields[2] != "" {
+			tag.Commit = fields[2]
+		}
+		if date, err := c.run(nil, "log", "-1", "--format=%aI", tag.Commit); err == nil {
+			if t, err := time.Parse(time.RFC3339, date); err == nil {
+				tag.Date = t.UTC()
+			}
+		}
+		branches, err := c.run(nil, "for-each-ref", "refs/heads", "--contains="+tag.Commit, "--format=%(refname:short)")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get branches: %w", err)
+		}
+		if branches != "" {
+			tag.Branches = strings.Split(branches, "\n")
+			sort.Strings(tag.Branches)
+		}
+		result = append(result, tag)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].Date.Equal(result[j].Date) {
+			return result[i].Date.Before(result[j].Date)
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
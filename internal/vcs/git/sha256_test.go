@@ -0,0 +1,221 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+)
+
+// newSHA256Repo creates a repository with `git init --object-format=sha256`
+// and returns a GitVersionControlSystem rooted at it. The test is skipped when
+// the installed git cannot create SHA-256 repositories.
+func newSHA256Repo(t *testing.T) (*GitVersionControlSystem, func(args ...string) string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Fixture Author", "GIT_AUTHOR_EMAIL=fixture@example.com",
+			"GIT_COMMITTER_NAME=Fixture Author", "GIT_COMMITTER_EMAIL=fixture@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s failed: %v: %s", strings.Join(args, " "), err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	if out, err := exec.Command("git", "init", "--object-format=sha256", dir).CombinedOutput(); err != nil {
+		t.Skipf("git does not support SHA-256 repositories: %v: %s", err, out)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "VERSION"), []byte("1.0.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "VERSION")
+	run("commit", "-m", "initial commit")
+
+	v := NewGitVCSDefault()
+	v.repoRoot = dir
+	return v, run
+}
+
+// TestSHA256_ReadOperations_ReturnFullLengthHashes validates reads on a
+// SHA-256 fixture repository.
+//
+// Why: go-git is compiled for SHA-1 and truncates or fails to resolve SHA-256
+// object IDs; Hash/ShortHash/MediumHash and tag distance must still be right.
+//
+// What: The identifier is the full 64-char HEAD ID, short windows are its
+// prefixes, commit metadata is read, and tag distance, messages, and tag
+// commits resolve correctly.
+func TestSHA256_ReadOperations_ReturnFullLengthHashes(t *testing.T) {
+	// Precondition: SHA-256 repo with an annotated tag and two later commits
+	v, run := newSHA256Repo(t)
+	run("tag", "-a", "v1.0.0", "-m", "Release 1.0.0")
+	tagCommit := run("rev-parse", "HEAD")
+	run("commit", "--allow-empty", "-m", "feat: second")
+	run("commit", "--allow-empty", "-m", "fix: third")
+	head := run("rev-parse", "HEAD")
+
+	// Action: Read identifiers and metadata
+	full, err := v.GetVCSIdentifier(64)
+	if err != nil {
+		t.Fatalf("GetVCSIdentifier(64) failed: %v", err)
+	}
+	short, _ := v.GetVCSIdentifier(7)
+	medium, _ := v.GetVCSIdentifier(12)
+	author, _ := v.GetCommitAuthor()
+	date, dateErr := v.GetCommitDate()
+	count, _ := v.GetCommitsSinceTag()
+	lastTag, _ := v.GetLastTag()
+	lastTagCommit, _ := v.GetLastTagCommit()
+	messages, _ := v.GetCommitMessagesSinceTag()
+	peeled, _ := v.GetTagCommit("v1.0.0")
+	versionChange, _ := v.LastChange("VERSION")
+
+	// Expected: Full-length IDs and correct tag information
+	if v.HashAlgorithm() != "sha256" || len(full) != 64 || full != head {
+		t.Errorf("expected sha256 HEAD %s, got %s (%s)", head, full, v.HashAlgorithm())
+	}
+	if short != head[:7] || medium != head[:12] {
+		t.Errorf("unexpected short/medium hashes %q/%q", short, medium)
+	}
+	if author != "Fixture Author" || dateErr != nil || date.IsZero() {
+		t.Errorf("unexpected author/date %q/%v (%v)", author, date, dateErr)
+	}
+	if count != 2 || lastTag != "v1.0.0" || lastTagCommit != tagCommit || peeled != tagCommit {
+		t.Errorf("unexpected tag info: count=%d tag=%q commit=%q peeled=%q", count, lastTag, lastTagCommit, peeled)
+	}
+	if len(messages) != 2 || strings.TrimSpace(messages[0]) != "fix: third" {
+		t.Errorf("unexpected messages %q", messages)
+	}
+	if versionChange.Author != "Fixture Author" || versionChange.Date.IsZero() {
+		t.Errorf("unexpected VERSION change %+v", versionChange)
+	}
+}
+
+// TestSHA256_WriteOperations_CreateValidObjects validates writes on a SHA-256
+// fixture repository.
+//
+// Why: Writing refs with truncated IDs would corrupt the repository; tags,
+// branches, and commits must point at real SHA-256 objects.
+//
+// What: CreateTag, CreateBranch, CommitFiles, and AmendCommit produce objects
+// that git itself resolves, and ListTags reports the full commit ID.
+func TestSHA256_WriteOperations_CreateValidObjects(t *testing.T) {
+	// Precondition: SHA-256 repo with one commit
+	v, run := newSHA256Repo(t)
+
+	// Action: Tag and branch HEAD
+	if err := v.CreateTag("v1.0.0", "Release 1.0.0"); err != nil {
+		t.Fatalf("CreateTag failed: %v", err)
+	}
+	if err := v.CreateBranch("release/v1.0.0"); err != nil {
+		t.Fatalf("CreateBranch failed: %v", err)
+	}
+
+	// Expected: Both resolve to HEAD
+	head := run("rev-parse", "HEAD")
+	if got := run("rev-parse", "v1.0.0^{commit}"); got != head {
+		t.Errorf("tag points at %s, want %s", got, head)
+	}
+	if got, _ := v.GetBranchCommit("release/v1.0.0"); got != head {
+		t.Errorf("branch points at %s, want %s", got, head)
+	}
+	tags, err := v.ListTags()
+	if err != nil || len(tags) != 1 || tags[0].Commit != head || len(tags[0].Branches) != 2 {
+		t.Errorf("unexpected ListTags result %+v (%v)", tags, err)
+	}
+
+	// Action: Commit a change, then amend it
+	if err := os.WriteFile(filepath.Join(v.repoRoot, "VERSION"), []byte("1.0.1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.CommitFiles([]string{"VERSION"}, "Bump to 1.0.1"); err != nil {
+		t.Fatalf("CommitFiles failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(v.repoRoot, "VERSION"), []byte("1.0.2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.AmendCommit([]string{"VERSION"}); err != nil {
+		t.Fatalf("AmendCommit failed: %v", err)
+	}
+
+	// Expected: One new commit with the amended content and original message
+	if got := run("rev-list", "--count", "HEAD"); got != "2" {
+		t.Errorf("expected 2 commits, got %s", got)
+	}
+	if got := run("log", "-1", "--format=%s|%an"); got != "Bump to 1.0.1|Fixture Author" {
+		t.Errorf("unexpected HEAD commit %q", got)
+	}
+	if got := run("show", "HEAD:VERSION"); got != "1.0.2" {
+		t.Errorf("expected amended VERSION 1.0.2, got %q", got)
+	}
+}
+
+// TestSHA256_TagFormat_MatchesComponentTags validates release.tagFormat on
+// the git CLI path.
+//
+// Why: SHA-256 repositories find the last tag with `git describe`, which
+// must be limited to the component's tags like the go-git walk.
+//
+// What: With "myapp-v{{Version}}", other-v2.0.0 at HEAD is ignored and
+// myapp-v1.0.0 one commit back is the last tag.
+func TestSHA256_TagFormat_MatchesComponentTags(t *testing.T) {
+	// Precondition: Component tags on consecutive commits
+	v, run := newSHA256Repo(t)
+	run("tag", "myapp-v1.0.0")
+	run("commit", "--allow-empty", "-m", "second")
+	run("tag", "other-v2.0.0")
+	if err := tagformat.Set("myapp-v{{Version}}"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = tagformat.Set("") }()
+
+	// Action
+	lastTag, err := v.GetLastTag()
+	count, _ := v.GetCommitsSinceTag()
+
+	// Expected
+	if err != nil || lastTag != "myapp-v1.0.0" || count != 1 {
+		t.Errorf("GetLastTag() = %q (%v) with %d commits since, want myapp-v1.0.0 with 1", lastTag, err, count)
+	}
+}
+
+// TestSHA256_TagNamespace_ListsOwnTags validates release.tagNamespace on the
+// git CLI path.
+//
+// Why: `git describe` and for-each-ref must be confined to the namespace
+// like the go-git walk.
+//
+// What: In namespace team-a, team-b/v2.0.0 at HEAD is ignored: the last tag
+// is team-a/v1.0.0 one commit back, and only it is listed.
+func TestSHA256_TagNamespace_ListsOwnTags(t *testing.T) {
+	// Precondition: Tags of two teams on consecutive commits
+	v, run := newSHA256Repo(t)
+	run("tag", "team-a/v1.0.0")
+	run("commit", "--allow-empty", "-m", "second")
+	run("tag", "team-b/v2.0.0")
+	tagformat.SetNamespace("team-a")
+	defer tagformat.SetNamespace("")
+
+	// Action
+	lastTag, err := v.GetLastTag()
+	count, _ := v.GetCommitsSinceTag()
+	names, namesErr := v.ListTagNames()
+
+	// Expected
+	if err != nil || lastTag != "team-a/v1.0.0" || count != 1 {
+		t.Errorf("GetLastTag() = %q (%v) with %d commits since, want team-a/v1.0.0 with 1", lastTag, err, count)
+	}
+	if namesErr != nil || len(names) != 1 || names[0] != "team-a/v1.0.0" {
+		t.Errorf("ListTagNames() = %v (%v), want [team-a/v1.0.0]", names, namesErr)
+	}
+}
@@ -0,0 +1,97 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+)
+
+// sshGoodSignature matches git's report of a valid SSH signature; the
+// principal is missing when the key is not in the allowed signers file
+var sshGoodSignature = regexp.MustCompile(`^Good "git" signature (?:for (.+) )?with (\S+) key (\S+)$`)
+
+// VerifyTag checks the GPG or SSH signature on tagName with `git
+// verify-tag`, which go-git cannot do for SSH signatures. allowedSignersFile
+// overrides gpg.ssh.allowedSignersFile; GPG signatures are checked against
+// the GPG keyring.
+func (g *GitVersionControlSystem) VerifyTag(tagName, allowedSignersFile string) (vcs.TagSignature, error) {
+	root, err := g.GetRepositoryRoot()
+	if err != nil {
+		return vcs.TagSignature{}, err
+	}
+
+	var args []string
+	if allowedSignersFile != "" {
+		abs, err := filepath.Abs(allowedSignersFile)
+		if err != nil {
+			return vcs.TagSignature{}, err
+		}
+		args = append(args, "-c", "gpg.ssh.allowedSignersFile="+abs)
+	}
+	args = append(args, "verify-tag", "--raw", "refs/tags/"+tagName)
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = root
+	var report bytes.Buffer
+	cmd.Stderr = &report
+	if err := cmd.Run(); err != nil {
+		return vcs.TagSignature{}, fmt.Errorf("tag %q has no valid signature: %s", tagName, strings.TrimSpace(report.String()))
+	}
+	return parseTagSignature(report.String())
+}
+
+// parseTagSignature reads the signer from `git verify-tag --raw` output:
+// GnuPG status lines for GPG, or ssh-keygen's report for SSH
+func parseTagSignature(report string) (vcs.TagSignature, error) {
+	var sig vcs.TagSignature
+	for _, line := range strings.Split(report, "\n") {
+		line = strings.TrimSpace(line)
+		if m := sshGoodSignature.FindStringSubmatch(line); m != nil {
+			return vcs.TagSignature{Format: vcs.SignatureFormatSSH, Signer: m[1], Fingerprints: []string{m[3]}}, nil
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[0] != "[GNUPG:]" {
+			continue
+		}
+		switch fields[1] {
+		case "GOODSIG":
+			sig.Format = vcs.SignatureFormatGPG
+			sig.Signer = strings.Join(fields[3:], " ")
+		case "VALIDSIG":
+			sig.Fingerprints = append(sig.Fingerprints, fields[2])
+			// The primary key fingerprint is the last field
+			if primary := fields[len(fields)-1]; len(fields) >= 12 && primary != fields[2] {
+				sig.Fingerprints = append(sig.Fingerprints, primary)
+			}
+		}
+	}
+	if sig.Format == "" || len(sig.Fingerprints) == 0 {
+		return vcs.TagSignature{}, fmt.Errorf("unrecognized signature report: %s", strings.TrimSpace(report))
+	}
+	return sig, nil
+}
+
+// ReadFileAt returns the contents of path (relative to the repository root)
+// as committed at commit
+func (g *GitVersionControlSystem) ReadFileAt(commit, path string) ([]byte, error) {
+	if cli, ok := g.cliFallback(); ok {
+		out, err := cli.run(nil, "show", commit+":"+filepath.ToSlash(path))
+		if err != nil {
+			return nil, err
+		}
+		return []byte(out), nil
+	}
+
+	repo, err := g.openRepository()
+	if err != nil {
+		return nil, err
+	}
+	return readCommitFile(repo, plumbing.NewHash(commit), path, commit)
+}
@@ -0,0 +1,116 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+)
+
+// newSSHKey generates an ed25519 key pair, returning the private key path
+// and the public key
+func newSSHKey(t *testing.T) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	key := filepath.Join(t.TempDir(), "key")
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "release", "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v: %s", err, out)
+	}
+	pub, err := os.ReadFile(key + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, strings.TrimSpace(string(pub))
+}
+
+// signTag creates a tag at HEAD signed with key, using the git CLI
+func signTag(t *testing.T, dir, tagName, key string) {
+	t.Helper()
+	cmd := exec.Command("git", "-c", "user.name=Test", "-c", "user.email=test@example.com",
+		"-c", "gpg.format=ssh", "-c", "user.signingkey="+key, "tag", "-s", "-m", tagName, tagName)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git tag -s: %v: %s", err, out)
+	}
+}
+
+// TestVerifyTag_SSHSignature_ChecksAllowedSigners validates SSH verification.
+//
+// Why: Release pipelines trust a tag only when a listed key signed it;
+// go-git cannot check SSH signatures, so the git CLI must be consulted.
+//
+// What: A tag signed by a listed key verifies with its principal; the same
+// tag fails against a file listing another key; an unsigned tag fails.
+func TestVerifyTag_SSHSignature_ChecksAllowedSigners(t *testing.T) {
+	// Precondition: Signed and unsigned tags
+	h := NewTestHelper(t)
+	defer h.Cleanup()
+	h.CreateCommit("release")
+	key, pub := newSSHKey(t)
+	_, otherPub := newSSHKey(t)
+	signTag(t, h.dir, "v1.0.0", key)
+	h.CreateTag("v1.0.1", "unsigned")
+	allowed := filepath.Join(t.TempDir(), "allowed_signers")
+	other := filepath.Join(t.TempDir(), "other_signers")
+	if err := os.WriteFile(allowed, []byte("release@example.com "+pub+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(other, []byte("someone@example.com "+otherPub+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	g := NewGitVCSDefault()
+
+	// Action
+	sig, err := g.VerifyTag("v1.0.0", allowed)
+	_, otherErr := g.VerifyTag("v1.0.0", other)
+	_, unsignedErr := g.VerifyTag("v1.0.1", allowed)
+
+	// Expected
+	if err != nil {
+		t.Fatalf("VerifyTag failed: %v", err)
+	}
+	if sig.Format != vcs.SignatureFormatSSH || sig.Signer != "release@example.com" || len(sig.Fingerprints) != 1 {
+		t.Errorf("signature = %+v, want ssh by release@example.com", sig)
+	}
+	if otherErr == nil {
+		t.Error("expected a key outside the allowed signers to fail")
+	}
+	if unsignedErr == nil {
+		t.Error("expected an unsigned tag to fail")
+	}
+}
+
+// TestParseTagSignature_GPGStatus validates reading GnuPG status lines.
+//
+// Why: Trusted GPG keys are listed by fingerprint, and may name either the
+// signing subkey or its primary key.
+//
+// What: GOODSIG supplies the user ID; VALIDSIG supplies the signing and
+// primary key fingerprints.
+func TestParseTagSignature_GPGStatus(t *testing.T) {
+	// Precondition
+	report := `[GNUPG:] NEWSIG
+[GNUPG:] GOODSIG 6A32387C04BB2393 Release <release@example.com>
+[GNUPG:] VALIDSIG 1111111111111111111111111111111111111111 2026-10-16 1792137409 0 4 0 22 8 00 74C08BB82326AE3C2EEC642C6A32387C04BB2393
+[GNUPG:] TRUST_ULTIMATE 0 pgp
+`
+
+	// Action
+	sig, err := parseTagSignature(report)
+
+	// Expected
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig.Format != vcs.SignatureFormatGPG || sig.Signer != "Release <release@example.com>" {
+		t.Errorf("signature = %+v", sig)
+	}
+	if strings.Join(sig.Fingerprints, ",") != "1111111111111111111111111111111111111111,74C08BB82326AE3C2EEC642C6A32387C04BB2393" {
+		t.Errorf("fingerprints = %v", sig.Fingerprints)
+	}
+}
@@ -0,0 +1,120 @@
+package vcs
+
+import (
+	"os"
+	"slices"
+	"strings"
+)
+
+// Confidence of an inferred branch name, from most to least certain
+const (
+	// BranchConfidenceExact: HEAD is on the branch
+	BranchConfidenceExact = "exact"
+	// BranchConfidenceHigh: the CI system names the branch it checked out
+	BranchConfidenceHigh = "high"
+	// BranchConfidenceMedium: the only branch containing HEAD, or the one
+	// the reflog names among several
+	BranchConfidenceMedium = "medium"
+	// BranchConfidenceLow: a guess among several branches, or the reflog
+	// alone
+	BranchConfidenceLow = "low"
+	// BranchConfidenceNone: nothing suggests a branch
+	BranchConfidenceNone = "none"
+)
+
+// InferredBranch is the branch a build most likely belongs to
+type InferredBranch struct {
+	// Name is the branch name, empty when none could be inferred
+	Name string
+	// Confidence is one of the BranchConfidence constants
+	Confidence string
+}
+
+// ciBranchEnv lists the variables CI systems set to the branch being built,
+// pull/merge request source branches first. Values may be full refs
+// (refs/heads/main); remote names the value is prefixed with are stripped.
+var ciBranchEnv = []struct {
+	name   string
+	remote bool
+}{
+	{name: "GITHUB_HEAD_REF"},
+	{name: "CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"},
+	{name: "CI_COMMIT_BRANCH"},
+	{name: "SYSTEM_PULLREQUEST_SOURCEBRANCH"},
+	{name: "BUILD_SOURCEBRANCH"},
+	{name: "BUILDKITE_BRANCH"},
+	{name: "CIRCLE_BRANCH"},
+	{name: "CHANGE_BRANCH"},
+	{name: "BRANCH_NAME"},
+	{name: "GIT_BRANCH", remote: true},
+}
+
+// ciBranch returns the branch named by the CI environment, or empty
+func ciBranch() string {
+	for _, env := range ciBranchEnv {
+		if name := normalizeBranchRef(os.Getenv(env.name), env.remote); name != "" {
+			return name
+		}
+	}
+	// GITHUB_REF_NAME is a tag name for tag builds
+	if os.Getenv("GITHUB_REF_TYPE") == "branch" {
+		return os.Getenv("GITHUB_REF_NAME")
+	}
+	return ""
+}
+
+// normalizeBranchRef strips refs/heads/ (and, for remote, the remote name)
+// from a branch reference; tag and pull request refs yield empty
+func normalizeBranchRef(ref string, remote bool) string {
+	ref = strings.TrimSpace(ref)
+	if name, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+		return name
+	}
+	if strings.HasPrefix(ref, "refs/") {
+		return ""
+	}
+	if remote {
+		if _, name, ok := strings.Cut(ref, "/"); ok {
+			return name
+		}
+	}
+	return ref
+}
+
+// InferBranch returns the branch HEAD is on, or for a detached HEAD (as CI
+// systems check out) the most likely branch: the one the CI environment
+// names, else the branches containing HEAD, using the reflog and the default
+// branch to choose among several, else the reflog alone. v may be nil when
+// there is no repository; only the CI environment is consulted then.
+func InferBranch(v VersionControlSystem) InferredBranch {
+	if v != nil {
+		if name, err := v.GetBranchName(); err == nil && name != "" {
+			return InferredBranch{Name: name, Confidence: BranchConfidenceExact}
+		}
+	}
+	if name := ciBranch(); name != "" {
+		return InferredBranch{Name: name, Confidence: BranchConfidenceHigh}
+	}
+	hints, ok := v.(DetachedHeadHints)
+	if !ok {
+		return InferredBranch{Confidence: BranchConfidenceNone}
+	}
+
+	reflog, _ := hints.ReflogBranch()
+	containing, _ := hints.BranchesContainingHead()
+	switch {
+	case len(containing) == 1:
+		return InferredBranch{Name: containing[0], Confidence: BranchConfidenceMedium}
+	case len(containing) > 1:
+		if reflog != "" && slices.Contains(containing, reflog) {
+			return InferredBranch{Name: reflog, Confidence: BranchConfidenceMedium}
+		}
+		if base := normalizeBranchRef(DefaultBranch(), true); base != "" && slices.Contains(containing, base) {
+			return InferredBranch{Name: base, Confidence: BranchConfidenceLow}
+		}
+		return InferredBranch{Name: containing[0], Confidence: BranchConfidenceLow}
+	case reflog != "":
+		return InferredBranch{Name: reflog, Confidence: BranchConfidenceLow}
+	}
+	return InferredBranch{Confidence: BranchConfidenceNone}
+}
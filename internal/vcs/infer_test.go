@@ -0,0 +1,101 @@
+package vcs
+
+import (
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
+	"github.com/golang/mock/gomock"
+)
+
+// hintsVCS adds DetachedHeadHints to the generated mock
+type hintsVCS struct {
+	*mock.MockVersionControlSystem
+	reflog     string
+	containing []string
+}
+
+func (v *hintsVCS) ReflogBranch() (string, error)             { return v.reflog, nil }
+func (v *hintsVCS) BranchesContainingHead() ([]string, error) { return v.containing, nil }
+
+// clearCIBranchEnv unsets the CI branch variables for the test
+func clearCIBranchEnv(t *testing.T) {
+	t.Helper()
+	for _, env := range ciBranchEnv {
+		t.Setenv(env.name, "")
+	}
+	t.Setenv("GITHUB_REF_TYPE", "")
+	t.Setenv("GITHUB_REF_NAME", "")
+}
+
+// TestInferBranch_Sources validates the inference order and confidence.
+//
+// Why: Detached CI checkouts must still produce a branch for templates, and
+// templates must know how far to trust it.
+//
+// What: HEAD on a branch is exact; CI variables are high; a single
+// containing branch is medium; the reflog picks among several (medium),
+// else the default branch (low); the reflog alone is low; nothing is none.
+func TestInferBranch_Sources(t *testing.T) {
+	tests := []struct {
+		name       string
+		head       string
+		env        map[string]string
+		reflog     string
+		containing []string
+		want       InferredBranch
+	}{
+		{name: "on a branch", head: "main", env: map[string]string{"CI_COMMIT_BRANCH": "other"},
+			want: InferredBranch{Name: "main", Confidence: BranchConfidenceExact}},
+		{name: "azure full ref", env: map[string]string{"BUILD_SOURCEBRANCH": "refs/heads/feature/x"},
+			want: InferredBranch{Name: "feature/x", Confidence: BranchConfidenceHigh}},
+		{name: "jenkins remote branch", env: map[string]string{"GIT_BRANCH": "origin/release/1.x"},
+			want: InferredBranch{Name: "release/1.x", Confidence: BranchConfidenceHigh}},
+		{name: "github tag build ignored", env: map[string]string{"GITHUB_REF_TYPE": "tag", "GITHUB_REF_NAME": "v1.0.0"}, containing: []string{"main"},
+			want: InferredBranch{Name: "main", Confidence: BranchConfidenceMedium}},
+		{name: "reflog among several", reflog: "feature", containing: []string{"feature", "main"},
+			want: InferredBranch{Name: "feature", Confidence: BranchConfidenceMedium}},
+		{name: "default among several", containing: []string{"develop", "main"},
+			want: InferredBranch{Name: "main", Confidence: BranchConfidenceLow}},
+		{name: "reflog only", reflog: "hotfix",
+			want: InferredBranch{Name: "hotfix", Confidence: BranchConfidenceLow}},
+		{name: "nothing",
+			want: InferredBranch{Confidence: BranchConfidenceNone}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition: Detached (or not) HEAD, CI variables, hints
+			clearCIBranchEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			SetDefaultBranch("origin/main")
+			defer SetDefaultBranch("")
+			ctrl := gomock.NewController(t)
+			m := mock.NewMockVersionControlSystem(ctrl)
+			m.EXPECT().GetBranchName().Return(tt.head, nil).AnyTimes()
+
+			// Action: Infer
+			got := InferBranch(&hintsVCS{MockVersionControlSystem: m, reflog: tt.reflog, containing: tt.containing})
+
+			// Expected: Name and confidence per source
+			if got != tt.want {
+				t.Errorf("InferBranch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestInferBranch_NoRepository_UsesCI validates inference without a VCS.
+func TestInferBranch_NoRepository_UsesCI(t *testing.T) {
+	// Precondition: No repository, CircleCI branch set
+	clearCIBranchEnv(t)
+	t.Setenv("CIRCLE_BRANCH", "main")
+
+	// Action: Infer without a VCS
+	got := InferBranch(nil)
+
+	// Expected: The CI branch
+	if got != (InferredBranch{Name: "main", Confidence: BranchConfidenceHigh}) {
+		t.Errorf("InferBranch(nil) = %+v", got)
+	}
+}
@@ -0,0 +1,336 @@
+// Package svn implements VersionControlSystem for Subversion working copies
+// by running the svn command line client.
+//
+// Subversion has no hashes: identifiers are revision numbers ("1234"), and
+// the version is the revision in which the working copy root last changed.
+// Tags and branches follow the standard layout, as directories under tags/
+// and branches/ next to trunk/ in the repository or project root.
+package svn
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+)
+
+// ErrUnsupported is returned for operations Subversion has no equivalent of
+var ErrUnsupported = errors.New("not supported by subversion")
+
+// Runner runs the svn client with args in dir and returns its output
+type Runner func(dir string, args ...string) (string, error)
+
+// runSVN runs the svn client non-interactively
+func runSVN(dir string, args ...string) (string, error) {
+	cmd := exec.Command("svn", append([]string{"--non-interactive"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("svn %s: %w: %s", args[0], err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("svn %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
+
+// SVNVersionControlSystem implements VersionControlSystem for Subversion
+type SVNVersionControlSystem struct {
+	root    string // working copy root
+	run     Runner // injected svn client
+	info    *wcInfo
+	tagInfo *tagInfo
+}
+
+// NewSVNVCS creates an SVNVersionControlSystem running svn through run.
+// Use this constructor for testing with canned svn output.
+func NewSVNVCS(run Runner) *SVNVersionControlSystem {
+	return &SVNVersionControlSystem{run: run}
+}
+
+// NewSVNVCSDefault creates an SVNVersionControlSystem using the svn client
+func NewSVNVCSDefault() *SVNVersionControlSystem {
+	return NewSVNVCS(runSVN)
+}
+
+// Name returns "svn"
+func (s *SVNVersionControlSystem) Name() string {
+	return "svn"
+}
+
+// HashAlgorithm reports numeric revisions
+func (s *SVNVersionControlSystem) HashAlgorithm() string {
+	return vcs.HashAlgorithmRevision
+}
+
+// MaxIdentifierLength is 0: revisions have no fixed length
+func (s *SVNVersionControlSystem) MaxIdentifierLength() int {
+	return 0
+}
+
+// IsRepository reports whether the working directory is inside a working
+// copy (a .svn directory in it or a parent)
+func (s *SVNVersionControlSystem) IsRepository() bool {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return false
+	}
+	return s.locate(cwd)
+}
+
+// locate finds the working copy root containing dir, dropping cached state
+// of another working copy
+func (s *SVNVersionControlSystem) locate(dir string) bool {
+	for {
+		if info, err := os.Stat(filepath.Join(dir, ".svn")); err == nil && info.IsDir() {
+			if dir != s.root {
+				s.root, s.info, s.tagInfo = dir, nil, nil
+			}
+			return true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
+
+// GetRepositoryRoot returns the working copy root
+func (s *SVNVersionControlSystem) GetRepositoryRoot() (string, error) {
+	if s.root != "" {
+		return s.root, nil
+	}
+	if !s.IsRepository() {
+		return "", fmt.Errorf("not a subversion working copy")
+	}
+	return s.root, nil
+}
+
+// wcInfo is the part of `svn info --xml` versionator uses
+type wcInfo struct {
+	Entry struct {
+		// Revision is the working copy (BASE) revision
+		Revision    int    `xml:"revision,attr"`
+		URL         string `xml:"url"`
+		RelativeURL string `xml:"relative-url"`
+		RepoRoot    string `xml:"repository>root"`
+		// Commit is the revision the path last changed in
+		Commit struct {
+			Revision int       `xml:"revision,attr"`
+			Author   string    `xml:"author"`
+			Date     time.Time `xml:"date"`
+		} `xml:"commit"`
+	} `xml:"entry"`
+}
+
+// layout locates the working copy in the standard layout, as paths relative
+// to the repository root: the project holding trunk/, branches/ and tags/
+// ("" at the root, "/proj" below it), the branch name ("trunk", the directory
+// under branches/, or empty for a tag) and the branch path ("/trunk")
+func (i *wcInfo) layout() (project, branch, branchPath string) {
+	path := strings.TrimSuffix(strings.TrimPrefix(i.Entry.RelativeURL, "^"), "/")
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for n, segment := range segments {
+		if n > 0 {
+			project += "/" + segments[n-1]
+		}
+		switch {
+		case segment == "trunk":
+			return project, "trunk", project + "/trunk"
+		case segment == "branches" && n+1 < len(segments):
+			return project, segments[n+1], project + "/branches/" + segments[n+1]
+		case segment == "tags" && n+1 < len(segments):
+			return project, "", project + "/tags/" + segments[n+1]
+		}
+	}
+	return "", "", path
+}
+
+// branchName returns the branch a repository path lies on, or empty
+// outside trunk/ and branches/
+func branchName(path string) string {
+	info := &wcInfo{}
+	info.Entry.RelativeURL = "^" + path
+	_, branch, _ := info.layout()
+	return branch
+}
+
+// wcInfo returns `svn info` of the working copy root, cached
+func (s *SVNVersionControlSystem) wcInfo() (*wcInfo, error) {
+	if s.info != nil {
+		return s.info, nil
+	}
+	root, err := s.GetRepositoryRoot()
+	if err != nil {
+		return nil, err
+	}
+	out, err := s.run(root, "info", "--xml", root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read working copy info: %w", err)
+	}
+	var info wcInfo
+	if err := xml.Unmarshal([]byte(out), &info); err != nil {
+		return nil, fmt.Errorf("failed to parse svn info: %w", err)
+	}
+	s.info = &info
+	return s.info, nil
+}
+
+// GetVCSIdentifier returns the revision the working copy root last changed
+// in. Revisions are never shortened, whatever length is asked for.
+func (s *SVNVersionControlSystem) GetVCSIdentifier(length int) (string, error) {
+	info, err := s.wcInfo()
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(info.Entry.Commit.Revision), nil
+}
+
+// GetBranchName returns "trunk", the branch directory name, or empty when
+// a tag is checked out
+func (s *SVNVersionControlSystem) GetBranchName() (string, error) {
+	info, err := s.wcInfo()
+	if err != nil {
+		return "", err
+	}
+	_, branch, _ := info.layout()
+	return branch, nil
+}
+
+// GetCommitDate returns the date of the last changed revision
+func (s *SVNVersionControlSystem) GetCommitDate() (time.Time, error) {
+	info, err := s.wcInfo()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.Entry.Commit.Date.UTC(), nil
+}
+
+// GetCommitAuthor returns the author of the last changed revision
+func (s *SVNVersionControlSystem) GetCommitAuthor() (string, error) {
+	info, err := s.wcInfo()
+	if err != nil {
+		return "", err
+	}
+	return info.Entry.Commit.Author, nil
+}
+
+// GetCommitAuthorEmail returns empty: Subversion records no email
+func (s *SVNVersionControlSystem) GetCommitAuthorEmail() (string, error) {
+	return "", nil
+}
+
+// wcStatus is `svn status --xml`
+type wcStatus struct {
+	Entries []struct {
+		Path   string `xml:"path,attr"`
+		Status struct {
+			Item string `xml:"item,attr"`
+		} `xml:"wc-status"`
+	} `xml:"target>entry"`
+}
+
+// GetDirtyFiles returns the paths `svn status` reports, relative to the
+// working copy root. Unversioned files are left out in vcs.DirtyCheckTracked
+// mode; ignored files are included when vcs.CountIgnored is set.
+func (s *SVNVersionControlSystem) GetDirtyFiles() ([]string, error) {
+	root, err := s.GetRepositoryRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"status", "--xml"}
+	switch {
+	case vcs.DirtyCheck() == vcs.DirtyCheckTracked:
+		args = append(args, "--quiet")
+	case vcs.CountIgnored():
+		args = append(args, "--no-ignore")
+	}
+	out, err := s.run(root, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get svn status: %w", err)
+	}
+	var status wcStatus
+	if err := xml.Unmarshal([]byte(out), &status); err != nil {
+		return nil, fmt.Errorf("failed to parse svn status: %w", err)
+	}
+
+	var files []string
+	for _, e := range status.Entries {
+		switch e.Status.Item {
+		case "normal", "external", "none":
+			continue
+		case "ignored":
+			if !vcs.CountIgnored() {
+				continue
+			}
+		}
+		files = append(files, filepath.ToSlash(e.Path))
+	}
+	return files, nil
+}
+
+// GetUncommittedChanges returns the number of files `svn status` reports
+func (s *SVNVersionControlSystem) GetUncommittedChanges() (int, error) {
+	files, err := s.GetDirtyFiles()
+	return len(files), err
+}
+
+// IsWorkingDirectoryClean reports whether `svn status` reports nothing
+func (s *SVNVersionControlSystem) IsWorkingDirectoryClean() (bool, error) {
+	files, err := s.GetDirtyFiles()
+	return len(files) == 0, err
+}
+
+// CommitFiles schedules unversioned files for addition and commits files
+func (s *SVNVersionControlSystem) CommitFiles(files []string, message string) error {
+	root, err := s.GetRepositoryRoot()
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	if _, err := s.run(root, append([]string{"add", "--force", "--parents", "--quiet"}, files...)...); err != nil {
+		return fmt.Errorf("failed to add files: %w", err)
+	}
+	if _, err := s.remote(append([]string{"commit", "--message", message}, files...)...); err != nil {
+		return fmt.Errorf("failed to create commit: %w", err)
+	}
+	s.info, s.tagInfo = nil, nil
+	return nil
+}
+
+// AmendCommit fails: Subversion revisions cannot be changed
+func (s *SVNVersionControlSystem) AmendCommit(files []string) error {
+	return fmt.Errorf("amending a commit: %w", ErrUnsupported)
+}
+
+// GetHooksPath fails: Subversion hooks run on the server
+func (s *SVNVersionControlSystem) GetHooksPath() (string, error) {
+	return "", fmt.Errorf("client-side hooks: %w", ErrUnsupported)
+}
+
+// PushTag does nothing: tags are created in the repository
+func (s *SVNVersionControlSystem) PushTag(tagName string) error {
+	return nil
+}
+
+// PushBranch does nothing: branches are created in the repository
+func (s *SVNVersionControlSystem) PushBranch(branchName string) error {
+	return nil
+}
+
+// Auto-registration as a VCS
+func init() {
+	vcs.RegisterVCS(NewSVNVCSDefault())
+}
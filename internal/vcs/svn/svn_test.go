@@ -0,0 +1,262 @@
+package svn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/offline"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+)
+
+// fakeSVN answers svn commands from canned XML keyed by the joined
+// arguments, recording every command run
+type fakeSVN struct {
+	responses map[string]string
+	commands  []string
+}
+
+func (f *fakeSVN) run(dir string, args ...string) (string, error) {
+	command := strings.Join(args, " ")
+	f.commands = append(f.commands, command)
+	if out, ok := f.responses[command]; ok {
+		return out, nil
+	}
+	return "", fmt.Errorf("svn %s: exit status 1: svn: E160013: path not found", args[0])
+}
+
+// newTestWorkingCopy returns an SVNVersionControlSystem on a working copy of
+// ^/proj/trunk at revision 12, last changed in revision 10
+func newTestWorkingCopy(t *testing.T, responses map[string]string) (*SVNVersionControlSystem, *fakeSVN, string) {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".svn"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	responses["info --xml "+root] = `<?xml version="1.0" encoding="UTF-8"?>
+<info>
+<entry kind="dir" path="." revision="12">
+<url>https://svn.example.com/repo/proj/trunk</url>
+<relative-url>^/proj/trunk</relative-url>
+<repository><root>https://svn.example.com/repo</root></repository>
+<wc-info><wcroot-abspath>` + root + `</wcroot-abspath></wc-info>
+<commit revision="10"><author>alice</author><date>2024-03-01T12:00:00.000000Z</date></commit>
+</entry>
+</info>`
+	fake := &fakeSVN{responses: responses}
+	s := NewSVNVCS(fake.run)
+	if !s.locate(filepath.Join(root)) {
+		t.Fatal("working copy not found")
+	}
+	return s, fake, root
+}
+
+// tagLog is the --stop-on-copy log of a tag copied from path@rev
+func tagLog(tag, path string, rev int) string {
+	return fmt.Sprintf(`<log>
+<logentry revision="%d"><author>alice</author><date>2024-03-0%dT12:00:00.000000Z</date>
+<paths><path action="A" kind="dir" copyfrom-path="%s" copyfrom-rev="%d">/proj/tags/%s</path></paths>
+<msg>Release %s</msg></logentry>
+</log>`, rev+1, rev%9+1, path, rev, tag, tag)
+}
+
+const tagList = `<lists><list path="https://svn.example.com/repo/proj/tags">
+<entry kind="dir"><name>v1.0.0</name></entry>
+<entry kind="dir"><name>v1.1.0</name></entry>
+<entry kind="dir"><name>v2.0.0-beta</name></entry>
+<entry kind="file"><name>README</name></entry>
+</list></lists>`
+
+const tagsURL = "https://svn.example.com/repo/proj/tags"
+
+// TestWorkingCopyInfo_RevisionsAndLayout validates identifiers and branch
+// detection.
+//
+// Why: Subversion has no hashes; versions built from a working copy must
+// carry the revision, and the branch comes from the standard layout.
+//
+// What: The identifier is the last changed revision, never shortened; the
+// branch of ^/proj/trunk is trunk, with author and date from svn info.
+func TestWorkingCopyInfo_RevisionsAndLayout(t *testing.T) {
+	// Precondition: A working copy of ^/proj/trunk
+	s, _, _ := newTestWorkingCopy(t, map[string]string{})
+
+	// Action
+	id, err := s.GetVCSIdentifier(7)
+	branch, _ := s.GetBranchName()
+	author, _ := s.GetCommitAuthor()
+	date, _ := s.GetCommitDate()
+
+	// Expected
+	if err != nil || id != "10" {
+		t.Errorf("GetVCSIdentifier(7) = %q, %v; want 10", id, err)
+	}
+	if branch != "trunk" || author != "alice" || date.Year() != 2024 {
+		t.Errorf("branch, author, date = %q, %q, %v", branch, author, date)
+	}
+	if vcs.HashAlgorithm(s) != vcs.HashAlgorithmRevision {
+		t.Errorf("HashAlgorithm = %q", vcs.HashAlgorithm(s))
+	}
+	if vcs.Supports(s, vcs.CapabilityAmend) {
+		t.Error("amending must not be reported as supported")
+	}
+}
+
+// TestLayout_BranchesAndTags validates standard layout detection.
+//
+// Why: Tags and branches are located next to the working copy's trunk or
+// branch; a wrong project root would look for tags in the wrong place.
+//
+// What: Branches, tags and trunk at the repository root or below a project
+// map to the right project, branch name and branch path.
+func TestLayout_BranchesAndTags(t *testing.T) {
+	tests := []struct {
+		relativeURL, project, branch, branchPath string
+	}{
+		{"^/trunk", "", "trunk", "/trunk"},
+		{"^/trunk/src", "", "trunk", "/trunk"},
+		{"^/proj/branches/release-1.x", "/proj", "release-1.x", "/proj/branches/release-1.x"},
+		{"^/a/b/tags/v1.0.0", "/a/b", "", "/a/b/tags/v1.0.0"},
+		{"^/flat", "", "", "/flat"},
+	}
+	for _, tt := range tests {
+		// Precondition
+		info := &wcInfo{}
+		info.Entry.RelativeURL = tt.relativeURL
+
+		// Action
+		project, branch, branchPath := info.layout()
+
+		// Expected
+		if project != tt.project || branch != tt.branch || branchPath != tt.branchPath {
+			t.Errorf("layout(%s) = %q, %q, %q; want %q, %q, %q", tt.relativeURL,
+				project, branch, branchPath, tt.project, tt.branch, tt.branchPath)
+		}
+	}
+}
+
+// TestGetCommitsSinceTag_LastTagOnBranch validates tag queries.
+//
+// Why: Tags are copies under tags/; the version's distance must count from
+// the last tag copied from the working copy's own branch.
+//
+// What: Of v1.0.0 and v1.1.0 copied from trunk, and v2.0.0-beta copied from
+// a branch, v1.1.0 (copied at r8) is last; two trunk revisions followed.
+func TestGetCommitsSinceTag_LastTagOnBranch(t *testing.T) {
+	// Precondition: Tags copied from trunk at r3 and r8, and from a branch at r11
+	s, fake, _ := newTestWorkingCopy(t, map[string]string{
+		"list --xml " + tagsURL: tagList,
+		"log --xml --verbose --stop-on-copy " + tagsURL + "/v1.0.0":      tagLog("v1.0.0", "/proj/trunk", 3),
+		"log --xml --verbose --stop-on-copy " + tagsURL + "/v1.1.0":      tagLog("v1.1.0", "/proj/trunk", 8),
+		"log --xml --verbose --stop-on-copy " + tagsURL + "/v2.0.0-beta": tagLog("v2.0.0-beta", "/proj/branches/next", 11),
+		"log --xml --revision 12:9 https://svn.example.com/repo/proj/trunk@12": `<log>
+<logentry revision="10"><msg>fix: second</msg></logentry>
+<logentry revision="9"><msg>feat: first</msg></logentry>
+</log>`,
+	})
+
+	// Action
+	count, err := s.GetCommitsSinceTag()
+	last, _ := s.GetLastTag()
+	rev, _ := s.GetLastTagCommit()
+	messages, _ := s.GetCommitMessagesSinceTag()
+	tags, _ := s.ListTags()
+
+	// Expected
+	if err != nil || count != 2 {
+		t.Fatalf("GetCommitsSinceTag() = %d, %v; want 2 (commands: %v)", count, err, fake.commands)
+	}
+	if last != "v1.1.0" || rev != "8" {
+		t.Errorf("last tag = %q at %q; want v1.1.0 at 8", last, rev)
+	}
+	if !reflect.DeepEqual(messages, []string{"fix: second", "feat: first"}) {
+		t.Errorf("messages = %q", messages)
+	}
+	if len(tags) != 3 || tags[2].Name != "v2.0.0-beta" || !reflect.DeepEqual(tags[2].Branches, []string{"next"}) {
+		t.Errorf("ListTags() = %+v", tags)
+	}
+}
+
+// TestGetCommitsSinceTag_NoTags validates a repository without tags/.
+//
+// Why: Many repositories have never been tagged; that is not an error.
+//
+// What: A missing tags directory means no tags: -1 commits since tag.
+func TestGetCommitsSinceTag_NoTags(t *testing.T) {
+	// Precondition: svn list fails with path not found
+	s, _, _ := newTestWorkingCopy(t, map[string]string{})
+
+	// Action
+	count, err := s.GetCommitsSinceTag()
+
+	// Expected
+	if err != nil || count != -1 {
+		t.Errorf("GetCommitsSinceTag() = %d, %v; want -1", count, err)
+	}
+}
+
+// TestCreateTag_CopiesWorkingCopyRevision validates tag creation.
+//
+// Why: A tag must capture the revision the version was built from, not
+// whatever HEAD is when the copy runs; offline builds must not contact the
+// server.
+//
+// What: CreateTag copies the trunk URL pegged at the working copy revision
+// to tags/, and is refused when offline.
+func TestCreateTag_CopiesWorkingCopyRevision(t *testing.T) {
+	// Precondition
+	copyCmd := "copy --parents --message Release 1.2.0 https://svn.example.com/repo/proj/trunk@12 " + tagsURL + "/v1.2.0"
+	s, fake, _ := newTestWorkingCopy(t, map[string]string{copyCmd: ""})
+
+	// Action
+	err := s.CreateTag("v1.2.0", "Release 1.2.0")
+
+	// Expected
+	if err != nil || fake.commands[len(fake.commands)-1] != copyCmd {
+		t.Errorf("CreateTag() = %v; commands %v", err, fake.commands)
+	}
+
+	// Action / Expected: Offline
+	offline.Set(true)
+	defer offline.Set(false)
+	if err := s.CreateTag("v1.2.1", "Release 1.2.1"); err == nil {
+		t.Error("CreateTag() should be refused offline")
+	}
+}
+
+// TestGetDirtyFiles_StatusModes validates dirty detection.
+//
+// Why: dirtyCheck: tracked must not count unversioned files, and unchanged
+// or external entries are never dirty.
+//
+// What: Modified and unversioned entries are reported in full mode; only
+// the modified one when svn status runs with --quiet in tracked mode.
+func TestGetDirtyFiles_StatusModes(t *testing.T) {
+	// Precondition
+	status := `<status><target path=".">
+<entry path="VERSION"><wc-status item="modified" props="none"/></entry>
+<entry path="notes.txt"><wc-status item="unversioned" props="none"/></entry>
+<entry path="vendor"><wc-status item="external" props="none"/></entry>
+</target></status>`
+	s, _, _ := newTestWorkingCopy(t, map[string]string{
+		"status --xml":         status,
+		"status --xml --quiet": strings.Replace(status, `<entry path="notes.txt"><wc-status item="unversioned" props="none"/></entry>`, "", 1),
+	})
+
+	// Action
+	full, err := s.GetDirtyFiles()
+	vcs.SetDirtyCheck(vcs.DirtyCheckTracked)
+	defer vcs.SetDirtyCheck(vcs.DirtyCheckFull)
+	tracked, _ := s.GetDirtyFiles()
+
+	// Expected
+	if err != nil || !reflect.DeepEqual(full, []string{"VERSION", "notes.txt"}) {
+		t.Errorf("full = %q, %v", full, err)
+	}
+	if !reflect.DeepEqual(tracked, []string{"VERSION"}) {
+		t.Errorf("tracked = %q", tracked)
+	}
+}
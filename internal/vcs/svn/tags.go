@@ -0,0 +1,434 @@
+package svn
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/offline"
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+)
+
+// tagInfo caches the last tag on the working copy's branch
+type tagInfo struct {
+	commitsSinceTag int
+	lastTagName     string
+	lastTagRevision int
+}
+
+// tagSource is where a tag was copied from
+type tagSource struct {
+	name     string
+	path     string // copied path, relative to the repository root
+	revision int    // copied revision
+	date     time.Time
+}
+
+// svnList is `svn ls --xml`
+type svnList struct {
+	Entries []struct {
+		Kind string `xml:"kind,attr"`
+		Name string `xml:"name"`
+	} `xml:"list>entry"`
+}
+
+// svnLog is `svn log --xml --verbose`
+type svnLog struct {
+	Entries []struct {
+		Revision int       `xml:"revision,attr"`
+		Date     time.Time `xml:"date"`
+		Msg      string    `xml:"msg"`
+		Paths    []struct {
+			Path         string `xml:",chardata"`
+			CopyFromPath string `xml:"copyfrom-path,attr"`
+			CopyFromRev  int    `xml:"copyfrom-rev,attr"`
+		} `xml:"paths>path"`
+	} `xml:"logentry"`
+}
+
+// Capabilities reports tags, branches and tag listing. Pushing is a no-op,
+// as tags and branches are created in the repository; revisions cannot be
+// amended.
+func (s *SVNVersionControlSystem) Capabilities() []vcs.Capability {
+	return []vcs.Capability{vcs.CapabilityTags, vcs.CapabilityBranches, vcs.CapabilityPush, vcs.CapabilityTagListing}
+}
+
+// remote runs an svn command that contacts the repository, refused when
+// offline
+func (s *SVNVersionControlSystem) remote(args ...string) (string, error) {
+	if err := offline.Require("svn " + args[0]); err != nil {
+		return "", err
+	}
+	root, err := s.GetRepositoryRoot()
+	if err != nil {
+		return "", err
+	}
+	return s.run(root, args...)
+}
+
+// url returns the repository URL of path, relative to the repository root
+func (s *SVNVersionControlSystem) url(path string) (string, error) {
+	info, err := s.wcInfo()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(info.Entry.RepoRoot, "/") + path, nil
+}
+
+// layoutPath returns the path of a tag or branch directory ("tags" or
+// "branches") of the working copy's project
+func (s *SVNVersionControlSystem) layoutPath(dir, name string) (string, error) {
+	info, err := s.wcInfo()
+	if err != nil {
+		return "", err
+	}
+	project, _, _ := info.layout()
+	path := project + "/" + dir
+	if name != "" {
+		path += "/" + name
+	}
+	return path, nil
+}
+
+// isNotFound reports whether err is svn failing on a missing path
+func isNotFound(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "E160013") || strings.Contains(msg, "W160013") || strings.Contains(msg, "E200009")
+}
+
+// listDir returns the directories in dir ("tags" or "branches"), or in sub
+// below it; a missing directory has none
+func (s *SVNVersionControlSystem) listDir(dir, sub string) ([]string, error) {
+	path, err := s.layoutPath(dir, strings.TrimSuffix(sub, "/"))
+	if err != nil {
+		return nil, err
+	}
+	url, err := s.url(path)
+	if err != nil {
+		return nil, err
+	}
+	out, err := s.remote("list", "--xml", url)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	var list svnList
+	if err := xml.Unmarshal([]byte(out), &list); err != nil {
+		return nil, fmt.Errorf("failed to parse svn list: %w", err)
+	}
+
+	var names []string
+	for _, e := range list.Entries {
+		if e.Kind == "dir" {
+			names = append(names, sub+e.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// log runs `svn log --xml` with args
+func (s *SVNVersionControlSystem) log(args ...string) (*svnLog, error) {
+	out, err := s.remote(append([]string{"log", "--xml"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	var log svnLog
+	if err := xml.Unmarshal([]byte(out), &log); err != nil {
+		return nil, fmt.Errorf("failed to parse svn log: %w", err)
+	}
+	return &log, nil
+}
+
+// tagSource returns where tag name was copied from: the earliest revision
+// of the tag's own history, which added it as a copy
+func (s *SVNVersionControlSystem) tagSource(name string) (tagSource, error) {
+	path, err := s.layoutPath("tags", name)
+	if err != nil {
+		return tagSource{}, err
+	}
+	url, err := s.url(path)
+	if err != nil {
+		return tagSource{}, err
+	}
+	log, err := s.log("--verbose", "--stop-on-copy", url)
+	if err != nil {
+		return tagSource{}, fmt.Errorf("failed to read tag %s: %w", name, err)
+	}
+	if len(log.Entries) > 0 {
+		first := log.Entries[len(log.Entries)-1]
+		for _, p := range first.Paths {
+			if p.Path == path && p.CopyFromPath != "" {
+				return tagSource{name: name, path: p.CopyFromPath, revision: p.CopyFromRev, date: first.Date}, nil
+			}
+		}
+	}
+	return tagSource{}, fmt.Errorf("tag %s is not a copy", name)
+}
+
+// ListTagNames returns the directories under tags/ (in release.tagNamespace,
+// when set), sorted by name
+func (s *SVNVersionControlSystem) ListTagNames() ([]string, error) {
+	return s.listDir("tags", tagformat.Namespace())
+}
+
+// ListTags returns every tag (in release.tagNamespace, when set) with the
+// revision it was copied from, ordered by that revision. Date is when the
+// tag was created; Branches holds the branch it was copied from.
+func (s *SVNVersionControlSystem) ListTags() ([]vcs.TagRef, error) {
+	names, err := s.ListTagNames()
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]tagSource, 0, len(names))
+	for _, name := range names {
+		source, err := s.tagSource(name)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sources[i].revision < sources[j].revision
+	})
+
+	tags := make([]vcs.TagRef, len(sources))
+	for i, source := range sources {
+		tags[i] = vcs.TagRef{Name: source.name, Commit: strconv.Itoa(source.revision), Date: source.date.UTC()}
+		if branch := branchName(source.path); branch != "" {
+			tags[i].Branches = []string{branch}
+		}
+	}
+	return tags, nil
+}
+
+// TagExists checks if a directory named tagName exists under tags/
+func (s *SVNVersionControlSystem) TagExists(tagName string) (bool, error) {
+	return s.exists("tags", tagName)
+}
+
+// GetTagCommit returns the revision tagName was copied from
+func (s *SVNVersionControlSystem) GetTagCommit(tagName string) (string, error) {
+	source, err := s.tagSource(tagName)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(source.revision), nil
+}
+
+// CreateTag copies the working copy's URL at its revision to tags/tagName
+func (s *SVNVersionControlSystem) CreateTag(tagName, message string) error {
+	if err := s.copyTo("tags", tagName, message); err != nil {
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+	s.tagInfo = nil
+	return nil
+}
+
+// CreateBranch copies the working copy's URL at its revision to
+// branches/branchName
+func (s *SVNVersionControlSystem) CreateBranch(branchName string) error {
+	if err := s.copyTo("branches", branchName, "Create branch "+branchName); err != nil {
+		return fmt.Errorf("failed to create branch: %w", err)
+	}
+	return nil
+}
+
+// BranchExists checks for trunk or a directory under branches/
+func (s *SVNVersionControlSystem) BranchExists(branchName string) (bool, error) {
+	if branchName == "trunk" {
+		return true, nil
+	}
+	return s.exists("branches", branchName)
+}
+
+// GetBranchCommit returns the revision the branch last changed in
+func (s *SVNVersionControlSystem) GetBranchCommit(branchName string) (string, error) {
+	dir, name := "branches", branchName
+	if branchName == "trunk" {
+		dir, name = "trunk", ""
+	}
+	path, err := s.layoutPath(dir, name)
+	if err != nil {
+		return "", err
+	}
+	url, err := s.url(path)
+	if err != nil {
+		return "", err
+	}
+	out, err := s.remote("info", "--xml", url)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve branch %s: %w", branchName, err)
+	}
+	var info wcInfo
+	if err := xml.Unmarshal([]byte(out), &info); err != nil {
+		return "", fmt.Errorf("failed to parse svn info: %w", err)
+	}
+	return strconv.Itoa(info.Entry.Commit.Revision), nil
+}
+
+// exists checks for a directory name under dir ("tags" or "branches")
+func (s *SVNVersionControlSystem) exists(dir, name string) (bool, error) {
+	parent, base := "", name
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		parent, base = name[:i+1], name[i+1:]
+	}
+	names, err := s.listDir(dir, parent)
+	if err != nil {
+		return false, err
+	}
+	for _, n := range names {
+		if n == parent+base {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// copyTo copies the working copy's URL at its revision into dir
+func (s *SVNVersionControlSystem) copyTo(dir, name, message string) error {
+	info, err := s.wcInfo()
+	if err != nil {
+		return err
+	}
+	path, err := s.layoutPath(dir, name)
+	if err != nil {
+		return err
+	}
+	target, err := s.url(path)
+	if err != nil {
+		return err
+	}
+	source := fmt.Sprintf("%s@%d", info.Entry.URL, info.Entry.Revision)
+	_, err = s.remote("copy", "--parents", "--message", message, source, target)
+	return err
+}
+
+// getTagInfo finds the last tag on the working copy's branch, cached
+func (s *SVNVersionControlSystem) getTagInfo() (*tagInfo, error) {
+	if s.tagInfo != nil {
+		return s.tagInfo, nil
+	}
+	info, err := s.computeTagInfo()
+	if err != nil {
+		return nil, err
+	}
+	s.tagInfo = info
+	return info, nil
+}
+
+// computeTagInfo picks, among tags following release.tagFormat that were
+// copied from the working copy's branch at or before its revision, the one
+// copied last, and counts the branch's revisions since
+func (s *SVNVersionControlSystem) computeTagInfo() (*tagInfo, error) {
+	info, err := s.wcInfo()
+	if err != nil {
+		return nil, err
+	}
+	_, _, branchPath := info.layout()
+
+	names, err := s.ListTagNames()
+	if err != nil {
+		return nil, err
+	}
+	var last *tagSource
+	for _, name := range names {
+		if _, ok := tagformat.Version(name); !ok {
+			continue
+		}
+		source, err := s.tagSource(name)
+		if err != nil {
+			return nil, err
+		}
+		if source.path != branchPath || source.revision > info.Entry.Revision {
+			continue
+		}
+		if last == nil || source.revision >= last.revision {
+			last = &source
+		}
+	}
+	if last == nil {
+		return &tagInfo{commitsSinceTag: -1}, nil
+	}
+
+	result := &tagInfo{lastTagName: last.name, lastTagRevision: last.revision}
+	if last.revision == info.Entry.Revision {
+		return result, nil
+	}
+	log, err := s.log(
+		"--revision", fmt.Sprintf("%d:%d", info.Entry.Revision, last.revision+1),
+		fmt.Sprintf("%s@%d", info.Entry.URL, info.Entry.Revision))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+	result.commitsSinceTag = len(log.Entries)
+	return result, nil
+}
+
+// GetCommitsSinceTag returns the number of revisions changing the branch
+// since the last tag was copied from it. Returns -1 if no tag was.
+func (s *SVNVersionControlSystem) GetCommitsSinceTag() (int, error) {
+	info, err := s.getTagInfo()
+	if err != nil {
+		return 0, err
+	}
+	return info.commitsSinceTag, nil
+}
+
+// GetLastTag returns the last tag copied from the working copy's branch
+func (s *SVNVersionControlSystem) GetLastTag() (string, error) {
+	info, err := s.getTagInfo()
+	if err != nil {
+		return "", err
+	}
+	return info.lastTagName, nil
+}
+
+// GetLastTagCommit returns the revision the last tag was copied from
+func (s *SVNVersionControlSystem) GetLastTagCommit() (string, error) {
+	info, err := s.getTagInfo()
+	if err != nil {
+		return "", err
+	}
+	if info.lastTagName == "" {
+		return "", nil
+	}
+	return strconv.Itoa(info.lastTagRevision), nil
+}
+
+// GetCommitMessagesSinceTag returns the log messages of the branch's
+// revisions since the last tag, newest first
+func (s *SVNVersionControlSystem) GetCommitMessagesSinceTag() ([]string, error) {
+	tag, err := s.getTagInfo()
+	if err != nil {
+		return nil, err
+	}
+	if tag.commitsSinceTag == 0 {
+		return []string{}, nil
+	}
+	info, err := s.wcInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{fmt.Sprintf("%s@%d", info.Entry.URL, info.Entry.Revision)}
+	if tag.lastTagName != "" {
+		args = append([]string{"--revision", fmt.Sprintf("%d:%d", info.Entry.Revision, tag.lastTagRevision+1)}, args...)
+	}
+	log, err := s.log(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+	messages := make([]string, 0, len(log.Entries))
+	for _, e := range log.Entries {
+		messages = append(messages, e.Msg)
+	}
+	return messages, nil
+}
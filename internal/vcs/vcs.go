@@ -1,6 +1,10 @@
 package vcs
 
-import "time"
+import (
+	"fmt"
+	"slices"
+	"time"
+)
 
 // VersionControlSystem defines the interface for version control operations
 type VersionControlSystem interface {
@@ -27,7 +31,7 @@ type VersionControlSystem interface {
 
 	// GetTagCommit returns the commit hash that the named tag points to.
 	// Returns an error if the tag does not exist. The returned hash is the
-	// full-length identifier (40 chars for git).
+	// full-length identifier (see MaxIdentifierLength).
 	GetTagCommit(tagName string) (string, error)
 
 	// CreateBranch creates a branch with the specified name from the current HEAD
@@ -50,10 +54,12 @@ type VersionControlSystem interface {
 	// Returns 0 if on a tagged commit, -1 if no tags exist
 	GetCommitsSinceTag() (int, error)
 
-	// GetUncommittedChanges returns the count of uncommitted changes (staged + unstaged + untracked)
+	// GetUncommittedChanges returns the count of uncommitted changes (staged + unstaged + untracked),
+	// honouring DirtyCheck and CountIgnored
 	GetUncommittedChanges() (int, error)
 
-	// GetLastTag returns the most recent semver tag
+	// GetLastTag returns the most recent tag reachable from HEAD that follows
+	// release.tagFormat (any tag when unset)
 	// Returns empty string if no matching tags exist
 	GetLastTag() (string, error)
 
@@ -89,3 +95,275 @@ type VersionControlSystem interface {
 	// PushBranch pushes a branch to the remote repository
 	PushBranch(branchName string) error
 }
+
+// Hash algorithm names reported by IdentifierInfo
+const (
+	HashAlgorithmSHA1     = "sha1"
+	HashAlgorithmSHA256   = "sha256"
+	HashAlgorithmRevision = "revision" // numeric revisions (e.g., Subversion)
+)
+
+// DefaultIdentifierLength is the full identifier length assumed for VCS
+// implementations that do not implement IdentifierInfo (hex SHA-1, as used
+// by git and Mercurial)
+const DefaultIdentifierLength = 40
+
+// IdentifierInfo is an optional capability for VCS implementations whose
+// identifiers are not 40-character SHA-1 hashes (e.g., git repositories using
+// the SHA-256 object format). Callers should use the HashAlgorithm and
+// MaxIdentifierLength helpers, which fall back to SHA-1 defaults.
+type IdentifierInfo interface {
+	// HashAlgorithm returns the identifier algorithm (e.g., "sha1", "sha256", "revision")
+	HashAlgorithm() string
+
+	// MaxIdentifierLength returns the length of a full identifier
+	// (40 for SHA-1, 64 for SHA-256, 0 when identifiers have no fixed length)
+	MaxIdentifierLength() int
+}
+
+// HashAlgorithm returns the identifier algorithm of v, or "sha1" when v does
+// not implement IdentifierInfo
+func HashAlgorithm(v VersionControlSystem) string {
+	if info, ok := v.(IdentifierInfo); ok {
+		return info.HashAlgorithm()
+	}
+	return HashAlgorithmSHA1
+}
+
+// MaxIdentifierLength returns the full identifier length of v, or
+// DefaultIdentifierLength when v does not implement IdentifierInfo or reports
+// no fixed length
+func MaxIdentifierLength(v VersionControlSystem) int {
+	if info, ok := v.(IdentifierInfo); ok && info.MaxIdentifierLength() > 0 {
+		return info.MaxIdentifierLength()
+	}
+	return DefaultIdentifierLength
+}
+
+// BareRepository is an optional capability for VCS implementations that can
+// operate on repositories without a working tree (e.g., server-side hooks
+// running against a bare repository)
+type BareRepository interface {
+	// IsBare reports whether the repository has no working tree
+	IsBare() bool
+
+	// ReadHeadFile returns the contents of path, relative to the repository
+	// root, as committed at HEAD
+	ReadHeadFile(path string) ([]byte, error)
+}
+
+// ShallowRepository is an optional capability for VCS implementations whose
+// clones can lack history (e.g., git clone --depth), so tags and commit
+// counts may be missing
+type ShallowRepository interface {
+	// IsShallow reports whether the repository's history is truncated
+	IsShallow() bool
+}
+
+// BranchSwitcher is an optional capability for VCS implementations that can
+// check out another branch in the working tree (e.g., to commit on a release
+// branch). Callers discover it with a type assertion on the active VCS.
+type BranchSwitcher interface {
+	// Checkout switches the working tree to the named local branch
+	Checkout(branchName string) error
+}
+
+// TagRef describes a tag and the commit it resolves to
+type TagRef struct {
+	// Name is the short tag name (e.g., "v1.2.3")
+	Name string
+	// Commit is the full hash of the commit the tag points to
+	// (annotated tags are peeled to their target commit)
+	Commit string
+	// Date is the author date of the tagged commit, in UTC
+	Date time.Time
+	// Branches lists the local branches whose history contains the tagged commit
+	Branches []string
+}
+
+// TagLister is an optional capability for VCS implementations that can
+// enumerate every tag in the repository, not just the nearest one reachable
+// from HEAD. Callers discover it with a type assertion on the active VCS.
+type TagLister interface {
+	// ListTags returns all tags in the repository, ordered by commit date
+	// (oldest first, ties broken by tag name)
+	ListTags() ([]TagRef, error)
+}
+
+// TagNameLister is an optional capability for VCS implementations that can
+// list tag names without resolving commits, dates, or branches. Callers that
+// only need names (e.g. to find the previous version) prefer it over
+// TagLister, which can be slow on large repositories.
+type TagNameLister interface {
+	// ListTagNames returns the short name of every tag, sorted by name
+	ListTagNames() ([]string, error)
+}
+
+// BranchDistance is an optional capability for VCS implementations that can
+// count the commits separating HEAD from another branch. Callers discover it
+// with a type assertion on the active VCS.
+type BranchDistance interface {
+	// RemoteDefaultBranch returns the primary remote's default branch as a
+	// ref HEAD can be compared with (e.g. "origin/main")
+	RemoteDefaultBranch() (string, error)
+
+	// CommitsAheadBehind returns the number of commits reachable from HEAD
+	// but not from base (ahead), and from base but not from HEAD (behind)
+	CommitsAheadBehind(base string) (ahead, behind int, err error)
+}
+
+// DetachedHeadHints is an optional capability for VCS implementations that
+// can suggest the branch a detached HEAD belongs to. Callers discover it with
+// a type assertion on the active VCS; InferBranch combines the hints.
+type DetachedHeadHints interface {
+	// ReflogBranch returns the branch HEAD was last checked out from, per
+	// the reflog; empty when the reflog names none
+	ReflogBranch() (string, error)
+
+	// BranchesContainingHead returns the local and remote-tracking branches
+	// whose history contains HEAD, remote branches without the remote name
+	// (origin/main -> main), sorted and deduplicated
+	BranchesContainingHead() ([]string, error)
+}
+
+// IgnoreChecker is an optional capability for VCS implementations that can
+// report whether a working tree file is ignored or tracked (e.g., to keep
+// generated files out of commits). Callers discover it with a type
+// assertion on the active VCS.
+type IgnoreChecker interface {
+	// IsIgnored reports whether the ignore rules (.gitignore) match path,
+	// whether or not the file is tracked
+	IsIgnored(path string) (bool, error)
+
+	// IsTracked reports whether path is tracked (in the index)
+	IsTracked(path string) (bool, error)
+
+	// Ignore adds an ignore rule matching exactly path to the ignore file
+	// at the repository root
+	Ignore(path string) error
+}
+
+// FileChange describes the most recent commit that modified a file
+type FileChange struct {
+	// Author is the name of the commit author
+	Author string
+	// Date is the author date of the commit, in UTC
+	Date time.Time
+}
+
+// FileHistory is an optional capability for VCS implementations that can
+// report who last changed a file (e.g., to attribute the last version bump).
+// Callers discover it with a type assertion on the active VCS.
+type FileHistory interface {
+	// LastChange returns the most recent commit reachable from HEAD that
+	// modified path, relative to the repository root. A file with no history
+	// returns a zero FileChange and no error.
+	LastChange(path string) (FileChange, error)
+}
+
+// Tag signature formats reported by TagSignature
+const (
+	SignatureFormatGPG = "gpg"
+	SignatureFormatSSH = "ssh"
+)
+
+// TagSignature describes a valid signature on a tag
+type TagSignature struct {
+	// Format is the signature format ("gpg" or "ssh")
+	Format string
+	// Signer is the SSH principal from the allowed signers file, or the GPG
+	// user ID; empty when the key matched no principal
+	Signer string
+	// Fingerprints identify the signing key: the SSH key fingerprint, or the
+	// GPG signing key and primary key fingerprints
+	Fingerprints []string
+}
+
+// TagVerifier is an optional capability for VCS implementations that can
+// check tag signatures and read files as committed at a tagged commit.
+// Callers discover it with a type assertion on the active VCS.
+type TagVerifier interface {
+	// VerifyTag checks the signature on tagName and returns the signer.
+	// allowedSignersFile lists the SSH keys trusted to sign ("" uses the
+	// VCS configuration). Unsigned tags and invalid signatures are errors.
+	VerifyTag(tagName, allowedSignersFile string) (TagSignature, error)
+
+	// ReadFileAt returns the contents of path, relative to the repository
+	// root, as committed at commit (a full commit hash)
+	ReadFileAt(commit, path string) ([]byte, error)
+}
+
+// Capability names a VCS feature that commands may depend on
+type Capability string
+
+const (
+	CapabilityTags       Capability = "tags"
+	CapabilitySignedTags Capability = "signed-tags"
+	CapabilityBranches   Capability = "branches"
+	CapabilityPush       Capability = "push"
+	CapabilityAmend      Capability = "amend"
+	CapabilityNotes      Capability = "notes"
+	CapabilityTagListing Capability = "tag-listing"
+	CapabilityBare       Capability = "bare"
+)
+
+// capabilityDescriptions complete "<vcs> does not support ..." messages
+var capabilityDescriptions = map[Capability]string{
+	CapabilityTags:       "tags",
+	CapabilitySignedTags: "signed tags",
+	CapabilityBranches:   "branches",
+	CapabilityPush:       "pushing to a remote",
+	CapabilityAmend:      "amending commits",
+	CapabilityNotes:      "notes",
+	CapabilityTagListing: "listing tags",
+	CapabilityBare:       "bare repositories",
+}
+
+// CapabilityReporter is an optional capability for VCS implementations whose
+// features differ from the defaults assumed by Capabilities (e.g., a backend
+// that cannot push, or one that can sign tags). Callers should use the
+// Capabilities, Supports, and RequireCapability helpers.
+type CapabilityReporter interface {
+	// Capabilities returns every feature the backend supports
+	Capabilities() []Capability
+}
+
+// Capabilities returns the features of v. Without CapabilityReporter, v is
+// assumed to support what the core interface offers (tags, branches, push,
+// amend) plus the optional interfaces it implements (TagLister,
+// BareRepository, TagVerifier); notes are never assumed.
+func Capabilities(v VersionControlSystem) []Capability {
+	if reporter, ok := v.(CapabilityReporter); ok {
+		return reporter.Capabilities()
+	}
+	caps := []Capability{CapabilityTags, CapabilityBranches, CapabilityPush, CapabilityAmend}
+	if _, ok := v.(TagLister); ok {
+		caps = append(caps, CapabilityTagListing)
+	}
+	if _, ok := v.(BareRepository); ok {
+		caps = append(caps, CapabilityBare)
+	}
+	if _, ok := v.(TagVerifier); ok {
+		caps = append(caps, CapabilitySignedTags)
+	}
+	return caps
+}
+
+// Supports reports whether v supports capability c
+func Supports(v VersionControlSystem, c Capability) bool {
+	return slices.Contains(Capabilities(v), c)
+}
+
+// RequireCapability returns an error naming the backend and the missing
+// feature when v does not support c, so commands can fail before making
+// any changes
+func RequireCapability(v VersionControlSystem, c Capability) error {
+	if Supports(v, c) {
+		return nil
+	}
+	description, ok := capabilityDescriptions[c]
+	if !ok {
+		description = string(c)
+	}
+	return fmt.Errorf("%s does not support %s", v.Name(), description)
+}
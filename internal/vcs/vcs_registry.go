@@ -1,13 +1,54 @@
 package vcs
 
 import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 )
 
+// ErrAmbiguousVCS is reported when repositories of several version control
+// systems are detected and nothing decides between them
+const ErrAmbiguousVCS = "multiple version control repositories detected"
+
+// Dirty check modes, trading accuracy of Dirty and UncommittedChanges for
+// speed on very large checkouts
+const (
+	// DirtyCheckFull counts staged, unstaged, and untracked files (default)
+	DirtyCheckFull = "full"
+	// DirtyCheckTracked ignores untracked files, so the working tree is not
+	// scanned for new files (git status -uno)
+	DirtyCheckTracked = "tracked"
+	// DirtyCheckOff skips the check when collecting template data; the tree
+	// is reported clean
+	DirtyCheckOff = "off"
+)
+
+// Tag distance modes, choosing which tag CommitsSinceTag counts from when
+// merges bring several tags into HEAD's history
+const (
+	// TagDistanceLog counts to the first tagged commit in log order (default)
+	TagDistanceLog = "log"
+	// TagDistanceDescribe picks the tag git describe would: of the nearest
+	// tags by commit date, the one with the fewest commits since it
+	TagDistanceDescribe = "describe"
+)
+
 // VCSRegistry manages available version control systems
 type VCSRegistry struct {
-	systems map[string]VersionControlSystem
-	mutex   sync.RWMutex
+	systems    map[string]VersionControlSystem
+	priority   []string
+	forced     string
+	dirtyCheck string
+	// countIgnored also counts files matched by ignore rules as dirty
+	countIgnored bool
+	// defaultBranch is the ref compared against for CommitsAheadOfDefault;
+	// empty uses the remote's default branch
+	defaultBranch string
+	// tagDistance chooses how the last tag is found; empty means TagDistanceLog
+	tagDistance string
+	mutex       sync.RWMutex
 }
 
 var registry = &VCSRegistry{
@@ -28,19 +69,159 @@ func (r *VCSRegistry) UnregisterVCS(name string) {
 	delete(r.systems, name)
 }
 
-// GetActiveVCS returns the first VCS that detects it's in a repository
+// SetPriority sets the detection order: the named systems are tried first,
+// in order, followed by the remaining systems alphabetically. Names that are
+// not registered are ignored, so the list may mention optional plugins.
+func (r *VCSRegistry) SetPriority(names []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.priority = slices.Clone(names)
+}
+
+// SetForced restricts detection to the named VCS; an empty name restores
+// detection across all registered systems
+func (r *VCSRegistry) SetForced(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.forced = name
+}
+
+// SetDirtyCheck sets the dirty check mode; an empty mode restores
+// DirtyCheckFull
+func (r *VCSRegistry) SetDirtyCheck(mode string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.dirtyCheck = mode
+}
+
+// DirtyCheck returns the dirty check mode
+func (r *VCSRegistry) DirtyCheck() string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.dirtyCheck == "" {
+		return DirtyCheckFull
+	}
+	return r.dirtyCheck
+}
+
+// SetCountIgnored sets whether files matched by ignore rules (.gitignore)
+// count as uncommitted changes in DirtyCheckFull mode
+func (r *VCSRegistry) SetCountIgnored(on bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.countIgnored = on
+}
+
+// CountIgnored reports whether ignored files count as uncommitted changes
+func (r *VCSRegistry) CountIgnored() bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.countIgnored
+}
+
+// SetDefaultBranch sets the ref HEAD is compared against when counting
+// commits ahead of and behind the default branch; empty detects it
+func (r *VCSRegistry) SetDefaultBranch(ref string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.defaultBranch = ref
+}
+
+// DefaultBranch returns the configured default branch ref, or empty to
+// detect it from the remote
+func (r *VCSRegistry) DefaultBranch() string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.defaultBranch
+}
+
+// SetTagDistance sets how the last tag and the commits since it are
+// found; an empty mode restores TagDistanceLog
+func (r *VCSRegistry) SetTagDistance(mode string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.tagDistance = mode
+}
+
+// TagDistance returns the tag distance mode
+func (r *VCSRegistry) TagDistance() string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.tagDistance == "" {
+		return TagDistanceLog
+	}
+	return r.tagDistance
+}
+
+// candidates returns the names of the registered systems in detection
+// order. The caller must hold the lock.
+func (r *VCSRegistry) candidates() []string {
+	if r.forced != "" {
+		if _, ok := r.systems[r.forced]; ok {
+			return []string{r.forced}
+		}
+		return nil
+	}
+
+	var ordered, rest []string
+	for _, name := range r.priority {
+		if _, ok := r.systems[name]; ok && !slices.Contains(ordered, name) {
+			ordered = append(ordered, name)
+		}
+	}
+	for name := range r.systems {
+		if !slices.Contains(ordered, name) {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(ordered, rest...)
+}
+
+// GetActiveVCS returns the first VCS, in detection order, that detects it's
+// in a repository
 func (r *VCSRegistry) GetActiveVCS() VersionControlSystem {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
-	for _, vcs := range r.systems {
-		if vcs.IsRepository() {
+	for _, name := range r.candidates() {
+		if vcs := r.systems[name]; vcs.IsRepository() {
 			return vcs
 		}
 	}
 	return nil
 }
 
+// DetectVCS returns the active VCS like GetActiveVCS, but fails when
+// repositories of several systems are detected (e.g. a git checkout nested in
+// a Mercurial repository) and neither the priority nor a forced VCS picks one
+func (r *VCSRegistry) DetectVCS() (VersionControlSystem, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var detected []string
+	for _, name := range r.candidates() {
+		if r.systems[name].IsRepository() {
+			detected = append(detected, name)
+		}
+	}
+	if len(detected) == 0 {
+		return nil, nil
+	}
+	if len(detected) > 1 && !slices.Contains(r.priority, detected[0]) {
+		found := make([]string, len(detected))
+		for i, name := range detected {
+			found[i] = name
+			if root, err := r.systems[name].GetRepositoryRoot(); err == nil {
+				found[i] += " (" + root + ")"
+			}
+		}
+		return nil, fmt.Errorf("%s: %s; set vcs.priority in .versionator.yaml or pass --vcs",
+			ErrAmbiguousVCS, strings.Join(found, ", "))
+	}
+	return r.systems[detected[0]], nil
+}
+
 // GetVCS returns a specific VCS by name
 func (r *VCSRegistry) GetVCS(name string) VersionControlSystem {
 	r.mutex.RLock()
@@ -48,7 +229,7 @@ func (r *VCSRegistry) GetVCS(name string) VersionControlSystem {
 	return r.systems[name]
 }
 
-// ListVCS returns all registered VCS names
+// ListVCS returns all registered VCS names, sorted
 func (r *VCSRegistry) ListVCS() []string {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
@@ -57,6 +238,7 @@ func (r *VCSRegistry) ListVCS() []string {
 	for name := range r.systems {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
@@ -73,6 +255,50 @@ func GetActiveVCS() VersionControlSystem {
 	return registry.GetActiveVCS()
 }
 
+func DetectVCS() (VersionControlSystem, error) {
+	return registry.DetectVCS()
+}
+
+func SetPriority(names []string) {
+	registry.SetPriority(names)
+}
+
+func SetForced(name string) {
+	registry.SetForced(name)
+}
+
+func SetDirtyCheck(mode string) {
+	registry.SetDirtyCheck(mode)
+}
+
+func DirtyCheck() string {
+	return registry.DirtyCheck()
+}
+
+func SetCountIgnored(on bool) {
+	registry.SetCountIgnored(on)
+}
+
+func CountIgnored() bool {
+	return registry.CountIgnored()
+}
+
+func SetDefaultBranch(ref string) {
+	registry.SetDefaultBranch(ref)
+}
+
+func DefaultBranch() string {
+	return registry.DefaultBranch()
+}
+
+func SetTagDistance(mode string) {
+	registry.SetTagDistance(mode)
+}
+
+func TagDistance() string {
+	return registry.TagDistance()
+}
+
 func GetVCS(name string) VersionControlSystem {
 	return registry.GetVCS(name)
 }
@@ -2,6 +2,7 @@ package vcs
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
@@ -541,3 +542,191 @@ func TestVCSRegistry_GetVCS_ReturnsNilForNonexistent(t *testing.T) {
 		t.Error("Expected nil for non-existent VCS")
 	}
 }
+
+// identifierInfoVCS wraps the generated mock with the optional IdentifierInfo
+// capability
+type identifierInfoVCS struct {
+	*mock.MockVersionControlSystem
+	algorithm string
+	length    int
+}
+
+func (v *identifierInfoVCS) HashAlgorithm() string    { return v.algorithm }
+func (v *identifierInfoVCS) MaxIdentifierLength() int { return v.length }
+
+// TestIdentifierHelpers_FallBackToSHA1 validates identifier capability discovery.
+//
+// Why: Callers must request full-length identifiers without assuming 40-char
+// SHA-1 hashes, while VCS plugins that predate IdentifierInfo keep working.
+//
+// What: A VCS without IdentifierInfo reports sha1/40; one with it reports its
+// own values; a zero length (no fixed length, e.g., numeric revisions) falls
+// back to the default.
+func TestIdentifierHelpers_FallBackToSHA1(t *testing.T) {
+	// Precondition: Plain mock and capability-wrapped mocks
+	ctrl := gomock.NewController(t)
+	plain := mock.NewMockVersionControlSystem(ctrl)
+	sha256 := &identifierInfoVCS{MockVersionControlSystem: plain, algorithm: HashAlgorithmSHA256, length: 64}
+	revision := &identifierInfoVCS{MockVersionControlSystem: plain, algorithm: HashAlgorithmRevision}
+
+	// Action / Expected: Plain mock uses SHA-1 defaults
+	if HashAlgorithm(plain) != HashAlgorithmSHA1 || MaxIdentifierLength(plain) != DefaultIdentifierLength {
+		t.Errorf("plain VCS: got %s/%d", HashAlgorithm(plain), MaxIdentifierLength(plain))
+	}
+
+	// Action / Expected: Capability values are reported
+	if HashAlgorithm(sha256) != HashAlgorithmSHA256 || MaxIdentifierLength(sha256) != 64 {
+		t.Errorf("sha256 VCS: got %s/%d", HashAlgorithm(sha256), MaxIdentifierLength(sha256))
+	}
+
+	// Action / Expected: No fixed length falls back to the default
+	if HashAlgorithm(revision) != HashAlgorithmRevision || MaxIdentifierLength(revision) != DefaultIdentifierLength {
+		t.Errorf("revision VCS: got %s/%d", HashAlgorithm(revision), MaxIdentifierLength(revision))
+	}
+}
+
+// nestedRegistry returns a registry where both a git and an hg repository
+// are detected, as for a git checkout inside a Mercurial repository
+func nestedRegistry(ctrl *gomock.Controller) (*VCSRegistry, VersionControlSystem, VersionControlSystem) {
+	git := mock.NewMockVersionControlSystem(ctrl)
+	git.EXPECT().IsRepository().Return(true).AnyTimes()
+	git.EXPECT().GetRepositoryRoot().Return("/work/outer/inner", nil).AnyTimes()
+	hg := mock.NewMockVersionControlSystem(ctrl)
+	hg.EXPECT().IsRepository().Return(true).AnyTimes()
+	hg.EXPECT().GetRepositoryRoot().Return("/work/outer", nil).AnyTimes()
+
+	registry := &VCSRegistry{systems: map[string]VersionControlSystem{"git": git, "hg": hg}}
+	return registry, git, hg
+}
+
+// TestVCSRegistry_DetectVCS_NestedRepositories_ReturnsError validates that
+// nested repositories of different systems are reported.
+//
+// Why: Silently picking one system would version and tag the wrong
+// repository; the user must choose.
+//
+// What: With git and hg both detected and no priority, DetectVCS fails with
+// ErrAmbiguousVCS naming both repositories.
+func TestVCSRegistry_DetectVCS_NestedRepositories_ReturnsError(t *testing.T) {
+	// Precondition: Nested git and hg repositories, no priority
+	ctrl := gomock.NewController(t)
+	registry, _, _ := nestedRegistry(ctrl)
+
+	// Action: Detect the VCS
+	active, err := registry.DetectVCS()
+
+	// Expected: Ambiguity error listing both systems with their roots
+	if err == nil || active != nil {
+		t.Fatalf("expected ambiguity error, got %v, %v", active, err)
+	}
+	for _, want := range []string{ErrAmbiguousVCS, "git (/work/outer/inner)", "hg (/work/outer)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
+
+// TestVCSRegistry_SetPriority_OrdersDetection validates vcs.priority.
+//
+// Why: Repositories that deliberately nest systems configure which one owns
+// the version.
+//
+// What: With priority [hg, git], hg is detected first and DetectVCS succeeds.
+func TestVCSRegistry_SetPriority_OrdersDetection(t *testing.T) {
+	// Precondition: Nested repositories with hg preferred
+	ctrl := gomock.NewController(t)
+	registry, _, hg := nestedRegistry(ctrl)
+	registry.SetPriority([]string{"svn", "hg", "git"})
+
+	// Action: Detect the VCS
+	active, err := registry.DetectVCS()
+
+	// Expected: hg, also from GetActiveVCS
+	if err != nil || active != hg {
+		t.Fatalf("expected hg, got %v, %v", active, err)
+	}
+	if registry.GetActiveVCS() != hg {
+		t.Error("expected GetActiveVCS to follow the priority")
+	}
+}
+
+// TestVCSRegistry_SetForced_RestrictsDetection validates the --vcs override.
+//
+// Why: A single command may need to act on the other repository without
+// editing the config.
+//
+// What: Forcing git selects it despite an hg priority; forcing an
+// unregistered name detects nothing.
+func TestVCSRegistry_SetForced_RestrictsDetection(t *testing.T) {
+	// Precondition: Nested repositories with hg preferred
+	ctrl := gomock.NewController(t)
+	registry, git, _ := nestedRegistry(ctrl)
+	registry.SetPriority([]string{"hg"})
+
+	// Action: Force git, then an unknown system
+	registry.SetForced("git")
+	forced, err := registry.DetectVCS()
+	registry.SetForced("fossil")
+	unknown := registry.GetActiveVCS()
+
+	// Expected: git, then nothing
+	if err != nil || forced != git {
+		t.Errorf("expected forced git, got %v, %v", forced, err)
+	}
+	if unknown != nil {
+		t.Errorf("expected no VCS for unregistered name, got %v", unknown)
+	}
+}
+
+// capabilityVCS wraps the generated mock with the optional
+// CapabilityReporter capability
+type capabilityVCS struct {
+	*mock.MockVersionControlSystem
+	caps []Capability
+}
+
+func (v *capabilityVCS) Capabilities() []Capability { return v.caps }
+
+// TestCapabilities_DefaultsAndReporter_DescribeBackend validates capability
+// discovery.
+//
+// Why: Commands must fail fast with a clear message on backends lacking a
+// feature, while plugins that predate CapabilityReporter keep working.
+//
+// What: A plain VCS reports the core features but not signed tags; a
+// TagLister adds tag listing; a reporter's own list wins, and
+// RequireCapability names the backend and the missing feature.
+func TestCapabilities_DefaultsAndReporter_DescribeBackend(t *testing.T) {
+	// Precondition: Plain, tag-listing, and reporting mocks
+	ctrl := gomock.NewController(t)
+	plain := mock.NewMockVersionControlSystem(ctrl)
+	plain.EXPECT().Name().Return("svn").AnyTimes()
+	lister := &tagListerVCS{MockVersionControlSystem: plain}
+	readOnly := &capabilityVCS{MockVersionControlSystem: plain, caps: []Capability{CapabilityTags}}
+
+	// Action / Expected: Defaults cover the core interface only
+	if !Supports(plain, CapabilityPush) || Supports(plain, CapabilitySignedTags) || Supports(plain, CapabilityTagListing) {
+		t.Errorf("plain VCS: unexpected capabilities %v", Capabilities(plain))
+	}
+
+	// Action / Expected: Optional interfaces are detected
+	if !Supports(lister, CapabilityTagListing) {
+		t.Errorf("tag lister: expected tag-listing in %v", Capabilities(lister))
+	}
+
+	// Action / Expected: Reporter overrides the defaults
+	if err := RequireCapability(readOnly, CapabilityTags); err != nil {
+		t.Errorf("expected tags to be supported, got %v", err)
+	}
+	err := RequireCapability(readOnly, CapabilityPush)
+	if err == nil || err.Error() != "svn does not support pushing to a remote" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// tagListerVCS wraps the generated mock with the optional TagLister capability
+type tagListerVCS struct {
+	*mock.MockVersionControlSystem
+}
+
+func (v *tagListerVCS) ListTags() ([]TagRef, error) { return nil, nil }
@@ -0,0 +1,81 @@
+package version
+
+import (
+	"strings"
+)
+
+// Compare returns -1, 0, or 1 depending on whether v has lower, equal, or
+// higher SemVer 2.0.0 precedence than other. Prefix and build metadata are
+// ignored. A missing revision compares as 0.
+func (v *Version) Compare(other *Version) int {
+	if c := compareInt(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	if c := compareInt(v.RevisionValue(), other.RevisionValue()); c != 0 {
+		return c
+	}
+	return comparePreRelease(v.PreRelease, other.PreRelease)
+}
+
+// comparePreRelease applies SemVer precedence rules to pre-release strings:
+// a release outranks any pre-release; identifiers are compared left to right,
+// numeric identifiers numerically and below alphanumeric ones; a longer set
+// of identifiers wins when all preceding identifiers are equal.
+func comparePreRelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareIdentifier(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(as), len(bs))
+}
+
+// compareIdentifier compares a single pre-release identifier. Identifiers
+// of digits only are numeric, compared by value whatever their length;
+// others, including "-1", are alphanumeric and compared in ASCII order.
+func compareIdentifier(a, b string) int {
+	aNumeric, bNumeric := isDigits(a), isDigits(b)
+	switch {
+	case aNumeric && bNumeric:
+		// Without leading zeros, the longer number is the larger
+		a, b = trimLeadingZeros(a), trimLeadingZeros(b)
+		if c := compareInt(len(a), len(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
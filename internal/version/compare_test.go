@@ -0,0 +1,110 @@
+package version
+
+import "testing"
+
+// TestCompare_SemVerPrecedence validates Compare against the precedence
+// examples from the SemVer 2.0.0 specification.
+//
+// Why: Finding the latest release across all tags depends on correct
+// precedence, including the pre-release rules that plain string sorting
+// gets wrong (e.g., "rc.10" vs "rc.2").
+//
+// What: Each pair is compared in both directions and must yield the
+// expected sign and its inverse.
+func TestCompare_SemVerPrecedence(t *testing.T) {
+	// Precondition: Pairs where the left side has lower precedence
+	tests := []struct {
+		lower  string
+		higher string
+	}{
+		{"1.0.0", "2.0.0"},
+		{"2.0.0", "2.1.0"},
+		{"2.1.0", "2.1.1"},
+		{"1.0.0-alpha", "1.0.0"},
+		{"1.0.0-alpha", "1.0.0-alpha.1"},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta"},
+		{"1.0.0-alpha.beta", "1.0.0-beta"},
+		{"1.0.0-beta", "1.0.0-beta.2"},
+		{"1.0.0-beta.2", "1.0.0-beta.11"},
+		{"1.0.0-beta.11", "1.0.0-rc.1"},
+		{"1.0.0-rc.1", "1.0.0"},
+		{"1.2.3", "1.2.3.1"},
+		// Numeric identifiers beyond int64 still compare numerically
+		{"1.0.0-rc.99999999999999999999", "1.0.0-rc.100000000000000000000"},
+		{"1.0.0-rc.100000000000000000000", "1.0.0-rc.a"},
+	}
+
+	for _, tt := range tests {
+		lower, err := ParseStrict(tt.lower)
+		if err != nil {
+			t.Fatalf("ParseStrict(%q) failed: %v", tt.lower, err)
+		}
+		higher, err := ParseStrict(tt.higher)
+		if err != nil {
+			t.Fatalf("ParseStrict(%q) failed: %v", tt.higher, err)
+		}
+
+		// Action: Compare in both directions
+		// Expected: lower < higher and higher > lower
+		if got := lower.Compare(higher); got != -1 {
+			t.Errorf("%s.Compare(%s) = %d, want -1", tt.lower, tt.higher, got)
+		}
+		if got := higher.Compare(lower); got != 1 {
+			t.Errorf("%s.Compare(%s) = %d, want 1", tt.higher, tt.lower, got)
+		}
+	}
+}
+
+// TestComparePreRelease_NumericIdentifiers validates which identifiers are
+// numeric.
+//
+// Why: SemVer 2.0.0 §11 compares identifiers of digits only numerically;
+// strconv parsing would treat "-1" as a number and give up on numbers too
+// large for an int.
+//
+// What: "-1" and "+1" are alphanumeric, above any number; numbers beyond
+// int64 and with leading zeros compare by value.
+func TestComparePreRelease_NumericIdentifiers(t *testing.T) {
+	// Precondition: Pre-release pairs where the left side has lower precedence
+	tests := []struct {
+		lower  string
+		higher string
+	}{
+		{"rc.2", "rc.-1"},
+		{"rc.99", "rc.+1"},
+		{"rc.9223372036854775807", "rc.9223372036854775808"},
+		{"rc.99999999999999999999", "rc.100000000000000000000"},
+		{"rc.0002", "rc.10"},
+	}
+
+	for _, tt := range tests {
+		// Action: Compare in both directions
+		// Expected: lower < higher and higher > lower
+		if got := comparePreRelease(tt.lower, tt.higher); got != -1 {
+			t.Errorf("comparePreRelease(%q, %q) = %d, want -1", tt.lower, tt.higher, got)
+		}
+		if got := comparePreRelease(tt.higher, tt.lower); got != 1 {
+			t.Errorf("comparePreRelease(%q, %q) = %d, want 1", tt.higher, tt.lower, got)
+		}
+	}
+}
+
+// TestCompare_IgnoresPrefixAndMetadata validates that prefix and build
+// metadata do not affect precedence.
+//
+// Why: Tags "v1.0.0" and "1.0.0+build.5" refer to the same release per SemVer.
+//
+// What: Versions differing only by prefix or metadata compare as equal.
+func TestCompare_IgnoresPrefixAndMetadata(t *testing.T) {
+	// Precondition: Same version with different prefix and metadata
+	a, _ := ParseStrict("v1.0.0+build.1")
+	b, _ := ParseStrict("1.0.0+build.2")
+
+	// Action: Compare
+	got := a.Compare(b)
+
+	// Expected: Equal precedence
+	if got != 0 {
+		t.Errorf("Compare = %d, want 0", got)
+	}
+}
@@ -0,0 +1,165 @@
+package version
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// VERSION file formats (versionFileFormat in .versionator.yaml)
+const (
+	FileFormatPlain = "plain"
+	FileFormatYAML  = "yaml"
+	FileFormatJSON  = "json"
+)
+
+// versionKey is the field holding the version in a structured VERSION file
+const versionKey = "version"
+
+// fileFormat is the configured VERSION file format
+var fileFormat atomic.Value
+
+// SetFileFormat sets the VERSION file format (FileFormatPlain, FileFormatYAML,
+// or FileFormatJSON); empty means plain
+func SetFileFormat(format string) {
+	fileFormat.Store(format)
+}
+
+// FileFormat returns the configured VERSION file format
+func FileFormat() string {
+	if format, _ := fileFormat.Load().(string); format != "" {
+		return format
+	}
+	return FileFormatPlain
+}
+
+// decodeVersionFile splits VERSION content into the version string and any
+// extra fields. In the structured formats, content that is not a mapping
+// (an existing plain VERSION file) is returned as the version unchanged.
+func decodeVersionFile(content string) (string, map[string]string, error) {
+	return decodeVersionFileAs(FileFormat(), content)
+}
+
+// decodeVersionFileAs is decodeVersionFile for an explicit format
+func decodeVersionFileAs(format, content string) (string, map[string]string, error) {
+	switch format {
+	case FileFormatYAML:
+		return decodeYAMLVersionFile(content)
+	case FileFormatJSON:
+		return decodeJSONVersionFile(content)
+	}
+	return content, nil, nil
+}
+
+// decodeYAMLVersionFile reads a YAML mapping of scalar fields
+func decodeYAMLVersionFile(content string) (string, map[string]string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return "", nil, fmt.Errorf("%s: %w", ErrVersionFileFormat, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return content, nil, nil
+	}
+
+	mapping := doc.Content[0]
+	values := make(map[string]string, len(mapping.Content)/2)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			return "", nil, fmt.Errorf("%s: %s", ErrVersionFileField, key.Value)
+		}
+		values[key.Value] = value.Value
+	}
+	return splitVersionField(values)
+}
+
+// decodeJSONVersionFile reads a JSON object of scalar fields
+func decodeJSONVersionFile(content string) (string, map[string]string, error) {
+	if !strings.HasPrefix(content, "{") {
+		return content, nil, nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.UseNumber()
+	var raw map[string]any
+	if err := decoder.Decode(&raw); err != nil {
+		return "", nil, fmt.Errorf("%s: %w", ErrVersionFileFormat, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		switch value.(type) {
+		case string, json.Number, bool:
+			values[key] = fmt.Sprint(value)
+		case nil:
+			values[key] = ""
+		default:
+			return "", nil, fmt.Errorf("%s: %s", ErrVersionFileField, key)
+		}
+	}
+	return splitVersionField(values)
+}
+
+// splitVersionField removes the version field from values, returning it and
+// the remaining fields
+func splitVersionField(values map[string]string) (string, map[string]string, error) {
+	versionStr, ok := values[versionKey]
+	if !ok {
+		return "", nil, fmt.Errorf("%s", ErrVersionFileNoVersion)
+	}
+	delete(values, versionKey)
+	if len(values) == 0 {
+		values = nil
+	}
+	return strings.TrimSpace(versionStr), values, nil
+}
+
+// encodeVersionFile renders VERSION content for versionStr and fields in the
+// configured format. Structured files list the version first, then the
+// fields sorted by name.
+func encodeVersionFile(versionStr string, fields map[string]string) ([]byte, error) {
+	keys := slices.Sorted(maps.Keys(fields))
+
+	switch FileFormat() {
+	case FileFormatYAML:
+		mapping := &yaml.Node{Kind: yaml.MappingNode}
+		add := func(key, value string) {
+			mapping.Content = append(mapping.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+				&yaml.Node{Kind: yaml.ScalarNode, Value: value})
+		}
+		add(versionKey, versionStr)
+		for _, key := range keys {
+			add(key, fields[key])
+		}
+		return yaml.Marshal(mapping)
+
+	case FileFormatJSON:
+		// Written by hand so the version stays first; json.Marshal sorts keys
+		var buf bytes.Buffer
+		buf.WriteString("{\n")
+		write := func(key, value string, last bool) {
+			k, _ := json.Marshal(key)
+			v, _ := json.Marshal(value)
+			buf.WriteString("  " + string(k) + ": " + string(v))
+			if !last {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		write(versionKey, versionStr, len(keys) == 0)
+		for i, key := range keys {
+			write(key, fields[key], i == len(keys)-1)
+		}
+		buf.WriteString("}\n")
+		return buf.Bytes(), nil
+	}
+
+	return []byte(versionStr + "\n"), nil
+}
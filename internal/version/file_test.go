@@ -0,0 +1,167 @@
+package version
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// useFileFormat switches to a temp directory and the given VERSION format
+func useFileFormat(t *testing.T, format string) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	SetFileFormat(format)
+	t.Cleanup(func() { SetFileFormat("") })
+}
+
+// TestStructuredVersionFile_BumpAndSave_KeepsFields validates structured
+// VERSION round-trips.
+//
+// Why: Extra fields (channel, build, team) are maintained by hand; a bump
+// must not drop or reorder them.
+//
+// What: For YAML and JSON, Load exposes the fields, and a saved bump
+// rewrites the version first with the fields unchanged after it.
+func TestStructuredVersionFile_BumpAndSave_KeepsFields(t *testing.T) {
+	tests := []struct {
+		format   string
+		content  string
+		expected string
+	}{
+		{
+			format:   FileFormatYAML,
+			content:  "version: v1.2.3\nchannel: beta\nbuild: 42\n",
+			expected: "version: v1.3.0\nbuild: 42\nchannel: beta\n",
+		},
+		{
+			format:   FileFormatJSON,
+			content:  `{"channel": "beta", "build": 42, "version": "v1.2.3"}`,
+			expected: "{\n  \"version\": \"v1.3.0\",\n  \"build\": \"42\",\n  \"channel\": \"beta\"\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			// Precondition: Structured VERSION with two extra fields
+			useFileFormat(t, tt.format)
+			if err := os.WriteFile(versionFile, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			// Action: Load, bump minor, save
+			v, err := Load()
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+			if err := v.IncrementLevelBy(MinorLevel, 1); err != nil {
+				t.Fatal(err)
+			}
+			saveErr := Save(v)
+			content, _ := os.ReadFile(versionFile)
+
+			// Expected: Fields loaded and written back after the new version
+			if v.Fields["channel"] != "beta" || v.Fields["build"] != "42" || len(v.Fields) != 2 {
+				t.Errorf("unexpected fields %v", v.Fields)
+			}
+			if saveErr != nil {
+				t.Fatalf("Save() error: %v", saveErr)
+			}
+			if string(content) != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, content)
+			}
+		})
+	}
+}
+
+// TestStructuredVersionFile_PlainContent_LoadsAndUpgrades validates backward
+// compatibility.
+//
+// Why: Switching versionFileFormat must not break repositories whose VERSION
+// is still a bare version string.
+//
+// What: A plain VERSION loads under the YAML format; SetVersion rewrites it
+// as a structured file.
+func TestStructuredVersionFile_PlainContent_LoadsAndUpgrades(t *testing.T) {
+	// Precondition: Plain VERSION, YAML format configured
+	useFileFormat(t, FileFormatYAML)
+	if err := os.WriteFile(versionFile, []byte("1.2.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action
+	v, loadErr := Load()
+	setErr := SetVersion("1.2.4")
+	content, _ := os.ReadFile(versionFile)
+
+	// Expected
+	if loadErr != nil || v.String() != "1.2.3" || v.Fields != nil {
+		t.Fatalf("Load() = %v (fields %v), %v", v, v.Fields, loadErr)
+	}
+	if setErr != nil {
+		t.Fatalf("SetVersion() error: %v", setErr)
+	}
+	if string(content) != "version: 1.2.4\n" {
+		t.Errorf("expected structured VERSION, got %q", content)
+	}
+}
+
+// TestStructuredVersionFile_SetVersion_KeepsFields validates that replacing
+// the version keeps the other fields.
+func TestStructuredVersionFile_SetVersion_KeepsFields(t *testing.T) {
+	// Precondition
+	useFileFormat(t, FileFormatYAML)
+	if err := os.WriteFile(versionFile, []byte("version: 1.0.0\nchannel: stable\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action
+	err := SetVersion("2.0.0")
+	content, _ := os.ReadFile(versionFile)
+
+	// Expected
+	if err != nil {
+		t.Fatalf("SetVersion() error: %v", err)
+	}
+	if string(content) != "version: 2.0.0\nchannel: stable\n" {
+		t.Errorf("unexpected VERSION %q", content)
+	}
+}
+
+// TestStructuredVersionFile_Invalid_ReturnsError validates structured file
+// errors.
+//
+// Why: A structured VERSION without a version, or with nested values that
+// cannot become template variables, should fail loudly rather than load as
+// 0.0.0.
+func TestStructuredVersionFile_Invalid_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		content  string
+		expected string
+	}{
+		{"yaml missing version", FileFormatYAML, "channel: beta\n", ErrVersionFileNoVersion},
+		{"yaml nested field", FileFormatYAML, "version: 1.0.0\nowners:\n  - a\n", ErrVersionFileField},
+		{"json missing version", FileFormatJSON, `{"channel": "beta"}`, ErrVersionFileNoVersion},
+		{"json nested field", FileFormatJSON, `{"version": "1.0.0", "meta": {"a": 1}}`, ErrVersionFileField},
+		{"json malformed", FileFormatJSON, `{"version": `, ErrVersionFileFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precondition
+			useFileFormat(t, tt.format)
+			if err := os.WriteFile(versionFile, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			// Action
+			_, err := Load()
+
+			// Expected
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
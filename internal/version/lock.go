@@ -0,0 +1,116 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
+	"github.com/benjaminabbitt/versionator/internal/logging"
+	"go.uber.org/zap"
+)
+
+// Lock settings for concurrent invocations against the same checkout
+const (
+	// LockTimeoutEnv overrides how long mutating operations wait for the lock
+	// (Go duration syntax, e.g. "2m"; "0" fails immediately when contended)
+	LockTimeoutEnv = "VERSIONATOR_LOCK_TIMEOUT"
+
+	// DefaultLockTimeout is how long to wait for a contended lock
+	DefaultLockTimeout = 30 * time.Second
+
+	// lockSuffix is appended to the VERSION path to form the lock file path
+	lockSuffix = ".lock"
+
+	// Retry backoff: starts at lockRetryMin and doubles up to lockRetryMax,
+	// so many waiting CI jobs do not hammer the filesystem
+	lockRetryMin = 25 * time.Millisecond
+	lockRetryMax = 500 * time.Millisecond
+)
+
+// FileLock is an advisory lock held by creating a lock file exclusively.
+// It is cooperative: only versionator invocations honour it.
+type FileLock struct {
+	path string
+}
+
+// LockTimeout returns the lock timeout from LockTimeoutEnv, or
+// DefaultLockTimeout when unset or invalid
+func LockTimeout() time.Duration {
+	if value := os.Getenv(LockTimeoutEnv); value != "" {
+		if timeout, err := time.ParseDuration(value); err == nil && timeout >= 0 {
+			return timeout
+		}
+	}
+	return DefaultLockTimeout
+}
+
+// AcquireLock creates the lock file at path, retrying with backoff until
+// timeout elapses. The lock file records the holder's pid, host, and start
+// time so contention errors can say who holds it.
+func AcquireLock(path string, timeout time.Duration) (*FileLock, error) {
+	logger := logging.GetLogger()
+	deadline := time.Now().Add(timeout)
+	wait := lockRetryMin
+
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, fileperm.Default)
+		if err == nil {
+			host, _ := os.Hostname()
+			_, writeErr := fmt.Fprintf(f, "pid %d on %s since %s\n", os.Getpid(), host, time.Now().UTC().Format(time.RFC3339))
+			closeErr := f.Close()
+			if err := errors.Join(writeErr, closeErr); err != nil {
+				_ = os.Remove(path)
+				return nil, fmt.Errorf("%s %s: %w", ErrLockCreate, path, err)
+			}
+			logger.Debug(LogLockAcquired, zap.String("path", path))
+			return &FileLock{path: path}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("%s %s: %w", ErrLockCreate, path, err)
+		}
+
+		if !time.Now().Add(wait).Before(deadline) {
+			holder := "unknown holder"
+			if data, readErr := os.ReadFile(path); readErr == nil && len(data) > 0 {
+				holder = "held by " + strings.TrimSpace(string(data))
+			}
+			return nil, fmt.Errorf("%s %s after %s (%s); if no other versionator process is running, remove the lock file",
+				ErrLockTimeout, path, timeout, holder)
+		}
+
+		logger.Debug(LogLockContended, zap.String("path", path), zap.Duration("retry_in", wait))
+		time.Sleep(wait)
+		wait = min(wait*2, lockRetryMax)
+	}
+}
+
+// Release removes the lock file
+func (l *FileLock) Release() error {
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("%s %s: %w", ErrLockRelease, l.path, err)
+	}
+	logging.GetLogger().Debug(LogLockReleased, zap.String("path", l.path))
+	return nil
+}
+
+// withLock runs fn while holding VERSION.lock next to the VERSION file, so a
+// read-modify-write of VERSION cannot interleave with another invocation
+func withLock(fn func() error) (err error) {
+	path, err := getVersionPath()
+	if err != nil {
+		return err
+	}
+
+	lock, err := AcquireLock(path+lockSuffix, LockTimeout())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, lock.Release())
+	}()
+
+	return fn()
+}
@@ -0,0 +1,163 @@
+package version
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestIncrement_Concurrent_NoLostUpdates validates locking of mutations.
+//
+// Why: Parallel CI jobs sharing a checkout must not interleave VERSION
+// read-modify-write cycles and silently drop bumps.
+//
+// What: Many concurrent patch increments all take effect and the lock file
+// is removed afterwards.
+func TestIncrement_Concurrent_NoLostUpdates(t *testing.T) {
+	// Precondition: VERSION at 1.0.0
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile(versionFile, []byte("1.0.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action: Increment patch from many goroutines at once
+	const workers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- Increment(PatchLevel)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	// Expected: Every increment applied, lock released
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Increment failed: %v", err)
+		}
+	}
+	v, err := Load()
+	if err != nil || v.String() != "1.0.20" {
+		t.Errorf("expected 1.0.20, got %v (%v)", v, err)
+	}
+	if _, err := os.Stat(versionFile + lockSuffix); !os.IsNotExist(err) {
+		t.Errorf("expected lock file to be removed, stat err: %v", err)
+	}
+}
+
+// TestAcquireLock_Contended_TimesOutWithHolder validates contention errors.
+//
+// Why: A job stuck behind another (or behind a lock left by a killed process)
+// needs to know what it is waiting for and how to recover.
+//
+// What: While the lock is held, a second acquire times out with
+// ErrLockTimeout naming the holder; the mutation is not applied; after
+// release the lock can be acquired again.
+func TestAcquireLock_Contended_TimesOutWithHolder(t *testing.T) {
+	// Precondition: VERSION at 1.0.0 and the lock held by this process
+	t.Chdir(t.TempDir())
+	t.Setenv(LockTimeoutEnv, "50ms")
+	if err := os.WriteFile(versionFile, []byte("1.0.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	held, err := AcquireLock(versionFile+lockSuffix, 0)
+	if err != nil {
+		t.Fatalf("AcquireLock failed: %v", err)
+	}
+
+	// Action: Attempt a mutation while the lock is held
+	start := time.Now()
+	err = SetVersion("2.0.0")
+
+	// Expected: Timeout error naming the holder; VERSION unchanged
+	if err == nil || !strings.Contains(err.Error(), ErrLockTimeout) || !strings.Contains(err.Error(), "held by pid") {
+		t.Fatalf("expected %q with holder, got %v", ErrLockTimeout, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("timeout took too long: %s", elapsed)
+	}
+	if data, _ := os.ReadFile(versionFile); strings.TrimSpace(string(data)) != "1.0.0" {
+		t.Errorf("VERSION changed while locked: %q", data)
+	}
+
+	// Action: Release and retry
+	if err := held.Release(); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	err = SetVersion("2.0.0")
+
+	// Expected: Mutation succeeds
+	if err != nil {
+		t.Fatalf("SetVersion after release failed: %v", err)
+	}
+}
+
+// TestUpdate_LockedReadModifyWrite validates the locked update helper.
+//
+// Why: Commands that compute a version from the one they loaded (release,
+// rc graduate, cut-release) must not overwrite a concurrent bump.
+//
+// What: Update waits for the lock, then applies fn to the version as it is
+// then; Replace refuses when the version changed since it was loaded.
+func TestUpdate_LockedReadModifyWrite(t *testing.T) {
+	// Precondition: VERSION at 1.0.0-SNAPSHOT, the lock held by another invocation
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile(versionFile, []byte("1.0.0-SNAPSHOT\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	held, err := AcquireLock(versionFile+lockSuffix, 0)
+	if err != nil {
+		t.Fatalf("AcquireLock failed: %v", err)
+	}
+
+	// Action: Update while the holder bumps and releases
+	done := make(chan error, 1)
+	var updated *Version
+	go func() {
+		var err error
+		updated, err = Update(func(v *Version) error {
+			v.PreRelease = ""
+			return nil
+		})
+		done <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(versionFile, []byte("1.1.0-SNAPSHOT\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := held.Release(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Expected: The update applies to the bumped version
+	if err := <-done; err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if updated.String() != "1.1.0" {
+		t.Errorf("Update returned %s; want 1.1.0", updated)
+	}
+	if data, _ := os.ReadFile(versionFile); strings.TrimSpace(string(data)) != "1.1.0" {
+		t.Errorf("VERSION = %q; want 1.1.0", data)
+	}
+
+	// Action / Expected: Replacing the stale version is refused
+	next := *loaded
+	next.PreRelease = ""
+	err = Replace(loaded, &next)
+	if err == nil || !strings.Contains(err.Error(), ErrVersionChanged) {
+		t.Fatalf("expected %q, got %v", ErrVersionChanged, err)
+	}
+	if data, _ := os.ReadFile(versionFile); strings.TrimSpace(string(data)) != "1.1.0" {
+		t.Errorf("VERSION = %q after refused Replace; want 1.1.0", data)
+	}
+}
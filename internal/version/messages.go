@@ -2,21 +2,34 @@
 // Exported so tests can compare against them
 package version
 
-import "os"
-
-// File permission constants
-const (
-	// FilePermission is the default permission for created files (owner rw, group/other r)
-	FilePermission os.FileMode = 0644
-)
-
 // Error messages
 const (
-	ErrCannotDecrementMajor = "cannot decrement major version below 0"
-	ErrCannotDecrementMinor = "cannot decrement minor version below 0"
-	ErrCannotDecrementPatch = "cannot decrement patch version below 0"
-	ErrInvalidVersionLevel  = "invalid version level"
-	ErrCustomKeyNotFound    = "custom key not found"
+	ErrCannotDecrementMajor    = "cannot decrement major version below 0"
+	ErrCannotDecrementMinor    = "cannot decrement minor version below 0"
+	ErrCannotDecrementPatch    = "cannot decrement patch version below 0"
+	ErrInvalidVersionLevel     = "invalid version level"
+	ErrInvalidIncrement        = "increment must be at least 1"
+	ErrNegativeComponent       = "version component cannot be negative"
+	ErrCustomKeyNotFound       = "custom key not found"
+	ErrBareNoVersion           = "VERSION not found at HEAD of bare repository"
+	ErrLockTimeout             = "timed out waiting for VERSION lock"
+	ErrLockCreate              = "failed to create VERSION lock"
+	ErrLockRelease             = "failed to release VERSION lock"
+	ErrInvalidVersion          = "invalid version"
+	ErrLooseVersion            = "not a SemVer version (looseVersions: reject)"
+	ErrCannotDecrementRevision = "cannot decrement revision below 0"
+	ErrMissingRevision         = "the four-part scheme requires Major.Minor.Patch.Revision (e.g. 'versionator set 1.2.3.0')"
+	ErrRevisionUnavailable     = "revision requires a four-part scheme (scheme: four-part)"
+	ErrVersionFileFormat       = "failed to parse structured VERSION file"
+	ErrVersionFileNoVersion    = "structured VERSION file has no version field"
+	ErrVersionFileField        = "VERSION file fields must be scalar values"
+	ErrStoreUnknown            = "unknown version store"
+	ErrStoreNoRepository       = "the git version store requires a repository"
+	ErrStoreNoTagList          = "the git version store cannot list tags with this VCS"
+	ErrStoreRequest            = "version store request failed"
+	ErrStoreNotReadable        = "the git version store cannot record this version"
+	ErrStoreConflict           = "the version changed in the store since it was loaded"
+	ErrVersionChanged          = "version changed by another invocation"
 )
 
 // Log messages for structured logging
@@ -25,6 +38,7 @@ const (
 	LogVersionSaved       = "version_saved"
 	LogVersionIncremented = "version_incremented"
 	LogVersionDecremented = "version_decremented"
+	LogComponentSet       = "version_component_set"
 	LogVersionMigrated    = "version_migrated"
 	LogVersionParsed      = "version_parsed"
 	LogVersionParseError  = "version_parse_error"
@@ -35,4 +49,7 @@ const (
 	LogPrefixSet          = "prefix_set"
 	LogFileReadError      = "file_read_error"
 	LogFileWriteError     = "file_write_error"
+	LogLockAcquired       = "lock_acquired"
+	LogLockContended      = "lock_contended"
+	LogLockReleased       = "lock_released"
 )
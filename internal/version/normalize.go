@@ -0,0 +1,165 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// Loose version handling modes (looseVersions in .versionator.yaml)
+const (
+	// LooseAccept accepts what the grammar accepts, unchanged (default)
+	LooseAccept = ""
+	// LooseNormalize rewrites loose input as SemVer and reports the changes
+	LooseNormalize = "normalize"
+	// LooseReject refuses input that is not already SemVer
+	LooseReject = "reject"
+)
+
+// Normalization is one change made to turn loose input into SemVer
+type Normalization struct {
+	// Field is the part of the version that changed (e.g. "major")
+	Field string
+	// Change describes what was done
+	Change string
+}
+
+func (n Normalization) String() string {
+	return n.Field + ": " + n.Change
+}
+
+// looseVersionPattern matches [v]N[.N[.N[.N]]][-pre][+meta] with any number
+// of leading zeros; the grammar does the real validation afterwards
+var looseVersionPattern = regexp.MustCompile(`^([vV]?)(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`)
+
+// Normalize parses common non-SemVer input as SemVer, returning every change
+// it made: leading zeros are removed (01.2.3), missing minor and patch
+// become 0 (1.2), and a fourth component moves to build metadata (1.2.3.4
+// becomes 1.2.3+4). With a four-part scheme the fourth component is kept
+// and a missing one becomes 0. SemVer input is returned with no changes.
+func Normalize(input string) (*Version, []Normalization, error) {
+	input = strings.TrimSpace(input)
+	m := looseVersionPattern.FindStringSubmatch(input)
+	if m == nil {
+		// Not even loosely a version; report the grammar's diagnosis
+		_, err := ParseStrict(input)
+		if err == nil {
+			err = &ParseError{Input: input, Reason: "unsupported version form"}
+		}
+		return nil, nil, err
+	}
+	prefix, major, minor, patch, revision, pre, meta := m[1], m[2], m[3], m[4], m[5], m[6], m[7]
+
+	var changes []Normalization
+	core := []string{major, minor, patch}
+	for i, field := range []string{"major", "minor", "patch"} {
+		if core[i] == "" {
+			core[i] = "0"
+			changes = append(changes, Normalization{Field: field, Change: "missing, set to 0"})
+			continue
+		}
+		if trimmed := trimLeadingZeros(core[i]); trimmed != core[i] {
+			changes = append(changes, Normalization{Field: field, Change: fmt.Sprintf("leading zeros removed (%s -> %s)", core[i], trimmed)})
+			core[i] = trimmed
+		}
+	}
+
+	if pre != "" {
+		ids := strings.Split(pre, ".")
+		for i, id := range ids {
+			if isDigits(id) {
+				if trimmed := trimLeadingZeros(id); trimmed != id {
+					changes = append(changes, Normalization{Field: "pre-release", Change: fmt.Sprintf("leading zeros removed (%s -> %s)", id, trimmed)})
+					ids[i] = trimmed
+				}
+			}
+		}
+		pre = strings.Join(ids, ".")
+	}
+
+	if FourSegments() {
+		// The revision is a core segment: keep it, adding it if missing
+		switch {
+		case revision == "":
+			revision = "0"
+			changes = append(changes, Normalization{Field: "revision", Change: "missing, set to 0"})
+		case trimLeadingZeros(revision) != revision:
+			trimmed := trimLeadingZeros(revision)
+			changes = append(changes, Normalization{Field: "revision", Change: fmt.Sprintf("leading zeros removed (%s -> %s)", revision, trimmed)})
+			revision = trimmed
+		}
+		core = append(core, revision)
+	} else if revision != "" {
+		revision = trimLeadingZeros(revision)
+		if meta == "" {
+			meta = revision
+		} else {
+			meta = revision + "." + meta
+		}
+		changes = append(changes, Normalization{Field: "revision", Change: fmt.Sprintf("fourth component moved to build metadata (+%s)", meta)})
+	}
+
+	normalized := prefix + strings.Join(core, ".")
+	if pre != "" {
+		normalized += "-" + pre
+	}
+	if meta != "" {
+		normalized += "+" + meta
+	}
+	v, err := ParseStrict(normalized)
+	if err != nil {
+		return nil, nil, err
+	}
+	v.Raw = input
+	return v, changes, nil
+}
+
+func trimLeadingZeros(s string) string {
+	trimmed := strings.TrimLeft(s, "0")
+	if trimmed == "" {
+		return "0"
+	}
+	return trimmed
+}
+
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// looseMode is the configured looseVersions handling
+var looseMode atomic.Value
+
+// SetLooseMode sets how ParseInput treats loose versions (LooseAccept,
+// LooseNormalize, or LooseReject)
+func SetLooseMode(mode string) {
+	looseMode.Store(mode)
+}
+
+// ParseInput parses a version typed by a user or read from a tag according
+// to the loose mode. The returned normalizations are what LooseNormalize
+// changed; they are empty in the other modes.
+func ParseInput(input string) (*Version, []Normalization, error) {
+	mode, _ := looseMode.Load().(string)
+	switch mode {
+	case LooseNormalize:
+		return Normalize(input)
+	case LooseReject:
+		v, changes, err := Normalize(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(changes) > 0 {
+			reasons := make([]string, len(changes))
+			for i, c := range changes {
+				reasons[i] = c.String()
+			}
+			return nil, nil, fmt.Errorf("%s %q: %s", ErrLooseVersion, strings.TrimSpace(input), strings.Join(reasons, "; "))
+		}
+		// Normalize keeps SemVer input as-is, so this is the strict parse
+		return v, nil, nil
+	default:
+		v, err := ParseStrict(input)
+		return v, nil, err
+	}
+}
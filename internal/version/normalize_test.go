@@ -0,0 +1,88 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestNormalize_LooseInputs_ReportsEachChange validates normalization.
+//
+// Why: Teams migrating historical tags need to know exactly how each loose
+// version was reinterpreted before trusting the result.
+//
+// What: Leading zeros, missing components, and a fourth component are
+// rewritten as SemVer with one reported change each; SemVer input is
+// returned unchanged with no report.
+func TestNormalize_LooseInputs_ReportsEachChange(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		changes []string
+	}{
+		{"1.2.3", "1.2.3", nil},
+		{"v01.2.3", "v1.2.3", []string{"major: leading zeros removed (01 -> 1)"}},
+		{"1.2", "1.2.0", []string{"patch: missing, set to 0"}},
+		{"7", "7.0.0", []string{"minor: missing, set to 0", "patch: missing, set to 0"}},
+		{"1.2.3.4", "1.2.3+4", []string{"revision: fourth component moved to build metadata (+4)"}},
+		{"1.2.3-rc.007+sha", "1.2.3-rc.7+sha", []string{"pre-release: leading zeros removed (007 -> 7)"}},
+	}
+	for _, tc := range tests {
+		// Action: Normalize
+		v, changes, err := Normalize(tc.input)
+
+		// Expected: SemVer result and the exact changes
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.input, err)
+			continue
+		}
+		if v.FullString() != tc.want {
+			t.Errorf("%q: got %q, want %q", tc.input, v.FullString(), tc.want)
+		}
+		var got []string
+		for _, c := range changes {
+			got = append(got, c.String())
+		}
+		if strings.Join(got, "|") != strings.Join(tc.changes, "|") {
+			t.Errorf("%q: changes %q, want %q", tc.input, got, tc.changes)
+		}
+	}
+}
+
+// TestParseInput_Modes_ApplyLooseVersionsConfig validates the modes.
+//
+// Why: Some teams want messy input fixed up, others want it refused so
+// nothing non-SemVer is ever written or compared.
+//
+// What: The default accepts what the grammar accepts, normalize rewrites,
+// and reject fails with the changes that would have been needed.
+func TestParseInput_Modes_ApplyLooseVersionsConfig(t *testing.T) {
+	t.Cleanup(func() { SetLooseMode(LooseAccept) })
+
+	// Precondition: Default mode
+	SetLooseMode(LooseAccept)
+	// Action / Expected: Four-part kept, leading zeros refused by the grammar
+	if v, _, err := ParseInput("1.2.3.4"); err != nil || !v.HasRevision() {
+		t.Errorf("default: expected four-part version, got %v (%v)", v, err)
+	}
+	if _, _, err := ParseInput("01.2.3"); err == nil {
+		t.Error("default: expected leading zeros to fail")
+	}
+
+	// Precondition: normalize
+	SetLooseMode(LooseNormalize)
+	// Action / Expected: Rewritten with a report
+	if v, changes, err := ParseInput("01.2.3"); err != nil || v.String() != "1.2.3" || len(changes) != 1 {
+		t.Errorf("normalize: got %v %v (%v)", v, changes, err)
+	}
+
+	// Precondition: reject
+	SetLooseMode(LooseReject)
+	// Action / Expected: SemVer accepted, loose refused with the reason
+	if _, _, err := ParseInput("v1.2.3-rc.1"); err != nil {
+		t.Errorf("reject: SemVer refused: %v", err)
+	}
+	_, _, err := ParseInput("1.2")
+	if err == nil || !strings.Contains(err.Error(), ErrLooseVersion) || !strings.Contains(err.Error(), "patch: missing") {
+		t.Errorf("reject: expected loose version error, got %v", err)
+	}
+}
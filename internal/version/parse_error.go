@@ -0,0 +1,54 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+)
+
+// ParseError describes why a string is not a valid version, with the
+// column of the offending input when the grammar can locate it
+type ParseError struct {
+	// Input is the (trimmed) string that failed to parse
+	Input string
+	// Column is the 1-based position of the problem, or 0 if unknown
+	Column int
+	// Reason describes what is wrong
+	Reason string
+	// Err is the underlying parser error
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	if e.Column > 0 {
+		return fmt.Sprintf("%s %q: %s at column %d", ErrInvalidVersion, e.Input, e.Reason, e.Column)
+	}
+	return fmt.Sprintf("%s %q: %s", ErrInvalidVersion, e.Input, e.Reason)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Diagnostic returns the error with the input and a caret under Column, for
+// multi-line error output
+func (e *ParseError) Diagnostic() string {
+	if e.Column <= 0 {
+		return e.Error()
+	}
+	return fmt.Sprintf("%s\n  %s\n  %s^", e.Error(), e.Input, strings.Repeat(" ", e.Column-1))
+}
+
+// newParseError converts a parser error into a ParseError
+func newParseError(input string, err error) *ParseError {
+	pe := &ParseError{Input: strings.TrimSpace(input), Reason: err.Error(), Err: err}
+
+	var grammarErr participle.Error
+	if errors.As(err, &grammarErr) {
+		pe.Reason = grammarErr.Message()
+		pe.Column = grammarErr.Position().Column
+	}
+	return pe
+}
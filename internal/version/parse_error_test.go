@@ -0,0 +1,110 @@
+package version
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestParseStrict_InvalidInput_ReturnsLocatedParseError validates diagnostics.
+//
+// Why: "invalid version" alone does not tell users which character of a
+// long VERSION string is wrong.
+//
+// What: Grammar errors return a *ParseError with the input, a reason, and the
+// column; Diagnostic points a caret at that column.
+func TestParseStrict_InvalidInput_ReturnsLocatedParseError(t *testing.T) {
+	// Precondition: Version with a dangling pre-release separator
+	// Action: Parse strictly
+	_, err := ParseStrict("v1.2.3-")
+
+	// Expected: Located ParseError
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected *ParseError, got %T: %v", err, err)
+	}
+	if pe.Input != "v1.2.3-" || pe.Column != 8 || pe.Reason == "" {
+		t.Errorf("unexpected error fields: %+v", pe)
+	}
+	if !strings.HasSuffix(pe.Diagnostic(), "\n  v1.2.3-\n         ^") {
+		t.Errorf("caret not under column 8:\n%s", pe.Diagnostic())
+	}
+
+	// Action: Validation error without a position
+	_, err = ParseStrict("1.02.3")
+
+	// Expected: ParseError without column
+	if !errors.As(err, &pe) || pe.Column != 0 || !strings.Contains(err.Error(), ErrInvalidVersion) {
+		t.Errorf("expected unlocated ParseError, got %v", err)
+	}
+}
+
+// TestLoad_InvalidVersionFile_StrictConfigFails validates the config switch.
+//
+// Why: Lenient loading turns a corrupted VERSION into 0.0.0, which a release
+// pipeline would then tag; strict projects need that to fail loudly.
+//
+// What: Junk in VERSION loads as zero by default and fails with a ParseError
+// when strictVersion is set.
+func TestLoad_InvalidVersionFile_StrictConfigFails(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	_ = os.Chdir(tempDir)
+
+	// Precondition: Unparseable VERSION, lenient default
+	if err := os.WriteFile(versionFile, []byte("not-a-version\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action: Load
+	v, err := Load()
+
+	// Expected: Zero version with raw content preserved
+	if err != nil || v.String() != "0.0.0" || v.Raw != "not-a-version" {
+		t.Fatalf("expected lenient zero version, got %v (%v)", v, err)
+	}
+
+	// Precondition: strictVersion enabled
+	if err := os.WriteFile(".versionator.yaml", []byte("strictVersion: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action: Load
+	_, err = Load()
+
+	// Expected: ParseError naming the file
+	var pe *ParseError
+	if !errors.As(err, &pe) || !strings.Contains(err.Error(), versionFile) {
+		t.Errorf("expected strict ParseError, got %v", err)
+	}
+}
+
+// FuzzParseStrict checks that arbitrary input never panics and that
+// accepted versions round-trip.
+func FuzzParseStrict(f *testing.F) {
+	for _, seed := range []string{"1.2.3", "v1.2.3-rc.1+build.5", "1.2.3.4", "V0.0.1-alpha", "1.2", "junk", "1.02.3", ""} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		v, err := ParseStrict(input)
+		if err != nil {
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("error is %T, want *ParseError", err)
+			}
+			if lenient := Parse(input); lenient.String() != "0.0.0" {
+				t.Fatalf("Parse(%q) = %s for invalid input, want 0.0.0", input, lenient.String())
+			}
+			return
+		}
+		again, err := ParseStrict(v.FullString())
+		if err != nil {
+			t.Fatalf("ParseStrict(%q) accepted, but its FullString %q fails: %v", input, v.FullString(), err)
+		}
+		if again.FullString() != v.FullString() {
+			t.Fatalf("round trip changed %q to %q", v.FullString(), again.FullString())
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package version
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// segments is the number of numeric core segments the active versioning
+// scheme requires; 0 means the default of three (Major.Minor.Patch)
+var segments atomic.Int32
+
+// SetSegments sets the number of numeric core segments versions must have,
+// as declared by the active versioning plugin. 4 makes Revision a
+// first-class level; anything else restores Major.Minor.Patch.
+func SetSegments(n int) {
+	if n != 4 {
+		n = 0
+	}
+	segments.Store(int32(n))
+}
+
+// FourSegments reports whether the active scheme is Major.Minor.Patch.Revision
+func FourSegments() bool {
+	return segments.Load() == 4
+}
+
+// Levels returns the levels the active scheme lets bump and set-component
+// modify, most significant first
+func Levels() []VersionLevel {
+	if FourSegments() {
+		return []VersionLevel{MajorLevel, MinorLevel, PatchLevel, RevisionLevel}
+	}
+	return []VersionLevel{MajorLevel, MinorLevel, PatchLevel}
+}
+
+// CheckLevel returns an error if level is not part of the active scheme
+func CheckLevel(level VersionLevel) error {
+	if level == RevisionLevel && !FourSegments() {
+		return fmt.Errorf("%s: %s", ErrInvalidVersionLevel, ErrRevisionUnavailable)
+	}
+	return nil
+}
+
+// CheckSegments returns an error if v does not have the segments the active
+// scheme requires
+func CheckSegments(v *Version) error {
+	return checkSegments(v, FourSegments())
+}
+
+// checkSegments returns an error if v lacks a revision four segments require
+func checkSegments(v *Version, four bool) error {
+	if four && v.Revision == nil {
+		return fmt.Errorf("%s %q: %s", ErrInvalidVersion, v.FullString(), ErrMissingRevision)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package version
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestFourSegments_BumpAndSetRevision_TreatsRevisionAsLevel validates the
+// four-part scheme's revision level.
+//
+// Why: Enterprises versioning Major.Minor.Patch.Build need the fourth
+// segment bumped and set like the others, and reset when a higher level
+// moves, or their build numbers drift from their releases.
+//
+// What: With four segments, revision (alias build) parses as a level,
+// increments, decrements, and can be set; bumping patch resets it to 0.
+func TestFourSegments_BumpAndSetRevision_TreatsRevisionAsLevel(t *testing.T) {
+	tempDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(origDir) }()
+	_ = os.Chdir(tempDir)
+
+	// Precondition: Four-part scheme and a four-part VERSION
+	SetSegments(4)
+	t.Cleanup(func() { SetSegments(3) })
+	if err := os.WriteFile(versionFile, []byte("1.2.3.4\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action: Resolve the level by its alias
+	level, err := ParseLevel("build")
+
+	// Expected: The revision level
+	if err != nil || level != RevisionLevel {
+		t.Fatalf("ParseLevel(build) = %v, %v; want RevisionLevel", level, err)
+	}
+
+	steps := []struct {
+		name string
+		do   func() error
+		want string
+	}{
+		{"increment revision", func() error { return Increment(RevisionLevel) }, "1.2.3.5"},
+		{"decrement revision", func() error { return Decrement(RevisionLevel) }, "1.2.3.4"},
+		{"set revision", func() error { return SetComponent(RevisionLevel, 40) }, "1.2.3.40"},
+		{"increment patch", func() error { return Increment(PatchLevel) }, "1.2.4.0"},
+	}
+	for _, step := range steps {
+		// Action: Change the version
+		if err := step.do(); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+
+		// Expected: Four segments with the revision updated
+		got, err := GetCurrentVersion()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != step.want {
+			t.Errorf("%s: got %s, want %s", step.name, got, step.want)
+		}
+	}
+
+	// Action: Decrement revision 0
+	err = Decrement(RevisionLevel)
+
+	// Expected: Refused
+	if err == nil || err.Error() != ErrCannotDecrementRevision {
+		t.Errorf("expected %q, got %v", ErrCannotDecrementRevision, err)
+	}
+}
+
+// TestThreeSegments_RevisionLevel_IsRefused validates the default scheme.
+//
+// Why: Without a four-part scheme, bumping a revision would silently turn a
+// SemVer VERSION into a four-part one.
+//
+// What: ParseLevel rejects "revision" and Increment refuses RevisionLevel.
+func TestThreeSegments_RevisionLevel_IsRefused(t *testing.T) {
+	// Precondition: Default scheme
+	SetSegments(3)
+
+	// Action: Resolve and increment the revision level
+	_, parseErr := ParseLevel("revision")
+	incErr := Increment(RevisionLevel)
+
+	// Expected: Both refused
+	if parseErr == nil {
+		t.Error("expected ParseLevel(revision) to fail")
+	}
+	if incErr == nil || !strings.Contains(incErr.Error(), ErrRevisionUnavailable) {
+		t.Errorf("expected %q, got %v", ErrRevisionUnavailable, incErr)
+	}
+}
+
+// TestFourSegments_ThreePartVersion_FailsValidation validates VERSION
+// checks under the four-part scheme.
+//
+// Why: A three-part VERSION in a four-part project would emit versions
+// Windows installers and assemblies reject.
+//
+// What: Loading or setting a three-part version fails with a hint, a
+// missing VERSION is created with four segments, and normalize mode adds
+// a missing revision as 0 and keeps a present one.
+func TestFourSegments_ThreePartVersion_FailsValidation(t *testing.T) {
+	tempDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(origDir) }()
+	_ = os.Chdir(tempDir)
+
+	// Precondition: Four-part scheme, no VERSION yet
+	SetSegments(4)
+	t.Cleanup(func() { SetSegments(3) })
+
+	// Action: Load creates the default VERSION
+	created, err := Load()
+
+	// Expected: Four segments
+	if err != nil || created.String() != "0.0.1.0" {
+		t.Fatalf("created %v, %v; want 0.0.1.0", created, err)
+	}
+
+	// Precondition: Three-part VERSION
+	if err := os.WriteFile(versionFile, []byte("1.2.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action: Load and set three-part versions
+	_, loadErr := Load()
+	setErr := SetVersion("2.0.0")
+
+	// Expected: Both fail with the missing-revision hint
+	for _, err := range []error{loadErr, setErr} {
+		if err == nil || !strings.Contains(err.Error(), ErrMissingRevision) {
+			t.Errorf("expected %q, got %v", ErrMissingRevision, err)
+		}
+	}
+
+	// Action: Normalize three- and four-part input
+	padded, changes, err := Normalize("1.2.3")
+	kept, _, keptErr := Normalize("1.2.3.04")
+
+	// Expected: Revision added as 0 and reported; present revision kept
+	if err != nil || padded.FullString() != "1.2.3.0" || len(changes) != 1 || changes[0].String() != "revision: missing, set to 0" {
+		t.Errorf("Normalize(1.2.3) = %v, %v, %v", padded, changes, err)
+	}
+	if keptErr != nil || kept.FullString() != "1.2.3.4" {
+		t.Errorf("Normalize(1.2.3.04) = %v, %v", kept, keptErr)
+	}
+}
@@ -0,0 +1,314 @@
+package version
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/logging"
+	"github.com/benjaminabbitt/versionator/internal/offline"
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+)
+
+// VersionStore persists the version Load returns and Save writes. The
+// VERSION file is the default; `store` in .versionator.yaml selects another
+// backend (git tags, an HTTP key-value endpoint) so an organization can keep
+// version state in one place.
+type VersionStore interface {
+	// Name identifies the backend in config and messages (e.g. "file")
+	Name() string
+	// Load returns the stored version, or Initial when none is stored yet
+	Load() (*Version, error)
+	// Save stores v, already validated by Save
+	Save(v *Version) error
+}
+
+// FileStore keeps the version in the VERSION file found walking up from the
+// working directory, creating it on first Load
+type FileStore struct{}
+
+// Name implements VersionStore
+func (FileStore) Name() string { return "file" }
+
+// store, when set, replaces FileStore
+var store atomic.Pointer[VersionStore]
+
+// SetStore makes Load and Save use s; nil restores FileStore
+func SetStore(s VersionStore) {
+	if s == nil {
+		store.Store(nil)
+		return
+	}
+	store.Store(&s)
+}
+
+// CurrentStore returns the VersionStore Load and Save use
+func CurrentStore() VersionStore {
+	if s := store.Load(); s != nil {
+		return *s
+	}
+	return FileStore{}
+}
+
+// UsesFile reports whether the version is kept in the VERSION file, so
+// commands committing VERSION know there is one to commit
+func UsesFile() bool {
+	_, ok := CurrentStore().(FileStore)
+	return ok
+}
+
+// Initial returns the version a project starts at: 0.0.1 (0.0.1.0 with a
+// four-part scheme) with the configured prefix
+func Initial() *Version {
+	cfg, _ := config.ReadConfig()
+	prefix := ""
+	if cfg != nil {
+		prefix = cfg.Prefix
+	}
+	v := &Version{Major: 0, Minor: 0, Patch: 1, Prefix: prefix}
+	if FourSegments() {
+		zero := 0
+		v.Revision = &zero
+	}
+	return v
+}
+
+// GitTagStore keeps the version only in tags: Load returns the highest tag
+// following release.tagFormat and Save tags HEAD with the new version, so
+// the repository needs no VERSION file
+type GitTagStore struct{}
+
+// Name implements VersionStore
+func (GitTagStore) Name() string { return "git" }
+
+// Load returns the highest version among the repository tags, by SemVer
+// precedence, or Initial when no tag holds a version
+func (GitTagStore) Load() (*Version, error) {
+	best, err := highestTaggedVersion()
+	if err != nil {
+		return nil, err
+	}
+	if best == nil {
+		return Initial(), nil
+	}
+	logging.GetLogger().Debug(LogVersionLoaded,
+		zap.String("store", "git"),
+		zap.String("version", best.String()))
+	return best, nil
+}
+
+// highestTaggedVersion returns the highest version among the repository
+// tags, or nil when no tag holds a version
+func highestTaggedVersion() (*Version, error) {
+	activeVCS := vcs.GetActiveVCS()
+	if activeVCS == nil {
+		return nil, errors.New(ErrStoreNoRepository)
+	}
+	lister, ok := activeVCS.(vcs.TagNameLister)
+	if !ok {
+		return nil, fmt.Errorf("%s: %s", ErrStoreNoTagList, activeVCS.Name())
+	}
+	tags, err := lister.ListTagNames()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	var best *Version
+	for _, tag := range tags {
+		name, ok := tagformat.Version(tag)
+		if !ok {
+			continue
+		}
+		v, err := ParseStrict(name)
+		if err != nil || CheckSegments(v) != nil {
+			continue
+		}
+		if best == nil || v.Compare(best) > 0 {
+			best = v
+		}
+	}
+	return best, nil
+}
+
+// Save tags HEAD with v, as `release` names tags; a tag already naming v is
+// left alone, so a following release finds it at HEAD. Load only sees the
+// highest tag, so a version below it (a decrement, a pre-release of a
+// released version) is refused rather than tagged and never read back.
+func (GitTagStore) Save(v *Version) error {
+	activeVCS := vcs.GetActiveVCS()
+	if activeVCS == nil {
+		return errors.New(ErrStoreNoRepository)
+	}
+	prefix := v.Prefix
+	if prefix == "" {
+		prefix = "v"
+	}
+	tagName := tagformat.Name(prefix, v.String())
+	if name, ok := tagformat.Version(tagName); !ok || !namesVersion(name, v) {
+		return fmt.Errorf("%s: tag '%s' does not follow the tag format", ErrStoreNotReadable, tagName)
+	}
+	best, err := highestTaggedVersion()
+	if err != nil {
+		return err
+	}
+	if best != nil && v.String() != best.String() && v.Compare(best) <= 0 {
+		return fmt.Errorf("%s: %s is not above the highest tagged version %s", ErrStoreNotReadable, v.String(), best.String())
+	}
+	exists, err := activeVCS.TagExists(tagName)
+	if err != nil {
+		return fmt.Errorf("failed to check tag '%s': %w", tagName, err)
+	}
+	if !exists {
+		if err := activeVCS.CreateTag(tagName, fmt.Sprintf("Version %s", v.String())); err != nil {
+			return fmt.Errorf("failed to create tag '%s': %w", tagName, err)
+		}
+	}
+	logging.GetLogger().Debug(LogVersionSaved,
+		zap.String("store", "git"),
+		zap.String("tag", tagName),
+		zap.String("version", v.String()))
+	return nil
+}
+
+// namesVersion reports whether a tag's version part parses back to v
+func namesVersion(name string, v *Version) bool {
+	parsed, err := ParseStrict(name)
+	return err == nil && parsed.String() == v.String()
+}
+
+// httpStoreTimeout bounds each request to the HTTP store
+const httpStoreTimeout = 10 * time.Second
+
+// HTTPStore keeps the version at a key-value endpoint shared across
+// repositories: GET URL returns the version as plain text (404 when none is
+// stored yet) and PUT URL stores it. Save is conditional on what Load read:
+// If-Match with the ETag GET returned, or If-None-Match: * after a 404, so
+// a concurrent update from another machine fails with 412 instead of being
+// overwritten.
+type HTTPStore struct {
+	// URL of the version key
+	URL string
+	// Token, when set, is sent as a bearer token
+	Token string
+	// Client defaults to one with a 10s timeout
+	Client *http.Client
+
+	// etag is the ETag of the last Load, and absent whether it found no key
+	etag   string
+	absent bool
+	loaded bool
+}
+
+// Name implements VersionStore
+func (*HTTPStore) Name() string { return "http" }
+
+func (s *HTTPStore) do(method string, body io.Reader, header http.Header) (*http.Response, error) {
+	if err := offline.Require("store: http"); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(method, s.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: httpStoreTimeout}
+	}
+	return client.Do(req)
+}
+
+// Load fetches the version, or returns Initial when the key is not set
+func (s *HTTPStore) Load() (*Version, error) {
+	resp, err := s.do(http.MethodGet, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrStoreRequest, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		s.etag, s.absent, s.loaded = "", true, true
+		return Initial(), nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: GET %s: %s", ErrStoreRequest, s.URL, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrStoreRequest, err)
+	}
+	v, err := parseVersionFile(strings.TrimSpace(string(data)), s.URL)
+	if err != nil {
+		return nil, err
+	}
+	s.etag, s.absent, s.loaded = resp.Header.Get("ETag"), false, true
+	logging.GetLogger().Debug(LogVersionLoaded,
+		zap.String("store", "http"),
+		zap.String("version", v.String()))
+	return v, nil
+}
+
+// Save stores the version, in the configured VERSION file format, unless
+// the key changed since the last Load
+func (s *HTTPStore) Save(v *Version) error {
+	content, err := encodeVersionFile(v.FullString(), v.Fields)
+	if err != nil {
+		return fmt.Errorf("failed to encode version: %w", err)
+	}
+	header := http.Header{}
+	switch {
+	case s.loaded && s.absent:
+		header.Set("If-None-Match", "*")
+	case s.loaded && s.etag != "":
+		header.Set("If-Match", s.etag)
+	}
+	resp, err := s.do(http.MethodPut, bytes.NewReader(content), header)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrStoreRequest, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return fmt.Errorf("%s: PUT %s: %s", ErrStoreConflict, s.URL, resp.Status)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: PUT %s: %s", ErrStoreRequest, s.URL, resp.Status)
+	}
+	s.etag, s.absent = resp.Header.Get("ETag"), false
+	logging.GetLogger().Debug(LogVersionSaved,
+		zap.String("store", "http"),
+		zap.String("version", v.String()))
+	return nil
+}
+
+// StoreFor returns the VersionStore configured by `store` in
+// .versionator.yaml; FileStore when no backend is set
+func StoreFor(cfg config.StoreConfig) (VersionStore, error) {
+	switch cfg.Backend {
+	case "", "file":
+		return FileStore{}, nil
+	case "git":
+		return GitTagStore{}, nil
+	case "http":
+		tokenEnv := cfg.TokenEnv
+		if tokenEnv == "" {
+			tokenEnv = config.DefaultStoreTokenEnv
+		}
+		return &HTTPStore{URL: cfg.URL, Token: os.Getenv(tokenEnv)}, nil
+	default:
+		return nil, fmt.Errorf("%s: '%s'", ErrStoreUnknown, cfg.Backend)
+	}
+}
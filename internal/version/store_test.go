@@ -0,0 +1,276 @@
+package version
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/vcs/mock"
+	"github.com/golang/mock/gomock"
+)
+
+// tagListingVCS adds TagNameLister to the generated mock
+type tagListingVCS struct {
+	*mock.MockVersionControlSystem
+	tags []string
+}
+
+func (v tagListingVCS) ListTagNames() ([]string, error) { return v.tags, nil }
+
+// TestHTTPStore_SaveThenLoad validates the key-value round trip.
+//
+// Why: Organizations centralizing version state need Save and Load to agree
+// on the wire format and to authenticate every request.
+//
+// What: Load of an unset key is the initial version; after Save, Load returns
+// the saved version; requests carry the bearer token.
+func TestHTTPStore_SaveThenLoad(t *testing.T) {
+	// Precondition: An in-memory key-value endpoint requiring a token
+	t.Chdir(t.TempDir())
+	var stored string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if stored == "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = io.WriteString(w, stored)
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			stored = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	store := &HTTPStore{URL: server.URL, Token: "secret"}
+
+	// Action: Load the unset key, save, and load again
+	initial, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := store.Save(&Version{Prefix: "v", Major: 2, Minor: 1, Patch: 0}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := store.Load()
+
+	// Expected: 0.0.1 first, then the saved version
+	if initial.String() != "0.0.1" {
+		t.Errorf("expected initial 0.0.1, got %s", initial)
+	}
+	if err != nil || loaded.FullString() != "v2.1.0" {
+		t.Errorf("expected v2.1.0, got %v (%v)", loaded, err)
+	}
+}
+
+// TestHTTPStore_ConcurrentSave_Conflict validates conditional writes.
+//
+// Why: The VERSION lock only covers one checkout; two machines bumping the
+// shared version at once must not silently overwrite each other.
+//
+// What: Save sends If-None-Match: * after a 404 and If-Match with the ETag
+// Load read; when another store saved in between, Save fails with
+// ErrStoreConflict and the other version is kept.
+func TestHTTPStore_ConcurrentSave_Conflict(t *testing.T) {
+	// Precondition: An endpoint honouring ETag preconditions
+	t.Chdir(t.TempDir())
+	var stored string
+	revision := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := fmt.Sprintf(`"%d"`, revision)
+		switch r.Method {
+		case http.MethodGet:
+			if stored == "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			_, _ = io.WriteString(w, stored)
+		case http.MethodPut:
+			match, noneMatch := r.Header.Get("If-Match"), r.Header.Get("If-None-Match")
+			if (match != "" && match != etag) || (noneMatch == "*" && stored != "") {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			stored = string(body)
+			revision++
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	first, second := &HTTPStore{URL: server.URL}, &HTTPStore{URL: server.URL}
+
+	// Action: Both load the unset key; the first saves, then the second
+	_, _ = first.Load()
+	_, _ = second.Load()
+	firstErr := first.Save(&Version{Major: 1})
+	secondErr := second.Save(&Version{Major: 2})
+
+	// Expected: The second save conflicts
+	if firstErr != nil {
+		t.Fatalf("first Save failed: %v", firstErr)
+	}
+	if secondErr == nil || !strings.Contains(secondErr.Error(), ErrStoreConflict) {
+		t.Fatalf("expected %q, got %v", ErrStoreConflict, secondErr)
+	}
+
+	// Action: Both load 1.0.0; the second saves, then the first
+	_, _ = first.Load()
+	_, _ = second.Load()
+	secondErr = second.Save(&Version{Major: 1, Minor: 1})
+	firstErr = first.Save(&Version{Major: 2})
+
+	// Expected: The first save conflicts; 1.1.0 is kept
+	if secondErr != nil {
+		t.Fatalf("second Save failed: %v", secondErr)
+	}
+	if firstErr == nil || !strings.Contains(firstErr.Error(), ErrStoreConflict) {
+		t.Fatalf("expected %q, got %v", ErrStoreConflict, firstErr)
+	}
+	if strings.TrimSpace(stored) != "1.1.0" {
+		t.Errorf("expected 1.1.0 stored, got %q", stored)
+	}
+}
+
+// TestHTTPStore_ErrorStatus validates failed requests are reported.
+//
+// Why: A rejected token must not be mistaken for an unset version.
+//
+// What: A 401 response fails Load with ErrStoreRequest.
+func TestHTTPStore_ErrorStatus(t *testing.T) {
+	// Precondition: An endpoint rejecting every request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	// Action: Load
+	_, err := (&HTTPStore{URL: server.URL}).Load()
+
+	// Expected: Request error
+	if err == nil || !strings.Contains(err.Error(), ErrStoreRequest) {
+		t.Errorf("expected %q, got %v", ErrStoreRequest, err)
+	}
+}
+
+// TestGitTagStore_LoadAndSave validates the tags-only store.
+//
+// Why: Repositories without a VERSION file take the version from tags, and
+// a bump must be visible to the next Load; a version Load would not return
+// must not be reported as saved.
+//
+// What: Load returns the highest version tag, ignoring other tags; Save tags
+// HEAD with a higher version, leaves the current one alone, and refuses a
+// lower one (a decrement, or a pre-release of the highest tag) without
+// tagging.
+func TestGitTagStore_LoadAndSave(t *testing.T) {
+	// Precondition: A repository with version and non-version tags
+	t.Chdir(t.TempDir())
+	ctrl := gomock.NewController(t)
+	m := mock.NewMockVersionControlSystem(ctrl)
+	m.EXPECT().Name().Return("mock-tags").AnyTimes()
+	m.EXPECT().IsRepository().Return(true).AnyTimes()
+	m.EXPECT().TagExists("v1.10.0").Return(false, nil)
+	m.EXPECT().CreateTag("v1.10.0", "Version 1.10.0").Return(nil)
+	m.EXPECT().TagExists("v1.10.0-rc.1").Return(true, nil)
+	vcs.RegisterVCS(tagListingVCS{m, []string{"v1.0.0", "v1.2.0", "deploy-prod", "v1.10.0-rc.1"}})
+	defer vcs.UnregisterVCS("mock-tags")
+
+	// Action: Load, then save the release, the current and lower versions
+	v, err := GitTagStore{}.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	err = GitTagStore{}.Save(&Version{Prefix: "v", Major: 1, Minor: 10})
+	current := GitTagStore{}.Save(&Version{Prefix: "v", Major: 1, Minor: 10, PreRelease: "rc.1"})
+	lower := GitTagStore{}.Save(&Version{Prefix: "v", Major: 1, Minor: 3})
+	preRelease := GitTagStore{}.Save(&Version{Prefix: "v", Major: 1, Minor: 10, PreRelease: "alpha"})
+
+	// Expected: Highest tag loaded, new tag created, lower versions refused
+	if v.FullString() != "v1.10.0-rc.1" {
+		t.Errorf("expected v1.10.0-rc.1, got %s", v.FullString())
+	}
+	if err != nil || current != nil {
+		t.Errorf("Save failed: %v, %v", err, current)
+	}
+	for _, err := range []error{lower, preRelease} {
+		if err == nil || !strings.Contains(err.Error(), ErrStoreNotReadable) {
+			t.Errorf("expected %q, got %v", ErrStoreNotReadable, err)
+		}
+	}
+}
+
+// TestStoreFor_Backends validates backend selection from config.
+//
+// Why: `store` in .versionator.yaml decides where every command reads and
+// writes the version.
+//
+// What: Each backend maps to its store, http reads the token from the
+// configured variable, and an unknown backend is an error.
+func TestStoreFor_Backends(t *testing.T) {
+	// Precondition: A token in the default variable
+	t.Setenv(config.DefaultStoreTokenEnv, "tok")
+
+	// Action/Expected: Each backend
+	for backend, name := range map[string]string{"": "file", "file": "file", "git": "git", "http": "http"} {
+		s, err := StoreFor(config.StoreConfig{Backend: backend, URL: "http://example.invalid/v"})
+		if err != nil || s.Name() != name {
+			t.Errorf("backend %q: expected %s, got %v (%v)", backend, name, s, err)
+		}
+	}
+	s, _ := StoreFor(config.StoreConfig{Backend: "http", URL: "http://example.invalid/v"})
+	if s.(*HTTPStore).Token != "tok" {
+		t.Errorf("expected token from %s", config.DefaultStoreTokenEnv)
+	}
+	if _, err := StoreFor(config.StoreConfig{Backend: "s3"}); err == nil || !strings.Contains(err.Error(), ErrStoreUnknown) {
+		t.Errorf("expected %q, got %v", ErrStoreUnknown, err)
+	}
+}
+
+// TestSetStore_RoutesLoadAndSave validates that Load and Save use the store.
+//
+// Why: Commands only call Load and Save; the configured store must take
+// effect without them knowing which backend is in use.
+//
+// What: With the http store set, Save writes no VERSION file and UsesFile is
+// false; SetStore(nil) restores the file store.
+func TestSetStore_RoutesLoadAndSave(t *testing.T) {
+	// Precondition: The http store set on an accepting endpoint
+	t.Chdir(t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	SetStore(&HTTPStore{URL: server.URL})
+	defer SetStore(nil)
+
+	// Action: Save through the package API
+	err := Save(&Version{Major: 1})
+
+	// Expected: Saved remotely, no VERSION file
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := os.Stat(versionFile); !os.IsNotExist(err) {
+		t.Errorf("expected no VERSION file, stat err: %v", err)
+	}
+	if UsesFile() {
+		t.Error("expected UsesFile false with the http store")
+	}
+	SetStore(nil)
+	if !UsesFile() {
+		t.Error("expected UsesFile true after SetStore(nil)")
+	}
+}
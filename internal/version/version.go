@@ -3,14 +3,17 @@ package version
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 
 	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/fileperm"
 	"github.com/benjaminabbitt/versionator/internal/logging"
 	"github.com/benjaminabbitt/versionator/internal/parser"
 	"github.com/benjaminabbitt/versionator/internal/vcs"
@@ -29,6 +32,10 @@ type Version struct {
 	PreRelease    string // Pre-release identifier (e.g., "alpha.1")
 	BuildMetadata string // Build metadata (e.g., "build.123")
 	Raw           string // Original parsed string
+
+	// Fields holds the extra fields of a structured VERSION file (channel,
+	// build, ...), exposed as custom template variables
+	Fields map[string]string
 }
 
 // VersionLevel represents the semantic version component to modify
@@ -38,6 +45,8 @@ const (
 	MajorLevel VersionLevel = iota
 	MinorLevel
 	PatchLevel
+	// RevisionLevel is the fourth segment, available with a four-part scheme
+	RevisionLevel
 )
 
 // Parse parses a version string into a Version struct using the grammar-based parser.
@@ -53,11 +62,12 @@ func Parse(version string) Version {
 }
 
 // ParseStrict parses a version string with full validation.
-// Returns an error if the version string is invalid according to the grammar.
+// Returns a *ParseError if the version string is invalid according to the
+// grammar, so junk input is never mistaken for 0.0.0.
 func ParseStrict(version string) (*Version, error) {
 	pv, err := parser.Parse(version)
 	if err != nil {
-		return nil, err
+		return nil, newParseError(version, err)
 	}
 	return fromParserVersion(pv), nil
 }
@@ -159,10 +169,43 @@ func getVersionPath() (string, error) {
 	return filepath.Join(cwd, versionFile), nil
 }
 
+// Path returns the path of the VERSION file Load reads, or where it would be
+// created when none exists
+func Path() (string, error) {
+	return getVersionPath()
+}
+
+// FindFile returns the VERSION file found walking up from dir, stopping at
+// stopPath (the repository root; empty walks to the filesystem root), or
+// empty when there is none
+func FindFile(dir, stopPath string) string {
+	return findVersionFile(dir, stopPath)
+}
+
+// fixed, when set, is returned by Load instead of reading VERSION
+var fixed atomic.Pointer[Version]
+
+// SetFixed makes Load return a copy of v instead of reading (or creating)
+// VERSION, e.g. for a version replayed from a template data snapshot;
+// nil restores reading the file
+func SetFixed(v *Version) {
+	fixed.Store(v)
+}
+
+// Load returns the current version from the configured VersionStore (the
+// VERSION file by default)
+func Load() (*Version, error) {
+	if v := fixed.Load(); v != nil {
+		copied := *v
+		return &copied, nil
+	}
+	return CurrentStore().Load()
+}
+
 // Load reads the VERSION file and returns the parsed Version
 // If VERSION doesn't exist, creates a default 0.0.1 (using config prefix if set)
 // VERSION file content is the source of truth - it takes priority over config
-func Load() (*Version, error) {
+func (FileStore) Load() (*Version, error) {
 	logger := logging.GetLogger()
 
 	path, err := getVersionPath()
@@ -175,24 +218,39 @@ func Load() (*Version, error) {
 		// VERSION file exists - parse it directly
 		// The VERSION file is the source of truth
 		versionStr := strings.TrimSpace(string(data))
-		v := Parse(versionStr)
+		v, err := parseVersionFile(versionStr, path)
+		if err != nil {
+			return nil, err
+		}
 
 		logger.Debug(LogVersionLoaded,
 			zap.String("path", path),
 			zap.String("version", v.String()))
-		return &v, nil
+		return v, nil
 	}
 
-	// VERSION doesn't exist, create default
+	// Bare repository: there is no working tree, so read VERSION as committed
+	// at HEAD rather than creating one inside the git directory
 	if os.IsNotExist(err) {
-		// Use config prefix as default for new files only
-		cfg, _ := config.ReadConfig()
-		defaultPrefix := ""
-		if cfg != nil {
-			defaultPrefix = cfg.Prefix
+		if bare, ok := vcs.GetActiveVCS().(vcs.BareRepository); ok && bare.IsBare() {
+			data, err := bare.ReadHeadFile(versionFile)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", ErrBareNoVersion, err)
+			}
+			v, err := parseVersionFile(strings.TrimSpace(string(data)), "HEAD:"+versionFile)
+			if err != nil {
+				return nil, err
+			}
+			logger.Debug(LogVersionLoaded,
+				zap.String("path", "HEAD:"+versionFile),
+				zap.String("version", v.String()))
+			return v, nil
 		}
+	}
 
-		v := &Version{Major: 0, Minor: 0, Patch: 1, Prefix: defaultPrefix}
+	// VERSION doesn't exist, create default
+	if os.IsNotExist(err) {
+		v := Initial()
 		logger.Info(LogVersionCreated,
 			zap.String("path", path),
 			zap.String("version", v.String()))
@@ -206,34 +264,113 @@ func Load() (*Version, error) {
 	return nil, fmt.Errorf("failed to read VERSION: %w", err)
 }
 
-// Save writes the version to the VERSION file.
-// Validates the version by round-tripping through the parser before writing.
-func Save(v *Version) error {
-	logger := logging.GetLogger()
+// ParseContent parses VERSION file content read from source (e.g. VERSION
+// as committed at a tag), the same way Load parses the file
+func ParseContent(content []byte, source string) (*Version, error) {
+	return parseVersionFile(strings.TrimSpace(string(content)), source)
+}
+
+// parseVersionFile parses VERSION content read from source. Invalid content
+// is an error when strictVersion is configured; otherwise it is logged and
+// loads as 0.0.0 with the raw content preserved, as Parse does.
+func parseVersionFile(content, source string) (*Version, error) {
+	content, fields, err := decodeVersionFile(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+
+	v, err := ParseStrict(content)
+	if err == nil {
+		if err := CheckSegments(v); err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		v.Fields = fields
+		return v, nil
+	}
+	if cfg, _ := config.ReadConfig(); cfg != nil && cfg.StrictVersion {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	logging.GetLogger().Warn(LogVersionParseError,
+		zap.String("path", source),
+		zap.Error(err))
+	return &Version{Raw: content, Fields: fields}, nil
+}
+
+// ParseFile parses the VERSION file at path without Load's fallback for
+// unparsable content, so a malformed file can be reported
+func ParseFile(path string) (*Version, error) {
+	return ParseFileWith(path, FileOptions{Format: FileFormat(), Segments: int(segments.Load())})
+}
+
+// FileOptions are the settings a VERSION file is read under: the
+// versionFileFormat and the core segments the scheme requires
+type FileOptions struct {
+	Format   string // FileFormatPlain, FileFormatYAML, or FileFormatJSON; empty means plain
+	Segments int    // 4 for Major.Minor.Patch.Revision; anything else means three
+}
 
+// ParseFileWith is ParseFile under explicit options rather than the
+// configured ones, for callers reading a workspace other than the current
+// one
+func ParseFileWith(path string, opts FileOptions) (*Version, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	content, fields, err := decodeVersionFileAs(opts.Format, strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	v, err := ParseStrict(content)
+	if err == nil {
+		err = checkSegments(v, opts.Segments == 4)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	v.Fields = fields
+	return v, nil
+}
+
+// Save writes the version to the configured VersionStore (the VERSION file
+// by default). Validates the version by round-tripping through the parser
+// before writing.
+func Save(v *Version) error {
 	// Validate by building through the parser (round-trip validation)
 	validated, err := v.toBuilder().Build()
 	if err != nil {
-		logger.Error(LogVersionParseError, zap.String("version", v.String()), zap.Error(err))
+		logging.GetLogger().Error(LogVersionParseError, zap.String("version", v.String()), zap.Error(err))
 		return fmt.Errorf("invalid version: %w", err)
 	}
+	saved := fromParserVersion(validated)
+	saved.Fields = v.Fields
+	return CurrentStore().Save(saved)
+}
+
+// Save writes the version to the VERSION file
+func (FileStore) Save(v *Version) error {
+	logger := logging.GetLogger()
 
 	path, err := getVersionPath()
 	if err != nil {
 		return err
 	}
 
-	// Write the validated version string with newline
-	content := validated.FullString() + "\n"
+	// Write the version in the configured file format, keeping any
+	// structured fields
+	content, err := encodeVersionFile(v.FullString(), v.Fields)
+	if err != nil {
+		return fmt.Errorf("failed to encode VERSION: %w", err)
+	}
 
-	if err := os.WriteFile(path, []byte(content), FilePermission); err != nil {
+	if err := fileperm.WriteFile(path, content); err != nil {
 		logger.Error(LogFileWriteError, zap.String("path", path), zap.Error(err))
 		return fmt.Errorf("failed to write VERSION: %w", err)
 	}
 
 	logger.Debug(LogVersionSaved,
 		zap.String("path", path),
-		zap.String("version", validated.String()))
+		zap.String("version", v.String()))
 	return nil
 }
 
@@ -512,8 +649,132 @@ func (v *Version) DecrementPatch() error {
 	return nil
 }
 
+// IncrementRevision increments the revision, adding it if the version has
+// only three segments
+func (v *Version) IncrementRevision() {
+	rev := v.RevisionValue() + 1
+	v.Revision = &rev
+	v.PreRelease = ""
+}
+
+// DecrementRevision decrements the revision, returns error if already 0
+func (v *Version) DecrementRevision() error {
+	if v.RevisionValue() == 0 {
+		return errors.New(ErrCannotDecrementRevision)
+	}
+	rev := *v.Revision - 1
+	v.Revision = &rev
+	return nil
+}
+
 // --- Package-level convenience functions ---
 
+// IncrementLevel increments the given level in memory
+func (v *Version) IncrementLevel(level VersionLevel) error {
+	switch level {
+	case MajorLevel:
+		v.IncrementMajor()
+	case MinorLevel:
+		v.IncrementMinor()
+	case PatchLevel:
+		v.IncrementPatch()
+	case RevisionLevel:
+		v.IncrementRevision()
+	default:
+		return fmt.Errorf("%s: %d", ErrInvalidVersionLevel, level)
+	}
+	return nil
+}
+
+// DecrementLevel decrements the given level in memory
+func (v *Version) DecrementLevel(level VersionLevel) error {
+	switch level {
+	case MajorLevel:
+		return v.DecrementMajor()
+	case MinorLevel:
+		return v.DecrementMinor()
+	case PatchLevel:
+		return v.DecrementPatch()
+	case RevisionLevel:
+		return v.DecrementRevision()
+	default:
+		return fmt.Errorf("%s: %d", ErrInvalidVersionLevel, level)
+	}
+}
+
+// IncrementLevelBy increments the given level by n (n >= 1) in memory,
+// resetting lower levels as IncrementLevel does
+func (v *Version) IncrementLevelBy(level VersionLevel, n int) error {
+	if n < 1 {
+		return fmt.Errorf("%s: %d", ErrInvalidIncrement, n)
+	}
+	if err := v.IncrementLevel(level); err != nil {
+		return err
+	}
+	*v.component(level) += n - 1
+	return nil
+}
+
+// SetLevel sets the given level to value in memory. A changed level resets
+// the lower levels and pre-release as incrementing does; setting the current
+// value leaves the version unchanged.
+func (v *Version) SetLevel(level VersionLevel, value int) error {
+	if value < 0 {
+		return fmt.Errorf("%s: %d", ErrNegativeComponent, value)
+	}
+	component := v.component(level)
+	if component == nil {
+		return fmt.Errorf("%s: %d", ErrInvalidVersionLevel, level)
+	}
+	if *component == value {
+		return nil
+	}
+	// Increment for its resets, then overwrite the incremented component;
+	// incrementing may replace the field (Revision), so look it up again
+	if err := v.IncrementLevel(level); err != nil {
+		return err
+	}
+	*v.component(level) = value
+	return nil
+}
+
+// component returns the field holding level, or nil for an unknown level
+func (v *Version) component(level VersionLevel) *int {
+	switch level {
+	case MajorLevel:
+		return &v.Major
+	case MinorLevel:
+		return &v.Minor
+	case PatchLevel:
+		return &v.Patch
+	case RevisionLevel:
+		if v.Revision == nil {
+			zero := 0
+			v.Revision = &zero
+		}
+		return v.Revision
+	default:
+		return nil
+	}
+}
+
+// ParseLevel returns the level named "major", "minor", or "patch", or with a
+// four-part scheme also "revision" (alias "build")
+func ParseLevel(name string) (VersionLevel, error) {
+	if strings.EqualFold(name, "build") {
+		name = levelString(RevisionLevel)
+	}
+	for _, level := range Levels() {
+		if strings.EqualFold(name, levelString(level)) {
+			return level, nil
+		}
+	}
+	if FourSegments() {
+		return 0, fmt.Errorf("%s: %q (use major, minor, patch, or revision)", ErrInvalidVersionLevel, name)
+	}
+	return 0, fmt.Errorf("%s: %q (use major, minor, or patch)", ErrInvalidVersionLevel, name)
+}
+
 // GetCurrentVersion reads the current version from VERSION file
 func GetCurrentVersion() (string, error) {
 	v, err := Load()
@@ -525,8 +786,21 @@ func GetCurrentVersion() (string, error) {
 
 // Increment increments the specified version level
 func Increment(level VersionLevel) error {
+	return IncrementBy(level, 1)
+}
+
+// IncrementBy increments the specified version level by n
+func IncrementBy(level VersionLevel, n int) error {
+	return withLock(func() error { return increment(level, n) })
+}
+
+func increment(level VersionLevel, n int) error {
 	logger := logging.GetLogger()
 
+	if err := CheckLevel(level); err != nil {
+		return err
+	}
+
 	v, err := Load()
 	if err != nil {
 		return err
@@ -534,29 +808,60 @@ func Increment(level VersionLevel) error {
 
 	oldVersion := v.String()
 
-	switch level {
-	case MajorLevel:
-		v.IncrementMajor()
-	case MinorLevel:
-		v.IncrementMinor()
-	case PatchLevel:
-		v.IncrementPatch()
-	default:
-		return fmt.Errorf("%s: %d", ErrInvalidVersionLevel, level)
+	if err := v.IncrementLevelBy(level, n); err != nil {
+		return err
 	}
 
 	logger.Info(LogVersionIncremented,
 		zap.String("level", levelString(level)),
+		zap.Int("by", n),
 		zap.String("from", oldVersion),
 		zap.String("to", v.String()))
 
 	return Save(v)
 }
 
+// SetComponent sets the specified version level to value
+func SetComponent(level VersionLevel, value int) error {
+	if err := CheckLevel(level); err != nil {
+		return err
+	}
+	return withLock(func() error {
+		logger := logging.GetLogger()
+
+		v, err := Load()
+		if err != nil {
+			return err
+		}
+
+		oldVersion := v.String()
+
+		if err := v.SetLevel(level, value); err != nil {
+			return err
+		}
+
+		logger.Info(LogComponentSet,
+			zap.String("level", levelString(level)),
+			zap.Int("value", value),
+			zap.String("from", oldVersion),
+			zap.String("to", v.String()))
+
+		return Save(v)
+	})
+}
+
 // Decrement decrements the specified version level
 func Decrement(level VersionLevel) error {
+	return withLock(func() error { return decrement(level) })
+}
+
+func decrement(level VersionLevel) error {
 	logger := logging.GetLogger()
 
+	if err := CheckLevel(level); err != nil {
+		return err
+	}
+
 	v, err := Load()
 	if err != nil {
 		return err
@@ -564,20 +869,8 @@ func Decrement(level VersionLevel) error {
 
 	oldVersion := v.String()
 
-	var decrementErr error
-	switch level {
-	case MajorLevel:
-		decrementErr = v.DecrementMajor()
-	case MinorLevel:
-		decrementErr = v.DecrementMinor()
-	case PatchLevel:
-		decrementErr = v.DecrementPatch()
-	default:
-		return fmt.Errorf("%s: %d", ErrInvalidVersionLevel, level)
-	}
-
-	if decrementErr != nil {
-		return decrementErr
+	if err := v.DecrementLevel(level); err != nil {
+		return err
 	}
 
 	logger.Info(LogVersionDecremented,
@@ -596,6 +889,8 @@ func levelString(level VersionLevel) string {
 		return "minor"
 	case PatchLevel:
 		return "patch"
+	case RevisionLevel:
+		return "revision"
 	default:
 		return "unknown"
 	}
@@ -606,16 +901,18 @@ func levelString(level VersionLevel) string {
 func SetPrefix(prefix string) error {
 	logger := logging.GetLogger()
 
-	v, err := Load()
-	if err != nil {
-		return err
-	}
+	return withLock(func() error {
+		v, err := Load()
+		if err != nil {
+			return err
+		}
 
-	oldPrefix := v.Prefix
-	v.Prefix = prefix
+		oldPrefix := v.Prefix
+		v.Prefix = prefix
 
-	logger.Debug(LogPrefixSet, zap.String("from", oldPrefix), zap.String("to", prefix))
-	return Save(v)
+		logger.Debug(LogPrefixSet, zap.String("from", oldPrefix), zap.String("to", prefix))
+		return Save(v)
+	})
 }
 
 // GetPrefix returns the current version prefix
@@ -629,22 +926,68 @@ func GetPrefix() (string, error) {
 
 // SetPreRelease sets the pre-release tag
 func SetPreRelease(preRelease string) error {
-	v, err := Load()
-	if err != nil {
-		return err
-	}
-	v.PreRelease = preRelease
-	return Save(v)
+	return withLock(func() error {
+		v, err := Load()
+		if err != nil {
+			return err
+		}
+		v.PreRelease = preRelease
+		return Save(v)
+	})
 }
 
 // SetMetadata sets the build metadata
 func SetMetadata(metadata string) error {
-	v, err := Load()
+	return withLock(func() error {
+		v, err := Load()
+		if err != nil {
+			return err
+		}
+		v.BuildMetadata = metadata
+		return Save(v)
+	})
+}
+
+// Update loads the version, applies fn to it and saves the result when fn
+// changed it, all while holding VERSION.lock, so the read-modify-write
+// cannot interleave with another invocation. Returns the resulting version.
+func Update(fn func(*Version) error) (*Version, error) {
+	var updated *Version
+	err := withLock(func() error {
+		v, err := Load()
+		if err != nil {
+			return err
+		}
+		before, fields := v.FullString(), maps.Clone(v.Fields)
+		if err := fn(v); err != nil {
+			return err
+		}
+		updated = v
+		if v.FullString() == before && maps.Equal(v.Fields, fields) {
+			return nil
+		}
+		return Save(v)
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	v.BuildMetadata = metadata
-	return Save(v)
+	return updated, nil
+}
+
+// Replace saves next in place of expected, keeping the stored fields, and
+// fails with ErrVersionChanged when another invocation changed the version
+// since expected was loaded
+func Replace(expected, next *Version) error {
+	_, err := Update(func(v *Version) error {
+		if v.FullString() != expected.FullString() {
+			return fmt.Errorf("%s: expected %s, found %s", ErrVersionChanged, expected.FullString(), v.FullString())
+		}
+		fields := v.Fields
+		*v = *next
+		v.Fields = fields
+		return nil
+	})
+	return err
 }
 
 // SetVersion sets the VERSION file to the given version string.
@@ -654,15 +997,21 @@ func SetVersion(versionString string) error {
 
 	v, err := ParseStrict(versionString)
 	if err != nil {
-		return fmt.Errorf("invalid version %q: %w", versionString, err)
+		return err
 	}
-
-	oldVersion := ""
-	if existing, loadErr := Load(); loadErr == nil {
-		oldVersion = existing.FullString()
+	if err := CheckSegments(v); err != nil {
+		return err
 	}
 
-	if err := Save(v); err != nil {
+	oldVersion := ""
+	err = withLock(func() error {
+		if existing, loadErr := Load(); loadErr == nil {
+			oldVersion = existing.FullString()
+			v.Fields = existing.Fields
+		}
+		return Save(v)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -3,6 +3,7 @@ package version
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/benjaminabbitt/versionator/internal/vcs"
@@ -43,6 +44,28 @@ func TestGetCurrentVersion_ExistingValidVersion(t *testing.T) {
 	}
 }
 
+// Validates that ParseFile rejects content Load would read leniently.
+// Diagnostics must report a malformed VERSION rather than treat it as 0.0.0.
+func TestParseFile_RejectsInvalidContent(t *testing.T) {
+	// Precondition: one valid and one invalid VERSION file
+	dir := t.TempDir()
+	valid, invalid := filepath.Join(dir, "valid"), filepath.Join(dir, "invalid")
+	_ = os.WriteFile(valid, []byte("v1.2.3-rc.1\n"), 0644)
+	_ = os.WriteFile(invalid, []byte("not a version\n"), 0644)
+
+	// Action
+	v, err := ParseFile(valid)
+	_, invalidErr := ParseFile(invalid)
+
+	// Expected: The valid file parses; the invalid one fails naming its path
+	if err != nil || v.Prefix != "v" || v.String() != "1.2.3-rc.1" {
+		t.Errorf("ParseFile(valid) = %v, %v", v, err)
+	}
+	if invalidErr == nil || !strings.Contains(invalidErr.Error(), invalid) {
+		t.Errorf("ParseFile(invalid) error = %v", invalidErr)
+	}
+}
+
 // Validates that Save writes a valid version to the VERSION file.
 // Save is the primary write operation - ensures versions persist correctly.
 func TestSave_Success(t *testing.T) {
@@ -1363,3 +1386,73 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+// Validates that IncrementBy steps a level by N with the usual resets.
+// Teams aligning with external numbering skip several versions at once.
+func TestIncrementBy_MinorByThree_ResetsPatchAndPreRelease(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	// Precondition: VERSION at 1.2.3-rc.1
+	if err := os.WriteFile(versionFile, []byte("1.2.3-rc.1"), 0644); err != nil {
+		t.Fatalf("Failed to create VERSION file: %v", err)
+	}
+
+	// Action: Increment minor by 3, then by an invalid step
+	err := IncrementBy(MinorLevel, 3)
+	zeroErr := IncrementBy(MinorLevel, 0)
+
+	// Expected: 1.5.0; a step below 1 is rejected
+	if err != nil {
+		t.Fatalf("Expected no error incrementing by 3, got: %v", err)
+	}
+	if version, _ := GetCurrentVersion(); version != "1.5.0" {
+		t.Errorf("Expected version '1.5.0', got '%s'", version)
+	}
+	if zeroErr == nil || !contains(zeroErr.Error(), ErrInvalidIncrement) {
+		t.Errorf("Expected %q error, got: %v", ErrInvalidIncrement, zeroErr)
+	}
+}
+
+// Validates SetLevel for external numbering (marketing or sprint versions).
+// Changing a component resets lower ones; the current value is a no-op.
+func TestSetLevel_ChangedAndUnchangedValues(t *testing.T) {
+	tests := []struct {
+		level VersionLevel
+		value int
+		want  string
+	}{
+		{MinorLevel, 7, "1.7.0"},
+		{MajorLevel, 2024, "2024.0.0"},
+		{PatchLevel, 0, "1.2.0"},
+		{MinorLevel, 2, "1.2.3-beta.1"},
+		{MinorLevel, 1, "1.1.0"},
+	}
+
+	for _, tt := range tests {
+		// Precondition: 1.2.3-beta.1
+		v := Parse("1.2.3-beta.1")
+
+		// Action: Set the component
+		err := v.SetLevel(tt.level, tt.value)
+
+		// Expected: Component set with resets
+		if err != nil {
+			t.Fatalf("SetLevel(%s, %d) failed: %v", levelString(tt.level), tt.value, err)
+		}
+		if got := v.String(); got != tt.want {
+			t.Errorf("SetLevel(%s, %d) = %s, want %s", levelString(tt.level), tt.value, got, tt.want)
+		}
+	}
+
+	// Action & Expected: Negative values and unknown names are rejected
+	v := Parse("1.2.3")
+	if err := v.SetLevel(MinorLevel, -1); err == nil {
+		t.Error("Expected error for negative component")
+	}
+	if _, err := ParseLevel("build"); err == nil {
+		t.Error("Expected error for unknown level name")
+	}
+	if level, err := ParseLevel("Minor"); err != nil || level != MinorLevel {
+		t.Errorf("Expected ParseLevel(\"Minor\") = MinorLevel, got %v, %v", level, err)
+	}
+}
@@ -0,0 +1,10 @@
+package webhook
+
+// Error messages
+const (
+	ErrNoURL           = "webhook URL is empty"
+	ErrInvalidPayload  = "webhook payload is not valid JSON"
+	ErrTemplateRender  = "failed to render webhook payload"
+	ErrDeliveryFailed  = "webhook delivery failed"
+	ErrConfigReadError = "failed to read webhook configuration"
+)
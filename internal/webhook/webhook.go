@@ -0,0 +1,153 @@
+// Package webhook provides a built-in hook that POSTs a templated JSON payload
+// to configured webhook URLs (Slack, Teams, or any generic endpoint) after
+// successful bump or tag operations.
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cbroglie/mustache"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/offline"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+)
+
+// DefaultPayload is used when a webhook has no payload template. The "text"
+// field is understood by both Slack and Teams incoming webhooks.
+const DefaultPayload = `{"text": "{{Event}}: {{Prefix}}{{MajorMinorPatch}}{{PreReleaseWithDash}}"}`
+
+// ConfigLoader returns the webhooks to notify
+type ConfigLoader func() ([]config.WebhookConfig, error)
+
+// Hook notifies configured webhooks of lifecycle events
+type Hook struct {
+	client     *http.Client
+	loadConfig ConfigLoader
+}
+
+// NewHook creates a Hook with an injected HTTP client and config loader
+func NewHook(client *http.Client, loader ConfigLoader) *Hook {
+	return &Hook{client: client, loadConfig: loader}
+}
+
+// NewHookDefault creates a Hook that reads webhooks from .versionator.yaml
+func NewHookDefault() *Hook {
+	return NewHook(&http.Client{Timeout: 10 * time.Second}, func() ([]config.WebhookConfig, error) {
+		cfg, err := config.ReadConfig()
+		if err != nil {
+			return nil, err
+		}
+		return cfg.Hooks.Webhooks, nil
+	})
+}
+
+// Name returns "webhook"
+func (h *Hook) Name() string {
+	return "webhook"
+}
+
+// Types returns the set of plugin types this hook implements
+func (h *Hook) Types() plugin.PluginTypeSet {
+	return plugin.NewPluginTypeSet(plugin.TypeHook)
+}
+
+// OnEvent sends the event to every configured webhook subscribed to it
+func (h *Hook) OnEvent(event plugin.Event, vars map[string]string) error {
+	hooks, err := h.loadConfig()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrConfigReadError, err)
+	}
+
+	for i, wh := range hooks {
+		if len(wh.Events) > 0 && !slices.Contains(wh.Events, string(event)) {
+			continue
+		}
+		if err := h.Send(wh, event, vars); err != nil {
+			return fmt.Errorf("hooks.webhooks[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Send renders the payload for a single webhook and POSTs it
+func (h *Hook) Send(wh config.WebhookConfig, event plugin.Event, vars map[string]string) error {
+	if err := offline.Require("webhook"); err != nil {
+		return err
+	}
+	url := wh.URL
+	if url == "" && wh.URLEnv != "" {
+		url = os.Getenv(wh.URLEnv)
+	}
+	if url == "" {
+		return fmt.Errorf(ErrNoURL)
+	}
+
+	payload, err := RenderPayload(wh.Payload, event, vars)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrDeliveryFailed, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range wh.Headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrDeliveryFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s: %s: %s", ErrDeliveryFailed, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// RenderPayload renders a payload template with JSON-escaped variables and
+// verifies that the result is valid JSON
+func RenderPayload(tmpl string, event plugin.Event, vars map[string]string) ([]byte, error) {
+	if tmpl == "" {
+		tmpl = DefaultPayload
+	}
+
+	escaped := make(map[string]string, len(vars)+1)
+	for k, v := range vars {
+		escaped[k] = jsonEscape(v)
+	}
+	escaped["Event"] = string(event)
+
+	rendered, err := mustache.RenderRaw(tmpl, true, escaped)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrTemplateRender, err)
+	}
+	if !json.Valid([]byte(rendered)) {
+		return nil, fmt.Errorf("%s: %s", ErrInvalidPayload, rendered)
+	}
+	return []byte(rendered), nil
+}
+
+// jsonEscape escapes s for embedding inside a JSON string literal
+func jsonEscape(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b[1 : len(b)-1])
+}
+
+// Auto-registration as a hook plugin
+func init() {
+	plugin.Register(NewHookDefault())
+}
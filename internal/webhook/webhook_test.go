@@ -0,0 +1,209 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+)
+
+// receivedRequest captures what a fake webhook endpoint received
+type receivedRequest struct {
+	Header http.Header
+	Body   string
+}
+
+// fakeEndpoint records requests and responds with a fixed status
+type fakeEndpoint struct {
+	mu       sync.Mutex
+	requests []receivedRequest
+	server   *httptest.Server
+}
+
+func newFakeEndpoint(t *testing.T, status int) *fakeEndpoint {
+	t.Helper()
+	f := &fakeEndpoint{}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		f.mu.Lock()
+		f.requests = append(f.requests, receivedRequest{Header: r.Header.Clone(), Body: string(body)})
+		f.mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, "invalid_token")
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// staticLoader returns a ConfigLoader serving the given webhooks
+func staticLoader(hooks ...config.WebhookConfig) ConfigLoader {
+	return func() ([]config.WebhookConfig, error) {
+		return hooks, nil
+	}
+}
+
+// TestOnEvent_FiltersBySubscribedEvents validates event subscription.
+//
+// Why: A channel that only cares about releases should not be notified of
+// every bump.
+//
+// What: A webhook subscribed to "tag" receives tag events but not bump events;
+// a webhook with no events list receives both.
+func TestOnEvent_FiltersBySubscribedEvents(t *testing.T) {
+	// Precondition: One tag-only webhook and one catch-all webhook
+	tagOnly := newFakeEndpoint(t, http.StatusOK)
+	all := newFakeEndpoint(t, http.StatusOK)
+	hook := NewHook(http.DefaultClient, staticLoader(
+		config.WebhookConfig{URL: tagOnly.server.URL, Events: []string{"tag"}},
+		config.WebhookConfig{URL: all.server.URL},
+	))
+	vars := map[string]string{"MajorMinorPatch": "1.2.3", "Prefix": "v"}
+
+	// Action: Fire a bump event, then a tag event
+	if err := hook.OnEvent(plugin.EventBump, vars); err != nil {
+		t.Fatalf("OnEvent(bump) failed: %v", err)
+	}
+	if err := hook.OnEvent(plugin.EventTag, vars); err != nil {
+		t.Fatalf("OnEvent(tag) failed: %v", err)
+	}
+
+	// Expected: tag-only saw one request, catch-all saw two
+	if len(tagOnly.requests) != 1 {
+		t.Errorf("tag-only webhook: expected 1 request, got %d", len(tagOnly.requests))
+	}
+	if len(all.requests) != 2 {
+		t.Errorf("catch-all webhook: expected 2 requests, got %d", len(all.requests))
+	}
+}
+
+// TestSend_DefaultPayloadAndHeaders validates the default request shape.
+//
+// Why: Slack and Teams accept {"text": ...} out of the box, and many generic
+// endpoints need an auth header sourced from the environment.
+//
+// What: With no payload configured, the body is the default text message;
+// header values have $VAR references expanded.
+func TestSend_DefaultPayloadAndHeaders(t *testing.T) {
+	// Precondition: Endpoint and a header referencing an env var
+	endpoint := newFakeEndpoint(t, http.StatusOK)
+	t.Setenv("WEBHOOK_TEST_TOKEN", "s3cret")
+	hook := NewHook(http.DefaultClient, staticLoader())
+	wh := config.WebhookConfig{
+		URL:     endpoint.server.URL,
+		Headers: map[string]string{"Authorization": "Bearer $WEBHOOK_TEST_TOKEN"},
+	}
+
+	// Action: Send a tag event
+	err := hook.Send(wh, plugin.EventTag, map[string]string{
+		"Prefix": "v", "MajorMinorPatch": "2.0.0", "PreReleaseWithDash": "-rc.1",
+	})
+
+	// Expected: Default text payload, JSON content type, expanded header
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	req := endpoint.requests[0]
+	if req.Body != `{"text": "tag: v2.0.0-rc.1"}` {
+		t.Errorf("unexpected body %q", req.Body)
+	}
+	if req.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected content type %q", req.Header.Get("Content-Type"))
+	}
+	if req.Header.Get("Authorization") != "Bearer s3cret" {
+		t.Errorf("unexpected auth header %q", req.Header.Get("Authorization"))
+	}
+}
+
+// TestSend_URLFromEnvironment validates urlEnv resolution.
+//
+// Why: Webhook URLs embed secrets and should not be committed to the config.
+//
+// What: urlEnv is read when url is empty; an empty result is an error.
+func TestSend_URLFromEnvironment(t *testing.T) {
+	// Precondition: URL stored in an environment variable
+	endpoint := newFakeEndpoint(t, http.StatusOK)
+	t.Setenv("WEBHOOK_TEST_URL", endpoint.server.URL)
+	hook := NewHook(http.DefaultClient, staticLoader())
+
+	// Action: Send using urlEnv
+	err := hook.Send(config.WebhookConfig{URLEnv: "WEBHOOK_TEST_URL"}, plugin.EventBump, nil)
+
+	// Expected: Delivered
+	if err != nil || len(endpoint.requests) != 1 {
+		t.Fatalf("expected delivery, err=%v requests=%d", err, len(endpoint.requests))
+	}
+
+	// Action: Send using an unset urlEnv
+	err = hook.Send(config.WebhookConfig{URLEnv: "WEBHOOK_TEST_UNSET"}, plugin.EventBump, nil)
+
+	// Expected: Missing URL error
+	if err == nil || !strings.Contains(err.Error(), ErrNoURL) {
+		t.Errorf("expected %q, got %v", ErrNoURL, err)
+	}
+}
+
+// TestSend_Non2xx_ReturnsDeliveryError validates failure reporting.
+//
+// Why: A rejected notification (bad token, removed channel) is only
+// diagnosable from the status and body.
+//
+// What: A non-2xx response returns ErrDeliveryFailed with status and body.
+func TestSend_Non2xx_ReturnsDeliveryError(t *testing.T) {
+	// Precondition: Endpoint that rejects every request
+	endpoint := newFakeEndpoint(t, http.StatusForbidden)
+	hook := NewHook(http.DefaultClient, staticLoader(config.WebhookConfig{URL: endpoint.server.URL}))
+
+	// Action: Fire an event
+	err := hook.OnEvent(plugin.EventTag, nil)
+
+	// Expected: Delivery error naming the webhook index, status, and body
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	for _, want := range []string{"hooks.webhooks[0]", ErrDeliveryFailed, "403", "invalid_token"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err.Error(), want)
+		}
+	}
+}
+
+// TestRenderPayload_EscapesValuesAndRejectsInvalidJSON validates payload rendering.
+//
+// Why: Branch names and authors may contain quotes or backslashes, which would
+// otherwise produce malformed JSON.
+//
+// What: Values are JSON-escaped inside string literals; a template that does
+// not render to valid JSON is rejected.
+func TestRenderPayload_EscapesValuesAndRejectsInvalidJSON(t *testing.T) {
+	// Precondition: A value containing a quote and a backslash
+	vars := map[string]string{"BranchName": `feature/"quoted"\path`}
+
+	// Action: Render a custom payload
+	payload, err := RenderPayload(`{"event": "{{Event}}", "branch": "{{BranchName}}"}`, plugin.EventBump, vars)
+
+	// Expected: Valid JSON round-trips the original value
+	if err != nil {
+		t.Fatalf("RenderPayload failed: %v", err)
+	}
+	var decoded map[string]string
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		t.Fatalf("invalid JSON %q: %v", payload, err)
+	}
+	if decoded["branch"] != vars["BranchName"] || decoded["event"] != "bump" {
+		t.Errorf("unexpected decoded payload: %v", decoded)
+	}
+
+	// Action: Render a template that is not JSON
+	_, err = RenderPayload(`text: {{Event}}`, plugin.EventBump, vars)
+
+	// Expected: Invalid payload error
+	if err == nil || !strings.Contains(err.Error(), ErrInvalidPayload) {
+		t.Errorf("expected %q, got %v", ErrInvalidPayload, err)
+	}
+}
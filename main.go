@@ -9,6 +9,25 @@ import (
 	// Import VCS implementations for auto-registration
 	// Git VCS also registers as a TemplateProvider plugin
 	_ "github.com/benjaminabbitt/versionator/internal/vcs/git"
+	_ "github.com/benjaminabbitt/versionator/internal/vcs/svn"
+
+	// Import built-in hooks for auto-registration
+	// Scripts run before webhooks (packages initialize in import path order),
+	// so variables scripts capture reach webhook payloads
+	_ "github.com/benjaminabbitt/versionator/internal/scripthook"
+	_ "github.com/benjaminabbitt/versionator/internal/webhook"
+
+	// Import built-in template providers for auto-registration
+	_ "github.com/benjaminabbitt/versionator/internal/issues"
+	_ "github.com/benjaminabbitt/versionator/internal/nuget"
+	_ "github.com/benjaminabbitt/versionator/internal/pep440"
+	_ "github.com/benjaminabbitt/versionator/internal/train"
+
+	// Import built-in metadata providers for auto-registration
+	_ "github.com/benjaminabbitt/versionator/internal/buildkite"
+
+	// Import built-in versioning schemes for auto-registration
+	_ "github.com/benjaminabbitt/versionator/internal/fourpart"
 )
 
 func main() {
@@ -0,0 +1,55 @@
+package testsupport
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/benjaminabbitt/versionator/internal/platform"
+)
+
+// FindBinary locates the versionator binary to test, in order:
+// $VERSIONATOR_PROJECT_ROOT/versionator, a versionator binary in the current
+// directory or up to four parents, $GOPATH/bin, then PATH. It falls back to
+// "go run" of the module. On Windows the binary is versionator.exe.
+func FindBinary() string {
+	name := platform.Executable("versionator")
+
+	if root := os.Getenv("VERSIONATOR_PROJECT_ROOT"); root != "" {
+		projectBinary := filepath.Join(root, name)
+		if _, err := os.Stat(projectBinary); err == nil {
+			return projectBinary
+		}
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		dir := wd
+		for i := 0; i < 5; i++ {
+			binary := filepath.Join(dir, name)
+			if info, err := os.Stat(binary); err == nil && !info.IsDir() {
+				return binary
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		home, _ := os.UserHomeDir()
+		gopath = filepath.Join(home, "go")
+	}
+	goBinary := filepath.Join(gopath, "bin", name)
+	if _, err := os.Stat(goBinary); err == nil {
+		return goBinary
+	}
+
+	if path, err := exec.LookPath("versionator"); err == nil {
+		return path
+	}
+
+	return "go run github.com/benjaminabbitt/versionator"
+}
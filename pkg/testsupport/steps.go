@@ -0,0 +1,428 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cucumber/godog"
+)
+
+// Scenario holds the workspace of one running scenario. Step definitions
+// are methods on it, so scenarios share no state.
+type Scenario struct {
+	binary string
+	ws     *Workspace
+}
+
+// RegisterSteps registers the versionator step definitions on sc. Each
+// scenario runs in a fresh Workspace using binary (see FindBinary), removed
+// when the scenario ends. Use the returned Scenario's Workspace in custom
+// steps registered alongside these.
+func RegisterSteps(sc *godog.ScenarioContext, binary string) *Scenario {
+	s := &Scenario{binary: binary}
+
+	sc.Before(func(c context.Context, _ *godog.Scenario) (context.Context, error) {
+		ws, err := NewWorkspace(s.binary)
+		if err != nil {
+			return c, err
+		}
+		s.ws = ws
+		return c, nil
+	})
+	sc.After(func(c context.Context, _ *godog.Scenario, _ error) (context.Context, error) {
+		if s.ws != nil {
+			_ = s.ws.Close()
+			s.ws = nil
+		}
+		return c, nil
+	})
+
+	// Background steps
+	sc.Step(`^a clean git repository$`, s.aCleanGitRepository)
+	sc.Step(`^versionator is installed$`, s.versionatorIsInstalled)
+	sc.Step(`^a VERSION file with version "([^"]*)"$`, s.aVersionFileWithVersion)
+	sc.Step(`^a VERSION file with prefix "([^"]*)" and version "([^"]*)"$`, s.aVersionFileWithPrefixAndVersion)
+	sc.Step(`^a VERSION file with prefix "([^"]*)", version "([^"]*)" and prerelease "([^"]*)"$`, s.aVersionFileWithPrefixVersionAndPrerelease)
+	sc.Step(`^a VERSION file with prefix "([^"]*)", version "([^"]*)" and metadata "([^"]*)"$`, s.aVersionFileWithPrefixVersionAndMetadata)
+	sc.Step(`^a VERSION file with version "([^"]*)" and custom variable "([^"]*)" set to "([^"]*)"$`, s.aVersionFileWithCustomVariable)
+	sc.Step(`^a committed file "([^"]*)" with content "([^"]*)"$`, s.aCommittedFileWithContent)
+	sc.Step(`^a file "([^"]*)" with content "([^"]*)"$`, s.aFileWithContent)
+	sc.Step(`^a file "([^"]*)" with content:$`, s.aFileWithDocString)
+	sc.Step(`^a template file "([^"]*)" with content "([^"]*)"$`, s.aFileWithContent)
+	sc.Step(`^a config file with prerelease enabled and template "([^"]*)"$`, s.aConfigFileWithPrereleaseTemplate)
+	sc.Step(`^a config file with:$`, s.aConfigFileWithDocString)
+	sc.Step(`^a subdirectory "([^"]*)"$`, s.aSubdirectory)
+	sc.Step(`^a VERSION file with version "([^"]*)" in subdirectory "([^"]*)"$`, s.aVersionFileInSubdirectory)
+
+	// Action steps
+	sc.Step(`^I run "([^"]*)"$`, s.iRun)
+	sc.Step(`^I run "([^"]*)" in subdirectory "([^"]*)"$`, s.iRunInSubdirectory)
+	sc.Step(`^I commit a file "([^"]*)" with content "([^"]*)"$`, s.iCommitAFileWithContent)
+	sc.Step(`^I commit the VERSION changes$`, s.iCommitTheVersionChanges)
+	sc.Step(`^I create (\d+) commits with message prefix "([^"]*)"$`, s.iCreateCommitsWithMessagePrefix)
+	sc.Step(`^I create a commit with message "([^"]*)"$`, s.iCreateACommitWithMessage)
+	sc.Step(`^I create a git tag "([^"]*)"$`, s.iCreateAGitTag)
+
+	// Assertion steps
+	sc.Step(`^the output should be "([^"]*)"$`, s.theOutputShouldBe)
+	sc.Step(`^the output should contain "([^"]*)"$`, s.theOutputShouldContain)
+	sc.Step(`^the output should contain '([^']*)'$`, s.theOutputShouldContain)   // Single-quoted variant
+	sc.Step(`^the output should contain ""([^"]*)""$`, s.theOutputShouldContain) // Double-quoted variant (for values with embedded quotes)
+	sc.Step(`^the output should match pattern "([^"]*)"$`, s.theOutputShouldMatchPattern)
+	sc.Step(`^the exit code should be (\d+)$`, s.theExitCodeShouldBe)
+	sc.Step(`^the exit code should not be (\d+)$`, s.theExitCodeShouldNotBe)
+	sc.Step(`^a git tag "([^"]*)" should exist$`, s.aGitTagShouldExist)
+	sc.Step(`^the tag "([^"]*)" should point to HEAD$`, s.theTagShouldPointToHEAD)
+	sc.Step(`^the tag "([^"]*)" should have message "([^"]*)"$`, s.theTagShouldHaveMessage)
+	sc.Step(`^the tag "([^"]*)" should be (\d+) commits ahead of "([^"]*)"$`, s.theTagShouldBeCommitsAheadOf)
+	sc.Step(`^the VERSION should have version "([^"]*)"$`, s.theVersionShouldHaveVersion)
+	sc.Step(`^the VERSION should have prefix "([^"]*)"$`, s.theVersionShouldHavePrefix)
+	sc.Step(`^the VERSION should have prerelease "([^"]*)"$`, s.theVersionShouldHavePrerelease)
+	sc.Step(`^the VERSION should have metadata "([^"]*)"$`, s.theVersionShouldHaveMetadata)
+	sc.Step(`^the file "([^"]*)" should exist$`, s.theFileShouldExist)
+	sc.Step(`^the file "([^"]*)" should contain "([^"]*)"$`, s.theFileShouldContain)
+	sc.Step(`^the file "([^"]*)" should contain '([^']*)'$`, s.theFileShouldContain) // Single-quoted variant
+
+	return s
+}
+
+// Workspace returns the running scenario's workspace
+func (s *Scenario) Workspace() *Workspace {
+	return s.ws
+}
+
+// Background steps
+
+func (s *Scenario) aCleanGitRepository() error {
+	return s.ws.InitGit()
+}
+
+func (s *Scenario) versionatorIsInstalled() error {
+	if s.ws.Binary == "" {
+		return fmt.Errorf("versionator binary not found")
+	}
+	return nil
+}
+
+// writeAndCommitVersion writes VERSION and commits it, keeping the working
+// tree clean for commands that commit
+func (s *Scenario) writeAndCommitVersion(prefix, version, prerelease, metadata string) error {
+	if err := s.ws.WriteVersion(prefix, version, prerelease, metadata); err != nil {
+		return err
+	}
+	return s.ws.CommitFiles("Add VERSION", "VERSION")
+}
+
+func (s *Scenario) aVersionFileWithVersion(version string) error {
+	return s.writeAndCommitVersion("", version, "", "")
+}
+
+func (s *Scenario) aVersionFileWithPrefixAndVersion(prefix, version string) error {
+	return s.writeAndCommitVersion(prefix, version, "", "")
+}
+
+func (s *Scenario) aVersionFileWithPrefixVersionAndPrerelease(prefix, version, prerelease string) error {
+	return s.writeAndCommitVersion(prefix, version, prerelease, "")
+}
+
+func (s *Scenario) aVersionFileWithPrefixVersionAndMetadata(prefix, version, metadata string) error {
+	return s.writeAndCommitVersion(prefix, version, "", metadata)
+}
+
+func (s *Scenario) aVersionFileWithCustomVariable(version, key, value string) error {
+	// Custom variables live in the config file, not VERSION
+	if err := s.ws.WriteVersion("", version, "", ""); err != nil {
+		return err
+	}
+	if err := s.ws.WriteFile(".versionator.yaml", fmt.Sprintf("custom:\n  %s: \"%s\"\n", key, value)); err != nil {
+		return err
+	}
+	return s.ws.CommitFiles("Add VERSION and config", "VERSION", ".versionator.yaml")
+}
+
+func (s *Scenario) aCommittedFileWithContent(filename, content string) error {
+	if err := s.ws.WriteFile(filename, content); err != nil {
+		return err
+	}
+	return s.ws.CommitFiles(fmt.Sprintf("Add %s", filename), filename)
+}
+
+func (s *Scenario) aFileWithContent(filename, content string) error {
+	return s.ws.WriteFile(filename, content)
+}
+
+func (s *Scenario) aFileWithDocString(filename string, doc *godog.DocString) error {
+	return s.ws.WriteFile(filename, doc.Content)
+}
+
+func (s *Scenario) aConfigFileWithPrereleaseTemplate(template string) error {
+	return s.ws.WriteFile(".versionator.yaml", fmt.Sprintf("prerelease:\n  enabled: true\n  template: \"%s\"\n", template))
+}
+
+func (s *Scenario) aConfigFileWithDocString(doc *godog.DocString) error {
+	return s.ws.WriteFile(".versionator.yaml", doc.Content)
+}
+
+func (s *Scenario) aSubdirectory(subdir string) error {
+	return os.MkdirAll(s.ws.Path(subdir), 0755)
+}
+
+func (s *Scenario) aVersionFileInSubdirectory(version, subdir string) error {
+	versionPath := subdir + "/VERSION"
+	if err := s.ws.WriteFile(versionPath, version+"\n"); err != nil {
+		return err
+	}
+	return s.ws.CommitFiles(fmt.Sprintf("Add VERSION in %s", subdir), versionPath)
+}
+
+// Action steps
+
+func (s *Scenario) iRun(command string) error {
+	return s.ws.Run(command)
+}
+
+func (s *Scenario) iRunInSubdirectory(command, subdir string) error {
+	return s.ws.RunIn(subdir, command)
+}
+
+func (s *Scenario) iCommitAFileWithContent(filename, content string) error {
+	if err := s.ws.WriteFile(filename, content); err != nil {
+		return err
+	}
+	return s.ws.CommitFiles(fmt.Sprintf("Update %s", filename), filename)
+}
+
+func (s *Scenario) iCommitTheVersionChanges() error {
+	err := s.ws.CommitFiles("Update VERSION", "VERSION")
+	// Nothing to commit is fine - the command left VERSION unchanged
+	if err != nil && strings.Contains(err.Error(), "nothing to commit") {
+		return nil
+	}
+	return err
+}
+
+func (s *Scenario) iCreateCommitsWithMessagePrefix(count int, prefix string) error {
+	for i := 1; i <= count; i++ {
+		filename := fmt.Sprintf("file_%d.txt", i)
+		if err := s.ws.WriteFile(filename, fmt.Sprintf("Content %d", i)); err != nil {
+			return err
+		}
+		if err := s.ws.CommitFiles(fmt.Sprintf("%s commit %d", prefix, i), filename); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Scenario) iCreateACommitWithMessage(message string) error {
+	filename := fmt.Sprintf("commit_%d.txt", len(message))
+	if err := s.ws.WriteFile(filename, message); err != nil {
+		return err
+	}
+	return s.ws.CommitFiles(message, filename)
+}
+
+func (s *Scenario) iCreateAGitTag(tag string) error {
+	return s.ws.Git("tag", tag)
+}
+
+// Assertion steps
+
+func (s *Scenario) theOutputShouldBe(expected string) error {
+	if s.ws.Output != expected {
+		return fmt.Errorf("expected output %q, got %q", expected, s.ws.Output)
+	}
+	return nil
+}
+
+func (s *Scenario) theOutputShouldContain(substring string) error {
+	if !strings.Contains(s.ws.Output, substring) {
+		return fmt.Errorf("expected output to contain %q, got %q", substring, s.ws.Output)
+	}
+	return nil
+}
+
+func (s *Scenario) theOutputShouldMatchPattern(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid regex pattern: %w", err)
+	}
+	if !re.MatchString(s.ws.Output) {
+		return fmt.Errorf("expected output to match pattern %q, got %q", pattern, s.ws.Output)
+	}
+	return nil
+}
+
+func (s *Scenario) theExitCodeShouldBe(expected int) error {
+	if s.ws.ExitCode != expected {
+		return fmt.Errorf("expected exit code %d, got %d (output: %s)", expected, s.ws.ExitCode, s.ws.Output)
+	}
+	return nil
+}
+
+func (s *Scenario) theExitCodeShouldNotBe(notExpected int) error {
+	if s.ws.ExitCode == notExpected {
+		return fmt.Errorf("expected exit code not to be %d", notExpected)
+	}
+	return nil
+}
+
+func (s *Scenario) aGitTagShouldExist(tag string) error {
+	out, err := s.ws.GitOutput("tag", "-l", tag)
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+	if out != tag {
+		return fmt.Errorf("tag %q does not exist", tag)
+	}
+	return nil
+}
+
+func (s *Scenario) theTagShouldPointToHEAD(tag string) error {
+	tagCommit, err := s.ws.GitOutput("rev-parse", tag+"^{}")
+	if err != nil {
+		return fmt.Errorf("failed to get tag commit: %w", err)
+	}
+	headCommit, err := s.ws.GitOutput("rev-parse", "HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD commit: %w", err)
+	}
+	if tagCommit != headCommit {
+		return fmt.Errorf("tag %q does not point to HEAD", tag)
+	}
+	return nil
+}
+
+func (s *Scenario) theTagShouldHaveMessage(tag, message string) error {
+	out, err := s.ws.GitOutput("tag", "-l", "-n", tag)
+	if err != nil {
+		return fmt.Errorf("failed to get tag message: %w", err)
+	}
+	if !strings.Contains(out, message) {
+		return fmt.Errorf("tag %q message does not contain %q, got %q", tag, message, out)
+	}
+	return nil
+}
+
+func (s *Scenario) theTagShouldBeCommitsAheadOf(tag string, count int, baseTag string) error {
+	out, err := s.ws.GitOutput("rev-list", "--count", baseTag+".."+tag)
+	if err != nil {
+		return fmt.Errorf("failed to count commits: %w", err)
+	}
+	actual, err := strconv.Atoi(out)
+	if err != nil {
+		return fmt.Errorf("failed to parse commit count: %w", err)
+	}
+	if actual != count {
+		return fmt.Errorf("expected %d commits between %s and %s, got %d", count, baseTag, tag, actual)
+	}
+	return nil
+}
+
+// readVersion returns the trimmed VERSION content
+func (s *Scenario) readVersion() (string, error) {
+	data, err := s.ws.ReadFile("VERSION")
+	if err != nil {
+		return "", fmt.Errorf("failed to read VERSION: %w", err)
+	}
+	return strings.TrimSpace(data), nil
+}
+
+func (s *Scenario) theVersionShouldHaveVersion(expected string) error {
+	versionStr, err := s.readVersion()
+	if err != nil {
+		return err
+	}
+
+	// Everything from the first digit, without pre-release or metadata
+	versionPart := versionStr
+	if i := strings.IndexAny(versionStr, "0123456789"); i != -1 {
+		versionPart = versionStr[i:]
+	}
+	if idx := strings.Index(versionPart, "-"); idx != -1 {
+		versionPart = versionPart[:idx]
+	}
+	if idx := strings.Index(versionPart, "+"); idx != -1 {
+		versionPart = versionPart[:idx]
+	}
+
+	if versionPart != expected {
+		return fmt.Errorf("expected version %q, got %q (from VERSION: %q)", expected, versionPart, versionStr)
+	}
+	return nil
+}
+
+func (s *Scenario) theVersionShouldHavePrefix(expected string) error {
+	versionStr, err := s.readVersion()
+	if err != nil {
+		return err
+	}
+
+	// The prefix is everything before the first digit
+	prefix := ""
+	if i := strings.IndexAny(versionStr, "0123456789"); i != -1 {
+		prefix = versionStr[:i]
+	}
+
+	if prefix != expected {
+		return fmt.Errorf("expected prefix %q, got %q (from VERSION: %q)", expected, prefix, versionStr)
+	}
+	return nil
+}
+
+func (s *Scenario) theVersionShouldHavePrerelease(expected string) error {
+	versionStr, err := s.readVersion()
+	if err != nil {
+		return err
+	}
+
+	// Pre-release is after the first - and before any +
+	prerelease := ""
+	if idx := strings.Index(versionStr, "-"); idx != -1 {
+		prerelease, _, _ = strings.Cut(versionStr[idx+1:], "+")
+	}
+
+	if prerelease != expected {
+		return fmt.Errorf("expected prerelease %q, got %q (from VERSION: %q)", expected, prerelease, versionStr)
+	}
+	return nil
+}
+
+func (s *Scenario) theVersionShouldHaveMetadata(expected string) error {
+	versionStr, err := s.readVersion()
+	if err != nil {
+		return err
+	}
+
+	metadata := ""
+	if idx := strings.Index(versionStr, "+"); idx != -1 {
+		metadata = versionStr[idx+1:]
+	}
+
+	if metadata != expected {
+		return fmt.Errorf("expected metadata %q, got %q (from VERSION: %q)", expected, metadata, versionStr)
+	}
+	return nil
+}
+
+func (s *Scenario) theFileShouldExist(filename string) error {
+	if _, err := os.Stat(s.ws.Path(filename)); os.IsNotExist(err) {
+		return fmt.Errorf("file %q does not exist", filename)
+	}
+	return nil
+}
+
+func (s *Scenario) theFileShouldContain(filename, substring string) error {
+	data, err := s.ws.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read file %q: %w", filename, err)
+	}
+	if !strings.Contains(data, substring) {
+		return fmt.Errorf("file %q does not contain %q", filename, substring)
+	}
+	return nil
+}
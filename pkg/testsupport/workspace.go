@@ -0,0 +1,210 @@
+// Package testsupport is the acceptance test harness for versionator: temp
+// repository workspaces and the Gherkin step definitions used by
+// tests/acceptance. Plugin authors and integrators can reuse it to test
+// their own emit templates and VCS plugins against a real binary.
+//
+// Nothing here changes the process working directory or shares global
+// state, so workspaces can be used from parallel tests and scenarios.
+package testsupport
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Workspace is an isolated temporary directory in which versionator is run
+type Workspace struct {
+	// Dir is the absolute path of the workspace
+	Dir string
+	// Binary is the versionator command, a path or "go run <module>"
+	Binary string
+	// Env is added to the inherited environment of commands (KEY=VALUE)
+	Env []string
+
+	// Output is the trimmed stdout of the last Run, or stderr if stdout was empty
+	Output string
+	// ExitCode is the exit code of the last Run
+	ExitCode int
+}
+
+// NewWorkspace creates an empty workspace that runs binary (see FindBinary)
+func NewWorkspace(binary string) (*Workspace, error) {
+	dir, err := os.MkdirTemp("", "versionator-test-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	return &Workspace{Dir: dir, Binary: binary}, nil
+}
+
+// Close removes the workspace
+func (w *Workspace) Close() error {
+	return os.RemoveAll(w.Dir)
+}
+
+// Path resolves name relative to the workspace
+func (w *Workspace) Path(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(w.Dir, name)
+}
+
+// WriteFile writes content to name, creating parent directories
+func (w *Workspace) WriteFile(name, content string) error {
+	path := w.Path(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+// ReadFile returns the content of name
+func (w *Workspace) ReadFile(name string) (string, error) {
+	data, err := os.ReadFile(w.Path(name))
+	return string(data), err
+}
+
+// WriteVersion writes VERSION as [prefix]version[-prerelease][+metadata]
+func (w *Workspace) WriteVersion(prefix, version, prerelease, metadata string) error {
+	versionStr := prefix + version
+	if prerelease != "" {
+		versionStr += "-" + prerelease
+	}
+	if metadata != "" {
+		versionStr += "+" + metadata
+	}
+	return w.WriteFile("VERSION", versionStr+"\n")
+}
+
+// InitGit initializes a git repository with a test identity
+func (w *Workspace) InitGit() error {
+	if err := w.Git("init"); err != nil {
+		return err
+	}
+	if err := w.Git("config", "user.email", "test@example.com"); err != nil {
+		return err
+	}
+	return w.Git("config", "user.name", "Test User")
+}
+
+// Git runs git in the workspace, failing with its output on error
+func (w *Workspace) Git(args ...string) error {
+	_, err := w.GitOutput(args...)
+	return err
+}
+
+// GitOutput runs git in the workspace and returns its trimmed stdout
+func (w *Workspace) GitOutput(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = w.Dir
+	cmd.Env = append(os.Environ(), w.Env...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("command %q failed: %w\nOutput: %s%s", "git", err, stdout.String(), stderr.String())
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// CommitFiles stages files and commits them with message
+func (w *Workspace) CommitFiles(message string, files ...string) error {
+	for _, file := range files {
+		if err := w.Git("add", file); err != nil {
+			return err
+		}
+	}
+	return w.Git("commit", "-m", message)
+}
+
+// Run runs a shell-like command line in the workspace, recording Output and
+// ExitCode. A leading "versionator" is replaced with Binary. A non-zero exit
+// is not an error; only failing to parse or start the command is.
+func (w *Workspace) Run(command string) error {
+	return w.RunIn("", command)
+}
+
+// RunIn is Run in a subdirectory of the workspace
+func (w *Workspace) RunIn(subdir, command string) error {
+	parts, err := ParseCommand(command)
+	if err != nil {
+		return err
+	}
+	if len(parts) == 0 {
+		return fmt.Errorf("empty command")
+	}
+
+	if parts[0] == "versionator" {
+		if strings.HasPrefix(w.Binary, "go run") {
+			parts = append(strings.Fields(w.Binary), parts[1:]...)
+		} else {
+			parts[0] = w.Binary
+		}
+	}
+
+	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd.Dir = w.Path(subdir)
+	cmd.Env = append(os.Environ(), w.Env...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	w.Output = strings.TrimSpace(stdout.String())
+	if w.Output == "" {
+		w.Output = strings.TrimSpace(stderr.String())
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		w.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		w.ExitCode = 1
+	default:
+		w.ExitCode = 0
+	}
+	return nil
+}
+
+// ParseCommand splits a shell-like command line, honoring single and double
+// quotes (no escapes or expansion)
+func ParseCommand(command string) ([]string, error) {
+	var parts []string
+	var current strings.Builder
+	inSingleQuote := false
+	inDoubleQuote := false
+
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+
+		switch {
+		case c == '\'' && !inDoubleQuote:
+			inSingleQuote = !inSingleQuote
+		case c == '"' && !inSingleQuote:
+			inDoubleQuote = !inDoubleQuote
+		case c == ' ' && !inSingleQuote && !inDoubleQuote:
+			if current.Len() > 0 {
+				parts = append(parts, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+
+	if inSingleQuote || inDoubleQuote {
+		return nil, fmt.Errorf("unclosed quote in command: %s", command)
+	}
+
+	return parts, nil
+}
@@ -0,0 +1,65 @@
+package testsupport
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+// TestParseCommand_QuotedArguments_KeepsQuotedSpaces validates parsing.
+//
+// Why: Feature files pass templates such as '{{Major}} {{Minor}}' as one
+// argument; splitting them would run a different command.
+//
+// What: Single- and double-quoted arguments stay whole without their quotes,
+// and an unclosed quote is an error.
+func TestParseCommand_QuotedArguments_KeepsQuotedSpaces(t *testing.T) {
+	// Precondition: Command with both quote styles
+	// Action: Parse
+	parts, err := ParseCommand(`versionator output version -t '{{Major}} {{Minor}}' --set "A=b c"`)
+
+	// Expected: Quoted arguments intact
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []string{"versionator", "output", "version", "-t", "{{Major}} {{Minor}}", "--set", "A=b c"}
+	if !reflect.DeepEqual(parts, want) {
+		t.Errorf("got %q, want %q", parts, want)
+	}
+	if _, err := ParseCommand(`echo "unclosed`); err == nil {
+		t.Error("expected error for unclosed quote")
+	}
+}
+
+// TestWorkspace_Run_RecordsResultWithoutChdir validates process safety.
+//
+// Why: Plugin authors run scenarios in parallel tests; changing the process
+// working directory would make concurrent workspaces interfere.
+//
+// What: Run executes in the workspace, records output and exit code, and
+// leaves the process working directory unchanged.
+func TestWorkspace_Run_RecordsResultWithoutChdir(t *testing.T) {
+	// Precondition: Workspace with a file
+	before, _ := os.Getwd()
+	ws, err := NewWorkspace("versionator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ws.Close() })
+	if err := ws.WriteFile("sub/marker.txt", "here"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action: Run a command that reads the file and fails
+	if err := ws.RunIn("sub", `sh -c "cat marker.txt; exit 3"`); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	// Expected: Output and exit code recorded; cwd untouched
+	if ws.Output != "here" || ws.ExitCode != 3 {
+		t.Errorf("got output %q exit %d, want %q exit 3", ws.Output, ws.ExitCode, "here")
+	}
+	if after, _ := os.Getwd(); after != before {
+		t.Errorf("working directory changed from %s to %s", before, after)
+	}
+}
@@ -0,0 +1,120 @@
+// Package versionator is the Go API for tools that embed versionator, such
+// as IDE extensions and dev servers: read a workspace's effective version,
+// and watch it for changes, without running the CLI.
+//
+// Nothing here reads or changes the process working directory, so several
+// workspaces can be read and watched from one process.
+package versionator
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/benjaminabbitt/versionator/internal/config"
+	_ "github.com/benjaminabbitt/versionator/internal/fourpart"
+	"github.com/benjaminabbitt/versionator/internal/plugin"
+	"github.com/benjaminabbitt/versionator/internal/tagformat"
+	"github.com/benjaminabbitt/versionator/internal/vcs"
+	"github.com/benjaminabbitt/versionator/internal/vcs/git"
+	"github.com/benjaminabbitt/versionator/internal/version"
+)
+
+// State is what a workspace's version is derived from at one moment: the
+// VERSION file and, in a git repository, HEAD and the nearest version tag
+type State struct {
+	// Version is the VERSION file's version, with its prefix (e.g. "v1.2.3-rc.1")
+	Version string `json:"version"`
+	// VersionFile is the path of the VERSION file read
+	VersionFile string `json:"versionFile"`
+	// Commit is the full HEAD commit hash; empty outside a repository or
+	// before the first commit
+	Commit string `json:"commit,omitempty"`
+	// Tag is the nearest version tag reachable from HEAD; empty when none
+	Tag string `json:"tag,omitempty"`
+	// CommitsSinceTag is the number of commits since Tag; -1 when there is
+	// no tag or no repository
+	CommitsSinceTag int `json:"commitsSinceTag"`
+}
+
+// Read returns the State of the workspace at dir. VERSION and
+// .versionator.yaml are looked up from dir towards the repository root, as
+// the CLI does, and the configuration's versionFileFormat, scheme, and
+// release tag format and namespace apply; a missing or invalid VERSION is
+// an error, a missing repository is not.
+func Read(dir string) (State, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return State{}, err
+	}
+
+	stopPath := ""
+	// A new backend per read: the registered one caches tag information
+	// for the life of a CLI command
+	repo, err := git.NewGitVCSAt(abs)
+	if err == nil {
+		if stopPath, err = repo.GetRepositoryRoot(); err != nil {
+			return State{}, err
+		}
+	}
+	opts, tags, err := readOptions(abs, stopPath)
+	if err != nil {
+		return State{}, err
+	}
+
+	state := State{CommitsSinceTag: -1}
+	if repo != nil {
+		repo.SetTagScope(tags)
+		if head, err := repo.GetVCSIdentifier(vcs.MaxIdentifierLength(repo)); err == nil {
+			state.Commit = head
+			if state.CommitsSinceTag, err = repo.GetCommitsSinceTag(); err != nil {
+				return State{}, fmt.Errorf("error reading tags: %w", err)
+			}
+			if state.Tag, err = repo.GetLastTag(); err != nil {
+				return State{}, fmt.Errorf("error reading tags: %w", err)
+			}
+			if state.Tag == "" {
+				state.CommitsSinceTag = -1
+			}
+		}
+	}
+
+	state.VersionFile = version.FindFile(abs, stopPath)
+	if state.VersionFile == "" {
+		return State{}, fmt.Errorf("no VERSION file found from %s", abs)
+	}
+	v, err := version.ParseFileWith(state.VersionFile, opts)
+	if err != nil {
+		return State{}, err
+	}
+	state.Version = v.FullString()
+	return state, nil
+}
+
+// readOptions returns the VERSION file options and tag scope of the
+// workspace's .versionator.yaml; the defaults without one. They are passed
+// explicitly rather than configured, so reading one workspace does not
+// change how another is read.
+func readOptions(dir, stopPath string) (version.FileOptions, tagformat.Scope, error) {
+	cfg := config.Default()
+	if path := config.FindFile(dir, stopPath); path != "" {
+		var err error
+		if cfg, err = config.ReadConfigFile(path); err != nil {
+			return version.FileOptions{}, tagformat.Scope{}, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	opts := version.FileOptions{Format: cfg.VersionFileFormat}
+	if cfg.Scheme != "" {
+		p := plugin.GetVersioningPlugin(cfg.Scheme)
+		if p == nil {
+			return version.FileOptions{}, tagformat.Scope{}, fmt.Errorf("unknown scheme %q", cfg.Scheme)
+		}
+		opts.Segments = p.Segments()
+	}
+
+	tags, err := tagformat.NewScope(cfg.Release.TagFormat, cfg.Release.TagNamespace)
+	if err != nil {
+		return version.FileOptions{}, tagformat.Scope{}, fmt.Errorf("release tagFormat: %w", err)
+	}
+	return opts, tags, nil
+}
@@ -0,0 +1,110 @@
+package versionator
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultInterval is how often a Watcher reads the workspace when no
+// interval is given
+const DefaultInterval = 2 * time.Second
+
+// Change is a change of a workspace's State
+type Change struct {
+	Previous State
+	Current  State
+}
+
+// Watcher notifies subscribers when a workspace's State changes: VERSION
+// is edited, a commit is made or checked out, or a version tag is created.
+// It reads the workspace at a fixed interval, which costs a file read and
+// a walk back to the nearest tag.
+type Watcher struct {
+	dir      string
+	interval time.Duration
+
+	mu          sync.Mutex
+	state       State
+	read        bool
+	nextID      int
+	subscribers map[int]func(Change)
+}
+
+// NewWatcher returns a Watcher for the workspace at dir, read every
+// interval (DefaultInterval when zero or negative). Call Run to start it.
+func NewWatcher(dir string, interval time.Duration) *Watcher {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Watcher{dir: dir, interval: interval, subscribers: map[int]func(Change){}}
+}
+
+// Subscribe registers fn to be called with each change, on the goroutine
+// running the Watcher, and returns a function that unregisters it
+func (w *Watcher) Subscribe(fn func(Change)) (unsubscribe func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	id := w.nextID
+	w.nextID++
+	w.subscribers[id] = fn
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subscribers, id)
+	}
+}
+
+// State returns the State last read, and whether the workspace has been
+// read yet
+func (w *Watcher) State() (State, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.state, w.read
+}
+
+// Check reads the workspace once and notifies subscribers if its State
+// changed since the last read. The first read records the State without a
+// notification. On error the previous State is kept.
+func (w *Watcher) Check() (changed bool, err error) {
+	current, err := Read(w.dir)
+	if err != nil {
+		return false, err
+	}
+
+	w.mu.Lock()
+	previous := w.state
+	changed = w.read && previous != current
+	w.state, w.read = current, true
+	var notify []func(Change)
+	if changed {
+		for _, fn := range w.subscribers {
+			notify = append(notify, fn)
+		}
+	}
+	w.mu.Unlock()
+
+	for _, fn := range notify {
+		fn(Change{Previous: previous, Current: current})
+	}
+	return changed, nil
+}
+
+// Run reads the workspace every interval until ctx is done, notifying
+// subscribers of each change. The first read must succeed; later failed
+// reads (e.g. VERSION caught mid-write) are skipped.
+func (w *Watcher) Run(ctx context.Context) error {
+	if _, err := w.Check(); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			_, _ = w.Check()
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package versionator
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newRepo creates a git repository with a committed VERSION and returns its
+// path and a function running git in it
+func newRepo(t *testing.T) (string, func(args ...string) string) {
+	t.Helper()
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Fixture Author", "GIT_AUTHOR_EMAIL=fixture@example.com",
+			"GIT_COMMITTER_NAME=Fixture Author", "GIT_COMMITTER_EMAIL=fixture@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s failed: %v: %s", strings.Join(args, " "), err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	run("init", "-q")
+	if err := os.WriteFile(filepath.Join(dir, "VERSION"), []byte("v1.2.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "VERSION")
+	run("commit", "-q", "-m", "initial commit")
+	return dir, run
+}
+
+// TestRead_ReportsVersionAndRepository validates reading a workspace.
+//
+// Why: Embedding tools show the version and how far HEAD is from the last
+// release, from a directory that need not be the working directory.
+//
+// What: VERSION is found from a subdirectory; HEAD, the nearest tag, and
+// the distance to it are read; without a tag the distance is -1.
+func TestRead_ReportsVersionAndRepository(t *testing.T) {
+	// Precondition: A repository with a subdirectory
+	dir, run := newRepo(t)
+	sub := filepath.Join(dir, "src")
+	_ = os.Mkdir(sub, 0755)
+
+	// Action
+	untagged, err := Read(sub)
+
+	// Expected
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if untagged.Version != "v1.2.3" || untagged.Commit != run("rev-parse", "HEAD") || untagged.CommitsSinceTag != -1 {
+		t.Errorf("untagged state = %+v", untagged)
+	}
+
+	// Precondition: A tag and a later commit
+	run("tag", "v1.2.3")
+	run("commit", "-q", "--allow-empty", "-m", "feat: next")
+
+	// Action
+	tagged, err := Read(sub)
+
+	// Expected
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if tagged.Tag != "v1.2.3" || tagged.CommitsSinceTag != 1 {
+		t.Errorf("tagged state = %+v", tagged)
+	}
+}
+
+// TestRead_WithoutVersionFile_Fails validates the missing VERSION error.
+//
+// Why: Unlike the CLI, a reader must not create VERSION in a workspace it
+// only watches.
+//
+// What: A directory without VERSION is an error and nothing is created.
+func TestRead_WithoutVersionFile_Fails(t *testing.T) {
+	// Precondition
+	dir := t.TempDir()
+
+	// Action
+	_, err := Read(dir)
+
+	// Expected
+	if err == nil {
+		t.Error("expected an error without VERSION")
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, "VERSION")); statErr == nil {
+		t.Error("VERSION was created")
+	}
+}
+
+// TestRead_AppliesWorkspaceConfig validates reading under the workspace's
+// .versionator.yaml.
+//
+// Why: An embedding process never runs the CLI's configuration step; a
+// workspace with a YAML VERSION file, a tag format, or the four-part scheme
+// would otherwise be read with the defaults, or with another workspace's
+// settings.
+//
+// What: With versionFileFormat yaml, a tag format, and a tag namespace, the
+// YAML VERSION is read and only tags in the namespace that follow the
+// format count; with the four-part scheme a three-segment VERSION is
+// rejected.
+func TestRead_AppliesWorkspaceConfig(t *testing.T) {
+	// Precondition: A configured workspace with a tag outside the namespace
+	dir, run := newRepo(t)
+	config := "versionFileFormat: yaml\nrelease:\n  tagFormat: app-v{{Version}}\n  tagNamespace: team-a\n"
+	if err := os.WriteFile(filepath.Join(dir, ".versionator.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "VERSION"), []byte("version: 1.2.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "-A")
+	run("commit", "-q", "-m", "chore: configure")
+	run("tag", "team-a/app-v1.2.3")
+	run("commit", "-q", "--allow-empty", "-m", "feat: next")
+	run("tag", "v9.9.9")
+
+	// Action
+	state, err := Read(dir)
+
+	// Expected
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if state.Version != "1.2.3" || state.Tag != "team-a/app-v1.2.3" || state.CommitsSinceTag != 1 {
+		t.Errorf("state = %+v", state)
+	}
+
+	// Precondition: The four-part scheme
+	if err := os.WriteFile(filepath.Join(dir, ".versionator.yaml"), []byte("scheme: four-part\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "VERSION"), []byte("1.2.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Action
+	_, err = Read(dir)
+
+	// Expected
+	if err == nil {
+		t.Error("expected a three-segment VERSION to be rejected under the four-part scheme")
+	}
+}
+
+// TestWatcher_Check_NotifiesChanges validates change notifications.
+//
+// Why: Dev servers and IDEs must react to a VERSION edit, a commit, or a
+// new tag without polling the CLI.
+//
+// What: The first read does not notify; each of the three changes notifies
+// every subscriber with the previous and current State; an unsubscribed
+// function is not called; an unchanged workspace does not notify.
+func TestWatcher_Check_NotifiesChanges(t *testing.T) {
+	// Precondition: A watcher with two subscribers, one removed
+	dir, run := newRepo(t)
+	w := NewWatcher(dir, 0)
+	var changes []Change
+	w.Subscribe(func(c Change) { changes = append(changes, c) })
+	unsubscribe := w.Subscribe(func(Change) { t.Error("unsubscribed function called") })
+	unsubscribe()
+	if changed, err := w.Check(); err != nil || changed {
+		t.Fatalf("first Check = %v, %v", changed, err)
+	}
+
+	steps := []struct {
+		name   string
+		change func()
+		check  func(c Change) bool
+	}{
+		{name: "VERSION edit", change: func() { _ = os.WriteFile(filepath.Join(dir, "VERSION"), []byte("v1.3.0\n"), 0644) },
+			check: func(c Change) bool { return c.Previous.Version == "v1.2.3" && c.Current.Version == "v1.3.0" }},
+		{name: "new tag", change: func() { run("tag", "v1.3.0") },
+			check: func(c Change) bool { return c.Current.Tag == "v1.3.0" && c.Current.CommitsSinceTag == 0 }},
+		{name: "new commit", change: func() { run("commit", "-q", "--allow-empty", "-m", "feat: next") },
+			check: func(c Change) bool { return c.Previous.Commit != c.Current.Commit && c.Current.CommitsSinceTag == 1 }},
+		{name: "no change", change: func() {}},
+	}
+	for _, step := range steps {
+		// Action
+		before := len(changes)
+		step.change()
+		changed, err := w.Check()
+
+		// Expected
+		if err != nil {
+			t.Fatalf("%s: Check failed: %v", step.name, err)
+		}
+		if step.check == nil {
+			if changed || len(changes) != before {
+				t.Errorf("%s: notified %v", step.name, changes[before:])
+			}
+			continue
+		}
+		if !changed || len(changes) != before+1 || !step.check(changes[before]) {
+			t.Errorf("%s: changes = %+v", step.name, changes[before:])
+		}
+	}
+}
+
+// TestWatcher_Run_PollsUntilCancelled validates the polling loop.
+//
+// Why: Embedding tools start the watcher once and expect notifications
+// until they shut down.
+//
+// What: A VERSION edit made while running is delivered, and Run returns
+// when its context is cancelled.
+func TestWatcher_Run_PollsUntilCancelled(t *testing.T) {
+	// Precondition: A running watcher
+	dir, _ := newRepo(t)
+	w := NewWatcher(dir, 10*time.Millisecond)
+	received := make(chan Change, 1)
+	w.Subscribe(func(c Change) {
+		select {
+		case received <- c:
+		default:
+		}
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+	for {
+		if _, read := w.State(); read {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Action
+	_ = os.WriteFile(filepath.Join(dir, "VERSION"), []byte("v2.0.0\n"), 0644)
+
+	// Expected
+	select {
+	case c := <-received:
+		if c.Current.Version != "v2.0.0" {
+			t.Errorf("change = %+v", c)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change delivered")
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run returned %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+@cross-platform
+Feature: Cross-Platform Hooks and Patching
+  As a developer whose CI runs on Linux, macOS, and Windows
+  I want hooks, patch, and release to behave the same on every runner
+  So that one .versionator.yaml works in bash and PowerShell pipelines
+
+  Background:
+    Given a clean git repository
+    And a VERSION file with prefix "v" and version "1.0.0"
+
+  Scenario: Hook scripts run in the platform shell
+    Given a config file with:
+      """
+      hooks:
+        scripts:
+          - run: echo bumped> hook.txt
+            events: [bump]
+      """
+    When I run "versionator bump patch increment"
+    Then the exit code should be 0
+    And the VERSION should have version "1.0.1"
+    And the file "hook.txt" should contain "bumped"
+
+  Scenario: Patch writes a manifest in a subdirectory
+    Given a file "web/chart.yaml" with content "version: 0.0.1"
+    And a config file with:
+      """
+      updates:
+        - file: web/chart.yaml
+          path: version
+          template: "{{MajorMinorPatch}}"
+      """
+    When I run "versionator patch"
+    Then the exit code should be 0
+    And the file "web/chart.yaml" should contain "version: 1.0.0"
+
+  Scenario: Release commits updated files named with a leading ./
+    Given a committed file "web/chart.yaml" with content "version: 0.0.1"
+    And a config file with:
+      """
+      prefix: v
+      release:
+        createBranch: false
+      updates:
+        - file: ./web/chart.yaml
+          path: version
+          template: "{{MajorMinorPatch}}"
+      """
+    When I run "versionator release"
+    Then the exit code should be 0
+    And a git tag "v1.0.0" should exist
+    And the file "web/chart.yaml" should contain "version: 1.0.0"
@@ -17,6 +17,12 @@ Feature: Error Handling
     Then the exit code should not be 0
     And the output should contain "unsupported"
 
+  Scenario: Mistyped emit format suggests the closest one
+    Given a VERSION file with version "1.0.0"
+    When I run "versionator output emit jsn"
+    Then the exit code should not be 0
+    And the output should contain "did you mean \"json\"?"
+
   Scenario: Missing required argument for custom get
     Given a VERSION file with version "1.0.0"
     When I run "versionator config custom get"
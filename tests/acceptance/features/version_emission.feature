@@ -29,7 +29,7 @@ Feature: Version Emission
 
   Scenario: Emit with prerelease template
     When I run "versionator output emit python --prerelease='alpha'"
-    Then the output should contain '__version__ = "2.3.4-alpha"'
+    Then the output should contain '__version__ = "2.3.4a0"'
 
   Scenario: Emit with metadata template
     When I run "versionator output emit python --metadata='build.42'"
@@ -41,13 +41,13 @@ Feature: Version Emission
 
   Scenario: Emit dump template
     When I run "versionator output emit dump python"
-    Then the output should contain '{{MajorMinorPatch}}'
+    Then the output should contain '{{Pep440Version}}'
     And the exit code should be 0
 
   Scenario: Emit dump to file
     When I run "versionator output emit dump python --output _version.tmpl.py"
     Then the file "_version.tmpl.py" should exist
-    And the file "_version.tmpl.py" should contain '{{MajorMinorPatch}}'
+    And the file "_version.tmpl.py" should contain '{{Pep440Version}}'
 
   Scenario: Emit with template file
     Given a template file "custom.tmpl" with content "Version: {{MajorMinorPatch}}"